@@ -69,3 +69,50 @@ func (st *State) WatchAuthorisedKeys(tag names.MachineTag) (watcher.NotifyWatche
 	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// SSHUserCertificateAuthority holds the public key of the controller's
+// SSH user certificate authority, and the principals a machine accepts
+// certificates for.
+type SSHUserCertificateAuthority struct {
+	// PublicKey is the certificate authority's public key in
+	// authorized_keys format. It's empty if the machine isn't to
+	// trust the certificate authority.
+	PublicKey string
+
+	// Principals are the principals the machine accepts
+	// certificates for.
+	Principals []string
+
+	// CertificatesOnly is true if the keys of the model's users are
+	// to be removed from the machine, so that users can only connect
+	// with certificates. The controller's system key is always kept.
+	CertificatesOnly bool
+}
+
+// SSHUserCertificateAuthority returns the controller's SSH user
+// certificate authority for the machine specified by machineTag.
+func (st *State) SSHUserCertificateAuthority(tag names.MachineTag) (SSHUserCertificateAuthority, error) {
+	if st.facade.BestAPIVersion() < 2 {
+		return SSHUserCertificateAuthority{}, errors.NotSupportedf("ssh user certificates on this version of Juju")
+	}
+	var results params.SSHUserCertificateAuthorityResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	err := st.facade.FacadeCall("SSHUserCertificateAuthority", args, &results)
+	if err != nil {
+		return SSHUserCertificateAuthority{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return SSHUserCertificateAuthority{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return SSHUserCertificateAuthority{}, result.Error
+	}
+	return SSHUserCertificateAuthority{
+		PublicKey:        result.PublicKey,
+		Principals:       result.Principals,
+		CertificatesOnly: result.CertificatesOnly,
+	}, nil
+}
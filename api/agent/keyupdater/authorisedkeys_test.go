@@ -4,15 +4,19 @@
 package keyupdater_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/agent/keyupdater"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/core/watcher/watchertest"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type keyupdaterSuite struct {
@@ -79,3 +83,45 @@ func (s *keyupdaterSuite) TestWatchAuthorisedKeys(c *gc.C) {
 	s.setAuthorisedKeys(c, "key1\nkey2\nkey3")
 	wc.AssertOneChange()
 }
+
+type certificateAuthoritySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&certificateAuthoritySuite{})
+
+func (s *certificateAuthoritySuite) TestSSHUserCertificateAuthority(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "KeyUpdater")
+		c.Check(request, gc.Equals, "SSHUserCertificateAuthority")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-0"}},
+		})
+		*(result.(*params.SSHUserCertificateAuthorityResults)) = params.SSHUserCertificateAuthorityResults{
+			Results: []params.SSHUserCertificateAuthorityResult{{
+				PublicKey:        "ssh-ed25519 AAAA",
+				Principals:       []string{"0.model.juju.local"},
+				CertificatesOnly: true,
+			}},
+		}
+		return nil
+	})
+	st := keyupdater.NewState(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2})
+	ca, err := st.SSHUserCertificateAuthority(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ca, jc.DeepEquals, keyupdater.SSHUserCertificateAuthority{
+		PublicKey:        "ssh-ed25519 AAAA",
+		Principals:       []string{"0.model.juju.local"},
+		CertificatesOnly: true,
+	})
+}
+
+func (s *certificateAuthoritySuite) TestSSHUserCertificateAuthorityNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call")
+		return nil
+	})
+	st := keyupdater.NewState(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 1})
+	_, err := st.SSHUserCertificateAuthority(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
@@ -1338,6 +1338,8 @@ func (s *apiclientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		Replay:        true,
 		NoTail:        true,
 		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		EndTime:       time.Date(2016, 11, 30, 12, 0, 0, 0, time.UTC),
+		Message:       "hook .* failed",
 	}
 
 	urlValues := url.Values{
@@ -1353,6 +1355,8 @@ func (s *apiclientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		"replay":        {"true"},
 		"noTail":        {"true"},
 		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+		"endTime":       {"2016-11-30T12:00:00Z"},
+		"message":       {"hook .* failed"},
 	}
 
 	client := apiclient.NewClient(s.APIState, jtesting.NoopLogger{})
@@ -0,0 +1,36 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Client is the api client for the AuditLog facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates an audit log api client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the audit log entries of every controller matching
+// the arguments, oldest first.
+func (c *Client) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	var result params.AuditLogQueryResult
+	if c.BestAPIVersion() < 1 {
+		return result, errors.NotSupportedf("audit log queries on this juju version")
+	}
+	if err := c.facade.FacadeCall("Query", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/client/auditlog"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	args := params.AuditLogQueryArgs{
+		Users:  []string{"bob"},
+		From:   &from,
+		Result: "error",
+		Limit:  10,
+	}
+	expected := params.AuditLogQueryResult{
+		Entries: []params.AuditLogEntry{{
+			ControllerID: "1",
+			Who:          "bob",
+			When:         from.Add(time.Hour),
+			Facade:       "Application",
+			Method:       "Deploy",
+			Responded:    true,
+			Errors:       []params.AuditLogError{{Message: "boom"}},
+		}},
+	}
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(version, gc.Equals, 1)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(arg, jc.DeepEquals, args)
+			c.Assert(result, gc.FitsTypeOf, &params.AuditLogQueryResult{})
+			*(result.(*params.AuditLogQueryResult)) = expected
+			return nil
+		}),
+		BestVersion: 1,
+	}
+	client := auditlog.NewClient(apiCaller)
+	result, err := client.Query(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *auditLogSuite) TestQueryNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}),
+		BestVersion: 0,
+	}
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, gc.ErrorMatches, "audit log queries on this juju version not supported")
+}
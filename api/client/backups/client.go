@@ -10,7 +10,7 @@ import (
 // Client wraps the backups API for the client.
 type Client struct {
 	base.ClientFacade
-	st     base.APICallCloser
+	st     base.APICaller
 	facade base.FacadeCaller
 }
 
@@ -19,3 +19,10 @@ func NewClient(caller base.APICallCloser) *Client {
 	frontend, backend := base.NewClientFacade(caller, "Backups")
 	return &Client{ClientFacade: frontend, st: caller, facade: backend}
 }
+
+// NewClientFromCaller returns a backups API client for use by
+// controller agents. The caller is shared with other workers, so
+// the returned client cannot be used to close it.
+func NewClientFromCaller(caller base.APICaller) *Client {
+	return &Client{st: caller, facade: base.NewFacadeCaller(caller, "Backups")}
+}
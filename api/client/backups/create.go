@@ -11,12 +11,14 @@ import (
 
 // Create sends a request to create a backup of juju's state.  It
 // returns the metadata associated with the resulting backup and a
-// filename for download.
-func (c *Client) Create(notes string, noDownload bool) (*params.BackupsMetadataResult, error) {
+// filename for download. The backup is encrypted to the public keys
+// in encryptTo, as well as to any configured on the controller.
+func (c *Client) Create(notes string, noDownload bool, encryptTo []string) (*params.BackupsMetadataResult, error) {
 	var result params.BackupsMetadataResult
 	args := params.BackupsCreateArgs{
 		Notes:      notes,
 		NoDownload: noDownload,
+		EncryptTo:  encryptTo,
 	}
 
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
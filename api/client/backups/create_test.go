@@ -33,7 +33,7 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	s.facade.EXPECT().FacadeCall("Create", arg, gomock.Any()).SetArg(2, result)
 
 	client := s.newClient()
-	got, err := client.Create("important", true)
+	got, err := client.Create("important", true, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Log(got)
 	resultMeta := backupstesting.UpdateNotes(meta, "important")
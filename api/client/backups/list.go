@@ -0,0 +1,41 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// List returns the backup archives held on the controller machine
+// serving the API connection, oldest first.
+func (c *Client) List() ([]params.BackupsArchive, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("listing backups on this controller")
+	}
+	var result params.BackupsListResult
+	if err := c.facade.FacadeCall("List", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.List, nil
+}
+
+// Remove deletes the specified backup archives from the controller
+// machine serving the API connection. The returned slice holds the
+// error, if any, for each archive in turn.
+func (c *Client) Remove(ids ...string) ([]params.ErrorResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("removing backups on this controller")
+	}
+	args := params.BackupsRemoveArgs{IDs: ids}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Remove", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d results, got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
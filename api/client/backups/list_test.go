@@ -0,0 +1,71 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+type listSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) TestList(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	archives := []params.BackupsArchive{{
+		ID:      "/var/lib/juju/backups/juju-backup-20250301-023000.tar.gz",
+		Size:    1024,
+		Created: time.Date(2025, 3, 1, 2, 30, 0, 0, time.UTC),
+		Machine: "0",
+	}}
+	s.facade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("List", nil, gomock.Any()).SetArg(2, params.BackupsListResult{List: archives})
+
+	got, err := s.newClient().List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, archives)
+}
+
+func (s *listSuite) TestListNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().BestAPIVersion().Return(3)
+
+	_, err := s.newClient().List()
+	c.Assert(err, gc.ErrorMatches, "listing backups on this controller not supported")
+}
+
+func (s *listSuite) TestRemove(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	args := params.BackupsRemoveArgs{IDs: []string{"one", "two"}}
+	results := params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+	}}
+	s.facade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("Remove", args, gomock.Any()).SetArg(2, results)
+
+	got, err := s.newClient().Remove("one", "two")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, results.Results)
+}
+
+func (s *listSuite) TestRemoveNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().BestAPIVersion().Return(3)
+
+	_, err := s.newClient().Remove("one")
+	c.Assert(err, gc.ErrorMatches, "removing backups on this controller not supported")
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhubcache
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Client is the api client for the CharmhubCache facade.
+type Client struct {
+	base.ClientFacade
+	st     base.APICaller
+	facade base.FacadeCaller
+}
+
+// NewClient creates a Charmhub cache api client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "CharmhubCache")
+	return &Client{ClientFacade: frontend, st: caller, facade: backend}
+}
+
+// Entries returns the entries in the controller's Charmhub cache.
+func (c *Client) Entries() ([]params.CharmhubCacheEntry, error) {
+	var result params.CharmhubCacheEntries
+	if err := c.facade.FacadeCall("Entries", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
+
+// Prune removes the entries matching the arguments from the
+// controller's Charmhub cache, and returns them.
+func (c *Client) Prune(args params.CharmhubCachePruneArgs) ([]params.CharmhubCacheEntry, error) {
+	var result params.CharmhubCacheEntries
+	if err := c.facade.FacadeCall("Prune", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
+
+// Seed adds the entries in the cache archive read from r to the
+// controller's Charmhub cache, and returns them.
+func (c *Client) Seed(r io.Reader) ([]params.CharmhubCacheEntry, error) {
+	req, err := http.NewRequest("POST", "/charmhub-cache", r)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create seed request")
+	}
+	req.Header.Set("Content-Type", params.ContentTypeTar)
+
+	httpClient, err := c.st.RootHTTPClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result params.CharmhubCacheEntries
+	if err := httpClient.Do(c.st.Context(), req, &result); err != nil {
+		return nil, errors.Trace(apiservererrors.RestoreError(err))
+	}
+	return result.Entries, nil
+}
+
+// Export returns a reader for a cache archive holding every entry in
+// the controller's Charmhub cache. The caller must close it.
+func (c *Client) Export() (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", "/charmhub-cache", nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create export request")
+	}
+
+	httpClient, err := c.st.RootHTTPClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var resp *http.Response
+	if err := httpClient.Do(c.st.Context(), req, &resp); err != nil {
+		return nil, errors.Trace(apiservererrors.RestoreError(err))
+	}
+	return resp.Body, nil
+}
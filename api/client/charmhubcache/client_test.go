@@ -0,0 +1,120 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhubcache_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/httprequest.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/client/charmhubcache"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type charmhubCacheSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&charmhubCacheSuite{})
+
+func (s *charmhubCacheSuite) TestEntries(c *gc.C) {
+	expected := []params.CharmhubCacheEntry{{
+		Key:      "refresh:mysql",
+		Kind:     "refresh",
+		Name:     "mysql",
+		Revision: 42,
+	}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CharmhubCache")
+		c.Check(request, gc.Equals, "Entries")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.CharmhubCacheEntries{})
+		*(result.(*params.CharmhubCacheEntries)) = params.CharmhubCacheEntries{Entries: expected}
+		return nil
+	})
+	entries, err := charmhubcache.NewClient(apiCaller).Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, expected)
+}
+
+func (s *charmhubCacheSuite) TestPrune(c *gc.C) {
+	args := params.CharmhubCachePruneArgs{Kind: "charm", Name: "mysql"}
+	expected := []params.CharmhubCacheEntry{{Key: "get:/api/v1/charms/download/mysql_42.charm"}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CharmhubCache")
+		c.Check(request, gc.Equals, "Prune")
+		c.Check(arg, jc.DeepEquals, args)
+		*(result.(*params.CharmhubCacheEntries)) = params.CharmhubCacheEntries{Entries: expected}
+		return nil
+	})
+	entries, err := charmhubcache.NewClient(apiCaller).Prune(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, expected)
+}
+
+func (s *charmhubCacheSuite) TestSeed(c *gc.C) {
+	apiCaller := httpAPICaller{doer: func(req *http.Request) (*http.Response, error) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/charmhub-cache")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-tar")
+		body, err := io.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(string(body), gc.Equals, "archive")
+		return jsonResponse(req, http.StatusOK, `{"entries":[{"key":"refresh:mysql","kind":"refresh"}]}`), nil
+	}}
+	entries, err := charmhubcache.NewClient(apiCaller).Seed(strings.NewReader("archive"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []params.CharmhubCacheEntry{{Key: "refresh:mysql", Kind: "refresh"}})
+}
+
+func (s *charmhubCacheSuite) TestExport(c *gc.C) {
+	apiCaller := httpAPICaller{doer: func(req *http.Request) (*http.Response, error) {
+		c.Check(req.Method, gc.Equals, "GET")
+		c.Check(req.URL.Path, gc.Equals, "/charmhub-cache")
+		return &http.Response{
+			Request:    req,
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/x-tar"}},
+			Body:       io.NopCloser(strings.NewReader("archive")),
+		}, nil
+	}}
+	r, err := charmhubcache.NewClient(apiCaller).Export()
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "archive")
+}
+
+func jsonResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		Request:    req,
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// httpAPICaller is an API caller whose root HTTP client sends
+// requests to doer.
+type httpAPICaller struct {
+	testing.APICallerFunc
+	doer doerFunc
+}
+
+func (a httpAPICaller) RootHTTPClient() (*httprequest.Client, error) {
+	return &httprequest.Client{Doer: a.doer}, nil
+}
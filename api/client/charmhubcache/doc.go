@@ -0,0 +1,6 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmhubcache provides the api client for the CharmhubCache
+// facade, and for seeding and exporting the controller's Charmhub cache.
+package charmhubcache
@@ -56,6 +56,15 @@ type StatusArgs struct {
 
 	// IncludeStorage can be set to true to return storage in the response.
 	IncludeStorage bool
+
+	// Where holds predicates on the status of units, such as
+	// "workload=blocked" or "since<10m", used to filter the status
+	// response.
+	Where []string
+
+	// Fields holds the sections of the status to return, such as
+	// "machines" or "units".
+	Fields []string
 }
 
 // Status returns the status of the juju model.
@@ -63,11 +72,19 @@ func (c *Client) Status(args *StatusArgs) (*params.FullStatus, error) {
 	if args == nil {
 		args = &StatusArgs{}
 	}
+	if (len(args.Where) > 0 || len(args.Fields) > 0) && c.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("filtering status by predicates or fields on this controller")
+	}
 	if c.BestAPIVersion() <= 6 {
 		return c.statusV6(args.Patterns, args.IncludeStorage)
 	}
 	var result params.FullStatus
-	p := params.StatusParams{Patterns: args.Patterns, IncludeStorage: args.IncludeStorage}
+	p := params.StatusParams{
+		Patterns:       args.Patterns,
+		IncludeStorage: args.IncludeStorage,
+		Where:          args.Where,
+		Fields:         args.Fields,
+	}
 	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
 		return nil, err
 	}
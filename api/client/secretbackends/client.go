@@ -159,3 +159,75 @@ func (api *Client) RemoveSecretBackend(name string, force bool) error {
 	}
 	return params.TranslateWellKnownError(results.OneError())
 }
+
+// MigrateSecrets holds details for migrating secret content
+// between backends.
+type MigrateSecrets struct {
+	FromBackend string
+	ToBackend   string
+	// ModelUUID, if set, limits the migration to a single model.
+	ModelUUID string
+	// After is the cursor returned by the previous call.
+	After string
+	Limit int
+}
+
+// SecretRevisionMigration holds the outcome of migrating
+// the content of a secret revision.
+type SecretRevisionMigration struct {
+	ModelUUID string
+	ModelName string
+	URI       string
+	Revision  int
+	Migrated  bool
+	Error     error
+}
+
+// MigrateSecretsResult holds the outcome of migrating a batch
+// of secret revisions.
+type MigrateSecretsResult struct {
+	Results   []SecretRevisionMigration
+	Remaining int
+	Next      string
+}
+
+// MigrateSecrets migrates the content of a batch of secret revisions
+// from one backend to another.
+func (api *Client) MigrateSecrets(arg MigrateSecrets) (MigrateSecretsResult, error) {
+	if api.BestAPIVersion() < 2 {
+		return MigrateSecretsResult{}, errors.NotSupportedf("migrating secrets on this juju version")
+	}
+
+	var response params.MigrateSecretsResult
+	args := params.MigrateSecretsArgs{
+		FromBackend: arg.FromBackend,
+		ToBackend:   arg.ToBackend,
+		ModelUUID:   arg.ModelUUID,
+		After:       arg.After,
+		Limit:       arg.Limit,
+	}
+	err := api.facade.FacadeCall("MigrateSecrets", args, &response)
+	if err != nil {
+		return MigrateSecretsResult{}, params.TranslateWellKnownError(err)
+	}
+	result := MigrateSecretsResult{
+		Results:   make([]SecretRevisionMigration, len(response.Results)),
+		Remaining: response.Remaining,
+		Next:      response.Next,
+	}
+	for i, r := range response.Results {
+		var resultErr error
+		if r.Error != nil {
+			resultErr = r.Error
+		}
+		result.Results[i] = SecretRevisionMigration{
+			ModelUUID: r.ModelUUID,
+			ModelName: r.ModelName,
+			URI:       r.URI,
+			Revision:  r.Revision,
+			Migrated:  r.Migrated,
+			Error:     resultErr,
+		}
+	}
+	return result, nil
+}
@@ -6,6 +6,7 @@ package secretbackends_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -177,3 +178,78 @@ func (s *SecretBackendsSuite) TestUpdateSecretsBackend(c *gc.C) {
 	err := client.UpdateSecretBackend(backend, true)
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
+
+func (s *SecretBackendsSuite) TestMigrateSecrets(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "SecretBackends")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "MigrateSecrets")
+			c.Check(arg, jc.DeepEquals, params.MigrateSecretsArgs{
+				FromBackend: "internal",
+				ToBackend:   "myvault",
+				ModelUUID:   coretesting.ModelTag.Id(),
+				After:       "cursor",
+				Limit:       10,
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.MigrateSecretsResult{})
+			*(result.(*params.MigrateSecretsResult)) = params.MigrateSecretsResult{
+				Results: []params.SecretRevisionMigrationResult{{
+					ModelUUID: coretesting.ModelTag.Id(),
+					ModelName: "fred",
+					URI:       "secret:9m4e2mr0ui3e8a215n4g",
+					Revision:  1,
+					Migrated:  true,
+				}, {
+					ModelUUID: coretesting.ModelTag.Id(),
+					ModelName: "fred",
+					URI:       "secret:9m4e2mr0ui3e8a215n4g",
+					Revision:  2,
+					Error:     &params.Error{Message: "FAIL"},
+				}},
+				Remaining: 3,
+				Next:      "next",
+			}
+			return nil
+		}), BestVersion: 2,
+	}
+	client := secretbackends.NewClient(apiCaller)
+	result, err := client.MigrateSecrets(secretbackends.MigrateSecrets{
+		FromBackend: "internal",
+		ToBackend:   "myvault",
+		ModelUUID:   coretesting.ModelTag.Id(),
+		After:       "cursor",
+		Limit:       10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, secretbackends.MigrateSecretsResult{
+		Results: []secretbackends.SecretRevisionMigration{{
+			ModelUUID: coretesting.ModelTag.Id(),
+			ModelName: "fred",
+			URI:       "secret:9m4e2mr0ui3e8a215n4g",
+			Revision:  1,
+			Migrated:  true,
+		}, {
+			ModelUUID: coretesting.ModelTag.Id(),
+			ModelName: "fred",
+			URI:       "secret:9m4e2mr0ui3e8a215n4g",
+			Revision:  2,
+			Error:     &params.Error{Message: "FAIL"},
+		}},
+		Remaining: 3,
+		Next:      "next",
+	})
+}
+
+func (s *SecretBackendsSuite) TestMigrateSecretsNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fail()
+			return nil
+		}), BestVersion: 1,
+	}
+	client := secretbackends.NewClient(apiCaller)
+	_, err := client.MigrateSecrets(secretbackends.MigrateSecrets{FromBackend: "internal", ToBackend: "myvault"})
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
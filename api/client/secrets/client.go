@@ -280,3 +280,45 @@ func (c *Client) RevokeSecret(uri *secrets.URI, name string, apps []string) ([]e
 	}
 	return processErrors(results), nil
 }
+
+// CheckSecretContentDrift reads back the content of the secrets matching
+// the filter which is stored in external backends, returning the number
+// of revisions checked and those which are missing or have been modified.
+func (c *Client) CheckSecretContentDrift(filter secrets.Filter) (int, []secrets.RevisionContentDrift, error) {
+	if c.BestAPIVersion() < 3 {
+		return 0, nil, errors.NotSupportedf("checking secret content drift")
+	}
+	arg := params.CheckSecretContentDriftArgs{
+		Filter: params.SecretsFilter{
+			OwnerTag: filter.OwnerTag,
+			Revision: filter.Revision,
+			Label:    filter.Label,
+		},
+	}
+	if filter.URI != nil {
+		uri := filter.URI.String()
+		arg.Filter.URI = &uri
+	}
+	var response params.SecretContentDriftResults
+	err := c.facade.FacadeCall("CheckSecretContentDrift", arg, &response)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	result := make([]secrets.RevisionContentDrift, len(response.Results))
+	for i, r := range response.Results {
+		uri, err := secrets.ParseURI(r.URI)
+		if err != nil {
+			return 0, nil, errors.Trace(err)
+		}
+		result[i] = secrets.RevisionContentDrift{
+			URI:         uri,
+			Revision:    r.Revision,
+			BackendName: r.BackendName,
+			Drift:       secrets.ContentDrift(r.Drift),
+		}
+		if r.Error != nil {
+			result[i].Error = r.Error
+		}
+	}
+	return response.Checked, result, nil
+}
@@ -6,6 +6,7 @@ package secrets_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -452,3 +453,52 @@ func (s *SecretsSuite) TestRevokeSecretByName(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.DeepEquals, []error{nil})
 }
+
+func (s *SecretsSuite) TestCheckSecretContentDrift(c *gc.C) {
+	uri := secrets.NewURI()
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Secrets")
+		c.Assert(request, gc.Equals, "CheckSecretContentDrift")
+		c.Assert(arg, gc.DeepEquals, params.CheckSecretContentDriftArgs{
+			Filter: params.SecretsFilter{Label: ptr("my-secret")},
+		})
+		*(result.(*params.SecretContentDriftResults)) = params.SecretContentDriftResults{
+			Checked: 3,
+			Results: []params.SecretContentDriftResult{{
+				URI:         uri.String(),
+				Revision:    1,
+				BackendName: "myvault",
+				Drift:       "missing",
+			}, {
+				URI:         uri.String(),
+				Revision:    2,
+				BackendName: "myvault",
+				Error:       &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	caller := testing.BestVersionCaller{apiCaller, 3}
+	client := apisecrets.NewClient(caller)
+	checked, result, err := client.CheckSecretContentDrift(secrets.Filter{Label: ptr("my-secret")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checked, gc.Equals, 3)
+	c.Assert(result, gc.HasLen, 2)
+	c.Assert(result[0], jc.DeepEquals, secrets.RevisionContentDrift{
+		URI:         uri,
+		Revision:    1,
+		BackendName: "myvault",
+		Drift:       secrets.ContentMissing,
+	})
+	c.Assert(result[1].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *SecretsSuite) TestCheckSecretContentDriftNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	caller := testing.BestVersionCaller{apiCaller, 2}
+	client := apisecrets.NewClient(caller)
+	_, _, err := client.CheckSecretContentDrift(secrets.Filter{})
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
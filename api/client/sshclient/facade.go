@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/sshpolicy"
 	"github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/rpc/params"
 )
@@ -203,3 +204,108 @@ func (facade *Facade) ModelCredentialForSSH() (cloudspec.CloudSpec, error) {
 	}
 	return spec, nil
 }
+
+// SSHSessionRecordings returns the recordings of SSH sessions
+// to the model's machines and units matching the filter.
+func (facade *Facade) SSHSessionRecordings(filter params.SSHSessionRecordingFilter) ([]params.SSHSessionRecording, error) {
+	if facade.caller.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("ssh session recordings")
+	}
+	var out params.SSHSessionRecordingsResult
+	if err := facade.caller.FacadeCall("ListSSHSessionRecordings", filter, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
+
+// SSHSessionRecording returns the SSH session recording with the
+// given ID along with its content, in asciicast v2 format.
+func (facade *Facade) SSHSessionRecording(id string) (params.SSHSessionRecording, []byte, error) {
+	if facade.caller.BestAPIVersion() < 6 {
+		return params.SSHSessionRecording{}, nil, errors.NotSupportedf("ssh session recordings")
+	}
+	var out params.SSHSessionRecordingResult
+	if err := facade.caller.FacadeCall("SSHSessionRecording", params.SSHSessionRecordingIDArg{ID: id}, &out); err != nil {
+		return params.SSHSessionRecording{}, nil, errors.Trace(err)
+	}
+	if out.Error != nil {
+		return params.SSHSessionRecording{}, nil, errors.Trace(apiservererrors.RestoreError(out.Error))
+	}
+	return out.Metadata, out.Recording, nil
+}
+
+// SSHPolicies returns the model's SSH policies.
+func (facade *Facade) SSHPolicies() ([]sshpolicy.Policy, error) {
+	if facade.caller.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("ssh policies")
+	}
+	var out params.SSHPoliciesResult
+	if err := facade.caller.FacadeCall("ListSSHPolicies", nil, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	policies := make([]sshpolicy.Policy, len(out.Policies))
+	for i, p := range out.Policies {
+		policies[i] = p.SSHPolicy()
+	}
+	return policies, nil
+}
+
+// SetSSHPolicy adds an SSH policy to the model, replacing
+// any existing policy with the same name.
+func (facade *Facade) SetSSHPolicy(policy sshpolicy.Policy) error {
+	if facade.caller.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("ssh policies")
+	}
+	var out params.ErrorResult
+	if err := facade.caller.FacadeCall("SetSSHPolicy", params.FromSSHPolicy(policy), &out); err != nil {
+		return errors.Trace(err)
+	}
+	if out.Error != nil {
+		return errors.Trace(apiservererrors.RestoreError(out.Error))
+	}
+	return nil
+}
+
+// RemoveSSHPolicy removes the named SSH policy from the model.
+func (facade *Facade) RemoveSSHPolicy(name string) error {
+	if facade.caller.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("ssh policies")
+	}
+	var out params.ErrorResult
+	if err := facade.caller.FacadeCall("RemoveSSHPolicy", params.SSHPolicyNameArg{Name: name}, &out); err != nil {
+		return errors.Trace(err)
+	}
+	if out.Error != nil {
+		return errors.Trace(apiservererrors.RestoreError(out.Error))
+	}
+	return nil
+}
+
+// SSHUserCertificate returns a short-lived SSH user certificate for the
+// public key, in authorized_keys format, valid for connecting to the
+// target machines and units.
+func (facade *Facade) SSHUserCertificate(targets []string, publicKey string) (string, error) {
+	if facade.caller.BestAPIVersion() < 8 {
+		return "", errors.NotSupportedf("ssh user certificates")
+	}
+	tags := make([]string, len(targets))
+	for i, target := range targets {
+		tag, err := targetToTag(target)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		tags[i] = tag.String()
+	}
+	arg := params.SSHUserCertificateArg{
+		Targets:   tags,
+		PublicKey: publicKey,
+	}
+	var out params.SSHUserCertificateResult
+	if err := facade.caller.FacadeCall("SSHUserCertificate", arg, &out); err != nil {
+		return "", errors.Trace(err)
+	}
+	if out.Error != nil {
+		return "", errors.Trace(apiservererrors.RestoreError(out.Error))
+	}
+	return out.Certificate, nil
+}
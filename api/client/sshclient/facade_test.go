@@ -15,6 +15,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	k8scloud "github.com/juju/juju/caas/kubernetes/cloud"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/sshpolicy"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/testing"
@@ -451,3 +452,197 @@ func (s *FacadeSuite) TestVirtualHostnameError(c *gc.C) {
 	_, err := facade.VirtualHostname("foo/0", nil)
 	c.Check(err, gc.ErrorMatches, "boom")
 }
+
+func (s *FacadeSuite) TestSSHSessionRecordings(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	filter := params.SSHSessionRecordingFilter{User: "ubuntu"}
+	res := new(params.SSHSessionRecordingsResult)
+	ress := params.SSHSessionRecordingsResult{
+		Results: []params.SSHSessionRecording{{ID: "id", User: "ubuntu"}},
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(6)
+	mockFacadeCaller.EXPECT().FacadeCall("ListSSHSessionRecordings", filter, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	recordings, err := facade.SSHSessionRecordings(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recordings, jc.DeepEquals, ress.Results)
+}
+
+func (s *FacadeSuite) TestSSHSessionRecordingsNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(5)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	_, err := facade.SSHSessionRecordings(params.SSHSessionRecordingFilter{})
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
+
+func (s *FacadeSuite) TestSSHSessionRecording(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	res := new(params.SSHSessionRecordingResult)
+	ress := params.SSHSessionRecordingResult{
+		Metadata:  params.SSHSessionRecording{ID: "id", User: "ubuntu"},
+		Recording: []byte("recording"),
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(6)
+	mockFacadeCaller.EXPECT().FacadeCall("SSHSessionRecording", params.SSHSessionRecordingIDArg{ID: "id"}, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	metadata, content, err := facade.SSHSessionRecording("id")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadata, jc.DeepEquals, ress.Metadata)
+	c.Assert(string(content), gc.Equals, "recording")
+}
+
+func (s *FacadeSuite) TestSSHSessionRecordingNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	res := new(params.SSHSessionRecordingResult)
+	ress := params.SSHSessionRecordingResult{
+		Error: apiservererrors.ServerError(errors.NotFoundf("ssh session recording %q", "id")),
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(6)
+	mockFacadeCaller.EXPECT().FacadeCall("SSHSessionRecording", params.SSHSessionRecordingIDArg{ID: "id"}, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	_, _, err := facade.SSHSessionRecording("id")
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *FacadeSuite) TestSSHPolicies(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	res := new(params.SSHPoliciesResult)
+	ress := params.SSHPoliciesResult{
+		Policies: []params.SSHPolicy{{
+			Name:  "operators",
+			Users: []string{sshpolicy.Everyone},
+			Shell: true,
+		}},
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(7)
+	mockFacadeCaller.EXPECT().FacadeCall("ListSSHPolicies", nil, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	policies, err := facade.SSHPolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, jc.DeepEquals, []sshpolicy.Policy{{
+		Name:  "operators",
+		Users: []string{sshpolicy.Everyone},
+		Shell: true,
+	}})
+}
+
+func (s *FacadeSuite) TestSSHPoliciesNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(6).Times(3)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	_, err := facade.SSHPolicies()
+	c.Check(err, jc.ErrorIs, errors.NotSupported)
+	err = facade.SetSSHPolicy(sshpolicy.Policy{})
+	c.Check(err, jc.ErrorIs, errors.NotSupported)
+	err = facade.RemoveSSHPolicy("operators")
+	c.Check(err, jc.ErrorIs, errors.NotSupported)
+}
+
+func (s *FacadeSuite) TestSetSSHPolicy(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	policy := sshpolicy.Policy{
+		Name:         "dbas",
+		Users:        []string{"alice"},
+		Applications: []string{"postgresql"},
+		Commands:     []string{"psql*"},
+	}
+	arg := params.SSHPolicy{
+		Name:         "dbas",
+		Users:        []string{"alice"},
+		Applications: []string{"postgresql"},
+		Commands:     []string{"psql*"},
+	}
+	res := new(params.ErrorResult)
+	ress := params.ErrorResult{
+		Error: apiservererrors.ServerError(errors.NotValidf("policy")),
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(7)
+	mockFacadeCaller.EXPECT().FacadeCall("SetSSHPolicy", arg, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	err := facade.SetSSHPolicy(policy)
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+}
+
+func (s *FacadeSuite) TestRemoveSSHPolicy(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	res := new(params.ErrorResult)
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(7)
+	mockFacadeCaller.EXPECT().FacadeCall("RemoveSSHPolicy", params.SSHPolicyNameArg{Name: "operators"}, res).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	err := facade.RemoveSSHPolicy("operators")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FacadeSuite) TestSSHUserCertificate(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	arg := params.SSHUserCertificateArg{
+		Targets:   []string{"machine-0", "unit-foo-1"},
+		PublicKey: "ssh-ed25519 AAAA",
+	}
+	res := new(params.SSHUserCertificateResult)
+	ress := params.SSHUserCertificateResult{
+		Certificate: "ssh-ed25519-cert-v01@openssh.com AAAA",
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(8)
+	mockFacadeCaller.EXPECT().FacadeCall("SSHUserCertificate", arg, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	cert, err := facade.SSHUserCertificate([]string{"0", "foo/1"}, "ssh-ed25519 AAAA")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cert, gc.Equals, "ssh-ed25519-cert-v01@openssh.com AAAA")
+}
+
+func (s *FacadeSuite) TestSSHUserCertificateNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(7)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	_, err := facade.SSHUserCertificate([]string{"0"}, "ssh-ed25519 AAAA")
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
@@ -60,6 +60,13 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, limits the records returned to those with a log
+	// time before EndTime. The server stops once it has sent them, without
+	// waiting for new logs to arrive.
+	EndTime time.Time
+	// Message is a regular expression that the log message must match to be
+	// included in the response. If empty, all messages are included.
+	Message string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -89,11 +96,18 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.Message != "" {
+		attrs.Set("message", args.Message)
+	}
 	return attrs
 }
 
 // LogMessage is a structured logging entry.
 type LogMessage struct {
+	ModelUUID string
 	Entity    string
 	Timestamp time.Time
 	Severity  string
@@ -132,6 +146,7 @@ func StreamDebugLog(ctx context.Context, source base.StreamConnector, args Debug
 				return
 			}
 			messages <- LogMessage{
+				ModelUUID: msg.ModelUUID,
 				Entity:    msg.Entity,
 				Timestamp: msg.Timestamp,
 				Severity:  msg.Severity,
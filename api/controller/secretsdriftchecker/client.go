@@ -0,0 +1,52 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsdriftchecker
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Client is the api client for the SecretsDriftChecker facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a secrets drift checker api client.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, "SecretsDriftChecker"),
+	}
+}
+
+// CheckSecretContentDrift reads back the content of the model's secret
+// revisions stored in external backends, returning the number of
+// revisions checked and those which are missing or have been modified.
+func (c *Client) CheckSecretContentDrift() (int, []secrets.RevisionContentDrift, error) {
+	var response params.SecretContentDriftResults
+	err := c.facade.FacadeCall("CheckSecretContentDrift", nil, &response)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	result := make([]secrets.RevisionContentDrift, len(response.Results))
+	for i, r := range response.Results {
+		uri, err := secrets.ParseURI(r.URI)
+		if err != nil {
+			return 0, nil, errors.Trace(err)
+		}
+		result[i] = secrets.RevisionContentDrift{
+			URI:         uri,
+			Revision:    r.Revision,
+			BackendName: r.BackendName,
+			Drift:       secrets.ContentDrift(r.Drift),
+		}
+		if r.Error != nil {
+			result[i].Error = r.Error
+		}
+	}
+	return response.Checked, result, nil
+}
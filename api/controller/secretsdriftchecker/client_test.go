@@ -0,0 +1,60 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsdriftchecker_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller/secretsdriftchecker"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&driftCheckerSuite{})
+
+type driftCheckerSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *driftCheckerSuite) TestCheckSecretContentDrift(c *gc.C) {
+	uri := coresecrets.NewURI()
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsDriftChecker")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "CheckSecretContentDrift")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.SecretContentDriftResults{})
+		*(result.(*params.SecretContentDriftResults)) = params.SecretContentDriftResults{
+			Checked: 2,
+			Results: []params.SecretContentDriftResult{{
+				URI:         uri.String(),
+				Revision:    1,
+				BackendName: "myvault",
+				Drift:       "modified",
+			}, {
+				URI:         uri.String(),
+				Revision:    2,
+				BackendName: "myvault",
+				Error:       &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	client := secretsdriftchecker.NewClient(apiCaller)
+	checked, result, err := client.CheckSecretContentDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checked, gc.Equals, 2)
+	c.Assert(result, gc.HasLen, 2)
+	c.Assert(result[0], jc.DeepEquals, coresecrets.RevisionContentDrift{
+		URI:         uri,
+		Revision:    1,
+		BackendName: "myvault",
+		Drift:       coresecrets.ContentModified,
+	})
+	c.Assert(result[1].Error, gc.ErrorMatches, "boom")
+}
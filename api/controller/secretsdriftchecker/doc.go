@@ -0,0 +1,6 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secretsdriftchecker provides the api client
+// for the secretsdriftchecker facade.
+package secretsdriftchecker
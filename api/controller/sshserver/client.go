@@ -4,11 +4,19 @@
 package sshserver
 
 import (
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
 	gossh "golang.org/x/crypto/ssh"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/core/sshpolicy"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/rpc/params"
 )
@@ -87,3 +95,81 @@ func (c *Client) ListPublicKeysForModel(sshPKIAuthArgs params.ListAuthorizedKeys
 	}
 	return publicKeys, nil
 }
+
+// SaveSessionRecording streams a recording of an SSH session proxied
+// through the controller, of the given size, to the controller's blob
+// storage.
+func (c *Client) SaveSessionRecording(arg params.SSHSessionRecordingArg, r io.Reader, size int64) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("ssh session recording")
+	}
+	query := url.Values{
+		"user":    {arg.User},
+		"target":  {arg.Target},
+		"started": {arg.Started.Format(time.RFC3339Nano)},
+		"ended":   {arg.Ended.Format(time.RFC3339Nano)},
+	}
+	if arg.KeyFingerprint != "" {
+		query.Set("key-fingerprint", arg.KeyFingerprint)
+	}
+	req, err := http.NewRequest("POST", "/ssh-session-recordings?"+query.Encode(), r)
+	if err != nil {
+		return errors.Annotate(err, "cannot create ssh session recording request")
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", params.ContentTypeAsciicast)
+
+	caller := c.facade.RawAPICaller()
+	httpClient, err := caller.RootHTTPClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := httpClient.Do(caller.Context(), req, nil); err != nil {
+		return errors.Trace(apiservererrors.RestoreError(err))
+	}
+	return nil
+}
+
+// SSHPoliciesForModel returns the SSH policies of the model.
+func (c *Client) SSHPoliciesForModel(modelUUID string) ([]sshpolicy.Policy, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("ssh policies")
+	}
+	var result params.SSHPoliciesResult
+	arg := params.SSHPoliciesArg{ModelUUID: modelUUID}
+	if err := c.facade.FacadeCall("SSHPoliciesForModel", arg, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	policies := make([]sshpolicy.Policy, len(result.Policies))
+	for i, p := range result.Policies {
+		policies[i] = p.SSHPolicy()
+	}
+	return policies, nil
+}
+
+// SSHUserCertificateAuthority returns the public key of the certificate
+// authority signing the SSH user certificates issued by the controller,
+// or nil if the controller isn't configured to use SSH user certificates.
+func (c *Client) SSHUserCertificateAuthority() (gossh.PublicKey, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("ssh user certificates")
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("SSHUserCertificateAuthority", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	if result.Result == "" {
+		return nil, nil
+	}
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(result.Result))
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing ssh user certificate authority")
+	}
+	return key, nil
+}
@@ -4,16 +4,26 @@
 package sshserver_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gossh "golang.org/x/crypto/ssh"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/httprequest.v1"
 
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/controller/sshserver"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/sshpolicy"
 	pkitest "github.com/juju/juju/pki/test"
 	"github.com/juju/juju/rpc/params"
 )
@@ -158,3 +168,179 @@ func (s *sshserverSuite) TestVirtualHostKey(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(key, gc.DeepEquals, []byte("key"))
 }
+
+func (s *sshserverSuite) TestSaveSessionRecording(c *gc.C) {
+	started := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	apiCaller := httpAPICaller{
+		BestVersionCaller: basetesting.BestVersionCaller{BestVersion: 2},
+		doer: func(req *http.Request) (*http.Response, error) {
+			c.Check(req.Method, gc.Equals, "POST")
+			c.Check(req.URL.Path, gc.Equals, "/ssh-session-recordings")
+			c.Check(req.URL.Query(), jc.DeepEquals, url.Values{
+				"user":            {"ubuntu"},
+				"key-fingerprint": {"SHA256:abc"},
+				"target":          {"1.8419cd78-4993-4c3a-928e-c646226beeee.juju.local"},
+				"started":         {"2025-01-01T10:00:00Z"},
+				"ended":           {"2025-01-01T10:01:00Z"},
+			})
+			c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-asciicast")
+			c.Check(req.ContentLength, gc.Equals, int64(9))
+			body, err := io.ReadAll(req.Body)
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(string(body), gc.Equals, "recording")
+			return &http.Response{
+				Request:    req,
+				StatusCode: http.StatusBadRequest,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"message":"boom"}`)),
+			}, nil
+		},
+	}
+	client, err := sshserver.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.SaveSessionRecording(params.SSHSessionRecordingArg{
+		User:           "ubuntu",
+		KeyFingerprint: "SHA256:abc",
+		Target:         "1.8419cd78-4993-4c3a-928e-c646226beeee.juju.local",
+		Started:        started,
+		Ended:          started.Add(time.Minute),
+	}, strings.NewReader("recording"), 9)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *sshserverSuite) TestSaveSessionRecordingNotSupported(c *gc.C) {
+	// A v1 facade means the controller doesn't have the
+	// /ssh-session-recordings endpoint, so it isn't called.
+	apiCaller := httpAPICaller{
+		BestVersionCaller: basetesting.BestVersionCaller{BestVersion: 1},
+		doer: func(req *http.Request) (*http.Response, error) {
+			c.Fatalf("unexpected http request to %q", req.URL.Path)
+			return nil, nil
+		},
+	}
+	client, err := sshserver.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.SaveSessionRecording(params.SSHSessionRecordingArg{}, strings.NewReader(""), 0)
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
+
+func (s *sshserverSuite) TestSSHPoliciesForModel(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "SSHServer")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SSHPoliciesForModel")
+			c.Check(a, jc.DeepEquals, params.SSHPoliciesArg{ModelUUID: "abcd"})
+			c.Assert(result, gc.FitsTypeOf, &params.SSHPoliciesResult{})
+			*(result.(*params.SSHPoliciesResult)) = params.SSHPoliciesResult{
+				Policies: []params.SSHPolicy{{
+					Name:  "operators",
+					Users: []string{"alice"},
+					Shell: true,
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client, err := sshserver.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	policies, err := client.SSHPoliciesForModel("abcd")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, jc.DeepEquals, []sshpolicy.Policy{{
+		Name:  "operators",
+		Users: []string{"alice"},
+		Shell: true,
+	}})
+}
+
+func (s *sshserverSuite) TestSSHPoliciesForModelNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected api call %q", request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client, err := sshserver.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = client.SSHPoliciesForModel("abcd")
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
+
+func (s *sshserverSuite) TestSSHUserCertificateAuthority(c *gc.C) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, jc.ErrorIsNil)
+	signer, err := gossh.NewSignerFromKey(privateKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "SSHServer")
+			c.Check(request, gc.Equals, "SSHUserCertificateAuthority")
+			*(result.(*params.StringResult)) = params.StringResult{
+				Result: string(gossh.MarshalAuthorizedKey(signer.PublicKey())),
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client, err := sshserver.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	key, err := client.SSHUserCertificateAuthority()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key.Marshal(), jc.DeepEquals, signer.PublicKey().Marshal())
+}
+
+func (s *sshserverSuite) TestSSHUserCertificateAuthorityDisabled(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.StringResult)) = params.StringResult{}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client, err := sshserver.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	key, err := client.SSHUserCertificateAuthority()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.IsNil)
+}
+
+func (s *sshserverSuite) TestSSHUserCertificateAuthorityNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected api call %q", request)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client, err := sshserver.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = client.SSHUserCertificateAuthority()
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
+
+// httpAPICaller is an API caller whose root HTTP client sends
+// requests to doer.
+type httpAPICaller struct {
+	basetesting.BestVersionCaller
+	doer func(*http.Request) (*http.Response, error)
+}
+
+func (a httpAPICaller) RootHTTPClient() (*httprequest.Client, error) {
+	return &httprequest.Client{Doer: doerFunc(a.doer)}, nil
+}
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
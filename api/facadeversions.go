@@ -26,13 +26,14 @@ var facadeVersions = facades.FacadeVersions{
 	"Agent":                        {3},
 	"AgentLifeFlag":                {1},
 	"AgentTools":                   {1},
-	"AllModelWatcher":              {4},
-	"AllWatcher":                   {3},
+	"AllModelWatcher":              {4, 5},
+	"AllWatcher":                   {3, 4},
 	"Annotations":                  {2},
 	"Application":                  {15, 16, 17, 18, 19, 20},
 	"ApplicationOffers":            {4, 5},
 	"ApplicationScaler":            {1},
-	"Backups":                      {3},
+	"AuditLog":                     {1},
+	"Backups":                      {3, 4},
 	"Block":                        {2},
 	"Bundle":                       {6},
 	"CAASAgent":                    {2},
@@ -48,10 +49,11 @@ var facadeVersions = facades.FacadeVersions{
 	"CAASOperatorUpgrader":         {1},
 	"CAASUnitProvisioner":          {2},
 	"CharmDownloader":              {1},
+	"CharmhubCache":                {1},
 	"CharmRevisionUpdater":         {2},
 	"Charms":                       {5, 6, 7},
 	"Cleaner":                      {2},
-	"Client":                       {6, 7, 8},
+	"Client":                       {6, 7, 8, 9},
 	"Cloud":                        {7},
 	"Controller":                   {11, 12},
 	"CredentialManager":            {1},
@@ -74,7 +76,7 @@ var facadeVersions = facades.FacadeVersions{
 	"InstanceMutater":              {3},
 	"InstancePoller":               {4},
 	"KeyManager":                   {1},
-	"KeyUpdater":                   {1},
+	"KeyUpdater":                   {1, 2},
 	"LeadershipService":            {2},
 	"LifeFlag":                     {1},
 	"LogForwarding":                {1},
@@ -113,19 +115,20 @@ var facadeVersions = facades.FacadeVersions{
 	"ResourcesHookContext":         {1},
 	"RetryStrategy":                {1},
 	"SecretsTriggerWatcher":        {1},
-	"SecretBackends":               {1},
+	"SecretBackends":               {1, 2},
 	"SecretBackendsManager":        {1},
 	"SecretBackendsRotateWatcher":  {1},
 	"SecretsRevisionWatcher":       {1},
-	"Secrets":                      {1, 2},
+	"Secrets":                      {1, 2, 3},
 	"SecretsManager":               {1, 2, 3},
 	"SecretsDrain":                 {1},
+	"SecretsDriftChecker":          {1},
 	"UserSecretsDrain":             {1},
 	"UserSecretsManager":           {1},
 	"Singular":                     {2},
 	"Spaces":                       {6},
-	"SSHClient":                    {4, 5},
-	"SSHServer":                    {1},
+	"SSHClient":                    {4, 5, 6, 7, 8},
+	"SSHServer":                    {1, 2, 3, 4},
 	"SSHSession":                   {1},
 	"SSHTunneler":                  {1},
 	"StatusHistory":                {2},
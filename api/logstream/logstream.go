@@ -135,6 +135,7 @@ func recordFromAPI(apiRec params.LogStreamRecord, controllerUUID string) (logfwd
 		ID:        apiRec.ID,
 		Timestamp: apiRec.Timestamp,
 		Message:   apiRec.Message,
+		Labels:    apiRec.Labels,
 	}
 
 	origin, err := originFromAPI(apiRec, controllerUUID)
@@ -72,6 +72,7 @@ func (s *LogReaderSuite) TestNextOneRecord(c *gc.C) {
 		Location:  "test.go:42",
 		Level:     loggo.INFO.String(),
 		Message:   "test message",
+		Labels:    []string{"cmr"},
 	}
 	apiRecords := params.LogStreamRecords{
 		Records: []params.LogStreamRecord{apiRec},
@@ -124,6 +125,7 @@ func (s *LogReaderSuite) TestNextOneRecord(c *gc.C) {
 			Line:     42,
 		},
 		Message: "test message",
+		Labels:  []string{"cmr"},
 	})
 	stub.CheckCallNames(c, "ReadJSON")
 
@@ -44,6 +44,7 @@ func (*ImportSuite) TestImports(c *gc.C) {
 		"core/relation",
 		"core/resources",
 		"core/secrets",
+		"core/sshpolicy",
 		"core/status",
 		"core/watcher",
 		"docker",
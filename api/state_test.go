@@ -261,7 +261,7 @@ func (s *stateSuite) TestLoginMacaroonInvalidId(c *gc.C) {
 }
 
 func (s *stateSuite) TestBestFacadeVersion(c *gc.C) {
-	c.Check(s.APIState.BestFacadeVersion("Client"), gc.Equals, 8)
+	c.Check(s.APIState.BestFacadeVersion("Client"), gc.Equals, 9)
 }
 
 func (s *stateSuite) TestAPIHostPortsMovesConnectedValueFirst(c *gc.C) {
@@ -47,13 +47,15 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/auditlog"          // Controller Superuser
 	"github.com/juju/juju/apiserver/facades/client/backups"           // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/block"             // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/bundle"
-	"github.com/juju/juju/apiserver/facades/client/charms"     // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/client"     // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/cloud"      // ModelUser Read
-	"github.com/juju/juju/apiserver/facades/client/controller" // ModelUser Admin (although some methods check for read only)
+	"github.com/juju/juju/apiserver/facades/client/charmhubcache" // Controller Superuser
+	"github.com/juju/juju/apiserver/facades/client/charms"        // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/client"        // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/cloud"         // ModelUser Read
+	"github.com/juju/juju/apiserver/facades/client/controller"    // ModelUser Admin (although some methods check for read only)
 	"github.com/juju/juju/apiserver/facades/client/credentialmanager"
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
@@ -102,6 +104,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/secretbackendmanager"
+	"github.com/juju/juju/apiserver/facades/controller/secretsdriftchecker"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
 	"github.com/juju/juju/apiserver/facades/controller/sshserver"
 	"github.com/juju/juju/apiserver/facades/controller/sshtunneler"
@@ -192,10 +195,12 @@ func AllFacades() *facade.Registry {
 	application.Register(registry)
 	applicationoffers.Register(registry)
 	applicationscaler.Register(registry)
+	auditlog.Register(registry)
 	backups.Register(registry)
 	block.Register(registry)
 	bundle.Register(registry)
 	charmdownloader.Register(registry)
+	charmhubcache.Register(registry)
 	charmrevisionupdater.Register(registry)
 	charms.Register(registry)
 	cleaner.Register(registry)
@@ -271,6 +276,7 @@ func AllFacades() *facade.Registry {
 	secretbackendmanager.Register(registry)
 	secretsmanager.Register(registry)
 	secretsdrain.Register(registry)
+	secretsdriftchecker.Register(registry)
 	usersecrets.Register(registry)
 	usersecretsdrain.Register(registry)
 	sshclient.Register(registry)
@@ -324,12 +330,14 @@ func registerWatchers(registry *facade.Registry) {
 		return NewPinger(ctx)
 	}, reflect.TypeOf((*Pinger)(nil)).Elem())
 
-	registry.MustRegister("AllWatcher", 3, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllWatcher", 3, newAllWatcherWithoutStorage, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllWatcher", 4, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	// Note: AllModelWatcher uses the same infrastructure as AllWatcher
 	// but they are get under separate names as it possible the may
 	// diverge in the future (especially in terms of authorisation
 	// checks).
-	registry.MustRegister("AllModelWatcher", 4, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllModelWatcher", 4, newAllWatcherWithoutStorage, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllModelWatcher", 5, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	registry.MustRegister("NotifyWatcher", 1, newNotifyWatcher, reflect.TypeOf((*srvNotifyWatcher)(nil)))
 	registry.MustRegister("StringsWatcher", 1, newStringsWatcher, reflect.TypeOf((*srvStringsWatcher)(nil)))
 	registry.MustRegister("OfferStatusWatcher", 1, newOfferStatusWatcher, reflect.TypeOf((*srvOfferStatusWatcher)(nil)))
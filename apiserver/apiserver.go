@@ -318,6 +318,9 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		logger:              loggo.GetLogger("juju.apiserver"),
 		charmhubHTTPClient:  cfg.CharmhubHTTPClient,
 		dbGetter:            cfg.DBGetter,
+		tag:                 cfg.Tag,
+		logDir:              cfg.LogDir,
+		clock:               cfg.Clock,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -844,6 +847,8 @@ func (srv *Server) endpoints() ([]apihttp.Endpoint, error) {
 		stateAuthFunc: httpCtxt.stateForMigrationImporting,
 	}, "resources")
 	backupHandler := srv.monitoredHandler(&backupHandler{ctxt: httpCtxt}, "backups")
+	charmhubCacheHandler := srv.monitoredHandler(&charmhubCacheHandler{ctxt: httpCtxt}, "charmhub-cache")
+	sshSessionRecordingsHandler := srv.monitoredHandler(&sshSessionRecordingsHandler{ctxt: httpCtxt}, "ssh-session-recordings")
 	registerHandler := srv.monitoredHandler(&registerUserHandler{ctxt: httpCtxt}, "register")
 
 	// HTTP handler for application offer macaroon authentication.
@@ -916,6 +921,16 @@ func (srv *Server) endpoints() ([]apihttp.Endpoint, error) {
 		pattern:    modelRoutePrefix + "/backups",
 		handler:    backupHandler,
 		authorizer: controllerAdminAuthorizer,
+	}, {
+		pattern:    "/charmhub-cache",
+		handler:    charmhubCacheHandler,
+		authorizer: controllerAdminAuthorizer,
+	}, {
+		// Recordings are sent by the controller's SSH server.
+		pattern:    "/ssh-session-recordings",
+		methods:    []string{"POST"},
+		handler:    sshSessionRecordingsHandler,
+		authorizer: controllerAuthorizer{},
 	}, {
 		// Legacy migration endpoint. Used by Juju 3.3 and prior
 		pattern:    "/migrate/charms",
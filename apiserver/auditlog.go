@@ -0,0 +1,151 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+	"github.com/juju/utils/v3"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/pubsub/apiserver"
+)
+
+// auditLogQueryTimeout is how long to wait for the API servers to
+// respond to an audit log query.
+const auditLogQueryTimeout = 30 * time.Second
+
+// auditLogReader answers the audit log queries of every API server by
+// searching the local audit log, and implements facade.AuditLog by
+// sending queries to every API server over the central hub.
+type auditLogReader struct {
+	hub    SharedHub
+	origin string
+	logDir string
+	clock  clock.Clock
+	logger loggo.Logger
+
+	// controllerIDs returns the IDs of the controllers expected to
+	// respond to a query.
+	controllerIDs func() ([]string, error)
+}
+
+func (r *auditLogReader) onQuery(topic string, data apiserver.AuditLogQuery, err error) {
+	if err != nil {
+		r.logger.Criticalf("programming error in %s message data: %v", topic, err)
+		return
+	}
+	result := apiserver.AuditLogResult{
+		Target:    data.Origin,
+		RequestID: data.RequestID,
+	}
+	entries, err := auditlog.Query(r.logDir, data.Filter)
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Entries = entries
+	}
+	if _, err := r.hub.Publish(apiserver.AuditLogResultTopic, result); err != nil {
+		r.logger.Errorf("cannot send audit log result: %v", err)
+	}
+}
+
+// Query is part of the facade.AuditLog interface.
+func (r *auditLogReader) Query(filter auditlog.Filter) ([]facade.AuditLogEntries, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids, err := r.controllerIDs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	requestID, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	results := make(chan apiserver.AuditLogResult, len(ids))
+	unsubscribe, err := r.hub.Subscribe(apiserver.AuditLogResultTopic,
+		func(topic string, data apiserver.AuditLogResult, err error) {
+			if err != nil {
+				r.logger.Criticalf("programming error in %s message data: %v", topic, err)
+				return
+			}
+			if data.Target != r.origin || data.RequestID != requestID.String() {
+				return
+			}
+			select {
+			case results <- data:
+			default:
+				r.logger.Warningf("dropping unexpected audit log result from %s", data.Origin)
+			}
+		})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer unsubscribe()
+
+	_, err = r.hub.Publish(apiserver.AuditLogQueryTopic, apiserver.AuditLogQuery{
+		Origin:    r.origin,
+		RequestID: requestID.String(),
+		Filter:    filter,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	waiting := set.NewStrings(ids...)
+	received := make(map[string]facade.AuditLogEntries)
+	timeout := r.clock.After(auditLogQueryTimeout)
+	for !waiting.IsEmpty() {
+		select {
+		case result := <-results:
+			id := originControllerID(result.Origin)
+			entries := facade.AuditLogEntries{
+				ControllerID: id,
+				Entries:      result.Entries,
+			}
+			if result.Error != "" {
+				entries.Error = errors.New(result.Error)
+			}
+			received[id] = entries
+			waiting.Remove(id)
+		case <-timeout:
+			r.logger.Warningf("timed out waiting for the audit logs of controllers %v", waiting.SortedValues())
+			for _, id := range waiting.Values() {
+				received[id] = facade.AuditLogEntries{
+					ControllerID: id,
+					Error:        errors.Timeoutf("waiting for controller %s", id),
+				}
+			}
+			waiting = set.NewStrings()
+		}
+	}
+
+	all := make([]facade.AuditLogEntries, 0, len(received))
+	for _, entries := range received {
+		all = append(all, entries)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ControllerID < all[j].ControllerID
+	})
+	return all, nil
+}
+
+// originControllerID returns the ID of the controller with the given
+// hub origin, which is the tag of its agent.
+func originControllerID(origin string) string {
+	tag, err := names.ParseTag(origin)
+	if err != nil {
+		return origin
+	}
+	return tag.Id()
+}
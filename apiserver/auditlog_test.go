@@ -0,0 +1,125 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+	"github.com/juju/pubsub/v2"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/pubsub/centralhub"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogReaderSuite struct {
+	testing.IsolationSuite
+
+	hub    *pubsub.StructuredHub
+	clock  *testclock.Clock
+	reader *auditLogReader
+	ids    []string
+}
+
+var _ = gc.Suite(&auditLogReaderSuite{})
+
+func (s *auditLogReaderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.hub = centralhub.New(names.NewMachineTag("0"), centralhub.PubsubNoOpMetrics{})
+	s.clock = testclock.NewClock(time.Now())
+	s.ids = []string{"0"}
+
+	logDir := c.MkDir()
+	logFile := auditlog.NewLogFile(logDir, 300, 10)
+	c.Assert(logFile.AddConversation(auditlog.Conversation{
+		Who: "bob", What: "juju deploy mysql", When: "2025-03-01T09:59:00Z",
+		ModelName: "default", ConversationID: "c1", ConnectionID: "A1",
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddRequest(auditlog.Request{
+		ConversationID: "c1", ConnectionID: "A1", RequestID: 1, When: "2025-03-01T09:59:01Z",
+		Facade: "Application", Method: "Deploy", Version: 19,
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+
+	s.reader = &auditLogReader{
+		hub:    s.hub,
+		origin: names.NewMachineTag("0").String(),
+		logDir: logDir,
+		clock:  s.clock,
+		logger: loggo.GetLogger("test"),
+		controllerIDs: func() ([]string, error) {
+			return s.ids, nil
+		},
+	}
+	unsubscribe, err := s.hub.Subscribe(apiserver.AuditLogQueryTopic, s.reader.onQuery)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { unsubscribe() })
+}
+
+func (s *auditLogReaderSuite) TestQueryLocal(c *gc.C) {
+	results, err := s.reader.Query(auditlog.Filter{
+		Users: []string{"bob"},
+		From:  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].ControllerID, gc.Equals, "0")
+	c.Check(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Entries, gc.HasLen, 1)
+	c.Check(results[0].Entries[0].Conversation.What, gc.Equals, "juju deploy mysql")
+	c.Check(results[0].Entries[0].Request.Method, gc.Equals, "Deploy")
+}
+
+func (s *auditLogReaderSuite) TestQueryOtherControllers(c *gc.C) {
+	s.ids = []string{"0", "1"}
+	unsubscribe, err := s.hub.Subscribe(apiserver.AuditLogQueryTopic,
+		func(topic string, data apiserver.AuditLogQuery, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(data.Filter.Method, gc.Equals, "Deploy")
+			_, err = s.hub.Publish(apiserver.AuditLogResultTopic, apiserver.AuditLogResult{
+				Origin:    names.NewMachineTag("1").String(),
+				Target:    data.Origin,
+				RequestID: data.RequestID,
+				Error:     "cannot read audit log",
+			})
+			c.Check(err, jc.ErrorIsNil)
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	results, err := s.reader.Query(auditlog.Filter{Method: "Deploy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results[0].ControllerID, gc.Equals, "0")
+	c.Check(results[0].Entries, gc.HasLen, 1)
+	c.Check(results[1].ControllerID, gc.Equals, "1")
+	c.Check(results[1].Error, gc.ErrorMatches, "cannot read audit log")
+}
+
+func (s *auditLogReaderSuite) TestQueryTimeout(c *gc.C) {
+	s.ids = []string{"0", "1"}
+	go func() {
+		c.Check(s.clock.WaitAdvance(auditLogQueryTimeout, coretesting.LongWait, 1), jc.ErrorIsNil)
+	}()
+
+	results, err := s.reader.Query(auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results[0].Entries, gc.HasLen, 1)
+	c.Check(results[1].ControllerID, gc.Equals, "1")
+	c.Check(results[1].Error, jc.Satisfies, errors.IsTimeout)
+}
+
+func (s *auditLogReaderSuite) TestQueryInvalidFilter(c *gc.C) {
+	_, err := s.reader.Query(auditlog.Filter{Result: "maybe"})
+	c.Assert(err, gc.ErrorMatches, `result "maybe" not valid`)
+}
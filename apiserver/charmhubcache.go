@@ -0,0 +1,133 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// charmhubCacheHandler exports the controller's Charmhub cache as a
+// cache archive, and seeds the cache from one.
+type charmhubCacheHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP implements [http.Handler].
+func (h *charmhubCacheHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	st, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		h.sendError(resp, err)
+		return
+	}
+	defer st.Release()
+
+	if !st.IsController() {
+		h.sendError(resp, errors.New("requested model is not the controller model"))
+		return
+	}
+
+	switch req.Method {
+	case "GET":
+		logger.Infof("handling charmhub cache export request")
+		h.export(st.CharmhubCache(), resp)
+	case "POST":
+		logger.Infof("handling charmhub cache seed request")
+		entries, err := h.seed(st.CharmhubCache(), req)
+		if err != nil {
+			h.sendError(resp, err)
+			return
+		}
+		if err := sendStatusAndJSON(resp, http.StatusOK, entries); err != nil {
+			logger.Errorf("%v", err)
+		}
+		logger.Infof("seeded charmhub cache with %d entries", len(entries.Entries))
+	default:
+		h.sendError(resp, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+	}
+}
+
+// export streams every entry in the cache to the response as a cache
+// archive.
+func (h *charmhubCacheHandler) export(cache *state.CharmhubCache, resp http.ResponseWriter) {
+	entries, err := cache.Entries()
+	if err != nil {
+		h.sendError(resp, err)
+		return
+	}
+
+	resp.Header().Set("Content-Type", params.ContentTypeTar)
+	resp.WriteHeader(http.StatusOK)
+	w := charmhub.NewCacheArchiveWriter(resp)
+	for _, entry := range entries {
+		if err := h.exportEntry(cache, w, entry.Key); err != nil {
+			// The response has started, so all we can do is abort it
+			// and leave the archive incomplete.
+			logger.Errorf("exporting charmhub cache: %v", err)
+			panic(http.ErrAbortHandler)
+		}
+	}
+	if err := w.Close(); err != nil {
+		logger.Errorf("exporting charmhub cache: %v", err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func (h *charmhubCacheHandler) exportEntry(cache *state.CharmhubCache, w *charmhub.CacheArchiveWriter, key string) error {
+	entry, r, err := cache.Open(key)
+	if errors.Is(err, errors.NotFound) {
+		// Pruned since the entries were listed.
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = r.Close() }()
+	return errors.Trace(w.Add(entry, r))
+}
+
+// seed adds the entries in the cache archive in the request body to
+// the cache, and returns them.
+func (h *charmhubCacheHandler) seed(cache *state.CharmhubCache, req *http.Request) (params.CharmhubCacheEntries, error) {
+	defer func() { _ = req.Body.Close() }()
+
+	var result params.CharmhubCacheEntries
+	if ctype := req.Header.Get("Content-Type"); ctype != params.ContentTypeTar {
+		return result, errors.BadRequestf("expected Content-Type %q, got %q", params.ContentTypeTar, ctype)
+	}
+	result.Entries = []params.CharmhubCacheEntry{}
+	err := charmhub.ReadCacheArchive(req.Body, func(entry charmhub.CacheEntry, r io.Reader) error {
+		if err := cache.Put(req.Context(), entry, r); err != nil {
+			return errors.Trace(err)
+		}
+		result.Entries = append(result.Entries, params.CharmhubCacheEntry{
+			Key:      entry.Key,
+			Kind:     string(entry.Kind),
+			Name:     entry.Name,
+			Revision: entry.Revision,
+			Size:     entry.Size,
+			SHA256:   entry.SHA256,
+		})
+		return nil
+	})
+	if err != nil {
+		return result, errors.Annotate(err, "seeding charmhub cache")
+	}
+	return result, nil
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *charmhubCacheHandler) sendError(w http.ResponseWriter, err error) {
+	err, status := apiservererrors.ServerErrorAndStatus(err)
+	if err := sendStatusAndJSON(w, status, err); err != nil {
+		logger.Errorf("%v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/names/v5"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/rpc/params"
 )
@@ -25,14 +26,21 @@ func NewStateControllerConfig(st ControllerConfigState) *ControllerConfigAPI {
 	}
 }
 
-// ControllerConfig returns the controller's configuration.
+// ControllerConfig returns the controller's configuration, without the
+// attributes holding credentials.
 func (s *ControllerConfigAPI) ControllerConfig() (params.ControllerConfigResult, error) {
 	result := params.ControllerConfigResult{}
 	config, err := s.st.ControllerConfig()
 	if err != nil {
 		return result, err
 	}
-	result.Config = params.ControllerConfig(config)
+	result.Config = make(params.ControllerConfig, len(config))
+	for key, value := range config {
+		if controller.SecretAttributes.Contains(key) {
+			continue
+		}
+		result.Config[key] = value
+	}
 	return result, nil
 }
 
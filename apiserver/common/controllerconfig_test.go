@@ -71,6 +71,30 @@ func (s *controllerConfigSuite) TestControllerConfigSuccess(c *gc.C) {
 	})
 }
 
+func (s *controllerConfigSuite) TestControllerConfigRedactsSecrets(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.EXPECT().ControllerConfig().Return(
+		map[string]interface{}{
+			controller.ControllerUUIDKey:         testing.ControllerTag.Id(),
+			controller.AuditLogForwardClientCert: "client-cert",
+			controller.AuditLogForwardClientKey:  "client-key",
+			controller.AuditLogForwardHeaders:    "Authorization=Bearer token",
+			controller.BackupS3AccessKey:         "access",
+			controller.BackupS3SecretKey:         "secret",
+		},
+		nil,
+	)
+
+	result, err := s.cc.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(map[string]interface{}(result.Config), jc.DeepEquals, map[string]interface{}{
+		"controller-uuid":               "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+		"audit-log-forward-client-cert": "client-cert",
+		"backup-s3-access-key":          "access",
+	})
+}
+
 func (s *controllerConfigSuite) TestControllerConfigFetchError(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -0,0 +1,97 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider/kubernetes"
+	"github.com/juju/juju/state"
+)
+
+// CheckContentDrift reads back the content of the secret revisions
+// matching the filter which are stored in an external backend, and
+// reports any which are missing or no longer match the checksum
+// recorded when the content was saved. Revisions saved without a
+// checksum can only be checked for missing content.
+func CheckContentDrift(
+	secretsState SecretsDriftState, adminConfigGetter BackendAdminConfigGetter, filter state.SecretsFilter,
+) (params.SecretContentDriftResults, error) {
+	var result params.SecretContentDriftResults
+	metadata, err := secretsState.ListSecrets(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var backends *cachedBackendGetter
+	for _, md := range metadata {
+		revs, err := secretsState.ListSecretRevisions(md.URI)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		for _, rev := range revs {
+			if rev.ValueRef == nil {
+				continue
+			}
+			if backends == nil {
+				if backends, err = newCachedBackendGetter(adminConfigGetter); err != nil {
+					return result, errors.Trace(err)
+				}
+			}
+			result.Checked++
+			drift, err := checkRevisionContent(backends, rev)
+			if drift == "" && err == nil {
+				continue
+			}
+			result.Results = append(result.Results, params.SecretContentDriftResult{
+				URI:         md.URI.String(),
+				Revision:    rev.Revision,
+				BackendName: driftBackendName(backends, rev),
+				Drift:       string(drift),
+				Error:       apiservererrors.ServerError(err),
+			})
+		}
+	}
+	return result, nil
+}
+
+func checkRevisionContent(backends *cachedBackendGetter, rev *coresecrets.SecretRevisionMetadata) (coresecrets.ContentDrift, error) {
+	backend, err := backends.getBackend(rev.ValueRef.BackendID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	val, err := backend.GetContent(context.TODO(), rev.ValueRef.RevisionID)
+	if errors.Is(err, errors.NotFound) {
+		return coresecrets.ContentMissing, nil
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if rev.Checksum == "" {
+		return "", nil
+	}
+	checksum, err := val.Checksum()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if checksum != rev.Checksum {
+		return coresecrets.ContentModified, nil
+	}
+	return "", nil
+}
+
+func driftBackendName(backends *cachedBackendGetter, rev *coresecrets.SecretRevisionMetadata) string {
+	if rev.BackendName != nil {
+		return *rev.BackendName
+	}
+	// Only the model's built in kubernetes backend has no name recorded.
+	if cfg, ok := backends.cfgInfo.Configs[rev.ValueRef.BackendID]; ok && cfg.ModelUUID == rev.ValueRef.BackendID {
+		return kubernetes.BuiltInName(cfg.ModelName)
+	}
+	return rev.ValueRef.BackendID
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common/secrets"
+	"github.com/juju/juju/apiserver/common/secrets/mocks"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type driftSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&driftSuite{})
+
+func (s *driftSuite) TestCheckContentDrift(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	driftState := mocks.NewMockSecretsDriftState(ctrl)
+	mockprovider := mocks.NewMockSecretBackendProvider(ctrl)
+	backend := mocks.NewMockSecretsBackend(ctrl)
+	s.PatchValue(&secrets.GetProvider, func(string) (provider.SecretBackendProvider, error) { return mockprovider, nil })
+	mockprovider.EXPECT().NewBackend(gomock.Any()).Return(backend, nil).Times(2)
+
+	modelUUID := coretesting.ModelTag.Id()
+	adminConfigGetter := func() (*provider.ModelBackendConfigInfo, error) {
+		return &provider.ModelBackendConfigInfo{
+			ActiveID: "backend-id",
+			Configs: map[string]provider.ModelBackendConfig{
+				"backend-id": {BackendConfig: provider.BackendConfig{BackendType: "vault"}},
+				modelUUID: {
+					ModelUUID:     modelUUID,
+					ModelName:     "fred",
+					BackendConfig: provider.BackendConfig{BackendType: "kubernetes"},
+				},
+			},
+		}, nil
+	}
+
+	value := coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	checksum, err := value.Checksum()
+	c.Assert(err, jc.ErrorIsNil)
+
+	uri := coresecrets.NewURI()
+	driftState.EXPECT().ListSecrets(state.SecretsFilter{}).Return([]*coresecrets.SecretMetadata{{URI: uri}}, nil)
+	driftState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{{
+		// Internal content isn't checked.
+		Revision: 1,
+	}, {
+		Revision:    2,
+		ValueRef:    &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "rev-2"},
+		BackendName: ptr("myvault"),
+		Checksum:    checksum,
+	}, {
+		Revision:    3,
+		ValueRef:    &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "rev-3"},
+		BackendName: ptr("myvault"),
+		Checksum:    "deadbeef",
+	}, {
+		Revision: 4,
+		ValueRef: &coresecrets.ValueRef{BackendID: modelUUID, RevisionID: "rev-4"},
+	}, {
+		// Without a checksum, only missing content can be detected.
+		Revision:    5,
+		ValueRef:    &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "rev-5"},
+		BackendName: ptr("myvault"),
+	}, {
+		Revision:    6,
+		ValueRef:    &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "rev-6"},
+		BackendName: ptr("myvault"),
+	}}, nil)
+	backend.EXPECT().GetContent(gomock.Any(), "rev-2").Return(value, nil)
+	backend.EXPECT().GetContent(gomock.Any(), "rev-3").Return(value, nil)
+	backend.EXPECT().GetContent(gomock.Any(), "rev-4").Return(nil, errors.NotFoundf("rev-4"))
+	backend.EXPECT().GetContent(gomock.Any(), "rev-5").Return(value, nil)
+	backend.EXPECT().GetContent(gomock.Any(), "rev-6").Return(nil, errors.New("boom"))
+
+	result, err := secrets.CheckContentDrift(driftState, adminConfigGetter, state.SecretsFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretContentDriftResults{
+		Checked: 5,
+		Results: []params.SecretContentDriftResult{{
+			URI:         uri.String(),
+			Revision:    3,
+			BackendName: "myvault",
+			Drift:       "modified",
+		}, {
+			URI:         uri.String(),
+			Revision:    4,
+			BackendName: "fred-local",
+			Drift:       "missing",
+		}, {
+			URI:         uri.String(),
+			Revision:    6,
+			BackendName: "myvault",
+			Error:       &params.Error{Message: "boom"},
+		}},
+	})
+}
+
+func (s *driftSuite) TestCheckContentDriftNoExternalContent(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	driftState := mocks.NewMockSecretsDriftState(ctrl)
+	adminConfigGetter := func() (*provider.ModelBackendConfigInfo, error) {
+		c.Fail()
+		return nil, nil
+	}
+
+	uri := coresecrets.NewURI()
+	driftState.EXPECT().ListSecrets(state.SecretsFilter{}).Return([]*coresecrets.SecretMetadata{{URI: uri}}, nil)
+	driftState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{{Revision: 1}}, nil)
+
+	result, err := secrets.CheckContentDrift(driftState, adminConfigGetter, state.SecretsFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretContentDriftResults{})
+}
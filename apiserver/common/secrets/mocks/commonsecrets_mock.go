@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/apiserver/common/secrets (interfaces: Model,Credential,SecretsConsumer,SecretsMetaState,SecretsRemoveState)
+// Source: github.com/juju/juju/apiserver/common/secrets (interfaces: Model,Credential,SecretsConsumer,SecretsMetaState,SecretsRemoveState,SecretsDriftState)
 //
 // Generated by this command:
 //
-//	mockgen -package mocks -destination mocks/commonsecrets_mock.go github.com/juju/juju/apiserver/common/secrets Model,Credential,SecretsConsumer,SecretsMetaState,SecretsRemoveState
+//	mockgen -package mocks -destination mocks/commonsecrets_mock.go github.com/juju/juju/apiserver/common/secrets Model,Credential,SecretsConsumer,SecretsMetaState,SecretsRemoveState,SecretsDriftState
 //
 
 // Package mocks is a generated GoMock package.
@@ -461,3 +461,56 @@ func (mr *MockSecretsRemoveStateMockRecorder) ListSecrets(arg0 any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockSecretsRemoveState)(nil).ListSecrets), arg0)
 }
+
+// MockSecretsDriftState is a mock of SecretsDriftState interface.
+type MockSecretsDriftState struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsDriftStateMockRecorder
+}
+
+// MockSecretsDriftStateMockRecorder is the mock recorder for MockSecretsDriftState.
+type MockSecretsDriftStateMockRecorder struct {
+	mock *MockSecretsDriftState
+}
+
+// NewMockSecretsDriftState creates a new mock instance.
+func NewMockSecretsDriftState(ctrl *gomock.Controller) *MockSecretsDriftState {
+	mock := &MockSecretsDriftState{ctrl: ctrl}
+	mock.recorder = &MockSecretsDriftStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretsDriftState) EXPECT() *MockSecretsDriftStateMockRecorder {
+	return m.recorder
+}
+
+// ListSecretRevisions mocks base method.
+func (m *MockSecretsDriftState) ListSecretRevisions(arg0 *secrets0.URI) ([]*secrets0.SecretRevisionMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretRevisions", arg0)
+	ret0, _ := ret[0].([]*secrets0.SecretRevisionMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretRevisions indicates an expected call of ListSecretRevisions.
+func (mr *MockSecretsDriftStateMockRecorder) ListSecretRevisions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretRevisions", reflect.TypeOf((*MockSecretsDriftState)(nil).ListSecretRevisions), arg0)
+}
+
+// ListSecrets mocks base method.
+func (m *MockSecretsDriftState) ListSecrets(arg0 state.SecretsFilter) ([]*secrets0.SecretMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", arg0)
+	ret0, _ := ret[0].([]*secrets0.SecretMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets.
+func (mr *MockSecretsDriftStateMockRecorder) ListSecrets(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockSecretsDriftState)(nil).ListSecrets), arg0)
+}
@@ -62,6 +62,13 @@ type ListSecretsState interface {
 	ListSecrets(state.SecretsFilter) ([]*secrets.SecretMetadata, error)
 }
 
+// SecretsDriftState instances provide the secret apis
+// needed to check secret content drift.
+type SecretsDriftState interface {
+	ListSecrets(state.SecretsFilter) ([]*secrets.SecretMetadata, error)
+	ListSecretRevisions(uri *secrets.URI) ([]*secrets.SecretRevisionMetadata, error)
+}
+
 // SecretsRemoveState instances provide secret removal apis.
 type SecretsRemoveState interface {
 	DeleteSecret(*secrets.URI, ...int) ([]secrets.ValueRef, error)
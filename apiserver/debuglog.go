@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -87,6 +88,10 @@ func newDebugLogHandler(
 //	replay -> string - one of [true, false], if true, start the file from the start
 //	noTail -> string - one of [true, false], if true, existing logs are sent back,
 //	   - but the command does not wait for new ones.
+//	startTime -> string - RFC3339 time, only logs on or after this time are sent
+//	endTime -> string - RFC3339 time, only logs before this time are sent
+//	   - the existing logs are sent back, but the command does not wait for new ones.
+//	message -> string - a regular expression that the log message must match
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -183,6 +188,7 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
 	startTime     time.Time
+	endTime       time.Time
 	maxLines      uint
 	fromTheStart  bool
 	noTail        bool
@@ -194,6 +200,7 @@ type debugLogParams struct {
 	excludeModule []string
 	includeLabel  []string
 	excludeLabel  []string
+	message       *regexp.Regexp
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -249,6 +256,25 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if !params.startTime.IsZero() && !endTime.After(params.startTime) {
+			return params, errors.Errorf("end time %q is not after start time %q", value, queryMap.Get("startTime"))
+		}
+		params.endTime = endTime
+	}
+
+	if value := queryMap.Get("message"); value != "" {
+		message, err := regexp.Compile(value)
+		if err != nil {
+			return params, errors.Errorf("message value %q is not a valid regular expression", value)
+		}
+		params.message = message
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
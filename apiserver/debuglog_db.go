@@ -58,6 +58,9 @@ func handleDebugLogDBRequest(
 			if !ok {
 				return errors.Annotate(tailer.Err(), "tailer stopped")
 			}
+			if reqParams.message != nil && !reqParams.message.MatchString(rec.Message) {
+				continue
+			}
 
 			if err := socket.sendLogRecord(formatLogRecord(rec)); err != nil {
 				return errors.Annotate(err, "sending failed")
@@ -76,6 +79,7 @@ func makeLogTailerParams(reqParams debugLogParams) corelogger.LogTailerParams {
 		MinLevel:      reqParams.filterLevel,
 		NoTail:        reqParams.noTail,
 		StartTime:     reqParams.startTime,
+		EndTime:       reqParams.endTime,
 		InitialLines:  int(reqParams.initialLines),
 		IncludeEntity: reqParams.includeEntity,
 		ExcludeEntity: reqParams.excludeEntity,
@@ -90,6 +94,7 @@ func makeLogTailerParams(reqParams debugLogParams) corelogger.LogTailerParams {
 
 func formatLogRecord(r *corelogger.LogRecord) *params.LogMessage {
 	return &params.LogMessage{
+		ModelUUID: r.ModelUUID,
 		Entity:    r.Entity,
 		Timestamp: r.Time,
 		Severity:  r.Level.String(),
@@ -5,6 +5,8 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -37,11 +39,13 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
+	t2 := time.Date(2016, 11, 30, 11, 0, 0, 0, time.UTC)
 	reqParams := debugLogParams{
 		fromTheStart:  false,
 		noTail:        true,
 		initialLines:  11,
 		startTime:     t1,
+		endTime:       t2,
 		filterLevel:   loggo.INFO,
 		includeEntity: []string{"foo"},
 		includeModule: []string{"bar"},
@@ -55,9 +59,8 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params corelogger.LogTailerParams) (corelogger.LogTailer, error) {
 		called = true
 
-		// Start time will be used once the client is extended to send
-		// time range arguments.
 		c.Assert(params.StartTime, gc.Equals, t1)
+		c.Assert(params.EndTime, gc.Equals, t2)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -222,6 +225,88 @@ func (s *debugLogDBIntSuite) TestMaxLines(c *gc.C) {
 	s.assertStops(c, done, tailer)
 }
 
+func (s *debugLogDBIntSuite) TestMessageFilter(c *gc.C) {
+	tailer := newFakeLogTailer()
+	for _, message := range []string{"stuff happened", "hook failed", "more stuff", "update-status hook failed"} {
+		tailer.logsCh <- &corelogger.LogRecord{
+			Time:     time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+			Entity:   "machine-99",
+			Module:   "some.where",
+			Location: "code.go:42",
+			Level:    loggo.INFO,
+			Message:  message,
+		}
+	}
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params corelogger.LogTailerParams) (corelogger.LogTailer, error) {
+		return tailer, nil
+	})
+
+	done := s.runRequest(debugLogParams{
+		maxLines: 2,
+		message:  regexp.MustCompile("hook (failed|errored)"),
+	}, nil)
+
+	s.assertOutput(c, []string{
+		"ok", // sendOk() call needs to happen first.
+		"machine-99: 2015-06-19 15:34:37 INFO some.where code.go:42 hook failed\n",
+		"machine-99: 2015-06-19 15:34:37 INFO some.where code.go:42 update-status hook failed\n",
+	})
+
+	// The line limit only counts the matching messages.
+	s.assertStops(c, done, tailer)
+}
+
+func (s *debugLogDBIntSuite) TestReadDebugLogParamsMessage(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{"message": {"hook (failed|errored)"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.message.String(), gc.Equals, "hook (failed|errored)")
+
+	_, err = readDebugLogParams(url.Values{"message": {"hook (failed"}})
+	c.Assert(err, gc.ErrorMatches, `message value "hook \(failed" is not a valid regular expression`)
+}
+
+func (s *debugLogDBIntSuite) TestReadDebugLogParamsTimeRange(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"startTime": {"2016-11-30T10:51:00Z"},
+		"endTime":   {"2016-11-30T11:00:00.5Z"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.startTime, gc.Equals, time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC))
+	c.Assert(params.endTime, gc.Equals, time.Date(2016, 11, 30, 11, 0, 0, 500000000, time.UTC))
+
+	_, err = readDebugLogParams(url.Values{"endTime": {"11:00"}})
+	c.Assert(err, gc.ErrorMatches, `end time "11:00" is not a valid time in RFC3339 format`)
+
+	_, err = readDebugLogParams(url.Values{
+		"startTime": {"2016-11-30T11:00:00Z"},
+		"endTime":   {"2016-11-30T10:51:00Z"},
+	})
+	c.Assert(err, gc.ErrorMatches, `end time "2016-11-30T10:51:00Z" is not after start time "2016-11-30T11:00:00Z"`)
+}
+
+func (s *debugLogDBIntSuite) TestFormatLogRecord(c *gc.C) {
+	record := formatLogRecord(&corelogger.LogRecord{
+		Time:      time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		ModelUUID: coretesting.ModelTag.Id(),
+		Entity:    "machine-99",
+		Module:    "some.where",
+		Location:  "code.go:42",
+		Level:     loggo.INFO,
+		Message:   "stuff happened",
+		Labels:    []string{"cmr"},
+	})
+	c.Assert(record, jc.DeepEquals, &params.LogMessage{
+		ModelUUID: coretesting.ModelTag.Id(),
+		Entity:    "machine-99",
+		Timestamp: time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		Severity:  "INFO",
+		Module:    "some.where",
+		Location:  "code.go:42",
+		Message:   "stuff happened",
+		Labels:    []string{"cmr"},
+	})
+}
+
 func (s *debugLogDBIntSuite) runRequest(params debugLogParams, stop chan struct{}) chan error {
 	done := make(chan error)
 	go func() {
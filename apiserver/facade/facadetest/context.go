@@ -37,6 +37,7 @@ type Context struct {
 	SingularClaimer_    lease.Claimer
 	CharmhubHTTPClient_ facade.HTTPClient
 	ControllerDB_       coredatabase.TrackedDB
+	AuditLog_           facade.AuditLog
 	// Identity is not part of the facade.Context interface, but is instead
 	// used to make sure that the context objects are the same.
 	Identity string
@@ -155,3 +156,8 @@ func (context Context) HTTPClient(purpose facade.HTTPClientPurpose) facade.HTTPC
 func (context Context) ControllerDB() (coredatabase.TrackedDB, error) {
 	return context.ControllerDB_, nil
 }
+
+// AuditLog implements facade.Context.
+func (context Context) AuditLog() facade.AuditLog {
+	return context.AuditLog_
+}
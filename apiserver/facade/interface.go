@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/names/v5"
 
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/cache"
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/core/leadership"
@@ -150,6 +151,9 @@ type Context interface {
 
 	// ControllerDB returns a TrackedDB reference for the controller database.
 	ControllerDB() (coredatabase.TrackedDB, error)
+
+	// AuditLog returns the audit logs of the controller's API servers.
+	AuditLog() AuditLog
 }
 
 // RequestRecorder is implemented by types that can record information about
@@ -246,6 +250,26 @@ type Hub interface {
 	Publish(topic string, data interface{}) (func(), error)
 }
 
+// AuditLog gives access to the audit logs written by each of the
+// controller's API servers.
+type AuditLog interface {
+	// Query returns the entries matching the filter from the audit
+	// log of each API server.
+	Query(filter auditlog.Filter) ([]AuditLogEntries, error)
+}
+
+// AuditLogEntries holds the audit log entries read by one API server.
+type AuditLogEntries struct {
+	// ControllerID is the ID of the controller running the API server.
+	ControllerID string
+
+	// Entries holds the matching entries, oldest first.
+	Entries []auditlog.Entry
+
+	// Error is set if the audit log could not be read.
+	Error error
+}
+
 // HTTPClient represents an HTTP client, for example, an *http.Client.
 type HTTPClient interface {
 	Do(*http.Request) (*http.Response, error)
@@ -94,6 +94,32 @@ func (s *agentSuite) TestAgentSucceedsWithUnitAgent(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *agentSuite) TestControllerConfigRedactsSecrets(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"audit-log-forward-headers": "Authorization=Bearer token",
+		"backup-s3-endpoint":        "https://s3.example.com",
+		"backup-s3-bucket":          "backups",
+		"backup-s3-access-key":      "access",
+		"backup-s3-secret-key":      "secret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := agent.NewAgentAPIV3(facadetest.Context{
+		State_:     s.State,
+		StatePool_: s.StatePool,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := api.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.Config["backup-s3-access-key"], gc.Equals, "access")
+	for _, key := range []string{"audit-log-forward-headers", "backup-s3-secret-key"} {
+		_, ok := cfg.Config[key]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", key))
+	}
+}
+
 func (s *agentSuite) TestGetEntities(c *gc.C) {
 	err := s.container.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
@@ -7,10 +7,12 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	"github.com/juju/utils/v3/ssh"
+	gossh "golang.org/x/crypto/ssh"
 
 	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/virtualhostname"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
@@ -20,6 +22,7 @@ import (
 type KeyUpdater interface {
 	AuthorisedKeys(args params.Entities) (params.StringsResults, error)
 	WatchAuthorisedKeys(args params.Entities) (params.NotifyWatchResults, error)
+	SSHUserCertificateAuthority(args params.Entities) (params.SSHUserCertificateAuthorityResults, error)
 }
 
 // KeyUpdaterAPI implements the KeyUpdater interface and is the concrete
@@ -32,13 +35,43 @@ type KeyUpdaterAPI struct {
 	getCanRead common.GetAuthFunc
 }
 
+// KeyUpdaterAPIV1 provides the KeyUpdater API facade version 1.
+type KeyUpdaterAPIV1 struct {
+	*KeyUpdaterAPI
+}
+
 var _ KeyUpdater = (*KeyUpdaterAPI)(nil)
 
+// SSHUserCertificateAuthority is not implemented in v1.
+func (*KeyUpdaterAPIV1) SSHUserCertificateAuthority(_, _ struct{}) {}
+
+// WatchAuthorisedKeys starts a watcher to track changes to the authorised
+// ssh keys for the specified machines. Version 1 of the facade doesn't
+// report changes to the controller config.
+func (api *KeyUpdaterAPIV1) WatchAuthorisedKeys(arg params.Entities) (params.NotifyWatchResults, error) {
+	return api.watchAuthorisedKeys(arg, func() state.NotifyWatcher {
+		return api.model.WatchForModelConfigChanges()
+	})
+}
+
 // WatchAuthorisedKeys starts a watcher to track changes to the authorised ssh keys
 // for the specified machines.
 // The current implementation relies on global authorised keys being stored in the model config.
 // This will change as new user management and authorisation functionality is added.
+// Changes to the controller config are also reported, as they determine
+// whether machines trust the controller's SSH user certificate authority.
 func (api *KeyUpdaterAPI) WatchAuthorisedKeys(arg params.Entities) (params.NotifyWatchResults, error) {
+	return api.watchAuthorisedKeys(arg, func() state.NotifyWatcher {
+		return common.NewMultiNotifyWatcher(
+			api.model.WatchForModelConfigChanges(),
+			api.state.WatchControllerConfig(),
+		)
+	})
+}
+
+func (api *KeyUpdaterAPI) watchAuthorisedKeys(
+	arg params.Entities, newWatcher func() state.NotifyWatcher,
+) (params.NotifyWatchResults, error) {
 	results := make([]params.NotifyWatchResult, len(arg.Entities))
 
 	canRead, err := api.getCanRead()
@@ -66,7 +99,7 @@ func (api *KeyUpdaterAPI) WatchAuthorisedKeys(arg params.Entities) (params.Notif
 			continue
 		}
 		// 3. Watch for changes
-		watch := api.model.WatchForModelConfigChanges()
+		watch := newWatcher()
 		// Consume the initial event.
 		if _, ok := <-watch.Changes(); ok {
 			results[i].NotifyWatcherId = api.resources.Register(watch)
@@ -128,3 +161,75 @@ func (api *KeyUpdaterAPI) AuthorisedKeys(arg params.Entities) (params.StringsRes
 	}
 	return params.StringsResults{Results: results}, nil
 }
+
+// SSHUserCertificateAuthority reports the public key of the controller's
+// SSH user certificate authority for the specified machines, along with
+// the principals each machine accepts certificates for. No key is
+// reported if the controller isn't configured to use SSH user
+// certificates, or if the machine is a container, which can't be the
+// target of a certificate.
+func (api *KeyUpdaterAPI) SSHUserCertificateAuthority(arg params.Entities) (params.SSHUserCertificateAuthorityResults, error) {
+	if len(arg.Entities) == 0 {
+		return params.SSHUserCertificateAuthorityResults{}, nil
+	}
+	results := make([]params.SSHUserCertificateAuthorityResult, len(arg.Entities))
+	canRead, err := api.getCanRead()
+	if err != nil {
+		return params.SSHUserCertificateAuthorityResults{}, err
+	}
+	controllerConfig, err := api.state.ControllerConfig()
+	if err != nil {
+		return params.SSHUserCertificateAuthorityResults{}, errors.Trace(err)
+	}
+	var publicKey string
+	if controllerConfig.SSHUserCertificates() {
+		if publicKey, err = api.sshUserCAPublicKey(); err != nil {
+			return params.SSHUserCertificateAuthorityResults{}, errors.Trace(err)
+		}
+	}
+	for i, entity := range arg.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canRead(tag) {
+			results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		if _, err := api.state.FindEntity(tag); err != nil {
+			if errors.IsNotFound(err) {
+				results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			} else {
+				results[i].Error = apiservererrors.ServerError(err)
+			}
+			continue
+		}
+		if publicKey == "" || names.IsContainerMachine(tag.Id()) {
+			continue
+		}
+		info, err := virtualhostname.NewInfoMachineTarget(api.model.UUID(), tag.Id())
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].PublicKey = publicKey
+		results[i].Principals = []string{info.String()}
+		results[i].CertificatesOnly = controllerConfig.SSHUserCertificatesOnly()
+	}
+	return params.SSHUserCertificateAuthorityResults{Results: results}, nil
+}
+
+// sshUserCAPublicKey returns the public key of the controller's SSH
+// user certificate authority in authorized_keys format.
+func (api *KeyUpdaterAPI) sshUserCAPublicKey() (string, error) {
+	privateKey, err := api.state.SSHUserCAKey()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	signer, err := gossh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(gossh.MarshalAuthorizedKey(signer.PublicKey())), nil
+}
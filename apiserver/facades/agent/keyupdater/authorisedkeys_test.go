@@ -4,14 +4,18 @@
 package keyupdater_test
 
 import (
+	"fmt"
+
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
+	gossh "golang.org/x/crypto/ssh"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade/facadetest"
 	"github.com/juju/juju/apiserver/facades/agent/keyupdater"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
@@ -153,3 +157,66 @@ func (s *authorisedKeysSuite) TestAuthorisedKeys(c *gc.C) {
 		},
 	})
 }
+
+func (s *authorisedKeysSuite) TestSSHUserCertificateAuthorityDisabled(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.rawMachine.Tag().String()},
+			{Tag: s.unrelatedMachine.Tag().String()},
+		},
+	}
+	results, err := s.keyupdater.SSHUserCertificateAuthority(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.SSHUserCertificateAuthorityResults{
+		Results: []params.SSHUserCertificateAuthorityResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *authorisedKeysSuite) TestSSHUserCertificateAuthority(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SSHUserCertificates: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	caKey, err := s.State.SSHUserCAKey()
+	c.Assert(err, jc.ErrorIsNil)
+	signer, err := gossh.ParsePrivateKey([]byte(caKey))
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.rawMachine.Tag().String()},
+			{Tag: "machine-42"},
+		},
+	}
+	results, err := s.keyupdater.SSHUserCertificateAuthority(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.SSHUserCertificateAuthorityResults{
+		Results: []params.SSHUserCertificateAuthorityResult{{
+			PublicKey:  string(gossh.MarshalAuthorizedKey(signer.PublicKey())),
+			Principals: []string{fmt.Sprintf("%s.%s.juju.local", s.rawMachine.Id(), s.Model.UUID())},
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+}
+
+func (s *authorisedKeysSuite) TestSSHUserCertificateAuthorityCertificatesOnly(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SSHUserCertificates:     true,
+		controller.SSHUserCertificatesOnly: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results, err := s.keyupdater.SSHUserCertificateAuthority(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].PublicKey, gc.Not(gc.Equals), "")
+	c.Assert(results.Results[0].CertificatesOnly, jc.IsTrue)
+}
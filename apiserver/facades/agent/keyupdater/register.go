@@ -16,10 +16,23 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("KeyUpdater", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return newKeyUpdaterAPIV1(ctx)
+	}, reflect.TypeOf((*KeyUpdaterAPIV1)(nil)))
+	registry.MustRegister("KeyUpdater", 2, func(ctx facade.Context) (facade.Facade, error) {
 		return newKeyUpdaterAPI(ctx)
 	}, reflect.TypeOf((*KeyUpdaterAPI)(nil)))
 }
 
+// newKeyUpdaterAPIV1 creates a new server-side keyupdater API end point
+// for version 1 of the facade.
+func newKeyUpdaterAPIV1(ctx facade.Context) (*KeyUpdaterAPIV1, error) {
+	api, err := newKeyUpdaterAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &KeyUpdaterAPIV1{api}, nil
+}
+
 // newKeyUpdaterAPI creates a new server-side keyupdater API end point.
 func newKeyUpdaterAPI(ctx facade.Context) (*KeyUpdaterAPI, error) {
 	authorizer := ctx.Auth()
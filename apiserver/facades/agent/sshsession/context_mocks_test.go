@@ -224,6 +224,20 @@ func (m *MockContext) EXPECT() *MockContextMockRecorder {
 	return m.recorder
 }
 
+// AuditLog mocks base method.
+func (m *MockContext) AuditLog() facade.AuditLog {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLog")
+	ret0, _ := ret[0].(facade.AuditLog)
+	return ret0
+}
+
+// AuditLog indicates an expected call of AuditLog.
+func (mr *MockContextMockRecorder) AuditLog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLog", reflect.TypeOf((*MockContext)(nil).AuditLog))
+}
+
 // Auth mocks base method.
 func (m *MockContext) Auth() facade.Authorizer {
 	m.ctrl.T.Helper()
@@ -0,0 +1,118 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/rpc/params"
+)
+
+// API serves the AuditLog facade, which queries the audit logs of
+// every controller. Only controller superusers may use it.
+type API struct {
+	auditLog facade.AuditLog
+}
+
+// NewAPI returns a new AuditLog API facade.
+func NewAPI(authorizer facade.Authorizer, controllerTag names.ControllerTag, auditLog facade.AuditLog) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	if err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{auditLog: auditLog}, nil
+}
+
+// Query returns the audit log entries matching the arguments from
+// every controller, oldest first. At most auditlog.MaxQueryLimit
+// entries are returned. A controller whose audit log cannot be read
+// is reported in the result rather than failing the call.
+func (a *API) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	var result params.AuditLogQueryResult
+	filter := auditlog.Filter{
+		Users:          args.Users,
+		Models:         args.Models,
+		ConversationID: args.ConversationID,
+		Facade:         args.Facade,
+		Method:         args.Method,
+		Result:         args.Result,
+		Limit:          args.Limit,
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	if err := filter.Validate(); err != nil {
+		return result, apiservererrors.ServerError(err)
+	}
+
+	controllers, err := a.auditLog.Query(filter)
+	if err != nil {
+		return result, apiservererrors.ServerError(err)
+	}
+	result.Entries = []params.AuditLogEntry{}
+	for _, controller := range controllers {
+		if controller.Error != nil {
+			result.Errors = append(result.Errors, params.AuditLogControllerError{
+				ControllerID: controller.ControllerID,
+				Error:        apiservererrors.ServerError(controller.Error),
+			})
+			continue
+		}
+		for _, entry := range controller.Entries {
+			result.Entries = append(result.Entries, toParams(controller.ControllerID, entry))
+		}
+	}
+	sort.SliceStable(result.Entries, func(i, j int) bool {
+		return result.Entries[i].When.Before(result.Entries[j].When)
+	})
+	if limit := filter.EffectiveLimit(); len(result.Entries) > limit {
+		result.Entries = result.Entries[len(result.Entries)-limit:]
+	}
+	return result, nil
+}
+
+func toParams(controllerID string, entry auditlog.Entry) params.AuditLogEntry {
+	// Audit log times are recorded to the second in RFC3339 format;
+	// an unreadable time sorts first.
+	when, _ := time.Parse(time.RFC3339, entry.Request.When)
+	result := params.AuditLogEntry{
+		ControllerID:   controllerID,
+		ConversationID: entry.Request.ConversationID,
+		ConnectionID:   entry.Request.ConnectionID,
+		RequestID:      entry.Request.RequestID,
+		Who:            entry.Conversation.Who,
+		What:           entry.Conversation.What,
+		ModelName:      entry.Conversation.ModelName,
+		ModelUUID:      entry.Conversation.ModelUUID,
+		When:           when.UTC(),
+		Facade:         entry.Request.Facade,
+		Method:         entry.Request.Method,
+		Version:        entry.Request.Version,
+		Args:           entry.Request.Args,
+		Responded:      entry.Responded,
+	}
+	for _, err := range entry.Errors {
+		if err == nil {
+			continue
+		}
+		result.Errors = append(result.Errors, params.AuditLogError{
+			Message: err.Message,
+			Code:    err.Code,
+		})
+	}
+	return result
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	auditLog   *fakeAuditLog
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	s.auditLog = &fakeAuditLog{}
+}
+
+func (s *auditLogSuite) newAPI(c *gc.C) *auditlog.API {
+	api, err := auditlog.NewAPI(s.authorizer, coretesting.ControllerTag, s.auditLog)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func entry(who, when, method string, errs ...*coreauditlog.Error) coreauditlog.Entry {
+	return coreauditlog.Entry{
+		Conversation: coreauditlog.Conversation{
+			Who:            who,
+			What:           "juju " + method,
+			ModelName:      "default",
+			ModelUUID:      "deadbeef",
+			ConversationID: who + "-conversation",
+			ConnectionID:   "A1",
+		},
+		Request: coreauditlog.Request{
+			ConversationID: who + "-conversation",
+			ConnectionID:   "A1",
+			RequestID:      1,
+			When:           when,
+			Facade:         "Application",
+			Method:         method,
+			Version:        19,
+		},
+		Errors:    errs,
+		Responded: true,
+	}
+}
+
+func (s *auditLogSuite) TestNonSuperuserDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := auditlog.NewAPI(s.authorizer, coretesting.ControllerTag, s.auditLog)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditLogSuite) TestAgentDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(s.authorizer, coretesting.ControllerTag, s.auditLog)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	s.auditLog.results = []facade.AuditLogEntries{{
+		ControllerID: "0",
+		Entries: []coreauditlog.Entry{
+			entry("bob", "2025-03-01T10:00:00Z", "Deploy"),
+			entry("bob", "2025-03-01T10:02:00Z", "Get"),
+		},
+	}, {
+		ControllerID: "1",
+		Entries: []coreauditlog.Entry{
+			entry("alice", "2025-03-01T10:01:00Z", "DestroyApplication", &coreauditlog.Error{
+				Message: "permission denied", Code: "unauthorized access",
+			}),
+		},
+	}, {
+		ControllerID: "2",
+		Error:        errors.Timeoutf("waiting for controller 2"),
+	}}
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	result, err := s.newAPI(c).Query(params.AuditLogQueryArgs{
+		Users:  []string{"bob", "alice"},
+		Models: []string{"default"},
+		Facade: "Application",
+		From:   &from,
+		Limit:  10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.auditLog.filter, jc.DeepEquals, coreauditlog.Filter{
+		Users:  []string{"bob", "alice"},
+		Models: []string{"default"},
+		Facade: "Application",
+		From:   from,
+		Limit:  10,
+	})
+
+	c.Assert(result.Entries, gc.HasLen, 3)
+	c.Check(result.Entries[0], jc.DeepEquals, params.AuditLogEntry{
+		ControllerID:   "0",
+		ConversationID: "bob-conversation",
+		ConnectionID:   "A1",
+		RequestID:      1,
+		Who:            "bob",
+		What:           "juju Deploy",
+		ModelName:      "default",
+		ModelUUID:      "deadbeef",
+		When:           time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        19,
+		Responded:      true,
+	})
+	c.Check(result.Entries[1].ControllerID, gc.Equals, "1")
+	c.Check(result.Entries[1].Errors, jc.DeepEquals, []params.AuditLogError{{
+		Message: "permission denied", Code: "unauthorized access",
+	}})
+	c.Check(result.Entries[2].Method, gc.Equals, "Get")
+
+	c.Assert(result.Errors, gc.HasLen, 1)
+	c.Check(result.Errors[0].ControllerID, gc.Equals, "2")
+	c.Check(result.Errors[0].Error, gc.ErrorMatches, "waiting for controller 2 timeout")
+}
+
+func (s *auditLogSuite) TestQueryLimitAcrossControllers(c *gc.C) {
+	s.auditLog.results = []facade.AuditLogEntries{{
+		ControllerID: "0",
+		Entries: []coreauditlog.Entry{
+			entry("bob", "2025-03-01T10:00:00Z", "Deploy"),
+			entry("bob", "2025-03-01T10:02:00Z", "Get"),
+		},
+	}, {
+		ControllerID: "1",
+		Entries: []coreauditlog.Entry{
+			entry("alice", "2025-03-01T10:01:00Z", "DestroyApplication"),
+		},
+	}}
+	result, err := s.newAPI(c).Query(params.AuditLogQueryArgs{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 2)
+	c.Check(result.Entries[0].Method, gc.Equals, "DestroyApplication")
+	c.Check(result.Entries[1].Method, gc.Equals, "Get")
+}
+
+func (s *auditLogSuite) TestQueryInvalidResult(c *gc.C) {
+	_, err := s.newAPI(c).Query(params.AuditLogQueryArgs{Result: "maybe"})
+	c.Assert(err, gc.ErrorMatches, `result "maybe" not valid`)
+	c.Assert(s.auditLog.called, jc.IsFalse)
+}
+
+func (s *auditLogSuite) TestQueryLimitTooLarge(c *gc.C) {
+	_, err := s.newAPI(c).Query(params.AuditLogQueryArgs{Limit: coreauditlog.MaxQueryLimit + 1})
+	c.Assert(err, gc.ErrorMatches, `limit 10001 greater than 10000 not valid`)
+	c.Assert(s.auditLog.called, jc.IsFalse)
+}
+
+type fakeAuditLog struct {
+	called  bool
+	filter  coreauditlog.Filter
+	results []facade.AuditLogEntries
+}
+
+func (f *fakeAuditLog) Query(filter coreauditlog.Filter) ([]facade.AuditLogEntries, error) {
+	f.called = true
+	f.filter = filter
+	return f.results, nil
+}
@@ -0,0 +1,6 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides the server implementation for the
+// AuditLog facade, which queries the audit logs of every controller.
+package auditlog
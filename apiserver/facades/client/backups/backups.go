@@ -43,20 +43,41 @@ type API struct {
 
 	// machineID is the ID of the machine where the API server is running.
 	machineID string
+
+	// scheduler is set when the API is used by a controller agent to
+	// run scheduled backups, in which case only scheduled backups can
+	// be created, listed and removed.
+	scheduler bool
+}
+
+// APIv4 provides the Backups API facade version 4,
+// which adds List and Remove.
+type APIv4 struct {
+	*API
+}
+
+// APIv3 provides the Backups API facade version 3.
+type APIv3 struct {
+	*APIv4
 }
 
 // NewAPI creates a new instance of the Backups API facade.
 func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
-	err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
-	if err != nil &&
-		!errors.Is(err, authentication.ErrorEntityMissingPermission) &&
-		!errors.Is(err, errors.NotFound) {
-		return nil, errors.Trace(err)
-	}
-	isControllerAdmin := err == nil
+	// Controller agents run scheduled backups; anyone else
+	// must be a controller superuser.
+	scheduler := authorizer.AuthController()
+	if !scheduler {
+		err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+		if err != nil &&
+			!errors.Is(err, authentication.ErrorEntityMissingPermission) &&
+			!errors.Is(err, errors.NotFound) {
+			return nil, errors.Trace(err)
+		}
+		isControllerAdmin := err == nil
 
-	if !authorizer.AuthClient() || !isControllerAdmin {
-		return nil, apiservererrors.ErrPerm
+		if !authorizer.AuthClient() || !isControllerAdmin {
+			return nil, apiservererrors.ErrPerm
+		}
 	}
 
 	// For now, backup operations are only permitted on the controller model.
@@ -98,6 +119,7 @@ func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Autho
 		backend:   backend,
 		paths:     &paths,
 		machineID: machineID,
+		scheduler: scheduler,
 	}
 	return &b, nil
 }
@@ -150,5 +172,22 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 	result.ControllerMachineInstanceID = meta.Controller.MachineInstanceID
 	result.Filename = filename
 
+	if meta.Remote != nil {
+		result.Remote = &params.BackupsRemoteResult{
+			Endpoint: meta.Remote.Endpoint,
+			Bucket:   meta.Remote.Bucket,
+			Key:      meta.Remote.Key,
+			ETag:     meta.Remote.ETag,
+			Uploaded: meta.Remote.Uploaded,
+		}
+	}
+
+	if meta.Encryption != nil {
+		result.Encryption = &params.BackupsEncryptionResult{
+			Format:     meta.Encryption.Format,
+			Recipients: meta.Encryption.Recipients,
+		}
+	}
+
 	return result
 }
@@ -96,6 +96,19 @@ func (s *backupsSuite) TestNewAPINotAuthorized(c *gc.C) {
 	c.Check(errors.Cause(err), gc.Equals, apiservererrors.ErrPerm)
 }
 
+func (s *backupsSuite) TestNewAPIControllerAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	s.authorizer.Controller = true
+	_, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *backupsSuite) TestNewAPIMachineAgentNotAuthorized(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("1")
+	_, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	c.Check(errors.Cause(err), gc.Equals, apiservererrors.ErrPerm)
+}
+
 func (s *backupsSuite) TestNewAPIHostedEnvironmentFails(c *gc.C) {
 	otherState := s.Factory.MakeModel(c, nil)
 	defer otherState.Close()
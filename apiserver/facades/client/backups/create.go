@@ -5,20 +5,43 @@ package backups
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/mgo/v3"
 	"github.com/juju/replicaset/v3"
 
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/internal/s3client"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state/backups"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.backups")
+
 var waitUntilReady = func(s *mgo.Session, timeout int) error {
 	return replicaset.WaitUntilReady(s, timeout)
 }
 
+var newObjectStore = func(cfg controller.Config) (backups.ObjectStore, error) {
+	uploader, err := s3client.NewS3ClientWithCredentials(
+		cfg.BackupS3Endpoint(),
+		cfg.BackupS3Region(),
+		cfg.BackupS3AccessKey(),
+		cfg.BackupS3SecretKey(),
+		logger,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return objectStoreShim{uploader}, nil
+}
+
 // Create is the API method that requests juju to create a new backup
 // of its state.
 func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	if a.scheduler && args.Notes != params.BackupsScheduledNotes {
+		return params.BackupsMetadataResult{}, apiservererrors.ErrPerm
+	}
 	backupsMethods := newBackups(a.paths)
 
 	session := a.backend.MongoSession().Copy()
@@ -66,10 +89,36 @@ func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResul
 	}
 	meta.Controller.HANodes = int64(len(nodes))
 
-	fileName, err := backupsMethods.Create(meta, dbInfo)
+	controllerConfig, err := a.backend.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	recipients, err := backups.ParseRecipients(
+		append(controllerConfig.BackupEncryptionRecipients(), args.EncryptTo...),
+	)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	fileName, err := backupsMethods.Create(meta, dbInfo, recipients)
 	if err != nil {
 		return result, errors.Trace(err)
 	}
+	if endpoint := controllerConfig.BackupS3Endpoint(); endpoint != "" {
+		store, err := newObjectStore(controllerConfig)
+		if err != nil {
+			return result, errors.Annotatef(err, "backup created as %q but cannot be uploaded", fileName)
+		}
+		target := backups.UploadTarget{
+			Store:    store,
+			Endpoint: endpoint,
+			Bucket:   controllerConfig.BackupS3Bucket(),
+			Prefix:   controllerConfig.BackupS3Prefix(),
+		}
+		if err := backupsMethods.Upload(meta, fileName, target); err != nil {
+			return result, errors.Annotatef(err, "backup created as %q but not uploaded", fileName)
+		}
+	}
 
 	result = CreateResult(meta, fileName)
 	return result, nil
@@ -4,12 +4,18 @@
 package backups_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/mgo/v3"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/rpc/params"
+	statebackups "github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -70,3 +76,116 @@ func (s *backupsSuite) TestCreateController(c *gc.C) {
 	expected := backups.CreateResult(s.meta, "test-filename")
 	c.Check(result, gc.DeepEquals, expected)
 }
+
+type fakeObjectStore struct {
+	statebackups.ObjectStore
+}
+
+func (s *backupsSuite) TestCreateUpload(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupS3Endpoint: "https://minio.example.com",
+		controller.BackupS3Bucket:   "juju-backups",
+		controller.BackupS3Prefix:   "prod",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	store := fakeObjectStore{}
+	var gotConfig controller.Config
+	s.PatchValue(backups.NewObjectStore, func(cfg controller.Config) (statebackups.ObjectStore, error) {
+		gotConfig = cfg
+		return store, nil
+	})
+	fake := s.setBackups(c, s.meta, "")
+	fake.Remote = &statebackups.RemoteMetadata{
+		Endpoint: "https://minio.example.com",
+		Bucket:   "juju-backups",
+		Key:      "prod/test-filename",
+		ETag:     "0123456789abcdef-1",
+		Uploaded: time.Date(2025, 3, 1, 2, 30, 0, 0, time.UTC),
+	}
+
+	result, err := s.api.Create(params.BackupsCreateArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Create", "Upload"})
+	c.Check(fake.IDArg, gc.Equals, "test-filename")
+	c.Check(fake.TargetArg, jc.DeepEquals, statebackups.UploadTarget{
+		Store:    store,
+		Endpoint: "https://minio.example.com",
+		Bucket:   "juju-backups",
+		Prefix:   "prod",
+	})
+	c.Check(gotConfig.BackupS3Endpoint(), gc.Equals, "https://minio.example.com")
+	c.Check(result.Remote, jc.DeepEquals, &params.BackupsRemoteResult{
+		Endpoint: "https://minio.example.com",
+		Bucket:   "juju-backups",
+		Key:      "prod/test-filename",
+		ETag:     "0123456789abcdef-1",
+		Uploaded: time.Date(2025, 3, 1, 2, 30, 0, 0, time.UTC),
+	})
+}
+
+func (s *backupsSuite) TestCreateUploadError(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupS3Endpoint: "https://minio.example.com",
+		controller.BackupS3Bucket:   "juju-backups",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(backups.NewObjectStore, func(controller.Config) (statebackups.ObjectStore, error) {
+		return fakeObjectStore{}, nil
+	})
+	fake := s.setBackups(c, s.meta, "")
+	s.PatchValue(backups.NewBackups, func(*statebackups.Paths) statebackups.Backups {
+		return &failingUpload{FakeBackups: fake}
+	})
+
+	_, err = s.api.Create(params.BackupsCreateArgs{})
+	c.Check(err, gc.ErrorMatches, `backup created as "test-filename" but not uploaded: boom`)
+}
+
+type failingUpload struct {
+	*backupstesting.FakeBackups
+}
+
+func (f *failingUpload) Upload(*statebackups.Metadata, string, statebackups.UploadTarget) error {
+	return errors.New("boom")
+}
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	const (
+		ageKey = "age1840d7j408juj3lknktrxhfhz5jkd7x64ya9x7a0ccnp7wyl37p0sse0tq2"
+		sshKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIjnSl4H7szDweaz/rUNLA6kcxHeBLEkBWd8rOs79qLU backup"
+	)
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupEncryptionRecipients: []interface{}{ageKey},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	fake := s.setBackups(c, s.meta, "")
+
+	_, err = s.api.Create(params.BackupsCreateArgs{EncryptTo: []string{sshKey, ageKey}})
+	c.Assert(err, jc.ErrorIsNil)
+	expected, err := statebackups.ParseRecipients([]string{ageKey, sshKey})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.RecipientsArg, gc.HasLen, 2)
+	for i, r := range fake.RecipientsArg {
+		c.Check(r.Fingerprint, gc.Equals, expected[i].Fingerprint)
+	}
+}
+
+func (s *backupsSuite) TestCreateInvalidRecipient(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+
+	_, err := s.api.Create(params.BackupsCreateArgs{EncryptTo: []string{"not-a-key"}})
+	c.Check(err, gc.ErrorMatches, "invalid backup recipient: .*")
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
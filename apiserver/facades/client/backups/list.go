@@ -0,0 +1,39 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// List is not available in v3.
+func (*APIv3) List(_, _ struct{}) {}
+
+// List returns the backup archives held in the backup directory
+// of the controller machine serving the request. Controller agents
+// are only given the scheduled backups.
+func (a *API) List() (params.BackupsListResult, error) {
+	archives, err := newBackups(a.paths).List()
+	if err != nil {
+		return params.BackupsListResult{}, errors.Trace(err)
+	}
+	result := params.BackupsListResult{
+		List: make([]params.BackupsArchive, 0, len(archives)),
+	}
+	for _, archive := range archives {
+		if a.scheduler && archive.Notes != params.BackupsScheduledNotes {
+			continue
+		}
+		result.List = append(result.List, params.BackupsArchive{
+			ID:      archive.Filename,
+			Size:    archive.Size,
+			Created: archive.Created,
+			Machine: a.machineID,
+			Notes:   archive.Notes,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	backupsAPI "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+func (s *backupsSuite) TestList(c *gc.C) {
+	created := time.Date(2025, 3, 1, 2, 30, 0, 0, time.UTC)
+	fake := s.setBackups(c, nil, "")
+	fake.Archives = []backups.ArchiveInfo{{
+		Filename: "/var/lib/juju/backups/juju-backup-20250301-023000.tar.gz",
+		Size:     1024,
+		Created:  created,
+	}}
+
+	result, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.BackupsListResult{
+		List: []params.BackupsArchive{{
+			ID:      "/var/lib/juju/backups/juju-backup-20250301-023000.tar.gz",
+			Size:    1024,
+			Created: created,
+			Machine: "0",
+		}},
+	})
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List"})
+}
+
+func (s *backupsSuite) TestListError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.api.List()
+	c.Check(err, gc.ErrorMatches, "failed!")
+}
+
+func (s *backupsSuite) TestRemove(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+
+	result := s.api.Remove(params.BackupsRemoveArgs{
+		IDs: []string{"/var/lib/juju/backups/juju-backup-20250301-023000.tar.gz"},
+	})
+	c.Assert(result.Combine(), jc.ErrorIsNil)
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Remove"})
+	c.Check(fake.IDArg, gc.Equals, "/var/lib/juju/backups/juju-backup-20250301-023000.tar.gz")
+}
+
+func (s *backupsSuite) TestRemoveError(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+	fake.Error = errors.NotFoundf("backup file %q", "/etc/hostname")
+
+	result := s.api.Remove(params.BackupsRemoveArgs{IDs: []string{"/etc/hostname"}})
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `backup file "/etc/hostname" not found`)
+	c.Check(result.Results[0].Error.Code, gc.Equals, params.CodeNotFound)
+}
+
+// schedulerAPI returns the API used by a controller agent to run
+// scheduled backups.
+func (s *backupsSuite) schedulerAPI(c *gc.C) *backupsAPI.API {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	s.authorizer.Controller = true
+	api, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *backupsSuite) scheduledArchives(c *gc.C) *backupstesting.FakeBackups {
+	fake := s.setBackups(c, nil, "")
+	fake.Archives = []backups.ArchiveInfo{{
+		Filename: "/var/lib/juju/backups/juju-backup-20250301-023000.tar.gz",
+		Notes:    "before upgrade",
+	}, {
+		Filename: "/var/lib/juju/backups/juju-backup-20250302-023000.tar.gz",
+		Notes:    params.BackupsScheduledNotes,
+	}}
+	return fake
+}
+
+func (s *backupsSuite) TestListScheduler(c *gc.C) {
+	s.scheduledArchives(c)
+
+	result, err := s.schedulerAPI(c).List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.List, gc.HasLen, 1)
+	c.Check(result.List[0].ID, gc.Equals, "/var/lib/juju/backups/juju-backup-20250302-023000.tar.gz")
+}
+
+func (s *backupsSuite) TestRemoveScheduler(c *gc.C) {
+	fake := s.scheduledArchives(c)
+
+	result := s.schedulerAPI(c).Remove(params.BackupsRemoveArgs{
+		IDs: []string{
+			"/var/lib/juju/backups/juju-backup-20250301-023000.tar.gz",
+			"/var/lib/juju/backups/juju-backup-20250302-023000.tar.gz",
+		},
+	})
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, "permission denied")
+	c.Check(result.Results[1].Error, gc.IsNil)
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List", "Remove"})
+	c.Check(fake.IDArg, gc.Equals, "/var/lib/juju/backups/juju-backup-20250302-023000.tar.gz")
+}
+
+func (s *backupsSuite) TestCreateSchedulerRequiresScheduledNotes(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+
+	_, err := s.schedulerAPI(c).Create(params.BackupsCreateArgs{Notes: "not scheduled"})
+	c.Check(err, gc.Equals, apiservererrors.ErrPerm)
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
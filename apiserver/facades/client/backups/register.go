@@ -14,8 +14,27 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("Backups", 3, func(ctx facade.Context) (facade.Facade, error) {
-		return newFacade(ctx)
-	}, reflect.TypeOf((*API)(nil)))
+		return newFacadeV3(ctx)
+	}, reflect.TypeOf((*APIv3)(nil)))
+	registry.MustRegister("Backups", 4, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacadeV4(ctx)
+	}, reflect.TypeOf((*APIv4)(nil)))
+}
+
+func newFacadeV3(ctx facade.Context) (*APIv3, error) {
+	api, err := newFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
+func newFacadeV4(ctx facade.Context) (*APIv4, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{api}, nil
 }
 
 // newFacade provides the required signature for facade registration.
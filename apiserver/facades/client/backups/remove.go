@@ -0,0 +1,47 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/collections/set"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Remove is not available in v3.
+func (*APIv3) Remove(_, _ struct{}) {}
+
+// Remove deletes the specified backup archives from the backup
+// directory of the controller machine serving the request. Controller
+// agents can only remove scheduled backups.
+func (a *API) Remove(args params.BackupsRemoveArgs) params.ErrorResults {
+	backupsMethods := newBackups(a.paths)
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.IDs)),
+	}
+	scheduled := set.NewStrings()
+	if a.scheduler {
+		archives, err := backupsMethods.List()
+		if err != nil {
+			for i := range results.Results {
+				results.Results[i].Error = apiservererrors.ServerError(err)
+			}
+			return results
+		}
+		for _, archive := range archives {
+			if archive.Notes == params.BackupsScheduledNotes {
+				scheduled.Add(archive.Filename)
+			}
+		}
+	}
+	for i, id := range args.IDs {
+		if a.scheduler && !scheduled.Contains(id) {
+			results.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		results.Results[i].Error = apiservererrors.ServerError(backupsMethods.Remove(id))
+	}
+	return results
+}
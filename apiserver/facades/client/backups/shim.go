@@ -4,6 +4,9 @@
 package backups
 
 import (
+	"context"
+	"io"
+
 	"github.com/juju/errors"
 	"github.com/juju/mgo/v3"
 	"github.com/juju/names/v5"
@@ -11,6 +14,7 @@ import (
 	corebase "github.com/juju/juju/core/base"
 	"github.com/juju/juju/core/instance"
 	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/internal/s3client"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
 )
@@ -102,3 +106,20 @@ type sessionShim struct {
 func (s sessionShim) DB(name string) backups.Database {
 	return s.Session.DB(name)
 }
+
+// objectStoreShim adapts an S3 client to the object store
+// expected by state/backups.
+type objectStoreShim struct {
+	s3client.Uploader
+}
+
+// PutObject implements backups.ObjectStore.
+func (s objectStoreShim) PutObject(
+	ctx context.Context, bucketName, objectName string, body io.Reader, metadata map[string]string,
+) (backups.UploadResult, error) {
+	result, err := s.Uploader.PutObject(ctx, bucketName, objectName, body, metadata)
+	if err != nil {
+		return backups.UploadResult{}, errors.Trace(err)
+	}
+	return backups.UploadResult{Size: result.Size, ETag: result.ETag}, nil
+}
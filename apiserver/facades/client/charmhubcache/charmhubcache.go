@@ -0,0 +1,99 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhubcache
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Cache describes the controller's Charmhub cache.
+type Cache interface {
+	Entries() ([]charmhub.CacheEntry, error)
+	Remove(key string) error
+}
+
+// API serves the CharmhubCache facade. Only controller superusers
+// may use it.
+type API struct {
+	cache Cache
+}
+
+// NewAPI returns a new CharmhubCache API facade.
+func NewAPI(authorizer facade.Authorizer, controllerTag names.ControllerTag, cache Cache) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	if err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{cache: cache}, nil
+}
+
+// Entries returns every entry in the cache, ordered by key.
+func (a *API) Entries() (params.CharmhubCacheEntries, error) {
+	entries, err := a.cache.Entries()
+	if err != nil {
+		return params.CharmhubCacheEntries{}, apiservererrors.ServerError(err)
+	}
+	return toParams(entries), nil
+}
+
+// Prune removes the entries matching the arguments from the cache,
+// and returns the entries removed.
+func (a *API) Prune(args params.CharmhubCachePruneArgs) (params.CharmhubCacheEntries, error) {
+	if !args.All && len(args.Keys) == 0 && args.Kind == "" && args.Name == "" && args.UnusedSince == nil {
+		return params.CharmhubCacheEntries{}, apiservererrors.ServerError(
+			errors.NotValidf("prune without criteria"))
+	}
+	entries, err := a.cache.Entries()
+	if err != nil {
+		return params.CharmhubCacheEntries{}, apiservererrors.ServerError(err)
+	}
+	keys := make(map[string]bool, len(args.Keys))
+	for _, key := range args.Keys {
+		keys[key] = true
+	}
+
+	var removed []charmhub.CacheEntry
+	for _, entry := range entries {
+		switch {
+		case len(keys) > 0 && !keys[entry.Key]:
+		case args.Kind != "" && string(entry.Kind) != args.Kind:
+		case args.Name != "" && entry.Name != args.Name:
+		case args.UnusedSince != nil && !entry.LastUsed.Before(*args.UnusedSince):
+		default:
+			if err := a.cache.Remove(entry.Key); err != nil {
+				return toParams(removed), apiservererrors.ServerError(err)
+			}
+			removed = append(removed, entry)
+		}
+	}
+	return toParams(removed), nil
+}
+
+func toParams(entries []charmhub.CacheEntry) params.CharmhubCacheEntries {
+	result := params.CharmhubCacheEntries{
+		Entries: make([]params.CharmhubCacheEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Entries[i] = params.CharmhubCacheEntry{
+			Key:      entry.Key,
+			Kind:     string(entry.Kind),
+			Name:     entry.Name,
+			Revision: entry.Revision,
+			Size:     entry.Size,
+			SHA256:   entry.SHA256,
+			Created:  entry.Created,
+			LastUsed: entry.LastUsed,
+		}
+	}
+	return result
+}
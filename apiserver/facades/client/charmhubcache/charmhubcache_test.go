@@ -0,0 +1,153 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhubcache_test
+
+import (
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/charmhubcache"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type charmhubCacheSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	cache      *fakeCache
+	now        time.Time
+}
+
+var _ = gc.Suite(&charmhubCacheSuite{})
+
+func (s *charmhubCacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	s.now = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.cache = &fakeCache{entries: []charmhub.CacheEntry{{
+		Key:      "get:/api/v1/charms/download/mysql-id_42.charm",
+		Kind:     charmhub.CacheEntryCharm,
+		Name:     "mysql-id_42.charm",
+		Size:     1024,
+		LastUsed: s.now.Add(-48 * time.Hour),
+	}, {
+		Key:      "refresh:mysql",
+		Kind:     charmhub.CacheEntryRefresh,
+		Name:     "mysql",
+		Revision: 42,
+		Size:     512,
+		LastUsed: s.now,
+	}, {
+		Key:      "refresh:postgresql",
+		Kind:     charmhub.CacheEntryRefresh,
+		Name:     "postgresql",
+		Revision: 7,
+		Size:     256,
+		LastUsed: s.now.Add(-48 * time.Hour),
+	}}}
+}
+
+func (s *charmhubCacheSuite) newAPI(c *gc.C) *charmhubcache.API {
+	api, err := charmhubcache.NewAPI(s.authorizer, coretesting.ControllerTag, s.cache)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func keys(entries params.CharmhubCacheEntries) []string {
+	var result []string
+	for _, entry := range entries.Entries {
+		result = append(result, entry.Key)
+	}
+	return result
+}
+
+func (s *charmhubCacheSuite) TestNonSuperuserDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := charmhubcache.NewAPI(s.authorizer, coretesting.ControllerTag, s.cache)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *charmhubCacheSuite) TestAgentDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := charmhubcache.NewAPI(s.authorizer, coretesting.ControllerTag, s.cache)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *charmhubCacheSuite) TestEntries(c *gc.C) {
+	result, err := s.newAPI(c).Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 3)
+	c.Check(result.Entries[1], jc.DeepEquals, params.CharmhubCacheEntry{
+		Key:      "refresh:mysql",
+		Kind:     "refresh",
+		Name:     "mysql",
+		Revision: 42,
+		Size:     512,
+		LastUsed: s.now,
+	})
+}
+
+func (s *charmhubCacheSuite) TestPruneRequiresCriteria(c *gc.C) {
+	_, err := s.newAPI(c).Prune(params.CharmhubCachePruneArgs{})
+	c.Assert(err, gc.ErrorMatches, "prune without criteria not valid")
+	c.Check(s.cache.removed, gc.HasLen, 0)
+}
+
+func (s *charmhubCacheSuite) TestPruneUnusedSince(c *gc.C) {
+	since := s.now.Add(-time.Hour)
+	result, err := s.newAPI(c).Prune(params.CharmhubCachePruneArgs{UnusedSince: &since})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(keys(result), jc.DeepEquals, []string{
+		"get:/api/v1/charms/download/mysql-id_42.charm",
+		"refresh:postgresql",
+	})
+	c.Check(s.cache.removed, jc.DeepEquals, keys(result))
+}
+
+func (s *charmhubCacheSuite) TestPruneCombinesCriteria(c *gc.C) {
+	since := s.now.Add(-time.Hour)
+	result, err := s.newAPI(c).Prune(params.CharmhubCachePruneArgs{
+		Kind:        "refresh",
+		UnusedSince: &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(keys(result), jc.DeepEquals, []string{"refresh:postgresql"})
+}
+
+func (s *charmhubCacheSuite) TestPruneByName(c *gc.C) {
+	result, err := s.newAPI(c).Prune(params.CharmhubCachePruneArgs{Name: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(keys(result), jc.DeepEquals, []string{"refresh:mysql"})
+}
+
+func (s *charmhubCacheSuite) TestPruneAll(c *gc.C) {
+	result, err := s.newAPI(c).Prune(params.CharmhubCachePruneArgs{All: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Entries, gc.HasLen, 3)
+	c.Check(s.cache.removed, gc.HasLen, 3)
+}
+
+type fakeCache struct {
+	entries []charmhub.CacheEntry
+	removed []string
+}
+
+func (f *fakeCache) Entries() ([]charmhub.CacheEntry, error) {
+	return f.entries, nil
+}
+
+func (f *fakeCache) Remove(key string) error {
+	f.removed = append(f.removed, key)
+	return nil
+}
@@ -0,0 +1,7 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmhubcache provides the server implementation for the
+// CharmhubCache facade, which lists and prunes the controller's cache
+// of Charmhub responses, charm archives and resources.
+package charmhubcache
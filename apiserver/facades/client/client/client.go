@@ -55,6 +55,11 @@ func (c *Client) state() *state.State {
 	return c.stateAccessor.(*stateShim).State
 }
 
+// ClientV8 serves the (v8) client-specific API methods.
+type ClientV8 struct {
+	*Client
+}
+
 // ClientV7 serves the (v7) client-specific API methods.
 type ClientV7 struct {
 	*Client
@@ -7,5 +7,5 @@ package client
 var (
 	MatchPortRanges = matchPortRanges
 	MatchSubnet     = matchSubnet
-	NewFacade       = newFacadeV8
+	NewFacade       = newFacadeV9
 )
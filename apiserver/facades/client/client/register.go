@@ -22,6 +22,9 @@ func Register(registry facade.FacadeRegistry) {
 	}, reflect.TypeOf((*ClientV7)(nil)))
 	registry.MustRegister("Client", 8, func(ctx facade.Context) (facade.Facade, error) {
 		return newFacadeV8(ctx)
+	}, reflect.TypeOf((*ClientV8)(nil)))
+	registry.MustRegister("Client", 9, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacadeV9(ctx)
 	}, reflect.TypeOf((*Client)(nil)))
 }
 
@@ -39,8 +42,19 @@ func newFacadeV7(ctx facade.Context) (*ClientV7, error) {
 	return NewFacadeV7(ctx)
 }
 
-// newFacadeV8 returns a new Client facade (v8).
-func newFacadeV8(ctx facade.Context) (*Client, error) {
+// newFacadeV8 returns a new ClientV8 facade.
+func newFacadeV8(ctx facade.Context) (*ClientV8, error) {
+	client, err := newFacadeV9(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientV8{client}, nil
+}
+
+// newFacadeV9 returns a new Client facade (v9).
+// Changes:
+// - FullStatus supports filtering by status predicates and selecting fields.
+func newFacadeV9(ctx facade.Context) (*Client, error) {
 	authorizer := ctx.Auth()
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
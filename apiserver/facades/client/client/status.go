@@ -217,6 +217,18 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 // FullStatus gives the information needed for juju status over the api
 func (c *ClientV6) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	args.IncludeStorage = false
+	return c.ClientV7.FullStatus(args)
+}
+
+// FullStatus gives the information needed for juju status over the api
+func (c *ClientV7) FullStatus(args params.StatusParams) (params.FullStatus, error) {
+	args.Where, args.Fields = nil, nil
+	return c.Client.FullStatus(args)
+}
+
+// FullStatus gives the information needed for juju status over the api
+func (c *ClientV8) FullStatus(args params.StatusParams) (params.FullStatus, error) {
+	args.Where, args.Fields = nil, nil
 	return c.Client.FullStatus(args)
 }
 
@@ -227,6 +239,15 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	}
 
 	var noStatus params.FullStatus
+	predicates, err := parseStatusPredicates(args.Where)
+	if err != nil {
+		return noStatus, errors.Trace(err)
+	}
+	fields, err := parseStatusFields(args.Fields)
+	if err != nil {
+		return noStatus, errors.Trace(err)
+	}
+
 	var context statusContext
 	context.cachedModel = c.modelCache
 
@@ -468,55 +489,19 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 			matchedUnits.Union(set.NewStrings(args.Patterns...)))
 
 		// Filter storage
-		matchedStorageTags := set.NewStrings()
-		matchedStorageInstances := []state.StorageInstance{}
-		for _, storageInstance := range context.storageInstances {
-			owner, ok := storageInstance.Owner()
-			if !ok {
-				continue
-			}
-			matched := false
-			switch tag := owner.(type) {
-			case names.UnitTag:
-				matched = matchedUnits.Contains(tag.Id())
-			case names.ApplicationTag:
-				matched = matchedApps.Contains(tag.Id())
-			}
-			if !matched {
-				continue
-			}
-			matchedStorageInstances = append(matchedStorageInstances, storageInstance)
-			matchedStorageTags.Add(storageInstance.StorageTag().Id())
+		if err := context.filterStorage(matchedApps, matchedUnits); err != nil {
+			return noStatus, errors.Trace(err)
 		}
-		context.storageInstances = matchedStorageInstances
+	}
 
-		matchedFilesystems := []state.Filesystem{}
-		for _, filesystem := range context.filesystems {
-			storageTag, err := filesystem.Storage()
-			if errors.Is(err, errors.NotAssigned) {
-				continue
-			} else if err != nil {
-				return noStatus, errors.Trace(err)
-			}
-			if matchedStorageTags.Contains(storageTag.Id()) {
-				matchedFilesystems = append(matchedFilesystems, filesystem)
-			}
+	if len(predicates) > 0 {
+		now := time.Now()
+		if context.controllerTimestamp != nil {
+			now = *context.controllerTimestamp
 		}
-		context.filesystems = matchedFilesystems
-
-		matchedVolumes := []state.Volume{}
-		for _, volume := range context.volumes {
-			storageTag, err := volume.StorageInstance()
-			if errors.Is(err, errors.NotAssigned) {
-				continue
-			} else if err != nil {
-				return noStatus, errors.Trace(err)
-			}
-			if matchedStorageTags.Contains(storageTag.Id()) {
-				matchedVolumes = append(matchedVolumes, volume)
-			}
+		if err := context.filterWhere(predicates, now); err != nil {
+			return noStatus, errors.Trace(err)
 		}
-		context.volumes = matchedVolumes
 	}
 
 	modelStatus, err := c.modelStatus()
@@ -527,7 +512,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	var storageDetails []params.StorageDetails
 	var filesystemDetails []params.FilesystemDetails
 	var volumeDetails []params.VolumeDetails
-	if args.IncludeStorage {
+	if args.IncludeStorage && fields.includes("storage") {
 		storageDetails, err = context.processStorage(c.storageAccessor)
 		if err != nil {
 			return noStatus, errors.Annotate(err, "cannot process storage instances")
@@ -542,19 +527,36 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		}
 	}
 
-	return params.FullStatus{
+	result := params.FullStatus{
 		Model:               modelStatus,
-		Machines:            context.processMachines(),
-		Applications:        context.processApplications(),
-		RemoteApplications:  context.processRemoteApplications(),
-		Offers:              context.processOffers(),
-		Relations:           context.processRelations(),
 		ControllerTimestamp: context.controllerTimestamp,
-		Branches:            context.processBranches(),
-		Storage:             storageDetails,
-		Filesystems:         filesystemDetails,
-		Volumes:             volumeDetails,
-	}, nil
+	}
+	if fields.includes("machines") {
+		result.Machines = context.processMachines()
+	}
+	if fields.includes("applications") || fields.includes("units") {
+		result.Applications = context.processApplications()
+	}
+	if fields.includes("remote-applications") {
+		result.RemoteApplications = context.processRemoteApplications()
+	}
+	if fields.includes("offers") {
+		result.Offers = context.processOffers()
+	}
+	if fields.includes("relations") {
+		result.Relations = context.processRelations()
+	}
+	if fields.includes("branches") {
+		result.Branches = context.processBranches()
+	}
+	if fields.includes("storage") {
+		result.Storage = storageDetails
+		result.Filesystems = filesystemDetails
+		result.Volumes = volumeDetails
+	}
+
+	fields.project(&result)
+	return result, nil
 }
 
 // resolveLeaderUnits resolves the passed in leader pattern to an existing application leader unit
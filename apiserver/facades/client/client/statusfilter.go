@@ -0,0 +1,404 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// The keys that can be used in a status predicate.
+const (
+	predicateWorkload = "workload"
+	predicateAgent    = "agent"
+	predicateSince    = "since"
+)
+
+// The sections of the status that can be selected with the status fields.
+var statusFieldNames = set.NewStrings(
+	"machines",
+	"applications",
+	"units",
+	"relations",
+	"remote-applications",
+	"offers",
+	"branches",
+	"storage",
+)
+
+// statusPredicate is a predicate on the status of a unit, such as
+// "workload=blocked", "agent!=idle|executing" or "since<10m".
+type statusPredicate struct {
+	key    string
+	op     string
+	values set.Strings
+	age    time.Duration
+}
+
+// parseStatusPredicates parses the predicates of a status "where" clause.
+func parseStatusPredicates(where []string) ([]statusPredicate, error) {
+	predicates := make([]statusPredicate, 0, len(where))
+	for _, clause := range where {
+		predicate, err := parseStatusPredicate(clause)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		predicates = append(predicates, predicate)
+	}
+	return predicates, nil
+}
+
+func parseStatusPredicate(clause string) (statusPredicate, error) {
+	index := strings.IndexAny(clause, "!=<>")
+	if index <= 0 {
+		return statusPredicate{}, errors.NotValidf("status predicate %q, expected <key><operator><value>", clause)
+	}
+	key := strings.TrimSpace(clause[:index])
+	op := clause[index : index+1]
+	if strings.HasPrefix(clause[index:], "!=") {
+		op = "!="
+	} else if op == "!" {
+		return statusPredicate{}, errors.NotValidf("operator in status predicate %q", clause)
+	}
+	value := strings.TrimSpace(clause[index+len(op):])
+	if value == "" {
+		return statusPredicate{}, errors.NotValidf("status predicate %q without a value", clause)
+	}
+
+	switch key {
+	case predicateWorkload, predicateAgent:
+		if op != "=" && op != "!=" {
+			return statusPredicate{}, errors.NotValidf("operator %q for %q, expected = or !=", op, key)
+		}
+		return statusPredicate{
+			key:    key,
+			op:     op,
+			values: set.NewStrings(strings.Split(value, "|")...),
+		}, nil
+	case predicateSince:
+		if op != "<" && op != ">" {
+			return statusPredicate{}, errors.NotValidf("operator %q for %q, expected < or >", op, key)
+		}
+		age, err := time.ParseDuration(value)
+		if err != nil {
+			return statusPredicate{}, errors.NotValidf("duration %q for %q", value, key)
+		}
+		return statusPredicate{
+			key: key,
+			op:  op,
+			age: age,
+		}, nil
+	}
+	return statusPredicate{}, errors.NotValidf("status predicate key %q, expected %s, %s or %s",
+		key, predicateWorkload, predicateAgent, predicateSince)
+}
+
+// matchUnit returns whether the status of the unit satisfies the predicate.
+// The age of the status is the time since the most recent change of either
+// the workload or the agent status.
+func (p statusPredicate) matchUnit(unit params.UnitStatus, now time.Time) bool {
+	switch p.key {
+	case predicateWorkload:
+		return p.values.Contains(unit.WorkloadStatus.Status) == (p.op == "=")
+	case predicateAgent:
+		return p.values.Contains(unit.AgentStatus.Status) == (p.op == "=")
+	case predicateSince:
+		var since *time.Time
+		for _, s := range []*time.Time{unit.WorkloadStatus.Since, unit.AgentStatus.Since} {
+			if s != nil && (since == nil || s.After(*since)) {
+				since = s
+			}
+		}
+		if since == nil {
+			return false
+		}
+		if p.op == "<" {
+			return now.Sub(*since) < p.age
+		}
+		return now.Sub(*since) > p.age
+	}
+	return false
+}
+
+func matchUnitPredicates(unit params.UnitStatus, predicates []statusPredicate, now time.Time) bool {
+	for _, predicate := range predicates {
+		if !predicate.matchUnit(unit, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// unitWhere holds what the status predicates look at for a unit.
+type unitWhere struct {
+	status       params.UnitStatus
+	principal    bool
+	subordinates []string
+}
+
+// selectUnitsWhere returns the names of the units that match all the
+// predicates. A principal unit that matches is selected with all its
+// subordinates. A principal unit that doesn't match is still selected if
+// any of its subordinates match, but only with the matching subordinates.
+func selectUnitsWhere(units map[string]unitWhere, predicates []statusPredicate, now time.Time) set.Strings {
+	selected := set.NewStrings()
+	for name, unit := range units {
+		if !unit.principal {
+			continue
+		}
+		if matchUnitPredicates(unit.status, predicates, now) {
+			selected.Add(name)
+			selected = selected.Union(set.NewStrings(unit.subordinates...))
+			continue
+		}
+		for _, subName := range unit.subordinates {
+			if sub, ok := units[subName]; ok && matchUnitPredicates(sub.status, predicates, now) {
+				selected.Add(name)
+				selected.Add(subName)
+			}
+		}
+	}
+	return selected
+}
+
+// hostsMachine returns whether the machine is one of the matched machines,
+// or a host of any of them.
+func hostsMachine(id string, matched set.Strings) bool {
+	if matched.Contains(id) {
+		return true
+	}
+	for _, matchedId := range matched.Values() {
+		if strings.HasPrefix(matchedId, id+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// filterWhere removes the units that don't match all the predicates from
+// the context, along with the applications, machines, relations, remote
+// applications, offers and storage that are no longer related to a
+// matching unit. It only looks at the cached unit status, so that the
+// status of everything that is filtered out is never built.
+func (context *statusContext) filterWhere(predicates []statusPredicate, now time.Time) error {
+	units := make(map[string]unitWhere)
+	for appName, unitMap := range context.allAppsUnitsCharmBindings.units {
+		expectWorkload := true
+		if app, ok := context.allAppsUnitsCharmBindings.applications[appName]; ok && app.IsPrincipal() {
+			var err error
+			expectWorkload, err = state.CheckApplicationExpectsWorkload(context.model, appName)
+			if err != nil {
+				return errors.Annotate(err, "could not filter units")
+			}
+		}
+		for name, unit := range unitMap {
+			var unitStatus params.UnitStatus
+			unitStatus.AgentStatus, unitStatus.WorkloadStatus = context.processUnitAndAgentStatus(unit, expectWorkload)
+			units[name] = unitWhere{
+				status:       unitStatus,
+				principal:    unit.IsPrincipal(),
+				subordinates: unit.SubordinateNames(),
+			}
+		}
+	}
+	selected := selectUnitsWhere(units, predicates, now)
+
+	// Filter units and applications
+	matchedApps := set.NewStrings()
+	matchedMachines := set.NewStrings()
+	for appName, unitMap := range context.allAppsUnitsCharmBindings.units {
+		for name, unit := range unitMap {
+			if !selected.Contains(name) {
+				delete(unitMap, name)
+				continue
+			}
+			matchedApps.Add(appName)
+			if machineId, err := unit.AssignedMachineId(); err == nil {
+				matchedMachines.Add(machineId)
+			}
+		}
+	}
+	for appName := range context.allAppsUnitsCharmBindings.applications {
+		if !matchedApps.Contains(appName) {
+			delete(context.allAppsUnitsCharmBindings.applications, appName)
+		}
+	}
+
+	// Filter machines
+	for id, machineList := range context.machines {
+		matched := make([]*state.Machine, 0, len(machineList))
+		for _, m := range machineList {
+			if hostsMachine(m.Id(), matchedMachines) {
+				matched = append(matched, m)
+			}
+		}
+		context.machines[id] = matched
+	}
+
+	// Filter relations
+	relatedApps := set.NewStrings()
+	for id, relation := range context.relationsById {
+		matched := false
+		for _, ep := range relation.Endpoints() {
+			matched = matched || matchedApps.Contains(ep.ApplicationName)
+		}
+		if !matched {
+			delete(context.relationsById, id)
+			continue
+		}
+		for _, ep := range relation.Endpoints() {
+			relatedApps.Add(ep.ApplicationName)
+		}
+	}
+	for appName, relations := range context.relations {
+		matched := make([]*state.Relation, 0, len(relations))
+		for _, r := range relations {
+			if _, ok := context.relationsById[r.Id()]; ok {
+				matched = append(matched, r)
+			}
+		}
+		if len(matched) == 0 {
+			delete(context.relations, appName)
+			continue
+		}
+		context.relations[appName] = matched
+	}
+
+	// Filter remote applications and offers
+	for appName := range context.consumerRemoteApplications {
+		if !relatedApps.Contains(appName) {
+			delete(context.consumerRemoteApplications, appName)
+		}
+	}
+	for offerName, offer := range context.offers {
+		if !matchedApps.Contains(offer.ApplicationName) {
+			delete(context.offers, offerName)
+		}
+	}
+
+	return errors.Trace(context.filterStorage(matchedApps, selected))
+}
+
+// filterStorage removes the storage that isn't owned by any of the matched
+// applications or units, along with its filesystems and volumes.
+func (context *statusContext) filterStorage(matchedApps, matchedUnits set.Strings) error {
+	matchedStorageTags := set.NewStrings()
+	matchedStorageInstances := []state.StorageInstance{}
+	for _, storageInstance := range context.storageInstances {
+		owner, ok := storageInstance.Owner()
+		if !ok {
+			continue
+		}
+		matched := false
+		switch tag := owner.(type) {
+		case names.UnitTag:
+			matched = matchedUnits.Contains(tag.Id())
+		case names.ApplicationTag:
+			matched = matchedApps.Contains(tag.Id())
+		}
+		if !matched {
+			continue
+		}
+		matchedStorageInstances = append(matchedStorageInstances, storageInstance)
+		matchedStorageTags.Add(storageInstance.StorageTag().Id())
+	}
+	context.storageInstances = matchedStorageInstances
+
+	matchedFilesystems := []state.Filesystem{}
+	for _, filesystem := range context.filesystems {
+		storageTag, err := filesystem.Storage()
+		if errors.Is(err, errors.NotAssigned) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if matchedStorageTags.Contains(storageTag.Id()) {
+			matchedFilesystems = append(matchedFilesystems, filesystem)
+		}
+	}
+	context.filesystems = matchedFilesystems
+
+	matchedVolumes := []state.Volume{}
+	for _, volume := range context.volumes {
+		storageTag, err := volume.StorageInstance()
+		if errors.Is(err, errors.NotAssigned) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if matchedStorageTags.Contains(storageTag.Id()) {
+			matchedVolumes = append(matchedVolumes, volume)
+		}
+	}
+	context.volumes = matchedVolumes
+	return nil
+}
+
+// statusFields holds the sections of the status that were requested. All
+// sections are included when it is empty.
+type statusFields set.Strings
+
+// parseStatusFields validates the requested sections of the status.
+func parseStatusFields(fields []string) (statusFields, error) {
+	result := set.NewStrings()
+	for _, field := range fields {
+		if !statusFieldNames.Contains(field) {
+			return nil, errors.NotValidf("status field %q, expected one of %s",
+				field, strings.Join(statusFieldNames.SortedValues(), ", "))
+		}
+		result.Add(field)
+	}
+	return statusFields(result), nil
+}
+
+// includes returns whether the section of the status was requested.
+func (f statusFields) includes(field string) bool {
+	return len(f) == 0 || set.Strings(f).Contains(field)
+}
+
+// project removes the details of applications and units which weren't
+// requested. When only units are requested, applications are reduced to
+// their units, and units to their status.
+func (f statusFields) project(status *params.FullStatus) {
+	switch {
+	case !f.includes("applications") && !f.includes("units"):
+		status.Applications = nil
+	case !f.includes("applications"):
+		for appName, app := range status.Applications {
+			status.Applications[appName] = params.ApplicationStatus{
+				Charm: app.Charm,
+				Units: projectUnits(app.Units),
+			}
+		}
+	case !f.includes("units"):
+		for appName, app := range status.Applications {
+			app.Units = nil
+			status.Applications[appName] = app
+		}
+	}
+}
+
+func projectUnits(units map[string]params.UnitStatus) map[string]params.UnitStatus {
+	if units == nil {
+		return nil
+	}
+	result := make(map[string]params.UnitStatus, len(units))
+	for name, unit := range units {
+		result[name] = params.UnitStatus{
+			AgentStatus:    unit.AgentStatus,
+			WorkloadStatus: unit.WorkloadStatus,
+			Machine:        unit.Machine,
+			Leader:         unit.Leader,
+			Subordinates:   projectUnits(unit.Subordinates),
+		}
+	}
+	return result
+}
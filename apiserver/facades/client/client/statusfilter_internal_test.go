@@ -0,0 +1,214 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+type statusFilterSuite struct{}
+
+var _ = gc.Suite(&statusFilterSuite{})
+
+var filterNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func ago(d time.Duration) *time.Time {
+	t := filterNow.Add(-d)
+	return &t
+}
+
+func (*statusFilterSuite) TestParseStatusPredicates(c *gc.C) {
+	predicates, err := parseStatusPredicates([]string{"workload=blocked|waiting", "agent!=idle", "since<10m"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(predicates, jc.DeepEquals, []statusPredicate{{
+		key:    "workload",
+		op:     "=",
+		values: set.NewStrings("blocked", "waiting"),
+	}, {
+		key:    "agent",
+		op:     "!=",
+		values: set.NewStrings("idle"),
+	}, {
+		key: "since",
+		op:  "<",
+		age: 10 * time.Minute,
+	}})
+}
+
+func (*statusFilterSuite) TestParseStatusPredicatesInvalid(c *gc.C) {
+	for _, test := range []struct {
+		where string
+		err   string
+	}{{
+		where: "blocked",
+		err:   `status predicate "blocked", expected <key><operator><value> not valid`,
+	}, {
+		where: "workload=",
+		err:   `status predicate "workload=" without a value not valid`,
+	}, {
+		where: "workload<blocked",
+		err:   `operator "<" for "workload", expected = or != not valid`,
+	}, {
+		where: "since=10m",
+		err:   `operator "=" for "since", expected < or > not valid`,
+	}, {
+		where: "since<ten",
+		err:   `duration "ten" for "since" not valid`,
+	}, {
+		where: "machine=started",
+		err:   `status predicate key "machine", expected workload, agent or since not valid`,
+	}} {
+		_, err := parseStatusPredicates([]string{test.where})
+		c.Check(err, gc.ErrorMatches, test.err, gc.Commentf("where %q", test.where))
+	}
+}
+
+func unitStatus(workload string, workloadSince *time.Time, agent string) params.UnitStatus {
+	return params.UnitStatus{
+		WorkloadStatus: params.DetailedStatus{Status: workload, Since: workloadSince},
+		AgentStatus:    params.DetailedStatus{Status: agent, Since: ago(time.Hour)},
+	}
+}
+
+func (*statusFilterSuite) TestSelectUnitsWhere(c *gc.C) {
+	predicates, err := parseStatusPredicates([]string{"workload=blocked", "since<10m"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	units := map[string]unitWhere{
+		"mysql/0": {
+			status:       unitStatus("active", ago(time.Hour), "idle"),
+			principal:    true,
+			subordinates: []string{"ntp/0"},
+		},
+		"wordpress/0": {
+			status:       unitStatus("blocked", ago(time.Minute), "idle"),
+			principal:    true,
+			subordinates: []string{"ntp/1"},
+		},
+		"wordpress/1": {
+			status:    unitStatus("blocked", ago(time.Hour), "idle"),
+			principal: true,
+		},
+		"ntp/0": {status: unitStatus("active", ago(time.Hour), "idle")},
+		"ntp/1": {status: unitStatus("active", ago(time.Hour), "idle")},
+	}
+	selected := selectUnitsWhere(units, predicates, filterNow)
+	c.Check(selected.SortedValues(), jc.DeepEquals, []string{"ntp/1", "wordpress/0"})
+}
+
+func (*statusFilterSuite) TestSelectUnitsWhereSubordinates(c *gc.C) {
+	predicates, err := parseStatusPredicates([]string{"agent=error"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	units := map[string]unitWhere{
+		"mysql/0": {
+			status:       unitStatus("active", ago(time.Hour), "idle"),
+			principal:    true,
+			subordinates: []string{"telegraf/0", "ntp/0"},
+		},
+		"mysql/1": {
+			status:       unitStatus("active", ago(time.Hour), "idle"),
+			principal:    true,
+			subordinates: []string{"telegraf/1"},
+		},
+		"telegraf/0": {status: unitStatus("active", ago(time.Hour), "error")},
+		"telegraf/1": {status: unitStatus("active", ago(time.Hour), "idle")},
+		"ntp/0":      {status: unitStatus("active", ago(time.Hour), "idle")},
+	}
+	selected := selectUnitsWhere(units, predicates, filterNow)
+	c.Check(selected.SortedValues(), jc.DeepEquals, []string{"mysql/0", "telegraf/0"})
+}
+
+func (*statusFilterSuite) TestHostsMachine(c *gc.C) {
+	matched := set.NewStrings("0", "1/lxd/1")
+	c.Check(hostsMachine("0", matched), jc.IsTrue)
+	c.Check(hostsMachine("1", matched), jc.IsTrue)
+	c.Check(hostsMachine("1/lxd/1", matched), jc.IsTrue)
+	c.Check(hostsMachine("1/lxd/0", matched), jc.IsFalse)
+	c.Check(hostsMachine("10", matched), jc.IsFalse)
+	c.Check(hostsMachine("2", matched), jc.IsFalse)
+}
+
+func (s *statusFilterSuite) TestParseStatusFields(c *gc.C) {
+	fields, err := parseStatusFields(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fields.includes("machines"), jc.IsTrue)
+
+	fields, err = parseStatusFields([]string{"units"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fields.includes("units"), jc.IsTrue)
+	c.Check(fields.includes("machines"), jc.IsFalse)
+
+	_, err = parseStatusFields([]string{"units", "charms"})
+	c.Assert(err, gc.ErrorMatches, `status field "charms", expected one of .* not valid`)
+}
+
+func (*statusFilterSuite) fullStatus() params.FullStatus {
+	return params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm: "ch:mysql",
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						Machine:        "0",
+						WorkloadStatus: params.DetailedStatus{Status: "active", Since: ago(time.Hour)},
+						AgentStatus:    params.DetailedStatus{Status: "idle", Since: ago(time.Hour)},
+						PublicAddress:  "10.0.0.1",
+					},
+				},
+			},
+			"wordpress": {
+				Charm: "ch:wordpress",
+				Units: map[string]params.UnitStatus{
+					"wordpress/0": {
+						Machine:        "1/lxd/1",
+						WorkloadStatus: params.DetailedStatus{Status: "blocked", Info: "no database", Since: ago(time.Minute)},
+						AgentStatus:    params.DetailedStatus{Status: "idle", Since: ago(time.Hour)},
+						PublicAddress:  "10.0.0.2",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *statusFilterSuite) TestProjectUnits(c *gc.C) {
+	fields, err := parseStatusFields([]string{"units"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	status := s.fullStatus()
+	fields.project(&status)
+
+	c.Check(status.Applications["wordpress"], jc.DeepEquals, params.ApplicationStatus{
+		Charm: "ch:wordpress",
+		Units: map[string]params.UnitStatus{
+			"wordpress/0": {
+				Machine:        "1/lxd/1",
+				WorkloadStatus: params.DetailedStatus{Status: "blocked", Info: "no database", Since: ago(time.Minute)},
+				AgentStatus:    params.DetailedStatus{Status: "idle", Since: ago(time.Hour)},
+			},
+		},
+	})
+}
+
+func (s *statusFilterSuite) TestProjectApplications(c *gc.C) {
+	fields, err := parseStatusFields([]string{"applications"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	status := s.fullStatus()
+	fields.project(&status)
+	c.Check(status.Applications["mysql"].Charm, gc.Equals, "ch:mysql")
+	c.Check(status.Applications["mysql"].Units, gc.IsNil)
+
+	fields, err = parseStatusFields([]string{"machines"})
+	c.Assert(err, jc.ErrorIsNil)
+	fields.project(&status)
+	c.Check(status.Applications, gc.IsNil)
+}
@@ -369,6 +369,37 @@ func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestControllerConfigRedactsSecretsForNonSuperUser(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"audit-log-forward-headers": "Authorization=Bearer token",
+		"backup-s3-endpoint":        "https://s3.example.com",
+		"backup-s3-bucket":          "backups",
+		"backup-s3-access-key":      "access",
+		"backup-s3-secret-key":      "secret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.LoginAccess,
+	})
+	endpoint, err := controller.NewControllerAPIv11(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := endpoint.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.Config["backup-s3-access-key"], gc.Equals, "access")
+	for _, key := range []string{"audit-log-forward-headers", "backup-s3-secret-key"} {
+		_, ok := cfg.Config[key]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", key))
+	}
+}
+
 func (s *controllerSuite) TestRemoveBlocks(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
@@ -0,0 +1,423 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretbackends
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/secrets/provider/juju"
+	"github.com/juju/juju/secrets/provider/kubernetes"
+	"github.com/juju/juju/state"
+	stateerrors "github.com/juju/juju/state/errors"
+)
+
+// defaultMigrateLimit is the number of secret revisions
+// migrated by a single MigrateSecrets call if no limit is given.
+const defaultMigrateLimit = 50
+
+// SecretBackendsAPIV1 is the version 1 SecretBackends facade,
+// which doesn't support migrating secrets between backends.
+type SecretBackendsAPIV1 struct {
+	*SecretBackendsAPI
+}
+
+// MigrateSecrets isn't on the v1 API.
+func (*SecretBackendsAPIV1) MigrateSecrets(_, _ struct{}) {}
+
+type successfulToken struct{}
+
+// Check implements lease.Token.
+func (t successfulToken) Check() error {
+	return nil
+}
+
+// revisionToMigrate identifies a secret revision whose content
+// is stored in the backend being migrated from.
+type revisionToMigrate struct {
+	modelUUID string
+	modelName string
+	uri       *secrets.URI
+	revision  int
+}
+
+func (r revisionToMigrate) key() string {
+	return fmt.Sprintf("%s/%s/%010d", r.modelUUID, r.uri.ID, r.revision)
+}
+
+// MigrateSecrets moves the content of secret revisions from one
+// backend to another. Each call migrates at most arg.Limit revisions,
+// starting after the arg.After cursor; callers repeat the call with
+// the returned Next cursor until there is none. Models and secrets
+// are listed in order, so each call only lists the revisions of the
+// secrets after the cursor, until it has found enough of them. Only
+// the first call, without a cursor, lists them all, to count how many
+// remain.
+//
+// Any model whose revisions are moved must already be configured to
+// use the target backend, otherwise the secret drain worker would
+// move the content straight back to the model's active backend.
+func (s *SecretBackendsAPI) MigrateSecrets(arg params.MigrateSecretsArgs) (params.MigrateSecretsResult, error) {
+	var result params.MigrateSecretsResult
+	if err := s.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if arg.FromBackend == "" || arg.ToBackend == "" {
+		return result, errors.NotValidf("missing backend name")
+	}
+	if arg.FromBackend == arg.ToBackend {
+		return result, errors.NotValidf("migrating secrets from backend %q to itself", arg.FromBackend)
+	}
+	modelUUIDs := []string{arg.ModelUUID}
+	if arg.ModelUUID == "" {
+		var err error
+		if modelUUIDs, err = s.statePool.AllModelUUIDs(); err != nil {
+			return result, errors.Trace(err)
+		}
+		sort.Strings(modelUUIDs)
+	}
+	limit := arg.Limit
+	if limit <= 0 {
+		limit = defaultMigrateLimit
+	}
+	// Find one more revision than the limit, to know whether there
+	// are more, unless they are all being counted.
+	max := limit + 1
+	if arg.After == "" {
+		max = 0
+	}
+	afterModel, _, _ := strings.Cut(arg.After, "/")
+
+	var pending []revisionToMigrate
+	for _, modelUUID := range modelUUIDs {
+		if modelUUID < afterModel {
+			continue
+		}
+		revs, err := s.revisionsToMigrate(modelUUID, arg.FromBackend, arg.ToBackend, arg.After, max-len(pending))
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		pending = append(pending, revs...)
+		if max > 0 && len(pending) >= max {
+			break
+		}
+	}
+	if len(pending) > limit {
+		if arg.After == "" {
+			result.Remaining = len(pending) - limit
+		}
+		result.Next = pending[limit-1].key()
+		pending = pending[:limit]
+	}
+
+	result.Results = make([]params.SecretRevisionMigrationResult, len(pending))
+	for i, rev := range pending {
+		migrated, err := s.migrateRevision(rev, arg.FromBackend, arg.ToBackend)
+		result.Results[i] = params.SecretRevisionMigrationResult{
+			ModelUUID: rev.modelUUID,
+			ModelName: rev.modelName,
+			URI:       rev.uri.String(),
+			Revision:  rev.revision,
+			Migrated:  migrated,
+			Error:     apiservererrors.ServerError(err),
+		}
+	}
+	return result, nil
+}
+
+// backendID returns the ID of the named backend as used by the model.
+func (s *SecretBackendsAPI) backendID(model SecretsModel, name string) (string, error) {
+	if name == juju.BackendName {
+		return s.controllerUUID, nil
+	}
+	if name == kubernetes.BuiltInName(model.Name()) {
+		return model.UUID(), nil
+	}
+	backend, err := s.backendState.GetSecretBackend(name)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return backend.ID, nil
+}
+
+// revisionsToMigrate returns, in order, the revisions in the model
+// after the cursor whose content is stored in the "from" backend. If
+// max is positive, at most max revisions are returned.
+func (s *SecretBackendsAPI) revisionsToMigrate(modelUUID, from, to, after string, max int) ([]revisionToMigrate, error) {
+	model, release, err := s.statePool.GetSecretsModel(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer release()
+
+	fromID, err := s.backendID(model, from)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secretsState := model.Secrets()
+	metadata, err := secretsState.ListSecrets(state.SecretsFilter{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].URI.ID < metadata[j].URI.ID
+	})
+	var result []revisionToMigrate
+	for _, md := range metadata {
+		if max > 0 && len(result) >= max {
+			break
+		}
+		// Skip the secrets before the cursor without listing
+		// their revisions.
+		if first := (revisionToMigrate{modelUUID: modelUUID, uri: md.URI}); first.key() < after &&
+			!strings.HasPrefix(after, modelUUID+"/"+md.URI.ID+"/") {
+			continue
+		}
+		revs, err := secretsState.ListSecretRevisions(md.URI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sort.Slice(revs, func(i, j int) bool {
+			return revs[i].Revision < revs[j].Revision
+		})
+		for _, rev := range revs {
+			if s.revisionBackendID(rev.ValueRef) != fromID {
+				continue
+			}
+			r := revisionToMigrate{
+				modelUUID: model.UUID(),
+				modelName: model.Name(),
+				uri:       md.URI,
+				revision:  rev.Revision,
+			}
+			if r.key() <= after {
+				continue
+			}
+			result = append(result, r)
+			if max > 0 && len(result) >= max {
+				break
+			}
+		}
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	toID, err := s.backendID(model, to)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfgInfo, err := model.BackendConfigInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfgInfo.ActiveID != toID {
+		return nil, errors.Errorf(
+			"model %q uses secret backend %q; change its secret-backend config to %q before migrating",
+			model.Name(), s.backendName(model, cfgInfo.ActiveID), to)
+	}
+	return result, nil
+}
+
+func (s *SecretBackendsAPI) revisionBackendID(ref *secrets.ValueRef) string {
+	if ref == nil {
+		return s.controllerUUID
+	}
+	return ref.BackendID
+}
+
+func (s *SecretBackendsAPI) backendName(model SecretsModel, id string) string {
+	switch id {
+	case s.controllerUUID:
+		return juju.BackendName
+	case model.UUID():
+		return kubernetes.BuiltInName(model.Name())
+	}
+	backend, err := s.backendState.GetSecretBackendByID(id)
+	if err != nil {
+		return id
+	}
+	return backend.Name
+}
+
+// migrateRevision moves the content of a single secret revision to the
+// "to" backend. The new copy is verified against the original before the
+// revision is updated to refer to it, and the original is only deleted
+// once the revision has been updated. It returns true if the revision
+// now refers to the new copy, even if deleting the original failed.
+func (s *SecretBackendsAPI) migrateRevision(rev revisionToMigrate, from, to string) (bool, error) {
+	model, release, err := s.statePool.GetSecretsModel(rev.modelUUID)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer release()
+
+	fromID, err := s.backendID(model, from)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	toID, err := s.backendID(model, to)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	cfgInfo, err := model.BackendConfigInfo()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	secretsState := model.Secrets()
+
+	value, oldRef, err := secretsState.GetSecretValue(rev.uri, rev.revision)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if s.revisionBackendID(oldRef) != fromID {
+		// The drain worker got here first and has already
+		// moved the content to the model's active backend.
+		return true, nil
+	}
+	var fromBackend provider.SecretsBackend
+	if oldRef != nil {
+		if fromBackend, err = backendFor(cfgInfo, oldRef.BackendID); err != nil {
+			return false, errors.Trace(err)
+		}
+		if value, err = fromBackend.GetContent(context.TODO(), oldRef.RevisionID); err != nil {
+			return false, errors.Annotatef(err, "reading content from %q", from)
+		}
+	}
+	checksum, err := value.Checksum()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+
+	// Admins aren't subject to the leadership of the secret owner;
+	// instead the change is conditional on the revision content not
+	// having been moved, by the drain worker or a new leader, since
+	// it was read above.
+	args := state.ChangeSecretBackendParams{
+		Token:         successfulToken{},
+		URI:           rev.uri,
+		Revision:      rev.revision,
+		CheckValueRef: true,
+		OldValueRef:   oldRef,
+	}
+	var toBackend provider.SecretsBackend
+	if toID == s.controllerUUID {
+		args.Data = value.EncodedValues()
+	} else {
+		if toBackend, err = backendFor(cfgInfo, toID); err != nil {
+			return false, errors.Trace(err)
+		}
+		revisionID, err := saveVerified(toBackend, rev, value, checksum)
+		if err != nil {
+			return false, errors.Annotatef(err, "saving content to %q", to)
+		}
+		args.ValueRef = &secrets.ValueRef{BackendID: toID, RevisionID: revisionID}
+	}
+
+	if err := secretsState.ChangeSecretBackend(args); err != nil {
+		if toBackend != nil {
+			_ = deleteContent(toBackend, args.ValueRef.RevisionID)
+		}
+		if !errors.Is(err, stateerrors.ErrSecretRevisionChanged) {
+			return false, errors.Trace(err)
+		}
+		md, mdErr := secretsState.GetSecretRevision(rev.uri, rev.revision)
+		if mdErr != nil {
+			return false, errors.Trace(mdErr)
+		}
+		if s.revisionBackendID(md.ValueRef) == fromID {
+			return false, errors.Trace(err)
+		}
+		return true, nil
+	}
+
+	if args.ValueRef == nil {
+		// Check what was written to the controller database before
+		// letting go of the original.
+		saved, _, err := secretsState.GetSecretValue(rev.uri, rev.revision)
+		if err == nil {
+			err = verifyChecksum(saved, checksum)
+		}
+		if err != nil {
+			_ = secretsState.ChangeSecretBackend(state.ChangeSecretBackendParams{
+				Token:         successfulToken{},
+				URI:           rev.uri,
+				Revision:      rev.revision,
+				ValueRef:      oldRef,
+				CheckValueRef: true,
+			})
+			return false, errors.Annotatef(err, "verifying content saved to %q", to)
+		}
+	}
+
+	if fromBackend != nil {
+		if err := deleteContent(fromBackend, oldRef.RevisionID); err != nil {
+			return true, errors.Annotatef(err, "deleting content from %q", from)
+		}
+	}
+	return true, nil
+}
+
+// saveVerified saves the value to the backend, reads it back and
+// checks it matches the checksum, returning the backend revision ID.
+func saveVerified(backend provider.SecretsBackend, rev revisionToMigrate, value secrets.SecretValue, checksum string) (string, error) {
+	revisionID, err := backend.SaveContent(context.TODO(), rev.uri, rev.revision, value)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	saved, err := backend.GetContent(context.TODO(), revisionID)
+	if err == nil {
+		err = verifyChecksum(saved, checksum)
+	}
+	if err != nil {
+		_ = deleteContent(backend, revisionID)
+		return "", errors.Trace(err)
+	}
+	return revisionID, nil
+}
+
+func verifyChecksum(value secrets.SecretValue, checksum string) error {
+	got, err := value.Checksum()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if got != checksum {
+		return errors.New("content checksum mismatch")
+	}
+	return nil
+}
+
+func deleteContent(backend provider.SecretsBackend, revisionID string) error {
+	if backend == nil {
+		return nil
+	}
+	err := backend.DeleteContent(context.TODO(), revisionID)
+	if errors.Is(err, errors.NotFound) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// backendFor returns a client for the specified backend
+// using the admin config from cfgInfo.
+func backendFor(cfgInfo *provider.ModelBackendConfigInfo, backendID string) (provider.SecretsBackend, error) {
+	cfg, ok := cfgInfo.Configs[backendID]
+	if !ok {
+		return nil, errors.NotFoundf("secret backend %q", backendID)
+	}
+	p, err := commonsecrets.GetProvider(cfg.BackendType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return p.NewBackend(&cfg)
+}
@@ -0,0 +1,368 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretbackends_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/authentication"
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	facademocks "github.com/juju/juju/apiserver/facade/mocks"
+	"github.com/juju/juju/apiserver/facades/client/secretbackends"
+	"github.com/juju/juju/apiserver/facades/client/secretbackends/mocks"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	stateerrors "github.com/juju/juju/state/errors"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type MigrateSuite struct {
+	testing.IsolationSuite
+
+	authorizer   *facademocks.MockAuthorizer
+	backendState *mocks.MockSecretsBackendState
+	statePool    *mocks.MockStatePool
+	model        *mocks.MockSecretsModel
+	modelSecrets *mocks.MockModelSecretsState
+	backend      *mocks.MockSecretsBackend
+
+	facade *secretbackends.SecretBackendsAPI
+}
+
+var _ = gc.Suite(&MigrateSuite{})
+
+const (
+	modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	vaultID   = "vault-id"
+)
+
+var controllerUUID = coretesting.ControllerTag.Id()
+
+func (s *MigrateSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+
+	s.authorizer = facademocks.NewMockAuthorizer(ctrl)
+	s.backendState = mocks.NewMockSecretsBackendState(ctrl)
+	s.statePool = mocks.NewMockStatePool(ctrl)
+	s.model = mocks.NewMockSecretsModel(ctrl)
+	s.modelSecrets = mocks.NewMockModelSecretsState(ctrl)
+	s.backend = mocks.NewMockSecretsBackend(ctrl)
+
+	s.authorizer.EXPECT().AuthClient().Return(true)
+	var err error
+	s.facade, err = secretbackends.NewTestAPI(
+		s.backendState, mocks.NewMockSecretsState(ctrl), s.statePool, s.authorizer, testclock.NewClock(time.Now()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	p := mocks.NewMockSecretBackendProvider(ctrl)
+	p.EXPECT().NewBackend(gomock.Any()).Return(s.backend, nil).AnyTimes()
+	s.PatchValue(&commonsecrets.GetProvider, func(string) (provider.SecretBackendProvider, error) {
+		return p, nil
+	})
+
+	s.model.EXPECT().UUID().Return(modelUUID).AnyTimes()
+	s.model.EXPECT().Name().Return("fred").AnyTimes()
+	s.model.EXPECT().Secrets().Return(s.modelSecrets).AnyTimes()
+	s.statePool.EXPECT().GetSecretsModel(modelUUID).Return(s.model, func() bool { return true }, nil).AnyTimes()
+	s.backendState.EXPECT().GetSecretBackend("myvault").Return(&secrets.SecretBackend{
+		ID:   vaultID,
+		Name: "myvault",
+	}, nil).AnyTimes()
+	return ctrl
+}
+
+func (s *MigrateSuite) expectAdmin() {
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
+}
+
+func (s *MigrateSuite) expectActiveBackend(activeID string) {
+	s.model.EXPECT().BackendConfigInfo().Return(&provider.ModelBackendConfigInfo{
+		ActiveID: activeID,
+		Configs: map[string]provider.ModelBackendConfig{
+			controllerUUID: {BackendConfig: provider.BackendConfig{BackendType: "controller"}},
+			vaultID:        {BackendConfig: provider.BackendConfig{BackendType: "vault"}},
+		},
+	}, nil).AnyTimes()
+}
+
+func (s *MigrateSuite) expectRevisions(uri *secrets.URI, refs ...*secrets.ValueRef) {
+	s.modelSecrets.EXPECT().ListSecrets(state.SecretsFilter{}).Return([]*secrets.SecretMetadata{{URI: uri}}, nil)
+	var revs []*secrets.SecretRevisionMetadata
+	for i, ref := range refs {
+		revs = append(revs, &secrets.SecretRevisionMetadata{Revision: i + 1, ValueRef: ref})
+	}
+	s.modelSecrets.EXPECT().ListSecretRevisions(uri).Return(revs, nil)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsPermissionDenied(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(
+		errors.WithType(apiservererrors.ErrPerm, authentication.ErrorEntityMissingPermission))
+
+	_, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{FromBackend: "internal", ToBackend: "myvault"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MigrateSuite) TestMigrateSecretsSameBackend(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+
+	_, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{FromBackend: "myvault", ToBackend: "myvault"})
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsInactiveTarget(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+	s.expectActiveBackend(controllerUUID)
+
+	uri := secrets.NewURI()
+	s.expectRevisions(uri, nil)
+
+	_, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		FromBackend: "internal", ToBackend: "myvault", ModelUUID: modelUUID,
+	})
+	c.Assert(err, gc.ErrorMatches,
+		`model "fred" uses secret backend "internal"; change its secret-backend config to "myvault" before migrating`)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsAllModels(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+	s.expectActiveBackend(vaultID)
+
+	s.statePool.EXPECT().AllModelUUIDs().Return([]string{modelUUID}, nil)
+	uri := secrets.NewURI()
+	s.expectRevisions(uri, &secrets.ValueRef{BackendID: vaultID, RevisionID: "rev-1"})
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{FromBackend: "internal", ToBackend: "myvault"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MigrateSecretsResult{
+		Results: []params.SecretRevisionMigrationResult{},
+	})
+}
+
+func (s *MigrateSuite) TestMigrateSecretsInternalToExternal(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+	s.expectActiveBackend(vaultID)
+
+	uri := secrets.NewURI()
+	s.expectRevisions(uri, nil)
+	value := secrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	s.modelSecrets.EXPECT().GetSecretValue(uri, 1).Return(value, nil, nil)
+	s.backend.EXPECT().SaveContent(gomock.Any(), uri, 1, value).Return("rev-id", nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "rev-id").Return(value, nil)
+	s.modelSecrets.EXPECT().ChangeSecretBackend(gomock.Any()).DoAndReturn(func(arg state.ChangeSecretBackendParams) error {
+		c.Assert(arg.URI, jc.DeepEquals, uri)
+		c.Assert(arg.Revision, gc.Equals, 1)
+		c.Assert(arg.ValueRef, jc.DeepEquals, &secrets.ValueRef{BackendID: vaultID, RevisionID: "rev-id"})
+		c.Assert(arg.Data, gc.IsNil)
+		c.Assert(arg.CheckValueRef, jc.IsTrue)
+		c.Assert(arg.OldValueRef, gc.IsNil)
+		return nil
+	})
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		FromBackend: "internal", ToBackend: "myvault", ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MigrateSecretsResult{
+		Results: []params.SecretRevisionMigrationResult{{
+			ModelUUID: modelUUID,
+			ModelName: "fred",
+			URI:       uri.String(),
+			Revision:  1,
+			Migrated:  true,
+		}},
+	})
+}
+
+func (s *MigrateSuite) TestMigrateSecretsExternalToInternal(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+	s.expectActiveBackend(controllerUUID)
+
+	uri := secrets.NewURI()
+	ref := &secrets.ValueRef{BackendID: vaultID, RevisionID: "rev-id"}
+	s.expectRevisions(uri, ref)
+	value := secrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	s.modelSecrets.EXPECT().GetSecretValue(uri, 1).Return(nil, ref, nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "rev-id").Return(value, nil)
+	s.modelSecrets.EXPECT().ChangeSecretBackend(gomock.Any()).DoAndReturn(func(arg state.ChangeSecretBackendParams) error {
+		c.Assert(arg.ValueRef, gc.IsNil)
+		c.Assert(arg.Data, jc.DeepEquals, secrets.SecretData{"foo": "YmFy"})
+		c.Assert(arg.CheckValueRef, jc.IsTrue)
+		c.Assert(arg.OldValueRef, jc.DeepEquals, ref)
+		return nil
+	})
+	s.modelSecrets.EXPECT().GetSecretValue(uri, 1).Return(value, nil, nil)
+	s.backend.EXPECT().DeleteContent(gomock.Any(), "rev-id").Return(nil)
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		FromBackend: "myvault", ToBackend: "internal", ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Migrated, jc.IsTrue)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsChecksumMismatch(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+	s.expectActiveBackend(vaultID)
+
+	uri := secrets.NewURI()
+	s.expectRevisions(uri, nil)
+	value := secrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	s.modelSecrets.EXPECT().GetSecretValue(uri, 1).Return(value, nil, nil)
+	s.backend.EXPECT().SaveContent(gomock.Any(), uri, 1, value).Return("rev-id", nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "rev-id").Return(
+		secrets.NewSecretValue(map[string]string{"foo": "YmF6"}), nil)
+	s.backend.EXPECT().DeleteContent(gomock.Any(), "rev-id").Return(nil)
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		FromBackend: "internal", ToBackend: "myvault", ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Migrated, jc.IsFalse)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `saving content to "myvault": content checksum mismatch`)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsDeleteFailed(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+	s.expectActiveBackend(controllerUUID)
+
+	uri := secrets.NewURI()
+	ref := &secrets.ValueRef{BackendID: vaultID, RevisionID: "rev-id"}
+	s.expectRevisions(uri, ref)
+	value := secrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	s.modelSecrets.EXPECT().GetSecretValue(uri, 1).Return(nil, ref, nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "rev-id").Return(value, nil)
+	s.modelSecrets.EXPECT().ChangeSecretBackend(gomock.Any()).Return(nil)
+	s.modelSecrets.EXPECT().GetSecretValue(uri, 1).Return(value, nil, nil)
+	s.backend.EXPECT().DeleteContent(gomock.Any(), "rev-id").Return(errors.New("boom"))
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		FromBackend: "myvault", ToBackend: "internal", ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Migrated, jc.IsTrue)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `deleting content from "myvault": boom`)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsDrained(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+	s.expectActiveBackend(vaultID)
+
+	uri := secrets.NewURI()
+	s.expectRevisions(uri, nil)
+	s.modelSecrets.EXPECT().GetSecretValue(uri, 1).Return(
+		nil, &secrets.ValueRef{BackendID: vaultID, RevisionID: "rev-id"}, nil)
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		FromBackend: "internal", ToBackend: "myvault", ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Migrated, jc.IsTrue)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsRevisionChanged(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+	s.expectActiveBackend(vaultID)
+
+	uri := secrets.NewURI()
+	s.expectRevisions(uri, nil)
+	value := secrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	s.modelSecrets.EXPECT().GetSecretValue(uri, 1).Return(value, nil, nil)
+	s.backend.EXPECT().SaveContent(gomock.Any(), uri, 1, value).Return("rev-id", nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "rev-id").Return(value, nil)
+	s.modelSecrets.EXPECT().ChangeSecretBackend(gomock.Any()).Return(stateerrors.ErrSecretRevisionChanged)
+	s.backend.EXPECT().DeleteContent(gomock.Any(), "rev-id").Return(nil)
+	s.modelSecrets.EXPECT().GetSecretRevision(uri, 1).Return(&secrets.SecretRevisionMetadata{
+		Revision: 1,
+		ValueRef: &secrets.ValueRef{BackendID: vaultID, RevisionID: "drained-rev-id"},
+	}, nil)
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		FromBackend: "internal", ToBackend: "myvault", ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Migrated, jc.IsTrue)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsFirstBatch(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+	s.expectActiveBackend(vaultID)
+
+	uri := secrets.NewURI()
+	s.expectRevisions(uri, nil, nil, nil)
+	s.modelSecrets.EXPECT().GetSecretValue(uri, 1).Return(
+		nil, &secrets.ValueRef{BackendID: vaultID, RevisionID: "rev-id"}, nil)
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		FromBackend: "internal",
+		ToBackend:   "myvault",
+		ModelUUID:   modelUUID,
+		Limit:       1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Remaining, gc.Equals, 2)
+	c.Assert(result.Next, gc.Equals, modelUUID+"/"+uri.ID+"/0000000001")
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Revision, gc.Equals, 1)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsBatches(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAdmin()
+	s.expectActiveBackend(vaultID)
+
+	// The revisions of the secret before the cursor aren't listed.
+	done := &secrets.URI{ID: "aaaaaaaaaaaaaaaaaaaa"}
+	uri := &secrets.URI{ID: "bbbbbbbbbbbbbbbbbbbb"}
+	s.modelSecrets.EXPECT().ListSecrets(state.SecretsFilter{}).Return(
+		[]*secrets.SecretMetadata{{URI: uri}, {URI: done}}, nil)
+	s.modelSecrets.EXPECT().ListSecretRevisions(uri).Return([]*secrets.SecretRevisionMetadata{
+		{Revision: 1}, {Revision: 2}, {Revision: 3}, {Revision: 4},
+	}, nil)
+	s.modelSecrets.EXPECT().GetSecretValue(uri, 2).Return(
+		nil, &secrets.ValueRef{BackendID: vaultID, RevisionID: "rev-id"}, nil)
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		FromBackend: "internal",
+		ToBackend:   "myvault",
+		ModelUUID:   modelUUID,
+		After:       modelUUID + "/" + uri.ID + "/0000000001",
+		Limit:       1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Remaining, gc.Equals, 0)
+	c.Assert(result.Next, gc.Equals, modelUUID+"/"+uri.ID+"/0000000002")
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Revision, gc.Equals, 2)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/apiserver/facades/client/secretbackends (interfaces: StatePool)
+// Source: github.com/juju/juju/apiserver/facades/client/secretbackends (interfaces: StatePool,SecretsModel,ModelSecretsState)
 //
 // Generated by this command:
 //
-//	mockgen -package mocks -destination mocks/state.go github.com/juju/juju/apiserver/facades/client/secretbackends StatePool
+//	mockgen -package mocks -destination mocks/state.go github.com/juju/juju/apiserver/facades/client/secretbackends StatePool,SecretsModel,ModelSecretsState
 //
 
 // Package mocks is a generated GoMock package.
@@ -13,6 +13,10 @@ import (
 	reflect "reflect"
 
 	common "github.com/juju/juju/apiserver/common"
+	secretbackends "github.com/juju/juju/apiserver/facades/client/secretbackends"
+	secrets "github.com/juju/juju/core/secrets"
+	provider "github.com/juju/juju/secrets/provider"
+	state "github.com/juju/juju/state"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,6 +43,21 @@ func (m *MockStatePool) EXPECT() *MockStatePoolMockRecorder {
 	return m.recorder
 }
 
+// AllModelUUIDs mocks base method.
+func (m *MockStatePool) AllModelUUIDs() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllModelUUIDs")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllModelUUIDs indicates an expected call of AllModelUUIDs.
+func (mr *MockStatePoolMockRecorder) AllModelUUIDs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllModelUUIDs", reflect.TypeOf((*MockStatePool)(nil).AllModelUUIDs))
+}
+
 // GetModel mocks base method.
 func (m *MockStatePool) GetModel(arg0 string) (common.Model, func() bool, error) {
 	m.ctrl.T.Helper()
@@ -54,3 +73,197 @@ func (mr *MockStatePoolMockRecorder) GetModel(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModel", reflect.TypeOf((*MockStatePool)(nil).GetModel), arg0)
 }
+
+// GetSecretsModel mocks base method.
+func (m *MockStatePool) GetSecretsModel(arg0 string) (secretbackends.SecretsModel, func() bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretsModel", arg0)
+	ret0, _ := ret[0].(secretbackends.SecretsModel)
+	ret1, _ := ret[1].(func() bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSecretsModel indicates an expected call of GetSecretsModel.
+func (mr *MockStatePoolMockRecorder) GetSecretsModel(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretsModel", reflect.TypeOf((*MockStatePool)(nil).GetSecretsModel), arg0)
+}
+
+// MockSecretsModel is a mock of SecretsModel interface.
+type MockSecretsModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsModelMockRecorder
+}
+
+// MockSecretsModelMockRecorder is the mock recorder for MockSecretsModel.
+type MockSecretsModelMockRecorder struct {
+	mock *MockSecretsModel
+}
+
+// NewMockSecretsModel creates a new mock instance.
+func NewMockSecretsModel(ctrl *gomock.Controller) *MockSecretsModel {
+	mock := &MockSecretsModel{ctrl: ctrl}
+	mock.recorder = &MockSecretsModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretsModel) EXPECT() *MockSecretsModelMockRecorder {
+	return m.recorder
+}
+
+// BackendConfigInfo mocks base method.
+func (m *MockSecretsModel) BackendConfigInfo() (*provider.ModelBackendConfigInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackendConfigInfo")
+	ret0, _ := ret[0].(*provider.ModelBackendConfigInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackendConfigInfo indicates an expected call of BackendConfigInfo.
+func (mr *MockSecretsModelMockRecorder) BackendConfigInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackendConfigInfo", reflect.TypeOf((*MockSecretsModel)(nil).BackendConfigInfo))
+}
+
+// Name mocks base method.
+func (m *MockSecretsModel) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockSecretsModelMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockSecretsModel)(nil).Name))
+}
+
+// Secrets mocks base method.
+func (m *MockSecretsModel) Secrets() secretbackends.ModelSecretsState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Secrets")
+	ret0, _ := ret[0].(secretbackends.ModelSecretsState)
+	return ret0
+}
+
+// Secrets indicates an expected call of Secrets.
+func (mr *MockSecretsModelMockRecorder) Secrets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Secrets", reflect.TypeOf((*MockSecretsModel)(nil).Secrets))
+}
+
+// UUID mocks base method.
+func (m *MockSecretsModel) UUID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UUID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UUID indicates an expected call of UUID.
+func (mr *MockSecretsModelMockRecorder) UUID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UUID", reflect.TypeOf((*MockSecretsModel)(nil).UUID))
+}
+
+// MockModelSecretsState is a mock of ModelSecretsState interface.
+type MockModelSecretsState struct {
+	ctrl     *gomock.Controller
+	recorder *MockModelSecretsStateMockRecorder
+}
+
+// MockModelSecretsStateMockRecorder is the mock recorder for MockModelSecretsState.
+type MockModelSecretsStateMockRecorder struct {
+	mock *MockModelSecretsState
+}
+
+// NewMockModelSecretsState creates a new mock instance.
+func NewMockModelSecretsState(ctrl *gomock.Controller) *MockModelSecretsState {
+	mock := &MockModelSecretsState{ctrl: ctrl}
+	mock.recorder = &MockModelSecretsStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModelSecretsState) EXPECT() *MockModelSecretsStateMockRecorder {
+	return m.recorder
+}
+
+// ChangeSecretBackend mocks base method.
+func (m *MockModelSecretsState) ChangeSecretBackend(arg0 state.ChangeSecretBackendParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeSecretBackend", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeSecretBackend indicates an expected call of ChangeSecretBackend.
+func (mr *MockModelSecretsStateMockRecorder) ChangeSecretBackend(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeSecretBackend", reflect.TypeOf((*MockModelSecretsState)(nil).ChangeSecretBackend), arg0)
+}
+
+// GetSecretRevision mocks base method.
+func (m *MockModelSecretsState) GetSecretRevision(arg0 *secrets.URI, arg1 int) (*secrets.SecretRevisionMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretRevision", arg0, arg1)
+	ret0, _ := ret[0].(*secrets.SecretRevisionMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretRevision indicates an expected call of GetSecretRevision.
+func (mr *MockModelSecretsStateMockRecorder) GetSecretRevision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretRevision", reflect.TypeOf((*MockModelSecretsState)(nil).GetSecretRevision), arg0, arg1)
+}
+
+// GetSecretValue mocks base method.
+func (m *MockModelSecretsState) GetSecretValue(arg0 *secrets.URI, arg1 int) (secrets.SecretValue, *secrets.ValueRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretValue", arg0, arg1)
+	ret0, _ := ret[0].(secrets.SecretValue)
+	ret1, _ := ret[1].(*secrets.ValueRef)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSecretValue indicates an expected call of GetSecretValue.
+func (mr *MockModelSecretsStateMockRecorder) GetSecretValue(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValue", reflect.TypeOf((*MockModelSecretsState)(nil).GetSecretValue), arg0, arg1)
+}
+
+// ListSecretRevisions mocks base method.
+func (m *MockModelSecretsState) ListSecretRevisions(arg0 *secrets.URI) ([]*secrets.SecretRevisionMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretRevisions", arg0)
+	ret0, _ := ret[0].([]*secrets.SecretRevisionMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretRevisions indicates an expected call of ListSecretRevisions.
+func (mr *MockModelSecretsStateMockRecorder) ListSecretRevisions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretRevisions", reflect.TypeOf((*MockModelSecretsState)(nil).ListSecretRevisions), arg0)
+}
+
+// ListSecrets mocks base method.
+func (m *MockModelSecretsState) ListSecrets(arg0 state.SecretsFilter) ([]*secrets.SecretMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", arg0)
+	ret0, _ := ret[0].([]*secrets.SecretMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets.
+func (mr *MockModelSecretsStateMockRecorder) ListSecrets(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockModelSecretsState)(nil).ListSecrets), arg0)
+}
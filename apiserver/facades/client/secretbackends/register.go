@@ -16,10 +16,21 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("SecretBackends", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return newSecretBackendsAPIV1(ctx)
+	}, reflect.TypeOf((*SecretBackendsAPIV1)(nil)))
+	registry.MustRegister("SecretBackends", 2, func(ctx facade.Context) (facade.Facade, error) {
 		return newSecretBackendsAPI(ctx)
 	}, reflect.TypeOf((*SecretBackendsAPI)(nil)))
 }
 
+func newSecretBackendsAPIV1(context facade.Context) (*SecretBackendsAPIV1, error) {
+	api, err := newSecretBackendsAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretBackendsAPIV1{api}, nil
+}
+
 // newSecretBackendsAPI creates a SecretBackendsAPI.
 func newSecretBackendsAPI(context facade.Context) (*SecretBackendsAPI, error) {
 	if !context.Auth().AuthClient() {
@@ -8,7 +8,9 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/common"
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
 	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
 	"github.com/juju/juju/state"
 )
 
@@ -28,6 +30,27 @@ type SecretsState interface {
 
 type StatePool interface {
 	GetModel(modelUUID string) (common.Model, func() bool, error)
+	GetSecretsModel(modelUUID string) (SecretsModel, func() bool, error)
+	AllModelUUIDs() ([]string, error)
+}
+
+// SecretsModel provides access to the secrets of a model.
+type SecretsModel interface {
+	UUID() string
+	Name() string
+	// BackendConfigInfo returns the admin config for the secret
+	// backends used by the model.
+	BackendConfigInfo() (*provider.ModelBackendConfigInfo, error)
+	Secrets() ModelSecretsState
+}
+
+// ModelSecretsState is used to access the secrets of a model.
+type ModelSecretsState interface {
+	ListSecrets(state.SecretsFilter) ([]*secrets.SecretMetadata, error)
+	ListSecretRevisions(uri *secrets.URI) ([]*secrets.SecretRevisionMetadata, error)
+	GetSecretRevision(uri *secrets.URI, revision int) (*secrets.SecretRevisionMetadata, error)
+	GetSecretValue(*secrets.URI, int) (secrets.SecretValue, *secrets.ValueRef, error)
+	ChangeSecretBackend(state.ChangeSecretBackendParams) error
 }
 
 type statePoolShim struct {
@@ -41,3 +64,31 @@ func (s *statePoolShim) GetModel(modelUUID string) (common.Model, func() bool, e
 	}
 	return m, hp.Release, nil
 }
+
+func (s *statePoolShim) GetSecretsModel(modelUUID string) (SecretsModel, func() bool, error) {
+	m, hp, err := s.pool.GetModel(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return &secretsModelShim{commonsecrets.SecretsModel(m)}, hp.Release, nil
+}
+
+func (s *statePoolShim) AllModelUUIDs() ([]string, error) {
+	st, err := s.pool.SystemState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.AllModelUUIDs()
+}
+
+type secretsModelShim struct {
+	commonsecrets.Model
+}
+
+func (m *secretsModelShim) BackendConfigInfo() (*provider.ModelBackendConfigInfo, error) {
+	return commonsecrets.AdminBackendConfigInfo(m.Model)
+}
+
+func (m *secretsModelShim) Secrets() ModelSecretsState {
+	return state.NewSecrets(m.State())
+}
@@ -47,6 +47,11 @@ type SecretsAPI struct {
 
 // SecretsAPIV1 is the backend for the Secrets facade v1.
 type SecretsAPIV1 struct {
+	*SecretsAPIV2
+}
+
+// SecretsAPIV2 is the backend for the Secrets facade v2.
+type SecretsAPIV2 struct {
 	*SecretsAPI
 }
 
@@ -81,24 +86,9 @@ func (s *SecretsAPI) ListSecrets(arg params.ListSecretsArgs) (params.ListSecretR
 			return result, errors.Trace(err)
 		}
 	}
-	var uri *coresecrets.URI
-	if arg.Filter.URI != nil {
-		var err error
-		uri, err = coresecrets.ParseURI(*arg.Filter.URI)
-		if err != nil {
-			return params.ListSecretResults{}, errors.Trace(err)
-		}
-	}
-	filter := state.SecretsFilter{
-		URI:   uri,
-		Label: arg.Filter.Label,
-	}
-	if arg.Filter.OwnerTag != nil {
-		tag, err := names.ParseTag(*arg.Filter.OwnerTag)
-		if err != nil {
-			return params.ListSecretResults{}, errors.Trace(err)
-		}
-		filter.OwnerTags = []names.Tag{tag}
+	filter, err := toStateFilter(arg.Filter)
+	if err != nil {
+		return params.ListSecretResults{}, errors.Trace(err)
 	}
 	metadata, err := s.secretsState.ListSecrets(filter)
 	if err != nil {
@@ -185,6 +175,46 @@ func (s *SecretsAPI) ListSecrets(arg params.ListSecretsArgs) (params.ListSecretR
 	return result, nil
 }
 
+func toStateFilter(arg params.SecretsFilter) (state.SecretsFilter, error) {
+	var uri *coresecrets.URI
+	if arg.URI != nil {
+		var err error
+		uri, err = coresecrets.ParseURI(*arg.URI)
+		if err != nil {
+			return state.SecretsFilter{}, errors.Trace(err)
+		}
+	}
+	filter := state.SecretsFilter{
+		URI:   uri,
+		Label: arg.Label,
+	}
+	if arg.OwnerTag != nil {
+		tag, err := names.ParseTag(*arg.OwnerTag)
+		if err != nil {
+			return state.SecretsFilter{}, errors.Trace(err)
+		}
+		filter.OwnerTags = []names.Tag{tag}
+	}
+	return filter, nil
+}
+
+// CheckSecretContentDrift isn't on the v2 API.
+func (s *SecretsAPIV2) CheckSecretContentDrift(_ struct{}) {}
+
+// CheckSecretContentDrift reads back the content of secret revisions
+// stored in external backends and reports any which are missing or
+// have been modified outside of Juju.
+func (s *SecretsAPI) CheckSecretContentDrift(arg params.CheckSecretContentDriftArgs) (params.SecretContentDriftResults, error) {
+	if err := s.checkCanAdmin(); err != nil {
+		return params.SecretContentDriftResults{}, errors.Trace(err)
+	}
+	filter, err := toStateFilter(arg.Filter)
+	if err != nil {
+		return params.SecretContentDriftResults{}, errors.Trace(err)
+	}
+	return commonsecrets.CheckContentDrift(s.secretsState, s.adminBackendConfigGetter, filter)
+}
+
 func (s *SecretsAPI) getBackendInfo() error {
 	info, err := s.adminBackendConfigGetter()
 	if err != nil {
@@ -962,3 +962,57 @@ func (s *SecretsSuite) TestRevokeSecretPermissionDenied(c *gc.C) {
 	_, err = facade.RevokeSecret(params.GrantRevokeUserSecretArg{Label: "my-secret"})
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
+
+func (s *SecretsSuite) TestCheckSecretContentDrift(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthClient()
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
+
+	uri := coresecrets.NewURI()
+	s.secretsState.EXPECT().ListSecrets(state.SecretsFilter{
+		OwnerTags: []names.Tag{coretesting.ModelTag},
+	}).Return([]*coresecrets.SecretMetadata{{URI: uri}}, nil)
+	s.secretsState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{{
+		Revision:    1,
+		ValueRef:    &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "rev-id"},
+		BackendName: ptr("myvault"),
+		Checksum:    "deadbeef",
+	}}, nil)
+	s.provider.EXPECT().NewBackend(gomock.Any()).Return(s.backend, nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "rev-id").Return(nil, errors.NotFoundf("rev-id"))
+
+	facade, err := apisecrets.NewTestAPI(s.authTag, s.authorizer, s.secretsState, s.secretConsumer,
+		adminBackendConfigGetter, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.CheckSecretContentDrift(params.CheckSecretContentDriftArgs{
+		Filter: params.SecretsFilter{OwnerTag: ptr(coretesting.ModelTag.String())},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretContentDriftResults{
+		Checked: 1,
+		Results: []params.SecretContentDriftResult{{
+			URI:         uri.String(),
+			Revision:    1,
+			BackendName: "myvault",
+			Drift:       "missing",
+		}},
+	})
+}
+
+func (s *SecretsSuite) TestCheckSecretContentDriftPermissionDenied(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthClient()
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(
+		errors.WithType(apiservererrors.ErrPerm, authentication.ErrorEntityMissingPermission))
+	s.authorizer.EXPECT().HasPermission(permission.AdminAccess, coretesting.ModelTag).Return(
+		errors.WithType(apiservererrors.ErrPerm, authentication.ErrorEntityMissingPermission))
+
+	facade, err := apisecrets.NewTestAPI(s.authTag, s.authorizer, s.secretsState, s.secretConsumer, nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.CheckSecretContentDrift(params.CheckSecretContentDriftArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -5,11 +5,14 @@ package sshclient
 
 import (
 	stdcontext "context"
+	"io"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
+	gossh "golang.org/x/crypto/ssh"
 
 	"github.com/juju/juju/apiserver/authentication"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
@@ -23,6 +26,7 @@ import (
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/context"
+	jujussh "github.com/juju/juju/pki/ssh"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 )
@@ -39,10 +43,28 @@ type Facade struct {
 	getBroker        newCaasBrokerFunc
 }
 
+// FacadeV8 provides the SSH Client API facade version 8
+// which adds SSHUserCertificate.
+type FacadeV8 struct {
+	*Facade
+}
+
+// FacadeV7 provides the SSH Client API facade version 7
+// which adds ListSSHPolicies, SetSSHPolicy and RemoveSSHPolicy.
+type FacadeV7 struct {
+	*FacadeV8
+}
+
+// FacadeV6 provides the SSH Client API facade version 6
+// which adds ListSSHSessionRecordings and SSHSessionRecording.
+type FacadeV6 struct {
+	*FacadeV7
+}
+
 // FacadeV5 provides the SSH Client API facade version 5
 // which adds VirtualHostname.
 type FacadeV5 struct {
-	*Facade
+	*FacadeV6
 }
 
 // FacadeV4 provides the SSH Client API facade version 4.
@@ -95,6 +117,24 @@ func (facade *Facade) checkIsModelReader() error {
 	return facade.authorizer.HasPermission(permission.ReadAccess, facade.backend.ModelTag())
 }
 
+// SSHUserCertificate is not implemented in v7.
+func (f *FacadeV7) SSHUserCertificate(_, _ struct{}) {}
+
+// ListSSHPolicies is not implemented in v6.
+func (f *FacadeV6) ListSSHPolicies(_, _ struct{}) {}
+
+// SetSSHPolicy is not implemented in v6.
+func (f *FacadeV6) SetSSHPolicy(_, _ struct{}) {}
+
+// RemoveSSHPolicy is not implemented in v6.
+func (f *FacadeV6) RemoveSSHPolicy(_, _ struct{}) {}
+
+// ListSSHSessionRecordings is not implemented in v5.
+func (f *FacadeV5) ListSSHSessionRecordings(_, _ struct{}) {}
+
+// SSHSessionRecording is not implemented in v5.
+func (f *FacadeV5) SSHSessionRecording(_, _ struct{}) {}
+
 // VirtualHostname is not implemented in v4.
 func (f *FacadeV4) VirtualHostname(_, _, _ struct{}) {}
 
@@ -426,3 +466,213 @@ func getVirtualHostnameForEntity(modelUUID string, tagString string, container *
 	}
 	return info.String(), nil
 }
+
+// ListSSHSessionRecordings returns the recordings of SSH sessions
+// to the model's machines and units matching the filter.
+func (facade *Facade) ListSSHSessionRecordings(arg params.SSHSessionRecordingFilter) (params.SSHSessionRecordingsResult, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.SSHSessionRecordingsResult{}, errors.Trace(err)
+	}
+	recordings, err := facade.backend.SSHSessionRecordings(state.SSHSessionRecordingFilter{
+		User:   arg.User,
+		Target: arg.Target,
+		Since:  arg.Since,
+		Until:  arg.Until,
+	})
+	if err != nil {
+		return params.SSHSessionRecordingsResult{}, errors.Trace(err)
+	}
+	result := params.SSHSessionRecordingsResult{
+		Results: make([]params.SSHSessionRecording, len(recordings)),
+	}
+	for i, r := range recordings {
+		result.Results[i] = toParamsSSHSessionRecording(r)
+	}
+	return result, nil
+}
+
+// SSHSessionRecording returns an SSH session recording along with its content.
+func (facade *Facade) SSHSessionRecording(arg params.SSHSessionRecordingIDArg) (params.SSHSessionRecordingResult, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.SSHSessionRecordingResult{}, errors.Trace(err)
+	}
+	recording, r, err := facade.backend.SSHSessionRecording(arg.ID)
+	if err != nil {
+		return params.SSHSessionRecordingResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return params.SSHSessionRecordingResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	return params.SSHSessionRecordingResult{
+		Metadata:  toParamsSSHSessionRecording(recording),
+		Recording: content,
+	}, nil
+}
+
+func toParamsSSHSessionRecording(r state.SSHSessionRecording) params.SSHSessionRecording {
+	return params.SSHSessionRecording{
+		ID:             r.ID,
+		User:           r.User,
+		KeyFingerprint: r.KeyFingerprint,
+		Target:         r.Target,
+		Started:        r.Started,
+		Ended:          r.Ended,
+		Size:           r.Size,
+	}
+}
+
+// ListSSHPolicies returns the model's SSH policies.
+func (facade *Facade) ListSSHPolicies() (params.SSHPoliciesResult, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.SSHPoliciesResult{}, errors.Trace(err)
+	}
+	policies, err := facade.backend.SSHPolicies()
+	if err != nil {
+		return params.SSHPoliciesResult{}, errors.Trace(err)
+	}
+	result := params.SSHPoliciesResult{
+		Policies: make([]params.SSHPolicy, len(policies)),
+	}
+	for i, p := range policies {
+		result.Policies[i] = params.FromSSHPolicy(p)
+	}
+	return result, nil
+}
+
+// SetSSHPolicy adds an SSH policy to the model, replacing
+// any existing policy with the same name.
+func (facade *Facade) SetSSHPolicy(arg params.SSHPolicy) (params.ErrorResult, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	err := facade.backend.SetSSHPolicy(arg.SSHPolicy())
+	return params.ErrorResult{Error: apiservererrors.ServerError(err)}, nil
+}
+
+// RemoveSSHPolicy removes an SSH policy from the model.
+func (facade *Facade) RemoveSSHPolicy(arg params.SSHPolicyNameArg) (params.ErrorResult, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	err := facade.backend.RemoveSSHPolicy(arg.Name)
+	return params.ErrorResult{Error: apiservererrors.ServerError(err)}, nil
+}
+
+// SSHUserCertificate issues a short-lived SSH user certificate for the
+// public key, signed by the controller's certificate authority. The
+// certificate is only valid for connecting to the given machines and
+// units, and to the machines hosting the units if the model has no SSH
+// policies.
+func (facade *Facade) SSHUserCertificate(arg params.SSHUserCertificateArg) (params.SSHUserCertificateResult, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.SSHUserCertificateResult{}, errors.Trace(err)
+	}
+	controllerConfig, err := facade.backend.ControllerConfig()
+	if err != nil {
+		return params.SSHUserCertificateResult{}, errors.Trace(err)
+	}
+	if !controllerConfig.SSHUserCertificates() {
+		return params.SSHUserCertificateResult{
+			Error: apiservererrors.ServerError(errors.NotSupportedf("ssh user certificates on this controller")),
+		}, nil
+	}
+	cert, err := facade.sshUserCertificate(arg)
+	if err != nil {
+		return params.SSHUserCertificateResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	return params.SSHUserCertificateResult{
+		Certificate: string(gossh.MarshalAuthorizedKey(cert)),
+	}, nil
+}
+
+func (facade *Facade) sshUserCertificate(arg params.SSHUserCertificateArg) (*gossh.Certificate, error) {
+	if len(arg.Targets) == 0 {
+		return nil, errors.NotValidf("ssh user certificate without targets")
+	}
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(arg.PublicKey))
+	if err != nil {
+		return nil, errors.NewNotValid(err, "public key")
+	}
+	if _, ok := key.(*gossh.Certificate); ok {
+		return nil, errors.NotValidf("public key is a certificate")
+	}
+	principals, err := facade.certificatePrincipals(arg.Targets)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	caKey, err := facade.backend.SSHUserCAKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ca, err := gossh.ParsePrivateKey([]byte(caKey))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return jujussh.NewUserCertificate(
+		ca, key, facade.authorizer.GetAuthTag().Id(), principals,
+		jujussh.DefaultUserCertificateValidity, time.Now(),
+	)
+}
+
+// certificatePrincipals returns the virtual hostnames of the targets
+// and of the machines hosting them, which are the principals machines
+// and the controller's SSH server accept certificates for.
+//
+// The machines hosting units aren't included if the model has SSH
+// policies. Only the controller's SSH server applies the policies, and
+// a machine's own SSH server accepts any certificate naming it, so a
+// certificate for a unit must not be usable to connect to its machine.
+func (facade *Facade) certificatePrincipals(targets []string) ([]string, error) {
+	modelUUID := facade.backend.ModelTag().Id()
+	policies, err := facade.backend.SSHPolicies()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var principals []string
+	seen := make(map[string]bool)
+	add := func(principal string) {
+		if !seen[principal] {
+			seen[principal] = true
+			principals = append(principals, principal)
+		}
+	}
+	for _, target := range targets {
+		tag, err := names.ParseTag(target)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch tag := tag.(type) {
+		case names.MachineTag:
+			if tag.ContainerType() != "" {
+				return nil, errors.NotSupportedf("ssh user certificates for container machines")
+			}
+		case names.UnitTag:
+		default:
+			return nil, errors.NotValidf("target %q", target)
+		}
+		hostname, err := getVirtualHostnameForEntity(modelUUID, target, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		add(hostname)
+		if tag.Kind() != names.UnitTagKind || len(policies) > 0 {
+			continue
+		}
+		machine, err := facade.backend.GetMachineForEntity(target)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineTag := machine.MachineTag()
+		if machineTag.ContainerType() != "" {
+			continue
+		}
+		info, err := virtualhostname.NewInfoMachineTarget(modelUUID, machineTag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		add(info.String())
+	}
+	return principals, nil
+}
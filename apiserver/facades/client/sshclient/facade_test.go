@@ -6,12 +6,17 @@ package sshclient_test
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	sshtesting "github.com/juju/utils/v3/ssh/testing"
 	"go.uber.org/mock/gomock"
+	gossh "golang.org/x/crypto/ssh"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/authentication"
@@ -22,13 +27,16 @@ import (
 	k8scloud "github.com/juju/juju/caas/kubernetes/cloud"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/sshpolicy"
 	"github.com/juju/juju/core/virtualhostname"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
 	environscontext "github.com/juju/juju/environs/context"
+	jujussh "github.com/juju/juju/pki/ssh"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
@@ -460,6 +468,34 @@ func (backend *mockBackend) UnitVirtualPublicKey(string) ([]byte, error) {
 	return nil, errors.NotImplementedf("UnitVirtualPublicKey")
 }
 
+func (backend *mockBackend) SSHSessionRecordings(state.SSHSessionRecordingFilter) ([]state.SSHSessionRecording, error) {
+	return nil, nil
+}
+
+func (backend *mockBackend) SSHSessionRecording(string) (state.SSHSessionRecording, io.ReadCloser, error) {
+	return state.SSHSessionRecording{}, nil, errors.NotImplemented
+}
+
+func (backend *mockBackend) SSHPolicies() ([]sshpolicy.Policy, error) {
+	return nil, errors.NotImplemented
+}
+
+func (backend *mockBackend) SetSSHPolicy(sshpolicy.Policy) error {
+	return errors.NotImplemented
+}
+
+func (backend *mockBackend) RemoveSSHPolicy(string) error {
+	return errors.NotImplemented
+}
+
+func (backend *mockBackend) ControllerConfig() (controller.Config, error) {
+	return nil, errors.NotImplemented
+}
+
+func (backend *mockBackend) SSHUserCAKey() (string, error) {
+	return "", errors.NotImplemented
+}
+
 func (backend *mockBackend) ModelTag() names.ModelTag {
 	return testing.ModelTag
 }
@@ -792,3 +828,328 @@ func (s *facadeSuiteNewMocks) TestPublicHostKeyForTargetUserAuth(c *gc.C) {
 	})
 	c.Assert(*res.Error, gc.ErrorMatches, "entity missing permission")
 }
+
+func (s *facadeSuiteNewMocks) newFacadeAsModelAdmin(c *gc.C) *sshclient.Facade {
+	s.mockBackend.EXPECT().ModelTag().Return(testing.ModelTag).AnyTimes()
+	s.mockBackend.EXPECT().ControllerTag().Return(testing.ControllerTag).AnyTimes()
+	s.mockAuthoriser.EXPECT().AuthClient().Return(true)
+	s.mockAuthoriser.EXPECT().HasPermission(permission.SuperuserAccess, testing.ControllerTag).Return(authentication.ErrorEntityMissingPermission)
+	s.mockAuthoriser.EXPECT().HasPermission(permission.AdminAccess, testing.ModelTag).Return(nil)
+
+	facade, err := sshclient.InternalFacade(s.mockBackend, nil, s.mockAuthoriser, s.callContext, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return facade
+}
+
+func (s *facadeSuiteNewMocks) TestListSSHSessionRecordings(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	recording := state.SSHSessionRecording{
+		ID:             "id",
+		User:           "ubuntu",
+		KeyFingerprint: "SHA256:abc",
+		Target:         "0." + testing.ModelTag.Id() + ".juju.local",
+		Started:        since.Add(time.Hour),
+		Ended:          since.Add(2 * time.Hour),
+		Size:           42,
+	}
+	s.mockBackend.EXPECT().SSHSessionRecordings(state.SSHSessionRecordingFilter{
+		User:  "ubuntu",
+		Since: &since,
+	}).Return([]state.SSHSessionRecording{recording}, nil)
+
+	result, err := facade.ListSSHSessionRecordings(params.SSHSessionRecordingFilter{
+		User:  "ubuntu",
+		Since: &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SSHSessionRecordingsResult{
+		Results: []params.SSHSessionRecording{{
+			ID:             "id",
+			User:           "ubuntu",
+			KeyFingerprint: "SHA256:abc",
+			Target:         recording.Target,
+			Started:        recording.Started,
+			Ended:          recording.Ended,
+			Size:           42,
+		}},
+	})
+}
+
+func (s *facadeSuiteNewMocks) TestListSSHSessionRecordingsPermissionDenied(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.mockBackend.EXPECT().ModelTag().Return(testing.ModelTag).AnyTimes()
+	s.mockBackend.EXPECT().ControllerTag().Return(testing.ControllerTag).AnyTimes()
+	s.mockAuthoriser.EXPECT().AuthClient().Return(true)
+	s.mockAuthoriser.EXPECT().HasPermission(permission.SuperuserAccess, testing.ControllerTag).Return(authentication.ErrorEntityMissingPermission)
+	s.mockAuthoriser.EXPECT().HasPermission(permission.AdminAccess, testing.ModelTag).Return(apiservererrors.ErrPerm)
+
+	facade, err := sshclient.InternalFacade(s.mockBackend, nil, s.mockAuthoriser, s.callContext, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.ListSSHSessionRecordings(params.SSHSessionRecordingFilter{})
+	c.Assert(err, gc.ErrorMatches, apiservererrors.ErrPerm.Error())
+}
+
+func (s *facadeSuiteNewMocks) TestSSHSessionRecording(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	s.mockBackend.EXPECT().SSHSessionRecording("id").Return(state.SSHSessionRecording{
+		ID:   "id",
+		User: "ubuntu",
+		Size: 9,
+	}, io.NopCloser(strings.NewReader("recording")), nil)
+
+	result, err := facade.SSHSessionRecording(params.SSHSessionRecordingIDArg{ID: "id"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SSHSessionRecordingResult{
+		Metadata: params.SSHSessionRecording{
+			ID:   "id",
+			User: "ubuntu",
+			Size: 9,
+		},
+		Recording: []byte("recording"),
+	})
+}
+
+func (s *facadeSuiteNewMocks) TestSSHSessionRecordingNotFound(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	s.mockBackend.EXPECT().SSHSessionRecording("id").Return(
+		state.SSHSessionRecording{}, nil, errors.NotFoundf("ssh session recording %q", "id"))
+
+	result, err := facade.SSHSessionRecording(params.SSHSessionRecordingIDArg{ID: "id"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *facadeSuiteNewMocks) TestListSSHPolicies(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	s.mockBackend.EXPECT().SSHPolicies().Return([]sshpolicy.Policy{{
+		Name:         "dbas",
+		Users:        []string{"alice"},
+		Applications: []string{"postgresql"},
+		Commands:     []string{"psql*"},
+	}}, nil)
+
+	result, err := facade.ListSSHPolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SSHPoliciesResult{
+		Policies: []params.SSHPolicy{{
+			Name:         "dbas",
+			Users:        []string{"alice"},
+			Applications: []string{"postgresql"},
+			Commands:     []string{"psql*"},
+		}},
+	})
+}
+
+func (s *facadeSuiteNewMocks) TestSetSSHPolicy(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	s.mockBackend.EXPECT().SetSSHPolicy(sshpolicy.Policy{
+		Name:  "operators",
+		Users: []string{sshpolicy.Everyone},
+		Shell: true,
+		SFTP:  true,
+	}).Return(nil)
+
+	result, err := facade.SetSSHPolicy(params.SSHPolicy{
+		Name:  "operators",
+		Users: []string{sshpolicy.Everyone},
+		Shell: true,
+		SFTP:  true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+}
+
+func (s *facadeSuiteNewMocks) TestSetSSHPolicyNotValid(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	s.mockBackend.EXPECT().SetSSHPolicy(sshpolicy.Policy{Name: "BAD"}).Return(
+		errors.NotValidf("policy name %q", "BAD"))
+
+	result, err := facade.SetSSHPolicy(params.SSHPolicy{Name: "BAD"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error.Code, gc.Equals, params.CodeNotValid)
+}
+
+func (s *facadeSuiteNewMocks) TestSetSSHPolicyPermissionDenied(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.mockBackend.EXPECT().ModelTag().Return(testing.ModelTag).AnyTimes()
+	s.mockBackend.EXPECT().ControllerTag().Return(testing.ControllerTag).AnyTimes()
+	s.mockAuthoriser.EXPECT().AuthClient().Return(true)
+	s.mockAuthoriser.EXPECT().HasPermission(permission.SuperuserAccess, testing.ControllerTag).Return(authentication.ErrorEntityMissingPermission)
+	s.mockAuthoriser.EXPECT().HasPermission(permission.AdminAccess, testing.ModelTag).Return(apiservererrors.ErrPerm)
+
+	facade, err := sshclient.InternalFacade(s.mockBackend, nil, s.mockAuthoriser, s.callContext, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.SetSSHPolicy(params.SSHPolicy{Name: "operators"})
+	c.Assert(err, gc.ErrorMatches, apiservererrors.ErrPerm.Error())
+}
+
+func (s *facadeSuiteNewMocks) TestRemoveSSHPolicy(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	s.mockBackend.EXPECT().RemoveSSHPolicy("operators").Return(errors.NotFoundf("ssh policy %q", "operators"))
+
+	result, err := facade.RemoveSSHPolicy(params.SSHPolicyNameArg{Name: "operators"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *facadeSuiteNewMocks) newSSHUserCAKey(c *gc.C) (string, gossh.PublicKey) {
+	privateKey, err := jujussh.ED25519()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := jujussh.MarshalPrivateKey(privateKey)
+	c.Assert(err, jc.ErrorIsNil)
+	signer, err := gossh.ParsePrivateKey(data)
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data), signer.PublicKey()
+}
+
+func (s *facadeSuiteNewMocks) TestSSHUserCertificate(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	caKey, caPublicKey := s.newSSHUserCAKey(c)
+	s.mockBackend.EXPECT().ControllerConfig().Return(controller.Config{
+		controller.SSHUserCertificates: true,
+	}, nil)
+	s.mockBackend.EXPECT().SSHPolicies().Return(nil, nil)
+	s.mockBackend.EXPECT().GetMachineForEntity("unit-foo-0").Return(&mockMachine{
+		tag: names.NewMachineTag("1"),
+	}, nil)
+	s.mockBackend.EXPECT().SSHUserCAKey().Return(caKey, nil)
+	s.mockAuthoriser.EXPECT().GetAuthTag().Return(names.NewUserTag("bob"))
+
+	result, err := facade.SSHUserCertificate(params.SSHUserCertificateArg{
+		Targets:   []string{"machine-0", "unit-foo-0"},
+		PublicKey: sshtesting.ValidKeyOne.Key,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(result.Certificate))
+	c.Assert(err, jc.ErrorIsNil)
+	cert, ok := key.(*gossh.Certificate)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(cert.KeyId, gc.Equals, "bob")
+	modelUUID := testing.ModelTag.Id()
+	c.Check(cert.ValidPrincipals, jc.DeepEquals, []string{
+		fmt.Sprintf("0.%s.juju.local", modelUUID),
+		fmt.Sprintf("0.foo.%s.juju.local", modelUUID),
+		fmt.Sprintf("1.%s.juju.local", modelUUID),
+	})
+	for _, principal := range cert.ValidPrincipals {
+		err = jujussh.CheckUserCertificate(caPublicKey, cert, principal, time.Now())
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *facadeSuiteNewMocks) TestSSHUserCertificateWithPolicies(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	caKey, _ := s.newSSHUserCAKey(c)
+	s.mockBackend.EXPECT().ControllerConfig().Return(controller.Config{
+		controller.SSHUserCertificates: true,
+	}, nil)
+	s.mockBackend.EXPECT().SSHPolicies().Return([]sshpolicy.Policy{{
+		Name:         "operators",
+		Users:        []string{"bob"},
+		Applications: []string{"foo"},
+		Commands:     []string{"uptime"},
+	}}, nil)
+	s.mockBackend.EXPECT().SSHUserCAKey().Return(caKey, nil)
+	s.mockAuthoriser.EXPECT().GetAuthTag().Return(names.NewUserTag("bob"))
+
+	result, err := facade.SSHUserCertificate(params.SSHUserCertificateArg{
+		Targets:   []string{"unit-foo-0"},
+		PublicKey: sshtesting.ValidKeyOne.Key,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(result.Certificate))
+	c.Assert(err, jc.ErrorIsNil)
+	cert, ok := key.(*gossh.Certificate)
+	c.Assert(ok, jc.IsTrue)
+	// The certificate isn't valid for the machine hosting the unit,
+	// whose SSH server doesn't apply the policies.
+	c.Check(cert.ValidPrincipals, jc.DeepEquals, []string{
+		fmt.Sprintf("0.foo.%s.juju.local", testing.ModelTag.Id()),
+	})
+}
+
+func (s *facadeSuiteNewMocks) TestSSHUserCertificateDisabled(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	s.mockBackend.EXPECT().ControllerConfig().Return(controller.Config{}, nil)
+
+	result, err := facade.SSHUserCertificate(params.SSHUserCertificateArg{
+		Targets:   []string{"machine-0"},
+		PublicKey: sshtesting.ValidKeyOne.Key,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *facadeSuiteNewMocks) TestSSHUserCertificateContainerMachine(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	s.mockBackend.EXPECT().ControllerConfig().Return(controller.Config{
+		controller.SSHUserCertificates: true,
+	}, nil)
+	s.mockBackend.EXPECT().SSHPolicies().Return(nil, nil)
+
+	result, err := facade.SSHUserCertificate(params.SSHUserCertificateArg{
+		Targets:   []string{"machine-0-lxd-1"},
+		PublicKey: sshtesting.ValidKeyOne.Key,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *facadeSuiteNewMocks) TestSSHUserCertificateInvalidPublicKey(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	facade := s.newFacadeAsModelAdmin(c)
+
+	s.mockBackend.EXPECT().ControllerConfig().Return(controller.Config{
+		controller.SSHUserCertificates: true,
+	}, nil)
+
+	result, err := facade.SSHUserCertificate(params.SSHUserCertificateArg{
+		Targets:   []string{"machine-0"},
+		PublicKey: "not a key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error.Code, gc.Equals, params.CodeNotValid)
+}
@@ -10,9 +10,12 @@
 package mocks
 
 import (
+	io "io"
 	reflect "reflect"
 
 	sshclient "github.com/juju/juju/apiserver/facades/client/sshclient"
+	controller "github.com/juju/juju/controller"
+	sshpolicy "github.com/juju/juju/core/sshpolicy"
 	cloudspec "github.com/juju/juju/environs/cloudspec"
 	config "github.com/juju/juju/environs/config"
 	state "github.com/juju/juju/state"
@@ -58,6 +61,21 @@ func (mr *MockBackendMockRecorder) CloudSpec() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudSpec", reflect.TypeOf((*MockBackend)(nil).CloudSpec))
 }
 
+// ControllerConfig mocks base method.
+func (m *MockBackend) ControllerConfig() (controller.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControllerConfig")
+	ret0, _ := ret[0].(controller.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ControllerConfig indicates an expected call of ControllerConfig.
+func (mr *MockBackendMockRecorder) ControllerConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControllerConfig", reflect.TypeOf((*MockBackend)(nil).ControllerConfig))
+}
+
 // ControllerTag mocks base method.
 func (m *MockBackend) ControllerTag() names.ControllerTag {
 	m.ctrl.T.Helper()
@@ -176,6 +194,95 @@ func (mr *MockBackendMockRecorder) ModelTag() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelTag", reflect.TypeOf((*MockBackend)(nil).ModelTag))
 }
 
+// RemoveSSHPolicy mocks base method.
+func (m *MockBackend) RemoveSSHPolicy(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSSHPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSSHPolicy indicates an expected call of RemoveSSHPolicy.
+func (mr *MockBackendMockRecorder) RemoveSSHPolicy(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSSHPolicy", reflect.TypeOf((*MockBackend)(nil).RemoveSSHPolicy), arg0)
+}
+
+// SSHPolicies mocks base method.
+func (m *MockBackend) SSHPolicies() ([]sshpolicy.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHPolicies")
+	ret0, _ := ret[0].([]sshpolicy.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSHPolicies indicates an expected call of SSHPolicies.
+func (mr *MockBackendMockRecorder) SSHPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHPolicies", reflect.TypeOf((*MockBackend)(nil).SSHPolicies))
+}
+
+// SSHSessionRecording mocks base method.
+func (m *MockBackend) SSHSessionRecording(arg0 string) (state.SSHSessionRecording, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHSessionRecording", arg0)
+	ret0, _ := ret[0].(state.SSHSessionRecording)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SSHSessionRecording indicates an expected call of SSHSessionRecording.
+func (mr *MockBackendMockRecorder) SSHSessionRecording(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHSessionRecording", reflect.TypeOf((*MockBackend)(nil).SSHSessionRecording), arg0)
+}
+
+// SSHSessionRecordings mocks base method.
+func (m *MockBackend) SSHSessionRecordings(arg0 state.SSHSessionRecordingFilter) ([]state.SSHSessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHSessionRecordings", arg0)
+	ret0, _ := ret[0].([]state.SSHSessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSHSessionRecordings indicates an expected call of SSHSessionRecordings.
+func (mr *MockBackendMockRecorder) SSHSessionRecordings(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHSessionRecordings", reflect.TypeOf((*MockBackend)(nil).SSHSessionRecordings), arg0)
+}
+
+// SSHUserCAKey mocks base method.
+func (m *MockBackend) SSHUserCAKey() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHUserCAKey")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSHUserCAKey indicates an expected call of SSHUserCAKey.
+func (mr *MockBackendMockRecorder) SSHUserCAKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHUserCAKey", reflect.TypeOf((*MockBackend)(nil).SSHUserCAKey))
+}
+
+// SetSSHPolicy mocks base method.
+func (m *MockBackend) SetSSHPolicy(arg0 sshpolicy.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSSHPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSSHPolicy indicates an expected call of SetSSHPolicy.
+func (mr *MockBackendMockRecorder) SetSSHPolicy(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSSHPolicy", reflect.TypeOf((*MockBackend)(nil).SetSSHPolicy), arg0)
+}
+
 // UnitVirtualPublicKey mocks base method.
 func (m *MockBackend) UnitVirtualPublicKey(arg0 string) ([]byte, error) {
 	m.ctrl.T.Helper()
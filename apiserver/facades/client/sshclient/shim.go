@@ -4,11 +4,15 @@
 package sshclient
 
 import (
+	"io"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	"golang.org/x/crypto/ssh"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/sshpolicy"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
@@ -28,6 +32,15 @@ type Backend interface {
 	JumpServerVirtualPublicKey() ([]byte, error)
 	MachineVirtualPublicKey(string) ([]byte, error)
 	UnitVirtualPublicKey(string) ([]byte, error)
+
+	SSHSessionRecordings(state.SSHSessionRecordingFilter) ([]state.SSHSessionRecording, error)
+	SSHSessionRecording(id string) (state.SSHSessionRecording, io.ReadCloser, error)
+	SSHPolicies() ([]sshpolicy.Policy, error)
+	SetSSHPolicy(sshpolicy.Policy) error
+	RemoveSSHPolicy(name string) error
+
+	ControllerConfig() (controller.Config, error)
+	SSHUserCAKey() (string, error)
 }
 
 // Model defines a point of use interface for the model from state.
@@ -156,6 +169,12 @@ func (b *backend) JumpServerVirtualPublicKey() ([]byte, error) {
 	return key, nil
 }
 
+// SSHUserCAKey returns the private key of the controller's SSH
+// user certificate authority.
+func (b *backend) SSHUserCAKey() (string, error) {
+	return b.controllerState.SSHUserCAKey()
+}
+
 // UnitVirtualAuthorizedKey returns the public key in SSH wire format.
 func (b *backend) UnitVirtualPublicKey(unitID string) ([]byte, error) {
 	vhk, err := b.controllerState.UnitVirtualHostKey(unitID)
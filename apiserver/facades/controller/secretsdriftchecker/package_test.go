@@ -0,0 +1,33 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsdriftchecker
+
+import (
+	"testing"
+
+	gc "gopkg.in/check.v1"
+
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/secrets/provider"
+)
+
+func TestPackage(t *testing.T) {
+	gc.TestingT(t)
+}
+
+func NewTestAPI(
+	authorizer facade.Authorizer,
+	secretsState commonsecrets.SecretsDriftState,
+	backendConfigGetter func() (*provider.ModelBackendConfigInfo, error),
+) (*SecretsDriftCheckerAPI, error) {
+	if !authorizer.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &SecretsDriftCheckerAPI{
+		secretsState:        secretsState,
+		backendConfigGetter: backendConfigGetter,
+	}, nil
+}
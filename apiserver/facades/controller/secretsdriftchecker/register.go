@@ -0,0 +1,43 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsdriftchecker
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common/secrets"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+)
+
+// Register is called to expose a package of facades onto a given registry.
+func Register(registry facade.FacadeRegistry) {
+	registry.MustRegister("SecretsDriftChecker", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return NewSecretsDriftCheckerAPI(ctx)
+	}, reflect.TypeOf((*SecretsDriftCheckerAPI)(nil)))
+}
+
+// NewSecretsDriftCheckerAPI creates a SecretsDriftCheckerAPI.
+func NewSecretsDriftCheckerAPI(context facade.Context) (*SecretsDriftCheckerAPI, error) {
+	if !context.Auth().AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	model, err := context.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	backendConfigGetter := func() (*provider.ModelBackendConfigInfo, error) {
+		return secrets.AdminBackendConfigInfo(secrets.SecretsModel(model))
+	}
+
+	return &SecretsDriftCheckerAPI{
+		secretsState:        state.NewSecrets(context.State()),
+		backendConfigGetter: backendConfigGetter,
+	}, nil
+}
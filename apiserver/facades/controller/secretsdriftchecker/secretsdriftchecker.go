@@ -0,0 +1,28 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsdriftchecker
+
+import (
+	"github.com/juju/errors"
+
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+)
+
+// SecretsDriftCheckerAPI is the implementation for the
+// secretsdriftchecker facade.
+type SecretsDriftCheckerAPI struct {
+	secretsState        commonsecrets.SecretsDriftState
+	backendConfigGetter func() (*provider.ModelBackendConfigInfo, error)
+}
+
+// CheckSecretContentDrift reads back the content of all the model's
+// secret revisions stored in external backends and reports those
+// which are missing or have been modified.
+func (s *SecretsDriftCheckerAPI) CheckSecretContentDrift() (params.SecretContentDriftResults, error) {
+	result, err := commonsecrets.CheckContentDrift(s.secretsState, s.backendConfigGetter, state.SecretsFilter{})
+	return result, errors.Trace(err)
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsdriftchecker_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	"github.com/juju/juju/apiserver/common/secrets/mocks"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	facademocks "github.com/juju/juju/apiserver/facade/mocks"
+	"github.com/juju/juju/apiserver/facades/controller/secretsdriftchecker"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+)
+
+type driftCheckerSuite struct {
+	testing.IsolationSuite
+
+	authorizer *facademocks.MockAuthorizer
+	state      *mocks.MockSecretsDriftState
+	provider   *mocks.MockSecretBackendProvider
+	backend    *mocks.MockSecretsBackend
+}
+
+var _ = gc.Suite(&driftCheckerSuite{})
+
+func (s *driftCheckerSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+
+	s.authorizer = facademocks.NewMockAuthorizer(ctrl)
+	s.state = mocks.NewMockSecretsDriftState(ctrl)
+	s.provider = mocks.NewMockSecretBackendProvider(ctrl)
+	s.backend = mocks.NewMockSecretsBackend(ctrl)
+	s.PatchValue(&commonsecrets.GetProvider, func(string) (provider.SecretBackendProvider, error) { return s.provider, nil })
+
+	return ctrl
+}
+
+func (s *driftCheckerSuite) backendConfigGetter() (*provider.ModelBackendConfigInfo, error) {
+	return &provider.ModelBackendConfigInfo{
+		ActiveID: "backend-id",
+		Configs: map[string]provider.ModelBackendConfig{
+			"backend-id": {BackendConfig: provider.BackendConfig{BackendType: "vault"}},
+		},
+	}, nil
+}
+
+func (s *driftCheckerSuite) TestPermissionDenied(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.authorizer.EXPECT().AuthController().Return(false)
+
+	_, err := secretsdriftchecker.NewTestAPI(s.authorizer, s.state, s.backendConfigGetter)
+	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
+func (s *driftCheckerSuite) TestCheckSecretContentDrift(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.authorizer.EXPECT().AuthController().Return(true)
+	facade, err := secretsdriftchecker.NewTestAPI(s.authorizer, s.state, s.backendConfigGetter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uri := coresecrets.NewURI()
+	backendName := "myvault"
+	s.state.EXPECT().ListSecrets(state.SecretsFilter{}).Return([]*coresecrets.SecretMetadata{{URI: uri}}, nil)
+	s.state.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{{
+		Revision: 1,
+	}, {
+		Revision:    2,
+		ValueRef:    &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "rev-2"},
+		BackendName: &backendName,
+		Checksum:    "deadbeef",
+	}}, nil)
+	s.provider.EXPECT().NewBackend(gomock.Any()).Return(s.backend, nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "rev-2").Return(nil, errors.NotFoundf("rev-2"))
+
+	result, err := facade.CheckSecretContentDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretContentDriftResults{
+		Checked: 1,
+		Results: []params.SecretContentDriftResult{{
+			URI:         uri.String(),
+			Revision:    2,
+			BackendName: "myvault",
+			Drift:       "missing",
+		}},
+	})
+}
@@ -5,10 +5,12 @@ package sshserver
 
 import (
 	"github.com/juju/errors"
+	gossh "golang.org/x/crypto/ssh"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/sshpolicy"
 	"github.com/juju/juju/core/virtualhostname"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
@@ -22,6 +24,8 @@ type Backend interface {
 	SSHServerHostKey() (string, error)
 	HostKeyForVirtualHostname(info virtualhostname.Info) ([]byte, error)
 	AuthorizedKeysForModel(uuid string) ([]string, error)
+	SSHPolicies(modelUUID string) ([]sshpolicy.Policy, error)
+	SSHUserCAKey() (string, error)
 }
 
 // Facade allows model config manager clients to watch controller config changes and fetch controller config.
@@ -31,6 +35,33 @@ type Facade struct {
 	backend Backend
 }
 
+// FacadeV3 is the version 3 SSHServer facade,
+// which doesn't support SSH user certificates.
+type FacadeV3 struct {
+	*Facade
+}
+
+// SSHUserCertificateAuthority isn't on the v3 API.
+func (*FacadeV3) SSHUserCertificateAuthority(_, _ struct{}) {}
+
+// FacadeV2 is the version 2 SSHServer facade,
+// which doesn't support SSH policies.
+type FacadeV2 struct {
+	*FacadeV3
+}
+
+// SSHPoliciesForModel isn't on the v2 API.
+func (*FacadeV2) SSHPoliciesForModel(_, _ struct{}) {}
+
+// FacadeV1 is the version 1 SSHServer facade, of controllers
+// without the /ssh-session-recordings HTTP endpoint. It has the same
+// methods as v2: the version only tells the SSH server whether session
+// recordings can be saved, as an HTTP endpoint can't be discovered
+// through the facade versions otherwise.
+type FacadeV1 struct {
+	*FacadeV2
+}
+
 // NewFacade returns a new SSHServer facade to be registered for use within
 // the worker.
 func NewFacade(ctx facade.Context, backend Backend) *Facade {
@@ -77,6 +108,31 @@ func (f *Facade) SSHServerHostKey() (params.StringResult, error) {
 	return result, nil
 }
 
+// SSHUserCertificateAuthority returns the public key, in authorized_keys
+// format, of the certificate authority signing the SSH user certificates
+// issued by the controller. No key is returned if the controller isn't
+// configured to use SSH user certificates.
+func (f *Facade) SSHUserCertificateAuthority() (params.StringResult, error) {
+	config, err := f.backend.ControllerConfig()
+	if err != nil {
+		return params.StringResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	if !config.SSHUserCertificates() {
+		return params.StringResult{}, nil
+	}
+	key, err := f.backend.SSHUserCAKey()
+	if err != nil {
+		return params.StringResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	signer, err := gossh.ParsePrivateKey([]byte(key))
+	if err != nil {
+		return params.StringResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	return params.StringResult{
+		Result: string(gossh.MarshalAuthorizedKey(signer.PublicKey())),
+	}, nil
+}
+
 // VirtualHostKey returns the virtual private host key for the target virtual hostname.
 func (facade *Facade) VirtualHostKey(arg params.SSHVirtualHostKeyRequestArg) (params.SSHHostKeyResult, error) {
 	var res params.SSHHostKeyResult
@@ -114,3 +170,23 @@ func (f *Facade) ListAuthorizedKeysForModel(args params.ListAuthorizedKeysArgs)
 	}, nil
 
 }
+
+// SSHPoliciesForModel returns the SSH policies of the model.
+func (f *Facade) SSHPoliciesForModel(arg params.SSHPoliciesArg) (params.SSHPoliciesResult, error) {
+	policies, err := f.backend.SSHPolicies(arg.ModelUUID)
+	if err != nil {
+		return params.SSHPoliciesResult{
+			Error: apiservererrors.ServerError(errors.Annotate(err, "failed to get ssh policies for model")),
+		}, nil
+	}
+	if len(policies) == 0 {
+		return params.SSHPoliciesResult{}, nil
+	}
+	result := params.SSHPoliciesResult{
+		Policies: make([]params.SSHPolicy, len(policies)),
+	}
+	for i, p := range policies {
+		result.Policies[i] = params.FromSSHPolicy(p)
+	}
+	return result, nil
+}
@@ -4,12 +4,18 @@
 package sshserver_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+
 	"github.com/juju/worker/v3/workertest"
 	"go.uber.org/mock/gomock"
+	gossh "golang.org/x/crypto/ssh"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/facades/controller/sshserver"
 	controller "github.com/juju/juju/controller"
+	"github.com/juju/juju/core/sshpolicy"
 	"github.com/juju/juju/rpc/params"
 )
 
@@ -156,3 +162,92 @@ func (s *sshserverSuite) TestAuthorizedKeysForModel(c *gc.C) {
 		c.Assert(results.AuthorizedKeys, gc.DeepEquals, tc.expectKeys)
 	}
 }
+
+func (s *sshserverSuite) TestSSHPoliciesForModel(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	policies := []sshpolicy.Policy{{
+		Name:         "dbas",
+		Users:        []string{"alice", "bob@external"},
+		Applications: []string{"postgresql"},
+		Commands:     []string{"psql*"},
+	}, {
+		Name:  "everyone",
+		Users: []string{sshpolicy.Everyone},
+		SFTP:  true,
+	}}
+	s.ctxMock.EXPECT().Resources().Times(1)
+	s.backendMock.EXPECT().SSHPolicies("abcd").Return(policies, nil)
+
+	f := sshserver.NewFacade(s.ctxMock, s.backendMock)
+
+	result, err := f.SSHPoliciesForModel(params.SSHPoliciesArg{ModelUUID: "abcd"})
+	c.Assert(err, gc.IsNil)
+	c.Assert(result, gc.DeepEquals, params.SSHPoliciesResult{
+		Policies: []params.SSHPolicy{{
+			Name:         "dbas",
+			Users:        []string{"alice", "bob@external"},
+			Applications: []string{"postgresql"},
+			Commands:     []string{"psql*"},
+		}, {
+			Name:  "everyone",
+			Users: []string{sshpolicy.Everyone},
+			SFTP:  true,
+		}},
+	})
+}
+
+func (s *sshserverSuite) TestSSHPoliciesForModelNoPolicies(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	s.ctxMock.EXPECT().Resources().Times(1)
+	s.backendMock.EXPECT().SSHPolicies("abcd").Return(nil, nil)
+
+	f := sshserver.NewFacade(s.ctxMock, s.backendMock)
+
+	result, err := f.SSHPoliciesForModel(params.SSHPoliciesArg{ModelUUID: "abcd"})
+	c.Assert(err, gc.IsNil)
+	c.Assert(result, gc.DeepEquals, params.SSHPoliciesResult{})
+}
+
+func (s *sshserverSuite) TestSSHUserCertificateAuthority(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, gc.IsNil)
+	pemKey, err := gossh.MarshalPrivateKey(privateKey, "")
+	c.Assert(err, gc.IsNil)
+	signer, err := gossh.NewSignerFromKey(privateKey)
+	c.Assert(err, gc.IsNil)
+
+	s.ctxMock.EXPECT().Resources().Times(1)
+	s.backendMock.EXPECT().ControllerConfig().Return(controller.Config{
+		controller.SSHUserCertificates: true,
+	}, nil)
+	s.backendMock.EXPECT().SSHUserCAKey().Return(string(pem.EncodeToMemory(pemKey)), nil)
+
+	f := sshserver.NewFacade(s.ctxMock, s.backendMock)
+
+	result, err := f.SSHUserCertificateAuthority()
+	c.Assert(err, gc.IsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResult{
+		Result: string(gossh.MarshalAuthorizedKey(signer.PublicKey())),
+	})
+}
+
+func (s *sshserverSuite) TestSSHUserCertificateAuthorityDisabled(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	s.ctxMock.EXPECT().Resources().Times(1)
+	s.backendMock.EXPECT().ControllerConfig().Return(controller.Config{}, nil)
+
+	f := sshserver.NewFacade(s.ctxMock, s.backendMock)
+
+	result, err := f.SSHUserCertificateAuthority()
+	c.Assert(err, gc.IsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResult{})
+}
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	controller "github.com/juju/juju/controller"
+	sshpolicy "github.com/juju/juju/core/sshpolicy"
 	virtualhostname "github.com/juju/juju/core/virtualhostname"
 	state "github.com/juju/juju/state"
 	gomock "go.uber.org/mock/gomock"
@@ -86,6 +87,21 @@ func (mr *MockBackendMockRecorder) HostKeyForVirtualHostname(arg0 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HostKeyForVirtualHostname", reflect.TypeOf((*MockBackend)(nil).HostKeyForVirtualHostname), arg0)
 }
 
+// SSHPolicies mocks base method.
+func (m *MockBackend) SSHPolicies(arg0 string) ([]sshpolicy.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHPolicies", arg0)
+	ret0, _ := ret[0].([]sshpolicy.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSHPolicies indicates an expected call of SSHPolicies.
+func (mr *MockBackendMockRecorder) SSHPolicies(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHPolicies", reflect.TypeOf((*MockBackend)(nil).SSHPolicies), arg0)
+}
+
 // SSHServerHostKey mocks base method.
 func (m *MockBackend) SSHServerHostKey() (string, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +117,21 @@ func (mr *MockBackendMockRecorder) SSHServerHostKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHServerHostKey", reflect.TypeOf((*MockBackend)(nil).SSHServerHostKey))
 }
 
+// SSHUserCAKey mocks base method.
+func (m *MockBackend) SSHUserCAKey() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHUserCAKey")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSHUserCAKey indicates an expected call of SSHUserCAKey.
+func (mr *MockBackendMockRecorder) SSHUserCAKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHUserCAKey", reflect.TypeOf((*MockBackend)(nil).SSHUserCAKey))
+}
+
 // WatchControllerConfig mocks base method.
 func (m *MockBackend) WatchControllerConfig() (state.NotifyWatcher, error) {
 	m.ctrl.T.Helper()
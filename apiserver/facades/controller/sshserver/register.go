@@ -13,10 +13,43 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("SSHServer", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return newExternalFacadeV1(ctx)
+	}, reflect.TypeOf((*FacadeV1)(nil)))
+	registry.MustRegister("SSHServer", 2, func(ctx facade.Context) (facade.Facade, error) {
+		return newExternalFacadeV2(ctx)
+	}, reflect.TypeOf((*FacadeV2)(nil)))
+	registry.MustRegister("SSHServer", 3, func(ctx facade.Context) (facade.Facade, error) {
+		return newExternalFacadeV3(ctx)
+	}, reflect.TypeOf((*FacadeV3)(nil)))
+	registry.MustRegister("SSHServer", 4, func(ctx facade.Context) (facade.Facade, error) {
 		return NewExternalFacade(ctx)
 	}, reflect.TypeOf((*Facade)(nil)))
 }
 
+func newExternalFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	f, err := newExternalFacadeV2(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &FacadeV1{f}, nil
+}
+
+func newExternalFacadeV2(ctx facade.Context) (*FacadeV2, error) {
+	f, err := newExternalFacadeV3(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &FacadeV2{f}, nil
+}
+
+func newExternalFacadeV3(ctx facade.Context) (*FacadeV3, error) {
+	f, err := NewExternalFacade(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &FacadeV3{f}, nil
+}
+
 // NewExternalFacade creates a new authorized Facade.
 func NewExternalFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -8,6 +8,7 @@ import (
 	jujussh "github.com/juju/utils/v3/ssh"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/sshpolicy"
 	"github.com/juju/juju/core/virtualhostname"
 	"github.com/juju/juju/state"
 )
@@ -34,6 +35,15 @@ func (b backend) SSHServerHostKey() (string, error) {
 	return systemState.SSHServerHostKey()
 }
 
+// SSHUserCAKey gets the ssh user certificate authority key from the systemState.
+func (b backend) SSHUserCAKey() (string, error) {
+	systemState, err := b.StatePool.SystemState()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return systemState.SSHUserCAKey()
+}
+
 // WatchControllerConfig gets the controller config watcher from the systemState.
 func (b backend) WatchControllerConfig() (state.NotifyWatcher, error) {
 	systemState, err := b.StatePool.SystemState()
@@ -71,3 +81,14 @@ func (b backend) AuthorizedKeysForModel(uuid string) ([]string, error) {
 	keys := jujussh.SplitAuthorisedKeys(cfg.AuthorizedKeys())
 	return keys, nil
 }
+
+// SSHPolicies returns the SSH policies of the model.
+func (b backend) SSHPolicies(modelUUID string) ([]sshpolicy.Policy, error) {
+	st, err := b.StatePool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+	policies, err := st.SSHPolicies()
+	return policies, errors.Trace(err)
+}
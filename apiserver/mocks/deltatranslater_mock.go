@@ -194,6 +194,20 @@ func (mr *MockDeltaTranslaterMockRecorder) TranslateRemoteApplication(arg0 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateRemoteApplication", reflect.TypeOf((*MockDeltaTranslater)(nil).TranslateRemoteApplication), arg0)
 }
 
+// TranslateStorageInstance mocks base method.
+func (m *MockDeltaTranslater) TranslateStorageInstance(arg0 multiwatcher.EntityInfo) params.EntityInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TranslateStorageInstance", arg0)
+	ret0, _ := ret[0].(params.EntityInfo)
+	return ret0
+}
+
+// TranslateStorageInstance indicates an expected call of TranslateStorageInstance.
+func (mr *MockDeltaTranslaterMockRecorder) TranslateStorageInstance(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateStorageInstance", reflect.TypeOf((*MockDeltaTranslater)(nil).TranslateStorageInstance), arg0)
+}
+
 // TranslateUnit mocks base method.
 func (m *MockDeltaTranslater) TranslateUnit(arg0 multiwatcher.EntityInfo) params.EntityInfo {
 	m.ctrl.T.Helper()
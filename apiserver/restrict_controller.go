@@ -16,6 +16,8 @@ import (
 var controllerFacadeNames = set.NewStrings(
 	"AllModelWatcher",
 	"ApplicationOffers",
+	"AuditLog",
+	"CharmhubCache",
 	"Cloud",
 	"Controller",
 	"CrossController",
@@ -42,6 +42,7 @@ var allowedMethodsDuringMigration = map[string]set.Strings{
 		"BestAPIVersion",
 		"AllAddresses",
 		"PublicKeys",
+		"SSHUserCertificate",
 		"Proxy",
 	),
 	"Pinger": set.NewStrings(
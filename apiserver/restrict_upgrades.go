@@ -43,6 +43,7 @@ var allowedMethodsDuringUpgrades = map[string]set.Strings{
 		"BestAPIVersion",
 		"AllAddresses",
 		"PublicKeys",
+		"SSHUserCertificate",
 		"Proxy",
 		"Leader",
 	),
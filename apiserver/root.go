@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/core/cache"
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/core/leadership"
@@ -607,12 +608,34 @@ func (ctx *facadeContext) SingularClaimer() (lease.Claimer, error) {
 func (ctx *facadeContext) HTTPClient(purpose facade.HTTPClientPurpose) facade.HTTPClient {
 	switch purpose {
 	case facade.CharmhubHTTPClient:
-		return ctx.r.shared.charmhubHTTPClient
+		return ctx.charmhubHTTPClient()
 	default:
 		return nil
 	}
 }
 
+// charmhubHTTPClient returns the client used to talk to Charmhub,
+// which goes through the controller's Charmhub cache when a cache mode
+// is configured.
+func (ctx *facadeContext) charmhubHTTPClient() facade.HTTPClient {
+	client := ctx.r.shared.charmhubHTTPClient
+	mode := ctx.r.shared.charmhubCacheMode()
+	if mode == "" {
+		return client
+	}
+	st, err := ctx.r.shared.statePool.SystemState()
+	if err != nil {
+		ctx.r.shared.logger.Errorf("cannot use charmhub cache: %v", err)
+		return client
+	}
+	return charmhub.NewCachingHTTPClient(client, st.CharmhubCache(), charmhub.CacheMode(mode), ctx.r.shared.logger)
+}
+
+// AuditLog is part of the facade.Context interface.
+func (ctx *facadeContext) AuditLog() facade.AuditLog {
+	return ctx.r.shared.auditLog
+}
+
 // ControllerDB returns a TrackedDB reference for the controller database.
 func (ctx *facadeContext) ControllerDB() (coredatabase.TrackedDB, error) {
 	db, err := ctx.r.shared.dbGetter.GetDB(coredatabase.ControllerNS)
@@ -7,9 +7,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
 
 	"github.com/juju/juju/apiserver/facade"
 	jujucontroller "github.com/juju/juju/controller"
@@ -18,6 +20,7 @@ import (
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
 )
@@ -48,12 +51,14 @@ type sharedServerContext struct {
 	cancel              <-chan struct{}
 	charmhubHTTPClient  facade.HTTPClient
 	dbGetter            coredatabase.DBGetter
+	auditLog            *auditLogReader
 
 	configMutex      sync.RWMutex
 	controllerConfig jujucontroller.Config
 	features         set.Strings
 
-	unsubscribe func()
+	unsubscribe         func()
+	unsubscribeAuditLog func()
 }
 
 type sharedServerConfig struct {
@@ -67,6 +72,9 @@ type sharedServerConfig struct {
 	logger              loggo.Logger
 	charmhubHTTPClient  facade.HTTPClient
 	dbGetter            coredatabase.DBGetter
+	tag                 names.Tag
+	logDir              string
+	clock               clock.Clock
 }
 
 func (c *sharedServerConfig) validate() error {
@@ -94,6 +102,12 @@ func (c *sharedServerConfig) validate() error {
 	if c.dbGetter == nil {
 		return errors.NotValidf("nil dbGetter")
 	}
+	if c.tag == nil {
+		return errors.NotValidf("nil tag")
+	}
+	if c.clock == nil {
+		return errors.NotValidf("nil clock")
+	}
 	return nil
 }
 
@@ -124,11 +138,34 @@ func newSharedServerContext(config sharedServerConfig) (*sharedServerContext, er
 		return nil, errors.Trace(err)
 	}
 	ctx.unsubscribe = unsubscribe
+
+	ctx.auditLog = &auditLogReader{
+		hub:    ctx.centralHub,
+		origin: config.tag.String(),
+		logDir: config.logDir,
+		clock:  config.clock,
+		logger: config.logger,
+		controllerIDs: func() ([]string, error) {
+			st, err := ctx.statePool.SystemState()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return st.ControllerIds()
+		},
+	}
+	unsubscribe, err = ctx.centralHub.Subscribe(apiserver.AuditLogQueryTopic, ctx.auditLog.onQuery)
+	if err != nil {
+		ctx.unsubscribe()
+		ctx.logger.Criticalf("programming error in subscribe function: %v", err)
+		return nil, errors.Trace(err)
+	}
+	ctx.unsubscribeAuditLog = unsubscribe
 	return ctx, nil
 }
 
 func (c *sharedServerContext) Close() {
 	c.unsubscribe()
+	c.unsubscribeAuditLog()
 }
 
 func (c *sharedServerContext) onConfigChanged(topic string, data controller.ConfigChangedMessage, err error) {
@@ -158,6 +195,12 @@ func (c *sharedServerContext) featureEnabled(flag string) bool {
 	return c.features.Contains(flag)
 }
 
+func (c *sharedServerContext) charmhubCacheMode() string {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+	return c.controllerConfig.CharmhubCacheMode()
+}
+
 func (c *sharedServerContext) maxDebugLogDuration() time.Duration {
 	c.configMutex.RLock()
 	defer c.configMutex.RUnlock()
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
 	"github.com/juju/pubsub/v2"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v3/workertest"
@@ -82,6 +83,9 @@ func (s *sharedServerContextSuite) SetUpTest(c *gc.C) {
 		controllerConfig:    controllerConfig,
 		logger:              loggo.GetLogger("test"),
 		dbGetter:            StubDBGetter{},
+		tag:                 names.NewMachineTag("0"),
+		logDir:              c.MkDir(),
+		clock:               clock.WallClock,
 	}
 }
 
@@ -134,6 +138,20 @@ func (s *sharedServerContextSuite) TestConfigNoControllerconfig(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "nil controllerConfig not valid")
 }
 
+func (s *sharedServerContextSuite) TestConfigNoTag(c *gc.C) {
+	s.config.tag = nil
+	err := s.config.validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil tag not valid")
+}
+
+func (s *sharedServerContextSuite) TestConfigNoClock(c *gc.C) {
+	s.config.clock = nil
+	err := s.config.validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil clock not valid")
+}
+
 func (s *sharedServerContextSuite) TestNewCallsConfigValidate(c *gc.C) {
 	s.config.statePool = nil
 	ctx, err := newSharedServerContext(s.config)
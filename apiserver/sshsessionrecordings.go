@@ -0,0 +1,86 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/core/virtualhostname"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// sshSessionRecordingsHandler stores the recordings of SSH sessions
+// proxied through the controller's SSH server, streaming them to the
+// blob storage of the target's model.
+type sshSessionRecordingsHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP implements [http.Handler].
+func (h *sshSessionRecordingsHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		h.sendError(resp, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+		return
+	}
+	if err := h.save(req); err != nil {
+		h.sendError(resp, err)
+		return
+	}
+	resp.WriteHeader(http.StatusOK)
+}
+
+// save stores the recording in the request body, described by the
+// request's query parameters.
+func (h *sshSessionRecordingsHandler) save(req *http.Request) error {
+	defer func() { _ = req.Body.Close() }()
+
+	if ctype := req.Header.Get("Content-Type"); ctype != params.ContentTypeAsciicast {
+		return errors.BadRequestf("expected Content-Type %q, got %q", params.ContentTypeAsciicast, ctype)
+	}
+	if req.ContentLength < 0 {
+		return errors.BadRequestf("missing Content-Length")
+	}
+	query := req.URL.Query()
+	info, err := virtualhostname.Parse(query.Get("target"))
+	if err != nil {
+		return errors.NewBadRequest(err, "failed to parse target")
+	}
+	started, err := time.Parse(time.RFC3339Nano, query.Get("started"))
+	if err != nil {
+		return errors.NewBadRequest(err, "failed to parse start time")
+	}
+	ended, err := time.Parse(time.RFC3339Nano, query.Get("ended"))
+	if err != nil {
+		return errors.NewBadRequest(err, "failed to parse end time")
+	}
+
+	st, err := h.ctxt.statePool().Get(info.ModelUUID())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Release()
+	_, err = st.AddSSHSessionRecording(state.AddSSHSessionRecordingArgs{
+		User:           query.Get("user"),
+		KeyFingerprint: query.Get("key-fingerprint"),
+		Target:         info.String(),
+		Started:        started,
+		Ended:          ended,
+		Data:           req.Body,
+		Size:           req.ContentLength,
+	})
+	return errors.Trace(err)
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *sshSessionRecordingsHandler) sendError(w http.ResponseWriter, err error) {
+	err, status := apiservererrors.ServerErrorAndStatus(err)
+	if err := sendStatusAndJSON(w, status, err); err != nil {
+		logger.Errorf("%v", err)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/virtualhostname"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type sshSessionRecordingsSuite struct {
+	apiserverBaseSuite
+	machineTag names.Tag
+	password   string
+	nonce      string
+	target     virtualhostname.Info
+	started    time.Time
+}
+
+var _ = gc.Suite(&sshSessionRecordingsSuite{})
+
+func (s *sshSessionRecordingsSuite) SetUpTest(c *gc.C) {
+	s.apiserverBaseSuite.SetUpTest(c)
+	s.nonce = "nonce"
+	m, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: s.nonce,
+		Jobs:  []state.MachineJob{state.JobManageModel},
+	})
+	s.machineTag = m.Tag()
+	s.password = password
+
+	var err error
+	s.target, err = virtualhostname.NewInfoMachineTarget(s.State.ModelUUID(), "0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.started = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+}
+
+func (s *sshSessionRecordingsSuite) recordingsURL(target string) string {
+	query := url.Values{
+		"user":    {"alice"},
+		"target":  {target},
+		"started": {s.started.Format(time.RFC3339Nano)},
+		"ended":   {s.started.Add(time.Minute).Format(time.RFC3339Nano)},
+	}
+	u := s.URL("/ssh-session-recordings", query)
+	return u.String()
+}
+
+func (s *sshSessionRecordingsSuite) send(c *gc.C, url, contentType, body string) *http.Response {
+	return apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "POST",
+		URL:         url,
+		Tag:         s.machineTag.String(),
+		Password:    s.password,
+		Nonce:       s.nonce,
+		ContentType: contentType,
+		Body:        strings.NewReader(body),
+	})
+}
+
+func (s *sshSessionRecordingsSuite) TestNoAuth(c *gc.C) {
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "POST",
+		URL:         s.recordingsURL(s.target.String()),
+		ContentType: params.ContentTypeAsciicast,
+		Body:        strings.NewReader("{}\n"),
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusUnauthorized)
+}
+
+func (s *sshSessionRecordingsSuite) TestRejectsUserLogins(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "sekrit"})
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "POST",
+		URL:         s.recordingsURL(s.target.String()),
+		Tag:         user.Tag().String(),
+		Password:    "sekrit",
+		ContentType: params.ContentTypeAsciicast,
+		Body:        strings.NewReader("{}\n"),
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusForbidden)
+}
+
+func (s *sshSessionRecordingsSuite) TestSave(c *gc.C) {
+	body := "{\"version\":2}\n[0.5,\"o\",\"hello\"]\n"
+	resp := s.send(c, s.recordingsURL(s.target.String()), params.ContentTypeAsciicast, body)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+
+	recordings, err := s.State.SSHSessionRecordings(state.SSHSessionRecordingFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recordings, gc.HasLen, 1)
+	c.Check(recordings[0].User, gc.Equals, "alice")
+	c.Check(recordings[0].Target, gc.Equals, s.target.String())
+	c.Check(recordings[0].Started, gc.Equals, s.started)
+	c.Check(recordings[0].Size, gc.Equals, int64(len(body)))
+
+	_, r, err := s.State.SSHSessionRecording(recordings[0].ID)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, body)
+}
+
+func (s *sshSessionRecordingsSuite) TestBadContentType(c *gc.C) {
+	resp := s.send(c, s.recordingsURL(s.target.String()), "text/plain", "hello")
+	body := apitesting.AssertResponse(c, resp, http.StatusBadRequest, params.ContentTypeJSON)
+	c.Check(string(body), jc.Contains, `expected Content-Type "application/x-asciicast"`)
+}
+
+func (s *sshSessionRecordingsSuite) TestBadTarget(c *gc.C) {
+	resp := s.send(c, s.recordingsURL("bogus"), params.ContentTypeAsciicast, "{}\n")
+	body := apitesting.AssertResponse(c, resp, http.StatusBadRequest, params.ContentTypeJSON)
+	c.Check(string(body), jc.Contains, "failed to parse target")
+}
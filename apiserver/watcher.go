@@ -95,6 +95,15 @@ func NewAllWatcher(context facade.Context) (facade.Facade, error) {
 	return newAllWatcher(context, newAllWatcherDeltaTranslater())
 }
 
+// newAllWatcherWithoutStorage returns a new API server endpoint for
+// interacting with a watcher, for older clients that do not know how to
+// decode storage instance deltas.
+func newAllWatcherWithoutStorage(context facade.Context) (facade.Facade, error) {
+	return newAllWatcher(context, withoutStorageDeltaTranslater{
+		DeltaTranslater: newAllWatcherDeltaTranslater(),
+	})
+}
+
 // Next will return the current state of everything on the first call
 // and subsequent calls will
 func (aw *SrvAllWatcher) Next() (params.AllWatcherNextResults, error) {
@@ -126,6 +135,18 @@ type DeltaTranslater interface {
 	TranslateBlock(multiwatcher.EntityInfo) params.EntityInfo
 	TranslateAction(multiwatcher.EntityInfo) params.EntityInfo
 	TranslateApplicationOffer(multiwatcher.EntityInfo) params.EntityInfo
+	TranslateStorageInstance(multiwatcher.EntityInfo) params.EntityInfo
+}
+
+// withoutStorageDeltaTranslater drops all storage instance deltas, as
+// clients of older facade versions will fail to decode the unknown kind.
+type withoutStorageDeltaTranslater struct {
+	DeltaTranslater
+}
+
+// TranslateStorageInstance implements DeltaTranslater.
+func (withoutStorageDeltaTranslater) TranslateStorageInstance(multiwatcher.EntityInfo) params.EntityInfo {
+	return nil
 }
 
 func translate(dt DeltaTranslater, deltas []multiwatcher.Delta) []params.Delta {
@@ -159,6 +180,8 @@ func translate(dt DeltaTranslater, deltas []multiwatcher.Delta) []params.Delta {
 			converted = dt.TranslateAction(delta.Entity)
 		case multiwatcher.ApplicationOfferKind:
 			converted = dt.TranslateApplicationOffer(delta.Entity)
+		case multiwatcher.StorageInstanceKind:
+			converted = dt.TranslateStorageInstance(delta.Entity)
 		default:
 			// converted stays nil
 		}
@@ -450,6 +473,8 @@ func (aw allWatcherDeltaTranslater) TranslateRelation(info multiwatcher.EntityIn
 		ModelUUID: orig.ModelUUID,
 		Key:       orig.Key,
 		Id:        orig.ID,
+		Life:      orig.Life,
+		Status:    aw.translateStatus(orig.Status),
 		Endpoints: aw.translateEndpoints(orig.Endpoints),
 	}
 }
@@ -475,6 +500,25 @@ func (aw allWatcherDeltaTranslater) translateEndpoints(eps []multiwatcher.Endpoi
 	return result
 }
 
+func (aw allWatcherDeltaTranslater) TranslateStorageInstance(info multiwatcher.EntityInfo) params.EntityInfo {
+	orig, ok := info.(*multiwatcher.StorageInstanceInfo)
+	if !ok {
+		logger.Criticalf("consistency error: %s", pretty.Sprint(info))
+		return nil
+	}
+	return &params.StorageInstanceInfo{
+		ModelUUID:       orig.ModelUUID,
+		Id:              orig.ID,
+		Kind:            orig.Kind,
+		Life:            orig.Life,
+		Owner:           orig.Owner,
+		StorageName:     orig.StorageName,
+		Pool:            orig.Pool,
+		Size:            orig.Size,
+		AttachmentCount: orig.AttachmentCount,
+	}
+}
+
 func (aw allWatcherDeltaTranslater) TranslateAnnotation(info multiwatcher.EntityInfo) params.EntityInfo {
 	orig, ok := info.(*multiwatcher.AnnotationInfo)
 	if !ok {
@@ -95,6 +95,7 @@ func (s *allWatcherSuite) TestTranslate(c *gc.C) {
 		dt.EXPECT().TranslateBlock(gomock.Any()).Return(nil),
 		dt.EXPECT().TranslateAction(gomock.Any()).Return(nil),
 		dt.EXPECT().TranslateApplicationOffer(gomock.Any()).Return(nil),
+		dt.EXPECT().TranslateStorageInstance(gomock.Any()).Return(nil),
 	)
 
 	deltas := []multiwatcher.Delta{
@@ -110,10 +111,23 @@ func (s *allWatcherSuite) TestTranslate(c *gc.C) {
 		newDelta(&multiwatcher.BlockInfo{}),
 		newDelta(&multiwatcher.ActionInfo{}),
 		newDelta(&multiwatcher.ApplicationOfferInfo{}),
+		newDelta(&multiwatcher.StorageInstanceInfo{}),
 	}
 	_ = translate(dt, deltas)
 }
 
+func (s *allWatcherSuite) TestTranslateWithoutStorage(c *gc.C) {
+	translator := withoutStorageDeltaTranslater{
+		DeltaTranslater: newAllWatcherDeltaTranslater(),
+	}
+	deltas := translate(translator, []multiwatcher.Delta{
+		newDelta(&multiwatcher.StorageInstanceInfo{ID: "data/0"}),
+		newDelta(&multiwatcher.ApplicationOfferInfo{OfferName: "mysql"}),
+	})
+	c.Assert(deltas, gc.HasLen, 1)
+	c.Assert(deltas[0].Entity, gc.FitsTypeOf, &params.ApplicationOfferInfo{})
+}
+
 func (s *allWatcherSuite) TestTranslateModelEmpty(c *gc.C) {
 	translator := newAllWatcherDeltaTranslater()
 	entityInfo := translator.TranslateModel(&multiwatcher.ModelInfo{
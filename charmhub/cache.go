@@ -0,0 +1,506 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+// CacheMode describes how a caching HTTP client uses its cache.
+type CacheMode string
+
+const (
+	// CacheModeFallback sends requests to Charmhub, caching successful
+	// responses, and serves cached responses when Charmhub can't be
+	// reached.
+	CacheModeFallback CacheMode = "fallback"
+
+	// CacheModeOffline serves requests from the cache only, without
+	// ever contacting Charmhub.
+	CacheModeOffline CacheMode = "offline"
+)
+
+// CacheEntryKind describes what a cache entry holds.
+type CacheEntryKind string
+
+const (
+	// CacheEntryRefresh holds the result of a single refresh action.
+	CacheEntryRefresh CacheEntryKind = "refresh"
+
+	// CacheEntryCharm holds a charm or bundle archive.
+	CacheEntryCharm CacheEntryKind = "charm"
+
+	// CacheEntryResource holds the content of a charm resource.
+	CacheEntryResource CacheEntryKind = "resource"
+
+	// CacheEntryMetadata holds the response to an info, find or
+	// resources query.
+	CacheEntryMetadata CacheEntryKind = "metadata"
+)
+
+// CacheEntry describes a cached Charmhub response or download.
+type CacheEntry struct {
+	// Key uniquely identifies the entry, and is derived from the
+	// request the entry answers.
+	Key string `json:"key"`
+
+	// Kind is the kind of content held by the entry.
+	Kind CacheEntryKind `json:"kind"`
+
+	// Name is the name of the charm, resource or query that the
+	// entry holds, for display.
+	Name string `json:"name"`
+
+	// Revision is the revision of the charm held by refresh entries.
+	Revision int `json:"revision,omitempty"`
+
+	// ContentType is the content type of the original response.
+	ContentType string `json:"content-type,omitempty"`
+
+	// Size and SHA256 describe the cached content.
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256,omitempty"`
+
+	// Created holds when the entry was added to the cache, and
+	// LastUsed when it was last served from it.
+	Created  time.Time `json:"created,omitempty"`
+	LastUsed time.Time `json:"last-used,omitempty"`
+}
+
+// Cache stores the responses to Charmhub requests.
+type Cache interface {
+	// Get returns the entry with the given key along with a reader for
+	// its content, or an error satisfying errors.IsNotFound. The
+	// caller must close the reader.
+	Get(ctx context.Context, key string) (CacheEntry, io.ReadCloser, error)
+
+	// Put stores the entry, reading its content from r and replacing
+	// any existing entry with the same key.
+	Put(ctx context.Context, entry CacheEntry, r io.Reader) error
+}
+
+// NewCachingHTTPClient returns an HTTPClient which stores responses from
+// Charmhub in the cache, and serves them from the cache according to
+// mode. It is intended to wrap the HTTP client passed to NewClient, so
+// that info, find, refresh and download requests are all cached.
+func NewCachingHTTPClient(client HTTPClient, cache Cache, mode CacheMode, logger Logger) HTTPClient {
+	return &cachingHTTPClient{
+		client: client,
+		cache:  cache,
+		mode:   mode,
+		logger: logger,
+	}
+}
+
+type cachingHTTPClient struct {
+	client HTTPClient
+	cache  Cache
+	mode   CacheMode
+	logger Logger
+}
+
+// Do implements HTTPClient.
+func (c *cachingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	switch {
+	case req.Method == http.MethodPost && path.Base(req.URL.Path) == "refresh":
+		return c.doRefresh(req)
+	case req.Method == http.MethodGet:
+		return c.doGet(req)
+	}
+	if c.mode == CacheModeOffline {
+		return nil, errors.NotSupportedf("%s %q with charmhub cache mode %q", req.Method, req.URL.Path, c.mode)
+	}
+	return c.client.Do(req)
+}
+
+// doGet serves GET requests, which are cached by the path and query of
+// the requested URL so that entries don't depend on the host serving
+// them.
+func (c *cachingHTTPClient) doGet(req *http.Request) (*http.Response, error) {
+	entry := getCacheEntry(req.URL)
+	if c.mode == CacheModeOffline {
+		resp, err := c.cachedResponse(req, entry.Key)
+		if errors.Is(err, errors.NotFound) {
+			return cacheMissResponse(req, fmt.Sprintf("%s %q not in the charmhub cache", entry.Kind, entry.Name)), nil
+		}
+		return resp, errors.Trace(err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err == nil && resp.StatusCode == http.StatusOK {
+		return c.storeResponse(req, entry, resp)
+	}
+	if !unreachable(resp, err) {
+		return resp, err
+	}
+	cached, cacheErr := c.cachedResponse(req, entry.Key)
+	if cacheErr != nil {
+		if !errors.Is(cacheErr, errors.NotFound) {
+			c.logger.Errorf("reading charmhub cache entry %q: %v", entry.Key, cacheErr)
+		}
+		return resp, err
+	}
+	c.logger.Tracef("charmhub unreachable, serving %q from the cache", entry.Key)
+	discard(resp)
+	return cached, nil
+}
+
+// doRefresh serves refresh requests, which are cached per action so
+// that the same charm can be found regardless of how requests are
+// batched.
+func (c *cachingHTTPClient) doRefresh(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var refreshReq transport.RefreshRequest
+	if err := json.Unmarshal(body, &refreshReq); err != nil {
+		return nil, errors.Annotate(err, "parsing refresh request")
+	}
+	keys := refreshCacheKeys(refreshReq)
+
+	if c.mode == CacheModeOffline {
+		responses, _ := c.cachedRefresh(req.Context(), refreshReq, keys)
+		return jsonResponse(req, http.StatusOK, responses)
+	}
+
+	resp, err := c.client.Do(req)
+	if err == nil && resp.StatusCode == http.StatusOK {
+		return c.storeRefresh(req, keys, resp)
+	}
+	if !unreachable(resp, err) {
+		return resp, err
+	}
+	responses, hits := c.cachedRefresh(req.Context(), refreshReq, keys)
+	if hits == 0 {
+		return resp, err
+	}
+	c.logger.Tracef("charmhub unreachable, serving %d of %d refresh actions from the cache", hits, len(refreshReq.Actions))
+	discard(resp)
+	return jsonResponse(req, http.StatusOK, responses)
+}
+
+// cachedRefresh returns the responses to the request's actions from the
+// cache, along with how many were found. Actions which aren't cached
+// result in a not-found error.
+func (c *cachingHTTPClient) cachedRefresh(
+	ctx context.Context, req transport.RefreshRequest, keys map[string]string,
+) (transport.RefreshResponses, int) {
+	var (
+		responses transport.RefreshResponses
+		hits      int
+	)
+	for _, action := range req.Actions {
+		result, err := c.cachedRefreshResult(ctx, keys[action.InstanceKey])
+		if err != nil {
+			if !errors.Is(err, errors.NotFound) {
+				c.logger.Errorf("reading charmhub cache entry %q: %v", keys[action.InstanceKey], err)
+			}
+			result = transport.RefreshResponse{
+				Result: "error",
+				Error: &transport.APIError{
+					Code:    transport.ErrorCodeNotFound,
+					Message: fmt.Sprintf("%s of %s not in the charmhub cache", action.Action, describeAction(req, action)),
+				},
+			}
+		} else {
+			hits++
+		}
+		result.InstanceKey = action.InstanceKey
+		responses.Results = append(responses.Results, result)
+	}
+	return responses, hits
+}
+
+func (c *cachingHTTPClient) cachedRefreshResult(ctx context.Context, key string) (transport.RefreshResponse, error) {
+	var result transport.RefreshResponse
+	_, r, err := c.cache.Get(ctx, key)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer func() { _ = r.Close() }()
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return result, errors.Annotatef(err, "decoding cached refresh result")
+	}
+	return result, nil
+}
+
+// storeRefresh caches each successful result in the refresh response,
+// and returns the response with its body intact.
+func (c *cachingHTTPClient) storeRefresh(req *http.Request, keys map[string]string, resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading refresh response")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var responses transport.RefreshResponses
+	if err := json.Unmarshal(body, &responses); err != nil {
+		// Let the client deal with the malformed response.
+		return resp, nil
+	}
+	for _, result := range responses.Results {
+		key, ok := keys[result.InstanceKey]
+		if !ok || result.Error != nil {
+			continue
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entry := CacheEntry{
+			Key:         key,
+			Kind:        CacheEntryRefresh,
+			Name:        result.Name,
+			Revision:    result.Entity.Revision,
+			ContentType: jsonContentType,
+			Size:        int64(len(data)),
+		}
+		if err := c.cache.Put(req.Context(), entry, bytes.NewReader(data)); err != nil {
+			c.logger.Errorf("caching refresh result for %q: %v", result.Name, err)
+		}
+	}
+	return resp, nil
+}
+
+// storeResponse caches the response body, and returns a response which
+// reads the body from a temporary copy.
+func (c *cachingHTTPClient) storeResponse(req *http.Request, entry CacheEntry, resp *http.Response) (*http.Response, error) {
+	original := resp.Body
+	defer func() { _ = original.Close() }()
+
+	f, err := os.CreateTemp("", "charmhub-cache-")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	body := &tempFileReadCloser{File: f}
+	size, err := io.Copy(f, original)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = body.Close()
+		return nil, errors.Annotatef(err, "reading %q", req.URL.Path)
+	}
+
+	entry.ContentType = resp.Header.Get("Content-Type")
+	entry.Size = size
+	if err := c.cache.Put(req.Context(), entry, f); err != nil {
+		c.logger.Errorf("caching %q: %v", entry.Key, err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = body.Close()
+		return nil, errors.Trace(err)
+	}
+	resp.Body = body
+	resp.ContentLength = size
+	return resp, nil
+}
+
+func (c *cachingHTTPClient) cachedResponse(req *http.Request, key string) (*http.Response, error) {
+	entry, r, err := c.cache.Get(req.Context(), key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	header := make(http.Header)
+	if entry.ContentType != "" {
+		header.Set("Content-Type", entry.ContentType)
+	}
+	return &http.Response{
+		Status:        http.StatusText(http.StatusOK),
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          r,
+		ContentLength: entry.Size,
+		Request:       req,
+	}, nil
+}
+
+// GetCacheKey returns the key of the cache entry holding the response
+// to a GET request for the given URL.
+func GetCacheKey(u *url.URL) string {
+	return getCacheEntry(u).Key
+}
+
+func getCacheEntry(u *url.URL) CacheEntry {
+	target := u.EscapedPath()
+	if u.RawQuery != "" {
+		target += "?" + u.RawQuery
+	}
+	entry := CacheEntry{
+		Key:  "get:" + target,
+		Kind: CacheEntryMetadata,
+		Name: path.Base(u.Path),
+	}
+	switch {
+	case strings.Contains(u.Path, "/charms/download/"):
+		entry.Kind = CacheEntryCharm
+	case strings.Contains(u.Path, "/resources/download/"):
+		entry.Kind = CacheEntryResource
+	default:
+		// Name metadata entries after the query, e.g. "info/mysql".
+		if i := strings.Index(u.Path, "/"+serverEntity+"/"); i >= 0 {
+			entry.Name = u.Path[i+len(serverEntity)+2:]
+		}
+	}
+	return entry
+}
+
+// refreshCacheKey holds the parts of a refresh action, and the context
+// it refers to, that determine its result. Instance keys and the
+// currently installed revision are left out, so that a cached result
+// can answer the same question asked by any model.
+type refreshCacheKey struct {
+	Action            string                              `json:"action"`
+	ID                string                              `json:"id,omitempty"`
+	Name              string                              `json:"name,omitempty"`
+	Channel           string                              `json:"channel,omitempty"`
+	Revision          *int                                `json:"revision,omitempty"`
+	Base              *transport.Base                     `json:"base,omitempty"`
+	ResourceRevisions []transport.RefreshResourceRevision `json:"resource-revisions,omitempty"`
+}
+
+// refreshCacheKeys returns the cache key for each action in the
+// request, indexed by the action's instance key.
+func refreshCacheKeys(req transport.RefreshRequest) map[string]string {
+	contexts := make(map[string]transport.RefreshRequestContext, len(req.Context))
+	for _, ctx := range req.Context {
+		contexts[ctx.InstanceKey] = ctx
+	}
+	keys := make(map[string]string, len(req.Actions))
+	for _, action := range req.Actions {
+		key := refreshCacheKey{
+			Action:            action.Action,
+			ID:                stringValue(action.ID),
+			Name:              stringValue(action.Name),
+			Channel:           stringValue(action.Channel),
+			Revision:          action.Revision,
+			Base:              action.Base,
+			ResourceRevisions: action.ResourceRevisions,
+		}
+		if ctx, ok := contexts[action.InstanceKey]; ok && action.Action == string(refreshAction) {
+			key.ID = ctx.ID
+			key.Channel = ctx.TrackingChannel
+			base := ctx.Base
+			key.Base = &base
+		}
+		data, _ := json.Marshal(key)
+		sum := sha256.Sum256(data)
+		keys[action.InstanceKey] = "refresh:" + hex.EncodeToString(sum[:])
+	}
+	return keys
+}
+
+func describeAction(req transport.RefreshRequest, action transport.RefreshRequestAction) string {
+	if action.Name != nil {
+		return fmt.Sprintf("%q", *action.Name)
+	}
+	if action.ID != nil {
+		return fmt.Sprintf("id %q", *action.ID)
+	}
+	for _, ctx := range req.Context {
+		if ctx.InstanceKey == action.InstanceKey {
+			return fmt.Sprintf("id %q", ctx.ID)
+		}
+	}
+	return fmt.Sprintf("instance %q", action.InstanceKey)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// unreachable reports whether the outcome of a request means that
+// Charmhub couldn't be reached, or couldn't answer.
+func unreachable(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading request body")
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func discard(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+func jsonResponse(req *http.Request, status int, value interface{}) (*http.Response, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	header := make(http.Header)
+	header.Set("Content-Type", jsonContentType)
+	return &http.Response{
+		Status:        http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+func cacheMissResponse(req *http.Request, message string) *http.Response {
+	resp, _ := jsonResponse(req, http.StatusNotFound, struct {
+		ErrorList transport.APIErrors `json:"error-list"`
+	}{
+		ErrorList: transport.APIErrors{{
+			Code:    transport.ErrorCodeNotFound,
+			Message: message,
+		}},
+	})
+	return resp
+}
+
+// tempFileReadCloser removes the temporary file it reads from
+// when closed.
+type tempFileReadCloser struct {
+	*os.File
+}
+
+func (f *tempFileReadCloser) Close() error {
+	err := f.File.Close()
+	_ = os.Remove(f.File.Name())
+	return err
+}
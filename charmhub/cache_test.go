@@ -0,0 +1,269 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+type CacheSuite struct {
+	testing.IsolationSuite
+
+	cache *fakeCache
+	base  RefreshBase
+}
+
+var _ = gc.Suite(&CacheSuite{})
+
+func (s *CacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.cache = &fakeCache{entries: make(map[string]fakeCacheEntry)}
+	s.base = RefreshBase{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"}
+}
+
+func (s *CacheSuite) newClient(c *gc.C, httpClient HTTPClient, mode CacheMode) *Client {
+	logger := loggo.GetLogger("juju.charmhub")
+	client, err := NewClient(Config{
+		URL:        "https://api.charmhub.io",
+		HTTPClient: NewCachingHTTPClient(httpClient, s.cache, mode, logger),
+		Logger:     logger,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *CacheSuite) refreshConfig(c *gc.C, instanceKey string) RefreshConfig {
+	config, err := RefreshOne(instanceKey, "mysql-id", 1, "8.0/stable", s.base)
+	c.Assert(err, jc.ErrorIsNil)
+	return config
+}
+
+func refreshResponse(c *gc.C, req *http.Request, revision int) *http.Response {
+	var refreshReq transport.RefreshRequest
+	err := json.NewDecoder(req.Body).Decode(&refreshReq)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := json.Marshal(transport.RefreshResponses{
+		Results: []transport.RefreshResponse{{
+			InstanceKey: refreshReq.Actions[0].InstanceKey,
+			ID:          "mysql-id",
+			Name:        "mysql",
+			Result:      "refresh",
+			Entity: transport.RefreshEntity{
+				Name:     "mysql",
+				Revision: revision,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return &http.Response{
+		Header:     MakeContentTypeHeader("application/json"),
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(data)),
+	}
+}
+
+func (s *CacheSuite) TestRefreshServedFromCacheWhenUnreachable(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	httpClient := NewMockHTTPClient(ctrl)
+	gomock.InOrder(
+		httpClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			return refreshResponse(c, req, 42), nil
+		}),
+		httpClient.EXPECT().Do(gomock.Any()).Return(nil, errors.New("connection refused")),
+	)
+	client := s.newClient(c, httpClient, CacheModeFallback)
+
+	results, err := client.Refresh(context.Background(), s.refreshConfig(c, "first"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].Entity.Revision, gc.Equals, 42)
+
+	entries := s.cache.list()
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Kind, gc.Equals, CacheEntryRefresh)
+	c.Check(entries[0].Name, gc.Equals, "mysql")
+	c.Check(entries[0].Revision, gc.Equals, 42)
+
+	// The same question asked by another application is
+	// answered from the cache.
+	results, err = client.Refresh(context.Background(), s.refreshConfig(c, "second"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].InstanceKey, gc.Equals, "second")
+	c.Check(results[0].Entity.Revision, gc.Equals, 42)
+	c.Check(results[0].Error, gc.IsNil)
+}
+
+func (s *CacheSuite) TestRefreshUnreachableNotCached(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	httpClient := NewMockHTTPClient(ctrl)
+	httpClient.EXPECT().Do(gomock.Any()).Return(nil, errors.New("connection refused"))
+	client := s.newClient(c, httpClient, CacheModeFallback)
+
+	_, err := client.Refresh(context.Background(), s.refreshConfig(c, "first"))
+	c.Assert(err, gc.ErrorMatches, ".*connection refused")
+}
+
+func (s *CacheSuite) TestRefreshOffline(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	httpClient := NewMockHTTPClient(ctrl)
+	httpClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		return refreshResponse(c, req, 7), nil
+	})
+	_, err := s.newClient(c, httpClient, CacheModeFallback).Refresh(
+		context.Background(), s.refreshConfig(c, "first"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The offline client never uses the HTTP client.
+	client := s.newClient(c, NewMockHTTPClient(ctrl), CacheModeOffline)
+	results, err := client.Refresh(context.Background(), s.refreshConfig(c, "second"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].Entity.Revision, gc.Equals, 7)
+
+	config, err := RefreshOne("third", "postgresql-id", 1, "14/stable", s.base)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = client.Refresh(context.Background(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].InstanceKey, gc.Equals, "third")
+	c.Assert(results[0].Error, gc.NotNil)
+	c.Check(results[0].Error.Code, gc.Equals, transport.ErrorCodeNotFound)
+	c.Check(results[0].Error.Message, gc.Equals, `refresh of id "postgresql-id" not in the charmhub cache`)
+}
+
+func (s *CacheSuite) TestDownloadCachedAndServedOffline(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	resourceURL := MustParseURL(c, "https://api.charmhub.io/api/v1/resources/download/charm_mysql-id.image_3")
+	httpClient := NewMockHTTPClient(ctrl)
+	httpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+		Header:     MakeContentTypeHeader("application/octet-stream"),
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString("resource content")),
+	}, nil)
+
+	r, err := s.newClient(c, httpClient, CacheModeFallback).DownloadResource(context.Background(), resourceURL)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Close(), jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "resource content")
+
+	entries := s.cache.list()
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Key, gc.Equals, GetCacheKey(resourceURL))
+	c.Check(entries[0].Kind, gc.Equals, CacheEntryResource)
+	c.Check(entries[0].Name, gc.Equals, "charm_mysql-id.image_3")
+	c.Check(entries[0].Size, gc.Equals, int64(16))
+
+	// The entry doesn't depend on which host served it.
+	mirrorURL := MustParseURL(c, "http://mirror.internal/api/v1/resources/download/charm_mysql-id.image_3")
+	client := s.newClient(c, NewMockHTTPClient(ctrl), CacheModeOffline)
+	r, err = client.DownloadResource(context.Background(), mirrorURL)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err = io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Close(), jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "resource content")
+}
+
+func (s *CacheSuite) TestDownloadOfflineNotCached(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	client := s.newClient(c, NewMockHTTPClient(ctrl), CacheModeOffline)
+	_, err := client.DownloadResource(context.Background(),
+		MustParseURL(c, "https://api.charmhub.io/api/v1/charms/download/mysql-id_42.charm"))
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *CacheSuite) TestStoreAnswersPassedThrough(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	resourceURL := MustParseURL(c, "https://api.charmhub.io/api/v1/charms/download/mysql-id_42.charm")
+	s.cache.entries[GetCacheKey(resourceURL)] = fakeCacheEntry{
+		entry: CacheEntry{Key: GetCacheKey(resourceURL)},
+		data:  []byte("stale"),
+	}
+	httpClient := NewMockHTTPClient(ctrl)
+	httpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(&bytes.Buffer{}),
+	}, nil)
+
+	client := s.newClient(c, httpClient, CacheModeFallback)
+	_, err := client.DownloadResource(context.Background(), resourceURL)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *CacheSuite) TestGetCacheEntry(c *gc.C) {
+	entry := getCacheEntry(MustParseURL(c, "https://api.charmhub.io/v2/charms/info/mysql?channel=8.0%2Fstable"))
+	c.Check(entry, jc.DeepEquals, CacheEntry{
+		Key:  "get:/v2/charms/info/mysql?channel=8.0%2Fstable",
+		Kind: CacheEntryMetadata,
+		Name: "info/mysql",
+	})
+	entry = getCacheEntry(MustParseURL(c, "https://api.charmhub.io/api/v1/charms/download/mysql-id_42.charm"))
+	c.Check(entry, jc.DeepEquals, CacheEntry{
+		Key:  "get:/api/v1/charms/download/mysql-id_42.charm",
+		Kind: CacheEntryCharm,
+		Name: "mysql-id_42.charm",
+	})
+}
+
+type fakeCacheEntry struct {
+	entry CacheEntry
+	data  []byte
+}
+
+type fakeCache struct {
+	entries map[string]fakeCacheEntry
+}
+
+func (f *fakeCache) Get(_ context.Context, key string) (CacheEntry, io.ReadCloser, error) {
+	e, ok := f.entries[key]
+	if !ok {
+		return CacheEntry{}, nil, errors.NotFoundf("cache entry %q", key)
+	}
+	return e.entry, io.NopCloser(bytes.NewReader(e.data)), nil
+}
+
+func (f *fakeCache) Put(_ context.Context, entry CacheEntry, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	f.entries[entry.Key] = fakeCacheEntry{entry: entry, data: data}
+	return nil
+}
+
+func (f *fakeCache) list() []CacheEntry {
+	var entries []CacheEntry
+	for _, e := range f.entries {
+		entries = append(entries, e.entry)
+	}
+	return entries
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// A cache archive is a tar file holding exported cache entries. Each
+// entry is held in its own directory, as an entry.json file describing
+// it followed by a content file, so that archives can be written and
+// read as a stream.
+const (
+	cacheArchiveEntryFile   = "entry.json"
+	cacheArchiveContentFile = "content"
+)
+
+// CacheArchiveWriter writes cache entries to a cache archive.
+type CacheArchiveWriter struct {
+	tw    *tar.Writer
+	count int
+}
+
+// NewCacheArchiveWriter returns a CacheArchiveWriter which writes a
+// cache archive to w. The archive is complete once Close is called.
+func NewCacheArchiveWriter(w io.Writer) *CacheArchiveWriter {
+	return &CacheArchiveWriter{tw: tar.NewWriter(w)}
+}
+
+// Add adds the entry to the archive, reading entry.Size bytes of
+// content from r.
+func (w *CacheArchiveWriter) Add(entry CacheEntry, r io.Reader) error {
+	dir := fmt.Sprintf("%06d", w.count)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modTime := entry.Created
+	if modTime.IsZero() {
+		modTime = time.Now()
+	}
+	if err := w.tw.WriteHeader(&tar.Header{
+		Name:    path.Join(dir, cacheArchiveEntryFile),
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := w.tw.Write(data); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.tw.WriteHeader(&tar.Header{
+		Name:    path.Join(dir, cacheArchiveContentFile),
+		Mode:    0644,
+		Size:    entry.Size,
+		ModTime: modTime,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(w.tw, r); err != nil {
+		return errors.Annotatef(err, "writing %q", entry.Key)
+	}
+	w.count++
+	return nil
+}
+
+// Close completes the archive. It doesn't close the
+// underlying writer.
+func (w *CacheArchiveWriter) Close() error {
+	return errors.Trace(w.tw.Close())
+}
+
+// ReadCacheArchive reads the cache archive from r, calling add with each
+// entry and a reader for its content. The content is checked against the
+// entry's size and SHA256 hash once add has read it all.
+func ReadCacheArchive(r io.Reader, add func(CacheEntry, io.Reader) error) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Annotate(err, "reading charmhub cache archive")
+		}
+		if path.Base(hdr.Name) != cacheArchiveEntryFile {
+			return errors.NotValidf("charmhub cache archive file %q", hdr.Name)
+		}
+		var entry CacheEntry
+		if err := json.NewDecoder(tr).Decode(&entry); err != nil {
+			return errors.Annotatef(err, "reading %q", hdr.Name)
+		}
+		if entry.Key == "" {
+			return errors.NotValidf("charmhub cache archive entry %q without key", hdr.Name)
+		}
+
+		contentHdr, err := tr.Next()
+		if err != nil {
+			return errors.Annotatef(err, "reading content of %q", entry.Key)
+		}
+		if contentHdr.Name != path.Join(path.Dir(hdr.Name), cacheArchiveContentFile) {
+			return errors.NotValidf("charmhub cache archive file %q", contentHdr.Name)
+		}
+		if contentHdr.Size != entry.Size {
+			return errors.NotValidf("content of %q with size %d, expected %d", entry.Key, contentHdr.Size, entry.Size)
+		}
+
+		hash := sha256.New()
+		if err := add(entry, io.TeeReader(tr, hash)); err != nil {
+			return errors.Annotatef(err, "adding %q", entry.Key)
+		}
+		if entry.SHA256 != "" {
+			// Make sure the whole of the content has been hashed.
+			if _, err := io.Copy(hash, tr); err != nil {
+				return errors.Annotatef(err, "reading content of %q", entry.Key)
+			}
+			if sum := hex.EncodeToString(hash.Sum(nil)); sum != entry.SHA256 {
+				return errors.NotValidf("content of %q with SHA256 %s, expected %s", entry.Key, sum, entry.SHA256)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CacheArchiveSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CacheArchiveSuite{})
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeCacheArchive(c *gc.C, entries []CacheEntry, contents []string) *bytes.Buffer {
+	var buf bytes.Buffer
+	w := NewCacheArchiveWriter(&buf)
+	for i, entry := range entries {
+		err := w.Add(entry, strings.NewReader(contents[i]))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return &buf
+}
+
+func (s *CacheArchiveSuite) TestRoundTrip(c *gc.C) {
+	entries := []CacheEntry{{
+		Key:    "refresh:abc",
+		Kind:   CacheEntryRefresh,
+		Name:   "mysql",
+		Size:   2,
+		SHA256: sha256Hex("{}"),
+	}, {
+		Key:  "get:/api/v1/charms/download/mysql-id_42.charm",
+		Kind: CacheEntryCharm,
+		Name: "mysql-id_42.charm",
+		Size: 7,
+	}}
+	buf := writeCacheArchive(c, entries, []string{"{}", "archive"})
+
+	var (
+		read     []CacheEntry
+		contents []string
+	)
+	err := ReadCacheArchive(buf, func(entry CacheEntry, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		c.Assert(err, jc.ErrorIsNil)
+		read = append(read, entry)
+		contents = append(contents, string(data))
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(read, jc.DeepEquals, entries)
+	c.Check(contents, jc.DeepEquals, []string{"{}", "archive"})
+}
+
+func (s *CacheArchiveSuite) TestReadChecksHash(c *gc.C) {
+	buf := writeCacheArchive(c, []CacheEntry{{
+		Key:    "refresh:abc",
+		Size:   2,
+		SHA256: sha256Hex("[]"),
+	}}, []string{"{}"})
+
+	err := ReadCacheArchive(buf, func(CacheEntry, io.Reader) error {
+		return nil
+	})
+	c.Assert(err, gc.ErrorMatches, `content of "refresh:abc" with SHA256 [0-9a-f]+, expected [0-9a-f]+ not valid`)
+}
+
+func (s *CacheArchiveSuite) TestReadNotAnArchive(c *gc.C) {
+	err := ReadCacheArchive(strings.NewReader("not a tar file"), func(CacheEntry, io.Reader) error {
+		return nil
+	})
+	c.Assert(err, gc.ErrorMatches, "reading charmhub cache archive: .*")
+}
@@ -70,7 +70,9 @@ func (*importSuite) TestImports(c *gc.C) {
 		"core/presence",
 		"core/relation",
 		"core/resources",
+		"core/schedule",
 		"core/secrets",
+		"core/sshpolicy",
 		"core/status",
 		"core/watcher",
 		"docker",
@@ -85,6 +87,9 @@ func (*importSuite) TestImports(c *gc.C) {
 		"juju/osenv",
 		"juju/sockets",
 		"logfwd",
+		"logfwd/httppush",
+		"logfwd/loki",
+		"logfwd/otlp",
 		"logfwd/syslog",
 		"mongo",
 		"network",
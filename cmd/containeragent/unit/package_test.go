@@ -117,6 +117,7 @@ func (*importSuite) TestImports(c *gc.C) {
 		"core/raftlease",
 		"core/relation",
 		"core/resources",
+		"core/schedule",
 		"core/secrets",
 		"core/snap",
 		"core/status",
@@ -145,6 +146,9 @@ func (*importSuite) TestImports(c *gc.C) {
 		"juju/sockets",
 		"jujuclient",
 		"logfwd",
+		"logfwd/httppush",
+		"logfwd/loki",
+		"logfwd/otlp",
 		"logfwd/syslog",
 		"mongo", // TODO: move mongo dependency from JUJU CLI if we decide to split the `agent.Config` for controller and machineagent/unitagent/containeragent.
 		"network",
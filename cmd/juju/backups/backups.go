@@ -24,9 +24,13 @@ import (
 type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
-	Create(notes string, noDownload bool) (*params.BackupsMetadataResult, error)
+	Create(notes string, noDownload bool, encryptTo []string) (*params.BackupsMetadataResult, error)
 	// Download pulls the backup archive file.
 	Download(filename string) (io.ReadCloser, error)
+	// List returns the backup archives held on the controller.
+	List() ([]params.BackupsArchive, error)
+	// Remove deletes the specified backup archives from the controller.
+	Remove(ids ...string) ([]params.ErrorResult, error)
 }
 
 // CommandBase is the base type for backups sub-commands.
@@ -113,7 +117,11 @@ size (B):              {{.Size}}
 stored:                {{.Stored}} 
 started:               {{.Started}} 
 finished:              {{.Finished}} 
-
+{{with .Remote}}uploaded to:           {{.Endpoint}}/{{.Bucket}}/{{.Key}} 
+uploaded etag:         {{.ETag}} 
+{{end}}{{with .Encryption}}encryption format:     {{.Format}} 
+{{range .Recipients}}encrypted to:          {{.}} 
+{{end}}{{end}}
 notes:                 {{.Notes}} 
 `
 
@@ -133,6 +141,8 @@ type MetadataParams struct {
 	Hostname       string
 	JujuVersion    version.Number
 	Base           string
+	Remote         *params.BackupsRemoteResult
+	Encryption     *params.BackupsEncryptionResult
 }
 
 func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
@@ -152,6 +162,8 @@ func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
 		result.Hostname,
 		result.Version,
 		result.Base,
+		result.Remote,
+		result.Encryption,
 	}
 	t := template.Must(template.New("template").Parse(backupMetadataTemplate))
 	content := bytes.Buffer{}
@@ -6,6 +6,8 @@ package backups
 import (
 	"fmt"
 	"io"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/juju/cmd/v3"
@@ -38,11 +40,25 @@ filesystem; the same path must exist on each controller if using HA.
 Use --verbose to see extra information about backup.
 
 To access remote backups stored on the controller, see 'juju download-backup'.
+
+If the "backup-s3-endpoint" controller config key is set, the controller
+also uploads the archive to that S3-compatible object store, in the bucket
+given by "backup-s3-bucket".
+
+Use --encrypt-to to encrypt the archive to a public key, given either
+as the key itself or as the path to a file holding it. Both age X25519
+keys (age1...) and SSH ed25519 or RSA keys are accepted, and the flag
+may be repeated. The archive is also encrypted to any keys in the
+"backup-encryption-recipients" controller config key. An encrypted
+archive can be decrypted with the matching private key using age:
+
+    age --decrypt -i <identity-file> -o <archive> <encrypted-archive>
 `
 
 const createExamples = `
     juju create-backup 
     juju create-backup --no-download
+    juju create-backup --encrypt-to ~/.ssh/id_ed25519.pub
 `
 
 // NewCreateCommand returns a command used to create backups.
@@ -59,6 +75,9 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// EncryptTo holds the public keys, or files holding them, to
+	// encrypt the backup to.
+	EncryptTo []string
 }
 
 // Info implements Command.Info.
@@ -80,6 +99,7 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive. DEPRECATED.")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.Var(cmd.NewAppendStringsValue(&c.EncryptTo), "encrypt-to", "Encrypt the archive to this public key or public key file")
 	c.fs = f
 }
 
@@ -119,7 +139,12 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 		ctx.Warningf(downloadWarning)
 	}
 
-	metadataResult, copyFrom, err := c.create(client)
+	recipients, err := c.recipients(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	metadataResult, copyFrom, err := c.create(client, recipients)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -128,6 +153,10 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 		fmt.Fprintln(ctx.Stdout, c.metadata(metadataResult))
 	}
 
+	if remote := metadataResult.Remote; remote != nil {
+		ctx.Infof("Backup uploaded to %s/%s/%s", remote.Endpoint, remote.Bucket, remote.Key)
+	}
+
 	if c.NoDownload {
 		ctx.Infof("Remote backup stored on the controller as %v", metadataResult.Filename)
 	} else {
@@ -169,8 +198,30 @@ func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom st
 	return nil
 }
 
-func (c *createCommand) create(client APIClient) (*params.BackupsMetadataResult, string, error) {
-	result, err := client.Create(c.Notes, c.NoDownload)
+// recipients returns the public keys to encrypt the backup to,
+// reading those given as files.
+func (c *createCommand) recipients(ctx *cmd.Context) ([]string, error) {
+	var keys []string
+	for _, value := range c.EncryptTo {
+		if strings.HasPrefix(value, "age1") || strings.HasPrefix(value, "ssh-") {
+			keys = append(keys, value)
+			continue
+		}
+		data, err := os.ReadFile(ctx.AbsPath(value))
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading public key file %q", value)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				keys = append(keys, line)
+			}
+		}
+	}
+	return keys, nil
+}
+
+func (c *createCommand) create(client APIClient, recipients []string) (*params.BackupsMetadataResult, string, error) {
+	result, err := client.Create(c.Notes, c.NoDownload, recipients)
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
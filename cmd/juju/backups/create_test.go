@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd/v3"
@@ -16,6 +17,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/rpc/params"
 )
 
 type createSuite struct {
@@ -213,6 +215,61 @@ func (s *createSuite) TestNoDownload(c *gc.C) {
 	c.Check(s.command.Filename, gc.Equals, backups.NotSet)
 }
 
+func (s *createSuite) TestNoDownloadUploaded(c *gc.C) {
+	s.metaresult.Remote = &params.BackupsRemoteResult{
+		Endpoint: "https://minio.example.com",
+		Bucket:   "juju-backups",
+		Key:      "prod/backup-filename",
+		ETag:     "0123456789abcdef-1",
+	}
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Backup uploaded to https://minio.example.com/juju-backups/prod/backup-filename
+Remote backup stored on the controller as backup-filename
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `
+finished:              0001-01-01 00:00:00 +0000 UTC 
+uploaded to:           https://minio.example.com/juju-backups/prod/backup-filename 
+uploaded etag:         0123456789abcdef-1 
+
+notes:`[1:])
+}
+
+func (s *createSuite) TestNoDownloadEncrypted(c *gc.C) {
+	const (
+		ageKey = "age1840d7j408juj3lknktrxhfhz5jkd7x64ya9x7a0ccnp7wyl37p0sse0tq2"
+		sshKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIjnSl4H7szDweaz/rUNLA6kcxHeBLEkBWd8rOs79qLU backup"
+	)
+	keyFile := filepath.Join(c.MkDir(), "id_ed25519.pub")
+	err := os.WriteFile(keyFile, []byte("# backup key\n"+sshKey+"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.metaresult.Encryption = &params.BackupsEncryptionResult{
+		Format:     "age-encryption.org/v1",
+		Recipients: []string{"SHA256:one", "SHA256:two"},
+	}
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--encrypt-to", ageKey, "--encrypt-to", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(client.encryptTo, jc.DeepEquals, []string{ageKey, sshKey})
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `
+finished:              0001-01-01 00:00:00 +0000 UTC 
+encryption format:     age-encryption.org/v1 
+encrypted to:          SHA256:one 
+encrypted to:          SHA256:two 
+
+notes:`[1:])
+}
+
+func (s *createSuite) TestEncryptToMissingFile(c *gc.C) {
+	s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--encrypt-to", "missing.pub")
+	c.Check(err, gc.ErrorMatches, `reading public key file "missing.pub": .*`)
+}
+
 func (s *createSuite) TestFilenameAndNoDownload(c *gc.C) {
 	s.setSuccess()
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--filename", "backup.tgz")
@@ -27,6 +27,10 @@ type DownloadCommand struct {
 	*downloadCommand
 }
 
+type RemoveCommand struct {
+	*removeCommand
+}
+
 func NewCreateCommandForTest(store jujuclient.ClientStore) (cmd.Command, *CreateCommand) {
 	c := &createCommand{}
 	c.SetClientStore(store)
@@ -38,3 +42,15 @@ func NewDownloadCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Down
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c), &DownloadCommand{c}
 }
+
+func NewListCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RemoveCommand) {
+	c := &removeCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &RemoveCommand{c}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const listDoc = `
+backups lists the backup archives retained on the controller, oldest
+first. These include archives created with 'juju create-backup
+--no-download' and those created on the schedule set by the
+"backup-schedule" controller config key.
+
+Archives are listed from the controller machine serving the API
+connection. In an HA controller, each controller machine holds its
+own archives.
+`
+
+const listExamples = `
+    juju backups
+    juju backups --format yaml
+`
+
+// NewListCommand returns a command used to list retained backups.
+func NewListCommand() cmd.Command {
+	return modelcmd.Wrap(&listCommand{})
+}
+
+// listCommand is the sub-command for listing retained backup archives.
+type listCommand struct {
+	CommandBase
+	out cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *listCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "backups",
+		Purpose:  "List the backup archives retained on the controller.",
+		Doc:      listDoc,
+		Aliases:  []string{"list-backups"},
+		Examples: listExamples,
+		SeeAlso: []string{
+			"create-backup",
+			"download-backup",
+			"remove-backup",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatListTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// formattedArchive is the serialisation of a backup archive
+// for yaml and json output.
+type formattedArchive struct {
+	ID      string    `json:"id" yaml:"id"`
+	Size    int64     `json:"size" yaml:"size"`
+	Created time.Time `json:"created" yaml:"created"`
+	Machine string    `json:"machine" yaml:"machine"`
+}
+
+// Run implements Command.Run.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	archives, err := client.List()
+	if err != nil {
+		return errors.Annotate(err, "cannot list backups")
+	}
+	if len(archives) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No backups to display.")
+		return nil
+	}
+
+	result := make([]formattedArchive, len(archives))
+	for i, archive := range archives {
+		result[i] = formattedArchive{
+			ID:      archive.ID,
+			Size:    archive.Size,
+			Created: archive.Created,
+			Machine: archive.Machine,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatListTabular(writer io.Writer, value interface{}) error {
+	archives, ok := value.([]formattedArchive)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", archives, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Created\tSize\tMachine\tID")
+	for _, archive := range archives {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			archive.Created.UTC().Format(time.RFC3339),
+			humanize.IBytes(uint64(archive.Size)),
+			archive.Machine,
+			archive.ID,
+		)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/rpc/params"
+)
+
+type listSuite struct {
+	BaseBackupsSuite
+	command cmd.Command
+}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.command = backups.NewListCommandForTest(s.store)
+}
+
+func (s *listSuite) setArchives() *fakeAPIClient {
+	client := s.setSuccess()
+	client.archives = []params.BackupsArchive{{
+		ID:      "/var/lib/juju/backups/juju-backup-20250301-023000.tar.gz",
+		Size:    2048,
+		Created: time.Date(2025, 3, 1, 2, 30, 0, 0, time.UTC),
+		Machine: "0",
+	}}
+	return client
+}
+
+func (s *listSuite) TestTabular(c *gc.C) {
+	client := s.setArchives()
+	ctx, err := cmdtesting.RunCommand(c, s.command)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Created               Size     Machine  ID
+2025-03-01T02:30:00Z  2.0 KiB  0        /var/lib/juju/backups/juju-backup-20250301-023000.tar.gz
+`[1:])
+	client.CheckCalls(c, "List")
+}
+
+func (s *listSuite) TestYAML(c *gc.C) {
+	s.setArchives()
+	ctx, err := cmdtesting.RunCommand(c, s.command, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: /var/lib/juju/backups/juju-backup-20250301-023000.tar.gz
+  size: 2048
+  created: 2025-03-01T02:30:00Z
+  machine: "0"
+`[1:])
+}
+
+func (s *listSuite) TestEmpty(c *gc.C) {
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.command)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No backups to display.\n")
+}
+
+func (s *listSuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.command)
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
+
+func (s *listSuite) TestArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.command, "extra")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
@@ -163,12 +163,15 @@ func (s *BaseBackupsSuite) checkArchive(c *gc.C) {
 type fakeAPIClient struct {
 	metaresult *params.BackupsMetadataResult
 	archive    io.ReadCloser
+	archives   []params.BackupsArchive
+	removeErrs map[string]error
 	err        error
 
-	calls []string
-	args  []string
-	idArg string
-	notes string
+	calls     []string
+	args      []string
+	idArg     string
+	notes     string
+	encryptTo []string
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -185,10 +188,11 @@ func (f *fakeAPIClient) CheckArgs(c *gc.C, args ...string) {
 	c.Check(f.args, jc.DeepEquals, args)
 }
 
-func (c *fakeAPIClient) Create(notes string, noDownload bool) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(notes string, noDownload bool, encryptTo []string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
 	c.args = append(c.args, notes, fmt.Sprintf("%t", noDownload))
 	c.notes = notes
+	c.encryptTo = encryptTo
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -206,6 +210,29 @@ func (c *fakeAPIClient) Download(id string) (io.ReadCloser, error) {
 	return c.archive, nil
 }
 
+func (c *fakeAPIClient) List() ([]params.BackupsArchive, error) {
+	c.calls = append(c.calls, "List")
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.archives, nil
+}
+
+func (c *fakeAPIClient) Remove(ids ...string) ([]params.ErrorResult, error) {
+	c.calls = append(c.calls, "Remove")
+	c.args = append(c.args, ids...)
+	if c.err != nil {
+		return nil, c.err
+	}
+	results := make([]params.ErrorResult, len(ids))
+	for i, id := range ids {
+		if err, ok := c.removeErrs[id]; ok {
+			results[i].Error = &params.Error{Message: err.Error()}
+		}
+	}
+	return results, nil
+}
+
 func (c *fakeAPIClient) Close() error {
 	return nil
 }
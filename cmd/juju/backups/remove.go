@@ -0,0 +1,88 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const removeDoc = `
+remove-backup deletes one or more backup archives retained on the
+controller. Archives are identified by the full path shown by
+'juju backups'.
+`
+
+const removeExamples = `
+    juju remove-backup /full/path/to/backup/on/controller
+`
+
+// NewRemoveCommand returns a command used to remove retained backups.
+func NewRemoveCommand() cmd.Command {
+	return modelcmd.Wrap(&removeCommand{})
+}
+
+// removeCommand is the sub-command for removing retained backup archives.
+type removeCommand struct {
+	CommandBase
+	// IDs holds the backup archives to remove.
+	IDs []string
+}
+
+// Info implements Command.Info.
+func (c *removeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "remove-backup",
+		Args:     "/full/path/to/backup/on/controller [...]",
+		Purpose:  "Remove backup archives retained on the controller.",
+		Doc:      removeDoc,
+		Examples: removeExamples,
+		SeeAlso: []string{
+			"backups",
+			"create-backup",
+		},
+	})
+}
+
+// Init implements Command.Init.
+func (c *removeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing filename")
+	}
+	c.IDs = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.Remove(c.IDs...)
+	if err != nil {
+		return errors.Annotate(err, "cannot remove backups")
+	}
+	failed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Errorf("removing %s: %v", c.IDs[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("removed %s", c.IDs[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+)
+
+type removeSuite struct {
+	BaseBackupsSuite
+	wrappedCommand cmd.Command
+	command        *backups.RemoveCommand
+}
+
+var _ = gc.Suite(&removeSuite{})
+
+func (s *removeSuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.wrappedCommand, s.command = backups.NewRemoveCommandForTest(s.store)
+}
+
+func (s *removeSuite) TestMissingFilename(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Check(err, gc.ErrorMatches, "missing filename")
+}
+
+func (s *removeSuite) TestOkay(c *gc.C) {
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "/backups/one.tar.gz", "/backups/two.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "removed /backups/one.tar.gz\nremoved /backups/two.tar.gz\n")
+	client.CheckCalls(c, "Remove")
+	client.CheckArgs(c, "/backups/one.tar.gz", "/backups/two.tar.gz")
+}
+
+func (s *removeSuite) TestPartialFailure(c *gc.C) {
+	client := s.setSuccess()
+	client.removeErrs = map[string]error{
+		"/etc/hostname": errors.New(`backup file "/etc/hostname" not found`),
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "/etc/hostname", "/backups/one.tar.gz")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "removed /backups/one.tar.gz\n")
+	c.Check(c.GetTestLog(), jc.Contains, `removing /etc/hostname: backup file "/etc/hostname" not found`)
+}
+
+func (s *removeSuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "/backups/one.tar.gz")
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
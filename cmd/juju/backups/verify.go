@@ -0,0 +1,259 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+const verifyDoc = `
+verify-backup checks that a downloaded backup archive holds what is
+needed to restore the controller, without restoring it. The archive is
+unpacked locally and checked for:
+
+ - the backup metadata, and the archive checksum
+ - a dump of the juju database, including the oplog and the required
+   collections, and any collections unknown to this version of juju
+ - the agent configuration and binaries of the controller machine
+
+The Juju version of the controller and the models held in the archive
+are reported. No connection to a controller is needed.
+
+The archive checksum is compared with the one given by --checksum, as
+reported by 'juju create-backup', or otherwise with any recorded in the
+archive metadata.
+
+Use --decrypt-with to verify an encrypted archive, passing the age
+identity file or SSH private key matching a key it was encrypted to.
+`
+
+const verifyExamples = `
+    juju verify-backup juju-backup-20250301-023000.tar.gz
+    juju verify-backup backup.tar.gz --checksum 2jmj7l5rSw0yVb/vlWAYkK/YBwk=
+    juju verify-backup backup.tar.gz --decrypt-with ~/.ssh/id_ed25519
+`
+
+// NewVerifyCommand returns a command used to verify backup archives.
+func NewVerifyCommand() cmd.Command {
+	return &verifyCommand{}
+}
+
+// verifyCommand is the sub-command for verifying a backup archive.
+type verifyCommand struct {
+	cmd.CommandBase
+	out cmd.Output
+
+	// Filename is the backup archive to verify.
+	Filename string
+	// Checksum is the expected checksum of the archive.
+	Checksum string
+	// DecryptWith is the identity file to decrypt the archive with.
+	DecryptWith string
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "verify-backup",
+		Args:     "<file>",
+		Purpose:  "Verify that a backup archive can be restored.",
+		Doc:      verifyDoc,
+		Examples: verifyExamples,
+		SeeAlso: []string{
+			"create-backup",
+			"download-backup",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.Checksum, "checksum", "", "The expected checksum of the archive")
+	f.StringVar(&c.DecryptWith, "decrypt-with", "", "The identity file to decrypt the archive with")
+	c.out.AddFlags(f, "summary", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"summary": formatVerifySummary,
+	})
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("missing backup filename")
+	case 1:
+		c.Filename = args[0]
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+// formattedModel is the serialisation of a model held in a backup
+// archive.
+type formattedModel struct {
+	Name  string `json:"name" yaml:"name"`
+	Owner string `json:"owner" yaml:"owner"`
+	Type  string `json:"type" yaml:"type"`
+	UUID  string `json:"uuid" yaml:"uuid"`
+}
+
+// formattedVerifyResult is the serialisation of a backup archive
+// verification.
+type formattedVerifyResult struct {
+	Filename           string           `json:"filename" yaml:"filename"`
+	Checksum           string           `json:"checksum" yaml:"checksum"`
+	ChecksumVerified   bool             `json:"checksum-verified" yaml:"checksum-verified"`
+	Encrypted          bool             `json:"encrypted" yaml:"encrypted"`
+	JujuVersion        string           `json:"juju-version,omitempty" yaml:"juju-version,omitempty"`
+	ControllerUUID     string           `json:"controller-uuid,omitempty" yaml:"controller-uuid,omitempty"`
+	Collections        int              `json:"collections" yaml:"collections"`
+	UnknownCollections []string         `json:"unknown-collections,omitempty" yaml:"unknown-collections,omitempty"`
+	AgentConfigs       []string         `json:"agent-configs" yaml:"agent-configs"`
+	AgentBinaries      []string         `json:"agent-binaries" yaml:"agent-binaries"`
+	Models             []formattedModel `json:"models" yaml:"models"`
+	Warnings           []string         `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	Problems           []string         `json:"problems,omitempty" yaml:"problems,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	args := backups.VerifyArgs{
+		Checksum:    c.Checksum,
+		Collections: state.AllCollectionNames(),
+	}
+	if c.DecryptWith != "" {
+		identityFile, err := os.Open(ctx.AbsPath(c.DecryptWith))
+		if err != nil {
+			return errors.Annotate(err, "reading identity file")
+		}
+		defer identityFile.Close()
+		args.Identities, err = backups.ParseIdentities(identityFile)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	archive, err := os.Open(ctx.AbsPath(c.Filename))
+	if err != nil {
+		return errors.Annotate(err, "opening backup archive")
+	}
+	defer archive.Close()
+
+	result, err := backups.Verify(archive, args)
+	if err != nil {
+		return errors.Annotatef(err, "cannot verify backup archive %q", c.Filename)
+	}
+
+	formatted := formattedVerifyResult{
+		Filename:           c.Filename,
+		Checksum:           result.Checksum,
+		ChecksumVerified:   result.ChecksumVerified,
+		Encrypted:          result.Encrypted,
+		Collections:        len(result.Collections),
+		UnknownCollections: result.UnknownCollections,
+		AgentConfigs:       result.AgentConfigs,
+		AgentBinaries:      result.Tools,
+		Models:             []formattedModel{},
+		Warnings:           result.Warnings,
+		Problems:           result.Problems,
+	}
+	if meta := result.Metadata; meta != nil {
+		formatted.JujuVersion = meta.Origin.Version.String()
+		formatted.ControllerUUID = meta.Controller.UUID
+	}
+	for _, model := range result.Models {
+		formatted.Models = append(formatted.Models, formattedModel{
+			Name:  model.Name,
+			Owner: model.Owner,
+			Type:  model.Type,
+			UUID:  model.UUID,
+		})
+	}
+	if err := c.out.Write(ctx, formatted); err != nil {
+		return errors.Trace(err)
+	}
+
+	if !result.OK() {
+		return errors.Errorf("backup archive %q failed verification", c.Filename)
+	}
+	return nil
+}
+
+func formatVerifySummary(writer io.Writer, value interface{}) error {
+	result, ok := value.(formattedVerifyResult)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", result, value)
+	}
+
+	checksum := result.Checksum
+	if result.ChecksumVerified {
+		checksum += " (verified)"
+	} else {
+		checksum += " (not verified)"
+	}
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "Archive:\t%s\n", result.Filename)
+	fmt.Fprintf(tw, "Checksum:\t%s\n", checksum)
+	fmt.Fprintf(tw, "Encrypted:\t%t\n", result.Encrypted)
+	fmt.Fprintf(tw, "Juju version:\t%s\n", result.JujuVersion)
+	fmt.Fprintf(tw, "Controller UUID:\t%s\n", result.ControllerUUID)
+	fmt.Fprintf(tw, "Collections:\t%d\n", result.Collections)
+	fmt.Fprintf(tw, "Agent configs:\t%s\n", joinOrNone(result.AgentConfigs))
+	fmt.Fprintf(tw, "Agent binaries:\t%s\n", joinOrNone(result.AgentBinaries))
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if len(result.Models) > 0 {
+		fmt.Fprintln(writer)
+		tw = output.TabWriter(writer)
+		fmt.Fprintln(tw, "Model\tOwner\tType\tUUID")
+		for _, model := range result.Models {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", model.Name, model.Owner, model.Type, model.UUID)
+		}
+		if err := tw.Flush(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for _, list := range []struct {
+		heading string
+		items   []string
+	}{
+		{"Warnings", result.Warnings},
+		{"Problems", result.Problems},
+	} {
+		if len(list.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(writer, "\n%s:\n", list.heading)
+		for _, item := range list.items {
+			fmt.Fprintf(writer, "  - %s\n", item)
+		}
+	}
+	return nil
+}
+
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"filippo.io/age"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	bt "github.com/juju/juju/state/backups/testing"
+)
+
+type verifySuite struct {
+	testing.IsolationSuite
+	dir string
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+}
+
+func (s *verifySuite) writeArchive(c *gc.C, files, dump []bt.File) string {
+	meta := bt.NewMetadataStarted()
+	meta.Controller.UUID = "controller-uuid"
+	archive, err := bt.NewArchive(meta, files, dump)
+	c.Assert(err, jc.ErrorIsNil)
+	filename := filepath.Join(s.dir, "backup.tar.gz")
+	err = os.WriteFile(filename, archive.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return filename
+}
+
+func (s *verifySuite) writeValidArchive(c *gc.C) string {
+	model, err := bson.Marshal(bson.M{
+		"_id":   "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"name":  "controller",
+		"owner": "admin",
+		"type":  "iaas",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return s.writeArchive(c, []bt.File{
+		{Name: "var/lib/juju/agents/machine-0/agent.conf", Content: "<agent config>"},
+		{Name: "var/lib/juju/tools/3.6.0-ubuntu-amd64/jujud", Content: "<jujud>"},
+	}, []bt.File{
+		{Name: "oplog.bson"},
+		{Name: "juju", IsDir: true},
+		{Name: "juju/controllers.bson"},
+		{Name: "juju/models.bson", Content: string(model)},
+	})
+}
+
+func (s *verifySuite) TestInitMissingFilename(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand())
+	c.Check(err, gc.ErrorMatches, "missing backup filename")
+}
+
+func (s *verifySuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand(), "one", "two")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	filename := s.writeValidArchive(c)
+
+	ctx, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand(), filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Matches, `(?s)`+
+		`Archive:          .*/backup.tar.gz\n`+
+		`Checksum:         \S+ \(not verified\)\n`+
+		`Encrypted:        false\n`+
+		`Juju version:     \S+\n`+
+		`Controller UUID:  controller-uuid\n`+
+		`Collections:      2\n`+
+		`Agent configs:    machine-0\n`+
+		`Agent binaries:   3.6.0-ubuntu-amd64\n`+
+		`\n`+
+		`Model       Owner  Type  UUID\n`+
+		`controller  admin  iaas  deadbeef-0bad-400d-8000-4b1d0d06f00d\n`+
+		`\n`+
+		`Warnings:\n`+
+		`  - no checksum to verify the archive against\n`)
+}
+
+func (s *verifySuite) TestVerifyChecksumMismatch(c *gc.C) {
+	filename := s.writeValidArchive(c)
+
+	ctx, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand(), filename, "--checksum", "bogus", "--format", "yaml")
+	c.Assert(err, gc.ErrorMatches, `backup archive ".*/backup.tar.gz" failed verification`)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "checksum-verified: false\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Matches, `(?s).*problems:\n- 'checksum mismatch: archive has "\S+", expected "bogus"'\n`)
+}
+
+func (s *verifySuite) TestVerifyIncomplete(c *gc.C) {
+	filename := s.writeArchive(c, nil, []bt.File{{Name: "oplog.bson"}})
+
+	ctx, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand(), filename)
+	c.Assert(err, gc.ErrorMatches, `backup archive ".*/backup.tar.gz" failed verification`)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `
+Problems:
+  - no juju database in database dump
+  - no agent configuration in files bundle
+  - no agent binaries in files bundle
+`)
+}
+
+func (s *verifySuite) TestVerifyEncrypted(c *gc.C) {
+	filename := s.writeValidArchive(c)
+	plain, err := os.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	identity, err := age.GenerateX25519Identity()
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, identity.Recipient())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write(plain)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	err = os.WriteFile(filename, buf.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	identityFile := filepath.Join(s.dir, "identity.txt")
+	err = os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, backups.NewVerifyCommand(), filename)
+	c.Check(err, gc.ErrorMatches, `cannot verify backup archive ".*": while unpacking archive: backup archive is encrypted; an identity is required to decrypt it`)
+
+	ctx, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand(), filename, "--decrypt-with", identityFile, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `"encrypted":true`)
+}
+
+func (s *verifySuite) TestVerifyMissingFile(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand(), filepath.Join(s.dir, "missing.tar.gz"))
+	c.Check(err, gc.ErrorMatches, "opening backup archive: .*")
+}
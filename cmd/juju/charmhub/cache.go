@@ -0,0 +1,304 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/client/charmhubcache"
+	"github.com/juju/juju/charmhub"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/rpc/params"
+)
+
+const (
+	cacheSummary = "Lists, seeds, exports and prunes the controller's Charmhub cache."
+	cacheDoc     = `
+When the charmhub-cache-mode controller config is set, the controller
+keeps the Charmhub responses, charm archives and resources it fetches
+in a cache of its own, and uses them for later deployments, refreshes
+and charm revision checks:
+
+    fallback: Charmhub is used when it can be reached, and the cache
+              when it can't.
+    offline:  Charmhub is never contacted, only the cache is used.
+
+With no options, the entries in the cache are listed.
+
+An air-gapped controller can be seeded with archives exported from a
+controller that can reach Charmhub: deploy or refresh the charms needed
+there, export its cache with --export, carry the archive across and
+load it with --seed. Seeding replaces entries with the same key.
+
+Entries are removed with --prune, selected by --kind, --name and how
+long they have been unused for; all the given criteria must match.
+--all removes every entry.
+
+Controller superuser access is required.
+`
+	cacheExamples = `
+    juju charmhub-cache
+    juju charmhub-cache --export charmhub-cache.tar
+    juju charmhub-cache --seed charmhub-cache.tar
+    juju charmhub-cache --prune --unused-for 720h
+    juju charmhub-cache --prune --kind charm --name mysql
+    juju charmhub-cache --prune --all
+`
+)
+
+// CharmhubCacheAPI is the API used by the charmhub-cache command.
+type CharmhubCacheAPI interface {
+	Entries() ([]params.CharmhubCacheEntry, error)
+	Prune(params.CharmhubCachePruneArgs) ([]params.CharmhubCacheEntry, error)
+	Seed(io.Reader) ([]params.CharmhubCacheEntry, error)
+	Export() (io.ReadCloser, error)
+	Close() error
+}
+
+// NewCacheCommand returns a command to manage the controller's
+// Charmhub cache.
+func NewCacheCommand() cmd.Command {
+	c := &cacheCommand{
+		clock: clock.WallClock,
+	}
+	c.newAPIFunc = func() (CharmhubCacheAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return charmhubcache.NewClient(root), nil
+	}
+	return modelcmd.WrapController(c)
+}
+
+// cacheCommand supplies the "charmhub-cache" CLI command.
+type cacheCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	newAPIFunc func() (CharmhubCacheAPI, error)
+	clock      clock.Clock
+
+	seed      string
+	export    string
+	prune     bool
+	unusedFor time.Duration
+	kind      string
+	name      string
+	all       bool
+}
+
+// Info implements cmd.Command.
+func (c *cacheCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "charmhub-cache",
+		Purpose:  cacheSummary,
+		Doc:      cacheDoc,
+		Examples: cacheExamples,
+		SeeAlso: []string{
+			"controller-config",
+			"download",
+		},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *cacheCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.seed, "seed", "", "Add the entries in this exported cache archive to the cache")
+	f.StringVar(&c.export, "export", "", "Write every entry in the cache to this archive")
+	f.BoolVar(&c.prune, "prune", false, "Remove the entries matching --kind, --name and --unused-for, or all of them with --all")
+	f.DurationVar(&c.unusedFor, "unused-for", 0, "With --prune, only remove entries unused for at least this long")
+	f.StringVar(&c.kind, "kind", "", "With --prune, only remove entries of this kind, one of [refresh, charm, resource, metadata]")
+	f.StringVar(&c.name, "name", "", "With --prune, only remove entries with this name")
+	f.BoolVar(&c.all, "all", false, "With --prune, remove every entry")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatCacheEntriesTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *cacheCommand) Init(args []string) error {
+	modes := 0
+	for _, set := range []bool{c.seed != "", c.export != "", c.prune} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return errors.New("only one of --seed, --export or --prune can be used")
+	}
+
+	criteria := c.unusedFor != 0 || c.kind != "" || c.name != ""
+	if !c.prune {
+		if criteria || c.all {
+			return errors.New("--unused-for, --kind, --name and --all can only be used with --prune")
+		}
+		return cmd.CheckEmpty(args)
+	}
+	switch {
+	case c.all && criteria:
+		return errors.New("--all cannot be used with --unused-for, --kind or --name")
+	case !c.all && !criteria:
+		return errors.New("--prune requires --unused-for, --kind, --name or --all")
+	case c.unusedFor < 0:
+		return errors.New("--unused-for must not be negative")
+	}
+	switch charmhub.CacheEntryKind(c.kind) {
+	case "", charmhub.CacheEntryRefresh, charmhub.CacheEntryCharm, charmhub.CacheEntryResource, charmhub.CacheEntryMetadata:
+	default:
+		return errors.Errorf("--kind must be one of [refresh, charm, resource, metadata], got %q", c.kind)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// formattedCacheEntry is the serialisation of a Charmhub cache entry.
+type formattedCacheEntry struct {
+	Key      string    `json:"key" yaml:"key"`
+	Kind     string    `json:"kind" yaml:"kind"`
+	Name     string    `json:"name" yaml:"name"`
+	Revision int       `json:"revision,omitempty" yaml:"revision,omitempty"`
+	Size     int64     `json:"size" yaml:"size"`
+	SHA256   string    `json:"sha256" yaml:"sha256"`
+	Created  time.Time `json:"created,omitempty" yaml:"created,omitempty"`
+	LastUsed time.Time `json:"last-used,omitempty" yaml:"last-used,omitempty"`
+}
+
+// Run implements cmd.Command.
+func (c *cacheCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	switch {
+	case c.seed != "":
+		return errors.Trace(c.seedCache(ctx, api))
+	case c.export != "":
+		return errors.Trace(c.exportCache(ctx, api))
+	case c.prune:
+		return errors.Trace(c.pruneCache(ctx, api))
+	}
+
+	entries, err := api.Entries()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("The Charmhub cache is empty.")
+		return nil
+	}
+	return errors.Trace(c.out.Write(ctx, formatCacheEntries(entries)))
+}
+
+func (c *cacheCommand) seedCache(ctx *cmd.Context, api CharmhubCacheAPI) error {
+	f, err := os.Open(ctx.AbsPath(c.seed))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	entries, err := api.Seed(f)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Added %d entries to the Charmhub cache.", len(entries))
+	return nil
+}
+
+func (c *cacheCommand) exportCache(ctx *cmd.Context, api CharmhubCacheAPI) error {
+	r, err := api.Export()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	path := ctx.AbsPath(c.export)
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return errors.Annotate(err, "exporting Charmhub cache")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Exported the Charmhub cache to %s.", c.export)
+	return nil
+}
+
+func (c *cacheCommand) pruneCache(ctx *cmd.Context, api CharmhubCacheAPI) error {
+	args := params.CharmhubCachePruneArgs{
+		Kind: c.kind,
+		Name: c.name,
+		All:  c.all,
+	}
+	if c.unusedFor != 0 {
+		since := c.clock.Now().Add(-c.unusedFor)
+		args.UnusedSince = &since
+	}
+	entries, err := api.Prune(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Removed %d entries from the Charmhub cache.", len(entries))
+	return nil
+}
+
+func formatCacheEntries(entries []params.CharmhubCacheEntry) []formattedCacheEntry {
+	result := make([]formattedCacheEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = formattedCacheEntry{
+			Key:      entry.Key,
+			Kind:     entry.Kind,
+			Name:     entry.Name,
+			Revision: entry.Revision,
+			Size:     entry.Size,
+			SHA256:   entry.SHA256,
+			Created:  entry.Created,
+			LastUsed: entry.LastUsed,
+		}
+	}
+	return result
+}
+
+func formatCacheEntriesTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]formattedCacheEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.SetColumnAlignRight(2)
+	w.SetColumnAlignRight(3)
+
+	w.Println("Kind", "Name", "Revision", "Size", "Last used")
+	for _, entry := range entries {
+		revision := ""
+		if entry.Revision != 0 {
+			revision = fmt.Sprint(entry.Revision)
+		}
+		lastUsed := entry.LastUsed
+		w.Println(entry.Kind, entry.Name, revision, entry.Size, common.FormatTime(&lastUsed, false))
+	}
+	return tw.Flush()
+}
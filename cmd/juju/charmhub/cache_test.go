@@ -0,0 +1,188 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/charmhub/mocks"
+	"github.com/juju/juju/rpc/params"
+)
+
+type cacheSuite struct {
+	testing.IsolationSuite
+
+	api *mocks.MockCharmhubCacheAPI
+	now time.Time
+}
+
+var _ = gc.Suite(&cacheSuite{})
+
+func (s *cacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.now = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *cacheSuite) setUpMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.api = mocks.NewMockCharmhubCacheAPI(ctrl)
+	s.api.EXPECT().Close()
+	return ctrl
+}
+
+func (s *cacheSuite) newCommand() *cacheCommand {
+	return &cacheCommand{
+		newAPIFunc: func() (CharmhubCacheAPI, error) {
+			return s.api, nil
+		},
+		clock: testclock.NewClock(s.now),
+	}
+}
+
+func (s *cacheSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := s.newCommand()
+	if err := cmdtesting.InitCommand(command, args); err != nil {
+		return nil, err
+	}
+	ctx := cmdtesting.Context(c)
+	return ctx, command.Run(ctx)
+}
+
+func (s *cacheSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--seed", "a.tar", "--export", "b.tar"},
+		err:  "only one of --seed, --export or --prune can be used",
+	}, {
+		args: []string{"--kind", "charm"},
+		err:  "--unused-for, --kind, --name and --all can only be used with --prune",
+	}, {
+		args: []string{"--prune"},
+		err:  "--prune requires --unused-for, --kind, --name or --all",
+	}, {
+		args: []string{"--prune", "--all", "--name", "mysql"},
+		err:  "--all cannot be used with --unused-for, --kind or --name",
+	}, {
+		args: []string{"--prune", "--unused-for", "-1h"},
+		err:  "--unused-for must not be negative",
+	}, {
+		args: []string{"--prune", "--kind", "bundle"},
+		err:  `--kind must be one of \[refresh, charm, resource, metadata\], got "bundle"`,
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("%v", t.args)
+		err := cmdtesting.InitCommand(s.newCommand(), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *cacheSuite) TestList(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.api.EXPECT().Entries().Return([]params.CharmhubCacheEntry{{
+		Key:      "get:/api/v1/charms/download/mysql-id_42.charm",
+		Kind:     "charm",
+		Name:     "mysql-id_42.charm",
+		Size:     1024,
+		SHA256:   "b5bb9d80",
+		Created:  s.now.Add(-time.Hour),
+		LastUsed: s.now,
+	}, {
+		Key:      "refresh:mysql",
+		Kind:     "refresh",
+		Name:     "mysql",
+		Revision: 42,
+		Size:     512,
+		SHA256:   "7d865e95",
+		Created:  s.now.Add(-time.Hour),
+		LastUsed: s.now,
+	}}, nil)
+
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- key: get:/api/v1/charms/download/mysql-id_42.charm
+  kind: charm
+  name: mysql-id_42.charm
+  size: 1024
+  sha256: b5bb9d80
+  created: 2025-05-01T11:00:00Z
+  last-used: 2025-05-01T12:00:00Z
+- key: refresh:mysql
+  kind: refresh
+  name: mysql
+  revision: 42
+  size: 512
+  sha256: 7d865e95
+  created: 2025-05-01T11:00:00Z
+  last-used: 2025-05-01T12:00:00Z
+`[1:])
+}
+
+func (s *cacheSuite) TestListEmpty(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.api.EXPECT().Entries().Return(nil, nil)
+
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "The Charmhub cache is empty.\n")
+}
+
+func (s *cacheSuite) TestSeed(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	path := filepath.Join(c.MkDir(), "cache.tar")
+	err := os.WriteFile(path, []byte("archive"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.EXPECT().Seed(gomock.Any()).DoAndReturn(func(r io.Reader) ([]params.CharmhubCacheEntry, error) {
+		data, err := io.ReadAll(r)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(string(data), gc.Equals, "archive")
+		return []params.CharmhubCacheEntry{{Key: "a"}, {Key: "b"}}, nil
+	})
+
+	ctx, err := s.run(c, "--seed", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added 2 entries to the Charmhub cache.\n")
+}
+
+func (s *cacheSuite) TestExport(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	path := filepath.Join(c.MkDir(), "cache.tar")
+	s.api.EXPECT().Export().Return(io.NopCloser(strings.NewReader("archive")), nil)
+
+	_, err := s.run(c, "--export", path)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := os.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "archive")
+}
+
+func (s *cacheSuite) TestPrune(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	since := s.now.Add(-720 * time.Hour)
+	s.api.EXPECT().Prune(params.CharmhubCachePruneArgs{
+		Kind:        "charm",
+		UnusedSince: &since,
+	}).Return([]params.CharmhubCacheEntry{{Key: "a"}}, nil)
+
+	ctx, err := s.run(c, "--prune", "--kind", "charm", "--unused-for", "720h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Removed 1 entries from the Charmhub cache.\n")
+}
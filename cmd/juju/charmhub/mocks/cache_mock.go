@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/cmd/juju/charmhub (interfaces: CharmhubCacheAPI)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination ./mocks/cache_mock.go github.com/juju/juju/cmd/juju/charmhub CharmhubCacheAPI
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	io "io"
+	reflect "reflect"
+
+	params "github.com/juju/juju/rpc/params"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCharmhubCacheAPI is a mock of CharmhubCacheAPI interface.
+type MockCharmhubCacheAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCharmhubCacheAPIMockRecorder
+}
+
+// MockCharmhubCacheAPIMockRecorder is the mock recorder for MockCharmhubCacheAPI.
+type MockCharmhubCacheAPIMockRecorder struct {
+	mock *MockCharmhubCacheAPI
+}
+
+// NewMockCharmhubCacheAPI creates a new mock instance.
+func NewMockCharmhubCacheAPI(ctrl *gomock.Controller) *MockCharmhubCacheAPI {
+	mock := &MockCharmhubCacheAPI{ctrl: ctrl}
+	mock.recorder = &MockCharmhubCacheAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCharmhubCacheAPI) EXPECT() *MockCharmhubCacheAPIMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockCharmhubCacheAPI) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockCharmhubCacheAPIMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCharmhubCacheAPI)(nil).Close))
+}
+
+// Entries mocks base method.
+func (m *MockCharmhubCacheAPI) Entries() ([]params.CharmhubCacheEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Entries")
+	ret0, _ := ret[0].([]params.CharmhubCacheEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Entries indicates an expected call of Entries.
+func (mr *MockCharmhubCacheAPIMockRecorder) Entries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entries", reflect.TypeOf((*MockCharmhubCacheAPI)(nil).Entries))
+}
+
+// Export mocks base method.
+func (m *MockCharmhubCacheAPI) Export() (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export")
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockCharmhubCacheAPIMockRecorder) Export() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockCharmhubCacheAPI)(nil).Export))
+}
+
+// Prune mocks base method.
+func (m *MockCharmhubCacheAPI) Prune(arg0 params.CharmhubCachePruneArgs) ([]params.CharmhubCacheEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", arg0)
+	ret0, _ := ret[0].([]params.CharmhubCacheEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockCharmhubCacheAPIMockRecorder) Prune(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockCharmhubCacheAPI)(nil).Prune), arg0)
+}
+
+// Seed mocks base method.
+func (m *MockCharmhubCacheAPI) Seed(arg0 io.Reader) ([]params.CharmhubCacheEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seed", arg0)
+	ret0, _ := ret[0].([]params.CharmhubCacheEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seed indicates an expected call of Seed.
+func (mr *MockCharmhubCacheAPIMockRecorder) Seed(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seed", reflect.TypeOf((*MockCharmhubCacheAPI)(nil).Seed), arg0)
+}
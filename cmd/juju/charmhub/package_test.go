@@ -13,6 +13,7 @@ import (
 )
 
 //go:generate go run go.uber.org/mock/mockgen -package mocks -destination ./mocks/api_mock.go github.com/juju/juju/cmd/juju/charmhub CharmHubClient
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination ./mocks/cache_mock.go github.com/juju/juju/cmd/juju/charmhub CharmhubCacheAPI
 //go:generate go run go.uber.org/mock/mockgen -package mocks -destination ./mocks/os_mock.go github.com/juju/juju/cmd/juju/charmhub OSEnviron
 //go:generate go run go.uber.org/mock/mockgen -package mocks -destination ./mocks/fsys_mock.go github.com/juju/juju/cmd/modelcmd Filesystem,ReadSeekCloser
 
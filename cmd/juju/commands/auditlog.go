@@ -0,0 +1,302 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/client/auditlog"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/rpc/params"
+)
+
+const auditLogDoc = `
+Every controller with auditing enabled records the commands run against
+it, the API requests made by them and the errors returned, in an audit
+log of its own. audit-log searches the audit logs of all the controllers
+in a highly available controller and shows the requests found, most
+recent last.
+
+Requests can be selected by the user who made them, the model they were
+made to, the conversation they were part of (one per command run), the
+API facade and method called, when they were made, and whether their
+response held errors. Model owners are ignored, as models are recorded
+by name or UUID only.
+
+The '--since' and '--until' options take the same values as for
+'juju debug-log': a time, a time of day today, or a duration ago.
+
+Controller superuser access is required. Controllers whose audit logs
+cannot be read are reported, and the requests found on the others are
+still shown.
+`
+
+const auditLogExamples = `
+Show the 100 most recent requests:
+
+    juju audit-log
+
+Show the requests made by bob to the prod model in the last day:
+
+    juju audit-log --user bob --model prod --since 24h
+
+Show the failed calls to the Application facade during an afternoon:
+
+    juju audit-log --method Application --result error --since "2025-03-01 12:00" --until "2025-03-01 18:00"
+
+Show all the requests of a conversation as JSON:
+
+    juju audit-log --conversation 3bd1b8f6a2e5c4d7 --limit 1000 --format json
+`
+
+// AuditLogAPI is the API client used by the audit-log command.
+type AuditLogAPI interface {
+	Query(params.AuditLogQueryArgs) (params.AuditLogQueryResult, error)
+	Close() error
+}
+
+func newAuditLogCommand() cmd.Command {
+	command := &auditLogCommand{
+		clock: clock.WallClock,
+		tz:    time.Local,
+	}
+	command.newAPIFunc = func() (AuditLogAPI, error) {
+		root, err := command.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return auditlog.NewClient(root), nil
+	}
+	return modelcmd.WrapController(command)
+}
+
+// auditLogCommand queries the audit logs of the controllers.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	newAPIFunc func() (AuditLogAPI, error)
+
+	args   params.AuditLogQueryArgs
+	method string
+	since  string
+	until  string
+	utc    bool
+
+	clock clock.Clock
+	tz    *time.Location
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "audit-log",
+		Purpose:  "Search the audit logs of the controllers.",
+		Doc:      auditLogDoc,
+		Examples: auditLogExamples,
+		SeeAlso: []string{
+			"controller-config",
+			"debug-log",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.args.Users), "user", "Only show requests made by these users")
+	f.Var(cmd.NewAppendStringsValue(&c.args.Models), "model", "Only show requests made to these models, by name or UUID")
+	f.StringVar(&c.args.ConversationID, "conversation", "", "Only show requests made in this conversation")
+	f.StringVar(&c.method, "method", "", "Only show calls to this API facade or method, as <facade> or <facade>.<method>")
+	f.StringVar(&c.since, "since", "", "Only show requests made at or after this time or duration ago")
+	f.StringVar(&c.until, "until", "", "Only show requests made before this time or duration ago")
+	f.StringVar(&c.args.Result, "result", "", "Only show requests with this result, one of [success, error]")
+	f.IntVar(&c.args.Limit, "limit", 100, fmt.Sprintf("Show at most this many of the most recent requests, up to %d", coreauditlog.MaxQueryLimit))
+	f.BoolVar(&c.utc, "utc", false, "Display times in UTC")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	switch c.args.Result {
+	case "", "success", "error":
+	default:
+		return errors.Errorf("--result must be one of [success, error], got %q", c.args.Result)
+	}
+	if c.args.Limit < 1 || c.args.Limit > coreauditlog.MaxQueryLimit {
+		return errors.Errorf("--limit must be between 1 and %d", coreauditlog.MaxQueryLimit)
+	}
+	if c.method != "" {
+		c.args.Facade, c.args.Method, _ = strings.Cut(c.method, ".")
+		if c.args.Facade == "" {
+			return errors.Errorf("invalid --method %q, expected <facade> or <facade>.<method>", c.method)
+		}
+	}
+	for i, model := range c.args.Models {
+		// Models are recorded without their owner.
+		if _, name, ok := strings.Cut(model, "/"); ok {
+			c.args.Models[i] = name
+		}
+	}
+	if err := c.parseTimeRange(); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// parseTimeRange sets the time range of the requests to show from the
+// --since and --until options.
+func (c *auditLogCommand) parseTimeRange() error {
+	now := c.clock.Now().In(c.tz)
+	if c.since != "" {
+		since, err := parseLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.args.From = &since
+	}
+	if c.until != "" {
+		until, err := parseLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		c.args.To = &until
+	}
+	if c.args.From != nil && c.args.To != nil && !c.args.To.After(*c.args.From) {
+		return errors.Errorf("--until time %s is not after --since time %s",
+			c.args.To.Format(time.RFC3339), c.args.From.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// formattedAuditLogEntry is the serialisation of an audit log entry.
+type formattedAuditLogEntry struct {
+	Time           time.Time                `json:"time" yaml:"time"`
+	Controller     string                   `json:"controller" yaml:"controller"`
+	ConversationID string                   `json:"conversation-id" yaml:"conversation-id"`
+	ConnectionID   string                   `json:"connection-id" yaml:"connection-id"`
+	RequestID      uint64                   `json:"request-id" yaml:"request-id"`
+	User           string                   `json:"user" yaml:"user"`
+	Model          string                   `json:"model" yaml:"model"`
+	ModelUUID      string                   `json:"model-uuid" yaml:"model-uuid"`
+	Command        string                   `json:"command" yaml:"command"`
+	Facade         string                   `json:"facade" yaml:"facade"`
+	Method         string                   `json:"method" yaml:"method"`
+	Version        int                      `json:"version" yaml:"version"`
+	Args           string                   `json:"args,omitempty" yaml:"args,omitempty"`
+	Result         string                   `json:"result" yaml:"result"`
+	Errors         []formattedAuditLogError `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// formattedAuditLogError is the serialisation of an error returned in
+// response to a request.
+type formattedAuditLogError struct {
+	Message string `json:"message" yaml:"message"`
+	Code    string `json:"code,omitempty" yaml:"code,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Query(c.args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, controllerErr := range result.Errors {
+		ctx.Warningf("cannot read the audit log of controller %s: %v", controllerErr.ControllerID, controllerErr.Error)
+	}
+
+	entries := make([]formattedAuditLogEntry, len(result.Entries))
+	for i, entry := range result.Entries {
+		entries[i] = formatAuditLogEntry(entry)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No audit log entries found.")
+		return nil
+	}
+	return errors.Trace(c.out.Write(ctx, entries))
+}
+
+func formatAuditLogEntry(entry params.AuditLogEntry) formattedAuditLogEntry {
+	result := formattedAuditLogEntry{
+		Time:           entry.When,
+		Controller:     entry.ControllerID,
+		ConversationID: entry.ConversationID,
+		ConnectionID:   entry.ConnectionID,
+		RequestID:      entry.RequestID,
+		User:           entry.Who,
+		Model:          entry.ModelName,
+		ModelUUID:      entry.ModelUUID,
+		Command:        entry.What,
+		Facade:         entry.Facade,
+		Method:         entry.Method,
+		Version:        entry.Version,
+		Args:           entry.Args,
+	}
+	switch {
+	case len(entry.Errors) > 0:
+		result.Result = "error"
+	case entry.Responded:
+		result.Result = "success"
+	default:
+		result.Result = "no response"
+	}
+	for _, err := range entry.Errors {
+		result.Errors = append(result.Errors, formattedAuditLogError{
+			Message: err.Message,
+			Code:    err.Code,
+		})
+	}
+	return result
+}
+
+func (c *auditLogCommand) formatTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]formattedAuditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Time\tController\tUser\tModel\tRequest\tResult\tCommand")
+	for _, entry := range entries {
+		result := entry.Result
+		if len(entry.Errors) > 0 {
+			messages := make([]string, len(entry.Errors))
+			for i, err := range entry.Errors {
+				messages[i] = err.Message
+			}
+			result = strings.Join(messages, "; ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s.%s\t%s\t%s\n",
+			common.FormatTime(&entry.Time, c.utc),
+			entry.Controller,
+			entry.User,
+			entry.Model,
+			entry.Facade, entry.Method,
+			result,
+			entry.Command,
+		)
+	}
+	return errors.Trace(tw.Flush())
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	api *fakeAuditLogAPI
+	now time.Time
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.now = time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeAuditLogAPI{
+		result: params.AuditLogQueryResult{
+			Entries: []params.AuditLogEntry{{
+				ControllerID:   "0",
+				ConversationID: "c1",
+				ConnectionID:   "A1",
+				RequestID:      1,
+				Who:            "bob",
+				What:           "juju deploy mysql",
+				ModelName:      "default",
+				ModelUUID:      "deadbeef",
+				When:           time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+				Facade:         "Application",
+				Method:         "Deploy",
+				Version:        19,
+				Responded:      true,
+			}, {
+				ControllerID:   "1",
+				ConversationID: "c2",
+				ConnectionID:   "A2",
+				RequestID:      1,
+				Who:            "alice",
+				What:           "juju remove-application mysql",
+				ModelName:      "prod",
+				ModelUUID:      "cafebabe",
+				When:           time.Date(2025, 3, 1, 10, 1, 0, 0, time.UTC),
+				Facade:         "Application",
+				Method:         "DestroyApplication",
+				Version:        19,
+				Responded:      true,
+				Errors:         []params.AuditLogError{{Message: "permission denied", Code: "unauthorized access"}},
+			}},
+		},
+	}
+}
+
+func (s *AuditLogSuite) runAuditLog(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &auditLogCommand{
+		newAPIFunc: func() (AuditLogAPI, error) { return s.api, nil },
+		clock:      testclock.NewClock(s.now),
+		tz:         time.UTC,
+	}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return cmdtesting.RunCommand(c, modelcmd.WrapController(command), args...)
+}
+
+func (s *AuditLogSuite) TestArgs(c *gc.C) {
+	since := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 3, 2, 11, 0, 0, 0, time.UTC)
+	_, err := s.runAuditLog(c,
+		"--user", "bob", "--user", "alice",
+		"--model", "admin/prod", "--model", "deadbeef",
+		"--conversation", "c1",
+		"--method", "Application.Deploy",
+		"--since", "2025-03-01 12:00",
+		"--until", "1h",
+		"--result", "error",
+		"--limit", "10",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.args, jc.DeepEquals, params.AuditLogQueryArgs{
+		Users:          []string{"bob", "alice"},
+		Models:         []string{"prod", "deadbeef"},
+		ConversationID: "c1",
+		Facade:         "Application",
+		Method:         "Deploy",
+		From:           &since,
+		To:             &until,
+		Result:         "error",
+		Limit:          10,
+	})
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *AuditLogSuite) TestDefaultArgs(c *gc.C) {
+	_, err := s.runAuditLog(c, "--method", "Application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.args, jc.DeepEquals, params.AuditLogQueryArgs{
+		Facade: "Application",
+		Limit:  100,
+	})
+}
+
+func (s *AuditLogSuite) TestInvalidArgs(c *gc.C) {
+	for _, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		args:     []string{"--result", "maybe"},
+		errMatch: `--result must be one of \[success, error\], got "maybe"`,
+	}, {
+		args:     []string{"--limit", "-1"},
+		errMatch: `--limit must be between 1 and 10000`,
+	}, {
+		args:     []string{"--limit", "0"},
+		errMatch: `--limit must be between 1 and 10000`,
+	}, {
+		args:     []string{"--limit", "10001"},
+		errMatch: `--limit must be between 1 and 10000`,
+	}, {
+		args:     []string{"--method", ".Deploy"},
+		errMatch: `invalid --method ".Deploy", expected <facade> or <facade>.<method>`,
+	}, {
+		args:     []string{"--since", "soon"},
+		errMatch: `invalid --since value: "soon" is not a time or duration`,
+	}, {
+		args:     []string{"--since", "1h", "--until", "2h"},
+		errMatch: `--until time .* is not after --since time .*`,
+	}, {
+		args:     []string{"extra"},
+		errMatch: `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("args: %v", test.args)
+		_, err := s.runAuditLog(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.errMatch)
+	}
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.runAuditLog(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  Controller  User   Model    Request                         Result             Command
+2025-03-01 10:00:00Z  0           bob    default  Application.Deploy              success            juju deploy mysql
+2025-03-01 10:01:00Z  1           alice  prod     Application.DestroyApplication  permission denied  juju remove-application mysql
+`[1:])
+}
+
+func (s *AuditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.result.Entries = nil
+	ctx, err := s.runAuditLog(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No audit log entries found.\n")
+}
+
+func (s *AuditLogSuite) TestControllerErrors(c *gc.C) {
+	s.api.result.Errors = []params.AuditLogControllerError{{
+		ControllerID: "2",
+		Error:        &params.Error{Message: "waiting for controller 2 timeout"},
+	}}
+	ctx, err := s.runAuditLog(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "cannot read the audit log of controller 2: waiting for controller 2 timeout")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(gc.Equals), "")
+}
+
+func (s *AuditLogSuite) TestJSON(c *gc.C) {
+	s.api.result.Entries = s.api.result.Entries[1:]
+	ctx, err := s.runAuditLog(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `[{"time":"2025-03-01T10:01:00Z","controller":"1",`+
+		`"conversation-id":"c2","connection-id":"A2","request-id":1,"user":"alice","model":"prod",`+
+		`"model-uuid":"cafebabe","command":"juju remove-application mysql","facade":"Application",`+
+		`"method":"DestroyApplication","version":19,"result":"error",`+
+		`"errors":[{"message":"permission denied","code":"unauthorized access"}]}]`+"\n")
+}
+
+type fakeAuditLogAPI struct {
+	args   params.AuditLogQueryArgs
+	result params.AuditLogQueryResult
+	closed bool
+}
+
+func (f *fakeAuditLogAPI) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	f.args = args
+	return f.result, nil
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.closed = true
+	return nil
+}
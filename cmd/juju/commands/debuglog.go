@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -32,6 +35,13 @@ import (
 // display, from the end of the consolidated log.
 const defaultLineCount = 10
 
+// The output formats of the log messages.
+const (
+	formatText   = "text"
+	formatJSON   = "json"
+	formatLogfmt = "logfmt"
+)
+
 var usageDebugLogSummary = `
 Displays log messages for a model.`[1:]
 
@@ -58,6 +68,10 @@ with the prefix will match.
 
 The '--include-label' and '--exclude-label' options filter by logging label.
 
+The '--message' option filters by a regular expression that the log message
+must match. The expression is evaluated by the controller, so only matching
+messages are sent.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
@@ -66,8 +80,19 @@ The filtering options combine as follows:
 * All --include-label options are logically ORed together.
 * All --exclude-label options are logically ORed together.
 * The combined --include, --exclude, --include-module, --exclude-module,
-  --include-label and --exclude-label selections are logically ANDed to form
-  the complete filter.
+  --include-label, --exclude-label and --message selections are logically
+  ANDed to form the complete filter.
+
+The '--format' option selects how each log message is emitted:
+* text (default) prints the human-readable format described above.
+* json prints each message as a JSON object on its own line, with the
+  model-uuid, entity, timestamp, severity, module, location, labels and
+  message fields.
+* logfmt prints each message as a line of key=value pairs with the same
+  fields.
+The '--color', '--date', '--ms' and '--location' options only apply to the
+text format. Timestamps are always printed in full in the json and logfmt
+formats, in UTC when '--utc' is given.
 
 The '--tail' option waits for and continuously prints new log lines after displaying the most recent log lines.
 
@@ -80,6 +105,32 @@ The '--lines' and '--limit' options control the number of log lines displayed:
 
 The '--replay' option displays log lines starting from the beginning.
 
+The '--since' and '--until' options limit the log lines displayed to those
+logged in a time range. The controller only sends the lines in the range.
+Each option accepts either an absolute time or a duration:
+* an RFC3339 time, such as 2024-03-01T14:00:00Z;
+* a date and time, such as "2024-03-01 14:00" or "2024-03-01 14:00:05";
+* a time of day, such as 14:00 or 14:00:05, meaning today;
+* a duration, such as 30m or 2h, meaning that long ago.
+Times without a time zone are in local time, or in UTC when '--utc' is given.
+The range includes log lines logged at the '--since' time, but not those
+logged at the '--until' time.
+
+Behavior when combining --since or --until with other options:
+* --since or --until on their own print all the lines in the range.
+* --since and --lines prints the specified number of the most recent lines
+  logged since then, and then waits for new lines.
+* --limit prints up to the specified number of the most recent lines in the
+  range, and --replay and --limit up to the specified number of the first
+  lines in the range. Results can be paged through by passing the time of
+  the last line printed as the next --since. As the range includes lines
+  logged at the '--since' time, the next page starts with the lines logged
+  at the time of the last line printed, which are printed again. Use '--ms'
+  to print times precise enough to limit this overlap.
+* --until implies --no-tail. The lines already logged in the range are
+  printed, and the command exits without waiting for new lines, even if the
+  '--until' time is in the future.
+
 Behavior when combining --replay with other options:
 * --replay and --limit prints the specified number of lines from the beginning of the log.
 * --replay and --lines is invalid as it causes confusion by skipping logs between the replayed lines and the current tailing point.
@@ -90,6 +141,9 @@ Given the above, the following flag combinations are incompatible and cannot be
 * --no-tail and --lines (-n)
 * --limit and --lines (-n)
 * --replay and --lines (-n)
+* --until and --tail
+* --until and --lines (-n)
+* --until and --retry
 `
 
 const usageDebugLogExamples = `
@@ -132,6 +186,26 @@ View all logs on the cmr topic (label):
 
     juju debug-log --include-label cmr
 
+View all messages about failed hooks, as JSON objects:
+
+    juju debug-log --replay --no-tail --message 'hook .* failed' --format json
+
+Stream the WARNING and ERROR messages in logfmt to another tool:
+
+    juju debug-log --level WARNING --format logfmt | my-log-shipper
+
+View the log messages from between 14:00 and 14:05 today:
+
+    juju debug-log --since 14:00 --until 14:05
+
+View the ERROR messages logged in the last hour, and then exit:
+
+    juju debug-log --since 1h --no-tail --level ERROR
+
+View the first 100 log messages logged on the 1st of March 2024 in UTC:
+
+    juju debug-log --utc --since "2024-03-01 00:00" --until "2024-03-02 00:00" --replay --limit 100
+
 Progressively exclude more content from the entire log:
 
     juju debug-log --replay --exclude-module juju.state.apiserver
@@ -195,7 +269,7 @@ func newDebugLogCommand(store jujuclient.ClientStore) cmd.Command {
 }
 
 func newDebugLogCommandTZ(store jujuclient.ClientStore, tz *time.Location) cmd.Command {
-	cmd := &debugLogCommand{tz: tz}
+	cmd := &debugLogCommand{tz: tz, clock: clock.WallClock}
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
@@ -222,6 +296,13 @@ type debugLogCommand struct {
 
 	format string
 	tz     *time.Location
+
+	outputFormat string
+	message      *regexp.Regexp
+
+	since string
+	until string
+	clock clock.Clock
 }
 
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -234,6 +315,7 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeLabel), "include-label", "Only show log messages for these logging labels")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeLabel), "exclude-label", "Do not show log messages for these logging labels")
+	f.StringVar(&c.params.Message, "message", "", "Only show log messages matching this regular expression")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -246,6 +328,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(c.limitFlag, "limit", "Show this many of the most recent logs and then exit")
 
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire log and continue to append new ones")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time or duration ago")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged before this time or duration ago, and then exit")
 
 	f.BoolVar(&c.noTail, "no-tail", false, "Show existing log messages and then exit")
 	f.BoolVar(&c.tail, "tail", false, "Show existing log messages and continue to append new ones")
@@ -256,6 +340,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.date, "date", false, "Show dates as well as times")
 	f.BoolVar(&c.ms, "ms", false, "Show times to millisecond precision")
 
+	f.StringVar(&c.outputFormat, "format", formatText, "Output format, one of [text, json, logfmt]")
+
 	f.BoolVar(&c.retry, "retry", false, "Retry connection on failure")
 	f.DurationVar(&c.retryDelay, "retry-delay", 1*time.Second, "Retry delay between connection failure retries")
 }
@@ -287,20 +373,51 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.params.Replay && c.backLogFlag.IsSet() {
 		return errors.NotValidf("setting --replay and --lines")
 	}
+	if c.until != "" && c.tail {
+		return errors.NotValidf("setting --until and --tail")
+	}
+	if c.until != "" && c.backLogFlag.IsSet() {
+		return errors.NotValidf("setting --until and --lines")
+	}
+	if c.until != "" && c.retry {
+		return errors.NotValidf("setting --until and --retry")
+	}
 	if c.retryDelay < 0 {
 		return errors.NotValidf("negative retry delay")
 	}
-	if c.limitFlag.IsSet() {
+	switch c.outputFormat {
+	case formatText, formatJSON, formatLogfmt:
+	default:
+		return errors.Errorf("format value %q is not one of %q, %q, %q",
+			c.outputFormat, formatText, formatJSON, formatLogfmt)
+	}
+	if c.params.Message != "" {
+		message, err := regexp.Compile(c.params.Message)
+		if err != nil {
+			return errors.Annotatef(err, "invalid --message regular expression")
+		}
+		c.message = message
+	}
+	if c.utc {
+		c.tz = time.UTC
+	}
+	if err := c.parseTimeRange(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.limitFlag.IsSet() || c.until != "" {
 		c.noTail = true
 	}
 	if c.backLogFlag.IsSet() {
 		c.tail = true
 	}
 	if !c.backLogFlag.IsSet() && !c.limitFlag.IsSet() && !c.params.Replay {
-		*c.backLogFlag.value = defaultLineCount
-	}
-	if c.utc {
-		c.tz = time.UTC
+		if c.since != "" || c.until != "" {
+			// Show everything in the time range, rather than only the
+			// most recent lines.
+			c.params.Replay = true
+		} else {
+			*c.backLogFlag.value = defaultLineCount
+		}
 	}
 	if c.date {
 		c.format = "2006-01-02 15:04:05"
@@ -315,6 +432,87 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// parseTimeRange sets the start and end times of the log messages to show
+// from the --since and --until options.
+func (c *debugLogCommand) parseTimeRange() error {
+	if c.since == "" && c.until == "" {
+		return nil
+	}
+	tz := c.tz
+	if tz == nil {
+		tz = time.Local
+	}
+	wallClock := c.clock
+	if wallClock == nil {
+		wallClock = clock.WallClock
+	}
+	now := wallClock.Now().In(tz)
+
+	if c.since != "" {
+		since, err := parseLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.params.StartTime = since
+	}
+	if c.until != "" {
+		until, err := parseLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		c.params.EndTime = until
+	}
+	if !c.params.StartTime.IsZero() && !c.params.EndTime.IsZero() && !c.params.EndTime.After(c.params.StartTime) {
+		return errors.Errorf("--until time %s is not after --since time %s",
+			c.params.EndTime.Format(time.RFC3339), c.params.StartTime.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// The layouts accepted for the --since and --until times, other than RFC3339.
+// They are interpreted in the time zone of the command.
+var (
+	logDateTimeLayouts = []string{
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02",
+	}
+	logTimeOfDayLayouts = []string{
+		"15:04:05",
+		"15:04",
+	}
+)
+
+// parseLogTime parses a --since or --until value, which is either a time
+// or a duration before now. Times of day are taken to be today, and times
+// without a time zone are taken to be in the time zone of now.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.Errorf("negative duration %q", value)
+		}
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range logDateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range logTimeOfDayLayouts {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			year, month, day := now.Date()
+			return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), now.Location()), nil
+		}
+	}
+	return time.Time{}, errors.Errorf("%q is not a time or duration", value)
+}
+
 func (c *debugLogCommand) parseEntity(entity string) string {
 	tag, err := names.ParseTag(entity)
 	switch {
@@ -388,7 +586,17 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) error {
 				if !ok {
 					return ErrConnectionClosed
 				}
-				c.writeLogRecord(writer, msg)
+				// Controllers that don't support filtering by message
+				// or end time ignore the filters, so check them again here.
+				if c.message != nil && !c.message.MatchString(msg.Message) {
+					continue
+				}
+				if !c.params.EndTime.IsZero() && !msg.Timestamp.Before(c.params.EndTime) {
+					continue
+				}
+				if err := c.writeMessage(writer, msg); err != nil {
+					return errors.Trace(err)
+				}
 			}
 		},
 		IsFatalError: func(err error) bool {
@@ -437,6 +645,18 @@ var SeverityColor = map[string]*ansiterm.Context{
 	},
 }
 
+// writeMessage writes the log message in the selected output format.
+func (c *debugLogCommand) writeMessage(w *ansiterm.Writer, r common.LogMessage) error {
+	switch c.outputFormat {
+	case formatJSON:
+		return c.writeJSONLogRecord(w, r)
+	case formatLogfmt:
+		return c.writeLogfmtLogRecord(w, r)
+	}
+	c.writeLogRecord(w, r)
+	return nil
+}
+
 func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage) {
 	ts := r.Timestamp.In(c.tz).Format(c.format)
 	fmt.Fprintf(w, "%s: %s ", r.Entity, ts)
@@ -451,6 +671,73 @@ func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage
 	fmt.Fprintln(w, r.Message)
 }
 
+// jsonLogRecord is the JSON representation of a log message.
+type jsonLogRecord struct {
+	ModelUUID string   `json:"model-uuid,omitempty"`
+	Entity    string   `json:"entity"`
+	Timestamp string   `json:"timestamp"`
+	Severity  string   `json:"severity"`
+	Module    string   `json:"module"`
+	Location  string   `json:"location,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Message   string   `json:"message"`
+}
+
+func (c *debugLogCommand) writeJSONLogRecord(w io.Writer, r common.LogMessage) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(jsonLogRecord{
+		ModelUUID: r.ModelUUID,
+		Entity:    r.Entity,
+		Timestamp: r.Timestamp.In(c.tz).Format(time.RFC3339Nano),
+		Severity:  r.Severity,
+		Module:    r.Module,
+		Location:  r.Location,
+		Labels:    r.Labels,
+		Message:   r.Message,
+	})
+}
+
+func (c *debugLogCommand) writeLogfmtLogRecord(w io.Writer, r common.LogMessage) error {
+	fields := []string{
+		"timestamp", r.Timestamp.In(c.tz).Format(time.RFC3339Nano),
+		"model-uuid", r.ModelUUID,
+		"entity", r.Entity,
+		"severity", r.Severity,
+		"module", r.Module,
+		"location", r.Location,
+		"labels", strings.Join(r.Labels, ","),
+		"message", r.Message,
+	}
+	var line strings.Builder
+	for i := 0; i < len(fields); i += 2 {
+		key, value := fields[i], fields[i+1]
+		if value == "" && key != "message" {
+			continue
+		}
+		if line.Len() > 0 {
+			line.WriteByte(' ')
+		}
+		line.WriteString(key)
+		line.WriteByte('=')
+		line.WriteString(logfmtValue(value))
+	}
+	line.WriteByte('\n')
+	_, err := io.WriteString(w, line.String())
+	return err
+}
+
+// logfmtValue quotes the value if it can't be written as is in a logfmt
+// key=value pair.
+func logfmtValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " =\"\\") || strings.IndexFunc(value, func(r rune) bool {
+		return r < ' ' || r == 0x7f
+	}) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
 // intValue implements gnuflag.Value for an int value that can be set
 // to differentiate user input value from default value.
 type intValue struct {
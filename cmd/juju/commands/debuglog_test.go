@@ -6,6 +6,7 @@ package commands
 import (
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/cmd/v3/cmdtesting"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -162,6 +163,18 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 		}, {
 			args:     []string{"--lines", "30", "--no-tail", "--limit", "50"},
 			errMatch: `setting --no-tail and --lines not valid`,
+		}, {
+			args: []string{"--message", "hook .* failed", "--format", "json"},
+			expected: common.DebugLogParams{
+				Backlog: 10,
+				Message: "hook .* failed",
+			},
+		}, {
+			args:     []string{"--message", "hook (failed"},
+			errMatch: `invalid --message regular expression: .*`,
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json", "logfmt"`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -177,6 +190,86 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 	}
 }
 
+func (s *DebugLogSuite) TestTimeRangeParsing(c *gc.C) {
+	tz := time.FixedZone("test", 6*60*60)
+	now := time.Date(2024, 3, 1, 15, 30, 0, 0, tz)
+	for i, test := range []struct {
+		args     []string
+		expected common.DebugLogParams
+		errMatch string
+	}{{
+		args: []string{"--since", "14:00", "--until", "14:05"},
+		expected: common.DebugLogParams{
+			Replay:    true,
+			StartTime: time.Date(2024, 3, 1, 14, 0, 0, 0, tz),
+			EndTime:   time.Date(2024, 3, 1, 14, 5, 0, 0, tz),
+		},
+	}, {
+		args: []string{"--since", "14:00:05.123", "--replay", "--limit", "20"},
+		expected: common.DebugLogParams{
+			Replay:    true,
+			Limit:     20,
+			StartTime: time.Date(2024, 3, 1, 14, 0, 5, 123000000, tz),
+		},
+	}, {
+		args: []string{"--since", "1h", "--limit", "20"},
+		expected: common.DebugLogParams{
+			Limit:     20,
+			StartTime: time.Date(2024, 3, 1, 14, 30, 0, 0, tz),
+		},
+	}, {
+		args: []string{"--since", "2024-02-29 23:00", "--lines", "5"},
+		expected: common.DebugLogParams{
+			Backlog:   5,
+			StartTime: time.Date(2024, 2, 29, 23, 0, 0, 0, tz),
+		},
+	}, {
+		args: []string{"--until", "2024-03-01T10:00:00Z", "--replay", "--limit", "100"},
+		expected: common.DebugLogParams{
+			Replay:  true,
+			Limit:   100,
+			EndTime: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}, {
+		args: []string{"--utc", "--since", "2024-03-01", "--until", "30m"},
+		expected: common.DebugLogParams{
+			Replay:    true,
+			StartTime: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		},
+	}, {
+		args:     []string{"--since", "yesterday"},
+		errMatch: `invalid --since value: "yesterday" is not a time or duration`,
+	}, {
+		args:     []string{"--until", "-5m"},
+		errMatch: `invalid --until value: negative duration "-5m"`,
+	}, {
+		args:     []string{"--since", "14:05", "--until", "14:00"},
+		errMatch: `--until time 2024-03-01T14:00:00\+06:00 is not after --since time 2024-03-01T14:05:00\+06:00`,
+	}, {
+		args:     []string{"--until", "14:00", "--tail"},
+		errMatch: `setting --until and --tail not valid`,
+	}, {
+		args:     []string{"--until", "14:00", "--lines", "10"},
+		errMatch: `setting --until and --lines not valid`,
+	}, {
+		args:     []string{"--until", "14:00", "--retry"},
+		errMatch: `setting --until and --retry not valid`,
+	}} {
+		c.Logf("test %v: %v", i, test.args)
+		command := &debugLogCommand{tz: tz, clock: testclock.NewClock(now)}
+		command.SetClientStore(jujuclienttesting.MinimalStore())
+		err := cmdtesting.InitCommand(modelcmd.Wrap(command), test.args)
+		if test.errMatch == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(command.params, jc.DeepEquals, test.expected)
+			c.Check(command.noTail, gc.Equals, !test.expected.EndTime.IsZero() || test.expected.Limit > 0)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+		}
+	}
+}
+
 func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	fake := &fakeDebugLogAPI{}
 	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
@@ -187,6 +280,7 @@ func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 		"--include-module=juju.provisioner",
 		"--lines=500",
 		"--level=WARNING",
+		"--message=hook .* failed",
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(fake.params, gc.DeepEquals, common.DebugLogParams{
@@ -195,6 +289,7 @@ func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 		ExcludeEntity: []string{"machine-1-lxd-1"},
 		Backlog:       500,
 		Level:         loggo.WARNING,
+		Message:       "hook .* failed",
 	})
 }
 
@@ -283,6 +378,51 @@ func (s *DebugLogSuite) TestLogOutputWithLogs(c *gc.C) {
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 http,foo this is the log output\n")
 }
 
+func (s *DebugLogSuite) TestLogOutputStructured(c *gc.C) {
+	// test timezone is 6 hours east of UTC
+	tz := time.FixedZone("test", 6*60*60)
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return &fakeDebugLogAPI{log: []common.LogMessage{
+			{
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Entity:    "unit-mysql-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 23, 345000000, time.UTC),
+				Severity:  "ERROR",
+				Module:    "juju.worker.uniter",
+				Location:  "uniter.go:123",
+				Message:   `hook "install" failed`,
+				Labels:    []string{"http", "foo"},
+			}, {
+				Entity:    "machine-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 24, 0, time.UTC),
+				Severity:  "INFO",
+				Module:    "test.module",
+				Message:   "started",
+			},
+		}}, nil
+	})
+	checkOutput := func(args ...string) {
+		count := len(args)
+		args, expected := args[:count-1], args[count-1]
+		ctx, err := cmdtesting.RunCommand(c, newDebugLogCommandTZ(jujuclienttesting.MinimalStore(), tz), args...)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
+	}
+	checkOutput(
+		"--format", "json",
+		`{"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","entity":"unit-mysql-0","timestamp":"2016-10-09T14:15:23.345+06:00","severity":"ERROR","module":"juju.worker.uniter","location":"uniter.go:123","labels":["http","foo"],"message":"hook \"install\" failed"}
+{"entity":"machine-0","timestamp":"2016-10-09T14:15:24+06:00","severity":"INFO","module":"test.module","message":"started"}
+`)
+	checkOutput(
+		"--format", "logfmt", "--utc",
+		`timestamp=2016-10-09T08:15:23.345Z model-uuid=deadbeef-0bad-400d-8000-4b1d0d06f00d entity=unit-mysql-0 severity=ERROR module=juju.worker.uniter location=uniter.go:123 labels=http,foo message="hook \"install\" failed"
+timestamp=2016-10-09T08:15:24Z entity=machine-0 severity=INFO module=test.module message=started
+`)
+	checkOutput(
+		"--format", "logfmt", "--utc", "--message", "^start",
+		"timestamp=2016-10-09T08:15:24Z entity=machine-0 severity=INFO module=test.module message=started\n")
+}
+
 type fakeDebugLogAPI struct {
 	log    []common.LogMessage
 	params common.DebugLogParams
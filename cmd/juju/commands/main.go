@@ -392,8 +392,14 @@ func registerCommands(r commandRegistry) {
 	r.Register(ssh.NewSSHCommand(nil, nil, ssh.DefaultSSHRetryStrategy, ssh.DefaultSSHPublicKeyRetryStrategy))
 	r.Register(application.NewResolvedCommand())
 	r.Register(newDebugLogCommand(nil))
+	r.Register(newAuditLogCommand())
+	r.Register(newVerifyAuditLogCommand())
 	r.Register(ssh.NewDebugHooksCommand(nil, ssh.DefaultSSHRetryStrategy, ssh.DefaultSSHPublicKeyRetryStrategy))
 	r.Register(ssh.NewDebugCodeCommand(nil, ssh.DefaultSSHRetryStrategy, ssh.DefaultSSHPublicKeyRetryStrategy))
+	r.Register(ssh.NewSSHRecordingsCommand())
+	r.Register(ssh.NewSSHPoliciesCommand())
+	r.Register(ssh.NewSetSSHPolicyCommand())
+	r.Register(ssh.NewRemoveSSHPolicyCommand())
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -412,6 +418,9 @@ func registerCommands(r commandRegistry) {
 	// Manage backups.
 	r.Register(backups.NewCreateCommand())
 	r.Register(backups.NewDownloadCommand())
+	r.Register(backups.NewListCommand())
+	r.Register(backups.NewRemoveCommand())
+	r.Register(backups.NewVerifyCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -577,6 +586,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(charmhub.NewInfoCommand())
 	r.Register(charmhub.NewFindCommand())
 	r.Register(charmhub.NewDownloadCommand())
+	r.Register(charmhub.NewCacheCommand())
 
 	// Secrets.
 	r.Register(secrets.NewListSecretsCommand())
@@ -593,6 +603,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(secretbackends.NewUpdateSecretBackendCommand())
 	r.Register(secretbackends.NewRemoveSecretBackendCommand())
 	r.Register(secretbackends.NewShowSecretBackendCommand())
+	r.Register(secretbackends.NewMigrateSecretsCommand())
 
 	// Payload commands.
 	r.Register(payload.NewListCommand())
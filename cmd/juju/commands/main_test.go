@@ -323,12 +323,15 @@ var commandNames = []string{
 	"agreements",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
+	"backups",
 	"bind",
 	"bootstrap",
 	"cancel-task",
 	"change-user-password",
 	"charm-resources",
+	"charmhub-cache",
 	"clouds",
 	"collect-metrics",
 	"config",
@@ -381,6 +384,7 @@ var commandNames = []string{
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
+	"list-backups",
 	"list-charm-resources",
 	"list-clouds",
 	"list-controllers",
@@ -398,6 +402,7 @@ var commandNames = []string{
 	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
+	"list-ssh-policies",
 	"list-storage",
 	"list-storage-pools",
 	"list-subnets",
@@ -407,6 +412,7 @@ var commandNames = []string{
 	"machines",
 	"metrics",
 	"migrate",
+	"migrate-secrets",
 	"model-config",
 	"model-default",
 	"model-defaults",
@@ -422,6 +428,7 @@ var commandNames = []string{
 	"relate", // alias for integrate
 	"reload-spaces",
 	"remove-application",
+	"remove-backup",
 	"remove-cloud",
 	"remove-credential",
 	"remove-k8s",
@@ -433,6 +440,7 @@ var commandNames = []string{
 	"remove-secret",
 	"remove-space",
 	"remove-ssh-key",
+	"remove-ssh-policy",
 	"remove-storage",
 	"remove-storage-pool",
 	"remove-unit",
@@ -459,6 +467,7 @@ var commandNames = []string{
 	"set-firewall-rule",
 	"set-meter-status",
 	"set-model-constraints",
+	"set-ssh-policy",
 	"show-action",
 	"show-application",
 	"show-cloud",
@@ -480,6 +489,8 @@ var commandNames = []string{
 	"spaces",
 	"ssh",
 	"ssh-keys",
+	"ssh-policies",
+	"ssh-recordings",
 	"status",
 	"storage",
 	"storage-pools",
@@ -502,6 +513,8 @@ var commandNames = []string{
 	"upgrade-model",
 	"upgrade-machine",
 	"users",
+	"verify-audit-log",
+	"verify-backup",
 	"version",
 	"wait-for",
 	"whoami",
@@ -0,0 +1,189 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/auditlog"
+)
+
+const verifyAuditLogDoc = `
+verify-audit-log checks the audit log of a controller for records which
+have been changed since they were written. Each record written to an
+audit log holds a sequence number and a hash chaining it to the record
+before, so that records which have been modified, removed, added or
+reordered can be found.
+
+The hashes are not keyed, so the chain only finds accidental changes
+and edits which don't rewrite it: anyone able to write the audit log
+can change a record and recompute the hashes of the records after it.
+To keep a copy of the audit log out of their reach, forward it to
+another host with the audit-log-forward-type controller config.
+
+Pass the directory holding the audit log, usually /var/log/juju on the
+controller machine, to verify the current file and the rotated files
+it holds, oldest first. Alternatively pass the audit log files to
+verify, in the order they were written. No connection to a controller
+is needed.
+
+Records removed from the end of the audit log cannot be found, so the
+sequence number of the last record is reported to be checked against
+the records expected. Records written by an earlier version of Juju,
+or while the controller could not read the end of the chain, are not
+chained, and are reported but not verified.
+`
+
+const verifyAuditLogExamples = `
+    juju verify-audit-log /var/log/juju
+    juju verify-audit-log audit-2025-03-01T10-00-00.000.log.gz audit.log
+`
+
+func newVerifyAuditLogCommand() cmd.Command {
+	return &verifyAuditLogCommand{}
+}
+
+// verifyAuditLogCommand verifies the chain of records in an audit log.
+type verifyAuditLogCommand struct {
+	cmd.CommandBase
+	out cmd.Output
+
+	paths []string
+}
+
+// Info implements Command.Info.
+func (c *verifyAuditLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "verify-audit-log",
+		Args:     "<directory> | <file> ...",
+		Purpose:  "Check an audit log for records changed since they were written.",
+		Doc:      verifyAuditLogDoc,
+		Examples: verifyAuditLogExamples,
+		SeeAlso: []string{
+			"audit-log",
+			"controller-config",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyAuditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "summary", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"summary": formatVerifyAuditLogSummary,
+	})
+}
+
+// Init implements Command.Init.
+func (c *verifyAuditLogCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing audit log directory or files")
+	}
+	c.paths = args
+	return nil
+}
+
+// formattedVerifyAuditLogResult is the serialisation of an audit log
+// verification.
+type formattedVerifyAuditLogResult struct {
+	Files         []string `json:"files" yaml:"files"`
+	Records       int      `json:"records" yaml:"records"`
+	Unchained     int      `json:"unchained" yaml:"unchained"`
+	FirstSequence uint64   `json:"first-sequence" yaml:"first-sequence"`
+	LastSequence  uint64   `json:"last-sequence" yaml:"last-sequence"`
+	Warnings      []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	Problems      []string `json:"problems,omitempty" yaml:"problems,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *verifyAuditLogCommand) Run(ctx *cmd.Context) error {
+	paths := make([]string, len(c.paths))
+	for i, path := range c.paths {
+		paths[i] = ctx.AbsPath(path)
+	}
+
+	info, err := os.Stat(paths[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var result *auditlog.VerifyResult
+	if info.IsDir() {
+		if len(paths) > 1 {
+			return errors.Errorf("cannot verify an audit log directory along with other files")
+		}
+		result, err = auditlog.Verify(paths[0])
+	} else {
+		result, err = auditlog.VerifyFiles(paths)
+	}
+	if err != nil {
+		return errors.Annotate(err, "cannot verify audit log")
+	}
+
+	formatted := formattedVerifyAuditLogResult{
+		Files:         result.Files,
+		Records:       result.Records,
+		Unchained:     result.Unchained,
+		FirstSequence: result.FirstSequence,
+		LastSequence:  result.LastSequence,
+		Warnings:      result.Warnings,
+		Problems:      result.Problems,
+	}
+	if err := c.out.Write(ctx, formatted); err != nil {
+		return errors.Trace(err)
+	}
+
+	if !result.OK() {
+		return errors.Errorf("audit log failed verification")
+	}
+	return nil
+}
+
+func formatVerifyAuditLogSummary(writer io.Writer, value interface{}) error {
+	result, ok := value.(formattedVerifyAuditLogResult)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", result, value)
+	}
+
+	sequence := "none"
+	if result.LastSequence > 0 {
+		sequence = fmt.Sprintf("%d to %d", result.FirstSequence, result.LastSequence)
+	}
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "Files:\t%s\n", strings.Join(result.Files, ", "))
+	fmt.Fprintf(tw, "Records:\t%d\n", result.Records)
+	fmt.Fprintf(tw, "Sequence:\t%s\n", sequence)
+	fmt.Fprintf(tw, "Unchained:\t%d\n", result.Unchained)
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, list := range []struct {
+		heading string
+		items   []string
+	}{
+		{"Warnings", result.Warnings},
+		{"Problems", result.Problems},
+	} {
+		if len(list.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(writer, "\n%s:\n", list.heading)
+		for _, item := range list.items {
+			fmt.Fprintf(writer, "  - %s\n", item)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type VerifyAuditLogSuite struct {
+	testing.IsolationSuite
+
+	dir string
+}
+
+var _ = gc.Suite(&VerifyAuditLogSuite{})
+
+func (s *VerifyAuditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+
+	logFile := auditlog.NewLogFile(s.dir, 300, 10)
+	c.Assert(logFile.AddConversation(auditlog.Conversation{
+		Who: "bob", What: "juju status", When: "2025-03-01T10:00:00Z",
+		ModelName: "default", ConversationID: "c1", ConnectionID: "A1",
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddRequest(auditlog.Request{
+		ConversationID: "c1", ConnectionID: "A1", RequestID: 1, When: "2025-03-01T10:00:01Z",
+		Facade: "Client", Method: "FullStatus", Version: 8,
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "c1", ConnectionID: "A1", RequestID: 1, When: "2025-03-01T10:00:02Z",
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+}
+
+func (s *VerifyAuditLogSuite) TestInitMissingArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, newVerifyAuditLogCommand())
+	c.Check(err, gc.ErrorMatches, "missing audit log directory or files")
+}
+
+func (s *VerifyAuditLogSuite) TestVerifyDirectory(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, newVerifyAuditLogCommand(), s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Files:      audit.log
+Records:    3
+Sequence:   1 to 3
+Unchained:  0
+`[1:])
+}
+
+func (s *VerifyAuditLogSuite) TestVerifyFiles(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, newVerifyAuditLogCommand(),
+		filepath.Join(s.dir, "audit.log"), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+files:
+- audit.log
+records: 3
+unchained: 0
+first-sequence: 1
+last-sequence: 3
+`[1:])
+}
+
+func (s *VerifyAuditLogSuite) TestVerifyDirectoryAndFiles(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, newVerifyAuditLogCommand(), s.dir, "audit.log")
+	c.Check(err, gc.ErrorMatches, "cannot verify an audit log directory along with other files")
+}
+
+func (s *VerifyAuditLogSuite) TestVerifyFailed(c *gc.C) {
+	path := filepath.Join(s.dir, "audit.log")
+	data, err := os.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.SplitAfter(string(data), "\n")
+	err = os.WriteFile(path, []byte(lines[0]+lines[2]), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, newVerifyAuditLogCommand(), s.dir)
+	c.Assert(err, gc.ErrorMatches, "audit log failed verification")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Files:      audit.log
+Records:    2
+Sequence:   1 to 3
+Unchained:  0
+
+Problems:
+  - audit.log line 2: record 2 is missing
+`[1:])
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretbackends
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/client/secretbackends"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+type migrateSecretsCommand struct {
+	modelcmd.ControllerCommandBase
+
+	MigrateSecretsAPIFunc func() (MigrateSecretsAPI, error)
+
+	From      string
+	To        string
+	ModelName string
+	BatchSize int
+}
+
+var migrateSecretsDoc = `
+Moves the content of existing secret revisions from one secret backend
+to another, for all models on the controller or, with --model, a single
+model. Only revisions stored in the --from backend are moved.
+
+Each model whose secrets are moved must already be configured to use the
+--to backend (using the secret-backend model config); new revisions are
+then written there and this command moves the existing ones.
+
+Each revision's content is read back from the new backend and checked
+against the original before the revision is updated to refer to it, and
+the original content is only deleted after that. Revisions are migrated in
+batches; an interrupted migration can be resumed by running the command
+again.
+
+Use "internal" to refer to the controller's built in backend.
+`
+
+const migrateSecretsExamples = `
+    juju migrate-secrets --from internal --to myvault
+    juju migrate-secrets --from myvault --to internal --model mymodel
+`
+
+// MigrateSecretsAPI is the secrets client API.
+type MigrateSecretsAPI interface {
+	MigrateSecrets(secretbackends.MigrateSecrets) (secretbackends.MigrateSecretsResult, error)
+	Close() error
+}
+
+// NewMigrateSecretsCommand returns a command to migrate secret content
+// between backends.
+func NewMigrateSecretsCommand() cmd.Command {
+	c := &migrateSecretsCommand{}
+	c.MigrateSecretsAPIFunc = c.secretBackendsAPI
+
+	return modelcmd.WrapController(c)
+}
+
+func (c *migrateSecretsCommand) secretBackendsAPI() (MigrateSecretsAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secretbackends.NewClient(root), nil
+}
+
+// Info implements cmd.Info.
+func (c *migrateSecretsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "migrate-secrets",
+		Purpose:  "Moves secret content from one secret backend to another.",
+		Doc:      migrateSecretsDoc,
+		Examples: migrateSecretsExamples,
+		SeeAlso: []string{
+			"secret-backends",
+			"model-config",
+			"remove-secret-backend",
+		},
+	})
+}
+
+// SetFlags implements cmd.SetFlags.
+func (c *migrateSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.From, "from", "", "the backend to move secret content from")
+	f.StringVar(&c.To, "to", "", "the backend to move secret content to")
+	f.StringVar(&c.ModelName, "model", "", "only migrate the secrets of this model")
+	f.IntVar(&c.BatchSize, "batch-size", 50, "the number of secret revisions to migrate in each batch")
+}
+
+func (c *migrateSecretsCommand) Init(args []string) error {
+	if c.From == "" || c.To == "" {
+		return errors.New("must specify --from and --to backends")
+	}
+	if c.From == c.To {
+		return errors.New("--from and --to must be different backends")
+	}
+	if c.BatchSize <= 0 {
+		return errors.New("--batch-size must be positive")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Run.
+func (c *migrateSecretsCommand) Run(ctxt *cmd.Context) error {
+	var modelUUID string
+	if c.ModelName != "" {
+		uuids, err := c.ModelUUIDs([]string{c.ModelName})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		modelUUID = uuids[0]
+	}
+
+	api, err := c.MigrateSecretsAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	arg := secretbackends.MigrateSecrets{
+		FromBackend: c.From,
+		ToBackend:   c.To,
+		ModelUUID:   modelUUID,
+		Limit:       c.BatchSize,
+	}
+	var total, migrated, failed int
+	for {
+		result, err := api.MigrateSecrets(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if total == 0 {
+			total = len(result.Results) + result.Remaining
+		}
+		for _, r := range result.Results {
+			if r.Migrated {
+				migrated++
+			}
+			if r.Error != nil {
+				failed++
+				cmd.WriteError(ctxt.Stderr, errors.Annotatef(r.Error,
+					"migrating %s revision %d in model %q", r.URI, r.Revision, r.ModelName))
+			}
+		}
+		if result.Next == "" {
+			break
+		}
+		ctxt.Infof("migrated %d of %d secret revisions", migrated, total)
+		arg.After = result.Next
+	}
+
+	if total == 0 {
+		ctxt.Infof("no secret revisions stored in %q", c.From)
+		return nil
+	}
+	ctxt.Infof("migrated %d of %d secret revisions from %q to %q", migrated, total, c.From, c.To)
+	if failed > 0 {
+		return errors.Errorf("migrating %d secret revisions failed; run the command again to retry", failed)
+	}
+	return nil
+}
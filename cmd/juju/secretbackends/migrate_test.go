@@ -0,0 +1,160 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretbackends_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuerrors "github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	apisecretbackends "github.com/juju/juju/api/client/secretbackends"
+	"github.com/juju/juju/cmd/juju/secretbackends"
+	"github.com/juju/juju/cmd/juju/secretbackends/mocks"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type MigrateSuite struct {
+	jujutesting.IsolationSuite
+	store             *jujuclient.MemStore
+	migrateSecretsAPI *mocks.MockMigrateSecretsAPI
+}
+
+var _ = gc.Suite(&MigrateSuite{})
+
+func (s *MigrateSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	store := jujuclient.NewMemStore()
+	store.Controllers["mycontroller"] = jujuclient.ControllerDetails{}
+	store.CurrentControllerName = "mycontroller"
+	store.Accounts["mycontroller"] = jujuclient.AccountDetails{User: "admin"}
+	store.Models["mycontroller"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/mymodel": {ModelUUID: coretesting.ModelTag.Id()},
+		},
+	}
+	s.store = store
+}
+
+func (s *MigrateSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+
+	s.migrateSecretsAPI = mocks.NewMockMigrateSecretsAPI(ctrl)
+
+	return ctrl
+}
+
+func (s *MigrateSuite) TestMigrateInitError(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--from", "internal"},
+		err:  "must specify --from and --to backends",
+	}, {
+		args: []string{"--from", "internal", "--to", "internal"},
+		err:  "--from and --to must be different backends",
+	}, {
+		args: []string{"--from", "internal", "--to", "myvault", "--batch-size", "0"},
+		err:  "--batch-size must be positive",
+	}, {
+		args: []string{"--from", "internal", "--to", "myvault", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, secretbackends.NewMigrateCommandForTest(s.store, s.migrateSecretsAPI), t.args...)
+		c.Assert(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *MigrateSuite) TestMigrate(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.migrateSecretsAPI.EXPECT().MigrateSecrets(apisecretbackends.MigrateSecrets{
+		FromBackend: "internal",
+		ToBackend:   "myvault",
+		ModelUUID:   coretesting.ModelTag.Id(),
+		Limit:       1,
+	}).Return(apisecretbackends.MigrateSecretsResult{
+		Results: []apisecretbackends.SecretRevisionMigration{{
+			ModelName: "mymodel",
+			URI:       "secret:9m4e2mr0ui3e8a215n4g",
+			Revision:  1,
+			Migrated:  true,
+		}},
+		Remaining: 1,
+		Next:      "cursor",
+	}, nil)
+	s.migrateSecretsAPI.EXPECT().MigrateSecrets(apisecretbackends.MigrateSecrets{
+		FromBackend: "internal",
+		ToBackend:   "myvault",
+		ModelUUID:   coretesting.ModelTag.Id(),
+		After:       "cursor",
+		Limit:       1,
+	}).Return(apisecretbackends.MigrateSecretsResult{
+		Results: []apisecretbackends.SecretRevisionMigration{{
+			ModelName: "mymodel",
+			URI:       "secret:9m4e2mr0ui3e8a215n4g",
+			Revision:  2,
+			Migrated:  true,
+		}},
+	}, nil)
+	s.migrateSecretsAPI.EXPECT().Close().Return(nil)
+
+	ctx, err := cmdtesting.RunCommand(c, secretbackends.NewMigrateCommandForTest(s.store, s.migrateSecretsAPI),
+		"--from", "internal", "--to", "myvault", "--model", "mymodel", "--batch-size", "1",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+migrated 1 of 2 secret revisions
+migrated 2 of 2 secret revisions from "internal" to "myvault"
+`[1:])
+}
+
+func (s *MigrateSuite) TestMigrateFailures(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.migrateSecretsAPI.EXPECT().MigrateSecrets(apisecretbackends.MigrateSecrets{
+		FromBackend: "myvault",
+		ToBackend:   "internal",
+		Limit:       50,
+	}).Return(apisecretbackends.MigrateSecretsResult{
+		Results: []apisecretbackends.SecretRevisionMigration{{
+			ModelName: "mymodel",
+			URI:       "secret:9m4e2mr0ui3e8a215n4g",
+			Revision:  1,
+			Migrated:  true,
+		}, {
+			ModelName: "mymodel",
+			URI:       "secret:9m4e2mr0ui3e8a215n4g",
+			Revision:  2,
+			Error:     jujuerrors.New("boom"),
+		}},
+	}, nil)
+	s.migrateSecretsAPI.EXPECT().Close().Return(nil)
+
+	ctx, err := cmdtesting.RunCommand(c, secretbackends.NewMigrateCommandForTest(s.store, s.migrateSecretsAPI),
+		"--from", "myvault", "--to", "internal",
+	)
+	c.Assert(err, gc.ErrorMatches, "migrating 1 secret revisions failed; run the command again to retry")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+ERROR migrating secret:9m4e2mr0ui3e8a215n4g revision 2 in model "mymodel": boom
+migrated 1 of 2 secret revisions from "myvault" to "internal"
+`[1:])
+}
+
+func (s *MigrateSuite) TestMigrateNothing(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.migrateSecretsAPI.EXPECT().MigrateSecrets(gomock.Any()).Return(apisecretbackends.MigrateSecretsResult{}, nil)
+	s.migrateSecretsAPI.EXPECT().Close().Return(nil)
+
+	ctx, err := cmdtesting.RunCommand(c, secretbackends.NewMigrateCommandForTest(s.store, s.migrateSecretsAPI),
+		"--from", "myvault", "--to", "internal",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "no secret revisions stored in \"myvault\"\n")
+}
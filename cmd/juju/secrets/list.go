@@ -28,20 +28,30 @@ type listSecretsCommand struct {
 	listSecretsAPIFunc func() (ListSecretsAPI, error)
 	revealSecrets      bool
 	owner              string
+	checkDrift         bool
 }
 
 var listSecretsDoc = `
 Displays the secrets available for charms to use if granted access.
+
+With --check-drift, the content of each secret revision stored in an
+external secret backend (such as Vault or Kubernetes) is read back and
+compared with the checksum recorded when it was saved. Revisions whose
+content is missing or has been changed outside of Juju are reported and
+the command exits with an error. Checking drift requires admin access
+to the model.
 `
 
 const listSecretsExamples = `
     juju secrets
     juju secrets --format yaml
+    juju secrets --check-drift
 `
 
 // ListSecretsAPI is the secrets client API.
 type ListSecretsAPI interface {
 	ListSecrets(bool, secrets.Filter) ([]apisecrets.SecretDetails, error)
+	CheckSecretContentDrift(secrets.Filter) (int, []secrets.RevisionContentDrift, error)
 	Close() error
 }
 
@@ -82,6 +92,7 @@ func (c *listSecretsCommand) Info() *cmd.Info {
 // SetFlags implements cmd.SetFlags.
 func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.owner, "owner", "", "Include secrets for the specified owner")
+	f.BoolVar(&c.checkDrift, "check-drift", false, "Check the content stored in external backends has not been changed or removed")
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
@@ -171,7 +182,39 @@ func (c *listSecretsCommand) Run(ctxt *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	details := gatherSecretInfo(result, c.revealSecrets, false, false)
-	return c.out.Write(ctxt, details)
+	if err := c.out.Write(ctxt, details); err != nil {
+		return errors.Trace(err)
+	}
+	if !c.checkDrift {
+		return nil
+	}
+	return c.reportContentDrift(ctxt, api, filter)
+}
+
+func (c *listSecretsCommand) reportContentDrift(ctxt *cmd.Context, api ListSecretsAPI, filter secrets.Filter) error {
+	checked, drifted, err := api.CheckSecretContentDrift(filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var failed int
+	for _, d := range drifted {
+		if d.Error != nil {
+			cmd.WriteError(ctxt.Stderr, errors.Annotatef(d.Error,
+				"checking %s revision %d in backend %q", d.URI, d.Revision, d.BackendName))
+			continue
+		}
+		failed++
+		cmd.WriteError(ctxt.Stderr, errors.Errorf(
+			"%s revision %d content %s in backend %q", d.URI, d.Revision, d.Drift, d.BackendName))
+	}
+	ctxt.Infof("checked %d secret revisions stored in external backends", checked)
+	if failed > 0 {
+		return errors.Errorf("content of %d secret revisions has drifted", failed)
+	}
+	if len(drifted) > 0 {
+		return errors.Errorf("checking %d secret revisions failed", len(drifted))
+	}
+	return nil
 }
 
 func gatherSecretInfo(
@@ -155,3 +155,47 @@ func (s *ListSuite) TestListJSON(c *gc.C) {
 {"%s":{"revision":2,"owner":"mariadb","created":"0001-01-01T00:00:00Z","updated":"0001-01-01T00:00:00Z"}}
 `[1:], uri.ID))
 }
+
+func (s *ListSuite) TestListCheckDrift(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	uri := coresecrets.NewURI()
+	s.secretsAPI.EXPECT().ListSecrets(false, coresecrets.Filter{}).Return(
+		[]apisecrets.SecretDetails{{
+			Metadata: coresecrets.SecretMetadata{
+				URI: uri, LatestRevision: 2, OwnerTag: "application-mysql"},
+		}}, nil)
+	s.secretsAPI.EXPECT().CheckSecretContentDrift(coresecrets.Filter{}).Return(
+		3, []coresecrets.RevisionContentDrift{{
+			URI:         uri,
+			Revision:    1,
+			BackendName: "myvault",
+			Drift:       coresecrets.ContentMissing,
+		}, {
+			URI:         uri,
+			Revision:    2,
+			BackendName: "myvault",
+			Drift:       coresecrets.ContentModified,
+		}}, nil)
+	s.secretsAPI.EXPECT().Close().Return(nil)
+
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(s.store, s.secretsAPI), "--check-drift")
+	c.Assert(err, gc.ErrorMatches, "content of 2 secret revisions has drifted")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, fmt.Sprintf(`
+ERROR %s revision 1 content missing in backend "myvault"
+ERROR %s revision 2 content modified in backend "myvault"
+checked 3 secret revisions stored in external backends
+`[1:], uri, uri))
+}
+
+func (s *ListSuite) TestListCheckDriftNone(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.secretsAPI.EXPECT().ListSecrets(false, coresecrets.Filter{}).Return(nil, nil)
+	s.secretsAPI.EXPECT().CheckSecretContentDrift(coresecrets.Filter{}).Return(2, nil, nil)
+	s.secretsAPI.EXPECT().Close().Return(nil)
+
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(s.store, s.secretsAPI), "--check-drift")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "checked 2 secret revisions stored in external backends\n")
+}
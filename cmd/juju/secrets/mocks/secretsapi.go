@@ -40,6 +40,22 @@ func (m *MockListSecretsAPI) EXPECT() *MockListSecretsAPIMockRecorder {
 	return m.recorder
 }
 
+// CheckSecretContentDrift mocks base method.
+func (m *MockListSecretsAPI) CheckSecretContentDrift(arg0 secrets0.Filter) (int, []secrets0.RevisionContentDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSecretContentDrift", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]secrets0.RevisionContentDrift)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CheckSecretContentDrift indicates an expected call of CheckSecretContentDrift.
+func (mr *MockListSecretsAPIMockRecorder) CheckSecretContentDrift(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSecretContentDrift", reflect.TypeOf((*MockListSecretsAPI)(nil).CheckSecretContentDrift), arg0)
+}
+
 // Close mocks base method.
 func (m *MockListSecretsAPI) Close() error {
 	m.ctrl.T.Helper()
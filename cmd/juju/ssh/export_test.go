@@ -4,8 +4,14 @@
 package ssh
 
 import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+
 	k8sexec "github.com/juju/juju/caas/kubernetes/provider/exec"
+	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/jujuclient"
 )
 
 type (
@@ -97,3 +103,33 @@ func NewSSHContainer(
 		controllerAPI: controllerAPI,
 	}
 }
+
+func NewSSHRecordingsCommandForTest(store jujuclient.ClientStore, api SSHRecordingsAPI, sleep func(time.Duration)) cmd.Command {
+	c := &sshRecordingsCommand{
+		sshRecordingsAPIFunc: func() (SSHRecordingsAPI, error) { return api, nil },
+		sleep:                sleep,
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewSSHPoliciesCommandForTest(store jujuclient.ClientStore, api SSHPoliciesAPI) cmd.Command {
+	c := &sshPoliciesCommand{}
+	c.sshPoliciesAPIFunc = func() (SSHPoliciesAPI, error) { return api, nil }
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewSetSSHPolicyCommandForTest(store jujuclient.ClientStore, api SSHPoliciesAPI) cmd.Command {
+	c := &setSSHPolicyCommand{}
+	c.sshPoliciesAPIFunc = func() (SSHPoliciesAPI, error) { return api, nil }
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveSSHPolicyCommandForTest(store jujuclient.ClientStore, api SSHPoliciesAPI) cmd.Command {
+	c := &removeSSHPolicyCommand{}
+	c.sshPoliciesAPIFunc = func() (SSHPoliciesAPI, error) { return api, nil }
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/cmd/juju/ssh (interfaces: Context,LeaderAPI,SSHClientAPI,SSHControllerAPI,CloudCredentialAPI,ApplicationAPI,CharmsAPI,ModelCommand)
+// Source: github.com/juju/juju/cmd/juju/ssh (interfaces: Context,LeaderAPI,SSHClientAPI,SSHControllerAPI,SSHRecordingsAPI,SSHPoliciesAPI,CloudCredentialAPI,ApplicationAPI,CharmsAPI,ModelCommand)
 //
 // Generated by this command:
 //
-//	mockgen -package mocks -destination mocks/package_mock.go github.com/juju/juju/cmd/juju/ssh Context,LeaderAPI,SSHClientAPI,SSHControllerAPI,CloudCredentialAPI,ApplicationAPI,CharmsAPI,ModelCommand
+//	mockgen -package mocks -destination mocks/package_mock.go github.com/juju/juju/cmd/juju/ssh Context,LeaderAPI,SSHClientAPI,SSHControllerAPI,SSHRecordingsAPI,SSHPoliciesAPI,CloudCredentialAPI,ApplicationAPI,CharmsAPI,ModelCommand
 //
 
 // Package mocks is a generated GoMock package.
@@ -20,6 +20,7 @@ import (
 	charms "github.com/juju/juju/api/common/charms"
 	cloud "github.com/juju/juju/cloud"
 	controller "github.com/juju/juju/controller"
+	sshpolicy "github.com/juju/juju/core/sshpolicy"
 	cloudspec "github.com/juju/juju/environs/cloudspec"
 	jujuclient "github.com/juju/juju/jujuclient"
 	params "github.com/juju/juju/rpc/params"
@@ -258,6 +259,169 @@ func (mr *MockSSHControllerAPIMockRecorder) ControllerConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControllerConfig", reflect.TypeOf((*MockSSHControllerAPI)(nil).ControllerConfig))
 }
 
+// MockSSHRecordingsAPI is a mock of SSHRecordingsAPI interface.
+type MockSSHRecordingsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSHRecordingsAPIMockRecorder
+}
+
+// MockSSHRecordingsAPIMockRecorder is the mock recorder for MockSSHRecordingsAPI.
+type MockSSHRecordingsAPIMockRecorder struct {
+	mock *MockSSHRecordingsAPI
+}
+
+// NewMockSSHRecordingsAPI creates a new mock instance.
+func NewMockSSHRecordingsAPI(ctrl *gomock.Controller) *MockSSHRecordingsAPI {
+	mock := &MockSSHRecordingsAPI{ctrl: ctrl}
+	mock.recorder = &MockSSHRecordingsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSHRecordingsAPI) EXPECT() *MockSSHRecordingsAPIMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockSSHRecordingsAPI) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSSHRecordingsAPIMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSSHRecordingsAPI)(nil).Close))
+}
+
+// SSHSessionRecording mocks base method.
+func (m *MockSSHRecordingsAPI) SSHSessionRecording(arg0 string) (params.SSHSessionRecording, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHSessionRecording", arg0)
+	ret0, _ := ret[0].(params.SSHSessionRecording)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SSHSessionRecording indicates an expected call of SSHSessionRecording.
+func (mr *MockSSHRecordingsAPIMockRecorder) SSHSessionRecording(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHSessionRecording", reflect.TypeOf((*MockSSHRecordingsAPI)(nil).SSHSessionRecording), arg0)
+}
+
+// SSHSessionRecordings mocks base method.
+func (m *MockSSHRecordingsAPI) SSHSessionRecordings(arg0 params.SSHSessionRecordingFilter) ([]params.SSHSessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHSessionRecordings", arg0)
+	ret0, _ := ret[0].([]params.SSHSessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSHSessionRecordings indicates an expected call of SSHSessionRecordings.
+func (mr *MockSSHRecordingsAPIMockRecorder) SSHSessionRecordings(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHSessionRecordings", reflect.TypeOf((*MockSSHRecordingsAPI)(nil).SSHSessionRecordings), arg0)
+}
+
+// VirtualHostname mocks base method.
+func (m *MockSSHRecordingsAPI) VirtualHostname(arg0 string, arg1 *string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VirtualHostname", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VirtualHostname indicates an expected call of VirtualHostname.
+func (mr *MockSSHRecordingsAPIMockRecorder) VirtualHostname(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VirtualHostname", reflect.TypeOf((*MockSSHRecordingsAPI)(nil).VirtualHostname), arg0, arg1)
+}
+
+// MockSSHPoliciesAPI is a mock of SSHPoliciesAPI interface.
+type MockSSHPoliciesAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSHPoliciesAPIMockRecorder
+}
+
+// MockSSHPoliciesAPIMockRecorder is the mock recorder for MockSSHPoliciesAPI.
+type MockSSHPoliciesAPIMockRecorder struct {
+	mock *MockSSHPoliciesAPI
+}
+
+// NewMockSSHPoliciesAPI creates a new mock instance.
+func NewMockSSHPoliciesAPI(ctrl *gomock.Controller) *MockSSHPoliciesAPI {
+	mock := &MockSSHPoliciesAPI{ctrl: ctrl}
+	mock.recorder = &MockSSHPoliciesAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSHPoliciesAPI) EXPECT() *MockSSHPoliciesAPIMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockSSHPoliciesAPI) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSSHPoliciesAPIMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSSHPoliciesAPI)(nil).Close))
+}
+
+// RemoveSSHPolicy mocks base method.
+func (m *MockSSHPoliciesAPI) RemoveSSHPolicy(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSSHPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSSHPolicy indicates an expected call of RemoveSSHPolicy.
+func (mr *MockSSHPoliciesAPIMockRecorder) RemoveSSHPolicy(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSSHPolicy", reflect.TypeOf((*MockSSHPoliciesAPI)(nil).RemoveSSHPolicy), arg0)
+}
+
+// SSHPolicies mocks base method.
+func (m *MockSSHPoliciesAPI) SSHPolicies() ([]sshpolicy.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHPolicies")
+	ret0, _ := ret[0].([]sshpolicy.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSHPolicies indicates an expected call of SSHPolicies.
+func (mr *MockSSHPoliciesAPIMockRecorder) SSHPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHPolicies", reflect.TypeOf((*MockSSHPoliciesAPI)(nil).SSHPolicies))
+}
+
+// SetSSHPolicy mocks base method.
+func (m *MockSSHPoliciesAPI) SetSSHPolicy(arg0 sshpolicy.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSSHPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSSHPolicy indicates an expected call of SetSSHPolicy.
+func (mr *MockSSHPoliciesAPIMockRecorder) SetSSHPolicy(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSSHPolicy", reflect.TypeOf((*MockSSHPoliciesAPI)(nil).SetSSHPolicy), arg0)
+}
+
 // MockCloudCredentialAPI is a mock of CloudCredentialAPI interface.
 type MockCloudCredentialAPI struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,184 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ssh
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/client/sshclient"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/sshpolicy"
+)
+
+// SSHPoliciesAPI is the API used to manage a model's SSH policies.
+type SSHPoliciesAPI interface {
+	SSHPolicies() ([]sshpolicy.Policy, error)
+	SetSSHPolicy(sshpolicy.Policy) error
+	RemoveSSHPolicy(name string) error
+	Close() error
+}
+
+// sshPoliciesCommandBase holds what's shared by the SSH policy commands.
+type sshPoliciesCommandBase struct {
+	modelcmd.ModelCommandBase
+
+	sshPoliciesAPIFunc func() (SSHPoliciesAPI, error)
+}
+
+func (c *sshPoliciesCommandBase) sshPoliciesAPI() (SSHPoliciesAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sshclient.NewFacade(root), nil
+}
+
+var usageSSHPoliciesSummary = `
+Lists the SSH policies of a model.`[1:]
+
+var usageSSHPoliciesDetails = `
+SSH policies control what may be done on a model's machines and units
+through the controller's SSH server. Without any policies, any key
+authorized for the model may open shells, run commands, transfer files
+and forward ports. Once a model has policies, only what a policy grants
+the Juju users it applies to is allowed.
+
+Listing SSH policies requires admin access to the model.
+`
+
+const usageSSHPoliciesExamples = `
+    juju ssh-policies
+    juju ssh-policies --format yaml
+`
+
+type sshPoliciesCommand struct {
+	sshPoliciesCommandBase
+	out cmd.Output
+}
+
+// NewSSHPoliciesCommand returns a command to list a model's SSH policies.
+func NewSSHPoliciesCommand() cmd.Command {
+	c := &sshPoliciesCommand{}
+	c.sshPoliciesAPIFunc = c.sshPoliciesAPI
+	return modelcmd.Wrap(c)
+}
+
+// Info implements cmd.Info.
+func (c *sshPoliciesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "ssh-policies",
+		Purpose:  usageSSHPoliciesSummary,
+		Doc:      usageSSHPoliciesDetails,
+		Aliases:  []string{"list-ssh-policies"},
+		Examples: usageSSHPoliciesExamples,
+		SeeAlso: []string{
+			"set-ssh-policy",
+			"remove-ssh-policy",
+			"ssh",
+		},
+	})
+}
+
+// SetFlags implements cmd.SetFlags.
+func (c *sshPoliciesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSSHPoliciesTabular,
+	})
+}
+
+// Init implements cmd.Init.
+func (c *sshPoliciesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+type sshPolicyDetails struct {
+	Users          []string `json:"users" yaml:"users"`
+	Applications   []string `json:"applications,omitempty" yaml:"applications,omitempty"`
+	Commands       []string `json:"commands,omitempty" yaml:"commands,omitempty"`
+	Shell          bool     `json:"shell" yaml:"shell"`
+	SFTP           bool     `json:"sftp" yaml:"sftp"`
+	PortForwarding bool     `json:"port-forwarding" yaml:"port-forwarding"`
+}
+
+// Run implements cmd.Run.
+func (c *sshPoliciesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.sshPoliciesAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	policies, err := api.SSHPolicies()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(policies) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No SSH policies to display. All authorized keys have full access.")
+		return nil
+	}
+	details := make(map[string]sshPolicyDetails, len(policies))
+	for _, p := range policies {
+		details[p.Name] = sshPolicyDetails{
+			Users:          p.Users,
+			Applications:   p.Applications,
+			Commands:       p.Commands,
+			Shell:          p.Shell,
+			SFTP:           p.SFTP,
+			PortForwarding: p.PortForwarding,
+		}
+	}
+	return c.out.Write(ctx, details)
+}
+
+func formatSSHPoliciesTabular(writer io.Writer, value interface{}) error {
+	policies, ok := value.(map[string]sshPolicyDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", policies, value)
+	}
+
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+
+	w.Println("Name", "Users", "Applications", "Allows")
+	for _, name := range names {
+		p := policies[name]
+		applications := "*"
+		if len(p.Applications) > 0 {
+			applications = strings.Join(p.Applications, ",")
+		}
+		var allows []string
+		if p.Shell {
+			allows = append(allows, "shell")
+		}
+		if p.SFTP {
+			allows = append(allows, "sftp")
+		}
+		if p.PortForwarding {
+			allows = append(allows, "port-forwarding")
+		}
+		for _, command := range p.Commands {
+			allows = append(allows, "command "+strconv.Quote(command))
+		}
+		w.Println(name, strings.Join(p.Users, ","), applications, strings.Join(allows, ", "))
+	}
+	return tw.Flush()
+}
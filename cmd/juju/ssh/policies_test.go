@@ -0,0 +1,192 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ssh_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/ssh"
+	"github.com/juju/juju/cmd/juju/ssh/mocks"
+	"github.com/juju/juju/core/sshpolicy"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SSHPoliciesSuite struct {
+	jujutesting.IsolationSuite
+	store *jujuclient.MemStore
+	api   *mocks.MockSSHPoliciesAPI
+}
+
+var _ = gc.Suite(&SSHPoliciesSuite{})
+
+func (s *SSHPoliciesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	store := jujuclient.NewMemStore()
+	store.Controllers["mycontroller"] = jujuclient.ControllerDetails{}
+	store.CurrentControllerName = "mycontroller"
+	store.Accounts["mycontroller"] = jujuclient.AccountDetails{User: "admin"}
+	store.Models["mycontroller"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/mymodel": {ModelUUID: coretesting.ModelTag.Id()},
+		},
+		CurrentModel: "admin/mymodel",
+	}
+	s.store = store
+}
+
+func (s *SSHPoliciesSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.api = mocks.NewMockSSHPoliciesAPI(ctrl)
+	return ctrl
+}
+
+func (s *SSHPoliciesSuite) TestListEmpty(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.api.EXPECT().SSHPolicies().Return(nil, nil)
+	s.api.EXPECT().Close()
+
+	ctx, err := cmdtesting.RunCommand(c, ssh.NewSSHPoliciesCommandForTest(s.store, s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No SSH policies to display. All authorized keys have full access.\n")
+}
+
+func (s *SSHPoliciesSuite) policies() []sshpolicy.Policy {
+	return []sshpolicy.Policy{{
+		Name:  "operators",
+		Users: []string{sshpolicy.Everyone},
+		Shell: true,
+		SFTP:  true,
+	}, {
+		Name:         "dbas",
+		Users:        []string{"alice", "bob@external"},
+		Applications: []string{"postgresql"},
+		Commands:     []string{"psql *"},
+	}}
+}
+
+func (s *SSHPoliciesSuite) TestListTabular(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.api.EXPECT().SSHPolicies().Return(s.policies(), nil)
+	s.api.EXPECT().Close()
+
+	ctx, err := cmdtesting.RunCommand(c, ssh.NewSSHPoliciesCommandForTest(s.store, s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Name       Users               Applications  Allows
+dbas       alice,bob@external  postgresql    command "psql *"
+operators  *                   *             shell, sftp
+`[1:])
+}
+
+func (s *SSHPoliciesSuite) TestListYAML(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.api.EXPECT().SSHPolicies().Return(s.policies(), nil)
+	s.api.EXPECT().Close()
+
+	ctx, err := cmdtesting.RunCommand(c, ssh.NewSSHPoliciesCommandForTest(s.store, s.api), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+dbas:
+  users:
+  - alice
+  - bob@external
+  applications:
+  - postgresql
+  commands:
+  - psql *
+  shell: false
+  sftp: false
+  port-forwarding: false
+operators:
+  users:
+  - '*'
+  shell: true
+  sftp: true
+  port-forwarding: false
+`[1:])
+}
+
+func (s *SSHPoliciesSuite) TestSetInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no policy name specified",
+	}, {
+		args: []string{"dbas"},
+		err:  "at least one --user must be specified",
+	}, {
+		args: []string{"dbas", "extra", "--user", "*"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, ssh.NewSetSSHPolicyCommandForTest(s.store, nil), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SSHPoliciesSuite) TestSet(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.api.EXPECT().SetSSHPolicy(sshpolicy.Policy{
+		Name:         "dbas",
+		Users:        []string{"alice", "bob@external"},
+		Applications: []string{"postgresql"},
+		Commands:     []string{"psql *", "pg_dump *"},
+		SFTP:         true,
+	}).Return(nil)
+	s.api.EXPECT().Close()
+
+	_, err := cmdtesting.RunCommand(c, ssh.NewSetSSHPolicyCommandForTest(s.store, s.api),
+		"dbas", "--user", "alice", "--user", "bob@external",
+		"--application", "postgresql",
+		"--command", "psql *", "--command", "pg_dump *",
+		"--allow-sftp",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SSHPoliciesSuite) TestSetNotValid(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := cmdtesting.RunCommand(c, ssh.NewSetSSHPolicyCommandForTest(s.store, s.api), "dbas", "--user", "*")
+	c.Assert(err, gc.ErrorMatches, `policy "dbas" granting nothing not valid`)
+}
+
+func (s *SSHPoliciesSuite) TestRemove(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.api.EXPECT().RemoveSSHPolicy("dbas").Return(nil)
+	s.api.EXPECT().Close()
+
+	_, err := cmdtesting.RunCommand(c, ssh.NewRemoveSSHPolicyCommandForTest(s.store, s.api), "dbas")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SSHPoliciesSuite) TestRemoveNotFound(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.api.EXPECT().RemoveSSHPolicy("dbas").Return(errors.NotFoundf("ssh policy %q", "dbas"))
+	s.api.EXPECT().Close()
+
+	_, err := cmdtesting.RunCommand(c, ssh.NewRemoveSSHPolicyCommandForTest(s.store, s.api), "dbas")
+	c.Assert(err, gc.ErrorMatches, `ssh policy "dbas" not found`)
+}
+
+func (s *SSHPoliciesSuite) TestRemoveInitErrors(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, ssh.NewRemoveSSHPolicyCommandForTest(s.store, nil))
+	c.Assert(err, gc.ErrorMatches, "no policy name specified")
+	_, err = cmdtesting.RunCommand(c, ssh.NewRemoveSSHPolicyCommandForTest(s.store, nil), "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
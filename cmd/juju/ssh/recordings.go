@@ -0,0 +1,288 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/client/sshclient"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/rpc/params"
+)
+
+var usageSSHRecordingsSummary = `
+Lists and replays recorded SSH sessions.`[1:]
+
+var usageSSHRecordingsDetails = `
+When the ssh-session-recording controller config is enabled, the sessions
+proxied through the controller's SSH server to the model's machines and
+units are recorded. This command lists those recordings, optionally
+filtered by user, target and when the session started.
+
+With --replay, the output of the given recording is written to the
+terminal with its original timing, which can be sped up with --speed.
+With --raw, the recording is written out as is, in the asciicast v2
+format understood by tools such as asciinema.
+
+Times for --since and --until are given in RFC3339 format.
+
+Listing and replaying recordings requires admin access to the model.
+`
+
+const usageSSHRecordingsExamples = `
+    juju ssh-recordings
+    juju ssh-recordings --user ubuntu --target mysql/0
+    juju ssh-recordings --since 2025-01-01T00:00:00Z --format yaml
+    juju ssh-recordings --replay 0b5e2b84-1a1c-4b5e-8c9a-7a3e6f0f2d11
+    juju ssh-recordings --replay 0b5e2b84-1a1c-4b5e-8c9a-7a3e6f0f2d11 --raw > session.cast
+`
+
+// SSHRecordingsAPI is the API used to list and fetch
+// SSH session recordings.
+type SSHRecordingsAPI interface {
+	VirtualHostname(target string, container *string) (string, error)
+	SSHSessionRecordings(params.SSHSessionRecordingFilter) ([]params.SSHSessionRecording, error)
+	SSHSessionRecording(id string) (params.SSHSessionRecording, []byte, error)
+	Close() error
+}
+
+type sshRecordingsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	sshRecordingsAPIFunc func() (SSHRecordingsAPI, error)
+	sleep                func(time.Duration)
+
+	user   string
+	target string
+	since  string
+	until  string
+	replay string
+	raw    bool
+	speed  float64
+
+	filter params.SSHSessionRecordingFilter
+}
+
+// NewSSHRecordingsCommand returns a command to list and
+// replay SSH session recordings.
+func NewSSHRecordingsCommand() cmd.Command {
+	c := &sshRecordingsCommand{
+		sleep: time.Sleep,
+	}
+	c.sshRecordingsAPIFunc = c.sshRecordingsAPI
+	return modelcmd.Wrap(c)
+}
+
+func (c *sshRecordingsCommand) sshRecordingsAPI() (SSHRecordingsAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sshclient.NewFacade(root), nil
+}
+
+// Info implements cmd.Info.
+func (c *sshRecordingsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "ssh-recordings",
+		Purpose:  usageSSHRecordingsSummary,
+		Doc:      usageSSHRecordingsDetails,
+		Examples: usageSSHRecordingsExamples,
+		SeeAlso: []string{
+			"ssh",
+			"controller-config",
+		},
+	})
+}
+
+// SetFlags implements cmd.SetFlags.
+func (c *sshRecordingsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Only list sessions opened by this user")
+	f.StringVar(&c.target, "target", "", "Only list sessions to this machine or unit")
+	f.StringVar(&c.since, "since", "", "Only list sessions started at or after this time")
+	f.StringVar(&c.until, "until", "", "Only list sessions started at or before this time")
+	f.StringVar(&c.replay, "replay", "", "Replay the recording with this ID")
+	f.BoolVar(&c.raw, "raw", false, "With --replay, write out the recording in asciicast v2 format")
+	f.Float64Var(&c.speed, "speed", 1, "With --replay, the speed at which to replay the recording")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSSHRecordingsTabular,
+	})
+}
+
+// Init implements cmd.Init.
+func (c *sshRecordingsCommand) Init(args []string) error {
+	if c.replay != "" {
+		if c.user != "" || c.target != "" || c.since != "" || c.until != "" {
+			return errors.New("--replay cannot be used with --user, --target, --since or --until")
+		}
+		if c.speed <= 0 {
+			return errors.New("--speed must be positive")
+		}
+	} else if c.raw {
+		return errors.New("--raw can only be used with --replay")
+	}
+
+	c.filter = params.SSHSessionRecordingFilter{User: c.user}
+	for _, t := range []struct {
+		flag  string
+		value string
+		into  **time.Time
+	}{
+		{"--since", c.since, &c.filter.Since},
+		{"--until", c.until, &c.filter.Until},
+	} {
+		if t.value == "" {
+			continue
+		}
+		when, err := time.Parse(time.RFC3339, t.value)
+		if err != nil {
+			return errors.Errorf("invalid %s time %q: expected RFC3339 format", t.flag, t.value)
+		}
+		*t.into = &when
+	}
+	return cmd.CheckEmpty(args)
+}
+
+type sshRecordingDetails struct {
+	User           string    `json:"user" yaml:"user"`
+	KeyFingerprint string    `json:"key-fingerprint,omitempty" yaml:"key-fingerprint,omitempty"`
+	Target         string    `json:"target" yaml:"target"`
+	Started        time.Time `json:"started" yaml:"started"`
+	Ended          time.Time `json:"ended" yaml:"ended"`
+	Size           int64     `json:"size" yaml:"size"`
+}
+
+// Run implements cmd.Run.
+func (c *sshRecordingsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.sshRecordingsAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if c.replay != "" {
+		_, recording, err := api.SSHSessionRecording(c.replay)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if c.raw {
+			_, err := ctx.Stdout.Write(recording)
+			return errors.Trace(err)
+		}
+		return errors.Trace(c.replayRecording(ctx.Stdout, recording))
+	}
+
+	if c.target != "" {
+		c.filter.Target, err = api.VirtualHostname(c.target, nil)
+		if err != nil {
+			return errors.Annotatef(err, "resolving target %q", c.target)
+		}
+	}
+	recordings, err := api.SSHSessionRecordings(c.filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(recordings) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No SSH session recordings to display.")
+		return nil
+	}
+	details := make(map[string]sshRecordingDetails, len(recordings))
+	for _, r := range recordings {
+		details[r.ID] = sshRecordingDetails{
+			User:           r.User,
+			KeyFingerprint: r.KeyFingerprint,
+			Target:         r.Target,
+			Started:        r.Started,
+			Ended:          r.Ended,
+			Size:           r.Size,
+		}
+	}
+	return c.out.Write(ctx, details)
+}
+
+// replayRecording writes the output events of an asciicast v2
+// recording to out, waiting between them as in the original session.
+func (c *sshRecordingsCommand) replayRecording(out io.Writer, recording []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(recording))
+	scanner.Buffer(nil, len(recording)+1)
+
+	// The first line is the header, which describes the terminal.
+	if !scanner.Scan() {
+		return errors.Annotate(scanner.Err(), "reading recording header")
+	}
+	var elapsed float64
+	for scanner.Scan() {
+		var event []any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return errors.Annotate(err, "reading recording event")
+		}
+		if len(event) != 3 {
+			return errors.Errorf("invalid recording event %s", scanner.Text())
+		}
+		at, _ := event[0].(float64)
+		eventType, _ := event[1].(string)
+		data, _ := event[2].(string)
+		if eventType != "o" {
+			continue
+		}
+		if at > elapsed {
+			c.sleep(time.Duration((at - elapsed) / c.speed * float64(time.Second)))
+			elapsed = at
+		}
+		if _, err := io.WriteString(out, data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(scanner.Err())
+}
+
+func formatSSHRecordingsTabular(writer io.Writer, value interface{}) error {
+	recordings, ok := value.(map[string]sshRecordingDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", recordings, value)
+	}
+
+	ids := make([]string, 0, len(recordings))
+	for id := range recordings {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := recordings[ids[i]], recordings[ids[j]]
+		if !a.Started.Equal(b.Started) {
+			return a.Started.Before(b.Started)
+		}
+		return ids[i] < ids[j]
+	})
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.SetColumnAlignRight(5)
+
+	w.Println("ID", "User", "Target", "Started", "Duration", "Size")
+	for _, id := range ids {
+		r := recordings[id]
+		started := r.Started
+		w.Println(id, r.User, r.Target,
+			common.FormatTime(&started, false),
+			r.Ended.Sub(r.Started).Round(time.Second),
+			r.Size)
+	}
+	return tw.Flush()
+}
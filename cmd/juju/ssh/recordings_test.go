@@ -0,0 +1,161 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ssh_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/ssh"
+	"github.com/juju/juju/cmd/juju/ssh/mocks"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SSHRecordingsSuite struct {
+	jujutesting.IsolationSuite
+	store  *jujuclient.MemStore
+	api    *mocks.MockSSHRecordingsAPI
+	sleeps []time.Duration
+}
+
+var _ = gc.Suite(&SSHRecordingsSuite{})
+
+func (s *SSHRecordingsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	store := jujuclient.NewMemStore()
+	store.Controllers["mycontroller"] = jujuclient.ControllerDetails{}
+	store.CurrentControllerName = "mycontroller"
+	store.Accounts["mycontroller"] = jujuclient.AccountDetails{User: "admin"}
+	store.Models["mycontroller"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/mymodel": {ModelUUID: coretesting.ModelTag.Id()},
+		},
+		CurrentModel: "admin/mymodel",
+	}
+	s.store = store
+	s.sleeps = nil
+}
+
+func (s *SSHRecordingsSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.api = mocks.NewMockSSHRecordingsAPI(ctrl)
+	return ctrl
+}
+
+func (s *SSHRecordingsSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, ssh.NewSSHRecordingsCommandForTest(s.store, s.api, func(d time.Duration) {
+		s.sleeps = append(s.sleeps, d)
+	}), args...)
+}
+
+func (s *SSHRecordingsSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--raw"},
+		err:  "--raw can only be used with --replay",
+	}, {
+		args: []string{"--replay", "id", "--user", "ubuntu"},
+		err:  "--replay cannot be used with --user, --target, --since or --until",
+	}, {
+		args: []string{"--replay", "id", "--speed", "0"},
+		err:  "--speed must be positive",
+	}, {
+		args: []string{"--since", "yesterday"},
+		err:  `invalid --since time "yesterday": expected RFC3339 format`,
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		_, err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SSHRecordingsSuite) TestList(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	target := "0.mysql." + coretesting.ModelTag.Id() + ".juju.local"
+	s.api.EXPECT().VirtualHostname("mysql/0", nil).Return(target, nil)
+	s.api.EXPECT().SSHSessionRecordings(params.SSHSessionRecordingFilter{
+		User:   "ubuntu",
+		Target: target,
+		Since:  &since,
+	}).Return([]params.SSHSessionRecording{{
+		ID:      "id-2",
+		User:    "ubuntu",
+		Target:  target,
+		Started: since.Add(2 * time.Hour),
+		Ended:   since.Add(2*time.Hour + 90*time.Second),
+		Size:    2048,
+	}, {
+		ID:      "id-1",
+		User:    "ubuntu",
+		Target:  target,
+		Started: since.Add(time.Hour),
+		Ended:   since.Add(time.Hour + time.Minute),
+		Size:    1024,
+	}}, nil)
+	s.api.EXPECT().Close().Return(nil)
+
+	ctx, err := s.run(c, "--user", "ubuntu", "--target", "mysql/0", "--since", "2025-01-01T00:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Matches, `
+ID    User    Target +Started +Duration  Size
+id-1  ubuntu  0\.mysql\.deadbeef-0bad-400d-8000-4b1d0d06f00d\.juju\.local  .*  1m0s      1024
+id-2  ubuntu  0\.mysql\.deadbeef-0bad-400d-8000-4b1d0d06f00d\.juju\.local  .*  1m30s     2048
+`[1:])
+}
+
+func (s *SSHRecordingsSuite) TestListNone(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.api.EXPECT().SSHSessionRecordings(params.SSHSessionRecordingFilter{}).Return(nil, nil)
+	s.api.EXPECT().Close().Return(nil)
+
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No SSH session recordings to display.\n")
+}
+
+const testRecording = `{"version":2,"width":80,"height":24,"timestamp":1700000000}
+[0.5,"i","ls\r"]
+[0.5,"o","ls\r\n"]
+[1.5,"r","100x40"]
+[2.5,"o","file\r\n"]
+`
+
+func (s *SSHRecordingsSuite) TestReplay(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.api.EXPECT().SSHSessionRecording("id").Return(params.SSHSessionRecording{ID: "id"}, []byte(testRecording), nil)
+	s.api.EXPECT().Close().Return(nil)
+
+	ctx, err := s.run(c, "--replay", "id", "--speed", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "ls\r\nfile\r\n")
+	c.Assert(s.sleeps, jc.DeepEquals, []time.Duration{250 * time.Millisecond, time.Second})
+}
+
+func (s *SSHRecordingsSuite) TestReplayRaw(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.api.EXPECT().SSHSessionRecording("id").Return(params.SSHSessionRecording{ID: "id"}, []byte(testRecording), nil)
+	s.api.EXPECT().Close().Return(nil)
+
+	ctx, err := s.run(c, "--replay", "id", "--raw")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, testRecording)
+	c.Assert(s.sleeps, gc.HasLen, 0)
+}
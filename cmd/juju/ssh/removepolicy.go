@@ -0,0 +1,75 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ssh
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageRemoveSSHPolicySummary = `
+Removes an SSH policy from a model.`[1:]
+
+var usageRemoveSSHPolicyDetails = `
+Removes the named SSH policy from a model. Removing a model's last policy
+gives all its authorized keys full access again.
+
+Removing SSH policies requires admin access to the model.
+`
+
+const usageRemoveSSHPolicyExamples = `
+    juju remove-ssh-policy dbas
+`
+
+type removeSSHPolicyCommand struct {
+	sshPoliciesCommandBase
+
+	name string
+}
+
+// NewRemoveSSHPolicyCommand returns a command to remove
+// an SSH policy from a model.
+func NewRemoveSSHPolicyCommand() cmd.Command {
+	c := &removeSSHPolicyCommand{}
+	c.sshPoliciesAPIFunc = c.sshPoliciesAPI
+	return modelcmd.Wrap(c)
+}
+
+// Info implements cmd.Info.
+func (c *removeSSHPolicyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "remove-ssh-policy",
+		Args:     "<policy name>",
+		Purpose:  usageRemoveSSHPolicySummary,
+		Doc:      usageRemoveSSHPolicyDetails,
+		Examples: usageRemoveSSHPolicyExamples,
+		SeeAlso: []string{
+			"ssh-policies",
+			"set-ssh-policy",
+		},
+	})
+}
+
+// Init implements cmd.Init.
+func (c *removeSSHPolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no policy name specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Run.
+func (c *removeSSHPolicyCommand) Run(ctx *cmd.Context) error {
+	api, err := c.sshPoliciesAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	return errors.Trace(api.RemoveSSHPolicy(c.name))
+}
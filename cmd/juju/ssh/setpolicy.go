@@ -0,0 +1,142 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ssh
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/sshpolicy"
+)
+
+var usageSetSSHPolicySummary = `
+Adds or replaces an SSH policy of a model.`[1:]
+
+var usageSetSSHPolicyDetails = `
+SSH policies control what may be done on a model's machines and units
+through the controller's SSH server. Once a model has any policies, a
+connection may only reach the targets, and do the things, that a policy
+applying to it grants.
+
+A policy applies to the Juju users given with --user, by name or tag,
+such as "alice", "bob@external" or "user-alice". A --user of
+"everyone@external" applies the policy to all external users.
+Connections are authenticated as a Juju user with the SSH user
+certificates the controller issues to "juju ssh" when the controller's
+"ssh-user-certificates" setting is enabled; the certificate names the
+user who logged in to request it. Connections with one of the model's
+authorized keys aren't authenticated as any user, so only a --user of
+"*", which applies the policy to everyone, applies to them.
+
+With --application, the policy only grants access to the units of the
+given applications; otherwise it grants access to all machines and units.
+
+Commands which may be run are given with --command, as glob patterns in
+which "*" matches any sequence of characters and "?" any one character.
+As commands are run by a shell, those holding shell metacharacters,
+such as ";", "|", "$" or a newline, are only allowed by a --command
+of "*".
+Interactive shells, file transfers with sftp or scp, and local port
+forwarding have to be allowed explicitly.
+
+Setting a policy with the name of an existing policy replaces it.
+
+Setting SSH policies requires admin access to the model.
+`
+
+const usageSetSSHPolicyExamples = `
+    juju set-ssh-policy operators --user '*' --allow-shell --allow-sftp
+    juju set-ssh-policy dbas --user alice --user bob@external \
+        --application postgresql --command 'psql *' --command 'pg_dump *'
+    juju set-ssh-policy tunnels --user everyone@external --allow-port-forwarding
+`
+
+type setSSHPolicyCommand struct {
+	sshPoliciesCommandBase
+
+	name                string
+	users               []string
+	applications        []string
+	commands            []string
+	allowShell          bool
+	allowSFTP           bool
+	allowPortForwarding bool
+}
+
+// NewSetSSHPolicyCommand returns a command to add or replace
+// an SSH policy of a model.
+func NewSetSSHPolicyCommand() cmd.Command {
+	c := &setSSHPolicyCommand{}
+	c.sshPoliciesAPIFunc = c.sshPoliciesAPI
+	return modelcmd.Wrap(c)
+}
+
+// Info implements cmd.Info.
+func (c *setSSHPolicyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "set-ssh-policy",
+		Args:     "<policy name>",
+		Purpose:  usageSetSSHPolicySummary,
+		Doc:      usageSetSSHPolicyDetails,
+		Examples: usageSetSSHPolicyExamples,
+		SeeAlso: []string{
+			"ssh-policies",
+			"remove-ssh-policy",
+			"ssh",
+		},
+	})
+}
+
+// SetFlags implements cmd.SetFlags.
+func (c *setSSHPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.users), "user", "A Juju user the policy applies to, or \"*\" for everyone")
+	f.Var(cmd.NewAppendStringsValue(&c.applications), "application", "Only grant access to the units of this application")
+	f.Var(cmd.NewAppendStringsValue(&c.commands), "command", "Allow commands matching this glob pattern to be run")
+	f.BoolVar(&c.allowShell, "allow-shell", false, "Allow interactive shells to be opened")
+	f.BoolVar(&c.allowSFTP, "allow-sftp", false, "Allow files to be transferred with sftp or scp")
+	f.BoolVar(&c.allowPortForwarding, "allow-port-forwarding", false, "Allow local ports to be forwarded")
+}
+
+// Init implements cmd.Init.
+func (c *setSSHPolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no policy name specified")
+	}
+	c.name, args = args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return err
+	}
+	if len(c.users) == 0 {
+		return errors.New("at least one --user must be specified")
+	}
+	return nil
+}
+
+// Run implements cmd.Run.
+func (c *setSSHPolicyCommand) Run(ctx *cmd.Context) error {
+	policy := sshpolicy.Policy{
+		Name:           c.name,
+		Users:          c.users,
+		Applications:   c.applications,
+		Commands:       c.commands,
+		Shell:          c.allowShell,
+		SFTP:           c.allowSFTP,
+		PortForwarding: c.allowPortForwarding,
+	}
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	api, err := c.sshPoliciesAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	return errors.Trace(api.SetSSHPolicy(policy))
+}
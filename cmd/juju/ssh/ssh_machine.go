@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -47,6 +48,7 @@ type sshMachine struct {
 	statusClient           statusClient
 	apiAddr                *url.URL
 	knownHostsPath         string
+	certificateDir         string
 	hostChecker            jujussh.ReachableChecker
 	retryStrategy          retry.CallArgs
 	publicKeyRetryStrategy retry.CallArgs
@@ -61,6 +63,7 @@ type sshAPIClient interface {
 	PrivateAddress(target string) (string, error)
 	AllAddresses(target string) ([]string, error)
 	PublicKeys(target string) ([]string, error)
+	SSHUserCertificate(targets []string, publicKey string) (string, error)
 	Proxy() (bool, error)
 	Close() error
 }
@@ -165,14 +168,18 @@ func (c *sshMachine) initRun(mc ModelCommand) (err error) {
 	return nil
 }
 
-// cleanupRun removes the temporary SSH known_hosts file (if one was
-// created) and closes the API connection. It must be called at the
-// end of the command's Run (i.e. as a defer).
+// cleanupRun removes the temporary SSH known_hosts file and user
+// certificate (if they were created) and closes the API connection.
+// It must be called at the end of the command's Run (i.e. as a defer).
 func (c *sshMachine) cleanupRun() {
 	if c.knownHostsPath != "" {
 		_ = os.Remove(c.knownHostsPath)
 		c.knownHostsPath = ""
 	}
+	if c.certificateDir != "" {
+		_ = os.RemoveAll(c.certificateDir)
+		c.certificateDir = ""
+	}
 	if c.sshClient != nil {
 		_ = c.sshClient.Close()
 		c.sshClient = nil
@@ -210,6 +217,10 @@ func (c *sshMachine) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (
 		}
 	}
 
+	if err := c.setCertificateIdentity(&options, targets); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if enablePty {
 		options.EnablePTY()
 	}
@@ -223,6 +234,60 @@ func (c *sshMachine) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (
 	return &options, nil
 }
 
+// setCertificateIdentity asks the controller for a short-lived SSH user
+// certificate for the Juju client key, valid for the machine and unit
+// targets, and has ssh authenticate with it. The client key is used on
+// its own if the controller doesn't issue certificates, and an error is
+// returned if it does but can't issue one. If the model has SSH
+// policies, a certificate for a unit isn't valid for its machine, so
+// the unit can only be reached through the controller's SSH server.
+func (c *sshMachine) setCertificateIdentity(options *ssh.Options, targets []*resolvedTarget) error {
+	var entities []string
+	for _, target := range targets {
+		if target.isAgent() {
+			entities = append(entities, target.entity)
+		}
+	}
+	keyFiles := ssh.PrivateKeyFiles()
+	if len(entities) == 0 || len(keyFiles) == 0 {
+		return nil
+	}
+	privateKey, err := os.ReadFile(keyFiles[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	publicKey, err := os.ReadFile(keyFiles[0] + ssh.PublicKeySuffix)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	certificate, err := c.sshClient.SSHUserCertificate(entities, string(publicKey))
+	if errors.Is(err, errors.NotSupported) {
+		logger.Debugf("not using an ssh user certificate: %v", err)
+		return nil
+	}
+	if err != nil {
+		// The controller issues certificates, so the machines may
+		// only accept them; don't hide why the connection would fail.
+		return errors.Annotate(err, "cannot get ssh user certificate from the controller")
+	}
+
+	// ssh looks for the certificate of an identity next to it, so
+	// both are written to a temporary directory.
+	if c.certificateDir, err = os.MkdirTemp("", "juju-ssh"); err != nil {
+		return errors.Trace(err)
+	}
+	identity := filepath.Join(c.certificateDir, "id")
+	if err := os.WriteFile(identity, privateKey, 0600); err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.WriteFile(identity+"-cert"+ssh.PublicKeySuffix, []byte(certificate), 0600); err != nil {
+		return errors.Trace(err)
+	}
+	options.SetIdentities(identity)
+	return nil
+}
+
 func (c *sshMachine) ssh(ctx Context, enablePty bool, target *resolvedTarget) error {
 	options, err := c.getSSHOptions(enablePty, target)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 	jujussh "github.com/juju/juju/network/ssh"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 )
 
 // argsSpec is a test helper which converts a number of options into
@@ -378,3 +379,98 @@ func (s *SSHMachineSuite) setKeys(c *gc.C, m *state.Machine) {
 	err := s.State.SetSSHHostKeys(m.MachineTag(), keys)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+type certificateIdentitySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&certificateIdentitySuite{})
+
+func (s *certificateIdentitySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	// Loading keys from an empty directory generates a key pair.
+	err := ssh.LoadClientKeys(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { ssh.ClearClientKeys() })
+}
+
+type fakeCertificateClient struct {
+	sshAPIClient
+	targets   []string
+	publicKey string
+	err       error
+}
+
+func (f *fakeCertificateClient) SSHUserCertificate(targets []string, publicKey string) (string, error) {
+	f.targets = targets
+	f.publicKey = publicKey
+	return "ssh-ed25519-cert-v01@openssh.com AAAA", f.err
+}
+
+func (s *certificateIdentitySuite) TestSetCertificateIdentity(c *gc.C) {
+	client := &fakeCertificateClient{}
+	machine := &sshMachine{sshClient: client}
+	defer machine.cleanupRun()
+
+	var options ssh.Options
+	err := machine.setCertificateIdentity(&options, []*resolvedTarget{
+		{user: "ubuntu", entity: "0"},
+		{user: "ubuntu", entity: "foo/1"},
+		{host: "10.0.0.1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.targets, jc.DeepEquals, []string{"0", "foo/1"})
+
+	keyFile := ssh.PrivateKeyFiles()[0]
+	publicKey, err := os.ReadFile(keyFile + ssh.PublicKeySuffix)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.publicKey, gc.Equals, string(publicKey))
+
+	// The client key and its certificate are written side by side.
+	identity := filepath.Join(machine.certificateDir, "id")
+	privateKey, err := os.ReadFile(keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := os.ReadFile(identity)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, privateKey)
+	data, err = os.ReadFile(identity + "-cert.pub")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "ssh-ed25519-cert-v01@openssh.com AAAA")
+
+	certificateDir := machine.certificateDir
+	machine.sshClient = nil
+	machine.cleanupRun()
+	_, err = os.Stat(certificateDir)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *certificateIdentitySuite) TestSetCertificateIdentityNotSupported(c *gc.C) {
+	client := &fakeCertificateClient{err: errors.NotSupportedf("ssh user certificates")}
+	machine := &sshMachine{sshClient: client}
+
+	var options ssh.Options
+	err := machine.setCertificateIdentity(&options, []*resolvedTarget{{user: "ubuntu", entity: "0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.certificateDir, gc.Equals, "")
+}
+
+func (s *certificateIdentitySuite) TestSetCertificateIdentityError(c *gc.C) {
+	client := &fakeCertificateClient{err: errors.New("boom")}
+	machine := &sshMachine{sshClient: client}
+
+	var options ssh.Options
+	err := machine.setCertificateIdentity(&options, []*resolvedTarget{{user: "ubuntu", entity: "0"}})
+	c.Assert(err, gc.ErrorMatches, "cannot get ssh user certificate from the controller: boom")
+	c.Assert(machine.certificateDir, gc.Equals, "")
+}
+
+func (s *certificateIdentitySuite) TestSetCertificateIdentityNoAgentTargets(c *gc.C) {
+	client := &fakeCertificateClient{}
+	machine := &sshMachine{sshClient: client}
+
+	var options ssh.Options
+	err := machine.setCertificateIdentity(&options, []*resolvedTarget{{host: "10.0.0.1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.targets, gc.IsNil)
+	c.Assert(machine.certificateDir, gc.Equals, "")
+}
@@ -0,0 +1,362 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/output"
+)
+
+// statusChange describes a single transition of an entity between two
+// status snapshots.
+type statusChange struct {
+	Time   string `json:"time,omitempty" yaml:"time,omitempty"`
+	Kind   string `json:"kind" yaml:"kind"`
+	Entity string `json:"entity" yaml:"entity"`
+	Change string `json:"change" yaml:"change"`
+	Field  string `json:"field,omitempty" yaml:"field,omitempty"`
+	Old    string `json:"old,omitempty" yaml:"old,omitempty"`
+	New    string `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+// The kinds of entities that are compared, in the order that they are
+// reported.
+var diffKinds = []string{"machine", "application", "unit", "relation"}
+
+// entityFields holds the compared fields of an entity, along with the time
+// at which each field last changed, if it is known.
+type entityFields struct {
+	values map[string]string
+	since  map[string]string
+}
+
+func (f entityFields) set(field, value, since string) {
+	f.values[field] = value
+	if since != "" {
+		f.since[field] = since
+	}
+}
+
+// snapshotEntities flattens a formatted status into the entities that are
+// compared, keyed by kind and then by entity name.
+func snapshotEntities(fs formattedStatus, fullRelations bool) map[string]map[string]entityFields {
+	entities := make(map[string]map[string]entityFields)
+	add := func(kind, name string) entityFields {
+		if entities[kind] == nil {
+			entities[kind] = make(map[string]entityFields)
+		}
+		fields := entityFields{
+			values: make(map[string]string),
+			since:  make(map[string]string),
+		}
+		entities[kind][name] = fields
+		return fields
+	}
+
+	var addMachine func(string, machineStatus)
+	addMachine = func(id string, m machineStatus) {
+		fields := add("machine", id)
+		currentStatus, message := getStatusAndMessageFromMachineStatus(m)
+		fields.set("status", string(currentStatus), m.JujuStatus.Since)
+		fields.set("instance-status", string(m.MachineStatus.Current), m.MachineStatus.Since)
+		fields.set("message", message, "")
+		fields.set("dns-name", m.DNSName, "")
+		for containerID, container := range m.Containers {
+			addMachine(containerID, container)
+		}
+	}
+	for id, m := range fs.Machines {
+		addMachine(id, m)
+	}
+
+	var addUnit func(string, unitStatus)
+	addUnit = func(name string, u unitStatus) {
+		fields := add("unit", name)
+		fields.set("workload-status", string(u.WorkloadStatusInfo.Current), u.WorkloadStatusInfo.Since)
+		fields.set("workload-message", u.WorkloadStatusInfo.Message, u.WorkloadStatusInfo.Since)
+		fields.set("agent-status", string(u.JujuStatusInfo.Current), u.JujuStatusInfo.Since)
+		fields.set("machine", u.Machine, "")
+		for subName, sub := range u.Subordinates {
+			addUnit(subName, sub)
+		}
+	}
+	for name, app := range fs.Applications {
+		fields := add("application", name)
+		fields.set("status", string(app.StatusInfo.Current), app.StatusInfo.Since)
+		fields.set("message", app.StatusInfo.Message, app.StatusInfo.Since)
+		fields.set("charm-rev", strconv.Itoa(app.CharmRev), "")
+		for unitName, u := range app.Units {
+			addUnit(unitName, u)
+		}
+		if fullRelations {
+			continue
+		}
+		// Saved status dumps don't include the relations section, so fall
+		// back to the relations of each application endpoint.
+		for endpoint, related := range app.Relations {
+			for _, rel := range related {
+				add("relation", fmt.Sprintf("%s:%s %s", name, endpoint, rel.RelatedApplicationName))
+			}
+		}
+	}
+	if fullRelations {
+		for _, rel := range fs.Relations {
+			fields := add("relation", fmt.Sprintf("%s %s", rel.Provider, rel.Requirer))
+			fields.set("status", rel.Status, "")
+			fields.set("message", rel.Message, "")
+		}
+	}
+	return entities
+}
+
+// diffStatus returns the changes between two formatted status snapshots.
+// Changes for which the time of the transition is not known are reported
+// with the given timestamp.
+func diffStatus(previous, current formattedStatus, timestamp string) []statusChange {
+	fullRelations := len(previous.Relations) > 0 && len(current.Relations) > 0
+	oldEntities := snapshotEntities(previous, fullRelations)
+	newEntities := snapshotEntities(current, fullRelations)
+
+	var changes []statusChange
+	for _, kind := range diffKinds {
+		names := make(map[string]bool)
+		for name := range oldEntities[kind] {
+			names[name] = true
+		}
+		for name := range newEntities[kind] {
+			names[name] = true
+		}
+		sorted := make([]string, 0, len(names))
+		for name := range names {
+			sorted = append(sorted, name)
+		}
+		for _, name := range naturalsort.Sort(sorted) {
+			oldFields, inOld := oldEntities[kind][name]
+			newFields, inNew := newEntities[kind][name]
+			switch {
+			case !inOld:
+				changes = append(changes, statusChange{
+					Time:   timestamp,
+					Kind:   kind,
+					Entity: name,
+					Change: changeAdded,
+				})
+			case !inNew:
+				changes = append(changes, statusChange{
+					Time:   timestamp,
+					Kind:   kind,
+					Entity: name,
+					Change: changeRemoved,
+				})
+			default:
+				changes = append(changes, diffFields(kind, name, oldFields, newFields, timestamp)...)
+			}
+		}
+	}
+	return changes
+}
+
+func diffFields(kind, name string, oldFields, newFields entityFields, timestamp string) []statusChange {
+	fieldNames := make([]string, 0, len(newFields.values))
+	for field := range newFields.values {
+		fieldNames = append(fieldNames, field)
+	}
+	sort.Strings(fieldNames)
+
+	var changes []statusChange
+	for _, field := range fieldNames {
+		oldValue, newValue := oldFields.values[field], newFields.values[field]
+		if oldValue == newValue {
+			continue
+		}
+		when := newFields.since[field]
+		if when == "" {
+			when = timestamp
+		}
+		changes = append(changes, statusChange{
+			Time:   when,
+			Kind:   kind,
+			Entity: name,
+			Change: changeChanged,
+			Field:  field,
+			Old:    oldValue,
+			New:    newValue,
+		})
+	}
+	return changes
+}
+
+// readStatusFile reads a status snapshot that was previously saved with
+// either the json or yaml format.
+func readStatusFile(path string) (formattedStatus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return formattedStatus{}, errors.Trace(err)
+	}
+	var fs formattedStatus
+	if jsonErr := json.Unmarshal(data, &fs); jsonErr == nil {
+		return fs, nil
+	}
+	fs = formattedStatus{}
+	if err := yaml.Unmarshal(data, &fs); err != nil {
+		return formattedStatus{}, errors.Annotatef(err, "reading status from %q, expected json or yaml", path)
+	}
+	return fs, nil
+}
+
+// snapshotTimestamp returns the controller timestamp of a status snapshot,
+// if there is one.
+func snapshotTimestamp(fs formattedStatus) string {
+	if fs.Controller == nil {
+		return ""
+	}
+	return fs.Controller.Timestamp
+}
+
+// writeStatusChanges writes the changes in the given output format. When
+// streaming, every json change is written on its own line, every yaml batch
+// of changes is written as a separate document and every tabular batch is
+// written as a separate table, so that the output can be consumed while
+// watching.
+func writeStatusChanges(writer io.Writer, format string, changes []statusChange, stream bool) error {
+	switch format {
+	case "json":
+		if !stream {
+			if changes == nil {
+				changes = []statusChange{}
+			}
+			return cmd.FormatJson(writer, changes)
+		}
+		encoder := json.NewEncoder(writer)
+		for _, change := range changes {
+			if err := encoder.Encode(change); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	case "yaml":
+		if len(changes) == 0 && stream {
+			return nil
+		}
+		if stream {
+			if _, err := io.WriteString(writer, "---\n"); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return cmd.FormatYaml(writer, changes)
+	}
+	if len(changes) > 0 && stream {
+		// Separate each batch of changes from the previous one.
+		if _, err := io.WriteString(writer, "\n"); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return formatStatusChangesTabular(writer, changes)
+}
+
+func formatStatusChangesTabular(writer io.Writer, changes []statusChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Time", "Entity", "Change", "Field", "Old", "New")
+	for _, change := range changes {
+		w.Print(change.Time, change.Kind+" "+change.Entity)
+		switch change.Change {
+		case changeAdded:
+			w.PrintColor(output.GoodHighlight, change.Change)
+		case changeRemoved:
+			w.PrintColor(output.ErrorHighlight, change.Change)
+		default:
+			w.Print(change.Change)
+		}
+		w.Println(change.Field, truncateMessage(strings.TrimSpace(change.Old)), truncateMessage(strings.TrimSpace(change.New)))
+	}
+	return errors.Trace(tw.Flush())
+}
+
+// runCompare reports the changes between a saved status file and the current
+// status of the model, or between two saved status files.
+func (c *statusCommand) runCompare(ctx *cmd.Context) error {
+	files := strings.Split(c.compare, ",")
+	previous, err := readStatusFile(ctx.AbsPath(strings.TrimSpace(files[0])))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var current formattedStatus
+	if len(files) == 2 {
+		if current, err = readStatusFile(ctx.AbsPath(strings.TrimSpace(files[1]))); err != nil {
+			return errors.Trace(err)
+		}
+	} else if _, current, err = c.formatStatus(ctx, true, false); err != nil {
+		return errors.Trace(err)
+	}
+
+	changes := diffStatus(previous, current, c.changeTimestamp(current))
+	if len(changes) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No changes.")
+		return nil
+	}
+	return writeStatusChanges(ctx.Stdout, c.out.Name(), changes, false)
+}
+
+// runDiffWatch queries the status every watch period, reporting only the
+// changes since the previous query, until the command is interrupted.
+func (c *statusCommand) runDiffWatch(ctx *cmd.Context) error {
+	_, previous, err := c.formatStatus(ctx, true, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Watching for status changes every %v...", c.watch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.clock.After(c.watch):
+		}
+
+		_, current, err := c.formatStatus(ctx, true, false)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		changes := diffStatus(previous, current, c.changeTimestamp(current))
+		if err := writeStatusChanges(ctx.Stdout, c.out.Name(), changes, true); err != nil {
+			return errors.Trace(err)
+		}
+		previous = current
+	}
+}
+
+// changeTimestamp returns the time to report for changes to the given status
+// for which the time of the transition isn't known.
+func (c *statusCommand) changeTimestamp(fs formattedStatus) string {
+	if timestamp := snapshotTimestamp(fs); timestamp != "" {
+		return timestamp
+	}
+	now := time.Now()
+	return common.FormatTime(&now, c.isoTime)
+}
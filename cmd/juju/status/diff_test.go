@@ -0,0 +1,210 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/status"
+)
+
+type diffSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&diffSuite{})
+
+func (s *diffSuite) snapshot() formattedStatus {
+	return formattedStatus{
+		Controller: &controllerStatus{Timestamp: "12:00:00Z"},
+		Machines: map[string]machineStatus{
+			"0": {
+				JujuStatus:    statusInfoContents{Current: status.Started, Since: "01 Jan 2025 11:00:00Z"},
+				MachineStatus: statusInfoContents{Current: status.Running, Message: "Running"},
+				DNSName:       "10.0.0.1",
+			},
+		},
+		Applications: map[string]applicationStatus{
+			"mysql": {
+				CharmRev:   42,
+				StatusInfo: statusInfoContents{Current: status.Active},
+				Units: map[string]unitStatus{
+					"mysql/0": {
+						WorkloadStatusInfo: statusInfoContents{Current: status.Active, Message: "ready"},
+						JujuStatusInfo:     statusInfoContents{Current: status.Idle},
+						Machine:            "0",
+					},
+				},
+				Relations: map[string][]applicationStatusRelation{
+					"db": {{RelatedApplicationName: "wordpress"}},
+				},
+			},
+		},
+	}
+}
+
+func (s *diffSuite) TestDiffStatusNoChanges(c *gc.C) {
+	c.Assert(diffStatus(s.snapshot(), s.snapshot(), "12:00:00Z"), gc.HasLen, 0)
+}
+
+func (s *diffSuite) TestDiffStatus(c *gc.C) {
+	previous := s.snapshot()
+	current := s.snapshot()
+
+	app := current.Applications["mysql"]
+	app.Units = map[string]unitStatus{
+		"mysql/0": {
+			WorkloadStatusInfo: statusInfoContents{Current: status.Blocked, Message: "no storage", Since: "01 Jan 2025 12:01:00Z"},
+			JujuStatusInfo:     statusInfoContents{Current: status.Idle},
+			Machine:            "0",
+		},
+		"mysql/1": {
+			WorkloadStatusInfo: statusInfoContents{Current: status.Waiting},
+			Machine:            "1",
+		},
+	}
+	app.Relations = nil
+	current.Applications["mysql"] = app
+	current.Machines = map[string]machineStatus{
+		"1": {JujuStatus: statusInfoContents{Current: status.Pending}},
+	}
+
+	changes := diffStatus(previous, current, "12:05:00Z")
+	c.Assert(changes, jc.DeepEquals, []statusChange{{
+		Time: "12:05:00Z", Kind: "machine", Entity: "0", Change: changeRemoved,
+	}, {
+		Time: "12:05:00Z", Kind: "machine", Entity: "1", Change: changeAdded,
+	}, {
+		Time: "01 Jan 2025 12:01:00Z", Kind: "unit", Entity: "mysql/0", Change: changeChanged,
+		Field: "workload-message", Old: "ready", New: "no storage",
+	}, {
+		Time: "01 Jan 2025 12:01:00Z", Kind: "unit", Entity: "mysql/0", Change: changeChanged,
+		Field: "workload-status", Old: "active", New: "blocked",
+	}, {
+		Time: "12:05:00Z", Kind: "unit", Entity: "mysql/1", Change: changeAdded,
+	}, {
+		Time: "12:05:00Z", Kind: "relation", Entity: "mysql:db wordpress", Change: changeRemoved,
+	}})
+}
+
+func (s *diffSuite) TestDiffStatusRelations(c *gc.C) {
+	previous := s.snapshot()
+	previous.Relations = []relationStatus{{
+		Provider: "mysql:db", Requirer: "wordpress:db", Status: "joining",
+	}}
+	current := s.snapshot()
+	current.Relations = []relationStatus{{
+		Provider: "mysql:db", Requirer: "wordpress:db", Status: "joined",
+	}}
+
+	changes := diffStatus(previous, current, "12:05:00Z")
+	c.Assert(changes, jc.DeepEquals, []statusChange{{
+		Time: "12:05:00Z", Kind: "relation", Entity: "mysql:db wordpress:db", Change: changeChanged,
+		Field: "status", Old: "joining", New: "joined",
+	}})
+}
+
+func (s *diffSuite) TestReadStatusFile(c *gc.C) {
+	dir := c.MkDir()
+	for _, format := range []string{"json", "yaml"} {
+		var data []byte
+		var err error
+		if format == "json" {
+			data, err = json.Marshal(s.snapshot())
+		} else {
+			data, err = goyaml.Marshal(s.snapshot())
+		}
+		c.Assert(err, jc.ErrorIsNil)
+
+		path := filepath.Join(dir, "status."+format)
+		err = os.WriteFile(path, data, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+
+		read, err := readStatusFile(path)
+		c.Assert(err, jc.ErrorIsNil, gc.Commentf("format %s", format))
+		c.Check(diffStatus(s.snapshot(), read, ""), gc.HasLen, 0, gc.Commentf("format %s", format))
+	}
+}
+
+func (s *diffSuite) TestReadStatusFileInvalid(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "status.txt")
+	err := os.WriteFile(path, []byte("machines: [\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = readStatusFile(path)
+	c.Assert(err, gc.ErrorMatches, `reading status from .*, expected json or yaml: .*`)
+}
+
+func (s *diffSuite) TestWriteStatusChanges(c *gc.C) {
+	changes := []statusChange{{
+		Time: "12:05:00Z", Kind: "machine", Entity: "1", Change: changeAdded,
+	}, {
+		Time: "12:05:00Z", Kind: "unit", Entity: "mysql/0", Change: changeChanged,
+		Field: "workload-status", Old: "active", New: "blocked",
+	}}
+
+	var buf bytes.Buffer
+	err := writeStatusChanges(&buf, "tabular", changes, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, ""+
+		"Time       Entity        Change   Field            Old     New\n"+
+		"12:05:00Z  machine 1     added                             \n"+
+		"12:05:00Z  unit mysql/0  changed  workload-status  active  blocked\n")
+
+	buf.Reset()
+	err = writeStatusChanges(&buf, "json", changes, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, `
+{"time":"12:05:00Z","kind":"machine","entity":"1","change":"added"}
+{"time":"12:05:00Z","kind":"unit","entity":"mysql/0","change":"changed","field":"workload-status","old":"active","new":"blocked"}
+`[1:])
+
+	buf.Reset()
+	err = writeStatusChanges(&buf, "yaml", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, "")
+}
+
+func (s *diffSuite) TestInitDiff(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--diff"},
+		err:  "--diff requires --watch",
+	}, {
+		args: []string{"--diff", "--watch", "5s", "--format", "oneline"},
+		err:  `changes cannot be displayed in the "oneline" format, use tabular, json or yaml`,
+	}, {
+		args: []string{"--compare", "a.json", "--watch", "5s"},
+		err:  "cannot mix --compare and --watch",
+	}, {
+		args: []string{"--compare", "a.json,b.json,c.json"},
+		err:  "--compare expects one or two status files, got 3",
+	}, {
+		args: []string{"--compare", "a.json,"},
+		err:  "--compare expects a status file name",
+	}, {
+		args: []string{"--diff", "--watch", "5s", "--format", "json"},
+	}, {
+		args: []string{"--compare", "a.json,b.yaml"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(&statusCommand{}, test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
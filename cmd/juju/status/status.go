@@ -74,6 +74,20 @@ type statusCommand struct {
 
 	// watch indicates the time to wait between consecutive status queries
 	watch time.Duration
+
+	// where holds the predicates on unit status used to filter the status
+	// on the controller
+	where []string
+
+	// fields holds the sections of the status requested from the controller
+	fields []string
+
+	// diff indicates that only the changes between consecutive status
+	// queries are displayed when watching
+	diff bool
+
+	// compare holds the saved status files to compare
+	compare string
 }
 
 var usageSummary = `
@@ -122,6 +136,49 @@ The '--format' option allows you to specify how the status report is formatted.
   --format=yaml
                     Provide information in a JSON or YAML formats for 
                     programmatic use.
+
+
+Filtering on the controller
+
+The '--where' option filters the status on the controller, so that only the
+units whose status matches all the given predicates are reported, along with
+their applications, machines and integrations. The supported predicates are:
+
+  workload=<status>  The workload status of the unit, such as blocked.
+  agent=<status>     The agent status of the unit, such as error.
+  since<<duration>>  The workload or agent status changed within the duration.
+  since><duration>   Neither status changed within the duration.
+
+Status predicates may use '!=' to negate the match, and may list alternative
+statuses separated by '|', such as 'workload=blocked|waiting'.
+
+The '--fields' option limits the sections of the status that are returned by
+the controller to the given comma separated list of machines, applications,
+units, relations, remote-applications, offers, branches and storage. When
+units are selected without applications, only the status of each unit is
+returned. The model section is always returned.
+
+Selectors, '--where' and '--fields' can be combined to reduce the size of the
+status of large models.
+
+
+Showing changes
+
+The '--diff' option, used together with '--watch', reports only the
+transitions between consecutive status queries: machines, applications, units
+and integrations that were added or removed, and changes to their status and
+status messages. Each change is reported with the time at which it happened,
+if known, or the time at which it was observed.
+
+The '--compare' option reports the same changes offline, between status that
+was previously saved with '--format=json' or '--format=yaml'. When a single
+file is given, it is compared with the current status of the model. When two
+comma separated files are given, the first is compared with the second without
+connecting to the controller.
+
+Changes are displayed in the tabular, json or yaml formats. When watching,
+each json change is written on its own line and each yaml batch of changes is
+written as a separate document.
 `
 
 const usageExamples = `
@@ -156,6 +213,29 @@ Show only applications/units in active status:
 Show only applications/units in error status:
 
     juju status error
+
+Show only the units that are blocked or whose agent is in error:
+
+    juju status --where 'workload=blocked|error'
+    juju status --where agent=error
+
+Show the units whose status changed in the last ten minutes, with only their
+status and messages:
+
+    juju status --where 'since<10m' --fields units
+
+Show only the changes to the status every five seconds:
+
+    juju status --watch 5s --diff
+
+Show the changes since the status was saved:
+
+    juju status --format=yaml > before.yaml
+    juju status --compare before.yaml
+
+Show the changes between two saved status files:
+
+    juju status --compare before.json,after.json
 `
 
 func (c *statusCommand) Info() *cmd.Info {
@@ -188,6 +268,10 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
 
 	f.DurationVar(&c.watch, "watch", 0, "Watch the status every period of time")
+	f.Var(cmd.NewStringsValue(nil, &c.where), "where", "Comma separated predicates on unit status, such as 'workload=blocked,since<10m'")
+	f.Var(cmd.NewStringsValue(nil, &c.fields), "fields", "Comma separated sections of the status to show, such as 'machines,units'")
+	f.BoolVar(&c.diff, "diff", false, "Show only the changes between consecutive status queries when watching")
+	f.StringVar(&c.compare, "compare", "", "Show the changes from a saved status file, or between two comma separated files")
 
 	c.checkProvidedIgnoredFlagF = func() set.Strings {
 		ignoredFlagForNonTabularFormat := set.NewStrings(
@@ -239,6 +323,31 @@ func (c *statusCommand) Init(args []string) error {
 		return errors.Errorf("cannot mix --no-color and --color")
 	}
 
+	if c.diff && c.watch == 0 {
+		return errors.Errorf("--diff requires --watch")
+	}
+	if c.compare != "" {
+		if c.watch != 0 {
+			return errors.Errorf("cannot mix --compare and --watch")
+		}
+		files := strings.Split(c.compare, ",")
+		if len(files) > 2 {
+			return errors.Errorf("--compare expects one or two status files, got %d", len(files))
+		}
+		for _, file := range files {
+			if strings.TrimSpace(file) == "" {
+				return errors.Errorf("--compare expects a status file name")
+			}
+		}
+	}
+	if c.diff || c.compare != "" {
+		switch c.out.Name() {
+		case "tabular", "json", "yaml":
+		default:
+			return errors.Errorf("changes cannot be displayed in the %q format, use tabular, json or yaml", c.out.Name())
+		}
+	}
+
 	return nil
 }
 
@@ -269,6 +378,8 @@ func (c *statusCommand) getStatus(includeStorage bool) (*params.FullStatus, erro
 	return apiclient.Status(&client.StatusArgs{
 		Patterns:       c.patterns,
 		IncludeStorage: includeStorage,
+		Where:          c.where,
+		Fields:         c.fields,
 	})
 }
 
@@ -291,6 +402,43 @@ func (c *statusCommand) runStatus(ctx *cmd.Context) error {
 		}
 	}
 
+	status, formatted, err := c.formatStatus(ctx, showIntegrations, showStorage)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if err = c.out.Write(ctx, formatted); err != nil {
+		return err
+	}
+
+	if !status.IsEmpty() {
+		return nil
+	}
+	if len(c.patterns) == 0 && len(c.where) == 0 {
+		modelName, err := c.ModelIdentifier()
+		if err != nil {
+			return err
+		}
+		// A change was made in cmd/v3.0.2 output.go that broke the consistency in output for the
+		// default formatter by removing the newline delimiter. Hence we prefix '\n' in the text below.
+		// https://github.com/juju/cmd/commit/be22fa661a798055c801f1511aee226db249ef95
+		ctx.Infof("\nModel %q is empty.", modelName)
+	} else {
+		plural := func() string {
+			if len(c.patterns)+len(c.where) == 1 {
+				return ""
+			}
+			return "s"
+		}
+		ctx.Infof("Nothing matched specified filter%v.", plural())
+	}
+
+	return nil
+}
+
+// formatStatus gets the status of the model, retrying on failure, and formats
+// it for the selected output.
+func (c *statusCommand) formatStatus(ctx *cmd.Context, showIntegrations, showStorage bool) (*params.FullStatus, formattedStatus, error) {
 	// Always attempt to get the status at least once, and retry if it fails.
 	status, err := c.getStatus(showStorage)
 	if err != nil && !modelcmd.IsModelMigratedError(err) {
@@ -309,21 +457,21 @@ func (c *statusCommand) runStatus(ctx *cmd.Context) error {
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
-			return errors.Trace(err)
+			return nil, formattedStatus{}, errors.Trace(err)
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
+		return nil, formattedStatus{}, errors.Errorf("unable to obtain the current status")
 	}
 
 	controllerName, err := c.ControllerName()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, formattedStatus{}, errors.Trace(err)
 	}
 	activeBranch, err := c.ActiveBranch()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, formattedStatus{}, errors.Trace(err)
 	}
 
 	formatterParams := NewStatusFormatterParams{
@@ -338,7 +486,7 @@ func (c *statusCommand) runStatus(ctx *cmd.Context) error {
 		// TODO: move this into StatusFormatter
 		storageInfo, err := storage.CombinedStorageFromParams(status.Storage, status.Filesystems, status.Volumes)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, formattedStatus{}, errors.Trace(err)
 		}
 		formatterParams.Storage = storageInfo
 		if storageInfo == nil || storageInfo.Empty() {
@@ -351,36 +499,9 @@ func (c *statusCommand) runStatus(ctx *cmd.Context) error {
 
 	formatted, err := NewStatusFormatter(formatterParams).Format()
 	if err != nil {
-		return errors.Trace(err)
-	}
-
-	if err = c.out.Write(ctx, formatted); err != nil {
-		return err
+		return nil, formattedStatus{}, errors.Trace(err)
 	}
-
-	if !status.IsEmpty() {
-		return nil
-	}
-	if len(c.patterns) == 0 {
-		modelName, err := c.ModelIdentifier()
-		if err != nil {
-			return err
-		}
-		// A change was made in cmd/v3.0.2 output.go that broke the consistency in output for the
-		// default formatter by removing the newline delimiter. Hence we prefix '\n' in the text below.
-		// https://github.com/juju/cmd/commit/be22fa661a798055c801f1511aee226db249ef95
-		ctx.Infof("\nModel %q is empty.", modelName)
-	} else {
-		plural := func() string {
-			if len(c.patterns) == 1 {
-				return ""
-			}
-			return "s"
-		}
-		ctx.Infof("Nothing matched specified filter%v.", plural())
-	}
-
-	return nil
+	return status, formatted, nil
 }
 
 // statusCommandForViddy returns the full juju command including all args
@@ -414,6 +535,12 @@ func (c *statusCommand) statusCommandForViddy(args []string) []string {
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	defer c.close()
 
+	if c.compare != "" {
+		return c.runCompare(ctx)
+	}
+	if c.watch != 0 && c.diff {
+		return c.runDiffWatch(ctx)
+	}
 	if c.watch != 0 {
 		jujuStatusArgs := c.statusCommandForViddy(os.Args)
 
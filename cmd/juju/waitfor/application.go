@@ -123,7 +123,9 @@ func (c *applicationCommand) Run(ctx *cmd.Context) (err error) {
 	scopedContext := MakeScopeContext()
 
 	defer func() {
-		if err != nil || !c.summary || c.appInfo == nil {
+		c.reportSummary(ctx, c.name, c.query, err)
+
+		if err != nil || c.formatted() || !c.summary || c.appInfo == nil {
 			return
 		}
 
@@ -148,7 +150,7 @@ func (c *applicationCommand) Run(ctx *cmd.Context) (err error) {
 			c.primeCache()
 		}
 	})
-	err = strategy.Run(ctx, c.name, c.query, c.waitFor(c.query, scopedContext, ctx), emptyNotify)
+	err = strategy.Run(ctx, c.name, c.query, c.reportProgress(ctx, c.waitFor(c.query, scopedContext, ctx)), emptyNotify)
 	return errors.Trace(err)
 }
 
@@ -160,7 +162,7 @@ func (c *applicationCommand) primeCache() {
 func (c *applicationCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
 	run := func(q query.Query) (bool, error) {
 		scope := MakeApplicationScope(ctx, c.appInfo, c.units, c.machines)
-		if done, err := runQuery(input, q, scope); err != nil {
+		if done, err := c.runQuery(input, q, scope); err != nil {
 			return false, errors.Trace(err)
 		} else if done {
 			return true, nil
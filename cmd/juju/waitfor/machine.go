@@ -111,7 +111,9 @@ func (c *machineCommand) Run(ctx *cmd.Context) (err error) {
 	scopedContext := MakeScopeContext()
 
 	defer func() {
-		if err != nil || !c.summary || c.machineInfo == nil {
+		c.reportSummary(ctx, c.id, c.query, err)
+
+		if err != nil || c.formatted() || !c.summary || c.machineInfo == nil {
 			return
 		}
 
@@ -130,14 +132,14 @@ func (c *machineCommand) Run(ctx *cmd.Context) (err error) {
 		ClientFn: c.newWatchAllAPIFunc,
 		Timeout:  c.timeout,
 	}
-	err = strategy.Run(ctx, c.id, c.query, c.waitFor(c.query, scopedContext, ctx), emptyNotify)
+	err = strategy.Run(ctx, c.id, c.query, c.reportProgress(ctx, c.waitFor(c.query, scopedContext, ctx)), emptyNotify)
 	return errors.Trace(err)
 }
 
 func (c *machineCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
 	run := func(q query.Query) (bool, error) {
 		scope := MakeMachineScope(ctx, c.machineInfo)
-		if done, err := runQuery(input, q, scope); err != nil {
+		if done, err := c.runQuery(input, q, scope); err != nil {
 			return false, errors.Trace(err)
 		} else if done {
 			return true, nil
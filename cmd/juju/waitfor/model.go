@@ -141,7 +141,9 @@ func (c *modelCommand) Run(ctx *cmd.Context) (err error) {
 	scopedContext := MakeScopeContext()
 
 	defer func() {
-		if err != nil || c.model == nil || !c.summary {
+		c.reportSummary(ctx, c.name, c.query, err)
+
+		if err != nil || c.formatted() || c.model == nil || !c.summary {
 			return
 		}
 
@@ -166,7 +168,7 @@ func (c *modelCommand) Run(ctx *cmd.Context) (err error) {
 			c.primeCache()
 		}
 	})
-	err = strategy.Run(ctx, c.name, c.query, c.waitFor(c.query, scopedContext, ctx), func(err error, attempt int) {
+	err = strategy.Run(ctx, c.name, c.query, c.reportProgress(ctx, c.waitFor(c.query, scopedContext, ctx)), func(err error, attempt int) {
 		if errors.Is(err, errors.NotFound) {
 			ctx.Infof("model %q not found, waiting...", c.name)
 		}
@@ -210,7 +212,7 @@ func (c *modelCommand) primeCache() {
 func (c *modelCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
 	run := func(q query.Query) (bool, error) {
 		scope := MakeModelScope(ctx, c.model, c.applications, c.units, c.machines)
-		if done, err := runQuery(input, q, scope); err != nil {
+		if done, err := c.runQuery(input, q, scope); err != nil {
 			return false, errors.Trace(err)
 		} else if done {
 			return true, nil
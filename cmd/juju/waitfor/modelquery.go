@@ -0,0 +1,402 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/yaml.v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/waitfor/api"
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+func newModelQueryCommand() cmd.Command {
+	cmd := &modelQueryCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelAllWatchShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const modelQueryCommandDoc = `
+The wait-for model-query command waits for a goal state spanning any number
+of entities within the current model, using a single stream of delta changes.
+The goal state can be defined programmatically using the query DSL (domain
+specific language). The default query waits for every unit in the model to be
+active and idle.
+
+The model-query scope exposes the model properties along with the following
+collections:
+
+    applications, machines, units, relations, offers,
+    remote-applications and storage
+
+Each collection can be reduced to a single value with the all, any and count
+functions, which take the collection and a lambda expression:
+
+    all(units, unit => unit.workload-status=="active")
+    any(machines, machine => machine.status=="error")
+    count(units, unit => unit.application=="postgresql")
+
+all returns true if the lambda is true for every entity in the collection,
+including when the collection is empty. any returns true if the lambda is true
+for at least one entity. count returns the number of entities for which the
+lambda is true.
+
+The wait-for command is an optimized alternative to the status command for
+determining programmatically if a goal state has been reached. The wait-for
+command streams delta changes from the underlying database, unlike the status
+command which performs a full query of the database.
+`
+
+const modelQueryCommandExamples = `
+Waits for all the units of postgresql and wordpress to be active and idle, and
+for no machine to be in error.
+
+    juju wait-for model-query --query='all(units, unit => (unit.application!="postgresql" && unit.application!="wordpress") || (unit.workload-status=="active" && unit.agent-status=="idle")) && any(machines, machine => machine.status=="error") == false'
+
+Waits for at least 3 units of postgresql to be active.
+
+    juju wait-for model-query --query='count(units, unit => unit.application=="postgresql" && unit.workload-status=="active") >= 3'
+
+Waits for every relation in the model to be joined.
+
+    juju wait-for model-query -m prod --query='len(relations) > 0 && all(relations, rel => rel.status=="joined")'
+`
+
+// modelQueryCommand defines a command for waiting for a goal state across
+// all the entities of a model.
+type modelQueryCommand struct {
+	waitForCommandBase
+
+	query   string
+	timeout time.Duration
+	summary bool
+
+	model              *params.ModelUpdate
+	applications       map[string]*params.ApplicationInfo
+	machines           map[string]*params.MachineInfo
+	units              map[string]*params.UnitInfo
+	relations          map[string]*params.RelationInfo
+	offers             map[string]*params.ApplicationOfferInfo
+	remoteApplications map[string]*params.RemoteApplicationUpdate
+	storage            map[string]*params.StorageInstanceInfo
+}
+
+// Info implements Command.Info.
+func (c *modelQueryCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "model-query",
+		Purpose:  "Wait for the entities of a model to reach a specified state.",
+		Doc:      modelQueryCommandDoc,
+		Examples: modelQueryCommandExamples,
+		SeeAlso: []string{
+			"wait-for model",
+			"wait-for application",
+			"wait-for unit",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *modelQueryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `all(units, unit => unit.workload-status=="active" && unit.agent-status=="idle")`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the model query on exit")
+}
+
+// Init implements Command.Init.
+func (c *modelQueryCommand) Init(args []string) (err error) {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *modelQueryCommand) Run(ctx *cmd.Context) (err error) {
+	name, err := c.ModelIdentifier()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		c.reportSummary(ctx, name, c.query, err)
+
+		if err != nil || c.formatted() || c.model == nil || !c.summary {
+			return
+		}
+
+		switch c.model.Life {
+		case life.Dead:
+			ctx.Infof("model %q has been removed", name)
+		case life.Dying:
+			ctx.Infof("model %q is being removed", name)
+		default:
+			ctx.Infof("model %q reached the goal state", name)
+			outputModelQuerySummary(ctx.Stdout, scopedContext, c.scope(scopedContext))
+		}
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	strategy.Subscribe(func(event EventType) {
+		switch event {
+		case WatchAllStarted:
+			c.primeCache()
+		}
+	})
+	err = strategy.Run(ctx, name, c.query, c.reportProgress(ctx, c.waitFor(c.query, scopedContext, ctx)), emptyNotify)
+	return errors.Trace(err)
+}
+
+func (c *modelQueryCommand) primeCache() {
+	c.applications = make(map[string]*params.ApplicationInfo)
+	c.machines = make(map[string]*params.MachineInfo)
+	c.units = make(map[string]*params.UnitInfo)
+	c.relations = make(map[string]*params.RelationInfo)
+	c.offers = make(map[string]*params.ApplicationOfferInfo)
+	c.remoteApplications = make(map[string]*params.RemoteApplicationUpdate)
+	c.storage = make(map[string]*params.StorageInstanceInfo)
+}
+
+func (c *modelQueryCommand) scope(ctx ScopeContext) ModelQueryScope {
+	return ModelQueryScope{
+		ModelScope:             MakeModelScope(ctx, c.model, c.applications, c.units, c.machines),
+		RelationInfos:          c.relations,
+		OfferInfos:             c.offers,
+		RemoteApplicationInfos: c.remoteApplications,
+		StorageInfos:           c.storage,
+	}
+}
+
+func (c *modelQueryCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
+	run := func(q query.Query) (bool, error) {
+		if done, err := c.runQuery(input, q, c.scope(ctx)); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+		return c.model.Life == life.Dead, nil
+	}
+	return func(name string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Verbosef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.ModelUpdate:
+				// The model watcher only ever streams deltas for the
+				// model it is watching.
+				if delta.Removed {
+					return false, errors.Errorf("model %v removed", name)
+				}
+				c.model = entityInfo
+
+			case *params.ApplicationInfo:
+				if delta.Removed {
+					delete(c.applications, entityInfo.Name)
+					break
+				}
+				c.applications[entityInfo.Name] = entityInfo
+
+			case *params.MachineInfo:
+				if delta.Removed {
+					delete(c.machines, entityInfo.Id)
+					break
+				}
+				c.machines[entityInfo.Id] = entityInfo
+
+			case *params.UnitInfo:
+				if delta.Removed {
+					delete(c.units, entityInfo.Name)
+					break
+				}
+				c.units[entityInfo.Name] = entityInfo
+
+			case *params.RelationInfo:
+				if delta.Removed {
+					delete(c.relations, entityInfo.Key)
+					break
+				}
+				c.relations[entityInfo.Key] = entityInfo
+
+			case *params.ApplicationOfferInfo:
+				if delta.Removed {
+					delete(c.offers, entityInfo.OfferName)
+					break
+				}
+				c.offers[entityInfo.OfferName] = entityInfo
+
+			case *params.RemoteApplicationUpdate:
+				if delta.Removed {
+					delete(c.remoteApplications, entityInfo.Name)
+					break
+				}
+				c.remoteApplications[entityInfo.Name] = entityInfo
+
+			case *params.StorageInstanceInfo:
+				if delta.Removed {
+					delete(c.storage, entityInfo.Id)
+					break
+				}
+				c.storage[entityInfo.Id] = entityInfo
+			}
+		}
+
+		if c.model == nil {
+			logger.Infof("model %q not found, waiting...", name)
+			return false, nil
+		}
+
+		if done, err := run(q); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+
+		logger.Infof("model %q with %d applications, %d units and %d machines, waiting...",
+			name, len(c.applications), len(c.units), len(c.machines))
+		return false, nil
+	}
+}
+
+// ModelQueryScope allows the query to introspect every entity within a
+// model.
+type ModelQueryScope struct {
+	ModelScope
+	RelationInfos          map[string]*params.RelationInfo
+	OfferInfos             map[string]*params.ApplicationOfferInfo
+	RemoteApplicationInfos map[string]*params.RemoteApplicationUpdate
+	StorageInfos           map[string]*params.StorageInstanceInfo
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m ModelQueryScope) GetIdents() []string {
+	idents := set.NewStrings(m.ModelScope.GetIdents()...)
+	return set.NewStrings("offers", "relations", "remote-applications", "storage").Union(idents).SortedValues()
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m ModelQueryScope) GetIdentValue(name string) (query.Box, error) {
+	switch name {
+	case "relations":
+		scopes := make(map[string]query.Scope)
+		for k, relation := range m.RelationInfos {
+			scopes[k] = MakeRelationScope(m.ctx.Child(name, k), relation)
+		}
+		return NewScopedBox(scopes), nil
+	case "offers":
+		scopes := make(map[string]query.Scope)
+		for k, offer := range m.OfferInfos {
+			scopes[k] = MakeOfferScope(m.ctx.Child(name, k), offer)
+		}
+		return NewScopedBox(scopes), nil
+	case "remote-applications":
+		scopes := make(map[string]query.Scope)
+		for k, remoteApp := range m.RemoteApplicationInfos {
+			scopes[k] = MakeRemoteApplicationScope(m.ctx.Child(name, k), remoteApp)
+		}
+		return NewScopedBox(scopes), nil
+	case "storage":
+		scopes := make(map[string]query.Scope)
+		for k, storage := range m.StorageInfos {
+			scopes[k] = MakeStorageScope(m.ctx.Child(name, k), storage)
+		}
+		return NewScopedBox(scopes), nil
+	}
+	return m.ModelScope.GetIdentValue(name)
+}
+
+func outputModelQuerySummary(writer io.Writer, scopedContext ScopeContext, scope ModelQueryScope) {
+	result := make(map[string]map[string]map[string]any)
+
+	properties := make(map[string]any)
+	for _, ident := range scopedContext.RecordedIdents() {
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		properties[ident] = box.Value()
+	}
+
+	for entity, scopes := range scopedContext.children {
+		for name, sctx := range scopes {
+			var entityScope query.Scope
+			switch entity {
+			case "applications":
+				if appInfo, ok := scope.ApplicationInfos[name]; ok {
+					entityScope = MakeApplicationScope(sctx, appInfo, scope.UnitInfos, scope.MachineInfos)
+				}
+			case "machines":
+				if machineInfo, ok := scope.MachineInfos[name]; ok {
+					entityScope = MakeMachineScope(sctx, machineInfo)
+				}
+			case "units":
+				if unitInfo, ok := scope.UnitInfos[name]; ok {
+					entityScope = MakeUnitScope(sctx, unitInfo, scope.MachineInfos)
+				}
+			case "relations":
+				if relationInfo, ok := scope.RelationInfos[name]; ok {
+					entityScope = MakeRelationScope(sctx, relationInfo)
+				}
+			case "offers":
+				if offerInfo, ok := scope.OfferInfos[name]; ok {
+					entityScope = MakeOfferScope(sctx, offerInfo)
+				}
+			case "remote-applications":
+				if remoteAppInfo, ok := scope.RemoteApplicationInfos[name]; ok {
+					entityScope = MakeRemoteApplicationScope(sctx, remoteAppInfo)
+				}
+			case "storage":
+				if storageInfo, ok := scope.StorageInfos[name]; ok {
+					entityScope = MakeStorageScope(sctx, storageInfo)
+				}
+			}
+			if entityScope == nil {
+				continue
+			}
+
+			values := make(map[string]any)
+			for _, ident := range sctx.RecordedIdents() {
+				box, err := entityScope.GetIdentValue(ident)
+				if err != nil {
+					continue
+				}
+				values[ident] = box.Value()
+			}
+			if len(values) == 0 {
+				continue
+			}
+			if result[entity] == nil {
+				result[entity] = make(map[string]map[string]any)
+			}
+			result[entity][name] = values
+		}
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(struct {
+		Properties map[string]any                       `yaml:"properties"`
+		Entities   map[string]map[string]map[string]any `yaml:",inline"`
+	}{
+		Properties: properties,
+		Entities:   result,
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+)
+
+type modelQueryScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&modelQueryScopeSuite{})
+
+func (s *modelQueryScopeSuite) makeScope() ModelQueryScope {
+	units := map[string]*params.UnitInfo{
+		"postgresql/0": {
+			Name:           "postgresql/0",
+			Application:    "postgresql",
+			WorkloadStatus: params.StatusInfo{Current: status.Active},
+			AgentStatus:    params.StatusInfo{Current: status.Idle},
+		},
+		"postgresql/1": {
+			Name:           "postgresql/1",
+			Application:    "postgresql",
+			WorkloadStatus: params.StatusInfo{Current: status.Waiting},
+			AgentStatus:    params.StatusInfo{Current: status.Executing},
+		},
+		"wordpress/0": {
+			Name:           "wordpress/0",
+			Application:    "wordpress",
+			WorkloadStatus: params.StatusInfo{Current: status.Active},
+			AgentStatus:    params.StatusInfo{Current: status.Idle},
+		},
+	}
+	machines := map[string]*params.MachineInfo{
+		"0": {Id: "0", AgentStatus: params.StatusInfo{Current: status.Started}},
+		"1": {Id: "1", AgentStatus: params.StatusInfo{Current: status.Error}},
+	}
+	return ModelQueryScope{
+		ModelScope: MakeModelScope(MakeScopeContext(), &params.ModelUpdate{
+			Name: "default",
+			Life: life.Alive,
+		}, map[string]*params.ApplicationInfo{}, units, machines),
+		RelationInfos: map[string]*params.RelationInfo{
+			"wordpress:db postgresql:db": {
+				Key:    "wordpress:db postgresql:db",
+				Status: params.StatusInfo{Current: status.Joined},
+			},
+		},
+		StorageInfos: map[string]*params.StorageInstanceInfo{},
+	}
+}
+
+func (s *modelQueryScopeSuite) TestQueries(c *gc.C) {
+	tests := []struct {
+		Query    string
+		Expected bool
+	}{{
+		Query:    `name=="default"`,
+		Expected: true,
+	}, {
+		Query:    `all(units, unit => unit.workload-status=="active")`,
+		Expected: false,
+	}, {
+		Query:    `all(units, unit => unit.application!="postgresql" || unit.workload-status=="active")`,
+		Expected: false,
+	}, {
+		Query:    `all(units, unit => unit.application!="wordpress" || unit.workload-status=="active")`,
+		Expected: true,
+	}, {
+		Query:    `all(storage, s => s.attachment-count > 0)`,
+		Expected: true,
+	}, {
+		Query:    `any(machines, machine => machine.status=="error")`,
+		Expected: true,
+	}, {
+		Query:    `any(machines, machine => machine.status=="error") == false`,
+		Expected: false,
+	}, {
+		Query:    `any(storage, s => s.attachment-count > 0)`,
+		Expected: false,
+	}, {
+		Query:    `count(units, unit => unit.application=="postgresql") == 2`,
+		Expected: true,
+	}, {
+		Query:    `count(units, unit => unit.agent-status=="idle") >= 3`,
+		Expected: false,
+	}, {
+		Query:    `all(relations, rel => rel.status=="joined")`,
+		Expected: true,
+	}}
+	for i, test := range tests {
+		c.Logf("%d: %s", i, test.Query)
+		q, err := query.Parse(test.Query)
+		c.Assert(err, jc.ErrorIsNil)
+		result, err := q.BuiltinsRun(s.makeScope())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, test.Expected)
+	}
+}
+
+func (s *modelQueryScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := s.makeScope()
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `.*"bad" on ModelInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *modelQueryScopeSuite) TestInit(c *gc.C) {
+	cmd := &modelQueryCommand{}
+	c.Assert(cmd.Init(nil), jc.ErrorIsNil)
+	c.Assert(cmd.Init([]string{"foo"}), gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
@@ -0,0 +1,240 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+	"gopkg.in/yaml.v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/waitfor/api"
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/rpc/params"
+)
+
+func newOfferCommand() cmd.Command {
+	cmd := &offerCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelAllWatchShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const offerCommandDoc = `
+The wait-for offer command waits for an application offer in the current
+model to reach a goal state. The goal state can be defined programmatically
+using the query DSL (domain specific language). The default query for an offer
+just waits for the offer to be created.
+
+Offers are only visible to model administrators, so the command must be run
+by a user with admin access to the offering model.
+
+The wait-for command is an optimized alternative to the status command for
+determining programmatically if a goal state has been reached. The wait-for
+command streams delta changes from the underlying database, unlike the status
+command which performs a full query of the database.
+`
+
+const offerCommandExamples = `
+Waits for the mysql offer to be created.
+
+    juju wait-for offer mysql
+
+Waits for the mysql offer to have at least 2 active connections.
+
+    juju wait-for offer mysql --query='active-connected-count >= 2'
+`
+
+// offerCommand defines a command for waiting for application offers.
+type offerCommand struct {
+	waitForCommandBase
+
+	name    string
+	query   string
+	timeout time.Duration
+	summary bool
+
+	offerInfo *params.ApplicationOfferInfo
+}
+
+// Info implements Command.Info.
+func (c *offerCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "offer",
+		Args:     "[<name>]",
+		Purpose:  "Wait for an application offer to reach a specified state.",
+		Doc:      offerCommandDoc,
+		Examples: offerCommandExamples,
+		SeeAlso: []string{
+			"wait-for application",
+			"wait-for relation",
+			"wait-for remote-application",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `name!=""`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the offer query on exit")
+}
+
+// Init implements Command.Init.
+func (c *offerCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("offer name must be supplied when waiting for an offer")
+	}
+	if len(args) != 1 {
+		return errors.New("only one offer name can be supplied as an argument to this command")
+	}
+	if ok := names.IsValidApplication(args[0]); !ok {
+		return errors.Errorf("%q is not valid offer name", args[0])
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *offerCommand) Run(ctx *cmd.Context) (err error) {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		c.reportSummary(ctx, c.name, c.query, err)
+
+		if err != nil || c.formatted() || !c.summary || c.offerInfo == nil {
+			return
+		}
+
+		ctx.Infof("offer %q is available", c.name)
+		outputOfferSummary(ctx.Stdout, scopedContext, c.offerInfo)
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	err = strategy.Run(ctx, c.name, c.query, c.reportProgress(ctx, c.waitFor(c.query, scopedContext, ctx)), emptyNotify)
+	return errors.Trace(err)
+}
+
+func (c *offerCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
+	run := func(q query.Query) (bool, error) {
+		scope := MakeOfferScope(ctx, c.offerInfo)
+		return c.runQuery(input, q, scope)
+	}
+	return func(name string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Verbosef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.ApplicationOfferInfo:
+				if entityInfo.OfferName != name {
+					break
+				}
+
+				if delta.Removed {
+					return false, errors.Errorf("offer %v removed", name)
+				}
+
+				c.offerInfo = entityInfo
+			}
+		}
+
+		if c.offerInfo != nil {
+			if found, err := run(q); err != nil {
+				return false, errors.Trace(err)
+			} else if found {
+				return true, nil
+			}
+		} else {
+			logger.Infof("offer %q not found, waiting...", name)
+			return false, nil
+		}
+
+		logger.Infof("offer %q found with %d active connections, waiting...", name, c.offerInfo.ActiveConnectedCount)
+		return false, nil
+	}
+}
+
+// OfferScope allows the query to introspect an application offer entity.
+type OfferScope struct {
+	ctx       ScopeContext
+	OfferInfo *params.ApplicationOfferInfo
+}
+
+// MakeOfferScope creates an OfferScope from an ApplicationOfferInfo.
+func MakeOfferScope(ctx ScopeContext, info *params.ApplicationOfferInfo) OfferScope {
+	return OfferScope{
+		ctx:       ctx,
+		OfferInfo: info,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m OfferScope) GetIdents() []string {
+	return []string{
+		"active-connected-count",
+		"application",
+		"charm",
+		"name",
+		"total-connected-count",
+		"uuid",
+	}
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m OfferScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "name":
+		return query.NewString(m.OfferInfo.OfferName), nil
+	case "uuid":
+		return query.NewString(m.OfferInfo.OfferUUID), nil
+	case "application":
+		return query.NewString(m.OfferInfo.ApplicationName), nil
+	case "charm":
+		return query.NewString(m.OfferInfo.CharmName), nil
+	case "total-connected-count":
+		return query.NewInteger(int64(m.OfferInfo.TotalConnectedCount)), nil
+	case "active-connected-count":
+		return query.NewInteger(int64(m.OfferInfo.ActiveConnectedCount)), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on ApplicationOfferInfo", name)
+}
+
+func outputOfferSummary(writer io.Writer, scopedContext ScopeContext, offerInfo *params.ApplicationOfferInfo) {
+	result := struct {
+		Elements map[string]interface{} `yaml:"properties"`
+	}{
+		Elements: make(map[string]interface{}),
+	}
+
+	idents := scopedContext.RecordedIdents()
+	for _, ident := range idents {
+		scope := MakeOfferScope(scopedContext, offerInfo)
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Elements[ident] = box.Value()
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/rpc/params"
+)
+
+type offerScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&offerScopeSuite{})
+
+func (s *offerScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field     string
+		OfferInfo *params.ApplicationOfferInfo
+		Expected  query.Box
+	}{{
+		Field:     "name",
+		OfferInfo: &params.ApplicationOfferInfo{OfferName: "mysql"},
+		Expected:  query.NewString("mysql"),
+	}, {
+		Field:     "application",
+		OfferInfo: &params.ApplicationOfferInfo{ApplicationName: "mysql-k8s"},
+		Expected:  query.NewString("mysql-k8s"),
+	}, {
+		Field:     "charm",
+		OfferInfo: &params.ApplicationOfferInfo{CharmName: "mysql"},
+		Expected:  query.NewString("mysql"),
+	}, {
+		Field:     "total-connected-count",
+		OfferInfo: &params.ApplicationOfferInfo{TotalConnectedCount: 3},
+		Expected:  query.NewInteger(3),
+	}, {
+		Field:     "active-connected-count",
+		OfferInfo: &params.ApplicationOfferInfo{ActiveConnectedCount: 2},
+		Expected:  query.NewInteger(2),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := OfferScope{
+			ctx:       MakeScopeContext(),
+			OfferInfo: test.OfferInfo,
+		}
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *offerScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := OfferScope{
+		ctx:       MakeScopeContext(),
+		OfferInfo: &params.ApplicationOfferInfo{},
+	}
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `.*"bad" on ApplicationOfferInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *offerScopeSuite) TestDefaultQuery(c *gc.C) {
+	q, err := query.Parse(`name!=""`)
+	c.Assert(err, jc.ErrorIsNil)
+	scope := MakeOfferScope(MakeScopeContext(), &params.ApplicationOfferInfo{OfferName: "mysql"})
+	result, err := q.BuiltinsRun(scope)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.IsTrue)
+}
@@ -61,16 +61,78 @@ func (q Query) Run(fnScope FuncScope, scope Scope) (bool, error) {
 		return false, errors.Trace(err)
 	}
 
-	// Attempt to workout if the result of the query is a boolean. This is a bit
-	// harder in go as we might have a lot of types that could be returned.
+	return truthy(res), nil
+}
+
+// Evaluation holds the result of evaluating a single term of a query.
+type Evaluation struct {
+	// Expression is the string representation of the evaluated term.
+	Expression string
+	// Result is the boolean result of the term.
+	Result bool
+}
+
+// BuiltinsExplain runs the query with a set of builtin functions, in the
+// same way as BuiltinsRun. Additionally every operand of the logical
+// operators (&& and ||) of the query is evaluated independently, so that
+// the caller can explain why a query did or did not match. Operands that
+// fail to evaluate on their own are omitted from the evaluations.
+func (q Query) BuiltinsExplain(scope Scope) (bool, []Evaluation, error) {
+	fnScope := NewGlobalFuncScope(scope)
+	result, err := q.Run(fnScope, scope)
+	if err != nil {
+		return false, nil, errors.Trace(err)
+	}
+
+	var evaluations []Evaluation
+	for _, term := range logicalTerms(q.ast) {
+		res, err := q.run(term, fnScope, scope)
+		if err != nil {
+			continue
+		}
+		evaluations = append(evaluations, Evaluation{
+			Expression: term.String(),
+			Result:     truthy(res),
+		})
+	}
+	return result, evaluations, nil
+}
+
+// logicalTerms flattens the logical operators of an expression into the
+// list of their operands.
+func logicalTerms(e Expression) []Expression {
+	switch node := e.(type) {
+	case *QueryExpression:
+		var terms []Expression
+		for _, exp := range node.Expressions {
+			terms = append(terms, logicalTerms(exp)...)
+		}
+		return terms
+	case *ExpressionStatement:
+		return logicalTerms(node.Expression)
+	case *InfixExpression:
+		switch node.Token.Type {
+		case CONDAND, CONDOR:
+			return append(logicalTerms(node.Left), logicalTerms(node.Right)...)
+		}
+	case *Empty:
+		return nil
+	}
+	return []Expression{e}
+}
+
+// truthy attempts to workout if the result of the query is a boolean. This
+// is a bit harder in go as we might have a lot of types that could be
+// returned.
+func truthy(res any) bool {
 	if res == nil {
-		return false, nil
+		return false
 	}
 	if box, ok := res.(Box); ok {
-		return !box.IsZero(), nil
+		return !box.IsZero()
 	}
 	ref := reflect.ValueOf(res)
-	return !ref.IsZero(), nil
+	return !ref.IsZero()
 }
 
 func (q Query) run(e Expression, fnScope FuncScope, scope Scope) (any, error) {
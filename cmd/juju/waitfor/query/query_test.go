@@ -359,3 +359,25 @@ func (s *querySuite) TestRunInfixLogicalOR(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.DeepEquals, true)
 }
+
+func (s *querySuite) TestBuiltinsExplain(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	scope := NewMockScope(ctrl)
+	scope.EXPECT().GetIdentValue("life").Return(NewString("alive"), nil).AnyTimes()
+	scope.EXPECT().GetIdentValue("status").Return(NewString("blocked"), nil).AnyTimes()
+	scope.EXPECT().GetIdentValue("count").Return(NewInteger(2), nil).AnyTimes()
+
+	query, err := Parse(`life == "alive" && (status == "active" || count > 3)`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	done, evaluations, err := query.BuiltinsExplain(scope)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+	c.Assert(evaluations, jc.DeepEquals, []Evaluation{
+		{Expression: `(life == "alive")`, Result: true},
+		{Expression: `(status == "active")`, Result: false},
+		{Expression: `(count > 3)`, Result: false},
+	})
+}
@@ -47,41 +47,12 @@ func NewGlobalFuncScope(scope Scope) *GlobalFuncScope {
 				return v, nil
 			},
 			"forEach": func(values, expr any) (any, error) {
-				scopes, ok := values.(Box)
-				if !ok {
-					return nil, RuntimeErrorf("unexpected lambda values %T", values)
-				}
-				lambda, ok := expr.(*BoxLambda)
-				if !ok {
-					return nil, RuntimeErrorf("unexpected lambda %T", expr)
-				}
-
 				var (
-					err    error
 					called bool
 					result = true
 				)
-				ForEach(scopes, func(value any) bool {
+				err := evalLambda(scope, values, expr, func(lambdaResult bool) bool {
 					called = true
-
-					nestedScope, ok := value.(Scope)
-					if !ok {
-						err = RuntimeErrorf("unexpected scope type %T", value)
-						return false
-					}
-
-					namedScope := MakeNestedScope(scope)
-					namedScope.SetScope(lambda.ArgName(), nestedScope)
-
-					var results []Box
-					results, err = lambda.Call(namedScope)
-					if err != nil {
-						return false
-					}
-					var lambdaResult bool
-					for _, result := range results {
-						lambdaResult = !result.IsZero()
-					}
 					result = result && lambdaResult
 					return result
 				})
@@ -93,6 +64,41 @@ func NewGlobalFuncScope(scope Scope) *GlobalFuncScope {
 				}
 				return result, nil
 			},
+			"all": func(values, expr any) (bool, error) {
+				result := true
+				err := evalLambda(scope, values, expr, func(lambdaResult bool) bool {
+					result = lambdaResult
+					return result
+				})
+				if err != nil {
+					return false, errors.Trace(err)
+				}
+				return result, nil
+			},
+			"any": func(values, expr any) (bool, error) {
+				var result bool
+				err := evalLambda(scope, values, expr, func(lambdaResult bool) bool {
+					result = lambdaResult
+					return !result
+				})
+				if err != nil {
+					return false, errors.Trace(err)
+				}
+				return result, nil
+			},
+			"count": func(values, expr any) (int, error) {
+				var num int
+				err := evalLambda(scope, values, expr, func(lambdaResult bool) bool {
+					if lambdaResult {
+						num++
+					}
+					return true
+				})
+				if err != nil {
+					return -1, errors.Trace(err)
+				}
+				return num, nil
+			},
 			"startsWith": func(v, prefix any) (bool, error) {
 				if _, ok := prefix.(string); !ok {
 					return false, RuntimeErrorf("requires string to be passed to startsWith, got %T", prefix)
@@ -132,6 +138,43 @@ func NewGlobalFuncScope(scope Scope) *GlobalFuncScope {
 	}
 }
 
+// evalLambda calls the lambda expr for every scope in values, passing the
+// result of each call to fn. Iteration stops when fn returns false.
+func evalLambda(scope Scope, values, expr any, fn func(bool) bool) error {
+	scopes, ok := values.(Box)
+	if !ok {
+		return RuntimeErrorf("unexpected lambda values %T", values)
+	}
+	lambda, ok := expr.(*BoxLambda)
+	if !ok {
+		return RuntimeErrorf("unexpected lambda %T", expr)
+	}
+
+	var err error
+	ForEach(scopes, func(value any) bool {
+		nestedScope, ok := value.(Scope)
+		if !ok {
+			err = RuntimeErrorf("unexpected scope type %T", value)
+			return false
+		}
+
+		namedScope := MakeNestedScope(scope)
+		namedScope.SetScope(lambda.ArgName(), nestedScope)
+
+		var results []Box
+		results, err = lambda.Call(namedScope)
+		if err != nil {
+			return false
+		}
+		var lambdaResult bool
+		for _, result := range results {
+			lambdaResult = !result.IsZero()
+		}
+		return fn(lambdaResult)
+	})
+	return errors.Trace(err)
+}
+
 // Add a function to the global scope.
 func (s *GlobalFuncScope) Add(name string, fn any) {
 	s.funcs[name] = fn
@@ -0,0 +1,408 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+	"gopkg.in/yaml.v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/waitfor/api"
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+func newRelationCommand() cmd.Command {
+	cmd := &relationCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelAllWatchShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const relationCommandDoc = `
+The wait-for relation command waits for a relation (integration) to reach a
+goal state. The goal state can be defined programmatically using the query DSL
+(domain specific language). The default query for a relation just waits for
+the relation to be created and joined.
+
+The relation can be identified either by its id, or by one or two endpoints
+in the form <application>[:<endpoint>]. When endpoints are used, the first
+relation matching all the given endpoints is waited for.
+
+The wait-for command is an optimized alternative to the status command for
+determining programmatically if a goal state has been reached. The wait-for
+command streams delta changes from the underlying database, unlike the status
+command which performs a full query of the database.
+
+The relation query DSL can be used to programmatically define the goal state
+for the endpoints of the relation. This can be achieved by using lambda
+expressions to iterate over the endpoints. Multiple expressions can be
+combined to define a complex goal state.
+`
+
+const relationCommandExamples = `
+Waits for the relation between mysql and wordpress to be joined.
+
+    juju wait-for relation mysql wordpress
+
+Waits for the relation with id 3 to be joined.
+
+    juju wait-for relation 3 --query='life=="alive" && status=="joined"'
+
+Waits for the relation to have a provider endpoint on the db interface.
+
+    juju wait-for relation mysql:db wordpress --query='forEach(endpoints, ep => ep.interface=="mysql")'
+`
+
+// relationCommand defines a command for waiting for relations.
+type relationCommand struct {
+	waitForCommandBase
+
+	id        int
+	endpoints []relationEndpoint
+	query     string
+	timeout   time.Duration
+	summary   bool
+
+	relationInfo *params.RelationInfo
+}
+
+// relationEndpoint identifies an application and an optional endpoint name
+// that a relation must have to match.
+type relationEndpoint struct {
+	application string
+	name        string
+}
+
+func (e relationEndpoint) String() string {
+	if e.name == "" {
+		return e.application
+	}
+	return e.application + ":" + e.name
+}
+
+func (e relationEndpoint) matches(ep params.Endpoint) bool {
+	if ep.ApplicationName != e.application {
+		return false
+	}
+	return e.name == "" || ep.Relation.Name == e.name
+}
+
+// Info implements Command.Info.
+func (c *relationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "relation",
+		Args:     "<id>|<application>[:<endpoint>] [<application>[:<endpoint>]]",
+		Purpose:  "Wait for a relation to reach a specified state.",
+		Doc:      relationCommandDoc,
+		Examples: relationCommandExamples,
+		SeeAlso: []string{
+			"wait-for application",
+			"wait-for offer",
+			"wait-for remote-application",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *relationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `life=="alive" && status=="joined"`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the relation query on exit")
+}
+
+// Init implements Command.Init.
+func (c *relationCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("relation id or endpoints must be supplied when waiting for a relation")
+	}
+	if len(args) > 2 {
+		return errors.New("only a relation id or up to two endpoints can be supplied as arguments to this command")
+	}
+
+	c.id = -1
+	if len(args) == 1 {
+		if id, err := strconv.Atoi(args[0]); err == nil {
+			if id < 0 {
+				return errors.Errorf("%q is not a valid relation id", args[0])
+			}
+			c.id = id
+			return nil
+		}
+	}
+
+	for _, arg := range args {
+		application, name, _ := strings.Cut(arg, ":")
+		if !names.IsValidApplication(application) {
+			return errors.Errorf("%q is not a valid application name", application)
+		}
+		c.endpoints = append(c.endpoints, relationEndpoint{
+			application: application,
+			name:        name,
+		})
+	}
+	return nil
+}
+
+func (c *relationCommand) Run(ctx *cmd.Context) (err error) {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		c.reportSummary(ctx, c.description(), c.query, err)
+
+		if err != nil || c.formatted() || !c.summary || c.relationInfo == nil {
+			return
+		}
+
+		switch c.relationInfo.Life {
+		case life.Dead:
+			ctx.Infof("relation %q has been removed", c.relationInfo.Key)
+		case life.Dying:
+			ctx.Infof("relation %q is being removed", c.relationInfo.Key)
+		default:
+			ctx.Infof("relation %q is running", c.relationInfo.Key)
+			outputRelationSummary(ctx.Stdout, scopedContext, c.relationInfo)
+		}
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	err = strategy.Run(ctx, c.description(), c.query, c.reportProgress(ctx, c.waitFor(c.query, scopedContext, ctx)), emptyNotify)
+	return errors.Trace(err)
+}
+
+// description returns a human readable identifier of the relation being
+// waited for.
+func (c *relationCommand) description() string {
+	if c.id >= 0 {
+		return strconv.Itoa(c.id)
+	}
+	parts := make([]string, len(c.endpoints))
+	for i, ep := range c.endpoints {
+		parts[i] = ep.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// matches returns true if the relation info is the relation being
+// waited for.
+func (c *relationCommand) matches(info *params.RelationInfo) bool {
+	if c.id >= 0 {
+		return info.Id == c.id
+	}
+	for _, want := range c.endpoints {
+		var found bool
+		for _, ep := range info.Endpoints {
+			if want.matches(ep) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *relationCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
+	run := func(q query.Query) (bool, error) {
+		scope := MakeRelationScope(ctx, c.relationInfo)
+		if done, err := c.runQuery(input, q, scope); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+		return c.relationInfo.Life == life.Dead, nil
+	}
+	return func(name string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Verbosef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.RelationInfo:
+				// Once a relation has been matched, only follow that
+				// relation, so that a second relation matching the same
+				// endpoints doesn't cause the wait to flip between them.
+				if c.relationInfo != nil {
+					if entityInfo.Key != c.relationInfo.Key {
+						break
+					}
+				} else if !c.matches(entityInfo) {
+					break
+				}
+
+				if delta.Removed {
+					return false, errors.Errorf("relation %v removed", name)
+				}
+
+				c.relationInfo = entityInfo
+			}
+		}
+
+		if c.relationInfo != nil {
+			if found, err := run(q); err != nil {
+				return false, errors.Trace(err)
+			} else if found {
+				return true, nil
+			}
+		} else {
+			logger.Infof("relation %q not found, waiting...", name)
+			return false, nil
+		}
+
+		logger.Infof("relation %q found with %q, waiting...", name, c.relationInfo.Status.Current)
+		return false, nil
+	}
+}
+
+// RelationScope allows the query to introspect a relation entity.
+type RelationScope struct {
+	ctx          ScopeContext
+	RelationInfo *params.RelationInfo
+}
+
+// MakeRelationScope creates a RelationScope from a RelationInfo.
+func MakeRelationScope(ctx ScopeContext, info *params.RelationInfo) RelationScope {
+	return RelationScope{
+		ctx:          ctx,
+		RelationInfo: info,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m RelationScope) GetIdents() []string {
+	idents := set.NewStrings(getIdents(m.RelationInfo)...)
+	return set.NewStrings("status", "message", "endpoints").Union(idents).SortedValues()
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m RelationScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "id":
+		return query.NewInteger(int64(m.RelationInfo.Id)), nil
+	case "key":
+		return query.NewString(m.RelationInfo.Key), nil
+	case "life":
+		return query.NewString(string(m.RelationInfo.Life)), nil
+	case "status":
+		return query.NewString(string(m.RelationInfo.Status.Current)), nil
+	case "message":
+		return query.NewString(m.RelationInfo.Status.Message), nil
+	case "endpoints":
+		scopes := make(map[string]query.Scope)
+		for _, ep := range m.RelationInfo.Endpoints {
+			k := ep.ApplicationName + ":" + ep.Relation.Name
+			scopes[k] = MakeEndpointScope(m.ctx.Child(name, k), ep)
+		}
+		return NewScopedBox(scopes), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on RelationInfo", name)
+}
+
+// EndpointScope allows the query to introspect a relation endpoint.
+type EndpointScope struct {
+	ctx      ScopeContext
+	Endpoint params.Endpoint
+}
+
+// MakeEndpointScope creates an EndpointScope from an Endpoint.
+func MakeEndpointScope(ctx ScopeContext, ep params.Endpoint) EndpointScope {
+	return EndpointScope{
+		ctx:      ctx,
+		Endpoint: ep,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m EndpointScope) GetIdents() []string {
+	return []string{"application", "interface", "limit", "name", "optional", "role", "scope"}
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m EndpointScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "application":
+		return query.NewString(m.Endpoint.ApplicationName), nil
+	case "name":
+		return query.NewString(m.Endpoint.Relation.Name), nil
+	case "role":
+		return query.NewString(m.Endpoint.Relation.Role), nil
+	case "interface":
+		return query.NewString(m.Endpoint.Relation.Interface), nil
+	case "optional":
+		return query.NewBool(m.Endpoint.Relation.Optional), nil
+	case "limit":
+		return query.NewInteger(int64(m.Endpoint.Relation.Limit)), nil
+	case "scope":
+		return query.NewString(m.Endpoint.Relation.Scope), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on Endpoint", name)
+}
+
+func outputRelationSummary(writer io.Writer, scopedContext ScopeContext, relationInfo *params.RelationInfo) {
+	result := struct {
+		Properties map[string]any            `yaml:"properties"`
+		Endpoints  map[string]map[string]any `yaml:"endpoints,omitempty"`
+	}{
+		Properties: make(map[string]any),
+		Endpoints:  make(map[string]map[string]any),
+	}
+
+	scope := MakeRelationScope(scopedContext, relationInfo)
+	for _, ident := range scopedContext.RecordedIdents() {
+		if ident == "endpoints" {
+			continue
+		}
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Properties[ident] = box.Value()
+	}
+
+	for name, sctx := range scopedContext.children["endpoints"] {
+		for _, ep := range relationInfo.Endpoints {
+			if ep.ApplicationName+":"+ep.Relation.Name != name {
+				continue
+			}
+			epScope := MakeEndpointScope(sctx, ep)
+			result.Endpoints[name] = make(map[string]any)
+			for _, ident := range sctx.RecordedIdents() {
+				box, err := epScope.GetIdentValue(ident)
+				if err != nil {
+					continue
+				}
+				result.Endpoints[name][ident] = box.Value()
+			}
+		}
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+)
+
+type relationScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&relationScopeSuite{})
+
+func (s *relationScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field        string
+		RelationInfo *params.RelationInfo
+		Expected     query.Box
+	}{{
+		Field:        "id",
+		RelationInfo: &params.RelationInfo{Id: 3},
+		Expected:     query.NewInteger(3),
+	}, {
+		Field:        "key",
+		RelationInfo: &params.RelationInfo{Key: "wordpress:db mysql:server"},
+		Expected:     query.NewString("wordpress:db mysql:server"),
+	}, {
+		Field:        "life",
+		RelationInfo: &params.RelationInfo{Life: life.Alive},
+		Expected:     query.NewString("alive"),
+	}, {
+		Field: "status",
+		RelationInfo: &params.RelationInfo{Status: params.StatusInfo{
+			Current: status.Joined,
+		}},
+		Expected: query.NewString("joined"),
+	}, {
+		Field: "message",
+		RelationInfo: &params.RelationInfo{Status: params.StatusInfo{
+			Message: "broken",
+		}},
+		Expected: query.NewString("broken"),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := RelationScope{
+			ctx:          MakeScopeContext(),
+			RelationInfo: test.RelationInfo,
+		}
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *relationScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := RelationScope{
+		ctx:          MakeScopeContext(),
+		RelationInfo: &params.RelationInfo{},
+	}
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `.*"bad" on RelationInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *relationScopeSuite) TestEndpointGetIdentValue(c *gc.C) {
+	ep := params.Endpoint{
+		ApplicationName: "mysql",
+		Relation: params.CharmRelation{
+			Name:      "server",
+			Role:      "provider",
+			Interface: "mysql",
+			Scope:     "global",
+		},
+	}
+	tests := []struct {
+		Field    string
+		Expected query.Box
+	}{{
+		Field:    "application",
+		Expected: query.NewString("mysql"),
+	}, {
+		Field:    "name",
+		Expected: query.NewString("server"),
+	}, {
+		Field:    "role",
+		Expected: query.NewString("provider"),
+	}, {
+		Field:    "interface",
+		Expected: query.NewString("mysql"),
+	}, {
+		Field:    "scope",
+		Expected: query.NewString("global"),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := MakeEndpointScope(MakeScopeContext(), ep)
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *relationScopeSuite) TestInit(c *gc.C) {
+	cmd := &relationCommand{}
+	err := cmd.Init([]string{"3"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.id, gc.Equals, 3)
+
+	cmd = &relationCommand{}
+	err = cmd.Init([]string{"mysql:server", "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.id, gc.Equals, -1)
+	c.Assert(cmd.endpoints, jc.DeepEquals, []relationEndpoint{
+		{application: "mysql", name: "server"},
+		{application: "wordpress"},
+	})
+	c.Assert(cmd.matches(&params.RelationInfo{
+		Endpoints: []params.Endpoint{
+			{ApplicationName: "mysql", Relation: params.CharmRelation{Name: "server"}},
+			{ApplicationName: "wordpress", Relation: params.CharmRelation{Name: "db"}},
+		},
+	}), jc.IsTrue)
+	c.Assert(cmd.matches(&params.RelationInfo{
+		Endpoints: []params.Endpoint{
+			{ApplicationName: "mysql", Relation: params.CharmRelation{Name: "cluster"}},
+		},
+	}), jc.IsFalse)
+
+	cmd = &relationCommand{}
+	err = cmd.Init([]string{"a", "b", "c"})
+	c.Assert(err, gc.ErrorMatches, `only a relation id or up to two endpoints.*`)
+}
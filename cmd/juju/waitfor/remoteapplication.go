@@ -0,0 +1,245 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+	"gopkg.in/yaml.v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/waitfor/api"
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+func newRemoteApplicationCommand() cmd.Command {
+	cmd := &remoteApplicationCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelAllWatchShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const remoteApplicationCommandDoc = `
+The wait-for remote-application command waits for a remote application, the
+consuming side of a cross model relation, to reach a goal state. The goal
+state can be defined programmatically using the query DSL (domain specific
+language). The default query for a remote application just waits for the
+remote application to be created and active.
+
+The wait-for command is an optimized alternative to the status command for
+determining programmatically if a goal state has been reached. The wait-for
+command streams delta changes from the underlying database, unlike the status
+command which performs a full query of the database.
+`
+
+const remoteApplicationCommandExamples = `
+Waits for the consumed mysql offer to be active.
+
+    juju wait-for remote-application mysql
+
+Waits for the remote application to be consumed from a given offer.
+
+    juju wait-for remote-application mysql --query='offer-url=="admin/prod.mysql"'
+`
+
+// remoteApplicationCommand defines a command for waiting for remote
+// applications.
+type remoteApplicationCommand struct {
+	waitForCommandBase
+
+	name    string
+	query   string
+	timeout time.Duration
+	summary bool
+
+	remoteAppInfo *params.RemoteApplicationUpdate
+}
+
+// Info implements Command.Info.
+func (c *remoteApplicationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "remote-application",
+		Args:     "[<name>]",
+		Purpose:  "Wait for a remote application to reach a specified state.",
+		Doc:      remoteApplicationCommandDoc,
+		Examples: remoteApplicationCommandExamples,
+		SeeAlso: []string{
+			"wait-for application",
+			"wait-for offer",
+			"wait-for relation",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *remoteApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `life=="alive" && status=="active"`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the remote application query on exit")
+}
+
+// Init implements Command.Init.
+func (c *remoteApplicationCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("remote application name must be supplied when waiting for a remote application")
+	}
+	if len(args) != 1 {
+		return errors.New("only one remote application name can be supplied as an argument to this command")
+	}
+	if ok := names.IsValidApplication(args[0]); !ok {
+		return errors.Errorf("%q is not valid remote application name", args[0])
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *remoteApplicationCommand) Run(ctx *cmd.Context) (err error) {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		c.reportSummary(ctx, c.name, c.query, err)
+
+		if err != nil || c.formatted() || !c.summary || c.remoteAppInfo == nil {
+			return
+		}
+
+		switch c.remoteAppInfo.Life {
+		case life.Dead:
+			ctx.Infof("remote application %q has been removed", c.name)
+		case life.Dying:
+			ctx.Infof("remote application %q is being removed", c.name)
+		default:
+			ctx.Infof("remote application %q is running", c.name)
+			outputRemoteApplicationSummary(ctx.Stdout, scopedContext, c.remoteAppInfo)
+		}
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	err = strategy.Run(ctx, c.name, c.query, c.reportProgress(ctx, c.waitFor(c.query, scopedContext, ctx)), emptyNotify)
+	return errors.Trace(err)
+}
+
+func (c *remoteApplicationCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
+	run := func(q query.Query) (bool, error) {
+		scope := MakeRemoteApplicationScope(ctx, c.remoteAppInfo)
+		if done, err := c.runQuery(input, q, scope); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+		return c.remoteAppInfo.Life == life.Dead, nil
+	}
+	return func(name string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Verbosef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.RemoteApplicationUpdate:
+				if entityInfo.Name != name {
+					break
+				}
+
+				if delta.Removed {
+					return false, errors.Errorf("remote application %v removed", name)
+				}
+
+				c.remoteAppInfo = entityInfo
+			}
+		}
+
+		if c.remoteAppInfo != nil {
+			if found, err := run(q); err != nil {
+				return false, errors.Trace(err)
+			} else if found {
+				return true, nil
+			}
+		} else {
+			logger.Infof("remote application %q not found, waiting...", name)
+			return false, nil
+		}
+
+		logger.Infof("remote application %q found with %q, waiting...", name, c.remoteAppInfo.Status.Current)
+		return false, nil
+	}
+}
+
+// RemoteApplicationScope allows the query to introspect a remote application
+// entity.
+type RemoteApplicationScope struct {
+	ctx                   ScopeContext
+	RemoteApplicationInfo *params.RemoteApplicationUpdate
+}
+
+// MakeRemoteApplicationScope creates a RemoteApplicationScope from a
+// RemoteApplicationUpdate.
+func MakeRemoteApplicationScope(ctx ScopeContext, info *params.RemoteApplicationUpdate) RemoteApplicationScope {
+	return RemoteApplicationScope{
+		ctx:                   ctx,
+		RemoteApplicationInfo: info,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m RemoteApplicationScope) GetIdents() []string {
+	return []string{"life", "message", "name", "offer-url", "status"}
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m RemoteApplicationScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "name":
+		return query.NewString(m.RemoteApplicationInfo.Name), nil
+	case "offer-url":
+		return query.NewString(m.RemoteApplicationInfo.OfferURL), nil
+	case "life":
+		return query.NewString(string(m.RemoteApplicationInfo.Life)), nil
+	case "status":
+		return query.NewString(string(m.RemoteApplicationInfo.Status.Current)), nil
+	case "message":
+		return query.NewString(m.RemoteApplicationInfo.Status.Message), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on RemoteApplicationInfo", name)
+}
+
+func outputRemoteApplicationSummary(writer io.Writer, scopedContext ScopeContext, remoteAppInfo *params.RemoteApplicationUpdate) {
+	result := struct {
+		Elements map[string]interface{} `yaml:"properties"`
+	}{
+		Elements: make(map[string]interface{}),
+	}
+
+	idents := scopedContext.RecordedIdents()
+	for _, ident := range idents {
+		scope := MakeRemoteApplicationScope(scopedContext, remoteAppInfo)
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Elements[ident] = box.Value()
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+)
+
+type remoteApplicationScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&remoteApplicationScopeSuite{})
+
+func (s *remoteApplicationScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field                 string
+		RemoteApplicationInfo *params.RemoteApplicationUpdate
+		Expected              query.Box
+	}{{
+		Field:                 "name",
+		RemoteApplicationInfo: &params.RemoteApplicationUpdate{Name: "mysql"},
+		Expected:              query.NewString("mysql"),
+	}, {
+		Field:                 "offer-url",
+		RemoteApplicationInfo: &params.RemoteApplicationUpdate{OfferURL: "admin/prod.mysql"},
+		Expected:              query.NewString("admin/prod.mysql"),
+	}, {
+		Field:                 "life",
+		RemoteApplicationInfo: &params.RemoteApplicationUpdate{Life: life.Alive},
+		Expected:              query.NewString("alive"),
+	}, {
+		Field: "status",
+		RemoteApplicationInfo: &params.RemoteApplicationUpdate{Status: params.StatusInfo{
+			Current: status.Active,
+		}},
+		Expected: query.NewString("active"),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := RemoteApplicationScope{
+			ctx:                   MakeScopeContext(),
+			RemoteApplicationInfo: test.RemoteApplicationInfo,
+		}
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *remoteApplicationScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := RemoteApplicationScope{
+		ctx:                   MakeScopeContext(),
+		RemoteApplicationInfo: &params.RemoteApplicationUpdate{},
+	}
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `.*"bad" on RemoteApplicationInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
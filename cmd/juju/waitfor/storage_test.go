@@ -0,0 +1,76 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+type storageScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&storageScopeSuite{})
+
+func (s *storageScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field       string
+		StorageInfo *params.StorageInstanceInfo
+		Expected    query.Box
+	}{{
+		Field:       "id",
+		StorageInfo: &params.StorageInstanceInfo{Id: "data/0"},
+		Expected:    query.NewString("data/0"),
+	}, {
+		Field:       "kind",
+		StorageInfo: &params.StorageInstanceInfo{Kind: "filesystem"},
+		Expected:    query.NewString("filesystem"),
+	}, {
+		Field:       "life",
+		StorageInfo: &params.StorageInstanceInfo{Life: life.Alive},
+		Expected:    query.NewString("alive"),
+	}, {
+		Field:       "owner",
+		StorageInfo: &params.StorageInstanceInfo{Owner: "unit-mysql-0"},
+		Expected:    query.NewString("unit-mysql-0"),
+	}, {
+		Field:       "pool",
+		StorageInfo: &params.StorageInstanceInfo{Pool: "rootfs"},
+		Expected:    query.NewString("rootfs"),
+	}, {
+		Field:       "size",
+		StorageInfo: &params.StorageInstanceInfo{Size: 1024},
+		Expected:    query.NewInteger(1024),
+	}, {
+		Field:       "attachment-count",
+		StorageInfo: &params.StorageInstanceInfo{AttachmentCount: 1},
+		Expected:    query.NewInteger(1),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := StorageScope{
+			ctx:         MakeScopeContext(),
+			StorageInfo: test.StorageInfo,
+		}
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *storageScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := StorageScope{
+		ctx:         MakeScopeContext(),
+		StorageInfo: &params.StorageInstanceInfo{},
+	}
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `.*"bad" on StorageInstanceInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
@@ -212,9 +212,14 @@ func MakeScopeContext() ScopeContext {
 	return ScopeContext{
 		idents: set.NewStrings(),
 		children: map[string]map[string]ScopeContext{
-			"applications": make(map[string]ScopeContext),
-			"machines":     make(map[string]ScopeContext),
-			"units":        make(map[string]ScopeContext),
+			"applications":        make(map[string]ScopeContext),
+			"endpoints":           make(map[string]ScopeContext),
+			"machines":            make(map[string]ScopeContext),
+			"offers":              make(map[string]ScopeContext),
+			"relations":           make(map[string]ScopeContext),
+			"remote-applications": make(map[string]ScopeContext),
+			"storage":             make(map[string]ScopeContext),
+			"units":               make(map[string]ScopeContext),
 		},
 	}
 }
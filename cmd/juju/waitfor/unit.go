@@ -120,7 +120,9 @@ func (c *unitCommand) Run(ctx *cmd.Context) (err error) {
 	scopedContext := MakeScopeContext()
 
 	defer func() {
-		if err != nil || !c.summary || c.unitInfo == nil {
+		c.reportSummary(ctx, c.name, c.query, err)
+
+		if err != nil || c.formatted() || !c.summary || c.unitInfo == nil {
 			return
 		}
 
@@ -145,7 +147,7 @@ func (c *unitCommand) Run(ctx *cmd.Context) (err error) {
 			c.primeCache()
 		}
 	})
-	err = strategy.Run(ctx, c.name, c.query, c.waitFor(c.query, scopedContext, ctx), emptyNotify)
+	err = strategy.Run(ctx, c.name, c.query, c.reportProgress(ctx, c.waitFor(c.query, scopedContext, ctx)), emptyNotify)
 	return errors.Trace(err)
 }
 
@@ -156,7 +158,7 @@ func (c *unitCommand) primeCache() {
 func (c *unitCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
 	run := func(q query.Query) (bool, error) {
 		scope := MakeUnitScope(ctx, c.unitInfo, c.machines)
-		if done, err := runQuery(input, q, scope); err != nil {
+		if done, err := c.runQuery(input, q, scope); err != nil {
 			return false, errors.Trace(err)
 		} else if done {
 			return true, nil
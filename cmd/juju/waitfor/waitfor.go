@@ -14,9 +14,10 @@ type Logger interface {
 }
 
 var waitForDoc = `
-The wait-for set of commands (model, application, machine and unit) defines 
-a way to wait for a goal state to be reached. The goal state can be defined
-programmatically using the query DSL (domain specific language).
+The wait-for set of commands (model, model-query, application, machine, unit,
+relation, offer, remote-application and storage) defines a way to wait for a
+goal state to be reached. The goal state can be defined programmatically using
+the query DSL (domain specific language).
 
 The wait-for command is an optimized alternative to the status command for 
 determining programmatically if a goal state has been reached. The wait-for
@@ -30,13 +31,28 @@ the command.
 
 Built-in functions are provided to help define the goal state. The built-in
 functions are defined in the query package. Examples of built-in functions
-include len, print, forEach (lambda), startsWith and endsWith.
+include len, print, forEach (lambda), all (lambda), any (lambda), count
+(lambda), startsWith and endsWith.
+
+By default the wait-for commands log their progress in a human readable form.
+The --format option (json or yaml) instead emits a machine readable event to
+stdout every time the query is evaluated, listing the changed entities, the
+result of the query and the terms of the query that were not met. A final
+summary event reports whether the goal state was reached and, if not, which
+terms of the query were still unmet. The goal state is not reached when the
+wait ends because the entity was removed. The --output option writes the
+summary event to a file instead of stdout.
 
 See also:
     wait-for model
+    wait-for model-query
     wait-for application
     wait-for machine
     wait-for unit
+    wait-for relation
+    wait-for offer
+    wait-for remote-application
+    wait-for storage
 `
 
 const waitForExamples = `
@@ -51,6 +67,15 @@ Waits for the mysql application to be active or idle.
 Waits for the model units to all start with ubuntu.
 
     juju wait-for model default --query='forEach(units, unit => startsWith(unit.name, "ubuntu"))'
+
+Waits for the relation between mysql and wordpress to be joined.
+
+    juju wait-for relation mysql wordpress
+
+Waits for the mysql application to be active, emitting progress events as
+JSON lines.
+
+    juju wait-for application mysql --format=json
 `
 
 // NewWaitForCommand creates the wait-for supercommand and registers the
@@ -67,6 +92,11 @@ func NewWaitForCommand() cmd.Command {
 	waitFor.Register(newApplicationCommand())
 	waitFor.Register(newMachineCommand())
 	waitFor.Register(newModelCommand())
+	waitFor.Register(newModelQueryCommand())
+	waitFor.Register(newOfferCommand())
+	waitFor.Register(newRelationCommand())
+	waitFor.Register(newRemoteApplicationCommand())
+	waitFor.Register(newStorageCommand())
 	waitFor.Register(newUnitCommand())
 	return waitFor
 }
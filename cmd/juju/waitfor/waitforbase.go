@@ -4,19 +4,182 @@
 package waitfor
 
 import (
+	"fmt"
+	"io"
 	"reflect"
 	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	apiclient "github.com/juju/juju/api/client/client"
 	"github.com/juju/juju/cmd/juju/waitfor/api"
 	"github.com/juju/juju/cmd/juju/waitfor/query"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/rpc/params"
 )
 
 type waitForCommandBase struct {
 	modelcmd.ModelCommandBase
 
 	newWatchAllAPIFunc func() (api.WatchAllAPI, error)
+
+	out         cmd.Output
+	started     time.Time
+	evaluations int
+	unmet       []string
+	reached     bool
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *waitForCommandBase) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "plain", eventFormatters)
+}
+
+// eventFormatters holds the formats of the progress and summary events. The
+// plain format logs the progress in a human readable form instead.
+var eventFormatters = map[string]cmd.Formatter{
+	"plain": func(io.Writer, interface{}) error { return nil },
+	"json":  cmd.FormatJson,
+	"yaml":  formatYamlEvent,
+}
+
+// formatYamlEvent writes each event as a separate yaml document, so that a
+// stream of events can be decoded.
+func formatYamlEvent(writer io.Writer, value interface{}) error {
+	if _, err := io.WriteString(writer, "---\n"); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.FormatYaml(writer, value)
+}
+
+// formatted reports whether progress and summary events have been requested
+// instead of the human readable output.
+func (c *waitForCommandBase) formatted() bool {
+	return c.out.Name() != "plain"
+}
+
+// progressEvent is emitted for every evaluation of the query, when a format
+// has been requested.
+type progressEvent struct {
+	Type    string    `json:"type" yaml:"type"`
+	Time    time.Time `json:"time" yaml:"time"`
+	Entity  string    `json:"entity" yaml:"entity"`
+	Changes []string  `json:"changes,omitempty" yaml:"changes,omitempty"`
+	Found   bool      `json:"found" yaml:"found"`
+	Result  bool      `json:"result" yaml:"result"`
+	Unmet   []string  `json:"unmet,omitempty" yaml:"unmet,omitempty"`
+}
+
+// summaryEvent is emitted once the wait has finished, when a format has
+// been requested.
+type summaryEvent struct {
+	Type        string    `json:"type" yaml:"type"`
+	Time        time.Time `json:"time" yaml:"time"`
+	Entity      string    `json:"entity" yaml:"entity"`
+	Query       string    `json:"query" yaml:"query"`
+	Reached     bool      `json:"reached" yaml:"reached"`
+	Error       string    `json:"error,omitempty" yaml:"error,omitempty"`
+	Elapsed     string    `json:"elapsed" yaml:"elapsed"`
+	Evaluations int       `json:"evaluations" yaml:"evaluations"`
+	Unmet       []string  `json:"unmet,omitempty" yaml:"unmet,omitempty"`
+}
+
+// runQuery runs the query with a given scope, recording whether the query
+// was met. When a format has been requested the query terms that evaluated
+// to false are also recorded, so they can be reported.
+func (c *waitForCommandBase) runQuery(input string, q query.Query, scope query.Scope) (bool, error) {
+	if !c.formatted() {
+		res, err := runQuery(input, q, scope)
+		c.reached = res
+		return res, err
+	}
+
+	res, evaluations, err := q.BuiltinsExplain(scope)
+	if err != nil {
+		return false, HelpDisplay(err, input, scope.GetIdents())
+	}
+	c.reached = res
+	c.evaluations++
+	c.unmet = nil
+	for _, evaluation := range evaluations {
+		if !evaluation.Result {
+			c.unmet = append(c.unmet, evaluation.Expression)
+		}
+	}
+	return res, nil
+}
+
+// reportProgress wraps a strategy function, so that a progress event is
+// emitted for every set of deltas that is evaluated, when a format has been
+// requested.
+func (c *waitForCommandBase) reportProgress(ctx *cmd.Context, fn StrategyFunc) StrategyFunc {
+	c.started = time.Now()
+	if !c.formatted() {
+		return fn
+	}
+	return func(name string, deltas []params.Delta, q query.Query) (bool, error) {
+		evaluations := c.evaluations
+		done, err := fn(name, deltas, q)
+		if err != nil {
+			return done, err
+		}
+
+		changes := make([]string, len(deltas))
+		for i, delta := range deltas {
+			id := delta.Entity.EntityId()
+			changes[i] = fmt.Sprintf("%s:%s", id.Kind, id.Id)
+			if delta.Removed {
+				changes[i] += " (removed)"
+			}
+		}
+		found := c.evaluations > evaluations
+		event := progressEvent{
+			Type:    "progress",
+			Time:    time.Now().UTC(),
+			Entity:  name,
+			Changes: changes,
+			Found:   found,
+			Result:  done,
+		}
+		if found {
+			event.Unmet = c.unmet
+		}
+		if err := eventFormatters[c.out.Name()](ctx.Stdout, event); err != nil {
+			return false, errors.Trace(err)
+		}
+		return done, nil
+	}
+}
+
+// reportSummary emits the final summary of the wait, when a format has been
+// requested. The goal state is only reported as reached if the query was met,
+// not if the wait ended because the entity was removed.
+func (c *waitForCommandBase) reportSummary(ctx *cmd.Context, name, input string, err error) {
+	if !c.formatted() {
+		return
+	}
+	event := summaryEvent{
+		Type:        "summary",
+		Time:        time.Now().UTC(),
+		Entity:      name,
+		Query:       input,
+		Reached:     err == nil && c.reached,
+		Elapsed:     time.Since(c.started).Round(time.Millisecond).String(),
+		Evaluations: c.evaluations,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	if !event.Reached {
+		event.Unmet = c.unmet
+	}
+	if err := c.out.Write(ctx, event); err != nil {
+		ctx.Warningf("unable to write summary: %v", err)
+	}
 }
 
 type modelAllWatchShim struct {
@@ -0,0 +1,191 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+)
+
+type waitForBaseSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&waitForBaseSuite{})
+
+// newFlaggedMachineCommand returns a machine command with its flags parsed from
+// the given arguments.
+func newFlaggedMachineCommand(c *gc.C, args ...string) *machineCommand {
+	cmd := &machineCommand{}
+	f := gnuflag.NewFlagSetWithFlagKnownAs("wait-for", gnuflag.ContinueOnError, "option")
+	cmd.SetFlags(f)
+	c.Assert(f.Parse(false, args), jc.ErrorIsNil)
+	return cmd
+}
+
+func (s *waitForBaseSuite) TestFormatFlag(c *gc.C) {
+	c.Check(newFlaggedMachineCommand(c).formatted(), jc.IsFalse)
+	c.Check(newFlaggedMachineCommand(c, "--format=json").formatted(), jc.IsTrue)
+	c.Check(newFlaggedMachineCommand(c, "--format=yaml").formatted(), jc.IsTrue)
+
+	f := gnuflag.NewFlagSetWithFlagKnownAs("wait-for", gnuflag.ContinueOnError, "option")
+	f.SetOutput(io.Discard)
+	(&machineCommand{}).SetFlags(f)
+	c.Assert(f.Parse(false, []string{"--format=tabular"}), gc.ErrorMatches, `invalid value "tabular" for option --format: unknown format "tabular"`)
+}
+
+func (s *waitForBaseSuite) TestReportProgressJSON(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+
+	cmd := newFlaggedMachineCommand(c, "--format=json")
+	cmd.query = `life=="alive" && status=="started"`
+
+	q, err := query.Parse(cmd.query)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fn := cmd.reportProgress(ctx, cmd.waitFor(cmd.query, MakeScopeContext(), ctx))
+	done, err := fn("0", []params.Delta{{
+		Entity: &params.MachineInfo{
+			Id:          "0",
+			Life:        life.Alive,
+			AgentStatus: params.StatusInfo{Current: status.Pending},
+		},
+	}}, q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+
+	cmd.reportSummary(ctx, "0", cmd.query, errors.New("timed out"))
+
+	lines := strings.Split(strings.TrimSpace(cmdtesting.Stdout(ctx)), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+
+	var progress progressEvent
+	err = json.Unmarshal([]byte(lines[0]), &progress)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(progress.Type, gc.Equals, "progress")
+	c.Check(progress.Entity, gc.Equals, "0")
+	c.Check(progress.Changes, jc.DeepEquals, []string{"machine:0"})
+	c.Check(progress.Found, jc.IsTrue)
+	c.Check(progress.Result, jc.IsFalse)
+	c.Check(progress.Unmet, jc.DeepEquals, []string{`(status == "started")`})
+
+	var summary summaryEvent
+	err = json.Unmarshal([]byte(lines[1]), &summary)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summary.Type, gc.Equals, "summary")
+	c.Check(summary.Reached, jc.IsFalse)
+	c.Check(summary.Error, gc.Equals, "timed out")
+	c.Check(summary.Evaluations, gc.Equals, 1)
+	c.Check(summary.Unmet, jc.DeepEquals, []string{`(status == "started")`})
+}
+
+func (s *waitForBaseSuite) TestReportProgressNotFound(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+
+	cmd := newFlaggedMachineCommand(c, "--format=yaml")
+
+	q, err := query.Parse(`life=="alive"`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fn := cmd.reportProgress(ctx, cmd.waitFor(`life=="alive"`, MakeScopeContext(), ctx))
+	done, err := fn("0", []params.Delta{{
+		Entity: &params.MachineInfo{Id: "1"},
+	}}, q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+
+	c.Assert(cmdtesting.Stdout(ctx), gc.Matches, `(?s)---\ntype: progress\n.*entity: "0"\nchanges:\n- machine:1\nfound: false\nresult: false\n`)
+}
+
+func (s *waitForBaseSuite) TestReportProgressWithoutFormat(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+
+	cmd := newFlaggedMachineCommand(c)
+	q, err := query.Parse(`life=="alive"`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fn := cmd.reportProgress(ctx, cmd.waitFor(`life=="alive"`, MakeScopeContext(), ctx))
+	done, err := fn("0", []params.Delta{{
+		Entity: &params.MachineInfo{Id: "0", Life: life.Alive},
+	}}, q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsTrue)
+
+	cmd.reportSummary(ctx, "0", `life=="alive"`, nil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *waitForBaseSuite) TestReportSummaryNotReachedWhenDead(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+
+	cmd := newFlaggedMachineCommand(c, "--format=json")
+	cmd.query = `status=="started"`
+
+	q, err := query.Parse(cmd.query)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fn := cmd.reportProgress(ctx, cmd.waitFor(cmd.query, MakeScopeContext(), ctx))
+	done, err := fn("0", []params.Delta{{
+		Entity: &params.MachineInfo{
+			Id:          "0",
+			Life:        life.Dead,
+			AgentStatus: params.StatusInfo{Current: status.Down},
+		},
+	}}, q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsTrue)
+
+	cmd.reportSummary(ctx, "0", cmd.query, nil)
+
+	lines := strings.Split(strings.TrimSpace(cmdtesting.Stdout(ctx)), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+
+	var summary summaryEvent
+	err = json.Unmarshal([]byte(lines[1]), &summary)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summary.Reached, jc.IsFalse)
+	c.Check(summary.Error, gc.Equals, "")
+	c.Check(summary.Unmet, jc.DeepEquals, []string{`(status == "started")`})
+}
+
+func (s *waitForBaseSuite) TestReportSummaryReached(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+
+	cmd := newFlaggedMachineCommand(c, "--format=json")
+	cmd.query = `life=="alive"`
+
+	q, err := query.Parse(cmd.query)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fn := cmd.reportProgress(ctx, cmd.waitFor(cmd.query, MakeScopeContext(), ctx))
+	done, err := fn("0", []params.Delta{{
+		Entity: &params.MachineInfo{Id: "0", Life: life.Alive},
+	}}, q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsTrue)
+
+	cmd.reportSummary(ctx, "0", cmd.query, nil)
+
+	lines := strings.Split(strings.TrimSpace(cmdtesting.Stdout(ctx)), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+
+	var summary summaryEvent
+	err = json.Unmarshal([]byte(lines[1]), &summary)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summary.Reached, jc.IsTrue)
+	c.Check(summary.Unmet, gc.HasLen, 0)
+}
@@ -54,6 +54,7 @@ var (
 		"undertaker",
 		"unit-assigner", // tertiary dependency: will be inactive because migration workers will be inactive
 		"secrets-pruner",
+		"secrets-drift-checker",
 		"user-secrets-drain-worker",
 	}
 	aliveModelWorkers = []string{
@@ -79,6 +80,7 @@ var (
 		"storage-provisioner",
 		"unit-assigner",
 		"secrets-pruner",
+		"secrets-drift-checker",
 		"user-secrets-drain-worker",
 	}
 	migratingModelWorkers = []string{
@@ -108,6 +110,8 @@ var (
 		"api-config-watcher",
 		"api-server",
 		"audit-config-updater",
+		"audit-log-forwarder",
+		"backup-scheduler",
 		"broker-tracker",
 		"central-hub",
 		"certificate-updater",
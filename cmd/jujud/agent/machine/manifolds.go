@@ -42,7 +42,9 @@ import (
 	"github.com/juju/juju/internal/worker/apiserver"
 	"github.com/juju/juju/internal/worker/apiservercertwatcher"
 	"github.com/juju/juju/internal/worker/auditconfigupdater"
+	"github.com/juju/juju/internal/worker/auditlogforwarder"
 	"github.com/juju/juju/internal/worker/authenticationworker"
+	"github.com/juju/juju/internal/worker/backupscheduler"
 	"github.com/juju/juju/internal/worker/caasunitsmanager"
 	"github.com/juju/juju/internal/worker/caasupgrader"
 	"github.com/juju/juju/internal/worker/centralhub"
@@ -726,6 +728,18 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker: auditconfigupdater.New,
 		})),
 
+		// The audit log forwarder sends the records written to the
+		// audit log to the syslog host or webhook set in controller
+		// config.
+		auditLogForwarderName: ifController(auditlogforwarder.Manifold(auditlogforwarder.ManifoldConfig{
+			AgentName:              agentName,
+			AuditConfigUpdaterName: auditConfigUpdaterName,
+			Clock:                  config.Clock,
+			Logger:                 loggo.GetLogger("juju.worker.auditlogforwarder"),
+			OpenSink:               auditlogforwarder.OpenSink,
+			NewWorker:              auditlogforwarder.NewWorker,
+		})),
+
 		// The lease expiry worker constantly deletes
 		// leases with an expiry time in the past.
 		leaseExpiryName: ifController(leaseexpiry.Manifold(leaseexpiry.ManifoldConfig{
@@ -1003,6 +1017,17 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 			ContainerType:                instance.LXD,
 		})),
+		// The backup scheduler creates controller backups on the
+		// schedule set in controller config.
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				APICallerName: apiCallerName,
+				Clock:         config.Clock,
+				Logger:        loggo.GetLogger("juju.worker.backupscheduler"),
+				NewFacade:     backupscheduler.NewFacade,
+				NewWorker:     backupscheduler.NewWorker,
+			},
+		))),
 		// isNotControllerFlagName is only used for the stateconverter,
 		isNotControllerFlagName: isControllerFlagManifold(false),
 		stateConverterName: ifNotController(ifNotMigrating(stateconverter.Manifold(stateconverter.ManifoldConfig{
@@ -1193,6 +1218,7 @@ const (
 	changeStreamName              = "change-stream"
 	certificateUpdaterName        = "certificate-updater"
 	auditConfigUpdaterName        = "audit-config-updater"
+	auditLogForwarderName         = "audit-log-forwarder"
 	leaseExpiryName               = "lease-expiry"
 	leaseManagerName              = "lease-manager"
 	stateConverterName            = "state-converter"
@@ -1201,6 +1227,8 @@ const (
 
 	secretBackendRotateName = "secret-backend-rotate"
 
+	backupSchedulerName = "backup-scheduler"
+
 	upgradeSeriesWorkerName = "upgrade-series"
 
 	httpServerName     = "http-server"
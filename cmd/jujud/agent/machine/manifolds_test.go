@@ -65,6 +65,8 @@ func (s *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"audit-log-forwarder",
+			"backup-scheduler",
 			"broker-tracker",
 			"central-hub",
 			"certificate-updater",
@@ -150,6 +152,7 @@ func (s *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"audit-log-forwarder",
 			"caas-units-manager",
 			"central-hub",
 			"certificate-watcher",
@@ -232,6 +235,7 @@ func (s *ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"api-config-watcher",
 		"api-server",
 		"audit-config-updater",
+		"audit-log-forwarder",
 		"certificate-updater",
 		"certificate-watcher",
 		"central-hub",
@@ -305,6 +309,7 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 	controllerWorkers := set.NewStrings(
 		"certificate-watcher",
 		"audit-config-updater",
+		"audit-log-forwarder",
 		"is-primary-controller-flag",
 		"model-cache-initialized-flag",
 		"model-cache-initialized-gate",
@@ -325,6 +330,7 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 
 	// Explicitly guarded by ifPrimaryController.
 	primaryControllerWorkers := set.NewStrings(
+		"backup-scheduler",
 		"external-controller-updater",
 		"secret-backend-rotate",
 	)
@@ -512,6 +518,29 @@ var expectedMachineManifoldsWithDependenciesIAAS = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"audit-log-forwarder": {
+		"agent",
+		"audit-config-updater",
+		"is-controller-flag",
+		"state",
+		"state-config-watcher",
+	},
+
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"broker-tracker": {
 		"agent",
 		"api-caller",
@@ -1161,6 +1190,14 @@ var expectedMachineManifoldsWithDependenciesCAAS = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"audit-log-forwarder": {
+		"agent",
+		"audit-config-updater",
+		"is-controller-flag",
+		"state",
+		"state-config-watcher",
+	},
+
 	"central-hub": {"agent", "state-config-watcher"},
 
 	"certificate-watcher": {
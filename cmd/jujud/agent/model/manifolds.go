@@ -63,6 +63,7 @@ import (
 	"github.com/juju/juju/internal/worker/pruner"
 	"github.com/juju/juju/internal/worker/remoterelations"
 	"github.com/juju/juju/internal/worker/secretsdrainworker"
+	"github.com/juju/juju/internal/worker/secretsdriftchecker"
 	"github.com/juju/juju/internal/worker/secretspruner"
 	"github.com/juju/juju/internal/worker/singular"
 	"github.com/juju/juju/internal/worker/statushistorypruner"
@@ -121,6 +122,10 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// SecretsDriftCheckInterval controls how often the content of secrets
+	// stored in external backends is checked for drift.
+	SecretsDriftCheckInterval time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -314,7 +319,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
+				OpenFn: sinks.Open,
 			}},
 			Logger: config.LoggingContext.GetLogger("juju.worker.logforwarder"),
 		})),
@@ -337,6 +342,14 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewUserSecretsFacade: secretspruner.NewUserSecretsFacade,
 			NewWorker:            secretspruner.NewWorker,
 		})),
+		secretsDriftCheckerName: ifNotMigrating(secretsdriftchecker.Manifold(secretsdriftchecker.ManifoldConfig{
+			APICallerName:    apiCallerName,
+			Logger:           config.LoggingContext.GetLogger("juju.worker.secretsdriftchecker"),
+			Clock:            config.Clock,
+			Period:           config.SecretsDriftCheckInterval,
+			NewSecretsFacade: secretsdriftchecker.NewSecretsFacade,
+			NewWorker:        secretsdriftchecker.NewWorker,
+		})),
 		// The userSecretsDrainWorker is the worker that drains the user secrets from the inactive backend to the current active backend.
 		userSecretsDrainWorker: ifNotMigrating(secretsdrainworker.Manifold(secretsdrainworker.ManifoldConfig{
 			APICallerName:         apiCallerName,
@@ -747,8 +760,9 @@ const (
 	caasStorageProvisionerName     = "caas-storage-provisioner"
 	caasBrokerTrackerName          = "caas-broker-tracker"
 
-	secretsPrunerName      = "secrets-pruner"
-	userSecretsDrainWorker = "user-secrets-drain-worker"
+	secretsPrunerName       = "secrets-pruner"
+	secretsDriftCheckerName = "secrets-drift-checker"
+	userSecretsDrainWorker  = "user-secrets-drain-worker"
 
 	validCredentialFlagName = "valid-credential-flag"
 )
@@ -61,6 +61,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"secrets-drift-checker",
 		"secrets-pruner",
 		"state-cleaner",
 		"status-history-pruner",
@@ -112,6 +113,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"secrets-drift-checker",
 		"secrets-pruner",
 		"state-cleaner",
 		"status-history-pruner",
@@ -219,6 +221,17 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"environ-upgraded-flag",
 		"not-dead-flag"},
 
+	"secrets-drift-checker": {
+		"agent",
+		"api-caller",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"not-dead-flag",
+	},
+
 	"secrets-pruner": {
 		"agent",
 		"api-caller",
@@ -464,6 +477,17 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"not-dead-flag",
 	},
 
+	"secrets-drift-checker": {
+		"agent",
+		"api-caller",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"not-dead-flag",
+	},
+
 	"secrets-pruner": {
 		"agent",
 		"api-caller",
@@ -7,17 +7,24 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 	"time"
 
+	"filippo.io/age"
+	"filippo.io/age/agessh"
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	"github.com/juju/romulus"
 	"github.com/juju/utils/v3"
+	"golang.org/x/crypto/ssh"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/core/schedule"
+	"github.com/juju/juju/logfwd/httppush"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/pki"
 )
 
@@ -92,6 +99,33 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogForwardType is the type of the target to which audit log
+	// records are forwarded, either "syslog" or "webhook". An empty
+	// value disables forwarding.
+	AuditLogForwardType = "audit-log-forward-type"
+
+	// AuditLogForwardEndpoint is where audit log records are
+	// forwarded: the host[:port] of a syslog server, or the URL of a
+	// webhook.
+	AuditLogForwardEndpoint = "audit-log-forward-endpoint"
+
+	// AuditLogForwardCACert is the CA certificate (x.509, PEM-encoded)
+	// used to verify the audit log forwarding target.
+	AuditLogForwardCACert = "audit-log-forward-ca-cert"
+
+	// AuditLogForwardClientCert is the client certificate (x.509,
+	// PEM-encoded) presented to the audit log forwarding target.
+	AuditLogForwardClientCert = "audit-log-forward-client-cert"
+
+	// AuditLogForwardClientKey is the private key (PEM-encoded) of the
+	// client certificate presented to the audit log forwarding target.
+	AuditLogForwardClientKey = "audit-log-forward-client-key"
+
+	// AuditLogForwardHeaders is a comma separated list of key=value
+	// HTTP headers sent with the audit log records forwarded to a
+	// webhook.
+	AuditLogForwardHeaders = "audit-log-forward-headers"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -278,6 +312,69 @@ const (
 	// SSHMaxConcurrentConnections is the maximum number of concurrent SSH
 	// connections to the controller.
 	SSHMaxConcurrentConnections = "ssh-max-concurrent-connections"
+
+	// SSHSessionRecording sets whether sessions proxied through the
+	// embedded SSH server are recorded.
+	SSHSessionRecording = "ssh-session-recording"
+
+	// SSHUserCertificates sets whether machines trust the controller's
+	// SSH certificate authority instead of the model's authorized keys.
+	SSHUserCertificates = "ssh-user-certificates"
+
+	// SSHUserCertificatesOnly sets whether, when machines trust the
+	// controller's SSH certificate authority, the keys of the model's
+	// users are removed from them.
+	SSHUserCertificatesOnly = "ssh-user-certificates-only"
+
+	// BackupSchedule is the cron-like schedule on which the controller
+	// creates backups of itself. An empty value disables scheduled backups.
+	BackupSchedule = "backup-schedule"
+
+	// BackupRetentionCount is the number of scheduled backup archives to
+	// keep on the controller. Older ones are pruned after each scheduled
+	// backup. A value of 0 means no limit.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupRetentionAge is the maximum age of scheduled backup archives
+	// kept on the controller. Older ones are pruned after each scheduled
+	// backup. A value of 0 means no limit.
+	BackupRetentionAge = "backup-retention-age"
+
+	// BackupS3Endpoint is the URL of an S3-compatible object store to
+	// which the controller uploads backup archives once created. An
+	// empty value disables uploading.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region used to sign requests to the backup
+	// object store.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3Bucket is the bucket to which backup archives are uploaded.
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// BackupS3Prefix is prepended to the name of each backup archive
+	// uploaded to the bucket.
+	BackupS3Prefix = "backup-s3-prefix"
+
+	// BackupS3AccessKey is the access key used to authenticate with
+	// the backup object store.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to authenticate with
+	// the backup object store.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
+	// BackupEncryptionRecipients is a list of public keys to which the
+	// controller encrypts each backup archive it creates. Both age
+	// X25519 and SSH (ed25519 or RSA) public keys are accepted. An
+	// empty list disables encryption.
+	BackupEncryptionRecipients = "backup-encryption-recipients"
+
+	// CharmhubCacheMode sets whether and how the controller caches
+	// Charmhub responses, charm archives and resources in its object
+	// store. It can be "fallback" or "offline"; an empty value disables
+	// the cache.
+	CharmhubCacheMode = "charmhub-cache-mode"
 )
 
 // Attribute Defaults
@@ -289,6 +386,23 @@ const (
 	// DefaultSSHServerPort is the default port used for the embedded SSH server.
 	DefaultSSHServerPort = 17022
 
+	// DefaultSSHSessionRecording is the default for whether sessions
+	// proxied through the embedded SSH server are recorded.
+	DefaultSSHSessionRecording = false
+
+	// DefaultSSHUserCertificates is the default for whether machines
+	// trust the controller's SSH certificate authority.
+	DefaultSSHUserCertificates = false
+
+	// DefaultSSHUserCertificatesOnly is the default for whether the
+	// keys of the model's users are removed from machines trusting the
+	// controller's SSH certificate authority.
+	DefaultSSHUserCertificatesOnly = false
+
+	// DefaultBackupRetentionCount is the default number of backup
+	// archives kept on the controller.
+	DefaultBackupRetentionCount = 7
+
 	// DefaultApplicationResourceDownloadLimit allows unlimited
 	// resource download requests initiated by a unit agent per application.
 	DefaultApplicationResourceDownloadLimit = 0
@@ -457,6 +571,12 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogForwardType,
+		AuditLogForwardEndpoint,
+		AuditLogForwardCACert,
+		AuditLogForwardClientCert,
+		AuditLogForwardClientKey,
+		AuditLogForwardHeaders,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
@@ -471,6 +591,20 @@ var (
 		JujudControllerSnapSource,
 		SSHMaxConcurrentConnections,
 		SSHServerPort,
+		SSHSessionRecording,
+		SSHUserCertificates,
+		SSHUserCertificatesOnly,
+		BackupSchedule,
+		BackupRetentionCount,
+		BackupRetentionAge,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3Prefix,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+		BackupEncryptionRecipients,
+		CharmhubCacheMode,
 	}
 
 	// For backwards compatibility, we must include "anything", "juju-apiserver"
@@ -498,9 +632,26 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		AuditLogForwardCACert,
+		AuditLogForwardClientCert,
+		AuditLogForwardClientKey,
+		AuditLogForwardEndpoint,
+		AuditLogForwardHeaders,
+		AuditLogForwardType,
 		AuditLogMaxBackups,
 		AuditLogMaxSize,
+		BackupEncryptionRecipients,
+		BackupRetentionAge,
+		BackupRetentionCount,
+		BackupS3AccessKey,
+		BackupS3Bucket,
+		BackupS3Endpoint,
+		BackupS3Prefix,
+		BackupS3Region,
+		BackupS3SecretKey,
+		BackupSchedule,
 		CAASImageRepo,
+		CharmhubCacheMode,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -524,6 +675,18 @@ var (
 		QueryTracingEnabled,
 		QueryTracingThreshold,
 		SSHMaxConcurrentConnections,
+		SSHSessionRecording,
+		SSHUserCertificates,
+		SSHUserCertificatesOnly,
+	)
+
+	// SecretAttributes contains the controller config attributes that
+	// hold credentials. They are only read by the controller itself,
+	// and are never returned over the API.
+	SecretAttributes = set.NewStrings(
+		AuditLogForwardClientKey,
+		AuditLogForwardHeaders,
+		BackupS3SecretKey,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -789,6 +952,58 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// The types of audit log forwarding target.
+const (
+	// AuditLogForwardTypeSyslog forwards audit log records to a syslog
+	// server over TLS.
+	AuditLogForwardTypeSyslog = "syslog"
+
+	// AuditLogForwardTypeWebhook forwards audit log records to a
+	// webhook, posting them as newline delimited JSON.
+	AuditLogForwardTypeWebhook = "webhook"
+)
+
+// AuditLogForwardType returns the type of the target to which audit
+// log records are forwarded, or the empty string if they aren't.
+func (c Config) AuditLogForwardType() string {
+	return c.asString(AuditLogForwardType)
+}
+
+// AuditLogForwardEndpoint returns where audit log records are
+// forwarded.
+func (c Config) AuditLogForwardEndpoint() string {
+	return c.asString(AuditLogForwardEndpoint)
+}
+
+// AuditLogForwardCACert returns the CA certificate used to verify the
+// audit log forwarding target.
+func (c Config) AuditLogForwardCACert() string {
+	return c.asString(AuditLogForwardCACert)
+}
+
+// AuditLogForwardClientCert returns the client certificate presented
+// to the audit log forwarding target.
+func (c Config) AuditLogForwardClientCert() string {
+	return c.asString(AuditLogForwardClientCert)
+}
+
+// AuditLogForwardClientKey returns the private key of the client
+// certificate presented to the audit log forwarding target.
+func (c Config) AuditLogForwardClientKey() string {
+	return c.asString(AuditLogForwardClientKey)
+}
+
+// AuditLogForwardHeaders returns the HTTP headers sent with the audit
+// log records forwarded to a webhook.
+func (c Config) AuditLogForwardHeaders() map[string]string {
+	headers, err := httppush.ParseHeaders(c.asString(AuditLogForwardHeaders))
+	if err != nil {
+		// Validated when the config is set.
+		return nil
+	}
+	return headers
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -1041,6 +1256,121 @@ func (c Config) SSHMaxConcurrentConnections() int {
 	return c.intOrDefault(SSHMaxConcurrentConnections, DefaultSSHMaxConcurrentConnections)
 }
 
+// SSHSessionRecording returns whether sessions proxied through the
+// embedded SSH server are recorded.
+func (c Config) SSHSessionRecording() bool {
+	return c.boolOrDefault(SSHSessionRecording, DefaultSSHSessionRecording)
+}
+
+// SSHUserCertificates returns whether machines trust the controller's
+// SSH certificate authority instead of the model's authorized keys.
+func (c Config) SSHUserCertificates() bool {
+	return c.boolOrDefault(SSHUserCertificates, DefaultSSHUserCertificates)
+}
+
+// SSHUserCertificatesOnly returns whether the keys of the model's users
+// are removed from machines trusting the controller's SSH certificate
+// authority.
+func (c Config) SSHUserCertificatesOnly() bool {
+	return c.boolOrDefault(SSHUserCertificatesOnly, DefaultSSHUserCertificatesOnly)
+}
+
+// BackupSchedule returns the schedule on which the controller creates
+// backups of itself, or the empty string if scheduled backups are
+// disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupRetentionCount returns the number of scheduled backup archives
+// to keep on the controller. A value of zero indicates no limit.
+func (c Config) BackupRetentionCount() int {
+	switch v := c[BackupRetentionCount].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		// nil type shows up here
+	}
+	return DefaultBackupRetentionCount
+}
+
+// BackupRetentionAge returns the maximum age of scheduled backup archives
+// kept on the controller. A value of zero indicates no limit.
+func (c Config) BackupRetentionAge() time.Duration {
+	return c.durationOrDefault(BackupRetentionAge, 0)
+}
+
+// BackupS3Endpoint returns the URL of the object store to which backup
+// archives are uploaded, or the empty string if uploading is disabled.
+func (c Config) BackupS3Endpoint() string {
+	return c.asString(BackupS3Endpoint)
+}
+
+// BackupS3Region returns the region used to sign requests to the
+// backup object store.
+func (c Config) BackupS3Region() string {
+	return c.asString(BackupS3Region)
+}
+
+// BackupS3Bucket returns the bucket to which backup archives are uploaded.
+func (c Config) BackupS3Bucket() string {
+	return c.asString(BackupS3Bucket)
+}
+
+// BackupS3Prefix returns the prefix of the name of each backup archive
+// uploaded to the bucket.
+func (c Config) BackupS3Prefix() string {
+	return c.asString(BackupS3Prefix)
+}
+
+// BackupS3AccessKey returns the access key used to authenticate with
+// the backup object store.
+func (c Config) BackupS3AccessKey() string {
+	return c.asString(BackupS3AccessKey)
+}
+
+// BackupS3SecretKey returns the secret key used to authenticate with
+// the backup object store.
+func (c Config) BackupS3SecretKey() string {
+	return c.asString(BackupS3SecretKey)
+}
+
+// BackupEncryptionRecipients returns the public keys to which backup
+// archives are encrypted, or nil if backups are not encrypted.
+func (c Config) BackupEncryptionRecipients() []string {
+	value, ok := c[BackupEncryptionRecipients].([]interface{})
+	if !ok {
+		return nil
+	}
+	var recipients []string
+	for _, item := range value {
+		if recipient, ok := item.(string); ok {
+			recipients = append(recipients, recipient)
+		}
+	}
+	return recipients
+}
+
+// The ways in which the controller can use its Charmhub cache.
+const (
+	// CharmhubCacheModeFallback sends requests to Charmhub, caching
+	// what it returns, and serves cached responses when Charmhub
+	// can't be reached.
+	CharmhubCacheModeFallback = "fallback"
+
+	// CharmhubCacheModeOffline serves requests from the cache only,
+	// without ever contacting Charmhub.
+	CharmhubCacheModeOffline = "offline"
+)
+
+// CharmhubCacheMode returns how the controller uses its Charmhub
+// cache, or the empty string if the cache is disabled.
+func (c Config) CharmhubCacheMode() string {
+	return c.asString(CharmhubCacheMode)
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -1317,6 +1647,48 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := schedule.Parse(v); err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupSchedule)
+		}
+	}
+
+	if v, ok := c[BackupRetentionCount].(int); ok {
+		if v < 0 {
+			return errors.NotValidf("negative %s", BackupRetentionCount)
+		}
+	}
+
+	if d, ok := c[BackupRetentionAge].(time.Duration); ok {
+		if d < 0 {
+			return errors.Errorf("%s value %q must be a positive duration", BackupRetentionAge, d)
+		}
+	}
+
+	if err := validateBackupS3(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := validateAuditLogForward(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	switch mode := c.CharmhubCacheMode(); mode {
+	case "", CharmhubCacheModeFallback, CharmhubCacheModeOffline:
+	default:
+		return errors.NotValidf("%s %q, expected %q or %q", CharmhubCacheMode,
+			mode, CharmhubCacheModeFallback, CharmhubCacheModeOffline)
+	}
+
+	if v, ok := c[BackupEncryptionRecipients].([]interface{}); ok {
+		for i, key := range v {
+			key, _ := key.(string)
+			if err := validateBackupRecipient(key); err != nil {
+				return errors.Annotatef(err, "invalid %s at position %d", BackupEncryptionRecipients, i+1)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -1372,3 +1744,78 @@ func (c Config) AsSpaceConstraints(spaces *[]string) *[]string {
 	ns := newSpaces.SortedValues()
 	return &ns
 }
+
+// validateBackupRecipient checks that key is a public key to which
+// backup archives can be encrypted.
+func validateBackupRecipient(key string) error {
+	key = strings.TrimSpace(key)
+	if strings.HasPrefix(key, "age1") {
+		_, err := age.ParseX25519Recipient(key)
+		return errors.Trace(err)
+	}
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = agessh.ParseRecipient(string(ssh.MarshalAuthorizedKey(pub)))
+	return errors.Trace(err)
+}
+
+func validateBackupS3(c Config) error {
+	endpoint := c.BackupS3Endpoint()
+	if endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", BackupS3Endpoint)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("%s %q scheme", BackupS3Endpoint, endpoint)
+	}
+	if c.BackupS3Bucket() == "" {
+		return errors.NotValidf("empty %s with %s set", BackupS3Bucket, BackupS3Endpoint)
+	}
+	if c.BackupS3AccessKey() != "" && c.BackupS3SecretKey() == "" {
+		return errors.NotValidf("%s without %s", BackupS3AccessKey, BackupS3SecretKey)
+	}
+	if c.BackupS3SecretKey() != "" && c.BackupS3AccessKey() == "" {
+		return errors.NotValidf("%s without %s", BackupS3SecretKey, BackupS3AccessKey)
+	}
+	return nil
+}
+
+func validateAuditLogForward(c Config) error {
+	if _, err := httppush.ParseHeaders(c.asString(AuditLogForwardHeaders)); err != nil {
+		return errors.Annotatef(err, "invalid %s", AuditLogForwardHeaders)
+	}
+	endpoint := c.AuditLogForwardEndpoint()
+	switch c.AuditLogForwardType() {
+	case "":
+		return nil
+	case AuditLogForwardTypeSyslog:
+		cfg := syslog.RawConfig{
+			Enabled:    true,
+			Host:       endpoint,
+			CACert:     c.AuditLogForwardCACert(),
+			ClientCert: c.AuditLogForwardClientCert(),
+			ClientKey:  c.AuditLogForwardClientKey(),
+		}
+		if err := cfg.Validate(); err != nil {
+			return errors.Annotatef(err, "invalid syslog %s", AuditLogForwardEndpoint)
+		}
+	case AuditLogForwardTypeWebhook:
+		if _, err := httppush.ParseEndpoint(endpoint, "/"); err != nil {
+			return errors.Annotatef(err, "invalid webhook %s", AuditLogForwardEndpoint)
+		}
+		_, err := httppush.TLSConfig(
+			c.AuditLogForwardCACert(), c.AuditLogForwardClientCert(), c.AuditLogForwardClientKey())
+		if err != nil {
+			return errors.Annotatef(err, "invalid webhook TLS config")
+		}
+	default:
+		return errors.NotValidf("%s %q, expected %q or %q", AuditLogForwardType,
+			c.AuditLogForwardType(), AuditLogForwardTypeSyslog, AuditLogForwardTypeWebhook)
+	}
+	return nil
+}
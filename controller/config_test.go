@@ -423,8 +423,136 @@ var newConfigTests = []struct {
 		controller.SSHServerPort:     17078,
 	},
 	expectError: `ssh-server-port matching controller-api-port not valid`,
+}, {
+	about: "valid backup schedule",
+	config: controller.Config{
+		controller.BackupSchedule: "30 2 * * *",
+	},
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.BackupSchedule: "every day",
+	},
+	expectError: `invalid backup-schedule: schedule "every day": expected 5 fields, got 2`,
+}, {
+	about: "negative backup retention count",
+	config: controller.Config{
+		controller.BackupRetentionCount: -1,
+	},
+	expectError: `negative backup-retention-count not valid`,
+}, {
+	about: "negative backup retention age",
+	config: controller.Config{
+		controller.BackupRetentionAge: "-1h",
+	},
+	expectError: `backup-retention-age value "-1h0m0s" must be a positive duration`,
+}, {
+	about: "valid backup s3 config",
+	config: controller.Config{
+		controller.BackupS3Endpoint:  "https://minio.example.com:9000",
+		controller.BackupS3Bucket:    "juju-backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	},
+}, {
+	about: "invalid backup s3 endpoint scheme",
+	config: controller.Config{
+		controller.BackupS3Endpoint: "ftp://minio.example.com",
+		controller.BackupS3Bucket:   "juju-backups",
+	},
+	expectError: `backup-s3-endpoint "ftp://minio.example.com" scheme not valid`,
+}, {
+	about: "backup s3 endpoint without bucket",
+	config: controller.Config{
+		controller.BackupS3Endpoint: "https://minio.example.com",
+	},
+	expectError: `empty backup-s3-bucket with backup-s3-endpoint set not valid`,
+}, {
+	about: "backup s3 access key without secret key",
+	config: controller.Config{
+		controller.BackupS3Endpoint:  "https://minio.example.com",
+		controller.BackupS3Bucket:    "juju-backups",
+		controller.BackupS3AccessKey: "access",
+	},
+	expectError: `backup-s3-access-key without backup-s3-secret-key not valid`,
+}, {
+	about: "valid backup encryption recipients",
+	config: controller.Config{
+		controller.BackupEncryptionRecipients: []interface{}{
+			"age1840d7j408juj3lknktrxhfhz5jkd7x64ya9x7a0ccnp7wyl37p0sse0tq2",
+			"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIjnSl4H7szDweaz/rUNLA6kcxHeBLEkBWd8rOs79qLU backup",
+		},
+	},
+}, {
+	about: "invalid backup encryption recipient",
+	config: controller.Config{
+		controller.BackupEncryptionRecipients: []interface{}{
+			"age1840d7j408juj3lknktrxhfhz5jkd7x64ya9x7a0ccnp7wyl37p0sse0tq2",
+			"ssh-dss AAAA",
+		},
+	},
+	expectError: `invalid backup-encryption-recipients at position 2: .*`,
+}, {
+	about: "valid audit log forwarding to syslog",
+	config: controller.Config{
+		controller.AuditLogForwardType:       "syslog",
+		controller.AuditLogForwardEndpoint:   "syslog.example.com:6514",
+		controller.AuditLogForwardCACert:     testing.CACert,
+		controller.AuditLogForwardClientCert: testing.ServerCert,
+		controller.AuditLogForwardClientKey:  testing.ServerKey,
+	},
+}, {
+	about: "audit log forwarding to syslog without certificates",
+	config: controller.Config{
+		controller.AuditLogForwardType:     "syslog",
+		controller.AuditLogForwardEndpoint: "syslog.example.com:6514",
+	},
+	expectError: `invalid syslog audit-log-forward-endpoint: validating TLS config: .*`,
+}, {
+	about: "valid audit log forwarding to webhook",
+	config: controller.Config{
+		controller.AuditLogForwardType:     "webhook",
+		controller.AuditLogForwardEndpoint: "https://siem.example.com/juju",
+		controller.AuditLogForwardHeaders:  "Authorization=Bearer token",
+	},
+}, {
+	about: "invalid audit log forwarding webhook URL",
+	config: controller.Config{
+		controller.AuditLogForwardType:     "webhook",
+		controller.AuditLogForwardEndpoint: "siem.example.com",
+	},
+	expectError: `invalid webhook audit-log-forward-endpoint: Endpoint "siem.example.com", expected an http or https URL not valid`,
+}, {
+	about: "invalid audit log forwarding headers",
+	config: controller.Config{
+		controller.AuditLogForwardHeaders: "Authorization",
+	},
+	expectError: `invalid audit-log-forward-headers: header "Authorization", expected key=value not valid`,
+}, {
+	about: "invalid audit log forwarding type",
+	config: controller.Config{
+		controller.AuditLogForwardType: "kafka",
+	},
+	expectError: `audit-log-forward-type "kafka", expected "syslog" or "webhook" not valid`,
+}, {
+	about: "invalid charmhub cache mode",
+	config: controller.Config{
+		controller.CharmhubCacheMode: "always",
+	},
+	expectError: `charmhub-cache-mode "always", expected "fallback" or "offline" not valid`,
 }}
 
+func (s *ConfigSuite) TestAuditLogForwardHeaders(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AuditLogForwardHeaders: "Authorization=Bearer token, X-Scope=juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogForwardHeaders(), jc.DeepEquals, map[string]string{
+		"Authorization": "Bearer token",
+		"X-Scope":       "juju",
+	})
+}
+
 func (s *ConfigSuite) TestNewConfig(c *gc.C) {
 	for i, test := range newConfigTests {
 		c.Logf("test %d: %v", i, test.about)
@@ -987,3 +1115,52 @@ func (s *ConfigSuite) TestSSHServerConcurrentConnections(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.SSHMaxConcurrentConnections(), gc.Equals, 10)
 }
+
+func (s *ConfigSuite) TestBackupConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupSchedule(), gc.Equals, "")
+	c.Check(cfg.BackupRetentionCount(), gc.Equals, controller.DefaultBackupRetentionCount)
+	c.Check(cfg.BackupRetentionAge(), gc.Equals, time.Duration(0))
+	c.Check(cfg.BackupEncryptionRecipients(), gc.HasLen, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.BackupSchedule:       "@daily",
+			controller.BackupRetentionCount: 3,
+			controller.BackupRetentionAge:   "168h",
+			controller.BackupEncryptionRecipients: []interface{}{
+				"age1840d7j408juj3lknktrxhfhz5jkd7x64ya9x7a0ccnp7wyl37p0sse0tq2",
+			},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupSchedule(), gc.Equals, "@daily")
+	c.Check(cfg.BackupRetentionCount(), gc.Equals, 3)
+	c.Check(cfg.BackupRetentionAge(), gc.Equals, 7*24*time.Hour)
+	c.Check(cfg.BackupEncryptionRecipients(), jc.DeepEquals, []string{
+		"age1840d7j408juj3lknktrxhfhz5jkd7x64ya9x7a0ccnp7wyl37p0sse0tq2",
+	})
+
+	// Values which aren't strings are ignored rather than panicking.
+	cfg = controller.Config{
+		controller.BackupEncryptionRecipients: []interface{}{
+			"age1840d7j408juj3lknktrxhfhz5jkd7x64ya9x7a0ccnp7wyl37p0sse0tq2", 42,
+		},
+	}
+	c.Check(cfg.BackupEncryptionRecipients(), jc.DeepEquals, []string{
+		"age1840d7j408juj3lknktrxhfhz5jkd7x64ya9x7a0ccnp7wyl37p0sse0tq2",
+	})
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.BackupRetentionCount: 0,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupRetentionCount(), gc.Equals, 0)
+}
@@ -19,6 +19,12 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:                  schema.String(),
 	AuditLogMaxBackups:               schema.ForceInt(),
 	AuditLogExcludeMethods:           schema.List(schema.String()),
+	AuditLogForwardType:              schema.String(),
+	AuditLogForwardEndpoint:          schema.String(),
+	AuditLogForwardCACert:            schema.String(),
+	AuditLogForwardClientCert:        schema.String(),
+	AuditLogForwardClientKey:         schema.String(),
+	AuditLogForwardHeaders:           schema.String(),
 	APIPort:                          schema.ForceInt(),
 	APIPortOpenDelay:                 schema.TimeDuration(),
 	ControllerAPIPort:                schema.ForceInt(),
@@ -61,9 +67,26 @@ var configChecker = schema.FieldMap(schema.Fields{
 	JujudControllerSnapSource:        schema.String(),
 	SSHServerPort:                    schema.ForceInt(),
 	SSHMaxConcurrentConnections:      schema.ForceInt(),
+	SSHSessionRecording:              schema.Bool(),
+	SSHUserCertificates:              schema.Bool(),
+	SSHUserCertificatesOnly:          schema.Bool(),
+	BackupSchedule:                   schema.String(),
+	BackupRetentionCount:             schema.ForceInt(),
+	BackupRetentionAge:               schema.TimeDuration(),
+	BackupS3Endpoint:                 schema.String(),
+	BackupS3Region:                   schema.String(),
+	BackupS3Bucket:                   schema.String(),
+	BackupS3Prefix:                   schema.String(),
+	BackupS3AccessKey:                schema.String(),
+	BackupS3SecretKey:                schema.String(),
+	BackupEncryptionRecipients:       schema.List(schema.String()),
+	CharmhubCacheMode:                schema.String(),
 }, schema.Defaults{
 	SSHServerPort:                    DefaultSSHServerPort,
 	SSHMaxConcurrentConnections:      DefaultSSHMaxConcurrentConnections,
+	SSHSessionRecording:              DefaultSSHSessionRecording,
+	SSHUserCertificates:              DefaultSSHUserCertificates,
+	SSHUserCertificatesOnly:          DefaultSSHUserCertificatesOnly,
 	AgentRateLimitMax:                schema.Omit,
 	AgentRateLimitRate:               schema.Omit,
 	APIPort:                          DefaultAPIPort,
@@ -75,6 +98,12 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:                  fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
 	AuditLogMaxBackups:               DefaultAuditLogMaxBackups,
 	AuditLogExcludeMethods:           DefaultAuditLogExcludeMethods,
+	AuditLogForwardType:              schema.Omit,
+	AuditLogForwardEndpoint:          schema.Omit,
+	AuditLogForwardCACert:            schema.Omit,
+	AuditLogForwardClientCert:        schema.Omit,
+	AuditLogForwardClientKey:         schema.Omit,
+	AuditLogForwardHeaders:           schema.Omit,
 	StatePort:                        DefaultStatePort,
 	LoginTokenRefreshURL:             schema.Omit,
 	IdentityURL:                      schema.Omit,
@@ -111,6 +140,17 @@ var configChecker = schema.FieldMap(schema.Fields{
 	QueryTracingEnabled:              DefaultQueryTracingEnabled,
 	QueryTracingThreshold:            DefaultQueryTracingThreshold,
 	JujudControllerSnapSource:        DefaultJujudControllerSnapSource,
+	BackupSchedule:                   schema.Omit,
+	BackupRetentionCount:             DefaultBackupRetentionCount,
+	BackupRetentionAge:               schema.Omit,
+	BackupS3Endpoint:                 schema.Omit,
+	BackupS3Region:                   schema.Omit,
+	BackupS3Bucket:                   schema.Omit,
+	BackupS3Prefix:                   schema.Omit,
+	BackupS3AccessKey:                schema.Omit,
+	BackupS3SecretKey:                schema.Omit,
+	BackupEncryptionRecipients:       schema.Omit,
+	CharmhubCacheMode:                schema.Omit,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -152,6 +192,32 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.Tlist,
 		Description: "The list of Facade.Method names that aren't interesting for audit logging purposes.",
 	},
+	AuditLogForwardType: {
+		Type: environschema.Tstring,
+		Description: `The type of the target to which audit log records are forwarded,
+either syslog or webhook. Empty disables forwarding.`,
+	},
+	AuditLogForwardEndpoint: {
+		Type: environschema.Tstring,
+		Description: `Where audit log records are forwarded: the host[:port] of a syslog
+server, or the URL of a webhook`,
+	},
+	AuditLogForwardCACert: {
+		Type:        environschema.Tstring,
+		Description: `The CA certificate used to verify the audit log forwarding target`,
+	},
+	AuditLogForwardClientCert: {
+		Type:        environschema.Tstring,
+		Description: `The client certificate presented to the audit log forwarding target`,
+	},
+	AuditLogForwardClientKey: {
+		Type:        environschema.Tstring,
+		Description: `The private key of the client certificate presented to the audit log forwarding target`,
+	},
+	AuditLogForwardHeaders: {
+		Type:        environschema.Tstring,
+		Description: `A comma separated list of key=value HTTP headers sent to an audit log forwarding webhook`,
+	},
 	APIPort: {
 		Type:        environschema.Tint,
 		Description: "The port used for api connections",
@@ -324,4 +390,84 @@ will be output if tracing is enabled.`,
 		Type:        environschema.Tint,
 		Description: `The maximum number of concurrent ssh connections to the controller`,
 	},
+	SSHSessionRecording: {
+		Type: environschema.Tbool,
+		Description: `Whether to record the sessions proxied through the controller's ssh
+server. Recordings are kept in the target's model and can be listed and
+replayed with juju ssh-recordings.`,
+	},
+	SSHUserCertificates: {
+		Type: environschema.Tbool,
+		Description: `Whether machines trust short-lived SSH certificates issued by the
+controller, as well as the keys in each model's authorized-keys. When
+enabled, juju ssh asks the controller for a certificate scoped to the
+target machine. Keys added with juju add-ssh-key remain on the
+machines unless ssh-user-certificates-only is also enabled.`,
+	},
+	SSHUserCertificatesOnly: {
+		Type: environschema.Tbool,
+		Description: `Whether, when ssh-user-certificates is enabled, keys added with juju
+add-ssh-key are removed from the machines, so that users can only
+connect with certificates issued by the controller. The controller's
+own system key is always kept.`,
+	},
+	BackupSchedule: {
+		Type: environschema.Tstring,
+		Description: `The schedule on which the controller backs itself up, as five cron
+fields (minute hour day-of-month month day-of-week) evaluated in UTC or
+one of @hourly, @daily, @weekly, @monthly and @yearly. Empty disables
+scheduled backups.`,
+	},
+	BackupRetentionCount: {
+		Type: environschema.Tint,
+		Description: `The number of scheduled backup archives to keep on the controller, or 0
+for no limit. Other archives on the controller, and archives uploaded to
+the backup object store, are not pruned.`,
+	},
+	BackupRetentionAge: {
+		Type: environschema.Tstring,
+		Description: `The maximum age of scheduled backup archives kept on the controller, or 0
+for no limit. Other archives on the controller, and archives uploaded to
+the backup object store, are not pruned.`,
+	},
+	BackupS3Endpoint: {
+		Type: environschema.Tstring,
+		Description: `The URL of an S3-compatible object store to which the controller
+uploads each backup archive it creates. Empty disables uploading.
+Uploaded archives are not pruned by the controller; use the object
+store's lifecycle rules to expire them.`,
+	},
+	BackupS3Region: {
+		Type:        environschema.Tstring,
+		Description: `The region used to sign requests to the backup object store`,
+	},
+	BackupS3Bucket: {
+		Type:        environschema.Tstring,
+		Description: `The bucket to which backup archives are uploaded`,
+	},
+	BackupS3Prefix: {
+		Type:        environschema.Tstring,
+		Description: `The prefix added to the name of each backup archive uploaded to the bucket`,
+	},
+	BackupS3AccessKey: {
+		Type:        environschema.Tstring,
+		Description: `The access key used to authenticate with the backup object store`,
+	},
+	BackupS3SecretKey: {
+		Type:        environschema.Tstring,
+		Description: `The secret key used to authenticate with the backup object store`,
+	},
+	BackupEncryptionRecipients: {
+		Type: environschema.Tlist,
+		Description: `The public keys to which each backup archive is encrypted, as age
+X25519 keys (age1...) or SSH ed25519 or RSA keys. Empty disables encryption.`,
+	},
+	CharmhubCacheMode: {
+		Type: environschema.Tstring,
+		Description: `How the controller caches Charmhub responses, charm archives and
+resources in its object store. With "fallback", requests are sent to
+Charmhub and served from the cache when it can't be reached. With
+"offline", requests are only ever served from the cache, which can be
+seeded with juju charmhub-cache. Empty disables the cache.`,
+	},
 }
@@ -10,6 +10,7 @@ import (
 	"io"
 	"math/rand"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/juju/clock"
@@ -22,18 +23,24 @@ import (
 
 var logger = loggo.GetLogger("core.auditlog")
 
+// logFileName is the name of the current audit log file. Rotated
+// files are named after it by lumberjack, with a timestamp added.
+const logFileName = "audit.log"
+
 // Conversation represents a high-level juju command from the juju
 // client (or other client). There'll be one Conversation per API
 // connection from the client, with zero or more associated
 // Request/ResponseErrors pairs.
 type Conversation struct {
-	Who            string `json:"who"`        // username@idm
-	What           string `json:"what"`       // "juju deploy ./foo/bar"
-	When           string `json:"when"`       // ISO 8601 to second precision
-	ModelName      string `json:"model-name"` // full representation "user/name"
-	ModelUUID      string `json:"model-uuid"`
-	ConversationID string `json:"conversation-id"` // uint64 in hex
-	ConnectionID   string `json:"connection-id"`   // uint64 in hex (using %X to match the value in log files)
+	Who            string `json:"who" yaml:"who"`               // username@idm
+	What           string `json:"what" yaml:"what"`             // "juju deploy ./foo/bar"
+	When           string `json:"when" yaml:"when"`             // ISO 8601 to second precision
+	ModelName      string `json:"model-name" yaml:"model-name"` // full representation "user/name"
+	ModelUUID      string `json:"model-uuid" yaml:"model-uuid"`
+	ConversationID string `json:"conversation-id" yaml:"conversation-id"` // uint64 in hex
+	ConnectionID   string `json:"connection-id" yaml:"connection-id"`     // uint64 in hex (using %X to match the value in log files)
+	Sequence       uint64 `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	Hash           string `json:"hash,omitempty" yaml:"hash,omitempty"`
 }
 
 // ConversationArgs is the information needed to create a method recorder.
@@ -48,14 +55,16 @@ type ConversationArgs struct {
 // Request represents a call to an API facade made as part of
 // a specific conversation.
 type Request struct {
-	ConversationID string `json:"conversation-id"`
-	ConnectionID   string `json:"connection-id"`
-	RequestID      uint64 `json:"request-id"`
-	When           string `json:"when"`
-	Facade         string `json:"facade"`
-	Method         string `json:"method"`
-	Version        int    `json:"version"`
-	Args           string `json:"args,omitempty"`
+	ConversationID string `json:"conversation-id" yaml:"conversation-id"`
+	ConnectionID   string `json:"connection-id" yaml:"connection-id"`
+	RequestID      uint64 `json:"request-id" yaml:"request-id"`
+	When           string `json:"when" yaml:"when"`
+	Facade         string `json:"facade" yaml:"facade"`
+	Method         string `json:"method" yaml:"method"`
+	Version        int    `json:"version" yaml:"version"`
+	Args           string `json:"args,omitempty" yaml:"args,omitempty"`
+	Sequence       uint64 `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	Hash           string `json:"hash,omitempty" yaml:"hash,omitempty"`
 }
 
 // RequestArgs is the information about an API call that we want to
@@ -71,11 +80,13 @@ type RequestArgs struct {
 // ResponseErrors captures any errors coming back from the API in
 // response to a request.
 type ResponseErrors struct {
-	ConversationID string   `json:"conversation-id"`
-	ConnectionID   string   `json:"connection-id"`
-	RequestID      uint64   `json:"request-id"`
-	When           string   `json:"when"`
-	Errors         []*Error `json:"errors"`
+	ConversationID string   `json:"conversation-id" yaml:"conversation-id"`
+	ConnectionID   string   `json:"connection-id" yaml:"connection-id"`
+	RequestID      uint64   `json:"request-id" yaml:"request-id"`
+	When           string   `json:"when" yaml:"when"`
+	Errors         []*Error `json:"errors" yaml:"errors"`
+	Sequence       uint64   `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	Hash           string   `json:"hash,omitempty" yaml:"hash,omitempty"`
 }
 
 // ResponseErrorsArgs has errors from an API response to record in the
@@ -87,16 +98,20 @@ type ResponseErrorsArgs struct {
 
 // Error holds the details of an error sent back from the API.
 type Error struct {
-	Message string `json:"message"`
-	Code    string `json:"code"`
+	Message string `json:"message" yaml:"message"`
+	Code    string `json:"code" yaml:"code"`
 }
 
 // Record is the top-level entry type in an audit log, which serves as
 // a type discriminator. Only one of Conversation/Request/Errors should be set.
+//
+// Each record written to an audit log file holds a sequence number,
+// counting the records written by the controller, and a hash chaining
+// it to the record before it; see Verify.
 type Record struct {
-	Conversation *Conversation   `json:"conversation,omitempty"`
-	Request      *Request        `json:"request,omitempty"`
-	Errors       *ResponseErrors `json:"errors,omitempty"`
+	Conversation *Conversation   `json:"conversation,omitempty" yaml:"conversation,omitempty"`
+	Request      *Request        `json:"request,omitempty" yaml:"request,omitempty"`
+	Errors       *ResponseErrors `json:"errors,omitempty" yaml:"errors,omitempty"`
 }
 
 // AuditLog represents something that can store calls, requests and
@@ -181,6 +196,13 @@ func newConversationID() string {
 
 type auditLogFile struct {
 	fileLogger io.WriteCloser
+
+	// mu guards the chain, which must be extended in the order the
+	// records are written. Records are only chained when the end of
+	// the chain written before could be read.
+	mu      sync.Mutex
+	chain   chainLink
+	chained bool
 }
 
 // NewLogFile returns an audit entry sink which writes to an audit.log
@@ -189,7 +211,7 @@ type auditLogFile struct {
 // the maximum number of old compressed log files to keep (or 0 to
 // keep all of them).
 func NewLogFile(logDir string, maxSize, maxBackups int) AuditLog {
-	logPath := filepath.Join(logDir, "audit.log")
+	logPath := filepath.Join(logDir, logFileName)
 	if err := paths.PrimeLogFile(logPath); err != nil {
 		// This isn't a fatal error so log and continue if priming
 		// fails.
@@ -204,8 +226,20 @@ func NewLogFile(logDir string, maxSize, maxBackups int) AuditLog {
 	}
 	logger.Debugf("created rotating log file %q with max size %d MB and max backups %d",
 		ljLogger.Filename, ljLogger.MaxSize, ljLogger.MaxBackups)
+
+	// Carry on the chain of records written before the controller
+	// was restarted.
+	chain, err := lastChainLink(logDir)
+	if err != nil {
+		// Starting a new chain would look like records had been
+		// removed, so carry on logging without chaining the records,
+		// which is reported when the log is verified.
+		logger.Errorf("cannot read the last audit log record (not chaining records): %v", err)
+	}
 	return &auditLogFile{
 		fileLogger: ljLogger,
+		chain:      chain,
+		chained:    err == nil,
 	}
 }
 
@@ -231,6 +265,16 @@ func (a *auditLogFile) Close() error {
 }
 
 func (a *auditLogFile) addRecord(r Record) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	link := a.chain
+	if a.chained {
+		var err error
+		if link, err = a.chain.extend(r); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	bytes, err := json.Marshal(r)
 	if err != nil {
 		return errors.Trace(err)
@@ -238,8 +282,11 @@ func (a *auditLogFile) addRecord(r Record) error {
 	// Add a linebreak to bytes rather than doing two calls to write
 	// just in case lumberjack rolls the file between them.
 	bytes = append(bytes, byte('\n'))
-	_, err = a.fileLogger.Write(bytes)
-	return errors.Trace(err)
+	if _, err = a.fileLogger.Write(bytes); err != nil {
+		return errors.Trace(err)
+	}
+	a.chain = link
+	return nil
 }
 
 func idString(id uint64) string {
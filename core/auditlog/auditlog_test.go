@@ -12,6 +12,7 @@ import (
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/paths"
@@ -141,6 +142,31 @@ func (s *AuditLogSuite) TestRecorder(c *gc.C) {
 	})
 }
 
+func (s *AuditLogSuite) TestRecordYAML(c *gc.C) {
+	out, err := yaml.Marshal(auditlog.Record{Errors: &auditlog.ResponseErrors{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      246,
+		When:           "2017-12-01T14:13:45Z",
+		Errors:         []*auditlog.Error{{Message: "oops", Code: "bad request"}},
+		Sequence:       3,
+		Hash:           "abc123",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+errors:
+  conversation-id: 0123456789abcdef
+  connection-id: AC1
+  request-id: 246
+  when: "2017-12-01T14:13:45Z"
+  errors:
+  - message: oops
+    code: bad request
+  sequence: 3
+  hash: abc123
+`[1:])
+}
+
 type fakeLog struct {
 	stub testing.Stub
 }
@@ -167,8 +193,8 @@ func (l *fakeLog) Close() error {
 
 var (
 	expectedLogContents = `
-{"conversation":{"who":"deerhoof","what":"gojira","when":"2017-11-27T13:21:24Z","model-name":"admin/default","model-uuid":"","conversation-id":"0123456789abcdef","connection-id":"AC1"}}
-{"request":{"conversation-id":"0123456789abcdef","connection-id":"AC1","request-id":25,"when":"2017-12-12T11:34:56Z","facade":"Application","method":"Deploy","version":4,"args":"{\"applications\": [{\"application\": \"prometheus\"}]}"}}
-{"errors":{"conversation-id":"0123456789abcdef","connection-id":"AC1","request-id":25,"when":"2017-12-12T11:35:11Z","errors":[{"message":"oops","code":"unauthorized access"}]}}
+{"conversation":{"who":"deerhoof","what":"gojira","when":"2017-11-27T13:21:24Z","model-name":"admin/default","model-uuid":"","conversation-id":"0123456789abcdef","connection-id":"AC1","sequence":1,"hash":"5dbc8fb8be695288aea4b35225a04ca1f4332d8ab26910d0181ac5364ebd0c05"}}
+{"request":{"conversation-id":"0123456789abcdef","connection-id":"AC1","request-id":25,"when":"2017-12-12T11:34:56Z","facade":"Application","method":"Deploy","version":4,"args":"{\"applications\": [{\"application\": \"prometheus\"}]}","sequence":2,"hash":"3a277d73932a379e9d1d792c17811b3f724aae86b5058148749b572d8dd159d6"}}
+{"errors":{"conversation-id":"0123456789abcdef","connection-id":"AC1","request-id":25,"when":"2017-12-12T11:35:11Z","errors":[{"message":"oops","code":"unauthorized access"}],"sequence":3,"hash":"57d7075397e6e045aa036aebd5b0f881ee6a42110033aec1d54b65dfac7d3503"}}
 `[1:]
 )
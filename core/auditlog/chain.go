@@ -0,0 +1,349 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// The records written by a controller are chained together, so that
+// accidental changes to the audit log, and edits that don't rewrite
+// the chain, can be detected. Each record holds a sequence number, one
+// more than that of the record before it, and a hash: the SHA-256 of
+// the hash of the record before it followed by the JSON encoding of
+// the record without its own hash. The first record written has
+// sequence number 1 and is chained to an empty hash.
+//
+// The hash isn't keyed, so anyone able to write the audit log can
+// change a record and recompute the hashes of the records after it.
+// Forwarding the audit log to another host keeps a copy out of their
+// reach.
+
+// chainLink is the sequence number and hash of the last record of a
+// chain.
+type chainLink struct {
+	sequence uint64
+	hash     string
+}
+
+// extend sets the sequence number and hash of the record to follow
+// the link, and returns the link to the record.
+func (l chainLink) extend(r Record) (chainLink, error) {
+	sequence, hash := r.chainFields()
+	if sequence == nil {
+		return l, errors.NotValidf("empty audit log record")
+	}
+	*sequence = l.sequence + 1
+	*hash = ""
+	h, err := hashRecord(l.hash, r)
+	if err != nil {
+		return l, errors.Trace(err)
+	}
+	*hash = h
+	return chainLink{sequence: *sequence, hash: h}, nil
+}
+
+// chainFields returns the sequence number and hash of the record, or
+// nils if the record is empty.
+func (r Record) chainFields() (*uint64, *string) {
+	switch {
+	case r.Conversation != nil:
+		return &r.Conversation.Sequence, &r.Conversation.Hash
+	case r.Request != nil:
+		return &r.Request.Sequence, &r.Request.Hash
+	case r.Errors != nil:
+		return &r.Errors.Sequence, &r.Errors.Hash
+	}
+	return nil, nil
+}
+
+// hashRecord returns the hash chaining the record, whose own hash must
+// be empty, to the record with the previous hash.
+func hashRecord(previous string, r Record) (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	h := sha256.New()
+	h.Write([]byte(previous))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// parseChainLink returns the link to the chained record held in the
+// line, if there is one.
+func parseChainLink(line []byte) (chainLink, bool) {
+	var record Record
+	if err := json.Unmarshal(line, &record); err != nil {
+		return chainLink{}, false
+	}
+	sequence, hash := record.chainFields()
+	if sequence == nil || *sequence == 0 {
+		return chainLink{}, false
+	}
+	return chainLink{sequence: *sequence, hash: *hash}, true
+}
+
+// lastChainLink returns the link to the last chained record written to
+// the audit log in logDir, or an empty link if there are none.
+func lastChainLink(logDir string) (chainLink, error) {
+	fileNames, err := logFileNames(logDir)
+	if err != nil {
+		return chainLink{}, errors.Trace(err)
+	}
+	for i := len(fileNames) - 1; i >= 0; i-- {
+		link, found, err := lastLinkInFile(fileNames[i])
+		if err != nil {
+			return chainLink{}, errors.Annotatef(err, "reading %s", filepath.Base(fileNames[i]))
+		}
+		if found {
+			return link, nil
+		}
+	}
+	return chainLink{}, nil
+}
+
+// tailSize is the amount read from the end of the current audit log
+// file at first when looking for its last record.
+const tailSize = 64 * 1024
+
+// lastLinkInFile returns the link to the last chained record in the
+// file. Only the end of the current file is read where possible, as it
+// can be large; rotated files are compressed so must be read in full.
+func lastLinkInFile(fileName string) (chainLink, bool, error) {
+	if strings.HasSuffix(fileName, ".gz") {
+		var (
+			link  chainLink
+			found bool
+		)
+		err := readLines(fileName, func(line []byte) error {
+			if l, ok := parseChainLink(line); ok {
+				link, found = l, true
+			}
+			return nil
+		})
+		return link, found, errors.Trace(err)
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return chainLink{}, false, errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return chainLink{}, false, errors.Trace(err)
+	}
+	size := info.Size()
+	for tail := int64(tailSize); ; tail *= 4 {
+		offset := size - tail
+		if offset < 0 {
+			offset = 0
+		}
+		buf := make([]byte, size-offset)
+		if _, err := f.ReadAt(buf, offset); err != nil && err != io.EOF {
+			return chainLink{}, false, errors.Trace(err)
+		}
+		lines := bytes.Split(buf, []byte("\n"))
+		if offset > 0 {
+			// The first line read is likely to be partial.
+			lines = lines[1:]
+		}
+		for i := len(lines) - 1; i >= 0; i-- {
+			if link, ok := parseChainLink(lines[i]); ok {
+				return link, true, nil
+			}
+		}
+		if offset == 0 {
+			return chainLink{}, false, nil
+		}
+	}
+}
+
+// VerifyResult holds the result of verifying the chain of records in
+// an audit log.
+type VerifyResult struct {
+	// Files holds the names of the files read, oldest first.
+	Files []string
+
+	// Records is the number of records read.
+	Records int
+
+	// Unchained is the number of records written before the records
+	// were chained, by an earlier version of juju.
+	Unchained int
+
+	// FirstSequence and LastSequence are the sequence numbers of the
+	// first and last chained records read. Records removed from the
+	// end of the audit log cannot be detected, so LastSequence should
+	// be checked against the records expected.
+	FirstSequence uint64
+	LastSequence  uint64
+
+	// Warnings holds the findings which do not show that the audit
+	// log has been tampered with.
+	Warnings []string
+
+	// Problems holds the gaps in the chain, and the records which
+	// have been modified.
+	Problems []string
+}
+
+// OK returns whether the chain of records is intact.
+func (r *VerifyResult) OK() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *VerifyResult) problemf(format string, args ...interface{}) {
+	r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+}
+
+func (r *VerifyResult) warningf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Verify verifies the chain of records in the audit log in logDir,
+// including any rotated files.
+func Verify(logDir string) (*VerifyResult, error) {
+	fileNames, err := logFileNames(logDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(fileNames) == 0 {
+		return nil, errors.NotFoundf("audit log in %q", logDir)
+	}
+	return VerifyFiles(fileNames)
+}
+
+// VerifyFiles verifies the chain of records in the audit log files,
+// which must be given in the order they were written.
+func VerifyFiles(fileNames []string) (*VerifyResult, error) {
+	v := &verifier{result: &VerifyResult{}}
+	for _, fileName := range fileNames {
+		v.file = filepath.Base(fileName)
+		v.line = 0
+		v.result.Files = append(v.result.Files, v.file)
+		if err := readLines(fileName, v.addLine); err != nil {
+			return nil, errors.Annotatef(err, "reading %s", v.file)
+		}
+	}
+
+	result := v.result
+	if result.Unchained > 0 {
+		result.warningf("%d records were written before records were chained", result.Unchained)
+	}
+	if result.FirstSequence > 1 {
+		result.warningf("records before %d are not present, and may have been removed by log rotation",
+			result.FirstSequence)
+	}
+	return result, nil
+}
+
+type verifier struct {
+	result *VerifyResult
+	file   string
+	line   int
+
+	// link is the link to the last chained record read.
+	link    chainLink
+	chained bool
+}
+
+func (v *verifier) addLine(line []byte) error {
+	v.line++
+	if len(bytes.TrimSpace(line)) == 0 {
+		return nil
+	}
+	v.result.Records++
+
+	var record Record
+	if err := json.Unmarshal(line, &record); err != nil {
+		v.problemf("record cannot be read: %v", err)
+		return nil
+	}
+	sequence, hash := record.chainFields()
+	if sequence == nil {
+		v.problemf("record is empty")
+		return nil
+	}
+	if *sequence == 0 {
+		if v.chained {
+			v.problemf("record has no sequence number")
+		} else {
+			v.result.Unchained++
+		}
+		return nil
+	}
+
+	link := chainLink{sequence: *sequence, hash: *hash}
+	modified, err := v.modified(line, record)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		v.link = link
+		v.chained = true
+		v.result.LastSequence = link.sequence
+	}()
+
+	if modified {
+		// The record holds something not covered by its hash.
+		v.problemf("record %d has been modified", link.sequence)
+		return nil
+	}
+
+	// The hash of a record can only be checked against the record
+	// before it.
+	switch {
+	case !v.chained:
+		v.result.FirstSequence = link.sequence
+		if link.sequence > 1 {
+			return nil
+		}
+	case link.sequence == v.link.sequence+1:
+	case link.sequence == v.link.sequence+2:
+		v.problemf("record %d is missing", v.link.sequence+1)
+		return nil
+	case link.sequence > v.link.sequence:
+		v.problemf("records %d to %d are missing", v.link.sequence+1, link.sequence-1)
+		return nil
+	default:
+		v.problemf("record %d follows record %d", link.sequence, v.link.sequence)
+		return nil
+	}
+
+	*hash = ""
+	expected, err := hashRecord(v.link.hash, record)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if expected != link.hash {
+		v.problemf("record %d does not match its hash; it or the record before it has been modified", link.sequence)
+	}
+	return nil
+}
+
+// modified returns whether the line differs from the encoding of the
+// record read from it, as written.
+func (v *verifier) modified(line []byte, record Record) (bool, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return !bytes.Equal(bytes.TrimRight(line, "\r\n"), data), nil
+}
+
+func (v *verifier) problemf(format string, args ...interface{}) {
+	v.result.problemf("%s line %d: %s", v.file, v.line, fmt.Sprintf(format, args...))
+}
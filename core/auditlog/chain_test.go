@@ -0,0 +1,259 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type ChainSuite struct {
+	testing.IsolationSuite
+
+	dir string
+}
+
+var _ = gc.Suite(&ChainSuite{})
+
+func (s *ChainSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+}
+
+// writeRequests writes a conversation holding n requests and their
+// responses to the audit log, as 2n+1 records.
+func (s *ChainSuite) writeRequests(c *gc.C, conversationID string, n int, args string) {
+	logFile := auditlog.NewLogFile(s.dir, 300, 10)
+	c.Assert(logFile.AddConversation(auditlog.Conversation{
+		Who: "bob", What: "juju status", When: "2025-03-01T10:00:00Z",
+		ModelName: "default", ConversationID: conversationID, ConnectionID: "A1",
+	}), jc.ErrorIsNil)
+	for i := 1; i <= n; i++ {
+		c.Assert(logFile.AddRequest(auditlog.Request{
+			ConversationID: conversationID, ConnectionID: "A1", RequestID: uint64(i),
+			When: "2025-03-01T10:00:01Z", Facade: "Client", Method: "FullStatus", Version: 8,
+			Args: args,
+		}), jc.ErrorIsNil)
+		c.Assert(logFile.AddResponse(auditlog.ResponseErrors{
+			ConversationID: conversationID, ConnectionID: "A1", RequestID: uint64(i),
+			When: "2025-03-01T10:00:02Z",
+		}), jc.ErrorIsNil)
+	}
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+}
+
+func (s *ChainSuite) readLines(c *gc.C) []string {
+	data, err := os.ReadFile(filepath.Join(s.dir, "audit.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.SplitAfter(string(data), "\n")
+	return lines[:len(lines)-1]
+}
+
+func (s *ChainSuite) writeLines(c *gc.C, lines []string) {
+	err := os.WriteFile(filepath.Join(s.dir, "audit.log"), []byte(strings.Join(lines, "")), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// rotate compresses the current audit log file, as lumberjack does.
+func (s *ChainSuite) rotate(c *gc.C, timestamp string) {
+	data, err := os.ReadFile(filepath.Join(s.dir, "audit.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	f, err := os.Create(filepath.Join(s.dir, fmt.Sprintf("audit-%s.log.gz", timestamp)))
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	gzw := gzip.NewWriter(f)
+	_, err = gzw.Write(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+	c.Assert(os.Remove(filepath.Join(s.dir, "audit.log")), jc.ErrorIsNil)
+}
+
+func (s *ChainSuite) verify(c *gc.C) *auditlog.VerifyResult {
+	result, err := auditlog.Verify(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
+func (s *ChainSuite) TestVerify(c *gc.C) {
+	s.writeRequests(c, "c1", 2, "")
+
+	result := s.verify(c)
+	c.Check(result.OK(), jc.IsTrue)
+	c.Check(result, jc.DeepEquals, &auditlog.VerifyResult{
+		Files:         []string{"audit.log"},
+		Records:       5,
+		FirstSequence: 1,
+		LastSequence:  5,
+	})
+}
+
+func (s *ChainSuite) TestChainContinuesAfterRestart(c *gc.C) {
+	s.writeRequests(c, "c1", 1, "")
+	s.writeRequests(c, "c2", 1, "")
+
+	result := s.verify(c)
+	c.Check(result.Problems, gc.HasLen, 0)
+	c.Check(result.Records, gc.Equals, 6)
+	c.Check(result.LastSequence, gc.Equals, uint64(6))
+}
+
+func (s *ChainSuite) TestChainContinuesAfterRestartWithLongRecords(c *gc.C) {
+	// The records are longer than the end of the file first read to
+	// find the last record.
+	args := strings.Repeat("x", 100*1024)
+	s.writeRequests(c, "c1", 1, args)
+	s.writeRequests(c, "c2", 1, args)
+
+	result := s.verify(c)
+	c.Check(result.Problems, gc.HasLen, 0)
+	c.Check(result.LastSequence, gc.Equals, uint64(6))
+}
+
+func (s *ChainSuite) TestChainContinuesAcrossRotation(c *gc.C) {
+	s.writeRequests(c, "c1", 1, "")
+	s.rotate(c, "2025-03-01T10-00-00.000")
+	s.writeRequests(c, "c2", 1, "")
+
+	result := s.verify(c)
+	c.Check(result.Problems, gc.HasLen, 0)
+	c.Check(result.Files, jc.DeepEquals, []string{"audit-2025-03-01T10-00-00.000.log.gz", "audit.log"})
+	c.Check(result.LastSequence, gc.Equals, uint64(6))
+}
+
+func (s *ChainSuite) TestChainNotRestartedWhenUnreadable(c *gc.C) {
+	s.writeRequests(c, "c1", 1, "")
+	s.rotate(c, "2025-03-01T10-00-00.000")
+	err := os.WriteFile(filepath.Join(s.dir, "audit-2025-03-01T10-00-00.000.log.gz"), []byte("garbage"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.writeRequests(c, "c2", 1, "")
+
+	// The records can't be chained to the records before them, so
+	// they aren't chained at all, rather than starting at 1 again.
+	result, err := auditlog.VerifyFiles([]string{filepath.Join(s.dir, "audit.log")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Records, gc.Equals, 3)
+	c.Check(result.Unchained, gc.Equals, 3)
+	c.Check(result.LastSequence, gc.Equals, uint64(0))
+}
+
+func (s *ChainSuite) TestRotatedRecordsRemoved(c *gc.C) {
+	s.writeRequests(c, "c1", 1, "")
+	s.rotate(c, "2025-03-01T10-00-00.000")
+	s.writeRequests(c, "c2", 1, "")
+	err := os.Remove(filepath.Join(s.dir, "audit-2025-03-01T10-00-00.000.log.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := s.verify(c)
+	c.Check(result.OK(), jc.IsTrue)
+	c.Check(result.FirstSequence, gc.Equals, uint64(4))
+	c.Check(result.Warnings, jc.DeepEquals, []string{
+		"records before 4 are not present, and may have been removed by log rotation",
+	})
+}
+
+func (s *ChainSuite) TestModifiedRecord(c *gc.C) {
+	s.writeRequests(c, "c1", 2, "")
+	lines := s.readLines(c)
+	lines[2] = strings.Replace(lines[2], `"request-id":1`, `"request-id":7`, 1)
+	s.writeLines(c, lines)
+
+	result := s.verify(c)
+	c.Check(result.OK(), jc.IsFalse)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"audit.log line 3: record 3 does not match its hash; it or the record before it has been modified",
+	})
+}
+
+func (s *ChainSuite) TestAddedField(c *gc.C) {
+	s.writeRequests(c, "c1", 1, "")
+	lines := s.readLines(c)
+	lines[0] = strings.Replace(lines[0], `"who":"bob"`, `"who":"bob","extra":"x"`, 1)
+	s.writeLines(c, lines)
+
+	result := s.verify(c)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"audit.log line 1: record 1 has been modified",
+	})
+}
+
+func (s *ChainSuite) TestRemovedRecords(c *gc.C) {
+	s.writeRequests(c, "c1", 3, "")
+	lines := s.readLines(c)
+	lines = append(lines[:1], lines[2:]...)
+	lines = append(lines[:3], lines[5:]...)
+	s.writeLines(c, lines)
+
+	result := s.verify(c)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"audit.log line 2: record 2 is missing",
+		"audit.log line 4: records 5 to 6 are missing",
+	})
+	c.Check(result.LastSequence, gc.Equals, uint64(7))
+}
+
+func (s *ChainSuite) TestReorderedRecords(c *gc.C) {
+	s.writeRequests(c, "c1", 1, "")
+	lines := s.readLines(c)
+	lines[1], lines[2] = lines[2], lines[1]
+	s.writeLines(c, lines)
+
+	result := s.verify(c)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"audit.log line 2: record 2 is missing",
+		"audit.log line 3: record 2 follows record 3",
+	})
+}
+
+func (s *ChainSuite) TestUnreadableRecord(c *gc.C) {
+	s.writeRequests(c, "c1", 1, "")
+	lines := s.readLines(c)
+	lines[1] = "not json\n"
+	s.writeLines(c, lines)
+
+	result := s.verify(c)
+	c.Assert(result.Problems, gc.HasLen, 2)
+	c.Check(result.Problems[0], gc.Matches, "audit.log line 2: record cannot be read: .*")
+	c.Check(result.Problems[1], gc.Equals, "audit.log line 3: record 2 is missing")
+}
+
+func (s *ChainSuite) TestUnchainedRecords(c *gc.C) {
+	s.writeLines(c, []string{
+		`{"conversation":{"who":"bob","conversation-id":"c0","connection-id":"A0"}}` + "\n",
+	})
+	s.writeRequests(c, "c1", 1, "")
+
+	result := s.verify(c)
+	c.Check(result.OK(), jc.IsTrue)
+	c.Check(result.Unchained, gc.Equals, 1)
+	c.Check(result.Warnings, jc.DeepEquals, []string{
+		"1 records were written before records were chained",
+	})
+}
+
+func (s *ChainSuite) TestUnchainedRecordInChain(c *gc.C) {
+	s.writeRequests(c, "c1", 1, "")
+	lines := s.readLines(c)
+	lines = append(lines, `{"conversation":{"who":"eve","conversation-id":"c0","connection-id":"A0"}}`+"\n")
+	s.writeLines(c, lines)
+
+	result := s.verify(c)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"audit.log line 4: record has no sequence number",
+	})
+}
+
+func (s *ChainSuite) TestVerifyNoAuditLog(c *gc.C) {
+	_, err := auditlog.Verify(s.dir)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
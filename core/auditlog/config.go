@@ -31,6 +31,35 @@ type Config struct {
 
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
+
+	// Forward holds the target audit log records are forwarded to
+	// once written, if any.
+	Forward ForwardConfig
+}
+
+// ForwardConfig holds the target audit log records are forwarded to.
+type ForwardConfig struct {
+	// Type is the type of the target, either "syslog" or "webhook",
+	// or empty if records aren't forwarded.
+	Type string
+
+	// Endpoint is the host[:port] of a syslog server, or the URL of a
+	// webhook.
+	Endpoint string
+
+	// CACert, ClientCert and ClientKey hold the TLS certificates
+	// (x.509, PEM-encoded) used to connect to the target.
+	CACert     string
+	ClientCert string
+	ClientKey  string
+
+	// Headers holds extra HTTP headers sent to a webhook.
+	Headers map[string]string
+}
+
+// Enabled returns whether records are forwarded.
+func (cfg ForwardConfig) Enabled() bool {
+	return cfg.Type != ""
 }
 
 // Validate checks the audit logging configuration.
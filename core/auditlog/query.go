@@ -0,0 +1,360 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// ResultSuccess selects requests which were answered without
+	// errors.
+	ResultSuccess = "success"
+
+	// ResultError selects requests whose responses held errors.
+	ResultError = "error"
+
+	// MaxQueryLimit is the maximum number of entries returned by a
+	// query, so that a query never holds a whole audit log in memory.
+	MaxQueryLimit = 10000
+)
+
+// Filter selects the entries returned by Query. Zero values match
+// every entry.
+type Filter struct {
+	// Users holds the names of the users whose requests are wanted.
+	Users []string `yaml:"users,omitempty"`
+
+	// Models holds the names or UUIDs of the models whose requests
+	// are wanted.
+	Models []string `yaml:"models,omitempty"`
+
+	// ConversationID selects the requests of a single conversation.
+	ConversationID string `yaml:"conversation-id,omitempty"`
+
+	// Facade and Method select requests by the API method called.
+	// Both are compared case insensitively.
+	Facade string `yaml:"facade,omitempty"`
+	Method string `yaml:"method,omitempty"`
+
+	// From and To select requests made at or after From and before
+	// To. Either may be zero to leave the range open.
+	From time.Time `yaml:"from,omitempty"`
+	To   time.Time `yaml:"to,omitempty"`
+
+	// Result selects requests by their outcome, either ResultSuccess
+	// or ResultError.
+	Result string `yaml:"result,omitempty"`
+
+	// Limit is the maximum number of entries to return; the most
+	// recent entries are kept. Zero means MaxQueryLimit, which Limit
+	// may not exceed.
+	Limit int `yaml:"limit,omitempty"`
+}
+
+// EffectiveLimit returns the maximum number of entries the filter
+// selects.
+func (f Filter) EffectiveLimit() int {
+	if f.Limit == 0 {
+		return MaxQueryLimit
+	}
+	return f.Limit
+}
+
+// Validate checks the filter.
+func (f Filter) Validate() error {
+	switch f.Result {
+	case "", ResultSuccess, ResultError:
+	default:
+		return errors.NotValidf("result %q", f.Result)
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && f.To.Before(f.From) {
+		return errors.NotValidf("time range ending before it starts")
+	}
+	if f.Limit < 0 {
+		return errors.NotValidf("negative limit")
+	}
+	if f.Limit > MaxQueryLimit {
+		return errors.NotValidf("limit %d greater than %d", f.Limit, MaxQueryLimit)
+	}
+	return nil
+}
+
+// Entry is a request read from an audit log, along with the
+// conversation it was made in and the errors in its response.
+type Entry struct {
+	Conversation Conversation `yaml:"conversation"`
+	Request      Request      `yaml:"request"`
+	Errors       []*Error     `yaml:"errors,omitempty"`
+
+	// Responded is false if the response to the request has not
+	// been recorded.
+	Responded bool `yaml:"responded"`
+}
+
+// Failed returns whether the response to the request held errors.
+func (e Entry) Failed() bool {
+	return len(e.Errors) > 0
+}
+
+// Query reads the audit log in logDir, including any rotated files,
+// and returns the entries matching the filter, oldest first. There
+// are no entries if auditing has never been enabled.
+func Query(logDir string, filter Filter) ([]Entry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	fileNames, err := logFileNames(logDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	q := &query{
+		filter:        filter,
+		conversations: make(map[string]Conversation),
+		pending:       make(map[requestKey]*Entry),
+	}
+	for _, fileName := range fileNames {
+		if err := q.readFile(fileName); err != nil {
+			return nil, errors.Annotatef(err, "reading %s", filepath.Base(fileName))
+		}
+	}
+	return q.results(), nil
+}
+
+// logFileNames returns the paths of the audit log files in logDir, in
+// the order they were written: the rotated files by the timestamps in
+// their names, then the current file.
+func logFileNames(logDir string) ([]string, error) {
+	ext := filepath.Ext(logFileName)
+	prefix := strings.TrimSuffix(logFileName, ext) + "-"
+	rotated, err := filepath.Glob(filepath.Join(logDir, prefix+"*"+ext+"*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(rotated)
+	current := filepath.Join(logDir, logFileName)
+	if _, err := os.Stat(current); err == nil {
+		return append(rotated, current), nil
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Trace(err)
+	}
+	return rotated, nil
+}
+
+type requestKey struct {
+	conversationID string
+	requestID      uint64
+}
+
+type query struct {
+	filter        Filter
+	conversations map[string]Conversation
+	pending       map[requestKey]*Entry
+	entries       []*Entry
+}
+
+func (q *query) readFile(fileName string) error {
+	return errors.Trace(readLines(fileName, func(line []byte) error {
+		q.addLine(line)
+		return nil
+	}))
+}
+
+// readLines calls f with each line of the audit log file, which is
+// decompressed if it has been rotated.
+func readLines(fileName string, f func(line []byte) error) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var r io.Reader = file
+	if strings.HasSuffix(fileName, ".gz") {
+		gzr, err := gzip.NewReader(file)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer func() { _ = gzr.Close() }()
+		r = gzr
+	}
+
+	// Captured API arguments make for long lines, so read whole
+	// lines rather than scanning with a fixed buffer.
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if err := f(line); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func (q *query) addLine(line []byte) {
+	var record Record
+	if err := json.Unmarshal(line, &record); err != nil {
+		// The last line of a file may be incomplete if the
+		// controller stopped while writing it.
+		logger.Debugf("skipping unreadable audit log record: %v", err)
+		return
+	}
+	switch {
+	case record.Conversation != nil:
+		q.conversations[record.Conversation.ConversationID] = *record.Conversation
+	case record.Request != nil:
+		q.addRequest(*record.Request)
+	case record.Errors != nil:
+		q.addResponse(*record.Errors)
+	}
+}
+
+func (q *query) addRequest(r Request) {
+	conversation, ok := q.conversations[r.ConversationID]
+	if !ok {
+		// The conversation was written to a file which has since
+		// been removed.
+		conversation = Conversation{
+			ConversationID: r.ConversationID,
+			ConnectionID:   r.ConnectionID,
+		}
+	}
+	entry := &Entry{
+		Conversation: conversation,
+		Request:      r,
+	}
+	q.pending[requestKey{r.ConversationID, r.RequestID}] = entry
+	if q.matches(entry) {
+		q.entries = append(q.entries, entry)
+		if len(q.entries) >= 2*q.filter.EffectiveLimit() {
+			q.trim()
+		}
+	}
+}
+
+// trim drops the entries which can no longer be returned: those older
+// than the most recent entries already known to be selected, and those
+// whose result isn't wanted.
+func (q *query) trim() {
+	limit := q.filter.EffectiveLimit()
+	start, selected := 0, 0
+	for i := len(q.entries) - 1; i >= 0; i-- {
+		if q.selected(q.entries[i]) {
+			selected++
+		}
+		if selected == limit {
+			start = i
+			break
+		}
+	}
+	entries := make([]*Entry, 0, len(q.entries)-start)
+	for _, entry := range q.entries[start:] {
+		if entry.Responded && !q.selected(entry) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	q.entries = entries
+}
+
+func (q *query) addResponse(r ResponseErrors) {
+	key := requestKey{r.ConversationID, r.RequestID}
+	entry, ok := q.pending[key]
+	if !ok {
+		return
+	}
+	delete(q.pending, key)
+	entry.Errors = r.Errors
+	entry.Responded = true
+}
+
+// matches reports whether the entry is selected by the filter,
+// without considering the result of the request, which may not
+// have been read yet.
+func (q *query) matches(entry *Entry) bool {
+	f := q.filter
+	if len(f.Users) > 0 && !contains(f.Users, entry.Conversation.Who) {
+		return false
+	}
+	if len(f.Models) > 0 &&
+		!contains(f.Models, entry.Conversation.ModelName) &&
+		!contains(f.Models, entry.Conversation.ModelUUID) {
+		return false
+	}
+	if f.ConversationID != "" && f.ConversationID != entry.Request.ConversationID {
+		return false
+	}
+	if f.Facade != "" && !strings.EqualFold(f.Facade, entry.Request.Facade) {
+		return false
+	}
+	if f.Method != "" && !strings.EqualFold(f.Method, entry.Request.Method) {
+		return false
+	}
+	if f.From.IsZero() && f.To.IsZero() {
+		return true
+	}
+	when, err := time.Parse(time.RFC3339, entry.Request.When)
+	if err != nil {
+		return false
+	}
+	if !f.From.IsZero() && when.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !when.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+// selected reports whether the result of the entry, as read so far,
+// is selected by the filter.
+func (q *query) selected(entry *Entry) bool {
+	switch q.filter.Result {
+	case ResultSuccess:
+		return entry.Responded && !entry.Failed()
+	case ResultError:
+		return entry.Failed()
+	}
+	return true
+}
+
+// results returns the entries matching the filter result, limited to
+// the most recent.
+func (q *query) results() []Entry {
+	var results []Entry
+	for _, entry := range q.entries {
+		if q.selected(entry) {
+			results = append(results, *entry)
+		}
+	}
+	if limit := q.filter.EffectiveLimit(); len(results) > limit {
+		results = results[len(results)-limit:]
+	}
+	return results
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
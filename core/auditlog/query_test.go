@@ -0,0 +1,200 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type QuerySuite struct {
+	testing.IsolationSuite
+
+	dir string
+}
+
+var _ = gc.Suite(&QuerySuite{})
+
+func (s *QuerySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+
+	// The first conversation was rotated out into a compressed file.
+	writeRotated(c, filepath.Join(s.dir, "audit-2025-03-01T10-00-00.000.log.gz"), []auditlog.Record{
+		{Conversation: &auditlog.Conversation{
+			Who: "bob", What: "juju deploy mysql", When: "2025-03-01T09:59:00Z",
+			ModelName: "default", ModelUUID: "deadbeef", ConversationID: "c1", ConnectionID: "A1",
+		}},
+		{Request: &auditlog.Request{
+			ConversationID: "c1", ConnectionID: "A1", RequestID: 1, When: "2025-03-01T09:59:01Z",
+			Facade: "Application", Method: "Deploy", Version: 19,
+		}},
+	})
+
+	logFile := auditlog.NewLogFile(s.dir, 300, 10)
+	c.Assert(logFile.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "c1", ConnectionID: "A1", RequestID: 1, When: "2025-03-01T10:00:01Z",
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddConversation(auditlog.Conversation{
+		Who: "alice", What: "juju remove-application mysql", When: "2025-03-02T12:00:00Z",
+		ModelName: "prod", ModelUUID: "cafebabe", ConversationID: "c2", ConnectionID: "A2",
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddRequest(auditlog.Request{
+		ConversationID: "c2", ConnectionID: "A2", RequestID: 1, When: "2025-03-02T12:00:01Z",
+		Facade: "Application", Method: "DestroyApplication", Version: 19,
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "c2", ConnectionID: "A2", RequestID: 1, When: "2025-03-02T12:00:02Z",
+		Errors: []*auditlog.Error{{Message: "permission denied", Code: "unauthorized access"}},
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.AddRequest(auditlog.Request{
+		ConversationID: "c2", ConnectionID: "A2", RequestID: 2, When: "2025-03-02T12:00:03Z",
+		Facade: "Application", Method: "Get", Version: 19,
+	}), jc.ErrorIsNil)
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+}
+
+func writeRotated(c *gc.C, path string, records []auditlog.Record) {
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	gzw := gzip.NewWriter(f)
+	enc := json.NewEncoder(gzw)
+	for _, record := range records {
+		c.Assert(enc.Encode(record), jc.ErrorIsNil)
+	}
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+}
+
+func (s *QuerySuite) query(c *gc.C, filter auditlog.Filter) []string {
+	entries, err := auditlog.Query(s.dir, filter)
+	c.Assert(err, jc.ErrorIsNil)
+	var methods []string
+	for _, entry := range entries {
+		methods = append(methods, entry.Conversation.Who+":"+entry.Request.Method)
+	}
+	return methods
+}
+
+func (s *QuerySuite) TestQueryAll(c *gc.C) {
+	entries, err := auditlog.Query(s.dir, auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 3)
+	c.Check(entries[0], jc.DeepEquals, auditlog.Entry{
+		Conversation: auditlog.Conversation{
+			Who: "bob", What: "juju deploy mysql", When: "2025-03-01T09:59:00Z",
+			ModelName: "default", ModelUUID: "deadbeef", ConversationID: "c1", ConnectionID: "A1",
+		},
+		Request: auditlog.Request{
+			ConversationID: "c1", ConnectionID: "A1", RequestID: 1, When: "2025-03-01T09:59:01Z",
+			Facade: "Application", Method: "Deploy", Version: 19,
+		},
+		Responded: true,
+	})
+	c.Check(entries[1].Failed(), jc.IsTrue)
+	c.Check(entries[1].Errors, jc.DeepEquals, []*auditlog.Error{
+		{Message: "permission denied", Code: "unauthorized access"},
+	})
+	c.Check(entries[2].Responded, jc.IsFalse)
+}
+
+func (s *QuerySuite) TestQueryByUser(c *gc.C) {
+	c.Check(s.query(c, auditlog.Filter{Users: []string{"bob"}}), jc.DeepEquals, []string{"bob:Deploy"})
+}
+
+func (s *QuerySuite) TestQueryByModel(c *gc.C) {
+	c.Check(s.query(c, auditlog.Filter{Models: []string{"default"}}), jc.DeepEquals, []string{"bob:Deploy"})
+	c.Check(s.query(c, auditlog.Filter{Models: []string{"cafebabe"}}), jc.DeepEquals, []string{
+		"alice:DestroyApplication", "alice:Get",
+	})
+}
+
+func (s *QuerySuite) TestQueryByConversation(c *gc.C) {
+	c.Check(s.query(c, auditlog.Filter{ConversationID: "c1"}), jc.DeepEquals, []string{"bob:Deploy"})
+}
+
+func (s *QuerySuite) TestQueryByMethod(c *gc.C) {
+	c.Check(s.query(c, auditlog.Filter{Facade: "application", Method: "get"}), jc.DeepEquals, []string{"alice:Get"})
+	c.Check(s.query(c, auditlog.Filter{Facade: "Client"}), gc.HasLen, 0)
+}
+
+func (s *QuerySuite) TestQueryByTime(c *gc.C) {
+	from := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+	c.Check(s.query(c, auditlog.Filter{From: from}), jc.DeepEquals, []string{
+		"alice:DestroyApplication", "alice:Get",
+	})
+	c.Check(s.query(c, auditlog.Filter{To: from}), jc.DeepEquals, []string{"bob:Deploy"})
+}
+
+func (s *QuerySuite) TestQueryByResult(c *gc.C) {
+	c.Check(s.query(c, auditlog.Filter{Result: auditlog.ResultSuccess}), jc.DeepEquals, []string{"bob:Deploy"})
+	c.Check(s.query(c, auditlog.Filter{Result: auditlog.ResultError}), jc.DeepEquals, []string{"alice:DestroyApplication"})
+}
+
+func (s *QuerySuite) TestQueryLimitKeepsMostRecent(c *gc.C) {
+	c.Check(s.query(c, auditlog.Filter{Limit: 2}), jc.DeepEquals, []string{
+		"alice:DestroyApplication", "alice:Get",
+	})
+}
+
+func (s *QuerySuite) TestQueryLimitWithResultAcrossManyRequests(c *gc.C) {
+	dir := c.MkDir()
+	logFile := auditlog.NewLogFile(dir, 300, 10)
+	c.Assert(logFile.AddConversation(auditlog.Conversation{
+		Who: "bob", When: "2025-03-01T10:00:00Z", ConversationID: "c1", ConnectionID: "A1",
+	}), jc.ErrorIsNil)
+	for i := uint64(1); i <= 20; i++ {
+		c.Assert(logFile.AddRequest(auditlog.Request{
+			ConversationID: "c1", ConnectionID: "A1", RequestID: i, When: "2025-03-01T10:00:01Z",
+			Facade: "Application", Method: fmt.Sprintf("Call%d", i), Version: 19,
+		}), jc.ErrorIsNil)
+		response := auditlog.ResponseErrors{
+			ConversationID: "c1", ConnectionID: "A1", RequestID: i, When: "2025-03-01T10:00:02Z",
+		}
+		if i%5 == 0 {
+			response.Errors = []*auditlog.Error{{Message: "boom"}}
+		}
+		c.Assert(logFile.AddResponse(response), jc.ErrorIsNil)
+	}
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+
+	entries, err := auditlog.Query(dir, auditlog.Filter{Result: auditlog.ResultError, Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+	c.Check(entries[0].Request.Method, gc.Equals, "Call15")
+	c.Check(entries[1].Request.Method, gc.Equals, "Call20")
+
+	entries, err = auditlog.Query(dir, auditlog.Filter{Limit: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 3)
+	c.Check(entries[0].Request.Method, gc.Equals, "Call18")
+}
+
+func (s *QuerySuite) TestQueryNoAuditLog(c *gc.C) {
+	entries, err := auditlog.Query(c.MkDir(), auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *QuerySuite) TestQueryInvalidFilter(c *gc.C) {
+	_, err := auditlog.Query(s.dir, auditlog.Filter{Result: "maybe"})
+	c.Assert(err, gc.ErrorMatches, `result "maybe" not valid`)
+
+	from := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+	_, err = auditlog.Query(s.dir, auditlog.Filter{From: from, To: from.Add(-time.Hour)})
+	c.Assert(err, gc.ErrorMatches, `time range ending before it starts not valid`)
+
+	_, err = auditlog.Query(s.dir, auditlog.Filter{Limit: auditlog.MaxQueryLimit + 1})
+	c.Assert(err, gc.ErrorMatches, `limit 10001 greater than 10000 not valid`)
+}
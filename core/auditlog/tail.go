@@ -0,0 +1,351 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+)
+
+// Sequence returns the sequence number of the record, or 0 if it was
+// written before records were chained.
+func (r Record) Sequence() uint64 {
+	sequence, _ := r.chainFields()
+	if sequence == nil {
+		return 0
+	}
+	return *sequence
+}
+
+// Hash returns the hash chaining the record to the record before it,
+// or "" if it was written before records were chained.
+func (r Record) Hash() string {
+	_, hash := r.chainFields()
+	if hash == nil {
+		return ""
+	}
+	return *hash
+}
+
+// LastSequence returns the sequence number of the last chained record
+// written to the audit log in logDir, or 0 if there are none.
+func LastSequence(logDir string) (uint64, error) {
+	link, err := lastChainLink(logDir)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return link.sequence, nil
+}
+
+// Tailer reads the chained records written to the audit log in a
+// directory, in order, following the current file as it is written to
+// and rotated.
+type Tailer struct {
+	logDir string
+
+	// after is the sequence number of the last record read.
+	after uint64
+
+	// skipping is true until a record following after is read.
+	// Records read from the current file after that are always
+	// returned, as the chain restarts if the audit log is removed.
+	skipping bool
+
+	// pending holds the rotated files still to be read, and
+	// lastRotated is the last rotated file known to the tailer.
+	pending     []string
+	lastRotated string
+
+	// src is the file being read, which is the current file if
+	// following is true. followed is true once the current file has
+	// been opened, after which rotated files are read through it.
+	src       *lineSource
+	following bool
+	followed  bool
+}
+
+// NewTailer returns a Tailer which reads the records in the audit log
+// in logDir with sequence numbers after the one given, including those
+// in rotated files. If the audit log holds no record with that
+// sequence number, as it has been removed and the chain restarted,
+// every record is read.
+func NewTailer(logDir string, after uint64) (*Tailer, error) {
+	last, err := lastChainLink(logDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if last.sequence < after {
+		after = 0
+	}
+	rotated, err := rotatedFileNames(logDir, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	t := &Tailer{
+		logDir:   logDir,
+		after:    after,
+		skipping: true,
+		pending:  rotated,
+	}
+	if len(rotated) > 0 {
+		t.lastRotated = rotated[len(rotated)-1]
+	}
+	if after > 0 {
+		// The rotated files needn't be read if the current file
+		// holds the record following after.
+		first, found, err := firstLinkInFile(filepath.Join(logDir, logFileName))
+		if err != nil && !os.IsNotExist(errors.Cause(err)) {
+			return nil, errors.Trace(err)
+		}
+		if found && first.sequence <= after+1 {
+			t.pending = nil
+		}
+	}
+	return t, nil
+}
+
+// Next returns up to max records written after those already read,
+// oldest first. It returns no records if none have been written.
+// Records written before records were chained are not returned.
+func (t *Tailer) Next(max int) ([]Record, error) {
+	var records []Record
+	for len(records) < max {
+		line, err := t.readLine()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return records, errors.Trace(err)
+		}
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			logger.Debugf("skipping unreadable audit log record: %v", err)
+			continue
+		}
+		sequence := record.Sequence()
+		if sequence == 0 {
+			continue
+		}
+		if sequence <= t.after && (t.skipping || !t.following) {
+			continue
+		}
+		records = append(records, record)
+		t.after = sequence
+		t.skipping = false
+	}
+	return records, nil
+}
+
+// Close closes the file being read.
+func (t *Tailer) Close() error {
+	if t.src == nil {
+		return nil
+	}
+	err := t.src.Close()
+	t.src = nil
+	return errors.Trace(err)
+}
+
+// readLine returns the next complete line of the audit log, or io.EOF
+// if there isn't one yet.
+func (t *Tailer) readLine() ([]byte, error) {
+	for {
+		if t.src == nil {
+			if err := t.openNext(); err != nil {
+				return nil, err
+			}
+		}
+		line, err := t.src.readLine()
+		if err != io.EOF {
+			return line, errors.Trace(err)
+		}
+		if !t.following {
+			if err := t.Close(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
+		}
+		rotated, err := t.rotated()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !rotated {
+			return nil, io.EOF
+		}
+		// Read the rest of the file, written before it was
+		// rotated, then move on to the new current file.
+		line, err = t.src.readLine()
+		if err != io.EOF {
+			return line, errors.Trace(err)
+		}
+		if err := t.Close(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+}
+
+// openNext opens the next file to read, or returns io.EOF if there is
+// no current file yet.
+func (t *Tailer) openNext() error {
+	for {
+		if len(t.pending) == 0 && !t.followed {
+			// Catch up on the files rotated since the tailer
+			// was created.
+			rotated, err := rotatedFileNames(t.logDir, t.lastRotated)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			t.pending = rotated
+		}
+		if len(t.pending) == 0 {
+			break
+		}
+		fileName := t.pending[0]
+		t.pending = t.pending[1:]
+		t.lastRotated = fileName
+		src, err := openLineSource(fileName)
+		if os.IsNotExist(errors.Cause(err)) && filepath.Ext(fileName) != ".gz" {
+			// Lumberjack may have compressed the file since
+			// it was listed.
+			fileName += ".gz"
+			t.lastRotated = fileName
+			src, err = openLineSource(fileName)
+		}
+		if os.IsNotExist(errors.Cause(err)) {
+			// The file has been removed since it was listed.
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "reading %s", filepath.Base(fileName))
+		}
+		t.src = src
+		t.following = false
+		return nil
+	}
+
+	src, err := openLineSource(filepath.Join(t.logDir, logFileName))
+	if os.IsNotExist(errors.Cause(err)) {
+		return io.EOF
+	} else if err != nil {
+		return errors.Annotatef(err, "reading %s", logFileName)
+	}
+	t.src = src
+	t.following = true
+	t.followed = true
+	return nil
+}
+
+// rotated returns whether the current file being read has been
+// rotated, and replaced by a new one.
+func (t *Tailer) rotated() (bool, error) {
+	info, err := os.Stat(filepath.Join(t.logDir, logFileName))
+	if os.IsNotExist(err) {
+		// The new file hasn't been created yet.
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	srcInfo, err := t.src.file.Stat()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return !os.SameFile(info, srcInfo), nil
+}
+
+// rotatedFileNames returns the paths of the rotated audit log files in
+// logDir whose names sort after the given path, oldest first.
+func rotatedFileNames(logDir, after string) ([]string, error) {
+	fileNames, err := logFileNames(logDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	current := filepath.Join(logDir, logFileName)
+	var rotated []string
+	for _, fileName := range fileNames {
+		if fileName != current && fileName > after {
+			rotated = append(rotated, fileName)
+		}
+	}
+	return rotated, nil
+}
+
+// errStop stops reading lines from a file.
+var errStop = errors.New("stop")
+
+// firstLinkInFile returns the link to the first chained record in the
+// file.
+func firstLinkInFile(fileName string) (chainLink, bool, error) {
+	var (
+		link  chainLink
+		found bool
+	)
+	err := readLines(fileName, func(line []byte) error {
+		if l, ok := parseChainLink(line); ok {
+			link, found = l, true
+			return errStop
+		}
+		return nil
+	})
+	if err != nil && errors.Cause(err) != errStop {
+		return chainLink{}, false, errors.Trace(err)
+	}
+	return link, found, nil
+}
+
+// lineSource reads the lines of an audit log file as they are written.
+type lineSource struct {
+	file   *os.File
+	gz     *gzip.Reader
+	reader *bufio.Reader
+
+	// partial holds the start of a line still being written.
+	partial []byte
+}
+
+func openLineSource(fileName string) (*lineSource, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	src := &lineSource{file: f}
+	var r io.Reader = f
+	if filepath.Ext(fileName) == ".gz" {
+		src.gz, err = gzip.NewReader(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, errors.Trace(err)
+		}
+		r = src.gz
+	}
+	src.reader = bufio.NewReader(r)
+	return src, nil
+}
+
+// readLine returns the next complete line, or io.EOF if there isn't
+// one yet.
+func (s *lineSource) readLine() ([]byte, error) {
+	line, err := s.reader.ReadBytes('\n')
+	if err == io.EOF {
+		s.partial = append(s.partial, line...)
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(s.partial) > 0 {
+		line = append(s.partial, line...)
+		s.partial = nil
+	}
+	return line, nil
+}
+
+func (s *lineSource) Close() error {
+	if s.gz != nil {
+		_ = s.gz.Close()
+	}
+	return errors.Trace(s.file.Close())
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type TailSuite struct {
+	testing.IsolationSuite
+
+	dir     string
+	logFile auditlog.AuditLog
+	count   int
+}
+
+var _ = gc.Suite(&TailSuite{})
+
+func (s *TailSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.count = 0
+	s.openLog()
+}
+
+func (s *TailSuite) TearDownTest(c *gc.C) {
+	c.Check(s.logFile.Close(), jc.ErrorIsNil)
+	s.IsolationSuite.TearDownTest(c)
+}
+
+func (s *TailSuite) openLog() {
+	s.logFile = auditlog.NewLogFile(s.dir, 300, 10)
+}
+
+// write writes n conversation records to the audit log.
+func (s *TailSuite) write(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		s.count++
+		c.Assert(s.logFile.AddConversation(auditlog.Conversation{
+			Who: "bob", What: "juju status", When: "2025-03-01T10:00:00Z",
+			ConversationID: fmt.Sprintf("c%d", s.count), ConnectionID: "A1",
+		}), jc.ErrorIsNil)
+	}
+}
+
+// rotate renames the current audit log file, as lumberjack does before
+// compressing it, and starts writing a new one.
+func (s *TailSuite) rotate(c *gc.C, timestamp string) {
+	c.Assert(s.logFile.Close(), jc.ErrorIsNil)
+	err := os.Rename(
+		filepath.Join(s.dir, "audit.log"),
+		filepath.Join(s.dir, fmt.Sprintf("audit-%s.log", timestamp)),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.openLog()
+}
+
+func (s *TailSuite) next(c *gc.C, t *auditlog.Tailer, max int) []string {
+	records, err := t.Next(max)
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, record := range records {
+		ids = append(ids, fmt.Sprintf("%s:%d", record.Conversation.ConversationID, record.Sequence()))
+	}
+	return ids
+}
+
+func (s *TailSuite) newTailer(c *gc.C, after uint64) *auditlog.Tailer {
+	t, err := auditlog.NewTailer(s.dir, after)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { _ = t.Close() })
+	return t
+}
+
+func (s *TailSuite) TestLastSequence(c *gc.C) {
+	sequence, err := auditlog.LastSequence(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sequence, gc.Equals, uint64(0))
+
+	s.write(c, 3)
+	sequence, err = auditlog.LastSequence(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sequence, gc.Equals, uint64(3))
+}
+
+func (s *TailSuite) TestNext(c *gc.C) {
+	t := s.newTailer(c, 0)
+	c.Check(s.next(c, t, 10), gc.HasLen, 0)
+
+	s.write(c, 3)
+	c.Check(s.next(c, t, 2), jc.DeepEquals, []string{"c1:1", "c2:2"})
+	c.Check(s.next(c, t, 2), jc.DeepEquals, []string{"c3:3"})
+	c.Check(s.next(c, t, 2), gc.HasLen, 0)
+
+	s.write(c, 1)
+	c.Check(s.next(c, t, 2), jc.DeepEquals, []string{"c4:4"})
+}
+
+func (s *TailSuite) TestAfter(c *gc.C) {
+	s.write(c, 3)
+	t := s.newTailer(c, 2)
+	c.Check(s.next(c, t, 10), jc.DeepEquals, []string{"c3:3"})
+}
+
+func (s *TailSuite) TestRotatedFiles(c *gc.C) {
+	s.write(c, 2)
+	s.rotate(c, "2025-03-01T10-00-00.000")
+	s.write(c, 2)
+	s.rotate(c, "2025-03-01T11-00-00.000")
+	s.write(c, 1)
+
+	t := s.newTailer(c, 1)
+	c.Check(s.next(c, t, 10), jc.DeepEquals, []string{"c2:2", "c3:3", "c4:4", "c5:5"})
+}
+
+func (s *TailSuite) TestFollowsRotation(c *gc.C) {
+	s.write(c, 1)
+	t := s.newTailer(c, 0)
+	c.Check(s.next(c, t, 10), jc.DeepEquals, []string{"c1:1"})
+
+	s.write(c, 1)
+	s.rotate(c, "2025-03-01T10-00-00.000")
+	s.write(c, 1)
+	c.Check(s.next(c, t, 10), jc.DeepEquals, []string{"c2:2", "c3:3"})
+}
+
+func (s *TailSuite) TestRotatedBeforeFirstRead(c *gc.C) {
+	s.write(c, 1)
+	t := s.newTailer(c, 0)
+
+	s.rotate(c, "2025-03-01T10-00-00.000")
+	s.write(c, 1)
+	c.Check(s.next(c, t, 10), jc.DeepEquals, []string{"c1:1", "c2:2"})
+}
+
+func (s *TailSuite) TestRotatedFileCompressed(c *gc.C) {
+	s.write(c, 2)
+	s.rotate(c, "2025-03-01T10-00-00.000")
+	s.write(c, 1)
+	t := s.newTailer(c, 0)
+
+	// Compress the rotated file after the tailer has listed it,
+	// as lumberjack does.
+	fileName := filepath.Join(s.dir, "audit-2025-03-01T10-00-00.000.log")
+	data, err := os.ReadFile(fileName)
+	c.Assert(err, jc.ErrorIsNil)
+	f, err := os.Create(fileName + ".gz")
+	c.Assert(err, jc.ErrorIsNil)
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gz.Close(), jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+	c.Assert(os.Remove(fileName), jc.ErrorIsNil)
+
+	c.Check(s.next(c, t, 10), jc.DeepEquals, []string{"c1:1", "c2:2", "c3:3"})
+}
+
+func (s *TailSuite) TestPartialLine(c *gc.C) {
+	t := s.newTailer(c, 0)
+	s.write(c, 1)
+	data, err := os.ReadFile(filepath.Join(s.dir, "audit.log"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Replace the record with the start of it, as if it were still
+	// being written.
+	c.Assert(s.logFile.Close(), jc.ErrorIsNil)
+	half := len(data) / 2
+	err = os.WriteFile(filepath.Join(s.dir, "audit.log"), data[:half], 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.next(c, t, 10), gc.HasLen, 0)
+
+	f, err := os.OpenFile(filepath.Join(s.dir, "audit.log"), os.O_APPEND|os.O_WRONLY, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = f.Write(data[half:])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+	c.Check(s.next(c, t, 10), jc.DeepEquals, []string{"c1:1"})
+	s.openLog()
+}
+
+func (s *TailSuite) TestSkipsUnchainedRecords(c *gc.C) {
+	c.Assert(s.logFile.Close(), jc.ErrorIsNil)
+	err := os.WriteFile(filepath.Join(s.dir, "audit.log"), []byte(
+		`{"conversation":{"who":"bob","conversation-id":"c0","connection-id":"A0"}}`+"\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.openLog()
+	s.write(c, 1)
+
+	t := s.newTailer(c, 0)
+	c.Check(s.next(c, t, 10), jc.DeepEquals, []string{"c1:1"})
+}
+
+func (s *TailSuite) TestChainRestarted(c *gc.C) {
+	s.write(c, 2)
+	c.Assert(s.logFile.Close(), jc.ErrorIsNil)
+	c.Assert(os.Remove(filepath.Join(s.dir, "audit.log")), jc.ErrorIsNil)
+	s.openLog()
+	s.write(c, 1)
+
+	// The audit log holds no record 2, so every record is read.
+	t := s.newTailer(c, 2)
+	c.Check(s.next(c, t, 10), jc.DeepEquals, []string{"c3:1"})
+}
@@ -28,6 +28,7 @@ const (
 	ModelKind             = "model"
 	RelationKind          = "relation"
 	RemoteApplicationKind = "remoteApplication"
+	StorageInstanceKind   = "storageInstance"
 	UnitKind              = "unit"
 )
 
@@ -455,6 +456,8 @@ type RelationInfo struct {
 	ModelUUID string
 	Key       string
 	ID        int
+	Life      life.Value
+	Status    StatusInfo
 	Endpoints []Endpoint
 }
 
@@ -496,6 +499,36 @@ func (i *RelationInfo) Clone() EntityInfo {
 	return &clone
 }
 
+// StorageInstanceInfo holds the information about a storage instance that
+// is tracked by multiwatcherStore.
+type StorageInstanceInfo struct {
+	ModelUUID       string
+	ID              string
+	Kind            string
+	Life            life.Value
+	Owner           string
+	StorageName     string
+	Pool            string
+	Size            uint64
+	AttachmentCount int
+}
+
+// EntityID returns a unique identifier for a storage instance across
+// models.
+func (i *StorageInstanceInfo) EntityID() EntityID {
+	return EntityID{
+		Kind:      StorageInstanceKind,
+		ModelUUID: i.ModelUUID,
+		ID:        i.ID,
+	}
+}
+
+// Clone returns a clone of the EntityInfo.
+func (i *StorageInstanceInfo) Clone() EntityInfo {
+	clone := *i
+	return &clone
+}
+
 // AnnotationInfo holds the information about an annotation that is
 // tracked by multiwatcherStore.
 type AnnotationInfo struct {
@@ -0,0 +1,229 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package schedule parses cron-like schedule specifications and
+// computes when they next fire.
+package schedule
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// maxSearch bounds how far ahead Next will look for a matching time.
+// Every valid schedule fires at least once in any eight year period
+// (February 29th being the rarest day that can be asked for).
+const maxSearch = 8 * 366 * 24 * time.Hour
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// field describes one of the five fields of a schedule specification.
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day-of-month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: monthNames}
+	// Day of week accepts 7 as well as 0 for Sunday.
+	dowField = field{name: "day-of-week", min: 0, max: 7, names: dayNames}
+)
+
+// Schedule is a parsed schedule specification. All times are
+// evaluated in UTC.
+type Schedule struct {
+	spec string
+
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny record whether the day fields were
+	// given as "*"; when both day fields are restricted a
+	// time matches if either of them does, as with cron.
+	domAny, dowAny bool
+}
+
+// Parse parses a schedule specification. A specification is either
+// one of the descriptors @yearly, @annually, @monthly, @weekly, @daily,
+// @midnight and @hourly, or five space separated fields giving the
+// minute, hour, day of month, month and day of week. Each field is
+// "*" or a comma separated list of values or ranges ("a-b"), each
+// optionally followed by a step ("*/15", "0-30/10").
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	expanded := spec
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		if expanded, ok = descriptors[strings.ToLower(spec)]; !ok {
+			return nil, errors.NotValidf("schedule descriptor %q", spec)
+		}
+	}
+	fields := strings.Fields(expanded)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	s := &Schedule{
+		spec:   spec,
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	var err error
+	if s.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, errors.Annotatef(err, "schedule %q", spec)
+	}
+	if s.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, errors.Annotatef(err, "schedule %q", spec)
+	}
+	if s.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, errors.Annotatef(err, "schedule %q", spec)
+	}
+	if s.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, errors.Annotatef(err, "schedule %q", spec)
+	}
+	if s.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, errors.Annotatef(err, "schedule %q", spec)
+	}
+	// Fold Sunday-as-7 onto Sunday-as-0.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	// Reject schedules such as "0 0 30 2 *" which can never fire.
+	ref := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if s.Next(ref).IsZero() {
+		return nil, errors.Errorf("schedule %q never fires", spec)
+	}
+	return s, nil
+}
+
+// MustParse is like Parse but panics if the specification is invalid.
+func MustParse(spec string) *Schedule {
+	s, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// String returns the specification the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time strictly after t at which the schedule
+// fires, truncated to the minute and expressed in UTC. The zero time
+// is returned if no such time exists.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// parse returns the set of values described by spec as a bitmask.
+func (f field) parse(spec string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(spec, ",") {
+		rangeSpec, stepSpec, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepSpec); err != nil || step <= 0 {
+				return 0, errors.NotValidf("%s step %q", f.name, stepSpec)
+			}
+		}
+
+		var lo, hi int
+		switch {
+		case rangeSpec == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rangeSpec, "-"):
+			loSpec, hiSpec, _ := strings.Cut(rangeSpec, "-")
+			var err error
+			if lo, err = f.value(loSpec); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi, err = f.value(hiSpec); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if lo > hi {
+				return 0, errors.NotValidf("%s range %q", f.name, rangeSpec)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rangeSpec); err != nil {
+				return 0, errors.Trace(err)
+			}
+			hi = lo
+			// "a/n" means every n starting at a.
+			if hasStep {
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f field) value(spec string) (int, error) {
+	if v, ok := f.names[strings.ToLower(spec)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(spec)
+	if err != nil {
+		return 0, errors.NotValidf("%s value %q", f.name, spec)
+	}
+	if v < f.min || v > f.max {
+		return 0, errors.NotValidf("%s value %d (expected %d-%d)", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package schedule_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/schedule"
+)
+
+type scheduleSuite struct{}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+// from is a Wednesday.
+var from = time.Date(2025, time.January, 15, 10, 20, 30, 0, time.UTC)
+
+func (*scheduleSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		expect time.Time
+	}{{
+		spec:   "* * * * *",
+		expect: time.Date(2025, 1, 15, 10, 21, 0, 0, time.UTC),
+	}, {
+		spec:   "@hourly",
+		expect: time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@daily",
+		expect: time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@weekly",
+		expect: time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@monthly",
+		expect: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@yearly",
+		expect: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "*/15 * * * *",
+		expect: time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC),
+	}, {
+		spec:   "30 2 * * *",
+		expect: time.Date(2025, 1, 16, 2, 30, 0, 0, time.UTC),
+	}, {
+		spec:   "0 9-17/4 * * *",
+		expect: time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 3 * * sat,sun",
+		expect: time.Date(2025, 1, 18, 3, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 3 * * 7",
+		expect: time.Date(2025, 1, 19, 3, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 1 mar *",
+		expect: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		// Both day fields restricted: either may match.
+		spec:   "0 0 20 * mon",
+		expect: time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 29 2 *",
+		expect: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "20 10 15 1 *",
+		expect: time.Date(2026, 1, 15, 10, 20, 0, 0, time.UTC),
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		s, err := schedule.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.Next(from), gc.Equals, test.expect)
+		c.Check(s.String(), gc.Equals, test.spec)
+	}
+}
+
+func (*scheduleSuite) TestNextUsesUTC(c *gc.C) {
+	loc := time.FixedZone("UTC+5", 5*60*60)
+	s := schedule.MustParse("@daily")
+	next := s.Next(time.Date(2025, 1, 15, 3, 0, 0, 0, loc))
+	c.Assert(next, gc.Equals, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC))
+}
+
+func (*scheduleSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "": expected 5 fields, got 0`,
+	}, {
+		spec: "* * * *",
+		err:  `schedule "\* \* \* \*": expected 5 fields, got 4`,
+	}, {
+		spec: "@fortnightly",
+		err:  `schedule descriptor "@fortnightly" not valid`,
+	}, {
+		spec: "60 * * * *",
+		err:  `schedule "60 \* \* \* \*": minute value 60 \(expected 0-59\) not valid`,
+	}, {
+		spec: "* 5-2 * * *",
+		err:  `schedule "\* 5-2 \* \* \*": hour range "5-2" not valid`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `schedule "\*/0 \* \* \* \*": minute step "0" not valid`,
+	}, {
+		spec: "* * * foo *",
+		err:  `schedule "\* \* \* foo \*": month value "foo" not valid`,
+	}, {
+		spec: "0 0 30 2 *",
+		err:  `schedule "0 0 30 2 \*" never fires`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := schedule.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
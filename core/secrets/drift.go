@@ -0,0 +1,26 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+// ContentDrift describes how the content of a secret revision
+// stored in an external backend differs from what was saved.
+type ContentDrift string
+
+const (
+	// ContentMissing means the content no longer exists in the backend.
+	ContentMissing ContentDrift = "missing"
+	// ContentModified means the content in the backend doesn't
+	// match the checksum recorded when it was saved.
+	ContentModified ContentDrift = "modified"
+)
+
+// RevisionContentDrift holds the outcome of checking the external
+// content of a secret revision which has drifted or couldn't be checked.
+type RevisionContentDrift struct {
+	URI         *URI
+	Revision    int
+	BackendName string
+	Drift       ContentDrift
+	Error       error
+}
@@ -195,6 +195,7 @@ type SecretRevisionMetadata struct {
 	Revision    int
 	ValueRef    *ValueRef
 	BackendName *string
+	Checksum    string
 	CreateTime  time.Time
 	UpdateTime  time.Time
 	ExpireTime  *time.Time
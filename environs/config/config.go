@@ -32,6 +32,8 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/loki"
+	"github.com/juju/juju/logfwd/otlp"
 	"github.com/juju/juju/logfwd/syslog"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -223,6 +225,49 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdOTLPEndpoint sets the URL of the OTLP/HTTP log receiver. When
+	// it is set, logs are forwarded using OTLP rather than syslog.
+	LogFwdOTLPEndpoint = "otlp-endpoint"
+
+	// LogFwdOTLPHeaders sets the extra HTTP headers to send to the OTLP
+	// receiver, as a comma separated list of key=value pairs.
+	LogFwdOTLPHeaders = "otlp-headers"
+
+	// LogFwdOTLPCACert sets the certificate of the CA that signed the OTLP
+	// receiver certificate.
+	LogFwdOTLPCACert = "otlp-ca-cert"
+
+	// LogFwdOTLPClientCert sets the client certificate for OTLP
+	// forwarding.
+	LogFwdOTLPClientCert = "otlp-client-cert"
+
+	// LogFwdOTLPClientKey sets the client key for OTLP forwarding.
+	LogFwdOTLPClientKey = "otlp-client-key"
+
+	// LogFwdLokiEndpoint sets the URL of the Loki server. When it is
+	// set, logs are pushed to Loki rather than forwarded using syslog.
+	LogFwdLokiEndpoint = "loki-endpoint"
+
+	// LogFwdLokiHeaders sets the extra HTTP headers to send to the Loki
+	// server, as a comma separated list of key=value pairs.
+	LogFwdLokiHeaders = "loki-headers"
+
+	// LogFwdLokiCACert sets the certificate of the CA that signed the
+	// Loki server certificate.
+	LogFwdLokiCACert = "loki-ca-cert"
+
+	// LogFwdLokiClientCert sets the client certificate for Loki
+	// forwarding.
+	LogFwdLokiClientCert = "loki-client-cert"
+
+	// LogFwdLokiClientKey sets the client key for Loki forwarding.
+	LogFwdLokiClientKey = "loki-client-key"
+
+	// LogForwardTargets sets the named log forwarding targets, as a YAML
+	// list. Logs are forwarded to each of them, in addition to the
+	// target configured by the syslog-*, otlp-* and loki-* keys.
+	LogForwardTargets = "logforward-targets"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -756,9 +801,13 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
-	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
+	if _, err := ParseLogForwardTargets(cfg.asString(LogForwardTargets)); err != nil {
+		return errors.Trace(err)
+	}
+
+	if lfCfg, ok := cfg.LogForward(); ok {
 		if err := lfCfg.Validate(); err != nil {
-			return errors.Annotate(err, "invalid syslog forwarding config")
+			return errors.Trace(err)
 		}
 	}
 
@@ -1344,6 +1393,222 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdOTLP returns the OTLP forwarding config.
+func (c *Config) LogFwdOTLP() (*otlp.RawConfig, bool) {
+	partial := false
+	var lfCfg otlp.RawConfig
+
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		lfCfg.Enabled = s.(bool)
+	}
+
+	for key, value := range map[string]*string{
+		LogFwdOTLPEndpoint:   &lfCfg.Endpoint,
+		LogFwdOTLPHeaders:    &lfCfg.Headers,
+		LogFwdOTLPCACert:     &lfCfg.CACert,
+		LogFwdOTLPClientCert: &lfCfg.ClientCert,
+		LogFwdOTLPClientKey:  &lfCfg.ClientKey,
+	} {
+		if s, ok := c.defined[key]; ok && s != "" {
+			partial = true
+			*value = s.(string)
+		}
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogFwdLoki returns the Loki forwarding config.
+func (c *Config) LogFwdLoki() (*loki.RawConfig, bool) {
+	partial := false
+	var lfCfg loki.RawConfig
+
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		lfCfg.Enabled = s.(bool)
+	}
+
+	for key, value := range map[string]*string{
+		LogFwdLokiEndpoint:   &lfCfg.Endpoint,
+		LogFwdLokiHeaders:    &lfCfg.Headers,
+		LogFwdLokiCACert:     &lfCfg.CACert,
+		LogFwdLokiClientCert: &lfCfg.ClientCert,
+		LogFwdLokiClientKey:  &lfCfg.ClientKey,
+	} {
+		if s, ok := c.defined[key]; ok && s != "" {
+			partial = true
+			*value = s.(string)
+		}
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogForwardConfig holds the log forwarding config of a model. Logs of
+// the default target are forwarded to the OTLP receiver or the Loki
+// server when its endpoint is set, and to the syslog host otherwise.
+// Logs are also forwarded to each of the named targets.
+type LogForwardConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Syslog is the config of the syslog target, if any.
+	Syslog *syslog.RawConfig
+
+	// OTLP is the config of the OTLP target, if any.
+	OTLP *otlp.RawConfig
+
+	// Loki is the config of the Loki target, if any.
+	Loki *loki.RawConfig
+
+	// Targets holds the named targets.
+	Targets []LogForwardTarget
+}
+
+// UseOTLP returns whether logs are forwarded to the OTLP receiver.
+func (cfg LogForwardConfig) UseOTLP() bool {
+	return cfg.OTLP != nil && cfg.OTLP.Endpoint != ""
+}
+
+// UseLoki returns whether logs are pushed to the Loki server.
+func (cfg LogForwardConfig) UseLoki() bool {
+	return cfg.Loki != nil && cfg.Loki.Endpoint != ""
+}
+
+// hasDefaultTarget returns whether the default target has a destination.
+func (cfg LogForwardConfig) hasDefaultTarget() bool {
+	return cfg.UseOTLP() || cfg.UseLoki() || (cfg.Syslog != nil && cfg.Syslog.Host != "")
+}
+
+// AllTargets returns the targets to which logs are forwarded: the default
+// target, if it has a destination, followed by the named targets.
+func (cfg LogForwardConfig) AllTargets() []LogForwardTarget {
+	var targets []LogForwardTarget
+	switch {
+	case cfg.UseOTLP():
+		otlpCfg := *cfg.OTLP
+		otlpCfg.Enabled = true
+		targets = append(targets, LogForwardTarget{
+			Name: DefaultLogForwardTarget,
+			Type: LogForwardTypeOTLP,
+			OTLP: &otlpCfg,
+		})
+	case cfg.UseLoki():
+		lokiCfg := *cfg.Loki
+		lokiCfg.Enabled = true
+		targets = append(targets, LogForwardTarget{
+			Name: DefaultLogForwardTarget,
+			Type: LogForwardTypeLoki,
+			Loki: &lokiCfg,
+		})
+	case cfg.hasDefaultTarget():
+		syslogCfg := *cfg.Syslog
+		syslogCfg.Enabled = true
+		targets = append(targets, LogForwardTarget{
+			Name:   DefaultLogForwardTarget,
+			Type:   LogForwardTypeSyslog,
+			Syslog: &syslogCfg,
+		})
+	}
+	return append(targets, cfg.Targets...)
+}
+
+// Target returns the target with the given name.
+func (cfg LogForwardConfig) Target(name string) (LogForwardTarget, bool) {
+	for _, target := range cfg.AllTargets() {
+		if target.Name == name {
+			return target, true
+		}
+	}
+	return LogForwardTarget{}, false
+}
+
+// Validate ensures that the config of the targets in use is valid.
+func (cfg LogForwardConfig) Validate() error {
+	seen := make(map[string]bool)
+	for _, target := range cfg.Targets {
+		if target.Name == DefaultLogForwardTarget {
+			return errors.NotValidf("log forwarding target name %q, which is reserved,", target.Name)
+		}
+		if seen[target.Name] {
+			return errors.NotValidf("duplicate log forwarding target %q", target.Name)
+		}
+		seen[target.Name] = true
+		if err := target.Validate(); err != nil {
+			return errors.Annotatef(err, "invalid log forwarding target %q", target.Name)
+		}
+	}
+	if cfg.UseOTLP() && cfg.UseLoki() {
+		return errors.NotValidf("setting both %s and %s", LogFwdOTLPEndpoint, LogFwdLokiEndpoint)
+	}
+	if cfg.UseOTLP() {
+		return errors.Annotate(cfg.OTLP.Validate(), "invalid OTLP forwarding config")
+	}
+	if cfg.UseLoki() {
+		return errors.Annotate(cfg.Loki.Validate(), "invalid Loki forwarding config")
+	}
+	// Validate the TLS material, even if there's no endpoint yet.
+	if cfg.OTLP != nil {
+		otlpCfg := *cfg.OTLP
+		otlpCfg.Enabled = false
+		if err := otlpCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid OTLP forwarding config")
+		}
+	}
+	if cfg.Loki != nil {
+		lokiCfg := *cfg.Loki
+		lokiCfg.Enabled = false
+		if err := lokiCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid Loki forwarding config")
+		}
+	}
+	syslogCfg := cfg.Syslog
+	if syslogCfg == nil {
+		syslogCfg = &syslog.RawConfig{Enabled: cfg.Enabled}
+	}
+	if len(cfg.Targets) > 0 && syslogCfg.Host == "" {
+		// The named targets are used without a default target.
+		copied := *syslogCfg
+		copied.Enabled = false
+		syslogCfg = &copied
+	}
+	return errors.Annotate(syslogCfg.Validate(), "invalid syslog forwarding config")
+}
+
+// LogForward returns the log forwarding config.
+func (c *Config) LogForward() (*LogForwardConfig, bool) {
+	syslogCfg, hasSyslog := c.LogFwdSyslog()
+	otlpCfg, hasOTLP := c.LogFwdOTLP()
+	lokiCfg, hasLoki := c.LogFwdLoki()
+	// The targets are checked when the config is validated.
+	targets, _ := ParseLogForwardTargets(c.asString(LogForwardTargets))
+	if !hasSyslog && !hasOTLP && !hasLoki && len(targets) == 0 {
+		return nil, false
+	}
+	lfCfg := &LogForwardConfig{
+		Syslog:  syslogCfg,
+		OTLP:    otlpCfg,
+		Loki:    lokiCfg,
+		Targets: targets,
+	}
+	switch {
+	case hasSyslog:
+		lfCfg.Enabled = syslogCfg.Enabled
+	case hasOTLP:
+		lfCfg.Enabled = otlpCfg.Enabled
+	case hasLoki:
+		lfCfg.Enabled = lokiCfg.Enabled
+	default:
+		lfCfg.Enabled, _ = c.defined[LogForwardEnabled].(bool)
+	}
+	return lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1916,6 +2181,17 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdOTLPEndpoint:     schema.Omit,
+	LogFwdOTLPHeaders:      schema.Omit,
+	LogFwdOTLPCACert:       schema.Omit,
+	LogFwdOTLPClientCert:   schema.Omit,
+	LogFwdOTLPClientKey:    schema.Omit,
+	LogFwdLokiEndpoint:     schema.Omit,
+	LogFwdLokiHeaders:      schema.Omit,
+	LogFwdLokiCACert:       schema.Omit,
+	LogFwdLokiClientCert:   schema.Omit,
+	LogFwdLokiClientKey:    schema.Omit,
+	LogForwardTargets:      schema.Omit,
 	LoggingOutputKey:       schema.Omit,
 
 	// Storage related config.
@@ -2432,6 +2708,61 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdOTLPEndpoint: {
+		Description: `The URL of the OTLP/HTTP log receiver. When set, logs are forwarded using OTLP instead of syslog.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdOTLPHeaders: {
+		Description: `Extra HTTP headers to send to the OTLP log receiver, as comma separated key=value pairs.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdOTLPCACert: {
+		Description: `The certificate of the CA that signed the OTLP log receiver certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdOTLPClientCert: {
+		Description: `The OTLP client certificate in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdOTLPClientKey: {
+		Description: `The OTLP client key in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLokiEndpoint: {
+		Description: `The URL of the Loki server. When set, logs are pushed to Loki instead of being forwarded using syslog.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLokiHeaders: {
+		Description: `Extra HTTP headers to send to the Loki server, as comma separated key=value pairs.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLokiCACert: {
+		Description: `The certificate of the CA that signed the Loki server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLokiClientCert: {
+		Description: `The Loki client certificate in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLokiClientKey: {
+		Description: `The Loki client key in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardTargets: {
+		Description: `A YAML list of named log forwarding targets. Each target has a name, a type (syslog, otlp or loki), an endpoint, optional headers, ca-cert, client-cert and client-key, and an optional minimum level and lists of modules and entity patterns selecting the logs to forward.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
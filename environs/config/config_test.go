@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -608,6 +609,114 @@ var configTests = []configTest{
 			"syslog-client-key":  serverKey2,
 		}),
 		err: `invalid syslog forwarding config: validating TLS config: parsing client key pair: (crypto/)?tls: private key does not match public key`,
+	}, {
+		about:       "OTLP forwarding without syslog",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"otlp-endpoint":      "https://otel-collector:4318",
+			"otlp-headers":       "authorization=Bearer abc",
+			"otlp-ca-cert":       testing.CACert,
+		}),
+	}, {
+		about:       "Invalid OTLP endpoint",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"otlp-endpoint":      "otel-collector:4318",
+		}),
+		err: `invalid OTLP forwarding config: Endpoint "otel-collector:4318", expected an http or https URL not valid`,
+	}, {
+		about:       "Invalid OTLP ca cert without endpoint",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"otlp-ca-cert": "abc",
+		}),
+		err: `invalid OTLP forwarding config: validating TLS config: parsing CA certificate: no certificates found`,
+	}, {
+		about:       "Loki forwarding without syslog",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"loki-endpoint":      "https://loki:3100",
+			"loki-headers":       "x-scope-orgid=juju",
+			"loki-ca-cert":       testing.CACert,
+		}),
+	}, {
+		about:       "Invalid Loki endpoint",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"loki-endpoint":      "loki:3100",
+		}),
+		err: `invalid Loki forwarding config: Endpoint "loki:3100", expected an http or https URL not valid`,
+	}, {
+		about:       "Both OTLP and Loki endpoints",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"otlp-endpoint": "https://otel-collector:4318",
+			"loki-endpoint": "https://loki:3100",
+		}),
+		err: `setting both otlp-endpoint and loki-endpoint not valid`,
+	}, {
+		about:       "Named log forwarding targets without a default target",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-targets": `
+- name: security
+  type: otlp
+  endpoint: https://siem:4318
+  level: warning
+  modules: [juju.apiserver]
+- name: ops
+  type: loki
+  endpoint: https://loki:3100
+  entities: [unit-mysql-*]
+`,
+		}),
+	}, {
+		about:       "Log forwarding target with an unknown type",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-targets": "[{name: ops, type: kafka, endpoint: kafka:9092}]",
+		}),
+		err: `log forwarding target "ops": target type "kafka" not valid`,
+	}, {
+		about:       "Log forwarding target with an unknown field",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-targets": "[{name: ops, type: loki, host: loki:3100}]",
+		}),
+		err: `parsing log forwarding targets: yaml: unmarshal errors:\n  line 1: field host not found in type config.logForwardTargetSpec`,
+	}, {
+		about:       "Log forwarding target with a bad level",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-targets": "[{name: ops, type: loki, endpoint: https://loki:3100, level: loud}]",
+		}),
+		err: `log forwarding target "ops": level "loud" not valid`,
+	}, {
+		about:       "Log forwarding targets with the same name",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-targets": "[{name: ops, type: loki, endpoint: https://loki:3100}, {name: ops, type: otlp, endpoint: https://otel:4318}]",
+		}),
+		err: `duplicate log forwarding target "ops" not valid`,
+	}, {
+		about:       "Log forwarding target with the reserved name",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-targets": "[{name: default, type: loki, endpoint: https://loki:3100}]",
+		}),
+		err: `log forwarding target name "default", which is reserved, not valid`,
+	}, {
+		about:       "Log forwarding target with a bad endpoint",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-targets": "[{name: ops, type: loki, endpoint: loki:3100}]",
+		}),
+		err: `invalid log forwarding target "ops": Endpoint "loki:3100", expected an http or https URL not valid`,
 	}, {
 		about:       "net-bond-reconfigure-delay value",
 		useDefaults: config.UseDefaults,
@@ -778,6 +887,24 @@ func (test configTest) check(c *gc.C) {
 	keys, _ := test.attrs["authorized-keys"].(string)
 	c.Check(cfg.AuthorizedKeys(), gc.Equals, keys)
 
+	if v, ok := test.attrs["otlp-endpoint"].(string); ok {
+		otlpCfg, hasOTLPCfg := cfg.LogFwdOTLP()
+		if c.Check(hasOTLPCfg, jc.IsTrue) {
+			c.Check(otlpCfg.Endpoint, gc.Equals, v)
+		}
+		lfCfg, _ := cfg.LogForward()
+		c.Check(lfCfg.UseOTLP(), jc.IsTrue)
+	}
+
+	if v, ok := test.attrs["loki-endpoint"].(string); ok {
+		lokiCfg, hasLokiCfg := cfg.LogFwdLoki()
+		if c.Check(hasLokiCfg, jc.IsTrue) {
+			c.Check(lokiCfg.Endpoint, gc.Equals, v)
+		}
+		lfCfg, _ := cfg.LogForward()
+		c.Check(lfCfg.UseLoki(), jc.IsTrue)
+	}
+
 	lfCfg, hasLogCfg := cfg.LogFwdSyslog()
 	if v, ok := test.attrs["logforward-enabled"].(bool); ok {
 		if c.Check(hasLogCfg, jc.IsTrue) {
@@ -1343,6 +1470,59 @@ func (s *ConfigSuite) TestSSHAllow(c *gc.C) {
 	c.Assert(allowlist, gc.HasLen, 0)
 }
 
+func (s *ConfigSuite) TestLogForwardTargets(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		config.LogForwardEnabled:  true,
+		config.LogFwdLokiEndpoint: "https://loki:3100",
+		config.LogForwardTargets: `
+- name: security
+  type: syslog
+  endpoint: 10.0.0.1:6514
+  ca-cert: |
+` + indent(testing.CACert, "    ") + `
+  client-cert: |
+` + indent(testing.ServerCert, "    ") + `
+  client-key: |
+` + indent(testing.ServerKey, "    ") + `
+  level: WARNING
+  entities: [machine-0, unit-mysql-*]
+`,
+	})
+	lfCfg, ok := cfg.LogForward()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(lfCfg.Validate(), jc.ErrorIsNil)
+
+	targets := lfCfg.AllTargets()
+	c.Assert(targets, gc.HasLen, 2)
+	c.Check(targets[0].Name, gc.Equals, config.DefaultLogForwardTarget)
+	c.Check(targets[0].Type, gc.Equals, config.LogForwardTypeLoki)
+	c.Check(targets[0].Loki.Enabled, jc.IsTrue)
+	c.Check(targets[0].Filter, jc.DeepEquals, logfwd.Filter{})
+
+	c.Check(targets[1].Name, gc.Equals, "security")
+	c.Check(targets[1].Type, gc.Equals, config.LogForwardTypeSyslog)
+	c.Check(targets[1].Syslog.Host, gc.Equals, "10.0.0.1:6514")
+	c.Check(targets[1].Syslog.ClientKey, gc.Equals, testing.ServerKey)
+	c.Check(targets[1].Filter, jc.DeepEquals, logfwd.Filter{
+		Level:    loggo.WARNING,
+		Entities: []string{"machine-0", "unit-mysql-*"},
+	})
+
+	target, ok := lfCfg.Target("security")
+	c.Check(ok, jc.IsTrue)
+	c.Check(target, jc.DeepEquals, targets[1])
+	_, ok = lfCfg.Target("ops")
+	c.Check(ok, jc.IsFalse)
+}
+
+func indent(text, prefix string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (s *ConfigSuite) TestApplicationOfferAllowList(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{})
 	allowlist := cfg.SAASIngressAllow()
@@ -0,0 +1,174 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/loki"
+	"github.com/juju/juju/logfwd/otlp"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// These are the supported types of log forwarding target.
+const (
+	LogForwardTypeSyslog = "syslog"
+	LogForwardTypeOTLP   = "otlp"
+	LogForwardTypeLoki   = "loki"
+)
+
+// DefaultLogForwardTarget is the name of the log forwarding target
+// configured by the syslog-*, otlp-* and loki-* keys.
+const DefaultLogForwardTarget = "default"
+
+var validLogForwardTargetName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// LogForwardTarget describes a destination to which logs are forwarded.
+// Exactly one of Syslog, OTLP and Loki is set, according to the type.
+type LogForwardTarget struct {
+	// Name identifies the target within the model.
+	Name string
+
+	// Type is the type of the target.
+	Type string
+
+	// Syslog is the config of a syslog target.
+	Syslog *syslog.RawConfig
+
+	// OTLP is the config of an OTLP/HTTP target.
+	OTLP *otlp.RawConfig
+
+	// Loki is the config of a Loki target.
+	Loki *loki.RawConfig
+
+	// Filter selects the log records forwarded to the target.
+	Filter logfwd.Filter
+}
+
+// Validate ensures that the target is valid.
+func (t LogForwardTarget) Validate() error {
+	if !validLogForwardTargetName.MatchString(t.Name) {
+		return errors.NotValidf("target name %q", t.Name)
+	}
+	var err error
+	switch t.Type {
+	case LogForwardTypeSyslog:
+		if t.Syslog == nil {
+			return errors.NotValidf("syslog target without syslog config")
+		}
+		err = t.Syslog.Validate()
+	case LogForwardTypeOTLP:
+		if t.OTLP == nil {
+			return errors.NotValidf("OTLP target without OTLP config")
+		}
+		err = t.OTLP.Validate()
+	case LogForwardTypeLoki:
+		if t.Loki == nil {
+			return errors.NotValidf("Loki target without Loki config")
+		}
+		err = t.Loki.Validate()
+	default:
+		return errors.NotValidf("target type %q", t.Type)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(t.Filter.Validate(), "invalid filter")
+}
+
+// logForwardTargetSpec is the YAML representation of a named log
+// forwarding target in the logforward-targets model config value.
+type logForwardTargetSpec struct {
+	Name       string   `yaml:"name"`
+	Type       string   `yaml:"type"`
+	Endpoint   string   `yaml:"endpoint"`
+	Headers    string   `yaml:"headers,omitempty"`
+	CACert     string   `yaml:"ca-cert,omitempty"`
+	ClientCert string   `yaml:"client-cert,omitempty"`
+	ClientKey  string   `yaml:"client-key,omitempty"`
+	Level      string   `yaml:"level,omitempty"`
+	Modules    []string `yaml:"modules,omitempty"`
+	Entities   []string `yaml:"entities,omitempty"`
+}
+
+// target converts the spec into a target. The raw configs are always
+// enabled, as the targets are only used when log forwarding is enabled.
+func (spec logForwardTargetSpec) target() (LogForwardTarget, error) {
+	t := LogForwardTarget{
+		Name: spec.Name,
+		Type: spec.Type,
+		Filter: logfwd.Filter{
+			Modules:  spec.Modules,
+			Entities: spec.Entities,
+		},
+	}
+	if spec.Level != "" {
+		level, ok := loggo.ParseLevel(spec.Level)
+		if !ok {
+			return LogForwardTarget{}, errors.NotValidf("level %q", spec.Level)
+		}
+		t.Filter.Level = level
+	}
+	switch spec.Type {
+	case LogForwardTypeSyslog:
+		if spec.Headers != "" {
+			return LogForwardTarget{}, errors.NotValidf("headers for a syslog target")
+		}
+		t.Syslog = &syslog.RawConfig{
+			Enabled:    true,
+			Host:       spec.Endpoint,
+			CACert:     spec.CACert,
+			ClientCert: spec.ClientCert,
+			ClientKey:  spec.ClientKey,
+		}
+	case LogForwardTypeOTLP:
+		t.OTLP = &otlp.RawConfig{
+			Enabled:    true,
+			Endpoint:   spec.Endpoint,
+			Headers:    spec.Headers,
+			CACert:     spec.CACert,
+			ClientCert: spec.ClientCert,
+			ClientKey:  spec.ClientKey,
+		}
+	case LogForwardTypeLoki:
+		t.Loki = &loki.RawConfig{
+			Enabled:    true,
+			Endpoint:   spec.Endpoint,
+			Headers:    spec.Headers,
+			CACert:     spec.CACert,
+			ClientCert: spec.ClientCert,
+			ClientKey:  spec.ClientKey,
+		}
+	default:
+		return LogForwardTarget{}, errors.NotValidf("target type %q", spec.Type)
+	}
+	return t, nil
+}
+
+// ParseLogForwardTargets parses the named log forwarding targets from
+// the YAML list held by the logforward-targets model config value.
+func ParseLogForwardTargets(in string) ([]LogForwardTarget, error) {
+	if strings.TrimSpace(in) == "" {
+		return nil, nil
+	}
+	var specs []logForwardTargetSpec
+	if err := yaml.UnmarshalStrict([]byte(in), &specs); err != nil {
+		return nil, errors.Annotate(err, "parsing log forwarding targets")
+	}
+	targets := make([]LogForwardTarget, len(specs))
+	for i, spec := range specs {
+		target, err := spec.target()
+		if err != nil {
+			return nil, errors.Annotatef(err, "log forwarding target %q", spec.Name)
+		}
+		targets[i] = target
+	}
+	return targets, nil
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Object is an object held by a Server.
+type Object struct {
+	Data     []byte
+	ETag     string
+	Metadata map[string]string
+}
+
+// Server is an in-memory stand-in for an S3-compatible object store,
+// such as MinIO. It supports path-style multipart uploads and enough
+// of the rest of the S3 API to read back what was written. Request
+// signatures are not checked, but the access key of the last request
+// is recorded.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	buckets   map[string]map[string]Object
+	uploads   map[string]*upload
+	nextID    int
+	accessKey string
+
+	// OpaqueETags causes the server to report entity tags which are
+	// not MD5 digests of the content, as S3 does for objects
+	// encrypted with SSE-KMS or SSE-C.
+	OpaqueETags bool
+
+	// TruncateObjects causes the server to drop the last byte of
+	// each object when a multipart upload is completed.
+	TruncateObjects bool
+}
+
+type upload struct {
+	bucket, key string
+	metadata    map[string]string
+	parts       map[int][]byte
+}
+
+// NewServer returns a running Server holding the given empty buckets.
+func NewServer(buckets ...string) *Server {
+	s := &Server{
+		buckets: make(map[string]map[string]Object),
+		uploads: make(map[string]*upload),
+	}
+	for _, bucket := range buckets {
+		s.buckets[bucket] = make(map[string]Object)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Object returns the named object, if it exists.
+func (s *Server) Object(bucket, key string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.buckets[bucket][key]
+	return obj, ok
+}
+
+// Keys returns the keys of the objects in the bucket, sorted.
+func (s *Server) Keys(bucket string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for key := range s.buckets[bucket] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// PendingUploads returns the number of multipart uploads which have
+// been started but neither completed nor aborted.
+func (s *Server) PendingUploads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.uploads)
+}
+
+// AccessKey returns the access key used to sign the last request.
+func (s *Server) AccessKey() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accessKey
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessKey = accessKey(req.Header.Get("Authorization"))
+
+	path := strings.TrimPrefix(req.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+	objects, ok := s.buckets[bucket]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	query := req.URL.Query()
+	switch {
+	case req.Method == http.MethodPost && query.Has("uploads"):
+		s.createUpload(w, req, bucket, key)
+	case req.Method == http.MethodPut && query.Has("uploadId"):
+		s.uploadPart(w, req, query.Get("uploadId"), query.Get("partNumber"))
+	case req.Method == http.MethodPost && query.Has("uploadId"):
+		s.completeUpload(w, req, query.Get("uploadId"))
+	case req.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(s.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case req.Method == http.MethodDelete:
+		delete(objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	case req.Method == http.MethodHead || req.Method == http.MethodGet:
+		obj, ok := objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		for k, v := range obj.Metadata {
+			w.Header().Set("X-Amz-Meta-"+k, v)
+		}
+		w.Header().Set("ETag", strconv.Quote(obj.ETag))
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.Data)))
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(obj.Data)
+		}
+	default:
+		writeError(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (s *Server) createUpload(w http.ResponseWriter, req *http.Request, bucket, key string) {
+	metadata := make(map[string]string)
+	for k, v := range req.Header {
+		if name, ok := strings.CutPrefix(strings.ToLower(k), "x-amz-meta-"); ok {
+			metadata[name] = v[0]
+		}
+	}
+	s.nextID++
+	id := fmt.Sprintf("upload-%d", s.nextID)
+	s.uploads[id] = &upload{
+		bucket:   bucket,
+		key:      key,
+		metadata: metadata,
+		parts:    make(map[int][]byte),
+	}
+	writeXML(w, struct {
+		XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+		Bucket   string
+		Key      string
+		UploadId string
+	}{Bucket: bucket, Key: key, UploadId: id})
+}
+
+func (s *Server) uploadPart(w http.ResponseWriter, req *http.Request, id, partNumber string) {
+	up, ok := s.uploads[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+	n, err := strconv.Atoi(partNumber)
+	if err != nil || n < 1 {
+		writeError(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "IncompleteBody")
+		return
+	}
+	sum := md5.Sum(data)
+	if want := req.Header.Get("Content-MD5"); want != "" && want != base64.StdEncoding.EncodeToString(sum[:]) {
+		writeError(w, http.StatusBadRequest, "BadDigest")
+		return
+	}
+	up.parts[n] = data
+	etag := hex.EncodeToString(sum[:])
+	if s.OpaqueETags {
+		etag = s.opaqueETag()
+	}
+	w.Header().Set("ETag", strconv.Quote(etag))
+}
+
+func (s *Server) completeUpload(w http.ResponseWriter, req *http.Request, id string) {
+	up, ok := s.uploads[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+	var body struct {
+		Parts []struct {
+			PartNumber int
+		} `xml:"Part"`
+	}
+	if err := xml.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+	var data, sums []byte
+	for _, part := range body.Parts {
+		content, ok := up.parts[part.PartNumber]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "InvalidPart")
+			return
+		}
+		sum := md5.Sum(content)
+		data = append(data, content...)
+		sums = append(sums, sum[:]...)
+	}
+	delete(s.uploads, id)
+
+	etag := fmt.Sprintf("%x-%d", md5.Sum(sums), len(body.Parts))
+	if s.OpaqueETags {
+		etag = s.opaqueETag()
+	}
+	if s.TruncateObjects && len(data) > 0 {
+		data = data[:len(data)-1]
+	}
+	s.buckets[up.bucket][up.key] = Object{
+		Data:     bytes.Clone(data),
+		ETag:     etag,
+		Metadata: up.metadata,
+	}
+	writeXML(w, struct {
+		XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+		Bucket  string
+		Key     string
+		ETag    string
+	}{Bucket: up.bucket, Key: up.key, ETag: strconv.Quote(etag)})
+}
+
+// opaqueETag returns an entity tag unrelated to any content.
+func (s *Server) opaqueETag() string {
+	s.nextID++
+	return fmt.Sprintf("%032x", s.nextID)
+}
+
+// accessKey extracts the access key from an AWS Signature Version 4
+// Authorization header.
+func accessKey(authorization string) string {
+	_, credential, ok := strings.Cut(authorization, "Credential=")
+	if !ok {
+		return ""
+	}
+	key, _, _ := strings.Cut(credential, "/")
+	return key
+}
+
+func writeXML(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: code})
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package s3client
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/juju/errors"
+)
+
+const (
+	// DefaultPartSize is the size of each part of a multipart upload.
+	DefaultPartSize = 16 * 1024 * 1024
+
+	// MinPartSize is the smallest part size accepted by S3 for
+	// any part other than the last.
+	MinPartSize = 5 * 1024 * 1024
+
+	// defaultRegion is used to sign requests when no region is given.
+	// Most S3-compatible stores ignore the region altogether.
+	defaultRegion = "us-east-1"
+)
+
+// UploadResult describes an object written to an S3 object store.
+type UploadResult struct {
+	// Size is the number of bytes written.
+	Size int64
+
+	// ETag is the entity tag of the object as reported by the store.
+	// Its format depends on the store and on how the object is
+	// encrypted, so it is not a checksum of the content.
+	ETag string
+
+	// Parts is the number of parts the object was uploaded in.
+	Parts int
+}
+
+// Uploader represents the interface objectsClient exports to upload
+// objects to S3.
+type Uploader interface {
+	PutObject(ctx context.Context, bucketName, objectName string, body io.Reader, metadata map[string]string) (UploadResult, error)
+}
+
+// PutObject uploads the content of body to an S3 object store using a
+// multipart upload, so that the size of the object need not be known
+// in advance. Each part is sent with its MD5 digest for the store to
+// check, and the size of the completed object is checked against the
+// size uploaded. On failure the upload is aborted, or the object is
+// removed if the upload had already completed.
+func (c *objectsClient) PutObject(
+	ctx context.Context, bucketName, objectName string, body io.Reader, metadata map[string]string,
+) (_ UploadResult, err error) {
+	c.logger.Tracef("uploading bucket %s object %s to s3 storage", bucketName, objectName)
+
+	partSize := c.partSize
+	if partSize <= 0 {
+		partSize = DefaultPartSize
+	}
+
+	created, err := c.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(objectName),
+		Metadata: metadata,
+	})
+	if err != nil {
+		return UploadResult{}, errors.Annotatef(err, "unable to start upload of object %s to bucket %s", objectName, bucketName)
+	}
+	uploadID := created.UploadId
+
+	completed := false
+	defer func() {
+		if err == nil || completed {
+			return
+		}
+		_, abortErr := c.client.AbortMultipartUpload(context.Background(), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucketName),
+			Key:      aws.String(objectName),
+			UploadId: uploadID,
+		})
+		if abortErr != nil {
+			c.logger.Warningf("unable to abort upload of object %s to bucket %s: %v", objectName, bucketName, abortErr)
+		}
+	}()
+
+	var (
+		size  int64
+		parts []types.CompletedPart
+	)
+	buf := make([]byte, partSize)
+	for partNumber := int32(1); ; partNumber++ {
+		n, readErr := io.ReadFull(body, buf)
+		if readErr == io.EOF && partNumber > 1 {
+			break
+		} else if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return UploadResult{}, errors.Annotate(readErr, "reading object content")
+		}
+
+		// The store rejects the part if its content doesn't match
+		// the digest. Part entity tags aren't compared with it, as
+		// they aren't MD5 digests with some server-side encryption
+		// and on some S3-compatible stores.
+		sum := md5.Sum(buf[:n])
+		out, err := c.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(bucketName),
+			Key:           aws.String(objectName),
+			UploadId:      uploadID,
+			PartNumber:    aws.Int32(partNumber),
+			Body:          bytes.NewReader(buf[:n]),
+			ContentLength: aws.Int64(int64(n)),
+			ContentMD5:    aws.String(base64.StdEncoding.EncodeToString(sum[:])),
+		})
+		if err != nil {
+			return UploadResult{}, errors.Annotatef(err, "unable to upload part %d of object %s", partNumber, objectName)
+		}
+		parts = append(parts, types.CompletedPart{
+			ETag:       out.ETag,
+			PartNumber: aws.Int32(partNumber),
+		})
+		size += int64(n)
+
+		// A short read means that was the last part.
+		if readErr != nil {
+			break
+		}
+	}
+
+	done, err := c.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(objectName),
+		UploadId: uploadID,
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: parts,
+		},
+	})
+	if err != nil {
+		return UploadResult{}, errors.Annotatef(err, "unable to complete upload of object %s to bucket %s", objectName, bucketName)
+	}
+	completed = true
+
+	if err := c.verifyObject(ctx, bucketName, objectName, size); err != nil {
+		_, deleteErr := c.client.DeleteObject(context.Background(), &s3.DeleteObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(objectName),
+		})
+		if deleteErr != nil {
+			c.logger.Warningf("unable to remove unverified object %s from bucket %s: %v", objectName, bucketName, deleteErr)
+		}
+		return UploadResult{}, errors.Trace(err)
+	}
+
+	return UploadResult{
+		Size:  size,
+		ETag:  trimETag(done.ETag),
+		Parts: len(parts),
+	}, nil
+}
+
+// verifyObject checks that the object in the bucket has the expected
+// size.
+func (c *objectsClient) verifyObject(ctx context.Context, bucketName, objectName string, size int64) error {
+	head, err := c.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(objectName),
+	})
+	if err != nil {
+		return errors.Annotatef(err, "unable to verify object %s on bucket %s", objectName, bucketName)
+	}
+	if got := aws.ToInt64(head.ContentLength); got != size {
+		return errors.Errorf("size mismatch for object %s: got %d, want %d", objectName, got, size)
+	}
+	return nil
+}
+
+// trimETag returns the entity tag without the surrounding quotes
+// which S3 includes in responses.
+func trimETag(etag *string) string {
+	return strings.Trim(aws.ToString(etag), `"`)
+}
+
+// NewS3ClientWithCredentials creates an S3 client for the S3-compatible
+// object store at endpoint, signing requests with the given static
+// credentials. Unlike NewS3Client, it is not tied to an API connection.
+func NewS3ClientWithCredentials(endpoint, region, accessKeyID, secretAccessKey string, logger Logger) (Uploader, error) {
+	if endpoint == "" {
+		return nil, errors.NotValidf("empty endpoint")
+	}
+	if region == "" {
+		region = defaultRegion
+	}
+
+	cfg, err := config.LoadDefaultConfig(
+		context.Background(),
+		config.WithLogger(&awsLogger{logger: logger}),
+		config.WithRegion(region),
+		config.WithEndpointResolver(&awsEndpointResolver{endpoint: endpoint}),
+		config.WithRetryer(func() aws.Retryer {
+			return retry.NewStandard(
+				func(o *retry.StandardOptions) {
+					o.MaxAttempts = 10
+					o.RateLimiter = unlimitedRateLimiter{}
+				},
+			)
+		}),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, "")),
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot load default config for s3 client")
+	}
+
+	return &objectsClient{
+		client: s3.NewFromConfig(cfg, func(o *s3.Options) {
+			o.UsePathStyle = true
+			// Parts are verified with Content-MD5, which every
+			// S3-compatible store supports, rather than the newer
+			// additional checksums which not all of them do.
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+			o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+		}),
+		logger:   logger,
+		partSize: DefaultPartSize,
+	}, nil
+}
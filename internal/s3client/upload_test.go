@@ -0,0 +1,153 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package s3client
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	s3testing "github.com/juju/juju/internal/s3client/testing"
+)
+
+type uploadSuite struct {
+	server *s3testing.Server
+}
+
+var _ = gc.Suite(&uploadSuite{})
+
+func (s *uploadSuite) SetUpTest(c *gc.C) {
+	s.server = s3testing.NewServer("backups")
+}
+
+func (s *uploadSuite) TearDownTest(c *gc.C) {
+	s.server.Close()
+}
+
+func (s *uploadSuite) newClient(c *gc.C) *objectsClient {
+	uploader, err := NewS3ClientWithCredentials(s.server.URL, "", "access-key", "secret-key", loggo.GetLogger("juju.testing.s3client"))
+	c.Assert(err, jc.ErrorIsNil)
+	cli := uploader.(*objectsClient)
+	cli.partSize = MinPartSize
+	return cli
+}
+
+func (s *uploadSuite) TestPutObjectMultipart(c *gc.C) {
+	data := bytes.Repeat([]byte("0123456789"), MinPartSize/5)
+
+	result, err := s.newClient(c).PutObject(context.Background(), "backups", "juju/archive.tar.gz",
+		bytes.NewReader(data), map[string]string{"sha1": "abc"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Size, gc.Equals, int64(len(data)))
+	c.Check(result.Parts, gc.Equals, 2)
+
+	obj, ok := s.server.Object("backups", "juju/archive.tar.gz")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(obj.Data, jc.DeepEquals, data)
+	c.Check(obj.ETag, gc.Equals, result.ETag)
+	c.Check(obj.Metadata, jc.DeepEquals, map[string]string{"sha1": "abc"})
+	c.Check(s.server.AccessKey(), gc.Equals, "access-key")
+	c.Check(s.server.PendingUploads(), gc.Equals, 0)
+}
+
+func (s *uploadSuite) TestPutObjectSinglePart(c *gc.C) {
+	result, err := s.newClient(c).PutObject(context.Background(), "backups", "small", strings.NewReader("small"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Size, gc.Equals, int64(5))
+	c.Check(result.Parts, gc.Equals, 1)
+
+	obj, ok := s.server.Object("backups", "small")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(string(obj.Data), gc.Equals, "small")
+}
+
+func (s *uploadSuite) TestPutObjectOpaqueETags(c *gc.C) {
+	s.server.OpaqueETags = true
+	data := bytes.Repeat([]byte("0123456789"), MinPartSize/5)
+
+	result, err := s.newClient(c).PutObject(context.Background(), "backups", "archive", bytes.NewReader(data), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Size, gc.Equals, int64(len(data)))
+
+	obj, ok := s.server.Object("backups", "archive")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(obj.Data, jc.DeepEquals, data)
+	c.Check(obj.ETag, gc.Equals, result.ETag)
+}
+
+func (s *uploadSuite) TestPutObjectSizeMismatchRemovesObject(c *gc.C) {
+	s.server.TruncateObjects = true
+
+	_, err := s.newClient(c).PutObject(context.Background(), "backups", "small", strings.NewReader("small"), nil)
+	c.Assert(err, gc.ErrorMatches, `size mismatch for object small: got 4, want 5`)
+
+	_, ok := s.server.Object("backups", "small")
+	c.Check(ok, jc.IsFalse)
+	c.Check(s.server.PendingUploads(), gc.Equals, 0)
+}
+
+func (s *uploadSuite) TestPutObjectNoSuchBucket(c *gc.C) {
+	_, err := s.newClient(c).PutObject(context.Background(), "missing", "small", strings.NewReader("small"), nil)
+	c.Assert(err, gc.ErrorMatches, `unable to start upload of object small to bucket missing: .*NoSuchBucket.*`)
+}
+
+func (s *uploadSuite) TestNewS3ClientWithCredentialsNoEndpoint(c *gc.C) {
+	_, err := NewS3ClientWithCredentials("", "", "access-key", "secret-key", loggo.GetLogger("juju.testing.s3client"))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *s3ClientSuite) TestPutObjectAbortsOnFailure(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.s3Client.EXPECT().CreateMultipartUpload(gomock.Any(), gomock.Any(), gomock.Any()).Return(&s3.CreateMultipartUploadOutput{
+		UploadId: aws.String("upload-id"),
+	}, nil)
+	s.s3Client.EXPECT().UploadPart(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+	s.s3Client.EXPECT().AbortMultipartUpload(gomock.Any(), &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String("bucket"),
+		Key:      aws.String("object"),
+		UploadId: aws.String("upload-id"),
+	}, gomock.Any()).Return(&s3.AbortMultipartUploadOutput{}, nil)
+
+	cli := objectsClient{
+		client: s.s3Client,
+		logger: loggo.GetLogger("juju.testing.s3client"),
+	}
+	_, err := cli.PutObject(context.Background(), "bucket", "object", strings.NewReader("blob"), nil)
+	c.Assert(err, gc.ErrorMatches, "unable to upload part 1 of object object: boom")
+}
+
+func (s *s3ClientSuite) TestPutObjectRemovesUnverifiedObject(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.s3Client.EXPECT().CreateMultipartUpload(gomock.Any(), gomock.Any(), gomock.Any()).Return(&s3.CreateMultipartUploadOutput{
+		UploadId: aws.String("upload-id"),
+	}, nil)
+	s.s3Client.EXPECT().UploadPart(gomock.Any(), gomock.Any(), gomock.Any()).Return(&s3.UploadPartOutput{
+		ETag: aws.String(`"etag"`),
+	}, nil)
+	s.s3Client.EXPECT().CompleteMultipartUpload(gomock.Any(), gomock.Any(), gomock.Any()).Return(&s3.CompleteMultipartUploadOutput{
+		ETag: aws.String(`"etag-1"`),
+	}, nil)
+	s.s3Client.EXPECT().HeadObject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+	s.s3Client.EXPECT().DeleteObject(gomock.Any(), &s3.DeleteObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("object"),
+	}, gomock.Any()).Return(&s3.DeleteObjectOutput{}, nil)
+
+	cli := objectsClient{
+		client: s.s3Client,
+		logger: loggo.GetLogger("juju.testing.s3client"),
+	}
+	_, err := cli.PutObject(context.Background(), "bucket", "object", strings.NewReader("blob"), nil)
+	c.Assert(err, gc.ErrorMatches, "unable to verify object object on bucket bucket: boom")
+}
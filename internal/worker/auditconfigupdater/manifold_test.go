@@ -126,6 +126,7 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 		ExcludeMethods: set.NewStrings("This.Method"),
 		MaxSizeMB:      10,
 		MaxBackups:     10,
+		Forward:        auditlog.ForwardConfig{Headers: map[string]string{}},
 	})
 
 	c.Assert(args[2], gc.NotNil)
@@ -95,6 +95,7 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Forward:        forwardConfig(cfg),
 	}
 	if result.Enabled && u.current.Target == nil {
 		result.Target = u.logFactory(result)
@@ -107,6 +108,19 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 	return result, nil
 }
 
+// forwardConfig returns the target audit log records are forwarded
+// to, from the controller config.
+func forwardConfig(cfg controller.Config) auditlog.ForwardConfig {
+	return auditlog.ForwardConfig{
+		Type:       cfg.AuditLogForwardType(),
+		Endpoint:   cfg.AuditLogForwardEndpoint(),
+		CACert:     cfg.AuditLogForwardCACert(),
+		ClientCert: cfg.AuditLogForwardClientCert(),
+		ClientKey:  cfg.AuditLogForwardClientKey(),
+		Headers:    cfg.AuditLogForwardHeaders(),
+	}
+}
+
 func (u *updater) update(newConfig auditlog.Config) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
@@ -66,6 +66,38 @@ func (s *updaterSuite) TestWorker(c *gc.C) {
 	c.Assert(calls, gc.HasLen, 1)
 }
 
+func (s *updaterSuite) TestChangingForward(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	initial := auditlog.Config{
+		Enabled: true,
+		Target:  &apitesting.FakeAuditLog{},
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-forward-type"] = "webhook"
+	cfg["audit-log-forward-endpoint"] = "https://audit.example.com/juju"
+	cfg["audit-log-forward-headers"] = "Authorization=Bearer xyz"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Forward.Enabled()
+	})
+	c.Assert(newConfig.Forward, jc.DeepEquals, auditlog.ForwardConfig{
+		Type:     "webhook",
+		Endpoint: "https://audit.example.com/juju",
+		Headers:  map[string]string{"Authorization": "Bearer xyz"},
+	})
+}
+
 func waitForConfig(c *gc.C, w worker.Worker, predicate func(auditlog.Config) bool) auditlog.Config {
 	for a := jujutesting.LongAttempt.Start(); a.Next(); {
 		config := getWorkerConfig(c, w)
@@ -0,0 +1,18 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlogforwarder provides a worker which forwards the
+// records written to a controller's audit log to the syslog host or
+// HTTP webhook set by the audit-log-forward-* controller config keys.
+//
+// The audit log files are the worker's buffer: records are read from
+// them, following the current file across rotation, and the sequence
+// number of the last record forwarded is recorded in the agent's data
+// directory. While the target is unavailable the records wait in the
+// audit log, and are sent once it can be reached again, so none are
+// lost unless the rotated files holding them are removed first. The
+// position is kept while forwarding is disabled, so the records written
+// meanwhile are sent when it is enabled again. Records may be sent more
+// than once, and their sequence numbers and hashes let the target
+// recognise duplicates and check the chain.
+package auditlogforwarder
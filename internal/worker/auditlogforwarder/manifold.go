@@ -0,0 +1,117 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlogforwarder
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/dependency"
+
+	jujuagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	jujuversion "github.com/juju/juju/version"
+)
+
+const (
+	// DefaultPollInterval is how often the worker reads the audit
+	// log for new records when run by the manifold.
+	DefaultPollInterval = 5 * time.Second
+
+	// DefaultBatchSize is the most records sent at once when run by
+	// the manifold.
+	DefaultBatchSize = 500
+
+	// positionFileName is the name of the file in the agent data
+	// directory recording the last record forwarded.
+	positionFileName = "audit-log-forwarder-position"
+)
+
+// ManifoldConfig holds dependencies and configuration for an
+// auditlogforwarder worker.
+type ManifoldConfig struct {
+	AgentName              string
+	AuditConfigUpdaterName string
+	Clock                  clock.Clock
+	Logger                 Logger
+
+	OpenSink  func(auditlog.ForwardConfig, logfwd.Origin, clock.Clock) (Sink, error)
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate validates a manifold config.
+func (c ManifoldConfig) Validate() error {
+	if c.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if c.AuditConfigUpdaterName == "" {
+		return errors.NotValidf("empty AuditConfigUpdaterName")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if c.OpenSink == nil {
+		return errors.NotValidf("nil OpenSink")
+	}
+	if c.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that runs an
+// auditlogforwarder worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.AuditConfigUpdaterName,
+		},
+		Start: config.start,
+	}
+}
+
+func (c ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := c.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var agent jujuagent.Agent
+	if err := context.Get(c.AgentName, &agent); err != nil {
+		return nil, err
+	}
+	var auditConfig func() auditlog.Config
+	if err := context.Get(c.AuditConfigUpdaterName, &auditConfig); err != nil {
+		return nil, err
+	}
+
+	agentConfig := agent.CurrentConfig()
+	tag, ok := agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected a machine agent, got %q", agentConfig.Tag())
+	}
+	origin := logfwd.OriginForMachineAgent(
+		tag, agentConfig.Controller().Id(), agentConfig.Model().Id(), jujuversion.Current)
+
+	return c.NewWorker(Config{
+		LogDir:       agentConfig.LogDir(),
+		PositionFile: filepath.Join(agentConfig.DataDir(), positionFileName),
+		AuditConfig:  auditConfig,
+		OpenSink: func(cfg auditlog.ForwardConfig) (Sink, error) {
+			return c.OpenSink(cfg, origin, c.Clock)
+		},
+		Clock:        c.Clock,
+		Logger:       c.Logger,
+		PollInterval: DefaultPollInterval,
+		BatchSize:    DefaultBatchSize,
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlogforwarder_test
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/dependency"
+	dt "github.com/juju/worker/v3/dependency/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/internal/worker/auditlogforwarder"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	config auditlogforwarder.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = auditlogforwarder.ManifoldConfig{
+		AgentName:              "agent",
+		AuditConfigUpdaterName: "audit-config-updater",
+		Clock:                  testclock.NewClock(coretesting.ZeroTime()),
+		Logger:                 loggo.GetLogger("test"),
+		OpenSink: func(auditlog.ForwardConfig, logfwd.Origin, clock.Clock) (auditlogforwarder.Sink, error) {
+			return nil, errors.New("not expected")
+		},
+		NewWorker: func(auditlogforwarder.Config) (worker.Worker, error) {
+			return nil, errors.New("not expected")
+		},
+	}
+}
+
+func (s *ManifoldSuite) TestValid(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ManifoldSuite) TestMissingAgentName(c *gc.C) {
+	s.config.AgentName = ""
+	s.checkNotValid(c, "empty AgentName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingAuditConfigUpdaterName(c *gc.C) {
+	s.config.AuditConfigUpdaterName = ""
+	s.checkNotValid(c, "empty AuditConfigUpdaterName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingClock(c *gc.C) {
+	s.config.Clock = nil
+	s.checkNotValid(c, "nil Clock not valid")
+}
+
+func (s *ManifoldSuite) TestMissingLogger(c *gc.C) {
+	s.config.Logger = nil
+	s.checkNotValid(c, "nil Logger not valid")
+}
+
+func (s *ManifoldSuite) TestMissingOpenSink(c *gc.C) {
+	s.config.OpenSink = nil
+	s.checkNotValid(c, "nil OpenSink not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
+	s.config.NewWorker = nil
+	s.checkNotValid(c, "nil NewWorker not valid")
+}
+
+func (s *ManifoldSuite) checkNotValid(c *gc.C, expect string) {
+	err := s.config.Validate()
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := auditlogforwarder.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"agent", "audit-config-updater"})
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	var got auditlogforwarder.Config
+	s.config.NewWorker = func(config auditlogforwarder.Config) (worker.Worker, error) {
+		got = config
+		return worker.NewRunner(worker.RunnerParams{}), nil
+	}
+	var origin logfwd.Origin
+	s.config.OpenSink = func(_ auditlog.ForwardConfig, o logfwd.Origin, _ clock.Clock) (auditlogforwarder.Sink, error) {
+		origin = o
+		return nil, nil
+	}
+	auditConfig := func() auditlog.Config { return auditlog.Config{} }
+	manifold := auditlogforwarder.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent": &mockAgent{conf: mockAgentConfig{
+			tag:     names.NewMachineTag("0"),
+			logDir:  "/var/log/juju",
+			dataDir: "/var/lib/juju",
+		}},
+		"audit-config-updater": auditConfig,
+	})
+	w, err := manifold.Start(context)
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(w)
+
+	c.Check(got.LogDir, gc.Equals, "/var/log/juju")
+	c.Check(got.PositionFile, gc.Equals, "/var/lib/juju/audit-log-forwarder-position")
+	c.Check(got.AuditConfig, gc.NotNil)
+	c.Check(got.Clock, gc.Equals, s.config.Clock)
+	c.Check(got.PollInterval, gc.Equals, auditlogforwarder.DefaultPollInterval)
+	c.Check(got.BatchSize, gc.Equals, auditlogforwarder.DefaultBatchSize)
+
+	_, err = got.OpenSink(auditlog.ForwardConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(origin.Hostname, gc.Equals, "machine-0."+coretesting.ModelTag.Id())
+	c.Check(origin.ControllerUUID, gc.Equals, coretesting.ControllerTag.Id())
+}
+
+func (s *ManifoldSuite) TestStartMissingAuditConfig(c *gc.C) {
+	manifold := auditlogforwarder.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":                &mockAgent{},
+		"audit-config-updater": dependency.ErrMissing,
+	})
+	_, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+type mockAgent struct {
+	agent.Agent
+	conf mockAgentConfig
+}
+
+func (ma *mockAgent) CurrentConfig() agent.Config {
+	return &ma.conf
+}
+
+type mockAgentConfig struct {
+	agent.Config
+	tag     names.Tag
+	logDir  string
+	dataDir string
+}
+
+func (c *mockAgentConfig) Tag() names.Tag {
+	return c.tag
+}
+
+func (c *mockAgentConfig) LogDir() string {
+	return c.logDir
+}
+
+func (c *mockAgentConfig) DataDir() string {
+	return c.dataDir
+}
+
+func (c *mockAgentConfig) Controller() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (c *mockAgentConfig) Model() names.ModelTag {
+	return coretesting.ModelTag
+}
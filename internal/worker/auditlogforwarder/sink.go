@@ -0,0 +1,217 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlogforwarder
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/sdelements"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httppush"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// Sink sends audit log records to a forwarding target.
+type Sink interface {
+	// Send sends the records to the target, in order. Records are
+	// sent again if sending them fails, so the target may receive
+	// them more than once.
+	Send(records []auditlog.Record) error
+
+	// Close closes the connection to the target.
+	Close() error
+}
+
+// OpenSink opens a Sink which sends audit log records to the target
+// in the config, on behalf of the controller agent with the origin.
+func OpenSink(cfg auditlog.ForwardConfig, origin logfwd.Origin, clock clock.Clock) (Sink, error) {
+	switch cfg.Type {
+	case controller.AuditLogForwardTypeSyslog:
+		client, err := syslog.Open(syslog.RawConfig{
+			Enabled:    true,
+			Host:       cfg.Endpoint,
+			CACert:     cfg.CACert,
+			ClientCert: cfg.ClientCert,
+			ClientKey:  cfg.ClientKey,
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "opening syslog connection")
+		}
+		return NewSyslogSink(client.Sender, origin), nil
+	case controller.AuditLogForwardTypeWebhook:
+		tlsCfg, err := httppush.TLSConfig(cfg.CACert, cfg.ClientCert, cfg.ClientKey)
+		if err != nil {
+			return nil, errors.Annotate(err, "constructing TLS config")
+		}
+		sink, err := NewWebhookSink(cfg, httppush.NewHTTPClient(tlsCfg), clock)
+		return sink, errors.Trace(err)
+	}
+	return nil, errors.NotValidf("audit log forward type %q", cfg.Type)
+}
+
+// appName identifies audit log records among the messages sent to a
+// syslog host.
+const appName = "juju-audit"
+
+// NewSyslogSink returns a Sink which sends every audit log record as
+// an RFC 5424 message through the sender.
+func NewSyslogSink(sender syslog.Sender, origin logfwd.Origin) Sink {
+	return &syslogSink{
+		sender: sender,
+		origin: origin,
+	}
+}
+
+type syslogSink struct {
+	sender syslog.Sender
+	origin logfwd.Origin
+}
+
+// Send is part of the Sink interface.
+func (s *syslogSink) Send(records []auditlog.Record) error {
+	for _, record := range records {
+		msg, err := s.message(record)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := s.sender.Send(msg); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// message returns the syslog message holding the record. The message
+// is the record as written to the audit log, and its sequence number
+// and hash are also held in the structured data.
+func (s *syslogSink) message(record auditlog.Record) (rfc5424.Message, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return rfc5424.Message{}, errors.Trace(err)
+	}
+	pen := sdelements.PrivateEnterpriseNumber(s.origin.Software.PrivateEnterpriseNumber)
+	msg := rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityInformational,
+				Facility: rfc5424.FacilityAuthpriv,
+			},
+			Timestamp: rfc5424.Timestamp{Time: recordTime(record)},
+			Hostname: rfc5424.Hostname{
+				FQDN: s.origin.Hostname,
+			},
+			AppName: appName,
+		},
+		StructuredData: rfc5424.StructuredData{
+			&sdelements.Private{
+				Name: "audit",
+				PEN:  pen,
+				Data: []rfc5424.StructuredDataParam{{
+					Name:  "controller-uuid",
+					Value: rfc5424.StructuredDataParamValue(s.origin.ControllerUUID),
+				}, {
+					Name:  "sequence",
+					Value: rfc5424.StructuredDataParamValue(strconv.FormatUint(record.Sequence(), 10)),
+				}, {
+					Name:  "hash",
+					Value: rfc5424.StructuredDataParamValue(record.Hash()),
+				}},
+			},
+		},
+		Msg: string(data),
+	}
+	if record.Errors != nil && len(record.Errors.Errors) > 0 {
+		msg.Priority.Severity = rfc5424.SeverityWarning
+	}
+	if err := msg.Validate(); err != nil {
+		return msg, errors.Trace(err)
+	}
+	return msg, nil
+}
+
+// Close is part of the Sink interface.
+func (s *syslogSink) Close() error {
+	return errors.Trace(s.sender.Close())
+}
+
+// recordTime returns when the record was written, or the zero time if
+// it can't be read.
+func recordTime(record auditlog.Record) time.Time {
+	var when string
+	switch {
+	case record.Conversation != nil:
+		when = record.Conversation.When
+	case record.Request != nil:
+		when = record.Request.When
+	case record.Errors != nil:
+		when = record.Errors.When
+	}
+	t, _ := time.Parse(time.RFC3339, when)
+	return t
+}
+
+// webhookContentType is the media type of the records posted to a
+// webhook: one JSON record per line.
+const webhookContentType = "application/x-ndjson"
+
+// NewWebhookSink returns a Sink which posts audit log records to the
+// webhook in the config, one JSON record per line as written to the
+// audit log.
+func NewWebhookSink(cfg auditlog.ForwardConfig, client httppush.HTTPClient, clock clock.Clock) (Sink, error) {
+	u, err := httppush.ParseEndpoint(cfg.Endpoint, "/")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Failed requests aren't retried here; the worker sends the
+	// records again when it next polls.
+	pusher, err := httppush.NewPusher(httppush.PusherConfig{
+		Name:          "audit log webhook",
+		URL:           u.String(),
+		Headers:       cfg.Headers,
+		HTTPClient:    client,
+		Clock:         clock,
+		RetryAttempts: 1,
+		RetryDelay:    httppush.DefaultRetryDelay,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &webhookSink{pusher: pusher}, nil
+}
+
+type webhookSink struct {
+	pusher *httppush.Pusher
+}
+
+// Send is part of the Sink interface.
+func (s *webhookSink) Send(records []auditlog.Record) error {
+	var body bytes.Buffer
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		body.Write(data)
+		body.WriteByte('\n')
+	}
+	err := s.pusher.Push(httppush.Request{
+		Body:        body.Bytes(),
+		ContentType: webhookContentType,
+	})
+	return errors.Trace(err)
+}
+
+// Close is part of the Sink interface.
+func (s *webhookSink) Close() error {
+	return errors.Trace(s.pusher.Close())
+}
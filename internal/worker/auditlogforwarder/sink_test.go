@@ -0,0 +1,170 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlogforwarder_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/sdelements"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/internal/worker/auditlogforwarder"
+	"github.com/juju/juju/logfwd"
+)
+
+type SinkSuite struct {
+	testing.IsolationSuite
+
+	records []auditlog.Record
+	origin  logfwd.Origin
+}
+
+var _ = gc.Suite(&SinkSuite{})
+
+func (s *SinkSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.records = []auditlog.Record{{
+		Conversation: &auditlog.Conversation{
+			Who: "bob", What: "juju deploy mysql", When: "2025-03-01T10:00:00Z",
+			ConversationID: "c1", ConnectionID: "A1", Sequence: 7, Hash: "abc",
+		},
+	}, {
+		Errors: &auditlog.ResponseErrors{
+			ConversationID: "c1", ConnectionID: "A1", RequestID: 1, When: "2025-03-01T10:00:01Z",
+			Errors:   []*auditlog.Error{{Message: "permission denied", Code: "unauthorized access"}},
+			Sequence: 8, Hash: "def",
+		},
+	}}
+	s.origin = logfwd.OriginForMachineAgent(
+		names.NewMachineTag("0"),
+		"deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"deadbeef-0bad-400d-8000-4b1d0d06f00e",
+		version.MustParse("3.6.0"),
+	)
+}
+
+func (s *SinkSuite) TestSyslog(c *gc.C) {
+	sender := &fakeSender{}
+	sink := auditlogforwarder.NewSyslogSink(sender, s.origin)
+	c.Assert(sink.Send(s.records), jc.ErrorIsNil)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+	c.Check(sender.closed, jc.IsTrue)
+
+	c.Assert(sender.messages, gc.HasLen, 2)
+	msg := sender.messages[0]
+	c.Check(msg.Priority, gc.Equals, rfc5424.Priority{
+		Severity: rfc5424.SeverityInformational,
+		Facility: rfc5424.FacilityAuthpriv,
+	})
+	c.Check(msg.Timestamp.Time, gc.Equals, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))
+	c.Check(msg.Hostname.FQDN, gc.Equals, "machine-0.deadbeef-0bad-400d-8000-4b1d0d06f00e")
+	c.Check(msg.AppName, gc.Equals, rfc5424.AppName("juju-audit"))
+	c.Check(msg.StructuredData, jc.DeepEquals, rfc5424.StructuredData{
+		&sdelements.Private{
+			Name: "audit",
+			PEN:  28978,
+			Data: []rfc5424.StructuredDataParam{
+				{Name: "controller-uuid", Value: "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+				{Name: "sequence", Value: "7"},
+				{Name: "hash", Value: "abc"},
+			},
+		},
+	})
+	c.Check(msg.Msg, gc.Equals, `{"conversation":{"who":"bob","what":"juju deploy mysql",`+
+		`"when":"2025-03-01T10:00:00Z","model-name":"","model-uuid":"","conversation-id":"c1",`+
+		`"connection-id":"A1","sequence":7,"hash":"abc"}}`)
+
+	// Records of failed requests are warnings.
+	c.Check(sender.messages[1].Priority.Severity, gc.Equals, rfc5424.SeverityWarning)
+}
+
+func (s *SinkSuite) TestSyslogSendError(c *gc.C) {
+	sender := &fakeSender{err: errors.New("broken pipe")}
+	sink := auditlogforwarder.NewSyslogSink(sender, s.origin)
+	err := sink.Send(s.records)
+	c.Assert(err, gc.ErrorMatches, "broken pipe")
+}
+
+func (s *SinkSuite) TestWebhook(c *gc.C) {
+	var (
+		header http.Header
+		body   []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	sink, err := auditlogforwarder.OpenSink(auditlog.ForwardConfig{
+		Type:     "webhook",
+		Endpoint: server.URL + "/audit",
+		Headers:  map[string]string{"Authorization": "Bearer xyz"},
+	}, s.origin, testclock.NewClock(time.Now()))
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	c.Assert(sink.Send(s.records), jc.ErrorIsNil)
+	c.Check(header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+	c.Check(header.Get("Authorization"), gc.Equals, "Bearer xyz")
+	c.Check(string(body), gc.Equals, ``+
+		`{"conversation":{"who":"bob","what":"juju deploy mysql","when":"2025-03-01T10:00:00Z",`+
+		`"model-name":"","model-uuid":"","conversation-id":"c1","connection-id":"A1","sequence":7,"hash":"abc"}}`+"\n"+
+		`{"errors":{"conversation-id":"c1","connection-id":"A1","request-id":1,"when":"2025-03-01T10:00:01Z",`+
+		`"errors":[{"message":"permission denied","code":"unauthorized access"}],"sequence":8,"hash":"def"}}`+"\n")
+}
+
+func (s *SinkSuite) TestWebhookError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink, err := auditlogforwarder.OpenSink(auditlog.ForwardConfig{
+		Type:     "webhook",
+		Endpoint: server.URL,
+	}, s.origin, testclock.NewClock(time.Now()))
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	err = sink.Send(s.records)
+	c.Assert(err, gc.ErrorMatches, "audit log webhook returned 503 Service Unavailable: nope")
+}
+
+func (s *SinkSuite) TestOpenSinkUnknownType(c *gc.C) {
+	_, err := auditlogforwarder.OpenSink(auditlog.ForwardConfig{
+		Type: "carrier-pigeon",
+	}, s.origin, testclock.NewClock(time.Now()))
+	c.Assert(err, gc.ErrorMatches, `audit log forward type "carrier-pigeon" not valid`)
+}
+
+type fakeSender struct {
+	messages []rfc5424.Message
+	err      error
+	closed   bool
+}
+
+func (f *fakeSender) Send(msg rfc5424.Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.messages = append(f.messages, msg)
+	return nil
+}
+
+func (f *fakeSender) Close() error {
+	f.closed = true
+	return nil
+}
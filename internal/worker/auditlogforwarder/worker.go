@@ -0,0 +1,316 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlogforwarder
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/utils/v3"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Config defines the operation of the Worker.
+type Config struct {
+	// LogDir is the directory holding the audit log.
+	LogDir string
+
+	// PositionFile is the path of the file recording the sequence
+	// number of the last record forwarded.
+	PositionFile string
+
+	// AuditConfig returns the current audit log config, which holds
+	// the target records are forwarded to.
+	AuditConfig func() auditlog.Config
+
+	// OpenSink opens a Sink sending records to the target.
+	OpenSink func(auditlog.ForwardConfig) (Sink, error)
+
+	Clock  clock.Clock
+	Logger Logger
+
+	// PollInterval is how often the audit log is read for new
+	// records, and failed sends are retried.
+	PollInterval time.Duration
+
+	// BatchSize is the most records sent to the target at once.
+	BatchSize int
+}
+
+// Validate returns an error if config cannot drive the Worker.
+func (config Config) Validate() error {
+	if config.LogDir == "" {
+		return errors.NotValidf("empty LogDir")
+	}
+	if config.PositionFile == "" {
+		return errors.NotValidf("empty PositionFile")
+	}
+	if config.AuditConfig == nil {
+		return errors.NotValidf("nil AuditConfig")
+	}
+	if config.OpenSink == nil {
+		return errors.NotValidf("nil OpenSink")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	if config.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which forwards the records written to the
+// audit log to the configured target.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Worker forwards audit log records to the configured target.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	sink       Sink
+	sinkConfig auditlog.ForwardConfig
+
+	tailer *auditlog.Tailer
+
+	// position is the sequence number of the last record forwarded,
+	// and pending holds the records read but not yet forwarded.
+	position uint64
+	pending  []auditlog.Record
+
+	// rescanned is true when the tailer has been reopened to look
+	// for records missing after position in the rotated files.
+	rescanned bool
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	defer w.closeSink()
+	defer w.closeTailer()
+	for {
+		if err := w.forward(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.PollInterval):
+		}
+	}
+}
+
+// forward sends the records written since the last poll to the target.
+// Records which can't be sent are kept, and sent again on the next
+// poll.
+func (w *Worker) forward() error {
+	cfg := w.config.AuditConfig().Forward
+	if !cfg.Enabled() {
+		if w.sink != nil {
+			w.config.Logger.Infof("audit log forwarding disabled")
+			w.closeSink()
+		}
+		// The position is kept, so the records written while
+		// forwarding is disabled are sent when it is enabled again.
+		w.closeTailer()
+		return nil
+	}
+
+	if w.sink == nil || !reflect.DeepEqual(cfg, w.sinkConfig) {
+		w.closeSink()
+		sink, err := w.config.OpenSink(cfg)
+		if err != nil {
+			w.config.Logger.Errorf("cannot forward audit log records to %s %q: %v", cfg.Type, cfg.Endpoint, err)
+			return nil
+		}
+		w.sink, w.sinkConfig = sink, cfg
+		w.config.Logger.Infof("forwarding audit log records to %s %q", cfg.Type, cfg.Endpoint)
+	}
+
+	if w.tailer == nil {
+		if err := w.openTailer(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for {
+		if len(w.pending) == 0 {
+			records, err := w.tailer.Next(w.config.BatchSize)
+			if err != nil {
+				return errors.Annotate(err, "reading audit log")
+			}
+			if w.missing(records) && !w.rescanned {
+				// The missing records may have been rotated out of
+				// the file being followed before they were read, so
+				// look for them in the rotated files.
+				w.config.Logger.Debugf("audit log records after %d missing, reading rotated files", w.position)
+				w.closeTailer()
+				if err := w.openTailer(); err != nil {
+					return errors.Trace(err)
+				}
+				w.rescanned = true
+				continue
+			}
+			w.rescanned = false
+			w.checkGap(records)
+			w.pending = records
+		}
+		if len(w.pending) == 0 {
+			return nil
+		}
+
+		if err := w.sink.Send(w.pending); err != nil {
+			// The connection is opened again on the next poll.
+			w.config.Logger.Warningf("cannot forward audit log records (will retry): %v", err)
+			w.closeSink()
+			return nil
+		}
+		w.position = w.pending[len(w.pending)-1].Sequence()
+		w.pending = nil
+		if err := w.writePosition(); err != nil {
+			return errors.Trace(err)
+		}
+
+		select {
+		case <-w.catacomb.Dying():
+			return nil
+		default:
+		}
+	}
+}
+
+// openTailer starts reading the audit log after the last record
+// forwarded. When forwarding starts, the records already written to
+// the audit log are not sent.
+func (w *Worker) openTailer() error {
+	position, found, err := w.readPosition()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !found {
+		position, err = auditlog.LastSequence(w.config.LogDir)
+		if err != nil {
+			return errors.Annotate(err, "reading audit log")
+		}
+	}
+	w.tailer, err = auditlog.NewTailer(w.config.LogDir, position)
+	if err != nil {
+		return errors.Annotate(err, "reading audit log")
+	}
+	w.position = position
+	return errors.Trace(w.writePosition())
+}
+
+// missing reports whether records were skipped between the last
+// record forwarded and the records, or within them.
+func (w *Worker) missing(records []auditlog.Record) bool {
+	previous := w.position
+	for _, record := range records {
+		sequence := record.Sequence()
+		if sequence > previous+1 {
+			return true
+		}
+		previous = sequence
+	}
+	return false
+}
+
+// checkGap logs the records that were removed from the audit log,
+// by rotation, before they could be forwarded.
+func (w *Worker) checkGap(records []auditlog.Record) {
+	previous := w.position
+	for _, record := range records {
+		sequence := record.Sequence()
+		switch {
+		case sequence == previous+1:
+		case sequence > previous+1:
+			w.config.Logger.Warningf("audit log records %d to %d were removed before they could be forwarded",
+				previous+1, sequence-1)
+		default:
+			w.config.Logger.Infof("audit log restarted at record %d", sequence)
+		}
+		previous = sequence
+	}
+}
+
+func (w *Worker) readPosition() (uint64, bool, error) {
+	data, err := os.ReadFile(w.config.PositionFile)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, errors.Annotate(err, "reading audit log forwarding position")
+	}
+	position, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		w.config.Logger.Warningf("ignoring invalid audit log forwarding position %q", data)
+		return 0, false, nil
+	}
+	return position, true, nil
+}
+
+func (w *Worker) writePosition() error {
+	data := []byte(strconv.FormatUint(w.position, 10) + "\n")
+	err := utils.AtomicWriteFile(w.config.PositionFile, data, 0600)
+	return errors.Annotate(err, "writing audit log forwarding position")
+}
+
+func (w *Worker) closeSink() {
+	if w.sink == nil {
+		return
+	}
+	if err := w.sink.Close(); err != nil {
+		w.config.Logger.Debugf("closing audit log forwarding connection: %v", err)
+	}
+	w.sink = nil
+}
+
+func (w *Worker) closeTailer() {
+	if w.tailer == nil {
+		return
+	}
+	_ = w.tailer.Close()
+	w.tailer = nil
+	w.pending = nil
+}
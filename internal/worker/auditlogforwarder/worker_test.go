@@ -0,0 +1,331 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlogforwarder_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/internal/worker/auditlogforwarder"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const pollInterval = 5 * time.Second
+
+var webhook = auditlog.ForwardConfig{
+	Type:     "webhook",
+	Endpoint: "https://audit.example.com/juju",
+}
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock        *testclock.Clock
+	logDir       string
+	positionFile string
+	logFile      auditlog.AuditLog
+	count        int
+
+	mu      sync.Mutex
+	forward auditlog.ForwardConfig
+	sendErr error
+	calls   chan string
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))
+	s.logDir = c.MkDir()
+	s.positionFile = filepath.Join(c.MkDir(), "position")
+	s.logFile = auditlog.NewLogFile(s.logDir, 300, 10)
+	s.AddCleanup(func(*gc.C) { _ = s.logFile.Close() })
+	s.count = 0
+	s.forward = webhook
+	s.sendErr = nil
+	s.calls = make(chan string, 10)
+}
+
+func (s *WorkerSuite) config() auditlogforwarder.Config {
+	return auditlogforwarder.Config{
+		LogDir:       s.logDir,
+		PositionFile: s.positionFile,
+		AuditConfig: func() auditlog.Config {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return auditlog.Config{Enabled: true, Forward: s.forward}
+		},
+		OpenSink: func(cfg auditlog.ForwardConfig) (auditlogforwarder.Sink, error) {
+			s.calls <- "open " + cfg.Endpoint
+			return &fakeSink{suite: s}, nil
+		},
+		Clock:        s.clock,
+		Logger:       loggo.GetLogger("test"),
+		PollInterval: pollInterval,
+		BatchSize:    2,
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := auditlogforwarder.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) setForward(cfg auditlog.ForwardConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.forward = cfg
+}
+
+func (s *WorkerSuite) setSendErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sendErr = err
+}
+
+// write writes n conversation records to the audit log.
+func (s *WorkerSuite) write(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		s.count++
+		c.Assert(s.logFile.AddConversation(auditlog.Conversation{
+			Who: "bob", What: "juju status", When: "2025-03-01T10:00:00Z",
+			ConversationID: fmt.Sprintf("c%d", s.count), ConnectionID: "A1",
+		}), jc.ErrorIsNil)
+	}
+}
+
+// rotate renames the current audit log file, as lumberjack does,
+// and starts writing a new one.
+func (s *WorkerSuite) rotate(c *gc.C, timestamp string) {
+	c.Assert(s.logFile.Close(), jc.ErrorIsNil)
+	err := os.Rename(
+		filepath.Join(s.logDir, "audit.log"),
+		filepath.Join(s.logDir, fmt.Sprintf("audit-%s.log", timestamp)),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.logFile = auditlog.NewLogFile(s.logDir, 300, 10)
+}
+
+func (s *WorkerSuite) expectCalls(c *gc.C, expected ...string) {
+	var calls []string
+	for range expected {
+		select {
+		case call := <-s.calls:
+			calls = append(calls, call)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for calls; got %v, expected %v", calls, expected)
+		}
+	}
+	c.Assert(calls, jc.DeepEquals, expected)
+}
+
+func (s *WorkerSuite) expectNoCalls(c *gc.C) {
+	select {
+	case call := <-s.calls:
+		c.Fatalf("unexpected call %q", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// waitIdle waits for the worker to finish forwarding.
+func (s *WorkerSuite) waitIdle(c *gc.C) {
+	err := s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// poll waits for the worker to finish forwarding, then starts the next
+// poll.
+func (s *WorkerSuite) poll(c *gc.C) {
+	err := s.clock.WaitAdvance(pollInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) position(c *gc.C) string {
+	data, err := os.ReadFile(s.positionFile)
+	c.Assert(err, jc.ErrorIsNil)
+	return strings.TrimSpace(string(data))
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*auditlogforwarder.Config)
+		err    string
+	}{{
+		mutate: func(cfg *auditlogforwarder.Config) { cfg.LogDir = "" },
+		err:    "empty LogDir not valid",
+	}, {
+		mutate: func(cfg *auditlogforwarder.Config) { cfg.PositionFile = "" },
+		err:    "empty PositionFile not valid",
+	}, {
+		mutate: func(cfg *auditlogforwarder.Config) { cfg.AuditConfig = nil },
+		err:    "nil AuditConfig not valid",
+	}, {
+		mutate: func(cfg *auditlogforwarder.Config) { cfg.OpenSink = nil },
+		err:    "nil OpenSink not valid",
+	}, {
+		mutate: func(cfg *auditlogforwarder.Config) { cfg.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		mutate: func(cfg *auditlogforwarder.Config) { cfg.Logger = nil },
+		err:    "nil Logger not valid",
+	}, {
+		mutate: func(cfg *auditlogforwarder.Config) { cfg.PollInterval = 0 },
+		err:    "non-positive PollInterval not valid",
+	}, {
+		mutate: func(cfg *auditlogforwarder.Config) { cfg.BatchSize = 0 },
+		err:    "non-positive BatchSize not valid",
+	}} {
+		c.Logf("test %d", i)
+		cfg := s.config()
+		test.mutate(&cfg)
+		_, err := auditlogforwarder.NewWorker(cfg)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *WorkerSuite) TestForwardsNewRecords(c *gc.C) {
+	// Records written before forwarding starts aren't sent.
+	s.write(c, 2)
+	s.startWorker(c)
+	s.expectCalls(c, "open https://audit.example.com/juju")
+	s.waitIdle(c)
+	s.expectNoCalls(c)
+
+	s.write(c, 3)
+	s.poll(c)
+	s.expectCalls(c, "send c3:3 c4:4", "send c5:5")
+	s.poll(c)
+	s.expectNoCalls(c)
+	c.Check(s.position(c), gc.Equals, "5")
+}
+
+func (s *WorkerSuite) TestResumesFromPosition(c *gc.C) {
+	s.write(c, 3)
+	err := os.WriteFile(s.positionFile, []byte("1\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.startWorker(c)
+	s.expectCalls(c, "open https://audit.example.com/juju", "send c2:2 c3:3")
+	s.poll(c)
+	c.Check(s.position(c), gc.Equals, "3")
+}
+
+func (s *WorkerSuite) TestRetriesFailedSend(c *gc.C) {
+	s.startWorker(c)
+	s.expectCalls(c, "open https://audit.example.com/juju")
+	s.waitIdle(c)
+
+	s.setSendErr(errors.New("connection refused"))
+	s.write(c, 1)
+	s.poll(c)
+	s.expectCalls(c, "send c1:1", "close")
+	s.poll(c)
+	s.expectCalls(c, "open https://audit.example.com/juju", "send c1:1", "close")
+	c.Check(s.position(c), gc.Equals, "0")
+
+	s.setSendErr(nil)
+	s.poll(c)
+	s.expectCalls(c, "open https://audit.example.com/juju", "send c1:1")
+	s.poll(c)
+	c.Check(s.position(c), gc.Equals, "1")
+}
+
+func (s *WorkerSuite) TestReopensWhenTargetChanges(c *gc.C) {
+	s.startWorker(c)
+	s.expectCalls(c, "open https://audit.example.com/juju")
+	s.waitIdle(c)
+
+	s.setForward(auditlog.ForwardConfig{
+		Type:     "webhook",
+		Endpoint: "https://other.example.com/juju",
+	})
+	s.write(c, 1)
+	s.poll(c)
+	s.expectCalls(c, "close", "open https://other.example.com/juju", "send c1:1")
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.startWorker(c)
+	s.expectCalls(c, "open https://audit.example.com/juju")
+	s.waitIdle(c)
+	s.write(c, 1)
+	s.poll(c)
+	s.expectCalls(c, "send c1:1")
+
+	s.setForward(auditlog.ForwardConfig{})
+	s.poll(c)
+	s.expectCalls(c, "close")
+	s.poll(c)
+	c.Check(s.position(c), gc.Equals, "1")
+
+	// Records written while forwarding is disabled are sent
+	// when it is enabled again.
+	s.write(c, 2)
+	s.poll(c)
+	s.expectNoCalls(c)
+	s.setForward(webhook)
+	s.poll(c)
+	s.expectCalls(c, "open https://audit.example.com/juju", "send c2:2 c3:3")
+	s.poll(c)
+	c.Check(s.position(c), gc.Equals, "3")
+}
+
+func (s *WorkerSuite) TestReadsRotatedFilesOnGap(c *gc.C) {
+	s.startWorker(c)
+	s.expectCalls(c, "open https://audit.example.com/juju")
+	s.waitIdle(c)
+	s.write(c, 1)
+	s.poll(c)
+	s.expectCalls(c, "send c1:1")
+
+	// The file being followed is rotated twice between polls, so
+	// record 3 is only in a rotated file the tailer doesn't follow.
+	s.write(c, 1)
+	s.rotate(c, "2025-03-01T10-00-00.000")
+	s.write(c, 1)
+	s.rotate(c, "2025-03-01T11-00-00.000")
+	s.write(c, 1)
+	s.poll(c)
+	s.expectCalls(c, "send c2:2 c3:3", "send c4:4")
+	s.poll(c)
+	c.Check(s.position(c), gc.Equals, "4")
+}
+
+type fakeSink struct {
+	suite *WorkerSuite
+}
+
+func (f *fakeSink) Send(records []auditlog.Record) error {
+	var ids []string
+	for _, record := range records {
+		ids = append(ids, fmt.Sprintf("%s:%d", record.Conversation.ConversationID, record.Sequence()))
+	}
+	f.suite.calls <- "send " + strings.Join(ids, " ")
+	f.suite.mu.Lock()
+	defer f.suite.mu.Unlock()
+	return f.suite.sendErr
+}
+
+func (f *fakeSink) Close() error {
+	f.suite.calls <- "close"
+	return nil
+}
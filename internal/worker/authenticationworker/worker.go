@@ -4,24 +4,71 @@
 package authenticationworker
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v5"
+	"github.com/juju/utils/v3"
 	"github.com/juju/utils/v3/ssh"
 	"github.com/juju/worker/v3"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/agent/keyupdater"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 )
 
 // The user name used to ssh into Juju nodes.
 // Override for testing.
 var SSHUser = "ubuntu"
 
+// The directory holding the sshd configuration.
+// Override for testing.
+var SSHDConfigDir = "/etc/ssh"
+
+// ReloadSSHD makes sshd reload its configuration.
+// Override for testing.
+var ReloadSSHD = func() error {
+	// The sshd service is called ssh on Ubuntu and Debian, and sshd
+	// on most other distributions.
+	var failures []string
+	for _, service := range []string{"ssh", "sshd"} {
+		out, err := exec.Command("systemctl", "reload", service).CombinedOutput()
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v (%s)", service, err, strings.TrimSpace(string(out))))
+	}
+	return errors.Errorf("cannot reload sshd service: %s", strings.Join(failures, "; "))
+}
+
+const (
+	// userCAKeyFile is the name of the file holding the public key of
+	// the controller's SSH user certificate authority.
+	userCAKeyFile = "juju_user_ca.pub"
+
+	// principalsDir is the name of the directory holding the principals
+	// each user accepts certificates for.
+	principalsDir = "juju_auth_principals"
+
+	// sshdDropInFile is the sshd configuration file, relative to the
+	// sshd configuration directory, making sshd trust the controller's
+	// SSH user certificate authority.
+	sshdDropInFile = "sshd_config.d/60-juju-user-ca.conf"
+
+	// sshdConfigFile is the main sshd configuration file, relative to
+	// the sshd configuration directory. It must include the drop-in
+	// file for sshd to read it.
+	sshdConfigFile = "sshd_config"
+)
+
 var logger = loggo.GetLogger("juju.worker.authenticationworker")
 
 type keyupdaterWorker struct {
@@ -32,11 +79,18 @@ type keyupdaterWorker struct {
 	// nonJujuKeys are those added externally to auth keys file
 	// such keys do not have comments with the Juju: prefix.
 	nonJujuKeys []string
+	// ca is the most recently retrieved SSH user certificate authority.
+	// While the machine only accepts certificates from it, the Juju keys
+	// other than the system key aren't written to the auth keys file.
+	ca keyupdater.SSHUserCertificateAuthority
 }
 
 // NewWorker returns a worker that keeps track of
 // the machine's authorised ssh keys and ensures the
 // ~/.ssh/authorized_keys file is up to date.
+// When the controller issues SSH user certificates, the
+// worker also makes sshd trust the controller's certificate
+// authority.
 func NewWorker(st *keyupdater.State, agentConfig agent.Config) (worker.Worker, error) {
 	machineTag, ok := agentConfig.Tag().(names.MachineTag)
 	if !ok {
@@ -65,6 +119,18 @@ func (kw *keyupdaterWorker) SetUp() (watcher.NotifyWatcher, error) {
 	}
 	kw.jujuKeys = set.NewStrings(jujuKeys...)
 
+	// Make sshd trust the controller's certificate authority, or stop
+	// trusting it if it no longer should.
+	if kw.ca, err = kw.certificateAuthority(); err != nil {
+		logger.Infof(err.Error())
+		return nil, err
+	}
+	if err := kw.writeCertificateAuthority(); err != nil {
+		err = errors.Annotate(err, "configuring sshd to trust the Juju certificate authority")
+		logger.Infof(err.Error())
+		return nil, err
+	}
+
 	// Read the keys currently in ~/.ssh/authorised_keys.
 	sshKeys, err := ssh.ListKeys(SSHUser, ssh.FullKeys)
 	if err != nil {
@@ -98,17 +164,30 @@ func (kw *keyupdaterWorker) SetUp() (watcher.NotifyWatcher, error) {
 }
 
 // writeSSHKeys writes out a new ~/.ssh/authorised_keys file, retaining any non Juju keys
-// and adding the specified set of Juju keys.
+// and adding the specified set of Juju keys. When the machine only accepts certificates
+// from the controller's certificate authority for users, only the system key is added.
 func (kw *keyupdaterWorker) writeSSHKeys(jujuKeys []string) error {
-	allKeys := kw.nonJujuKeys
-	// Ensure any Juju keys have the required prefix in their comment.
-	for i, key := range jujuKeys {
-		jujuKeys[i] = ssh.EnsureJujuComment(key)
+	allKeys := append([]string(nil), kw.nonJujuKeys...)
+	for _, key := range jujuKeys {
+		if kw.ca.PublicKey != "" && kw.ca.CertificatesOnly && !isSystemKey(key) {
+			continue
+		}
+		// Ensure any Juju keys have the required prefix in their comment.
+		allKeys = append(allKeys, ssh.EnsureJujuComment(key))
 	}
-	allKeys = append(allKeys, jujuKeys...)
 	return ssh.ReplaceKeys(SSHUser, allKeys...)
 }
 
+// isSystemKey returns whether key is the controller's system key, which
+// the controller uses to reach the machine.
+func isSystemKey(key string) bool {
+	_, comment, err := ssh.KeyFingerprint(key)
+	if err != nil {
+		return false
+	}
+	return strings.TrimPrefix(comment, ssh.JujuCommentPrefix) == config.JujuSystemKey
+}
+
 // Handle is defined on the worker.NotifyWatchHandler interface.
 func (kw *keyupdaterWorker) Handle(_ <-chan struct{}) error {
 	// Read the keys that Juju has.
@@ -118,11 +197,28 @@ func (kw *keyupdaterWorker) Handle(_ <-chan struct{}) error {
 		logger.Infof(err.Error())
 		return err
 	}
+	// Figure out if the certificate authority has changed.
+	ca, err := kw.certificateAuthority()
+	if err != nil {
+		logger.Infof(err.Error())
+		return err
+	}
+	caChanged := ca.PublicKey != kw.ca.PublicKey ||
+		ca.CertificatesOnly != kw.ca.CertificatesOnly ||
+		strings.Join(ca.Principals, "\n") != strings.Join(kw.ca.Principals, "\n")
+	if caChanged {
+		kw.ca = ca
+		if err := kw.writeCertificateAuthority(); err != nil {
+			err = errors.Annotate(err, "configuring sshd to trust the Juju certificate authority")
+			logger.Infof(err.Error())
+			return err
+		}
+	}
 	// Figure out if any keys have been added or deleted.
 	newJujuKeys := set.NewStrings(newKeys...)
 	deleted := kw.jujuKeys.Difference(newJujuKeys)
 	added := newJujuKeys.Difference(kw.jujuKeys)
-	if added.Size() > 0 || deleted.Size() > 0 {
+	if added.Size() > 0 || deleted.Size() > 0 || caChanged {
 		logger.Infof("adding ssh keys to authorised keys: %v", added)
 		logger.Infof("deleting ssh keys from authorised keys: %v", deleted)
 		if err = kw.writeSSHKeys(newKeys); err != nil {
@@ -135,6 +231,119 @@ func (kw *keyupdaterWorker) Handle(_ <-chan struct{}) error {
 	return nil
 }
 
+// certificateAuthority returns the controller's SSH user certificate
+// authority for the machine. A zero value is returned if the machine
+// isn't to trust it, including when the controller doesn't support
+// SSH user certificates.
+func (kw *keyupdaterWorker) certificateAuthority() (keyupdater.SSHUserCertificateAuthority, error) {
+	ca, err := kw.st.SSHUserCertificateAuthority(kw.tag)
+	if errors.Is(err, errors.NotSupported) {
+		return keyupdater.SSHUserCertificateAuthority{}, nil
+	}
+	if err != nil {
+		return keyupdater.SSHUserCertificateAuthority{}, errors.Annotatef(err, "reading Juju ssh certificate authority for %q", kw.tag)
+	}
+	return ca, nil
+}
+
+// writeCertificateAuthority writes out the sshd configuration making sshd
+// trust the controller's certificate authority for the certificate principals
+// of the machine, or removes it if the machine isn't to trust the certificate
+// authority. Then sshd is reloaded if its configuration has changed.
+func (kw *keyupdaterWorker) writeCertificateAuthority() error {
+	sshUser, err := sshUserName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	caKeyPath := filepath.Join(SSHDConfigDir, userCAKeyFile)
+	principalsPath := filepath.Join(SSHDConfigDir, principalsDir, sshUser)
+	dropInPath := filepath.Join(SSHDConfigDir, sshdDropInFile)
+
+	if kw.ca.PublicKey == "" {
+		if _, err := os.Stat(dropInPath); os.IsNotExist(err) {
+			return nil
+		}
+		for _, path := range []string{dropInPath, principalsPath, caKeyPath} {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return errors.Trace(err)
+			}
+		}
+		logger.Infof("sshd no longer trusts the Juju certificate authority")
+		return errors.Annotate(ReloadSSHD(), "reloading sshd")
+	}
+
+	dropIn := fmt.Sprintf(`# Written by the Juju machine agent, changes will be overwritten.
+TrustedUserCAKeys %s
+AuthorizedPrincipalsFile %s
+`, caKeyPath, filepath.Join(SSHDConfigDir, principalsDir, "%u"))
+	files := []struct {
+		path    string
+		content string
+	}{
+		{caKeyPath, kw.ca.PublicKey},
+		{principalsPath, strings.Join(kw.ca.Principals, "\n") + "\n"},
+		{dropInPath, dropIn},
+	}
+	// Without the Include directive sshd would silently ignore the
+	// drop-in file, and certificates would be rejected.
+	included, err := sshdIncludes(filepath.Join(SSHDConfigDir, sshdConfigFile), dropInPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !included {
+		return errors.NotSupportedf("sshd configuration %q without an Include directive for %q",
+			filepath.Join(SSHDConfigDir, sshdConfigFile), filepath.Dir(dropInPath))
+	}
+	for _, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+			return errors.Trace(err)
+		}
+		if err := utils.AtomicWriteFile(file.path, []byte(file.content), 0644); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	logger.Infof("sshd trusts the Juju certificate authority for principals %v", kw.ca.Principals)
+	return errors.Annotate(ReloadSSHD(), "reloading sshd")
+}
+
+// sshdIncludes returns whether the sshd configuration file at configPath
+// has an Include directive matching path. Relative Include paths are
+// relative to the sshd configuration directory, as they are for sshd.
+func sshdIncludes(configPath, path string) (bool, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return false, errors.Annotate(err, "reading sshd configuration")
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "Include") {
+			continue
+		}
+		for _, pattern := range fields[1:] {
+			if !filepath.IsAbs(pattern) {
+				pattern = filepath.Join(filepath.Dir(configPath), pattern)
+			}
+			if matched, _ := filepath.Match(pattern, path); matched {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// sshUserName returns the name of the user whose ssh authorised keys
+// are maintained.
+func sshUserName() (string, error) {
+	if SSHUser != "" {
+		return SSHUser, nil
+	}
+	u, err := user.Current()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return u.Username, nil
+}
+
 // TearDown is defined on the worker.NotifyWatchHandler interface.
 func (kw *keyupdaterWorker) TearDown() error {
 	// Nothing to do here.
@@ -4,19 +4,26 @@
 package authenticationworker_test
 
 import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v3/ssh"
 	sshtesting "github.com/juju/utils/v3/ssh/testing"
 	"github.com/juju/worker/v3"
+	gossh "golang.org/x/crypto/ssh"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/agent/keyupdater"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/internal/worker/authenticationworker"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -30,6 +37,7 @@ type workerSuite struct {
 
 	existingEnvKey string
 	existingKeys   []string
+	sshdConfigDir  string
 }
 
 var _ = gc.Suite(&workerSuite{})
@@ -40,6 +48,13 @@ func (s *workerSuite) SetUpTest(c *gc.C) {
 	c.Assert(authenticationworker.SSHUser, gc.Equals, "ubuntu")
 	// Set the ssh user to empty (the current user) as required by the test infrastructure.
 	s.PatchValue(&authenticationworker.SSHUser, "")
+	// Write the sshd configuration to a temporary directory, and don't
+	// reload sshd.
+	s.sshdConfigDir = c.MkDir()
+	s.PatchValue(&authenticationworker.SSHDConfigDir, s.sshdConfigDir)
+	s.PatchValue(&authenticationworker.ReloadSSHD, func() error { return nil })
+	err := os.WriteFile(filepath.Join(s.sshdConfigDir, "sshd_config"), []byte("Include sshd_config.d/*.conf\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
 
 	// Replace the default dummy key in the test environment with a valid one.
 	// This will be added to the ssh authorised keys when the agent starts.
@@ -49,7 +64,7 @@ func (s *workerSuite) SetUpTest(c *gc.C) {
 
 	// Set up an existing key (which is not in the environment) in the ssh authorised_keys file.
 	s.existingKeys = []string{sshtesting.ValidKeyTwo.Key + " existinguser@host"}
-	err := ssh.AddKeys(authenticationworker.SSHUser, s.existingKeys...)
+	err = ssh.AddKeys(authenticationworker.SSHUser, s.existingKeys...)
 	c.Assert(err, jc.ErrorIsNil)
 
 	var apiRoot api.Connection
@@ -175,3 +190,103 @@ func (s *workerSuite) TestWorkerRestart(c *gc.C) {
 	yetAnotherKeyWithCommentPrefix := sshtesting.ValidKeyThree.Key + " Juju:yetanother@host"
 	s.waitSSHKeys(c, append(s.existingKeys, yetAnotherKeyWithCommentPrefix))
 }
+
+func (s *workerSuite) setSSHUserCertificates(c *gc.C, enabled bool) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SSHUserCertificates: enabled,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) waitSSHDConfig(c *gc.C, exists bool) {
+	dropIn := filepath.Join(s.sshdConfigDir, "sshd_config.d", "60-juju-user-ca.conf")
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case <-timeout:
+			c.Fatalf("timeout while waiting for sshd configuration to change")
+		case <-time.After(coretesting.ShortWait):
+			_, err := os.Stat(dropIn)
+			if exists != (err == nil) {
+				continue
+			}
+			return
+		}
+	}
+}
+
+func (s *workerSuite) TestCertificateAuthority(c *gc.C) {
+	s.setSSHUserCertificates(c, true)
+	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)))
+	c.Assert(err, jc.ErrorIsNil)
+	defer stop(c, authWorker)
+
+	// The Juju keys are kept alongside the certificate authority.
+	s.waitSSHKeys(c, append(s.existingKeys, s.existingEnvKey))
+	s.waitSSHDConfig(c, true)
+
+	caKey, err := s.State.SSHUserCAKey()
+	c.Assert(err, jc.ErrorIsNil)
+	signer, err := gossh.ParsePrivateKey([]byte(caKey))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := os.ReadFile(filepath.Join(s.sshdConfigDir, "juju_user_ca.pub"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, string(gossh.MarshalAuthorizedKey(signer.PublicKey())))
+
+	u, err := user.Current()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err = os.ReadFile(filepath.Join(s.sshdConfigDir, "juju_auth_principals", u.Username))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, fmt.Sprintf("%s.%s.juju.local\n", s.machine.Id(), s.Model.UUID()))
+
+	// Disabling certificates stops trusting the certificate authority.
+	s.setSSHUserCertificates(c, false)
+	s.waitSSHKeys(c, append(s.existingKeys, s.existingEnvKey))
+	s.waitSSHDConfig(c, false)
+}
+
+func (s *workerSuite) TestCertificateAuthorityCertificatesOnly(c *gc.C) {
+	systemKey := sshtesting.ValidKeyThree.Key + " juju-system-key"
+	s.setAuthorisedKeys(c, sshtesting.ValidKeyOne.Key+" firstuser@host", systemKey)
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SSHUserCertificates:     true,
+		controller.SSHUserCertificatesOnly: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)))
+	c.Assert(err, jc.ErrorIsNil)
+	defer stop(c, authWorker)
+
+	// The users' keys are removed, but the system key is kept.
+	systemKeyWithCommentPrefix := sshtesting.ValidKeyThree.Key + " Juju:juju-system-key"
+	s.waitSSHKeys(c, append(s.existingKeys, systemKeyWithCommentPrefix))
+	s.waitSSHDConfig(c, true)
+
+	// Allowing keys again restores the users' keys.
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SSHUserCertificatesOnly: false,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitSSHKeys(c, append(s.existingKeys, s.existingEnvKey, systemKeyWithCommentPrefix))
+}
+
+func (s *workerSuite) TestCertificateAuthorityWithoutInclude(c *gc.C) {
+	err := os.WriteFile(filepath.Join(s.sshdConfigDir, "sshd_config"), []byte("PasswordAuthentication no\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setSSHUserCertificates(c, true)
+	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = authWorker.Wait()
+	c.Assert(err, gc.ErrorMatches, `configuring sshd to trust the Juju certificate authority: sshd configuration ".*/sshd_config" without an Include directive for ".*/sshd_config.d" not supported`)
+}
+
+func (s *workerSuite) TestCertificateAuthorityReloadFailure(c *gc.C) {
+	s.PatchValue(&authenticationworker.ReloadSSHD, func() error { return errors.New("boom") })
+	s.setSSHUserCertificates(c, true)
+	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = authWorker.Wait()
+	c.Assert(err, gc.ErrorMatches, `configuring sshd to trust the Juju certificate authority: reloading sshd: boom`)
+}
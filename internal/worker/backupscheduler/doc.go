@@ -0,0 +1,17 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker which creates controller
+// backups on the schedule set by the backup-schedule controller config
+// key, then prunes the scheduled backup archives held on the controller
+// according to backup-retention-count and backup-retention-age. Archives
+// created by other means, such as juju create-backup --no-download, are
+// never pruned, and neither are copies uploaded to an object store. The
+// Backups facade only lets controller agents create, list and remove
+// scheduled backups.
+//
+// The worker runs on the primary controller only. Archives are written
+// to the backup directory of the controller machine serving its API
+// connection, which is where they can later be listed, downloaded and
+// removed with the backups client commands.
+package backupscheduler
@@ -0,0 +1,101 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/dependency"
+
+	"github.com/juju/juju/api/agent/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/client/backups"
+)
+
+// DefaultPollInterval is how often the worker re-reads the controller
+// config when run by the manifold.
+const DefaultPollInterval = 5 * time.Minute
+
+// ManifoldConfig holds dependencies and configuration for a
+// backupscheduler worker.
+type ManifoldConfig struct {
+	APICallerName string
+	Clock         clock.Clock
+	Logger        Logger
+
+	NewFacade func(base.APICaller) (Facade, error)
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate validates a manifold config.
+func (c ManifoldConfig) Validate() error {
+	if c.APICallerName == "" {
+		return errors.NotValidf("missing APICallerName")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if c.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if c.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that runs a backupscheduler worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+		},
+		Start: config.start,
+	}
+}
+
+func (c ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := c.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var apiCaller base.APICaller
+	if err := context.Get(c.APICallerName, &apiCaller); err != nil {
+		return nil, err
+	}
+	facade, err := c.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.NewWorker(Config{
+		Facade:       facade,
+		Clock:        c.Clock,
+		Logger:       c.Logger,
+		PollInterval: DefaultPollInterval,
+	})
+}
+
+// NewFacade returns a Facade which reads controller config through
+// the Agent facade and drives backups through the Backups facade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	agentState, err := agent.NewState(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &facade{
+		State:  agentState,
+		Client: backups.NewClientFromCaller(apiCaller),
+	}, nil
+}
+
+type facade struct {
+	*agent.State
+	*backups.Client
+}
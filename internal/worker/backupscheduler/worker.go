@@ -0,0 +1,216 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/schedule"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Notes is recorded against every backup created by the worker. Only
+// archives with these notes are pruned, so that backups made by
+// operators are kept, and only they can be removed by the worker.
+const Notes = params.BackupsScheduledNotes
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Facade exposes the controller functionality used by the worker.
+type Facade interface {
+	ControllerConfig() (controller.Config, error)
+	Create(notes string, noDownload bool, encryptTo []string) (*params.BackupsMetadataResult, error)
+	List() ([]params.BackupsArchive, error)
+	Remove(ids ...string) ([]params.ErrorResult, error)
+}
+
+// Config defines the operation of the Worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+	Logger Logger
+
+	// PollInterval is how often the controller config is re-read
+	// to pick up changes to the schedule and retention policy.
+	PollInterval time.Duration
+}
+
+// Validate returns an error if config cannot drive the Worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which creates controller backups on
+// schedule and prunes old archives.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Worker creates controller backups on schedule.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	spec     string
+	schedule *schedule.Schedule
+	next     time.Time
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		cfg, err := w.config.Facade.ControllerConfig()
+		if err != nil {
+			return errors.Annotate(err, "reading controller config")
+		}
+		if err := w.updateSchedule(cfg.BackupSchedule()); err != nil {
+			return errors.Trace(err)
+		}
+
+		if w.schedule != nil && !w.config.Clock.Now().Before(w.next) {
+			w.backup(cfg)
+			w.next = w.schedule.Next(w.config.Clock.Now())
+			w.config.Logger.Infof("next scheduled backup at %s", w.next.Format(time.RFC3339))
+		}
+
+		wait := w.config.PollInterval
+		if w.schedule != nil {
+			if untilNext := w.next.Sub(w.config.Clock.Now()); untilNext < wait {
+				wait = untilNext
+			}
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(wait):
+		}
+	}
+}
+
+// updateSchedule resets the time of the next backup when the
+// configured schedule changes.
+func (w *Worker) updateSchedule(spec string) error {
+	if spec == w.spec {
+		return nil
+	}
+	w.spec = spec
+	w.schedule = nil
+	if spec == "" {
+		w.config.Logger.Infof("scheduled backups disabled")
+		return nil
+	}
+	sched, err := schedule.Parse(spec)
+	if err != nil {
+		return errors.Annotatef(err, "parsing %s", controller.BackupSchedule)
+	}
+	w.schedule = sched
+	w.next = sched.Next(w.config.Clock.Now())
+	w.config.Logger.Infof("backup schedule %q: next backup at %s", spec, w.next.Format(time.RFC3339))
+	return nil
+}
+
+// backup creates a backup and then prunes the retained archives.
+// Failures are logged rather than returned so that a transient
+// problem does not stop later backups from being attempted.
+func (w *Worker) backup(cfg controller.Config) {
+	w.config.Logger.Infof("creating scheduled backup")
+	result, err := w.config.Facade.Create(Notes, true, nil)
+	if err != nil {
+		w.config.Logger.Errorf("scheduled backup failed: %v", err)
+		return
+	}
+	w.config.Logger.Infof("created scheduled backup %s", result.Filename)
+
+	if err := w.prune(cfg.BackupRetentionCount(), cfg.BackupRetentionAge()); err != nil {
+		w.config.Logger.Errorf("pruning backups: %v", err)
+	}
+}
+
+// prune removes the scheduled backup archives which exceed the
+// retention count or are older than the retention age. A zero value
+// for either disables that limit. Archives uploaded to an object
+// store are not pruned there.
+func (w *Worker) prune(count int, maxAge time.Duration) error {
+	if count <= 0 && maxAge <= 0 {
+		return nil
+	}
+	all, err := w.config.Facade.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var archives []params.BackupsArchive
+	for _, archive := range all {
+		if archive.Notes == Notes {
+			archives = append(archives, archive)
+		}
+	}
+
+	// Archives are listed oldest first.
+	now := w.config.Clock.Now()
+	var expired []string
+	for i, archive := range archives {
+		excess := count > 0 && len(archives)-i > count
+		tooOld := maxAge > 0 && now.Sub(archive.Created) > maxAge
+		if excess || tooOld {
+			expired = append(expired, archive.ID)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	w.config.Logger.Debugf("removing %d expired backups", len(expired))
+	results, err := w.config.Facade.Remove(expired...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			w.config.Logger.Errorf("removing backup %s: %v", expired[i], result.Error)
+			continue
+		}
+		w.config.Logger.Infof("removed expired backup %s", expired[i])
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/internal/worker/backupscheduler"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const pollInterval = 5 * time.Minute
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *fakeFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+// start is a Wednesday.
+var start = time.Date(2025, time.January, 15, 10, 20, 30, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(start)
+	s.facade = &fakeFacade{
+		cfg:   controller.Config{},
+		calls: make(chan string, 10),
+	}
+}
+
+func (s *WorkerSuite) config() backupscheduler.Config {
+	return backupscheduler.Config{
+		Facade:       s.facade,
+		Clock:        s.clock,
+		Logger:       loggo.GetLogger("test"),
+		PollInterval: pollInterval,
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := backupscheduler.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) expectCalls(c *gc.C, expected ...string) {
+	var calls []string
+	for range expected {
+		select {
+		case call := <-s.facade.calls:
+			calls = append(calls, call)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for calls; got %v, expected %v", calls, expected)
+		}
+	}
+	c.Assert(calls, jc.DeepEquals, expected)
+}
+
+func (s *WorkerSuite) expectNoCalls(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected call %q", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) advance(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*backupscheduler.Config)
+		err    string
+	}{{
+		mutate: func(cfg *backupscheduler.Config) { cfg.Facade = nil },
+		err:    "nil Facade not valid",
+	}, {
+		mutate: func(cfg *backupscheduler.Config) { cfg.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		mutate: func(cfg *backupscheduler.Config) { cfg.Logger = nil },
+		err:    "nil Logger not valid",
+	}, {
+		mutate: func(cfg *backupscheduler.Config) { cfg.PollInterval = 0 },
+		err:    "non-positive PollInterval not valid",
+	}} {
+		c.Logf("test %d", i)
+		cfg := s.config()
+		test.mutate(&cfg)
+		_, err := backupscheduler.NewWorker(cfg)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.startWorker(c)
+	s.expectCalls(c, "ControllerConfig")
+
+	s.advance(c, pollInterval)
+	s.expectCalls(c, "ControllerConfig")
+	s.expectNoCalls(c)
+}
+
+func (s *WorkerSuite) TestBackupOnSchedule(c *gc.C) {
+	s.facade.setConfig(controller.Config{
+		controller.BackupSchedule:       "30 10 * * *",
+		controller.BackupRetentionCount: 0,
+	})
+	s.startWorker(c)
+	s.expectCalls(c, "ControllerConfig")
+
+	// The first wait is cut short by the 10:30 backup.
+	s.advance(c, pollInterval)
+	s.expectCalls(c, "ControllerConfig")
+	s.expectNoCalls(c)
+
+	s.advance(c, 4*time.Minute+30*time.Second)
+	s.expectCalls(c, "ControllerConfig", "Create")
+	c.Check(s.facade.createNotes(), gc.Equals, backupscheduler.Notes)
+}
+
+func (s *WorkerSuite) TestPrune(c *gc.C) {
+	s.facade.setConfig(controller.Config{
+		controller.BackupSchedule:       "30 10 * * *",
+		controller.BackupRetentionCount: 2,
+		controller.BackupRetentionAge:   24 * time.Hour,
+	})
+	s.facade.archives = []params.BackupsArchive{
+		{ID: "too-old", Created: start.Add(-48 * time.Hour), Notes: backupscheduler.Notes},
+		{ID: "manual-old", Created: start.Add(-48 * time.Hour), Notes: "before upgrade"},
+		{ID: "excess", Created: start.Add(-2 * time.Hour), Notes: backupscheduler.Notes},
+		{ID: "unknown", Created: start.Add(-90 * time.Minute)},
+		{ID: "kept", Created: start.Add(-time.Hour), Notes: backupscheduler.Notes},
+		{ID: "manual", Created: start.Add(-30 * time.Minute)},
+		{ID: "new", Created: start.Add(10 * time.Minute), Notes: backupscheduler.Notes},
+	}
+	s.startWorker(c)
+	s.expectCalls(c, "ControllerConfig")
+
+	s.advance(c, pollInterval)
+	s.expectCalls(c, "ControllerConfig")
+	s.advance(c, 4*time.Minute+30*time.Second)
+	s.expectCalls(c, "ControllerConfig", "Create", "List", "Remove")
+	c.Check(s.facade.removedIDs(), jc.DeepEquals, []string{"too-old", "excess"})
+}
+
+func (s *WorkerSuite) TestCreateFailureNotFatal(c *gc.C) {
+	s.facade.setConfig(controller.Config{
+		controller.BackupSchedule: "@hourly",
+	})
+	s.facade.createErr = errors.New("boom")
+	w := s.startWorker(c)
+	s.expectCalls(c, "ControllerConfig")
+
+	s.advance(c, pollInterval)
+	s.expectCalls(c, "ControllerConfig")
+	s.advance(c, pollInterval)
+	s.expectCalls(c, "ControllerConfig")
+	s.advance(c, 29*time.Minute+30*time.Second)
+	// No pruning happens after a failed backup.
+	s.expectCalls(c, "ControllerConfig", "Create")
+	s.advance(c, pollInterval)
+	s.expectCalls(c, "ControllerConfig")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestScheduleChange(c *gc.C) {
+	s.startWorker(c)
+	s.expectCalls(c, "ControllerConfig")
+
+	s.facade.setConfig(controller.Config{
+		controller.BackupSchedule:       "*/10 * * * *",
+		controller.BackupRetentionCount: 0,
+	})
+	// At 10:25:30 the new schedule is read; the next backup is at 10:30.
+	s.advance(c, pollInterval)
+	s.expectCalls(c, "ControllerConfig")
+	s.advance(c, 4*time.Minute+30*time.Second)
+	s.expectCalls(c, "ControllerConfig", "Create")
+}
+
+func (s *WorkerSuite) TestControllerConfigError(c *gc.C) {
+	s.facade.configErr = errors.New("boom")
+	w, err := backupscheduler.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "reading controller config: boom")
+}
+
+type fakeFacade struct {
+	mu        sync.Mutex
+	cfg       controller.Config
+	configErr error
+	createErr error
+	archives  []params.BackupsArchive
+	notes     string
+	removed   []string
+	calls     chan string
+}
+
+func (f *fakeFacade) setConfig(cfg controller.Config) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cfg = cfg
+}
+
+func (f *fakeFacade) createNotes() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.notes
+}
+
+func (f *fakeFacade) removedIDs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.removed
+}
+
+func (f *fakeFacade) ControllerConfig() (controller.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls <- "ControllerConfig"
+	return f.cfg, f.configErr
+}
+
+func (f *fakeFacade) Create(notes string, noDownload bool, encryptTo []string) (*params.BackupsMetadataResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls <- "Create"
+	f.notes = notes
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	return &params.BackupsMetadataResult{Filename: "juju-backup-new.tar.gz"}, nil
+}
+
+func (f *fakeFacade) List() ([]params.BackupsArchive, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls <- "List"
+	return f.archives, nil
+}
+
+func (f *fakeFacade) Remove(ids ...string) ([]params.ErrorResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls <- "Remove"
+	f.removed = append(f.removed, ids...)
+	return make([]params.ErrorResult, len(ids)), nil
+}
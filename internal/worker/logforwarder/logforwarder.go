@@ -5,6 +5,7 @@ package logforwarder
 
 import (
 	"io"
+	"reflect"
 	"sync"
 
 	"github.com/juju/errors"
@@ -12,6 +13,7 @@ import (
 	"gopkg.in/tomb.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/rpc/params"
 )
@@ -44,17 +46,15 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
-// to a sender.
+// to the sender of a single log forwarding target.
 type LogForwarder struct {
 	catacomb  catacomb.Catacomb
 	args      OpenLogForwarderArgs
 	enabledCh chan bool
 	mu        sync.Mutex
 	enabled   bool
+	target    *config.LogForwardTarget
 }
 
 // OpenLogForwarderArgs holds the info needed to open a LogForwarder.
@@ -68,9 +68,14 @@ type OpenLogForwarderArgs struct {
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
-	// Name is the name given to the log sink.
+	// Name is the name given to the log sink. The last record sent to
+	// the sink is tracked under this name.
 	Name string
 
+	// Target is the name of the log forwarding target in the log
+	// forwarding config.
+	Target string
+
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
@@ -82,13 +87,14 @@ type OpenLogForwarderArgs struct {
 	Logger Logger
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
 
 	closeExisting := func() error {
 		lf.enabled = false
+		lf.target = nil
 		// If we are already sending, close the current sender.
 		if currentSender != nil {
 			return currentSender.Close()
@@ -115,19 +121,31 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 		return currentSender, nil
 	}
 
+	target, ok := cfg.Target(lf.args.Target)
+	if !ok {
+		lf.args.Logger.Infof("config change - log forwarding target %q not configured", lf.args.Target)
+		return nil, closeExisting()
+	}
+	// The config is shared by all the targets, so keep sending using
+	// the current sink if this target hasn't changed.
+	if currentSender != nil && reflect.DeepEqual(lf.target, &target) {
+		return currentSender, nil
+	}
+
 	// Shutdown the existing sink since we need to now create a new one.
 	if err := closeExisting(); err != nil {
 		return nil, errors.Trace(err)
 	}
 	sink, err := OpenTrackingSink(TrackingSinkArgs{
 		Name:     lf.args.Name,
-		Config:   cfg,
+		Target:   &target,
 		Caller:   lf.args.Caller,
 		OpenSink: lf.args.OpenSink,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	lf.target = &target
 	lf.enabledCh <- true
 	return sink, nil
 }
@@ -151,7 +169,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		lf.args.Logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		lf.args.Logger.Infof("log forward enabled, starting to stream logs to %q sink", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
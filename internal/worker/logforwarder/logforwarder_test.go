@@ -5,6 +5,7 @@ package logforwarder_test
 
 import (
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/internal/worker/logforwarder"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
@@ -86,8 +88,10 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		Name:             "juju-log-forward",
+		Target:           config.DefaultLogForwardTarget,
+		OpenSink: func(target *config.LogForwardTarget) (*logforwarder.LogSink, error) {
+			sender.host = target.Syslog.Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -212,12 +216,146 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 	c.Check(slices.ContainsFunc(s.stream.stub.Calls(), func(call testing.StubCall) bool { return call.FuncName == "Close" }), jc.IsTrue)
 }
 
+func (s *LogForwarderSuite) TestUnchangedTarget(c *gc.C) {
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+
+	api := &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, rec0)
+	s.sender.waitForSend(c)
+
+	// A config change which doesn't affect the target keeps the sink.
+	api.changes <- struct{}{}
+
+	s.stream.addRecords(c, rec1)
+	s.sender.waitForSend(c)
+
+	workertest.CleanKill(c, lf)
+
+	rec0.Message = "send to 10.0.0.1"
+	rec1.Message = "send to 10.0.0.1"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec0}}},
+		{"Send", []interface{}{[]logfwd.Record{rec1}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestNamedTargetFilter(c *gc.C) {
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+	rec1.Level = loggo.ERROR
+
+	api := &mockLogForwardConfig{
+		enabled: true,
+		targets: []config.LogForwardTarget{
+			syslogTarget("security", "10.0.0.3", logfwd.Filter{Level: loggo.WARNING}),
+		},
+	}
+	caller := &recordingCaller{}
+	args := s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender)
+	args.Caller = caller
+	args.Name = "juju-log-forward/security"
+	args.Target = "security"
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	// Only the second record is selected by the filter.
+	s.stream.addRecords(c, rec0, rec1)
+	s.sender.waitForSend(c)
+
+	workertest.CleanKill(c, lf)
+
+	rec1.Message = "send to 10.0.0.3"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec1}}},
+		{"Close", nil},
+	})
+
+	// The last sent record is tracked for the target, including the
+	// record which was filtered out.
+	c.Check(caller.lastSent(), jc.DeepEquals, []params.LogForwardingSetLastSentParam{{
+		LogForwardingID: params.LogForwardingID{
+			ModelTag: "model-deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Sink:     "juju-log-forward/security",
+		},
+		RecordID:        10,
+		RecordTimestamp: rec0.Timestamp.UnixNano(),
+	}, {
+		LogForwardingID: params.LogForwardingID{
+			ModelTag: "model-deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Sink:     "juju-log-forward/security",
+		},
+		RecordID:        11,
+		RecordTimestamp: rec1.Timestamp.UnixNano(),
+	}})
+}
+
+func (s *LogForwarderSuite) TestNamedTargetRemoved(c *gc.C) {
+	api := &mockLogForwardConfig{
+		enabled: true,
+		targets: []config.LogForwardTarget{
+			syslogTarget("security", "10.0.0.3", logfwd.Filter{}),
+		},
+	}
+	args := s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender)
+	args.Target = "security"
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, s.rec)
+	s.sender.waitForSend(c)
+
+	api.setTargets([]config.LogForwardTarget{
+		syslogTarget("ops", "10.0.0.4", logfwd.Filter{}),
+	})
+	api.changes <- struct{}{}
+	s.sender.waitForClose(c)
+
+	workertest.CleanKill(c, lf)
+	s.sender.stub.CheckCallNames(c, "Send", "Close")
+}
+
+func syslogTarget(name, host string, filter logfwd.Filter) config.LogForwardTarget {
+	return config.LogForwardTarget{
+		Name: name,
+		Type: config.LogForwardTypeSyslog,
+		Syslog: &syslog.RawConfig{
+			Enabled:    true,
+			Host:       host,
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+		Filter: filter,
+	}
+}
+
 type mockLogForwardConfig struct {
+	mu      sync.Mutex
 	enabled bool
 	host    string
+	targets []config.LogForwardTarget
 	changes chan struct{}
 }
 
+func (c *mockLogForwardConfig) setTargets(targets []config.LogForwardTarget) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.targets = targets
+}
+
 type mockWatcher struct {
 	watcher.NotifyWatcher
 	changes chan struct{}
@@ -246,6 +384,29 @@ func (*mockCaller) BestFacadeVersion(facade string) int {
 	return 0
 }
 
+// recordingCaller records the log forwarding "last sent" calls.
+type recordingCaller struct {
+	mockCaller
+
+	mu    sync.Mutex
+	calls []params.LogForwardingSetLastSentParam
+}
+
+func (c *recordingCaller) APICall(objType string, version int, id, request string, args, response interface{}) error {
+	if objType == "LogForwarding" && request == "SetLastSent" {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.calls = append(c.calls, args.(params.LogForwardingSetLastSentParams).Params...)
+	}
+	return nil
+}
+
+func (c *recordingCaller) lastSent() []params.LogForwardingSetLastSentParam {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
 func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error) {
 	c.changes = make(chan struct{}, 1)
 	c.changes <- struct{}{}
@@ -254,13 +415,19 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
+func (c *mockLogForwardConfig) LogForwardConfig() (*config.LogForwardConfig, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &config.LogForwardConfig{
+		Enabled: c.enabled,
+		Syslog: &syslog.RawConfig{
+			Enabled:    c.enabled,
+			Host:       c.host,
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+		Targets: c.targets,
 	}, true, nil
 }
 
@@ -16,6 +16,7 @@ import (
 
 // Logger represents the methods used by the worker to log details.
 type Logger interface {
+	Debugf(string, ...interface{})
 	Infof(string, ...interface{})
 	Errorf(string, ...interface{})
 }
@@ -4,13 +4,30 @@
 package logforwarder
 
 import (
+	"time"
+
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/environs/config"
 )
 
+// restartDelay is the time to wait before restarting the log forwarder
+// of a target after it fails.
+const restartDelay = 10 * time.Second
+
+// orchestrator runs a log forwarder for each log forwarding target of
+// the model, starting and stopping them as the targets are configured.
+// Each forwarder streams the logs independently, so a failing target
+// doesn't hold back the others.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	catacomb catacomb.Catacomb
+	args     OrchestratorArgs
+	sink     LogSinkSpec
+	runner   *worker.Runner
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -40,22 +57,134 @@ type OrchestratorArgs struct {
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
+	// A single sink spec opens the sinks of all the targets.
 	if len(args.Sinks) == 0 {
 		return nil, nil
 	}
 	if len(args.Sinks) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
-	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		ControllerUUID:   args.ControllerUUID,
-		LogForwardConfig: args.LogForwardConfig,
-		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
-		OpenLogStream:    args.OpenLogStream,
-		Logger:           args.Logger,
+		return nil, errors.Errorf("multiple log sinks not supported")
+	}
+	o := &orchestrator{
+		args: args,
+		sink: args.Sinks[0],
+		runner: worker.NewRunner(worker.RunnerParams{
+			IsFatal:      func(error) bool { return false },
+			RestartDelay: restartDelay,
+			Logger:       args.Logger,
+		}),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: o.loop,
+		Init: []worker.Worker{o.runner},
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
+}
+
+func (o *orchestrator) loop() error {
+	configWatcher, err := o.args.LogForwardConfig.WatchForLogForwardConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := o.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	for {
+		select {
+		case <-o.catacomb.Dying():
+			return o.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("log forward configuration watcher closed")
+			}
+			if err := o.updateTargets(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// updateTargets starts a log forwarder for each new target and stops the
+// forwarders of the targets that were removed. Changes to the config of
+// existing targets are handled by their forwarders.
+func (o *orchestrator) updateTargets() error {
+	cfg, ok, err := o.args.LogForwardConfig.LogForwardConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	targets := set.NewStrings()
+	if ok && cfg.Enabled {
+		if err := cfg.Validate(); err != nil {
+			// Keep the current forwarders, which carry on sending
+			// until the config is fixed.
+			o.args.Logger.Errorf("invalid log forward config: %v", err)
+			return nil
+		}
+		for _, target := range cfg.AllTargets() {
+			targets.Add(target.Name)
+		}
+	}
+
+	for _, name := range o.runner.WorkerNames() {
+		if targets.Contains(name) {
+			continue
+		}
+		err := o.runner.StopAndRemoveWorker(name, o.catacomb.Dying())
+		if err == worker.ErrAborted || err == worker.ErrDead {
+			return o.catacomb.ErrDying()
+		} else if err != nil {
+			o.args.Logger.Errorf("log forwarder for target %q stopped: %v", name, err)
+		}
+		o.args.Logger.Infof("stopped forwarding logs to target %q", name)
+	}
+
+	for _, name := range targets.SortedValues() {
+		err := o.runner.StartWorker(name, func() (worker.Worker, error) {
+			lf, err := o.args.OpenLogForwarder(OpenLogForwarderArgs{
+				ControllerUUID:   o.args.ControllerUUID,
+				LogForwardConfig: o.args.LogForwardConfig,
+				Caller:           o.args.Caller,
+				Name:             targetSinkName(o.sink.Name, name),
+				Target:           name,
+				OpenSink:         o.sink.OpenFn,
+				OpenLogStream:    o.args.OpenLogStream,
+				Logger:           o.args.Logger,
+			})
+			if err != nil {
+				return nil, errors.Annotate(err, "opening log forwarder")
+			}
+			return lf, nil
+		})
+		if errors.Is(err, errors.AlreadyExists) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "starting log forwarder for target %q", name)
+		}
+	}
+	return nil
+}
+
+// targetSinkName returns the name under which the last record sent to
+// the target is tracked. The default target uses the name of the sink,
+// so that it resumes from the record that was last sent before named
+// targets were supported.
+func targetSinkName(sinkName, target string) string {
+	if target == config.DefaultLogForwardTarget {
+		return sinkName
+	}
+	return sinkName + "/" + target
+}
+
+// Kill implements Worker.Kill()
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
 }
@@ -0,0 +1,239 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/internal/worker/logforwarder"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type OrchestratorSuite struct {
+	testing.IsolationSuite
+
+	api     *sharedLogForwardConfig
+	opened  chan logforwarder.OpenLogForwarderArgs
+	sinks   chan string
+	mu      sync.Mutex
+	senders map[string]*stubSender
+}
+
+var _ = gc.Suite(&OrchestratorSuite{})
+
+func (s *OrchestratorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &sharedLogForwardConfig{
+		cfg: config.LogForwardConfig{
+			Enabled: true,
+			Syslog: &syslog.RawConfig{
+				Enabled:    true,
+				Host:       "10.0.0.1",
+				CACert:     coretesting.CACert,
+				ClientCert: coretesting.ServerCert,
+				ClientKey:  coretesting.ServerKey,
+			},
+			Targets: []config.LogForwardTarget{
+				syslogTarget("security", "10.0.0.3", logfwd.Filter{Level: loggo.WARNING}),
+			},
+		},
+	}
+	s.opened = make(chan logforwarder.OpenLogForwarderArgs, 10)
+	s.sinks = make(chan string, 10)
+	s.senders = make(map[string]*stubSender)
+}
+
+func (s *OrchestratorSuite) sender(target string) *stubSender {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sender, ok := s.senders[target]
+	if !ok {
+		sender = newStubSender()
+		s.senders[target] = sender
+	}
+	return sender
+}
+
+func (s *OrchestratorSuite) start(c *gc.C) {
+	w, err := logforwarder.NewOrchestratorForController(logforwarder.OrchestratorArgs{
+		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		LogForwardConfig: s.api,
+		Caller:           &mockCaller{},
+		Sinks: []logforwarder.LogSinkSpec{{
+			Name: "juju-log-forward",
+			OpenFn: func(target *config.LogForwardTarget) (*logforwarder.LogSink, error) {
+				s.sinks <- target.Name
+				return &logforwarder.LogSink{s.sender(target.Name)}, nil
+			},
+		}},
+		OpenLogStream: func(base.APICaller, params.LogStreamConfig, string) (logforwarder.LogStream, error) {
+			return newStubStream(), nil
+		},
+		OpenLogForwarder: func(args logforwarder.OpenLogForwarderArgs) (*logforwarder.LogForwarder, error) {
+			s.opened <- args
+			return logforwarder.NewLogForwarder(args)
+		},
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *OrchestratorSuite) waitForOpened(c *gc.C, n int) map[string]string {
+	sinks := make(map[string]string)
+	for i := 0; i < n; i++ {
+		select {
+		case args := <-s.opened:
+			sinks[args.Target] = args.Name
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for log forwarder")
+		}
+	}
+	return sinks
+}
+
+func (s *OrchestratorSuite) waitForSinks(c *gc.C, n int) []string {
+	var targets []string
+	for i := 0; i < n; i++ {
+		select {
+		case target := <-s.sinks:
+			targets = append(targets, target)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for log sink")
+		}
+	}
+	return targets
+}
+
+func (s *OrchestratorSuite) TestForwarderPerTarget(c *gc.C) {
+	s.start(c)
+
+	c.Check(s.waitForOpened(c, 2), jc.DeepEquals, map[string]string{
+		"default":  "juju-log-forward",
+		"security": "juju-log-forward/security",
+	})
+	c.Check(s.waitForSinks(c, 2), jc.SameContents, []string{"default", "security"})
+}
+
+func (s *OrchestratorSuite) TestTargetsChange(c *gc.C) {
+	s.start(c)
+	s.waitForOpened(c, 2)
+	s.waitForSinks(c, 2)
+
+	s.api.update(func(cfg *config.LogForwardConfig) {
+		cfg.Targets = []config.LogForwardTarget{
+			syslogTarget("ops", "10.0.0.4", logfwd.Filter{}),
+		}
+	})
+
+	c.Check(s.waitForOpened(c, 1), jc.DeepEquals, map[string]string{
+		"ops": "juju-log-forward/ops",
+	})
+	s.sender("security").waitForClose(c)
+}
+
+func (s *OrchestratorSuite) TestNotEnabled(c *gc.C) {
+	s.api.update(func(cfg *config.LogForwardConfig) {
+		cfg.Enabled = false
+	})
+	s.start(c)
+
+	select {
+	case args := <-s.opened:
+		c.Fatalf("unexpected log forwarder for %q", args.Target)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *OrchestratorSuite) TestInvalidConfig(c *gc.C) {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("orchestrator-test", &tw), jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { _, _ = loggo.RemoveWriter("orchestrator-test") })
+
+	s.api.update(func(cfg *config.LogForwardConfig) {
+		cfg.Targets = append(cfg.Targets, cfg.Targets[0])
+	})
+	s.start(c)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(tw.Log()) > 0 {
+			break
+		}
+	}
+	c.Check(tw.Log(), jc.LogMatches, jc.SimpleMessages{{
+		loggo.ERROR, `invalid log forward config: duplicate log forwarding target "security" not valid`,
+	}})
+	select {
+	case args := <-s.opened:
+		c.Fatalf("unexpected log forwarder for %q", args.Target)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *OrchestratorSuite) TestInvalidConfigKeepsForwarders(c *gc.C) {
+	s.start(c)
+	s.waitForOpened(c, 2)
+	s.waitForSinks(c, 2)
+
+	s.api.update(func(cfg *config.LogForwardConfig) {
+		cfg.Targets = append(cfg.Targets, cfg.Targets[0])
+	})
+
+	select {
+	case a := <-s.sender("security").activity:
+		c.Fatalf("unexpected %v of the security target sink", a)
+	case args := <-s.opened:
+		c.Fatalf("unexpected log forwarder for %q", args.Target)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// sharedLogForwardConfig is a log forward config API which notifies
+// all of its watchers of a change.
+type sharedLogForwardConfig struct {
+	mu       sync.Mutex
+	cfg      config.LogForwardConfig
+	watchers []chan struct{}
+}
+
+func (c *sharedLogForwardConfig) update(f func(*config.LogForwardConfig)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f(&c.cfg)
+	for _, changes := range c.watchers {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (c *sharedLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	c.watchers = append(c.watchers, changes)
+	return &mockWatcher{changes: changes}, nil
+}
+
+func (c *sharedLogForwardConfig) LogForwardConfig() (*config.LogForwardConfig, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cfg := c.cfg
+	return &cfg, true, nil
+}
@@ -5,7 +5,7 @@ package logforwarder
 
 import (
 	"github.com/juju/juju/core/watcher"
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/environs/config"
 )
 
 // LogForwardConfig provides access to the log forwarding config for a model.
@@ -15,19 +15,22 @@ type LogForwardConfig interface {
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
 	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	LogForwardConfig() (*config.LogForwardConfig, bool, error)
 }
 
 type LogSinkSpec struct {
-	// Name is the name of the log sink.
+	// Name is the name of the log sink. It is also the name under which
+	// the last record sent to the default log forwarding target is
+	// tracked; the names of the other targets are appended to it.
 	Name string
 
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
-// LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+// LogSinkFn is a function that opens a log sink for a log forwarding
+// target.
+type LogSinkFn func(target *config.LogForwardTarget) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
@@ -0,0 +1,26 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/internal/worker/logforwarder"
+	"github.com/juju/juju/logfwd/otlp"
+)
+
+// OpenOTLP returns a sink used to receive log messages to be forwarded
+// to an OTLP/HTTP receiver.
+func OpenOTLP(cfg *otlp.RawConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := otlp.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
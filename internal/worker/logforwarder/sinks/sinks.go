@@ -0,0 +1,28 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/internal/worker/logforwarder"
+)
+
+// Open returns a sink used to receive log messages to be forwarded to
+// the log forwarding target, which is either an OTLP/HTTP receiver, a
+// Loki server or a syslog host.
+func Open(target *config.LogForwardTarget) (*logforwarder.LogSink, error) {
+	if err := target.Validate(); err != nil {
+		return nil, errors.Annotatef(err, "log forwarding target %q", target.Name)
+	}
+	switch target.Type {
+	case config.LogForwardTypeOTLP:
+		return OpenOTLP(target.OTLP)
+	case config.LogForwardTypeLoki:
+		return OpenLoki(target.Loki)
+	default:
+		return OpenSyslog(target.Syslog)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/internal/worker/logforwarder/sinks"
+	"github.com/juju/juju/logfwd/loki"
+	"github.com/juju/juju/logfwd/otlp"
+)
+
+type SinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinksSuite{})
+
+func (s *SinksSuite) TestOpenOTLP(c *gc.C) {
+	sink, err := sinks.Open(&config.LogForwardTarget{
+		Name: "ops",
+		Type: config.LogForwardTypeOTLP,
+		OTLP: &otlp.RawConfig{Enabled: true, Endpoint: "http://otel-collector:4318"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := sink.SendCloser.(*otlp.Client)
+	c.Check(ok, jc.IsTrue)
+	c.Check(sink.Close(), jc.ErrorIsNil)
+}
+
+func (s *SinksSuite) TestOpenLoki(c *gc.C) {
+	sink, err := sinks.Open(&config.LogForwardTarget{
+		Name: "ops",
+		Type: config.LogForwardTypeLoki,
+		Loki: &loki.RawConfig{Enabled: true, Endpoint: "http://loki:3100"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := sink.SendCloser.(*loki.Client)
+	c.Check(ok, jc.IsTrue)
+	c.Check(sink.Close(), jc.ErrorIsNil)
+}
+
+func (s *SinksSuite) TestOpenNotEnabled(c *gc.C) {
+	_, err := sinks.Open(&config.LogForwardTarget{
+		Name: "ops",
+		Type: config.LogForwardTypeOTLP,
+		OTLP: &otlp.RawConfig{Endpoint: "http://otel-collector:4318"},
+	})
+	c.Assert(err, gc.ErrorMatches, "log forwarding not enabled")
+}
+
+func (s *SinksSuite) TestOpenWithoutConfig(c *gc.C) {
+	_, err := sinks.Open(&config.LogForwardTarget{
+		Name: "ops",
+		Type: config.LogForwardTypeLoki,
+	})
+	c.Assert(err, gc.ErrorMatches, `log forwarding target "ops": Loki target without Loki config not valid`)
+}
+
+func (s *SinksSuite) TestOpenUnknownType(c *gc.C) {
+	_, err := sinks.Open(&config.LogForwardTarget{
+		Name: "ops",
+		Type: "kafka",
+	})
+	c.Assert(err, gc.ErrorMatches, `log forwarding target "ops": target type "kafka" not valid`)
+}
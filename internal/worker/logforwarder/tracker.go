@@ -9,14 +9,14 @@ import (
 
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/controller/logfwd"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
 type TrackingSinkArgs struct {
-	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	// Target is the log forwarding target that will be used.
+	Target *config.LogForwardTarget
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -30,9 +30,10 @@ type TrackingSinkArgs struct {
 }
 
 // OpenTrackingSink opens a log record sender to use with a worker.
-// The sender also tracks records that were successfully sent.
+// The sender only sends the records selected by the target's filter,
+// and tracks records that were successfully sent.
 func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
-	sink, err := args.OpenSink(args.Config)
+	sink, err := args.OpenSink(args.Target)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -40,6 +41,7 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 	return &LogSink{
 		&trackingSender{
 			SendCloser: sink,
+			filter:     args.Target.Filter,
 			tracker:    newLastSentTracker(args.Name, args.Caller),
 		},
 	}, nil
@@ -47,14 +49,19 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 
 type trackingSender struct {
 	SendCloser
+	filter  logfwd.Filter
 	tracker *lastSentTracker
 }
 
 // Send implements Sender.
 func (s *trackingSender) Send(records []logfwd.Record) error {
-	if err := s.SendCloser.Send(records); err != nil {
-		return errors.Trace(err)
+	if selected := s.filter.Apply(records); len(selected) > 0 {
+		if err := s.SendCloser.Send(selected); err != nil {
+			return errors.Trace(err)
+		}
 	}
+	// The last record is tracked even if it wasn't selected, so that
+	// the filtered out records aren't streamed again after a restart.
 	if err := s.tracker.setLastSent(records); err != nil {
 		return errors.Trace(err)
 	}
@@ -0,0 +1,7 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secretsdriftchecker provides a worker which periodically
+// checks that secret content stored in external backends has not
+// been removed or changed outside of Juju.
+package secretsdriftchecker
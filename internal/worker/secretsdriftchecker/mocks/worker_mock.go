@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/internal/worker/secretsdriftchecker (interfaces: Logger,SecretsFacade)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/worker_mock.go github.com/juju/juju/internal/worker/secretsdriftchecker Logger,SecretsFacade
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	secrets "github.com/juju/juju/core/secrets"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLogger is a mock of Logger interface.
+type MockLogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoggerMockRecorder
+}
+
+// MockLoggerMockRecorder is the mock recorder for MockLogger.
+type MockLoggerMockRecorder struct {
+	mock *MockLogger
+}
+
+// NewMockLogger creates a new mock instance.
+func NewMockLogger(ctrl *gomock.Controller) *MockLogger {
+	mock := &MockLogger{ctrl: ctrl}
+	mock.recorder = &MockLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogger) EXPECT() *MockLoggerMockRecorder {
+	return m.recorder
+}
+
+// Debugf mocks base method.
+func (m *MockLogger) Debugf(arg0 string, arg1 ...any) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Debugf", varargs...)
+}
+
+// Debugf indicates an expected call of Debugf.
+func (mr *MockLoggerMockRecorder) Debugf(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debugf", reflect.TypeOf((*MockLogger)(nil).Debugf), varargs...)
+}
+
+// Warningf mocks base method.
+func (m *MockLogger) Warningf(arg0 string, arg1 ...any) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warningf", varargs...)
+}
+
+// Warningf indicates an expected call of Warningf.
+func (mr *MockLoggerMockRecorder) Warningf(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warningf", reflect.TypeOf((*MockLogger)(nil).Warningf), varargs...)
+}
+
+// MockSecretsFacade is a mock of SecretsFacade interface.
+type MockSecretsFacade struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsFacadeMockRecorder
+}
+
+// MockSecretsFacadeMockRecorder is the mock recorder for MockSecretsFacade.
+type MockSecretsFacadeMockRecorder struct {
+	mock *MockSecretsFacade
+}
+
+// NewMockSecretsFacade creates a new mock instance.
+func NewMockSecretsFacade(ctrl *gomock.Controller) *MockSecretsFacade {
+	mock := &MockSecretsFacade{ctrl: ctrl}
+	mock.recorder = &MockSecretsFacadeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretsFacade) EXPECT() *MockSecretsFacadeMockRecorder {
+	return m.recorder
+}
+
+// CheckSecretContentDrift mocks base method.
+func (m *MockSecretsFacade) CheckSecretContentDrift() (int, []secrets.RevisionContentDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSecretContentDrift")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]secrets.RevisionContentDrift)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CheckSecretContentDrift indicates an expected call of CheckSecretContentDrift.
+func (mr *MockSecretsFacadeMockRecorder) CheckSecretContentDrift() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSecretContentDrift", reflect.TypeOf((*MockSecretsFacade)(nil).CheckSecretContentDrift))
+}
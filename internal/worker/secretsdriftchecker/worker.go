@@ -0,0 +1,122 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsdriftchecker
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	coresecrets "github.com/juju/juju/core/secrets"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead use the one passed as manifold config.
+type logger interface{}
+
+var _ logger = struct{}{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// SecretsFacade instances provide the API for the worker
+// to check secret content drift.
+type SecretsFacade interface {
+	CheckSecretContentDrift() (int, []coresecrets.RevisionContentDrift, error)
+}
+
+// Config defines the operation of the Worker.
+type Config struct {
+	SecretsFacade
+	Logger Logger
+	Clock  clock.Clock
+
+	// Period is the time between checks.
+	Period time.Duration
+}
+
+// Validate returns an error if config cannot drive the Worker.
+func (config Config) Validate() error {
+	if config.SecretsFacade == nil {
+		return errors.NotValidf("nil SecretsFacade")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a secretsdriftchecker Worker backed by config, or an error.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Worker checks the content of the model's secrets
+// stored in external backends every Period.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return errors.Trace(w.catacomb.ErrDying())
+		case <-w.config.Clock.After(w.config.Period):
+			if err := w.check(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *Worker) check() error {
+	checked, drifted, err := w.config.SecretsFacade.CheckSecretContentDrift()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.config.Logger.Debugf("checked content of %d secret revisions in external backends", checked)
+	for _, d := range drifted {
+		if d.Error != nil {
+			w.config.Logger.Warningf("cannot check content of secret %q revision %d in backend %q: %v",
+				d.URI, d.Revision, d.BackendName, d.Error)
+			continue
+		}
+		w.config.Logger.Warningf("content of secret %q revision %d is %s in backend %q",
+			d.URI, d.Revision, d.Drift, d.BackendName)
+	}
+	return nil
+}
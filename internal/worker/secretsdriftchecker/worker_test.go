@@ -0,0 +1,109 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsdriftchecker_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3/workertest"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/internal/worker/secretsdriftchecker"
+	"github.com/juju/juju/internal/worker/secretsdriftchecker/mocks"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	logger *mocks.MockLogger
+	facade *mocks.MockSecretsFacade
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.logger = mocks.NewMockLogger(ctrl)
+	s.facade = mocks.NewMockSecretsFacade(ctrl)
+	return ctrl
+}
+
+func (s *workerSuite) TestValidateConfig(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := secretsdriftchecker.NewWorker(secretsdriftchecker.Config{
+		SecretsFacade: s.facade,
+		Logger:        s.logger,
+		Clock:         s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *workerSuite) TestCheck(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	uri := coresecrets.NewURI()
+	done := make(chan struct{})
+	s.facade.EXPECT().CheckSecretContentDrift().Return(3, []coresecrets.RevisionContentDrift{{
+		URI:         uri,
+		Revision:    1,
+		BackendName: "myvault",
+		Drift:       coresecrets.ContentMissing,
+	}, {
+		URI:         uri,
+		Revision:    2,
+		BackendName: "myvault",
+		Error:       errors.New("boom"),
+	}}, nil)
+	s.logger.EXPECT().Debugf("checked content of %d secret revisions in external backends", 3)
+	s.logger.EXPECT().Warningf("content of secret %q revision %d is %s in backend %q",
+		uri, 1, coresecrets.ContentMissing, "myvault")
+	s.logger.EXPECT().Warningf("cannot check content of secret %q revision %d in backend %q: %v",
+		uri, 2, "myvault", gomock.Any()).Do(func(string, ...interface{}) {
+		close(done)
+	})
+
+	w, err := secretsdriftchecker.NewWorker(secretsdriftchecker.Config{
+		SecretsFacade: s.facade,
+		Logger:        s.logger,
+		Clock:         s.clock,
+		Period:        time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for drift check")
+	}
+}
+
+func (s *workerSuite) TestCheckError(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.facade.EXPECT().CheckSecretContentDrift().Return(0, nil, errors.New("boom"))
+
+	w, err := secretsdriftchecker.NewWorker(secretsdriftchecker.Config{
+		SecretsFacade: s.facade,
+		Logger:        s.logger,
+		Clock:         s.clock,
+		Period:        time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
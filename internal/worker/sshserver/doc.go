@@ -13,4 +13,27 @@
 // because we are passing the piped connection to it, essentially allowing the following
 // to work (despite only having one server listening):
 // - `ssh -J controller:2223 ubuntu@app.controller.model`
+//
+// For machines, and units of machine models, the sftp subsystem is proxied to the target
+// too, so file transfers work the same way:
+// - `sftp -J controller:2223 ubuntu@app.controller.model`
+// - `scp -J controller:2223 file ubuntu@app.controller.model:`
+//
+// Sessions with units of k8s models, including their sidecar containers, aren't proxied
+// yet, so file transfers to them through the controller aren't supported.
+//
+// When the target's model has SSH policies, the second server only accepts
+// connections a policy applies to, and only allows the shells, commands, file
+// transfers and port forwards those policies grant. Policies apply to Juju
+// users, who are identified by the SSH user certificates the controller
+// issues them; connections with the model's authorized keys are only subject
+// to policies applying to everyone.
+//
+// When the controller issues SSH user certificates, the second server also
+// accepts certificates signed by the controller's certificate authority whose
+// principals include the target's virtual hostname, whether or not the
+// certified key is one of the model's authorized keys. Certificates for units
+// of models with SSH policies only name the units, not the machines hosting
+// them, so they can't be used to bypass the policies by connecting to the
+// machines directly.
 package sshserver
@@ -10,10 +10,12 @@
 package sshserver
 
 import (
+	io "io"
 	reflect "reflect"
 
 	ssh "github.com/gliderlabs/ssh"
 	controller "github.com/juju/juju/controller"
+	sshpolicy "github.com/juju/juju/core/sshpolicy"
 	virtualhostname "github.com/juju/juju/core/virtualhostname"
 	watcher "github.com/juju/juju/core/watcher"
 	params "github.com/juju/juju/rpc/params"
@@ -122,6 +124,45 @@ func (c *MockFacadeClientListPublicKeysForModelCall) DoAndReturn(f func(params.L
 	return c
 }
 
+// SSHPoliciesForModel mocks base method.
+func (m *MockFacadeClient) SSHPoliciesForModel(arg0 string) ([]sshpolicy.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHPoliciesForModel", arg0)
+	ret0, _ := ret[0].([]sshpolicy.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSHPoliciesForModel indicates an expected call of SSHPoliciesForModel.
+func (mr *MockFacadeClientMockRecorder) SSHPoliciesForModel(arg0 any) *MockFacadeClientSSHPoliciesForModelCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHPoliciesForModel", reflect.TypeOf((*MockFacadeClient)(nil).SSHPoliciesForModel), arg0)
+	return &MockFacadeClientSSHPoliciesForModelCall{Call: call}
+}
+
+// MockFacadeClientSSHPoliciesForModelCall wrap *gomock.Call
+type MockFacadeClientSSHPoliciesForModelCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFacadeClientSSHPoliciesForModelCall) Return(arg0 []sshpolicy.Policy, arg1 error) *MockFacadeClientSSHPoliciesForModelCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFacadeClientSSHPoliciesForModelCall) Do(f func(string) ([]sshpolicy.Policy, error)) *MockFacadeClientSSHPoliciesForModelCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFacadeClientSSHPoliciesForModelCall) DoAndReturn(f func(string) ([]sshpolicy.Policy, error)) *MockFacadeClientSSHPoliciesForModelCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SSHServerHostKey mocks base method.
 func (m *MockFacadeClient) SSHServerHostKey() (string, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +202,83 @@ func (c *MockFacadeClientSSHServerHostKeyCall) DoAndReturn(f func() (string, err
 	return c
 }
 
+// SSHUserCertificateAuthority mocks base method.
+func (m *MockFacadeClient) SSHUserCertificateAuthority() (ssh0.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSHUserCertificateAuthority")
+	ret0, _ := ret[0].(ssh0.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSHUserCertificateAuthority indicates an expected call of SSHUserCertificateAuthority.
+func (mr *MockFacadeClientMockRecorder) SSHUserCertificateAuthority() *MockFacadeClientSSHUserCertificateAuthorityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHUserCertificateAuthority", reflect.TypeOf((*MockFacadeClient)(nil).SSHUserCertificateAuthority))
+	return &MockFacadeClientSSHUserCertificateAuthorityCall{Call: call}
+}
+
+// MockFacadeClientSSHUserCertificateAuthorityCall wrap *gomock.Call
+type MockFacadeClientSSHUserCertificateAuthorityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFacadeClientSSHUserCertificateAuthorityCall) Return(arg0 ssh0.PublicKey, arg1 error) *MockFacadeClientSSHUserCertificateAuthorityCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFacadeClientSSHUserCertificateAuthorityCall) Do(f func() (ssh0.PublicKey, error)) *MockFacadeClientSSHUserCertificateAuthorityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFacadeClientSSHUserCertificateAuthorityCall) DoAndReturn(f func() (ssh0.PublicKey, error)) *MockFacadeClientSSHUserCertificateAuthorityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SaveSessionRecording mocks base method.
+func (m *MockFacadeClient) SaveSessionRecording(arg0 params.SSHSessionRecordingArg, arg1 io.Reader, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSessionRecording", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSessionRecording indicates an expected call of SaveSessionRecording.
+func (mr *MockFacadeClientMockRecorder) SaveSessionRecording(arg0, arg1, arg2 any) *MockFacadeClientSaveSessionRecordingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSessionRecording", reflect.TypeOf((*MockFacadeClient)(nil).SaveSessionRecording), arg0, arg1, arg2)
+	return &MockFacadeClientSaveSessionRecordingCall{Call: call}
+}
+
+// MockFacadeClientSaveSessionRecordingCall wrap *gomock.Call
+type MockFacadeClientSaveSessionRecordingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFacadeClientSaveSessionRecordingCall) Return(arg0 error) *MockFacadeClientSaveSessionRecordingCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFacadeClientSaveSessionRecordingCall) Do(f func(params.SSHSessionRecordingArg, io.Reader, int64) error) *MockFacadeClientSaveSessionRecordingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFacadeClientSaveSessionRecordingCall) DoAndReturn(f func(params.SSHSessionRecordingArg, io.Reader, int64) error) *MockFacadeClientSaveSessionRecordingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // VirtualHostKey mocks base method.
 func (m *MockFacadeClient) VirtualHostKey(arg0 params.SSHVirtualHostKeyRequestArg) ([]byte, error) {
 	m.ctrl.T.Helper()
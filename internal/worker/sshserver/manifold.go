@@ -4,6 +4,7 @@
 package sshserver
 
 import (
+	"io"
 	"net"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/api/base"
 	sshserverapi "github.com/juju/juju/api/controller/sshserver"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/sshpolicy"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/rpc/params"
@@ -24,6 +26,7 @@ import (
 // Logger holds the methods required to log messages.
 type Logger interface {
 	Errorf(string, ...interface{})
+	Warningf(string, ...interface{})
 	Debugf(string, ...interface{})
 }
 
@@ -34,6 +37,9 @@ type FacadeClient interface {
 	SSHServerHostKey() (string, error)
 	VirtualHostKey(arg params.SSHVirtualHostKeyRequestArg) ([]byte, error)
 	ListPublicKeysForModel(sshPKIAuthArgs params.ListAuthorizedKeysArgs) ([]gossh.PublicKey, error)
+	SaveSessionRecording(arg params.SSHSessionRecordingArg, r io.Reader, size int64) error
+	SSHPoliciesForModel(modelUUID string) ([]sshpolicy.Policy, error)
+	SSHUserCertificateAuthority() (gossh.PublicKey, error)
 }
 
 // ManifoldConfig holds the information necessary to run an embedded SSH server
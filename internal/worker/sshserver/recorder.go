@@ -0,0 +1,333 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sshserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gliderlabs/ssh"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/virtualhostname"
+)
+
+const (
+	// maxRecordingSize is the size after which any further
+	// events in a session are dropped from its recording.
+	maxRecordingSize = 64 * 1024 * 1024
+
+	defaultRecordingWidth  = 80
+	defaultRecordingHeight = 24
+)
+
+// Event types of an asciicast v2 recording.
+const (
+	castOutput = "o"
+	castInput  = "i"
+	castResize = "r"
+)
+
+// castHeader is the header line of an asciicast v2 recording.
+// See https://docs.asciinema.org/manual/asciicast/v2/
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// sessionRecorder records the input and output of an SSH
+// session in the asciicast v2 format. The recording is spooled
+// to a temporary file, so that it needn't be held in memory
+// until it's saved.
+type sessionRecorder struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	started time.Time
+	title   string
+	logger  Logger
+
+	file *os.File
+	w    *bufio.Writer
+	size int64
+
+	// truncated is set when events are dropped from the
+	// recording, and err when it couldn't be written.
+	truncated bool
+	err       error
+	closed    bool
+
+	// pending holds the trailing bytes of an incomplete UTF-8
+	// sequence for each event type, so that multi-byte
+	// characters split across writes are recorded intact.
+	pending map[string][]byte
+}
+
+func newSessionRecorder(header castHeader, now func() time.Time, logger Logger) (*sessionRecorder, error) {
+	file, err := os.CreateTemp("", "juju-ssh-session-*.cast")
+	if err != nil {
+		return nil, errors.Annotate(err, "creating ssh session recording file")
+	}
+	r := &sessionRecorder{
+		now:     now,
+		started: now(),
+		title:   header.Title,
+		logger:  logger,
+		file:    file,
+		w:       bufio.NewWriter(file),
+		pending: make(map[string][]byte),
+	}
+	header.Version = 2
+	header.Timestamp = r.started.Unix()
+	// Marshalling a struct of strings and ints can't fail.
+	line, _ := json.Marshal(header)
+	r.writeLine(line)
+	return r, nil
+}
+
+// record adds an event of the given type to the recording.
+func (r *sessionRecorder) record(eventType string, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.truncated {
+		return
+	}
+	data = append(r.pending[eventType], data...)
+	complete := completeRunesLen(data)
+	r.pending[eventType] = append([]byte(nil), data[complete:]...)
+	if complete == 0 {
+		return
+	}
+	r.writeEvent(eventType, string(data[:complete]))
+}
+
+// completeRunesLen returns the length of data without any
+// incomplete UTF-8 sequence at its end.
+func completeRunesLen(data []byte) int {
+	for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+		if !utf8.RuneStart(data[len(data)-i]) {
+			continue
+		}
+		if utf8.FullRune(data[len(data)-i:]) {
+			return len(data)
+		}
+		return len(data) - i
+	}
+	return len(data)
+}
+
+// writeEvent writes an event line to the recording. It must be
+// called with the mutex held.
+func (r *sessionRecorder) writeEvent(eventType, data string) {
+	elapsed := r.now().Sub(r.started).Seconds()
+	// Marshalling a slice of floats and strings can't fail.
+	line, _ := json.Marshal([]any{elapsed, eventType, data})
+	r.writeLine(line)
+}
+
+// writeLine writes a line to the recording, unless that would
+// make it too large, in which case it and any further events are
+// dropped. It must be called with the mutex held.
+func (r *sessionRecorder) writeLine(line []byte) {
+	if r.closed {
+		return
+	}
+	if r.size+int64(len(line))+1 > maxRecordingSize {
+		r.truncated = true
+		r.logger.Warningf("ssh session recording for %s exceeded %d bytes, dropping further events",
+			r.title, maxRecordingSize)
+		return
+	}
+	if _, err := r.w.Write(append(line, '\n')); err != nil {
+		r.truncated = true
+		r.err = errors.Annotate(err, "writing ssh session recording")
+		r.logger.Errorf("cannot record ssh session for %s, dropping further events: %v", r.title, err)
+		return
+	}
+	r.size += int64(len(line)) + 1
+}
+
+// resize adds a terminal resize event to the recording.
+func (r *sessionRecorder) resize(w ssh.Window) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.truncated {
+		return
+	}
+	r.writeEvent(castResize, fmt.Sprintf("%dx%d", w.Width, w.Height))
+}
+
+// Content returns a reader for the recording, and its size. The
+// reader is only valid until the recorder is closed.
+func (r *sessionRecorder) Content() (io.Reader, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return nil, 0, r.err
+	}
+	if err := r.w.Flush(); err != nil {
+		return nil, 0, errors.Annotate(err, "writing ssh session recording")
+	}
+	if _, err := r.file.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, errors.Annotate(err, "reading ssh session recording")
+	}
+	return io.LimitReader(r.file, r.size), r.size, nil
+}
+
+// Close removes the recording's temporary file.
+func (r *sessionRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	closeErr := r.file.Close()
+	if err := os.Remove(r.file.Name()); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(closeErr)
+}
+
+// Started returns when the recording started.
+func (r *sessionRecorder) Started() time.Time {
+	return r.started
+}
+
+// Truncated returns whether events were dropped from the
+// recording because it grew too large.
+func (r *sessionRecorder) Truncated() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.truncated
+}
+
+// recordedSession wraps an ssh.Session, recording the
+// data read from and written to it.
+type recordedSession struct {
+	ssh.Session
+
+	recorder *sessionRecorder
+
+	pty    ssh.Pty
+	winCh  <-chan ssh.Window
+	hasPty bool
+}
+
+func newRecordedSession(
+	session ssh.Session, destination virtualhostname.Info, now func() time.Time, logger Logger,
+) (*recordedSession, error) {
+	pty, winCh, hasPty := session.Pty()
+
+	header := castHeader{
+		Width:   defaultRecordingWidth,
+		Height:  defaultRecordingHeight,
+		Command: session.RawCommand(),
+		Title:   fmt.Sprintf("%s@%s", session.User(), destination.String()),
+	}
+	if hasPty {
+		if pty.Window.Width > 0 && pty.Window.Height > 0 {
+			header.Width = pty.Window.Width
+			header.Height = pty.Window.Height
+		}
+		if pty.Term != "" {
+			header.Env = map[string]string{"TERM": pty.Term}
+		}
+	}
+
+	recorder, err := newSessionRecorder(header, now, logger)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &recordedSession{
+		Session:  session,
+		recorder: recorder,
+		pty:      pty,
+		hasPty:   hasPty,
+	}
+	if winCh != nil {
+		s.winCh = s.forwardWindowChanges(winCh, pty.Window)
+	}
+	return s, nil
+}
+
+// forwardWindowChanges records terminal resizes as they're
+// passed on to the session handler. The returned channel is
+// closed when the session's window channel is.
+func (s *recordedSession) forwardWindowChanges(in <-chan ssh.Window, current ssh.Window) <-chan ssh.Window {
+	out := make(chan ssh.Window, 1)
+	go func() {
+		defer close(out)
+		for w := range in {
+			if w != current {
+				s.recorder.resize(w)
+				current = w
+			}
+			select {
+			case out <- w:
+			case <-s.Context().Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Read implements io.Reader, recording the session's input.
+func (s *recordedSession) Read(p []byte) (int, error) {
+	n, err := s.Session.Read(p)
+	if n > 0 {
+		s.recorder.record(castInput, p[:n])
+	}
+	return n, err
+}
+
+// Write implements io.Writer, recording the session's output.
+func (s *recordedSession) Write(p []byte) (int, error) {
+	n, err := s.Session.Write(p)
+	if n > 0 {
+		s.recorder.record(castOutput, p[:n])
+	}
+	return n, err
+}
+
+// Stderr returns the session's stderr stream, which is
+// recorded as output.
+func (s *recordedSession) Stderr() io.ReadWriter {
+	return recordedStderr{
+		ReadWriter: s.Session.Stderr(),
+		recorder:   s.recorder,
+	}
+}
+
+// Pty returns the session's pty, with a window channel
+// that has its changes recorded.
+func (s *recordedSession) Pty() (ssh.Pty, <-chan ssh.Window, bool) {
+	return s.pty, s.winCh, s.hasPty
+}
+
+type recordedStderr struct {
+	io.ReadWriter
+	recorder *sessionRecorder
+}
+
+// Write implements io.Writer.
+func (s recordedStderr) Write(p []byte) (int, error) {
+	n, err := s.ReadWriter.Write(p)
+	if n > 0 {
+		s.recorder.record(castOutput, p[:n])
+	}
+	return n, err
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sshserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gliderlabs/ssh"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/virtualhostname"
+)
+
+type recorderSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&recorderSuite{})
+
+type fakeSession struct {
+	ssh.Session
+	stdin  bytes.Buffer
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+	pty    ssh.Pty
+	winCh  chan ssh.Window
+}
+
+func (f *fakeSession) Read(p []byte) (int, error)  { return f.stdin.Read(p) }
+func (f *fakeSession) Write(p []byte) (int, error) { return f.stdout.Write(p) }
+func (f *fakeSession) Stderr() io.ReadWriter       { return &f.stderr }
+func (f *fakeSession) User() string                { return "ubuntu" }
+func (f *fakeSession) RawCommand() string          { return "" }
+func (f *fakeSession) Context() ssh.Context        { return fakeContext{} }
+
+// fakeContext is an ssh.Context which is never done.
+type fakeContext struct {
+	ssh.Context
+}
+
+func (fakeContext) Done() <-chan struct{} { return context.Background().Done() }
+
+func (f *fakeSession) Pty() (ssh.Pty, <-chan ssh.Window, bool) {
+	if f.winCh == nil {
+		return ssh.Pty{}, nil, false
+	}
+	return f.pty, f.winCh, true
+}
+
+// fakeClock returns a now func which advances a second each call.
+func fakeClock() func() time.Time {
+	t := time.Unix(1700000000, 0)
+	return func() time.Time {
+		now := t
+		t = t.Add(time.Second)
+		return now
+	}
+}
+
+// fakeLogger records the warnings logged.
+type fakeLogger struct {
+	Logger
+	warnings []string
+}
+
+func (l *fakeLogger) Warningf(format string, args ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(format, args...))
+}
+
+func (s *recorderSuite) newRecorder(c *gc.C, logger Logger) *sessionRecorder {
+	r, err := newSessionRecorder(castHeader{}, fakeClock(), logger)
+	c.Assert(err, jc.ErrorIsNil)
+	s.closeRecorder(c, r)
+	return r
+}
+
+func (s *recorderSuite) newRecordedSession(c *gc.C, session ssh.Session) *recordedSession {
+	recorded, err := newRecordedSession(session, s.destination(c), fakeClock(), loggo.GetLogger("test"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.closeRecorder(c, recorded.recorder)
+	return recorded
+}
+
+// closeRecorder closes the recorder at the end of the test, and
+// checks its temporary file is removed.
+func (s *recorderSuite) closeRecorder(c *gc.C, r *sessionRecorder) {
+	s.AddCleanup(func(c *gc.C) {
+		c.Check(r.Close(), jc.ErrorIsNil)
+		_, err := os.Stat(r.file.Name())
+		c.Check(err, jc.Satisfies, os.IsNotExist)
+	})
+}
+
+// content returns the recording made by the recorder.
+func content(c *gc.C, r *sessionRecorder) string {
+	reader, size, err := r.Content()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := io.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(int64(len(data)), gc.Equals, size)
+	return string(data)
+}
+
+func (s *recorderSuite) destination(c *gc.C) virtualhostname.Info {
+	info, err := virtualhostname.Parse("1.8419cd78-4993-4c3a-928e-c646226beeee.juju.local")
+	c.Assert(err, jc.ErrorIsNil)
+	return info
+}
+
+func (s *recorderSuite) TestRecordsInputAndOutput(c *gc.C) {
+	session := &fakeSession{}
+	session.stdin.WriteString("ls\n")
+
+	recorded := s.newRecordedSession(c, session)
+	buf := make([]byte, 10)
+	n, err := recorded.Read(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(buf[:n]), gc.Equals, "ls\n")
+	_, err = recorded.Write([]byte("file\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = recorded.Stderr().Write([]byte("oops\n"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(session.stdout.String(), gc.Equals, "file\n")
+	c.Check(session.stderr.String(), gc.Equals, "oops\n")
+	c.Check(content(c, recorded.recorder), gc.Equals, `
+{"version":2,"width":80,"height":24,"timestamp":1700000000,"title":"ubuntu@1.8419cd78-4993-4c3a-928e-c646226beeee.juju.local"}
+[1,"i","ls\n"]
+[2,"o","file\n"]
+[3,"o","oops\n"]
+`[1:])
+}
+
+func (s *recorderSuite) TestRecordsPtyAndResizes(c *gc.C) {
+	session := &fakeSession{
+		pty: ssh.Pty{
+			Term:   "xterm",
+			Window: ssh.Window{Width: 100, Height: 40},
+		},
+		winCh: make(chan ssh.Window, 2),
+	}
+	// The initial window size is sent on the channel too, and
+	// shouldn't be recorded as a resize.
+	session.winCh <- ssh.Window{Width: 100, Height: 40}
+	session.winCh <- ssh.Window{Width: 120, Height: 50}
+	close(session.winCh)
+
+	recorded := s.newRecordedSession(c, session)
+	pty, winCh, isPty := recorded.Pty()
+	c.Assert(isPty, jc.IsTrue)
+	c.Assert(pty.Term, gc.Equals, "xterm")
+	var windows []ssh.Window
+	for w := range winCh {
+		windows = append(windows, w)
+	}
+	c.Assert(windows, gc.HasLen, 2)
+
+	c.Check(content(c, recorded.recorder), gc.Equals, `
+{"version":2,"width":100,"height":40,"timestamp":1700000000,"title":"ubuntu@1.8419cd78-4993-4c3a-928e-c646226beeee.juju.local","env":{"TERM":"xterm"}}
+[1,"r","120x50"]
+`[1:])
+}
+
+func (s *recorderSuite) TestRecordsSplitRunes(c *gc.C) {
+	r := s.newRecorder(c, loggo.GetLogger("test"))
+	euro := []byte("€")
+	r.record(castOutput, []byte{'a', euro[0]})
+	r.record(castOutput, euro[1:])
+
+	lines := strings.Split(strings.TrimSpace(content(c, r)), "\n")
+	c.Assert(lines, gc.HasLen, 3)
+	c.Check(lines[1], gc.Equals, `[1,"o","a"]`)
+	c.Check(lines[2], gc.Equals, `[2,"o","€"]`)
+}
+
+func (s *recorderSuite) TestTruncatesLargeRecordings(c *gc.C) {
+	logger := &fakeLogger{}
+	r := s.newRecorder(c, logger)
+	chunk := bytes.Repeat([]byte("x"), 1024*1024)
+	for i := 0; i < 65; i++ {
+		r.record(castOutput, chunk)
+	}
+	c.Assert(r.Truncated(), jc.IsTrue)
+	c.Assert(len(content(c, r)) <= maxRecordingSize, jc.IsTrue)
+	c.Assert(logger.warnings, gc.HasLen, 1)
+	c.Check(logger.warnings[0], gc.Matches, `ssh session recording for .* exceeded 67108864 bytes, dropping further events`)
+}
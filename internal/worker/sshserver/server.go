@@ -4,6 +4,7 @@
 package sshserver
 
 import (
+	"fmt"
 	"net"
 	"strconv"
 	"sync/atomic"
@@ -12,15 +13,20 @@ import (
 	"github.com/canonical/lxd/shared/logger"
 	"github.com/gliderlabs/ssh"
 	"github.com/juju/errors"
+	"github.com/juju/names/v5"
 	"github.com/juju/worker/v3"
 	gossh "golang.org/x/crypto/ssh"
 	"gopkg.in/tomb.v2"
 
+	"github.com/juju/juju/core/sshpolicy"
 	"github.com/juju/juju/core/virtualhostname"
 	jujussh "github.com/juju/juju/pki/ssh"
 	"github.com/juju/juju/rpc/params"
 )
 
+// sftpSubsystem is the name of the SSH subsystem used for sftp.
+const sftpSubsystem = "sftp"
+
 type authenticatedViaPublicKey struct{}
 
 // SessionHandler is an interface that proxies SSH sessions to a target unit/machine.
@@ -57,6 +63,10 @@ type ServerWorkerConfig struct {
 
 	// SessionHandler handles proxying SSH sessions to the target machine.
 	SessionHandler SessionHandler
+
+	// RecordSessions sets whether sessions proxied through the server
+	// are recorded and saved to the controller.
+	RecordSessions bool
 }
 
 // Validate validates the workers configuration is as expected.
@@ -311,18 +321,56 @@ func (s *ServerWorker) newEmbeddedSSHServer(ctx ssh.Context, info virtualhostnam
 		}
 	}
 
+	policies, err := s.config.FacadeClient.SSHPoliciesForModel(info.ModelUUID())
+	if err != nil {
+		s.config.Logger.Errorf("failed to fetch ssh policies for model: %v", err)
+		return nil, errors.Trace(err)
+	}
+	var application string
+	if unit, ok := info.Unit(); ok {
+		application, _ = names.UnitApplication(unit)
+	}
+	allowed := func(ctx ssh.Context, action sshpolicy.Action, command string) bool {
+		return sshpolicy.Allowed(policies, sshpolicy.Request{
+			User:        certifiedUser(ctx),
+			Application: application,
+			Action:      action,
+			Command:     command,
+		})
+	}
+
 	forwardHandler := &ssh.ForwardedTCPHandler{}
 	server := &ssh.Server{
 		PublicKeyHandler: func(ctx ssh.Context, keyPresented ssh.PublicKey) bool {
+			// Certificates issued by the controller for the target are
+			// accepted in place of the model's authorized keys.
+			if cert, ok := keyPresented.(*gossh.Certificate); ok {
+				if !s.certificateAllowed(cert, info) {
+					return false
+				}
+				// The certificate names the Juju user
+				// the controller issued it to.
+				return sshpolicy.Allowed(policies, sshpolicy.Request{
+					User:        cert.KeyId,
+					Application: application,
+					Action:      sshpolicy.Connect,
+				})
+			}
 			for _, key := range keysToVerify {
-				if ssh.KeysEqual(key, keyPresented) {
-					return true
+				if !ssh.KeysEqual(key, keyPresented) {
+					continue
 				}
+				// Authorized keys aren't tied to any Juju
+				// user, so only policies for everyone apply.
+				return sshpolicy.Allowed(policies, sshpolicy.Request{
+					Application: application,
+					Action:      sshpolicy.Connect,
+				})
 			}
 			return false
 		},
 		LocalPortForwardingCallback: ssh.LocalPortForwardingCallback(func(ctx ssh.Context, dhost string, dport uint32) bool {
-			return true
+			return allowed(ctx, sshpolicy.PortForward, "")
 		}),
 		// ReversePortForwarding will not be supported.
 		ReversePortForwardingCallback: ssh.ReversePortForwardingCallback(func(ctx ssh.Context, host string, port uint32) bool {
@@ -336,8 +384,42 @@ func (s *ServerWorker) newEmbeddedSSHServer(ctx ssh.Context, info virtualhostnam
 			"tcpip-forward":        forwardHandler.HandleSSHRequest,
 			"cancel-tcpip-forward": forwardHandler.HandleSSHRequest,
 		},
+		// The sftp subsystem is proxied to machine targets like a
+		// command, which allows file transfers with sftp and scp. File
+		// transfers aren't terminal sessions, so they're never recorded.
+		SubsystemHandlers: map[string]ssh.SubsystemHandler{
+			sftpSubsystem: func(session ssh.Session) {
+				if !allowed(session.Context(), sshpolicy.SFTP, "") {
+					denySession(session, sshpolicy.SFTP)
+					return
+				}
+				s.config.SessionHandler.Handle(session, info)
+			},
+		},
 		Handler: func(session ssh.Session) {
-			s.config.SessionHandler.Handle(session, info)
+			action, command := sshpolicy.Shell, session.RawCommand()
+			if command != "" {
+				action = sshpolicy.Exec
+			}
+			if !allowed(session.Context(), action, command) {
+				denySession(session, action)
+				return
+			}
+			if !s.config.RecordSessions {
+				s.config.SessionHandler.Handle(session, info)
+				return
+			}
+			recorded, err := newRecordedSession(session, info, time.Now, s.config.Logger)
+			if err != nil {
+				// Sessions mustn't go unrecorded when recording
+				// is enabled.
+				s.config.Logger.Errorf("cannot record ssh session for %s@%s: %v", session.User(), info.String(), err)
+				_, _ = fmt.Fprintln(session.Stderr(), "cannot record ssh session")
+				_ = session.Exit(1)
+				return
+			}
+			s.config.SessionHandler.Handle(recorded, info)
+			s.saveRecording(recorded, info)
 		},
 	}
 
@@ -349,6 +431,72 @@ func (s *ServerWorker) newEmbeddedSSHServer(ctx ssh.Context, info virtualhostnam
 	return server, nil
 }
 
+// certificateAllowed reports whether the certificate is an SSH user
+// certificate issued by the controller for connecting to the target.
+func (s *ServerWorker) certificateAllowed(cert *gossh.Certificate, info virtualhostname.Info) bool {
+	caKey, err := s.config.FacadeClient.SSHUserCertificateAuthority()
+	if err != nil {
+		s.config.Logger.Errorf("failed to fetch ssh user certificate authority: %v", err)
+		return false
+	}
+	if caKey == nil {
+		return false
+	}
+	if err := jujussh.CheckUserCertificate(caKey, cert, info.String(), time.Now()); err != nil {
+		s.config.Logger.Debugf("rejecting ssh user certificate %q: %v", cert.KeyId, err)
+		return false
+	}
+	return true
+}
+
+// certifiedUser returns the name of the Juju user the connection was
+// authenticated as, which is the user the controller issued the SSH
+// user certificate it was authenticated with to, or an empty string if
+// it wasn't authenticated with a certificate.
+func certifiedUser(ctx ssh.Context) string {
+	cert, ok := ctx.Value(ssh.ContextKeyPublicKey).(*gossh.Certificate)
+	if !ok {
+		return ""
+	}
+	return cert.KeyId
+}
+
+// denySession tells the user the SSH policies of the model don't
+// allow the action, and ends the session.
+func denySession(session ssh.Session, action sshpolicy.Action) {
+	_, _ = fmt.Fprintf(session.Stderr(), "permission denied by ssh policy: %s not allowed\n", action)
+	_ = session.Exit(1)
+}
+
+// saveRecording streams the recording of a session to the
+// controller, and removes its temporary file.
+func (s *ServerWorker) saveRecording(session *recordedSession, info virtualhostname.Info) {
+	defer func() {
+		if err := session.recorder.Close(); err != nil {
+			s.config.Logger.Errorf("failed to remove ssh session recording for %s@%s: %v", session.User(), info.String(), err)
+		}
+	}()
+	content, size, err := session.recorder.Content()
+	if err != nil {
+		s.config.Logger.Errorf("failed to save ssh session recording for %s@%s: %v", session.User(), info.String(), err)
+		return
+	}
+	var fingerprint string
+	if key := session.PublicKey(); key != nil {
+		fingerprint = gossh.FingerprintSHA256(key)
+	}
+	err = s.config.FacadeClient.SaveSessionRecording(params.SSHSessionRecordingArg{
+		User:           session.User(),
+		KeyFingerprint: fingerprint,
+		Target:         info.String(),
+		Started:        session.recorder.Started(),
+		Ended:          time.Now(),
+	}, content, size)
+	if err != nil {
+		s.config.Logger.Errorf("failed to save ssh session recording for %s@%s: %v", session.User(), info.String(), err)
+	}
+}
+
 // Report returns a map of metrics from the server worker.
 func (s *ServerWorker) Report() map[string]any {
 	return map[string]any{
@@ -9,6 +9,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -18,14 +19,18 @@ import (
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v3/workertest"
+	"github.com/pkg/sftp"
 	"go.uber.org/mock/gomock"
 	gossh "golang.org/x/crypto/ssh"
 	"google.golang.org/grpc/test/bufconn"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/sshpolicy"
 	"github.com/juju/juju/core/virtualhostname"
+	jujussh "github.com/juju/juju/pki/ssh"
 	pkitest "github.com/juju/juju/pki/test"
 	params "github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
 	jujutesting "github.com/juju/juju/testing"
 )
 
@@ -80,6 +85,12 @@ func (s *sshServerSuite) setupMocks(c *gc.C) *gomock.Controller {
 	return ctrl
 }
 
+// expectNoPolicies sets up the facade client to report
+// that models have no SSH policies.
+func (s *sshServerSuite) expectNoPolicies() {
+	s.facadeClient.EXPECT().SSHPoliciesForModel(gomock.Any()).Return(nil, nil).AnyTimes()
+}
+
 func newServerWorkerConfig(
 	l Logger,
 	j string,
@@ -122,6 +133,7 @@ func (s *sshServerSuite) TestValidate(c *gc.C) {
 
 func (s *sshServerSuite) TestSSHServerNoAuth(c *gc.C) {
 	defer s.setupMocks(c).Finish()
+	s.expectNoPolicies()
 
 	s.facadeClient.EXPECT().VirtualHostKey(gomock.Any()).Return(s.hostKey, nil)
 
@@ -197,6 +209,7 @@ func (s *sshServerSuite) TestSSHServerNoAuth(c *gc.C) {
 
 func (s *sshServerSuite) TestSSHPublicKeyHandler(c *gc.C) {
 	defer s.setupMocks(c).Finish()
+	s.expectNoPolicies()
 
 	listener := bufconn.Listen(1024)
 
@@ -278,6 +291,7 @@ func (s *sshServerSuite) TestSSHPublicKeyHandler(c *gc.C) {
 
 func (s *sshServerSuite) TestHostKeyForTarget(c *gc.C) {
 	defer s.setupMocks(c).Finish()
+	s.expectNoPolicies()
 	// Firstly, start the server on an in-memory listener
 	listener := bufconn.Listen(8 * 1024)
 	s.facadeClient.EXPECT().VirtualHostKey(gomock.Any()).Return(s.hostKey, nil)
@@ -331,6 +345,7 @@ func (s *sshServerSuite) TestHostKeyForTarget(c *gc.C) {
 
 func (s *sshServerSuite) TestSSHServerMaxConnections(c *gc.C) {
 	defer s.setupMocks(c).Finish()
+	s.expectNoPolicies()
 
 	s.facadeClient.EXPECT().VirtualHostKey(gomock.Any()).Return(s.hostKey, nil).AnyTimes()
 
@@ -444,3 +459,457 @@ func inMemoryDial(c *gc.C, listener *bufconn.Listener, config *gossh.ClientConfi
 	c.Assert(err, jc.ErrorIsNil)
 	return gossh.NewClient(sshConn, newChan, reqs)
 }
+
+func (s *sshServerSuite) TestSSHServerRecordsSessions(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.expectNoPolicies()
+
+	s.facadeClient.EXPECT().VirtualHostKey(gomock.Any()).Return(s.hostKey, nil)
+
+	listener := bufconn.Listen(1024)
+
+	server, err := NewServerWorker(ServerWorkerConfig{
+		Logger:                   loggo.GetLogger("test"),
+		Listener:                 listener,
+		MaxConcurrentConnections: maxConcurrentConnections,
+		JumpHostKey:              jujutesting.SSHServerHostKey,
+		FacadeClient:             s.facadeClient,
+		disableAuth:              true,
+		SessionHandler:           s.sessionHandler,
+		RecordSessions:           true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, server)
+
+	client := inMemoryDial(c, listener, &gossh.ClientConfig{
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+		Auth: []gossh.AuthMethod{
+			gossh.Password(""),
+		},
+	})
+	tunnel, err := client.Dial("tcp", fmt.Sprintf("%s:0", testVirtualHostname))
+	c.Assert(err, jc.ErrorIsNil)
+
+	terminatingClientConn, terminatingClientChan, terminatingReqs, err := gossh.NewClientConn(
+		tunnel,
+		"",
+		&gossh.ClientConfig{
+			User:            "ubuntu",
+			HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+			Auth: []gossh.AuthMethod{
+				gossh.PublicKeys(s.userSigner),
+			},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	terminatingClient := gossh.NewClient(terminatingClientConn, terminatingClientChan, terminatingReqs)
+	terminatingSession, err := terminatingClient.NewSession()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.sessionHandler.EXPECT().Handle(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(session ssh.Session, destination virtualhostname.Info) {
+			_, _ = session.Write([]byte("hello\n"))
+		},
+	)
+	type savedRecording struct {
+		arg       params.SSHSessionRecordingArg
+		recording string
+		err       error
+	}
+	saved := make(chan savedRecording, 1)
+	s.facadeClient.EXPECT().SaveSessionRecording(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(arg params.SSHSessionRecordingArg, r io.Reader, size int64) error {
+			data, err := io.ReadAll(r)
+			if err == nil && int64(len(data)) != size {
+				err = errors.Errorf("read %d bytes, want %d", len(data), size)
+			}
+			saved <- savedRecording{arg: arg, recording: string(data), err: err}
+			return nil
+		},
+	)
+	output, err := terminatingSession.CombinedOutput("ls")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, "hello\n")
+
+	select {
+	case saved := <-saved:
+		c.Assert(saved.err, jc.ErrorIsNil)
+		c.Check(saved.arg.User, gc.Equals, "ubuntu")
+		c.Check(saved.arg.Target, gc.Equals, testVirtualHostname)
+		lines := strings.Split(strings.TrimSpace(saved.recording), "\n")
+		c.Assert(lines, gc.HasLen, 2)
+		c.Check(lines[0], gc.Matches, `\{"version":2,"width":80,"height":24,.*"command":"ls".*`)
+		c.Check(lines[1], gc.Matches, `\[.*,"o","hello\\n"\]`)
+	case <-time.After(jujutesting.LongWait):
+		c.Fatalf("timed out waiting for the recording to be saved")
+	}
+
+	workertest.CleanKill(c, server)
+}
+
+func (s *sshServerSuite) TestSSHServerSFTP(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	s.expectNoPolicies()
+	defer ctrl.Finish()
+
+	s.facadeClient.EXPECT().VirtualHostKey(gomock.Any()).Return(s.hostKey, nil)
+
+	// Start an sftp server to emulate the target machine.
+	machineServer := &ssh.Server{
+		SubsystemHandlers: map[string]ssh.SubsystemHandler{
+			"sftp": func(session ssh.Session) {
+				server := sftp.NewRequestServer(session, sftp.InMemHandler())
+				_ = server.Serve()
+				_ = server.Close()
+			},
+		},
+	}
+	machineListener := bufconn.Listen(1024)
+	defer machineListener.Close()
+	go func() {
+		_ = machineServer.Serve(machineListener)
+	}()
+
+	connector := NewMockSSHConnector(ctrl)
+	connector.EXPECT().Connect(gomock.Any()).DoAndReturn(
+		func(destination virtualhostname.Info) (*gossh.Client, error) {
+			c.Check(destination.String(), gc.Equals, testVirtualHostname)
+			conn, err := machineListener.Dial()
+			if err != nil {
+				return nil, err
+			}
+			sshConn, newChan, reqs, err := gossh.NewClientConn(conn, "", &gossh.ClientConfig{
+				HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return gossh.NewClient(sshConn, newChan, reqs), nil
+		},
+	)
+
+	listener := bufconn.Listen(1024)
+	server, err := NewServerWorker(ServerWorkerConfig{
+		Logger:                   loggo.GetLogger("test"),
+		Listener:                 listener,
+		MaxConcurrentConnections: maxConcurrentConnections,
+		JumpHostKey:              jujutesting.SSHServerHostKey,
+		FacadeClient:             s.facadeClient,
+		disableAuth:              true,
+		SessionHandler: &sessionHandler{
+			connector: connector,
+			modelType: state.ModelTypeIAAS,
+			logger:    loggo.GetLogger("test"),
+		},
+		// Recording is enabled to check that file transfers aren't recorded.
+		RecordSessions: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, server)
+
+	client := inMemoryDial(c, listener, &gossh.ClientConfig{
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+		Auth: []gossh.AuthMethod{
+			gossh.Password(""),
+		},
+	})
+	tunnel, err := client.Dial("tcp", fmt.Sprintf("%s:0", testVirtualHostname))
+	c.Assert(err, jc.ErrorIsNil)
+
+	terminatingClientConn, terminatingClientChan, terminatingReqs, err := gossh.NewClientConn(
+		tunnel,
+		"",
+		&gossh.ClientConfig{
+			User:            "ubuntu",
+			HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+			Auth: []gossh.AuthMethod{
+				gossh.PublicKeys(s.userSigner),
+			},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	terminatingClient := gossh.NewClient(terminatingClientConn, terminatingClientChan, terminatingReqs)
+
+	sftpClient, err := sftp.NewClient(terminatingClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	f, err := sftpClient.Create("/hello.txt")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = f.Write([]byte("hello through the controller"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	f, err = sftpClient.Open("/hello.txt")
+	c.Assert(err, jc.ErrorIsNil)
+	content, err := io.ReadAll(f)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "hello through the controller")
+
+	c.Assert(sftpClient.Close(), jc.ErrorIsNil)
+	workertest.CleanKill(c, server)
+}
+
+func (s *sshServerSuite) TestSSHServerRejectsUnknownSubsystem(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.expectNoPolicies()
+
+	s.facadeClient.EXPECT().VirtualHostKey(gomock.Any()).Return(s.hostKey, nil)
+
+	listener := bufconn.Listen(1024)
+	server, err := NewServerWorker(ServerWorkerConfig{
+		Logger:                   loggo.GetLogger("test"),
+		Listener:                 listener,
+		MaxConcurrentConnections: maxConcurrentConnections,
+		JumpHostKey:              jujutesting.SSHServerHostKey,
+		FacadeClient:             s.facadeClient,
+		disableAuth:              true,
+		SessionHandler:           s.sessionHandler,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, server)
+
+	client := inMemoryDial(c, listener, &gossh.ClientConfig{
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+		Auth: []gossh.AuthMethod{
+			gossh.Password(""),
+		},
+	})
+	tunnel, err := client.Dial("tcp", fmt.Sprintf("%s:0", testVirtualHostname))
+	c.Assert(err, jc.ErrorIsNil)
+
+	terminatingClientConn, terminatingClientChan, terminatingReqs, err := gossh.NewClientConn(
+		tunnel,
+		"",
+		&gossh.ClientConfig{
+			User:            "ubuntu",
+			HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+			Auth: []gossh.AuthMethod{
+				gossh.PublicKeys(s.userSigner),
+			},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	terminatingClient := gossh.NewClient(terminatingClientConn, terminatingClientChan, terminatingReqs)
+	session, err := terminatingClient.NewSession()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = session.RequestSubsystem("netconf")
+	c.Assert(err, gc.ErrorMatches, "ssh: subsystem request failed")
+
+	workertest.CleanKill(c, server)
+}
+
+func (s *sshServerSuite) TestSSHServerEnforcesPolicies(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	newSigner := func() gossh.Signer {
+		privateKey, err := jujussh.ED25519()
+		c.Assert(err, jc.ErrorIsNil)
+		signer, err := gossh.NewSignerFromKey(privateKey)
+		c.Assert(err, jc.ErrorIsNil)
+		return signer
+	}
+	caSigner := newSigner()
+	newCertSigner := func(user string) gossh.Signer {
+		keySigner := newSigner()
+		cert, err := jujussh.NewUserCertificate(
+			caSigner, keySigner.PublicKey(), user, []string{testVirtualHostname}, time.Minute, time.Now())
+		c.Assert(err, jc.ErrorIsNil)
+		signer, err := gossh.NewCertSigner(cert, keySigner)
+		c.Assert(err, jc.ErrorIsNil)
+		return signer
+	}
+
+	s.facadeClient.EXPECT().VirtualHostKey(gomock.Any()).Return(s.hostKey, nil).AnyTimes()
+	s.facadeClient.EXPECT().ListPublicKeysForModel(gomock.Any()).Return(
+		[]gossh.PublicKey{s.userSigner.PublicKey()}, nil,
+	).AnyTimes()
+	s.facadeClient.EXPECT().SSHUserCertificateAuthority().Return(caSigner.PublicKey(), nil).AnyTimes()
+	s.facadeClient.EXPECT().SSHPoliciesForModel("8419cd78-4993-4c3a-928e-c646226beeee").Return(
+		[]sshpolicy.Policy{{
+			Name:         "dbas",
+			Users:        []string{"alice"},
+			Applications: []string{"postgresql"},
+			Commands:     []string{"psql *"},
+		}}, nil,
+	).AnyTimes()
+
+	listener := bufconn.Listen(1024)
+	server, err := NewServerWorker(ServerWorkerConfig{
+		Logger:                   loggo.GetLogger("test"),
+		Listener:                 listener,
+		MaxConcurrentConnections: maxConcurrentConnections,
+		JumpHostKey:              jujutesting.SSHServerHostKey,
+		FacadeClient:             s.facadeClient,
+		SessionHandler:           s.sessionHandler,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, server)
+
+	dialTarget := func(signer gossh.Signer) (*gossh.Client, error) {
+		client := inMemoryDial(c, listener, &gossh.ClientConfig{
+			HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+			Auth:            []gossh.AuthMethod{gossh.PublicKeys(signer)},
+		})
+		tunnel, err := client.Dial("tcp", fmt.Sprintf("%s:0", testVirtualHostname))
+		c.Assert(err, jc.ErrorIsNil)
+		conn, chans, reqs, err := gossh.NewClientConn(tunnel, "", &gossh.ClientConfig{
+			User:            "ubuntu",
+			HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+			Auth:            []gossh.AuthMethod{gossh.PublicKeys(signer)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return gossh.NewClient(conn, chans, reqs), nil
+	}
+
+	// An authorized key isn't authenticated as any user,
+	// so the policy doesn't apply to it.
+	_, err = dialTarget(s.userSigner)
+	c.Assert(err, gc.ErrorMatches, `.*ssh: handshake failed.*`)
+
+	// Nor does it apply to other users.
+	_, err = dialTarget(newCertSigner("bob"))
+	c.Assert(err, gc.ErrorMatches, `.*ssh: handshake failed.*`)
+
+	client, err := dialTarget(newCertSigner("alice"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Commands matching the policy are proxied.
+	s.sessionHandler.EXPECT().Handle(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(session ssh.Session, destination virtualhostname.Info) {
+			_, _ = session.Write([]byte("ok\n"))
+		},
+	)
+	session, err := client.NewSession()
+	c.Assert(err, jc.ErrorIsNil)
+	output, err := session.CombinedOutput("psql -l")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, "ok\n")
+
+	// Including when they're run with a terminal, when they're still
+	// proxied as commands rather than shells.
+	s.sessionHandler.EXPECT().Handle(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(session ssh.Session, destination virtualhostname.Info) {
+			_, _, isPty := session.Pty()
+			_, _ = fmt.Fprintf(session, "%s pty=%v\n", session.RawCommand(), isPty)
+		},
+	)
+	session, err = client.NewSession()
+	c.Assert(err, jc.ErrorIsNil)
+	err = session.RequestPty("xterm", 40, 80, gossh.TerminalModes{})
+	c.Assert(err, jc.ErrorIsNil)
+	output, err = session.CombinedOutput("psql -l")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Matches, "psql -l pty=true\r?\n")
+
+	// Other commands and shells are denied, with or without a terminal.
+	for _, withPty := range []bool{false, true} {
+		for _, command := range []string{"rm -rf /", ""} {
+			session, err = client.NewSession()
+			c.Assert(err, jc.ErrorIsNil)
+			if withPty {
+				err = session.RequestPty("xterm", 40, 80, gossh.TerminalModes{})
+				c.Assert(err, jc.ErrorIsNil)
+			}
+			output, err = session.CombinedOutput(command)
+			c.Assert(err, gc.FitsTypeOf, &gossh.ExitError{})
+			c.Assert(err.(*gossh.ExitError).ExitStatus(), gc.Equals, 1)
+			c.Assert(string(output), gc.Matches, "permission denied by ssh policy: (exec|shell) not allowed\r?\n")
+		}
+	}
+
+	// As are sftp and port forwarding.
+	_, err = sftp.NewClient(client)
+	c.Assert(err, gc.NotNil)
+
+	_, err = client.Dial("tcp", "localhost:5432")
+	c.Assert(err, gc.ErrorMatches, ".*administratively prohibited.*")
+
+	workertest.CleanKill(c, server)
+}
+
+func (s *sshServerSuite) TestSSHServerAcceptsUserCertificates(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	newSigner := func() gossh.Signer {
+		privateKey, err := jujussh.ED25519()
+		c.Assert(err, jc.ErrorIsNil)
+		signer, err := gossh.NewSignerFromKey(privateKey)
+		c.Assert(err, jc.ErrorIsNil)
+		return signer
+	}
+	caSigner := newSigner()
+	keySigner := newSigner()
+	newCertSigner := func(ca gossh.Signer, principal string) gossh.Signer {
+		cert, err := jujussh.NewUserCertificate(
+			ca, keySigner.PublicKey(), "bob", []string{principal}, time.Minute, time.Now())
+		c.Assert(err, jc.ErrorIsNil)
+		signer, err := gossh.NewCertSigner(cert, keySigner)
+		c.Assert(err, jc.ErrorIsNil)
+		return signer
+	}
+
+	s.expectNoPolicies()
+	s.facadeClient.EXPECT().VirtualHostKey(gomock.Any()).Return(s.hostKey, nil).AnyTimes()
+	// The certified key isn't one of the model's authorized keys.
+	s.facadeClient.EXPECT().ListPublicKeysForModel(gomock.Any()).Return(
+		[]gossh.PublicKey{s.userSigner.PublicKey()}, nil,
+	).AnyTimes()
+	s.facadeClient.EXPECT().SSHUserCertificateAuthority().Return(caSigner.PublicKey(), nil).AnyTimes()
+
+	listener := bufconn.Listen(1024)
+	server, err := NewServerWorker(ServerWorkerConfig{
+		Logger:                   loggo.GetLogger("test"),
+		Listener:                 listener,
+		MaxConcurrentConnections: maxConcurrentConnections,
+		JumpHostKey:              jujutesting.SSHServerHostKey,
+		FacadeClient:             s.facadeClient,
+		SessionHandler:           s.sessionHandler,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, server)
+
+	dialTarget := func(signer gossh.Signer) (*gossh.Client, error) {
+		client := inMemoryDial(c, listener, &gossh.ClientConfig{
+			HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+			Auth:            []gossh.AuthMethod{gossh.PublicKeys(signer)},
+		})
+		tunnel, err := client.Dial("tcp", fmt.Sprintf("%s:0", testVirtualHostname))
+		c.Assert(err, jc.ErrorIsNil)
+		conn, chans, reqs, err := gossh.NewClientConn(tunnel, "", &gossh.ClientConfig{
+			User:            "ubuntu",
+			HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+			Auth:            []gossh.AuthMethod{gossh.PublicKeys(signer)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return gossh.NewClient(conn, chans, reqs), nil
+	}
+
+	// The plain key isn't accepted.
+	_, err = dialTarget(keySigner)
+	c.Assert(err, gc.ErrorMatches, `.*ssh: handshake failed.*`)
+
+	// Nor are certificates for another target, or from another CA.
+	_, err = dialTarget(newCertSigner(caSigner, "0.8419cd78-4993-4c3a-928e-c646226beeee.juju.local"))
+	c.Assert(err, gc.ErrorMatches, `.*ssh: handshake failed.*`)
+	_, err = dialTarget(newCertSigner(newSigner(), testVirtualHostname))
+	c.Assert(err, gc.ErrorMatches, `.*ssh: handshake failed.*`)
+
+	client, err := dialTarget(newCertSigner(caSigner, testVirtualHostname))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.sessionHandler.EXPECT().Handle(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(session ssh.Session, destination virtualhostname.Info) {
+			_, _ = session.Write([]byte("ok\n"))
+		},
+	)
+	session, err := client.NewSession()
+	c.Assert(err, jc.ErrorIsNil)
+	output, err := session.CombinedOutput("hostname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, "ok\n")
+
+	workertest.CleanKill(c, server)
+}
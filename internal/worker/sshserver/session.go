@@ -4,6 +4,9 @@
 package sshserver
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/gliderlabs/ssh"
 	"github.com/juju/errors"
 	gossh "golang.org/x/crypto/ssh"
@@ -49,7 +52,14 @@ func (s *sessionHandler) Handle(session ssh.Session, destination virtualhostname
 			handleError(err)
 		}
 	case state.ModelTypeIAAS:
-		if err := s.machineSessionProxy(session, destination); err != nil {
+		err := s.machineSessionProxy(session, destination)
+		if status, ok := exitStatus(err); ok {
+			// The remote command or subsystem ran but failed, pass its
+			// exit status on to the user, as scp and sftp rely on it.
+			_ = session.Exit(status)
+			return
+		}
+		if err != nil {
 			err = errors.Annotate(err, "failed to proxy machine session")
 			handleError(err)
 		}
@@ -58,7 +68,13 @@ func (s *sessionHandler) Handle(session ssh.Session, destination virtualhostname
 	}
 }
 
-func (s *sessionHandler) k8sSessionProxy(_ ssh.Session) error {
+func (s *sessionHandler) k8sSessionProxy(session ssh.Session) error {
+	if subsystem := session.Subsystem(); subsystem != "" {
+		// Subsystems, such as sftp, are only proxied to machines.
+		// Proxying them to k8s units needs the k8s exec path, which
+		// the k8s session proxy doesn't have yet.
+		return errors.NotSupportedf("%s subsystem for k8s units", subsystem)
+	}
 	return errors.New("k8s session proxy not implemented")
 }
 
@@ -69,6 +85,10 @@ func (s *sessionHandler) machineSessionProxy(userSession ssh.Session, destinatio
 	}
 	defer client.Close()
 
+	if subsystem := userSession.Subsystem(); subsystem != "" {
+		return s.subsystemProxy(userSession, client, subsystem)
+	}
+
 	machineSSHSession, err := client.NewSession()
 	if err != nil {
 		return err
@@ -100,7 +120,13 @@ func (*sessionHandler) setupShellOrCommand(userSession ssh.Session, machineSSHSe
 		}); err != nil {
 			return err
 		}
-		if err := machineSSHSession.Shell(); err != nil {
+		// A command run with a terminal must still be run as a command,
+		// as SSH policies may only allow certain commands.
+		if command := userSession.RawCommand(); command != "" {
+			if err := machineSSHSession.Start(command); err != nil {
+				return err
+			}
+		} else if err := machineSSHSession.Shell(); err != nil {
 			return err
 		}
 
@@ -115,3 +141,91 @@ func (*sessionHandler) setupShellOrCommand(userSession ssh.Session, machineSSHSe
 	}
 	return nil
 }
+
+// subsystemProxy starts a subsystem, such as sftp, on the machine
+// and proxies the user's session to it. The gossh.Session type can't
+// wait for a subsystem to exit so the session channel is used directly.
+func (s *sessionHandler) subsystemProxy(userSession ssh.Session, client *gossh.Client, subsystem string) error {
+	ch, reqs, err := client.OpenChannel("session", nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer ch.Close()
+
+	exited := make(chan int, 1)
+	requestsDone := make(chan struct{})
+	go func() {
+		defer close(requestsDone)
+		for req := range reqs {
+			if req.Type == "exit-status" {
+				var msg struct{ Status uint32 }
+				if err := gossh.Unmarshal(req.Payload, &msg); err == nil {
+					select {
+					case exited <- int(msg.Status):
+					default:
+					}
+				}
+			}
+			if req.WantReply {
+				_ = req.Reply(false, nil)
+			}
+		}
+	}()
+
+	ok, err := ch.SendRequest("subsystem", true, gossh.Marshal(struct{ Name string }{subsystem}))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return errors.Errorf("subsystem %q request failed", subsystem)
+	}
+
+	go func() {
+		_, _ = io.Copy(ch, userSession)
+		_ = ch.CloseWrite()
+	}()
+	stderrDone := make(chan struct{})
+	go func() {
+		defer close(stderrDone)
+		_, _ = io.Copy(userSession.Stderr(), ch.Stderr())
+	}()
+	if _, err := io.Copy(userSession, ch); err != nil {
+		return errors.Trace(err)
+	}
+	<-stderrDone
+
+	// The machine closes the channel after sending the exit status,
+	// which ends the requests.
+	<-requestsDone
+	select {
+	case status := <-exited:
+		if status != 0 {
+			return exitStatusError(status)
+		}
+	default:
+	}
+	return nil
+}
+
+// exitStatusError is returned when a subsystem exits
+// with a non-zero status.
+type exitStatusError int
+
+// Error implements error.
+func (e exitStatusError) Error() string {
+	return fmt.Sprintf("exited with status %d", int(e))
+}
+
+// exitStatus returns the exit status of a command or subsystem
+// on the machine, if err records one.
+func exitStatus(err error) (int, bool) {
+	var exitErr *gossh.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus(), true
+	}
+	var statusErr exitStatusError
+	if errors.As(err, &statusErr) {
+		return int(statusErr), true
+	}
+	return 0, false
+}
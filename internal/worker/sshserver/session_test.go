@@ -30,9 +30,10 @@ type machineSessionSuite struct {
 var _ = gc.Suite(&machineSessionSuite{})
 
 type testServer struct {
-	server   *ssh.Server
-	serverRx []byte
-	listener *bufconn.Listener
+	server        *ssh.Server
+	serverRx      []byte
+	serverCommand string
+	listener      *bufconn.Listener
 }
 
 // startTestServer creates a test server that emulates the
@@ -44,6 +45,7 @@ func startTestServer(_ *gc.C) *testServer {
 		Handler: func(session ssh.Session) {
 			_, _, isPty := session.Pty()
 			if isPty {
+				ts.serverCommand = session.RawCommand()
 				ts.serverRx, _ = io.ReadAll(session)
 				_, _ = io.WriteString(session, "Hello from the server!\n")
 				_, _ = io.WriteString(session.Stderr(), "An error from the server!\n")
@@ -54,6 +56,15 @@ func startTestServer(_ *gc.C) *testServer {
 			}
 		},
 	}
+	ts.server.SubsystemHandlers = map[string]ssh.SubsystemHandler{
+		"sftp": func(session ssh.Session) {
+			ts.serverRx, _ = io.ReadAll(session)
+			_, _ = io.WriteString(session, "sftp from the server\n")
+		},
+		"failing": func(session ssh.Session) {
+			_ = session.Exit(3)
+		},
+	}
 	ts.listener = bufconn.Listen(1024)
 	go func() {
 		_ = ts.server.Serve(ts.listener)
@@ -68,6 +79,7 @@ type userSession struct {
 	stderr        bytes.Buffer
 	isPty         bool
 	clientCommand string
+	subsystem     string
 	exitCode      int
 }
 
@@ -91,6 +103,10 @@ func (u *userSession) RawCommand() string {
 	return u.clientCommand
 }
 
+func (u *userSession) Subsystem() string {
+	return u.subsystem
+}
+
 func (u *userSession) Exit(code int) error {
 	u.exitCode = code
 	return nil
@@ -202,6 +218,43 @@ func (s *machineSessionSuite) TestMachineCommandProxy(c *gc.C) {
 	c.Check(string(testServer.serverRx), gc.Equals, "neovim")
 }
 
+func (s *machineSessionSuite) TestMachineCommandProxyWithPty(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	isPty := true
+	s.setupUserSession(c, isPty, "select 1;\n")
+	s.userSession.clientCommand = "psql -l"
+
+	testServer := startTestServer(c)
+	defer testServer.listener.Close()
+
+	conn, err := testServer.listener.Dial()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.mockConnector.EXPECT().Connect(gomock.Any()).DoAndReturn(
+		func(destination virtualhostname.Info) (*gossh.Client, error) {
+			sshConn, newChan, reqs, err := gossh.NewClientConn(conn, "", &gossh.ClientConfig{
+				HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return gossh.NewClient(sshConn, newChan, reqs), nil
+		},
+	)
+
+	sessionHandler := sessionHandler{
+		connector: s.mockConnector,
+		modelType: state.ModelTypeIAAS,
+	}
+
+	// The command is run with the terminal, rather than a shell.
+	err = sessionHandler.machineSessionProxy(s.userSession, virtualhostname.Info{})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(testServer.serverCommand, gc.Equals, "psql -l")
+	c.Check(string(testServer.serverRx), gc.Equals, "select 1;\n")
+}
+
 func (s *machineSessionSuite) TestConnectToMachineError(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -225,3 +278,95 @@ func (s *machineSessionSuite) TestConnectToMachineError(c *gc.C) {
 	c.Check(s.userSession.stdout.String(), gc.Equals, "")
 	c.Check(s.userSession.stderr.String(), gc.Equals, "failed to proxy machine session: fake-connection-error\n")
 }
+
+func (s *machineSessionSuite) dialTestServer(c *gc.C, testServer *testServer) {
+	conn, err := testServer.listener.Dial()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.mockConnector.EXPECT().Connect(gomock.Any()).DoAndReturn(
+		func(destination virtualhostname.Info) (*gossh.Client, error) {
+			sshConn, newChan, reqs, err := gossh.NewClientConn(conn, "", &gossh.ClientConfig{
+				HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return gossh.NewClient(sshConn, newChan, reqs), nil
+		},
+	)
+}
+
+func (s *machineSessionSuite) TestMachineSubsystemProxy(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.userSession = &userSession{subsystem: "sftp"}
+	s.userSession.stdin.WriteString("sftp from the client\n")
+
+	testServer := startTestServer(c)
+	defer testServer.listener.Close()
+	s.dialTestServer(c, testServer)
+
+	sessionHandler := sessionHandler{
+		connector: s.mockConnector,
+		modelType: state.ModelTypeIAAS,
+	}
+
+	err := sessionHandler.machineSessionProxy(s.userSession, virtualhostname.Info{})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(s.userSession.stdout.String(), gc.Equals, "sftp from the server\n")
+	c.Check(string(testServer.serverRx), gc.Equals, "sftp from the client\n")
+}
+
+func (s *machineSessionSuite) TestMachineSubsystemExitStatus(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.userSession = &userSession{subsystem: "failing"}
+
+	testServer := startTestServer(c)
+	defer testServer.listener.Close()
+	s.dialTestServer(c, testServer)
+
+	sessionHandler := sessionHandler{
+		connector: s.mockConnector,
+		modelType: state.ModelTypeIAAS,
+		logger:    loggo.GetLogger("test"),
+	}
+
+	sessionHandler.Handle(s.userSession, virtualhostname.Info{})
+	c.Check(s.userSession.exitCode, gc.Equals, 3)
+	c.Check(s.userSession.stderr.String(), gc.Equals, "")
+}
+
+func (s *machineSessionSuite) TestMachineSubsystemNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.userSession = &userSession{subsystem: "unknown"}
+
+	testServer := startTestServer(c)
+	defer testServer.listener.Close()
+	s.dialTestServer(c, testServer)
+
+	sessionHandler := sessionHandler{
+		connector: s.mockConnector,
+		modelType: state.ModelTypeIAAS,
+		logger:    loggo.GetLogger("test"),
+	}
+
+	sessionHandler.Handle(s.userSession, virtualhostname.Info{})
+	c.Check(s.userSession.exitCode, gc.Equals, 1)
+	c.Check(s.userSession.stderr.String(), gc.Matches, "failed to proxy machine session: .*\n")
+}
+
+func (s *machineSessionSuite) TestK8sSubsystemNotSupported(c *gc.C) {
+	s.userSession = &userSession{subsystem: "sftp"}
+
+	sessionHandler := sessionHandler{
+		modelType: state.ModelTypeCAAS,
+		logger:    loggo.GetLogger("test"),
+	}
+
+	sessionHandler.Handle(s.userSession, virtualhostname.Info{})
+	c.Check(s.userSession.exitCode, gc.Equals, 1)
+	c.Check(s.userSession.stderr.String(), gc.Equals,
+		"failed to proxy k8s session: sftp subsystem for k8s units not supported\n")
+}
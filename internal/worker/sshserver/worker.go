@@ -54,7 +54,7 @@ type serverWrapperWorker struct {
 
 // NewServerWrapperWorker returns a new worker that runs an ssh server worker internally.
 // This worker will listen for changes in the controller configuration and restart the
-// server worker when the port or max concurrent connections changes.
+// server worker when the max concurrent connections or session recording changes.
 func NewServerWrapperWorker(config ServerWrapperWorkerConfig) (worker.Worker, error) {
 	if err := config.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -99,13 +99,13 @@ func (ssw *serverWrapperWorker) Report() map[string]any {
 	}
 }
 
-func (ssw *serverWrapperWorker) getLatestControllerConfig() (port, maxConns int, err error) {
+func (ssw *serverWrapperWorker) getLatestControllerConfig() (port, maxConns int, recordSessions bool, err error) {
 	ctrlCfg, err := ssw.config.FacadeClient.ControllerConfig()
 	if err != nil {
-		return port, maxConns, errors.Trace(err)
+		return port, maxConns, recordSessions, errors.Trace(err)
 	}
 
-	return ctrlCfg.SSHServerPort(), ctrlCfg.SSHMaxConcurrentConnections(), nil
+	return ctrlCfg.SSHServerPort(), ctrlCfg.SSHMaxConcurrentConnections(), ctrlCfg.SSHSessionRecording(), nil
 }
 
 // loop is the main loop of the server wrapper worker. It starts the server worker
@@ -128,7 +128,7 @@ func (ssw *serverWrapperWorker) loop() error {
 		return errors.Trace(err)
 	}
 
-	port, maxConns, err := ssw.getLatestControllerConfig()
+	port, maxConns, recordSessions, err := ssw.getLatestControllerConfig()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -140,6 +140,7 @@ func (ssw *serverWrapperWorker) loop() error {
 		MaxConcurrentConnections: maxConns,
 		FacadeClient:             ssw.config.FacadeClient,
 		SessionHandler:           ssw.config.SessionHandler,
+		RecordSessions:           recordSessions,
 	})
 	ssw.addWorkerReporter("ssh-server", srv)
 	if err != nil {
@@ -155,11 +156,11 @@ func (ssw *serverWrapperWorker) loop() error {
 			return ssw.catacomb.ErrDying()
 		case <-controllerConfigWatcher.Changes():
 			// The ssh server port can't change after bootstrap so we ignore it.
-			_, newMaxConnections, err := ssw.getLatestControllerConfig()
+			_, newMaxConnections, newRecordSessions, err := ssw.getLatestControllerConfig()
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if newMaxConnections == maxConns {
+			if newMaxConnections == maxConns && newRecordSessions == recordSessions {
 				ssw.config.Logger.Debugf("controller configuration changed, but nothing changed for the ssh server.")
 				continue
 			}
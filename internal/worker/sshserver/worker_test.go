@@ -236,6 +236,66 @@ func (s *workerSuite) TestSSHServerWrapperWorkerRestartsServerWorker(c *gc.C) {
 	c.Check(workertest.CheckKilled(c, controllerConfigWatcher), jc.ErrorIsNil)
 }
 
+func (s *workerSuite) TestSSHServerWrapperWorkerRestartsOnSessionRecordingChange(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeClient := NewMockFacadeClient(ctrl)
+
+	serverWorker := workertest.NewErrorWorker(nil)
+	defer workertest.DirtyKill(c, serverWorker)
+
+	watcherChan := make(chan struct{})
+	controllerConfigWatcher := watchertest.NewMockNotifyWatcher(watcherChan)
+	defer workertest.DirtyKill(c, controllerConfigWatcher)
+
+	mockFacadeClient.EXPECT().SSHServerHostKey().Return("key", nil).Times(1)
+	mockFacadeClient.EXPECT().WatchControllerConfig().Return(controllerConfigWatcher, nil)
+	mockFacadeClient.EXPECT().
+		ControllerConfig().
+		Return(
+			controller.Config{
+				controller.SSHServerPort:               22,
+				controller.SSHMaxConcurrentConnections: 10,
+			},
+			nil,
+		).
+		Times(1)
+	// Enabling session recording should restart the worker.
+	mockFacadeClient.EXPECT().
+		ControllerConfig().
+		Return(
+			controller.Config{
+				controller.SSHServerPort:               22,
+				controller.SSHMaxConcurrentConnections: 10,
+				controller.SSHSessionRecording:         true,
+			},
+			nil,
+		).
+		Times(1)
+
+	cfg := ServerWrapperWorkerConfig{
+		FacadeClient: mockFacadeClient,
+		Logger:       loggo.GetLogger("test"),
+		NewServerWorker: func(swc ServerWorkerConfig) (worker.Worker, error) {
+			c.Check(swc.RecordSessions, jc.IsFalse)
+			return serverWorker, nil
+		},
+		SessionHandler: &stubSessionHandler{},
+	}
+	w, err := NewServerWrapperWorker(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	workertest.CheckAlive(c, w)
+
+	watcherChan <- struct{}{}
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "changes detected, stopping SSH server worker")
+	c.Check(workertest.CheckKilled(c, serverWorker), jc.ErrorIsNil)
+}
+
 func (s *workerSuite) TestSSHServerWrapperWorkerErrorsOnMissingHostKey(c *gc.C) {
 	l := loggo.GetLogger("test")
 
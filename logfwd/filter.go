@@ -0,0 +1,117 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+)
+
+// Filter selects the log records that are forwarded to a log sink.
+// The zero value selects every record.
+type Filter struct {
+	// Level is the minimum level of the selected records.
+	Level loggo.Level
+
+	// Modules holds the modules of the selected records. A module also
+	// selects the records of its submodules, so "juju.worker" selects
+	// records of "juju.worker.uniter". If it is empty, records of any
+	// module are selected.
+	Modules []string
+
+	// Entities holds patterns matching the tags of the entities that
+	// logged the selected records, for example "machine-0" or
+	// "unit-mysql-*". The patterns use the syntax of path.Match. If it
+	// is empty, records of any entity are selected.
+	Entities []string
+}
+
+// Validate ensures that the filter is correct.
+func (f Filter) Validate() error {
+	if f.Level > loggo.CRITICAL {
+		return errors.NotValidf("level %d", f.Level)
+	}
+	for _, module := range f.Modules {
+		if module == "" || strings.HasPrefix(module, ".") || strings.HasSuffix(module, ".") {
+			return errors.NotValidf("module %q", module)
+		}
+	}
+	for _, pattern := range f.Entities {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return errors.NotValidf("entity pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// Match returns whether the record is selected by the filter.
+func (f Filter) Match(rec Record) bool {
+	if rec.Level < f.Level {
+		return false
+	}
+	if len(f.Modules) > 0 && !matchModule(f.Modules, rec.Location.Module) {
+		return false
+	}
+	if len(f.Entities) > 0 && !matchEntity(f.Entities, rec.Origin) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the records selected by the filter, in order.
+func (f Filter) Apply(records []Record) []Record {
+	var selected []Record
+	for _, rec := range records {
+		if f.Match(rec) {
+			selected = append(selected, rec)
+		}
+	}
+	return selected
+}
+
+func matchModule(modules []string, module string) bool {
+	for _, m := range modules {
+		if module == m || strings.HasPrefix(module, m+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func matchEntity(patterns []string, origin Origin) bool {
+	tag := originTag(origin)
+	if tag == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// originTag returns the tag of the entity that created the record, or
+// an empty string if it isn't known.
+func originTag(origin Origin) string {
+	switch origin.Type {
+	case OriginTypeMachine:
+		if names.IsValidMachine(origin.Name) {
+			return names.NewMachineTag(origin.Name).String()
+		}
+	case OriginTypeUnit:
+		if names.IsValidUnit(origin.Name) {
+			return names.NewUnitTag(origin.Name).String()
+		}
+	case OriginTypeUser:
+		if names.IsValidUser(origin.Name) {
+			return names.NewUserTag(origin.Name).String()
+		}
+	}
+	return ""
+}
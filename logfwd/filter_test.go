@@ -0,0 +1,93 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type FilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FilterSuite{})
+
+func (s *FilterSuite) record(originType logfwd.OriginType, name, module string, level loggo.Level) logfwd.Record {
+	rec := validRecord
+	rec.Origin.Type = originType
+	rec.Origin.Name = name
+	rec.Location.Module = module
+	rec.Level = level
+	return rec
+}
+
+func (s *FilterSuite) TestZeroValueMatchesAll(c *gc.C) {
+	var filter logfwd.Filter
+
+	c.Check(filter.Validate(), jc.ErrorIsNil)
+	c.Check(filter.Match(validRecord), jc.IsTrue)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeUnknown, "", "", loggo.TRACE)), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestLevel(c *gc.C) {
+	filter := logfwd.Filter{Level: loggo.WARNING}
+
+	c.Check(filter.Match(s.record(logfwd.OriginTypeMachine, "0", "juju", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeMachine, "0", "juju", loggo.WARNING)), jc.IsTrue)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeMachine, "0", "juju", loggo.ERROR)), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestModules(c *gc.C) {
+	filter := logfwd.Filter{Modules: []string{"juju.worker", "juju.apiserver"}}
+
+	c.Check(filter.Match(s.record(logfwd.OriginTypeMachine, "0", "juju.worker", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeMachine, "0", "juju.worker.uniter", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeMachine, "0", "juju.apiserver.logsink", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeMachine, "0", "juju.workers", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeMachine, "0", "juju", loggo.INFO)), jc.IsFalse)
+}
+
+func (s *FilterSuite) TestEntities(c *gc.C) {
+	filter := logfwd.Filter{Entities: []string{"machine-0", "unit-mysql-*"}}
+
+	c.Check(filter.Match(s.record(logfwd.OriginTypeMachine, "0", "juju", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeMachine, "1", "juju", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeUnit, "mysql/1", "juju", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeUnit, "wordpress/0", "juju", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(s.record(logfwd.OriginTypeUnknown, "", "juju", loggo.INFO)), jc.IsFalse)
+}
+
+func (s *FilterSuite) TestApply(c *gc.C) {
+	filter := logfwd.Filter{
+		Level:    loggo.WARNING,
+		Modules:  []string{"juju.worker"},
+		Entities: []string{"unit-*"},
+	}
+	records := []logfwd.Record{
+		s.record(logfwd.OriginTypeUnit, "mysql/0", "juju.worker.uniter", loggo.ERROR),
+		s.record(logfwd.OriginTypeUnit, "mysql/0", "juju.worker.uniter", loggo.DEBUG),
+		s.record(logfwd.OriginTypeMachine, "0", "juju.worker.uniter", loggo.ERROR),
+		s.record(logfwd.OriginTypeUnit, "mysql/0", "juju.apiserver", loggo.ERROR),
+		s.record(logfwd.OriginTypeUnit, "mysql/1", "juju.worker.uniter", loggo.WARNING),
+	}
+
+	c.Check(filter.Apply(records), jc.DeepEquals, []logfwd.Record{records[0], records[4]})
+}
+
+func (s *FilterSuite) TestValidateBadModule(c *gc.C) {
+	filter := logfwd.Filter{Modules: []string{"juju."}}
+
+	c.Check(filter.Validate(), gc.ErrorMatches, `module "juju." not valid`)
+}
+
+func (s *FilterSuite) TestValidateBadEntityPattern(c *gc.C) {
+	filter := logfwd.Filter{Entities: []string{"unit-[mysql"}}
+
+	c.Check(filter.Validate(), gc.ErrorMatches, `entity pattern "unit-\[mysql" not valid`)
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httppush
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v3/cert"
+)
+
+// ParseEndpoint parses the URL of an HTTP log forwarding target. If the
+// URL has no path then the default path is used.
+func ParseEndpoint(endpoint, defaultPath string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.NotValidf("Endpoint %q", endpoint)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.NotValidf("Endpoint %q, expected an http or https URL", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = defaultPath
+	}
+	return u, nil
+}
+
+// ParseHeaders parses a comma separated list of key=value pairs into
+// HTTP headers.
+func ParseHeaders(headers string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, header := range strings.Split(headers, ",") {
+		if strings.TrimSpace(header) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(header, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, errors.NotValidf("header %q, expected key=value", header)
+		}
+		result[key] = strings.TrimSpace(value)
+	}
+	return result, nil
+}
+
+// TLSConfig returns the TLS config for the given CA certificate and
+// client key pair (x.509, PEM-encoded), all of which are optional. It
+// returns nil if none are set, meaning the default config should be used.
+func TLSConfig(caCert, clientCert, clientKey string) (*tls.Config, error) {
+	if caCert == "" && clientCert == "" && clientKey == "" {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{}
+	if clientCert != "" || clientKey != "" {
+		keyPair, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		tlsCfg.Certificates = []tls.Certificate{keyPair}
+	}
+	if caCert != "" {
+		parsed, err := cert.ParseCert(caCert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(parsed)
+		tlsCfg.RootCAs = rootCAs
+	}
+	return tlsCfg, nil
+}
@@ -0,0 +1,7 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package httppush holds the tools shared by the log forwarding targets
+// that push batches of log records over HTTP, such as OTLP receivers and
+// Loki.
+package httppush
@@ -0,0 +1,242 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httppush
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+)
+
+const (
+	// DefaultRetryAttempts is the number of times a push is attempted
+	// before giving up.
+	DefaultRetryAttempts = 5
+
+	// DefaultRetryDelay is the delay before the first retry of a failed
+	// push. The delay doubles for every following attempt.
+	DefaultRetryDelay = time.Second
+
+	// maxRetryDelay is the longest delay between attempts.
+	maxRetryDelay = time.Minute
+
+	// requestTimeout is the timeout of every push request.
+	requestTimeout = 30 * time.Second
+)
+
+// HTTPClient exposes the underlying functionality needed by Pusher.
+type HTTPClient interface {
+	// Do sends the HTTP request and returns the response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// NewHTTPClient returns an HTTP client which uses the TLS config, if it
+// isn't nil.
+func NewHTTPClient(tlsCfg *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	}
+}
+
+// Request is a single push to the target.
+type Request struct {
+	// Body is the encoded batch of log records.
+	Body []byte
+
+	// ContentType is the media type of the body.
+	ContentType string
+
+	// ContentEncoding is the compression of the body, if any.
+	ContentEncoding string
+}
+
+// PusherConfig holds the settings of a Pusher.
+type PusherConfig struct {
+	// Name describes the target in errors, for example "Loki".
+	Name string
+
+	// URL is where the requests are sent.
+	URL string
+
+	// Headers are extra HTTP headers to send with every request.
+	Headers map[string]string
+
+	// HTTPClient is used to send the requests.
+	HTTPClient HTTPClient
+
+	// Clock is used to wait between retries.
+	Clock clock.Clock
+
+	// RetryAttempts is the number of times a push is attempted before
+	// giving up.
+	RetryAttempts int
+
+	// RetryDelay is the delay before the first retry of a failed push.
+	RetryDelay time.Duration
+}
+
+// Validate ensures that the config is valid.
+func (cfg PusherConfig) Validate() error {
+	if cfg.URL == "" {
+		return errors.NotValidf("empty URL")
+	}
+	if cfg.HTTPClient == nil {
+		return errors.NotValidf("nil HTTPClient")
+	}
+	if cfg.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if cfg.RetryAttempts <= 0 {
+		return errors.NotValidf("non-positive RetryAttempts")
+	}
+	if cfg.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// Pusher sends requests to an HTTP log forwarding target, retrying them
+// with an increasing delay while the target is unavailable or asks for
+// requests to be slowed down.
+type Pusher struct {
+	config PusherConfig
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewPusher returns a new Pusher.
+func NewPusher(config PusherConfig) (*Pusher, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Pusher{
+		config: config,
+		stop:   make(chan struct{}),
+	}, nil
+}
+
+// Close stops any retries in progress.
+func (p *Pusher) Close() error {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+	return nil
+}
+
+// Push sends the request, retrying it while the target is unavailable.
+func (p *Pusher) Push(req Request) error {
+	var retryAfter time.Duration
+	err := retry.Call(retry.CallArgs{
+		Func: func() error {
+			err := p.send(req)
+			retryAfter = 0
+			if unavailable, ok := errors.Cause(err).(*unavailableError); ok {
+				retryAfter = unavailable.retryAfter
+			}
+			return err
+		},
+		IsFatalError: func(err error) bool {
+			_, ok := errors.Cause(err).(*unavailableError)
+			return !ok
+		},
+		BackoffFunc: func(delay time.Duration, attempt int) time.Duration {
+			delay = retry.DoubleDelay(delay, attempt)
+			if retryAfter > delay {
+				delay = retryAfter
+			}
+			return delay
+		},
+		Attempts: p.config.RetryAttempts,
+		Delay:    p.config.RetryDelay,
+		MaxDelay: maxRetryDelay,
+		Clock:    p.config.Clock,
+		Stop:     p.stop,
+	})
+	if retry.IsAttemptsExceeded(err) || retry.IsRetryStopped(err) {
+		err = retry.LastError(err)
+	}
+	return errors.Trace(err)
+}
+
+// send sends a single request.
+func (p *Pusher) send(req Request) error {
+	httpReq, err := http.NewRequest(http.MethodPost, p.config.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	httpReq.Header.Set("Content-Type", req.ContentType)
+	if req.ContentEncoding != "" {
+		httpReq.Header.Set("Content-Encoding", req.ContentEncoding)
+	}
+	for key, value := range p.config.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		// The target can't be reached, so try again later.
+		return &unavailableError{err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = errors.Errorf("%s returned %s: %s", p.config.Name, resp.Status, bytes.TrimSpace(message))
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return &unavailableError{
+			err:        err,
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+	return err
+}
+
+// unavailableError is returned when a push may succeed if it is retried
+// later.
+type unavailableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *unavailableError) Error() string {
+	if e.retryAfter > 0 {
+		return fmt.Sprintf("%v (retry after %v)", e.err, e.retryAfter)
+	}
+	return e.err.Error()
+}
+
+// parseRetryAfter returns the delay of a Retry-After header given in
+// seconds. Dates aren't supported, and result in no delay.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	delay := time.Duration(seconds) * time.Second
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loki_test
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/loki"
+)
+
+// stream is a decoded stream of a push request.
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// receiver is an in-process Loki push API.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	pushes   [][]stream
+	headers  []http.Header
+	statuses []int
+}
+
+func newReceiver(statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	return r
+}
+
+func (r *receiver) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if req.URL.Path != "/loki/api/v1/push" ||
+		req.Header.Get("Content-Type") != "application/json" ||
+		req.Header.Get("Content-Encoding") != "gzip" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.headers = append(r.headers, req.Header)
+	if len(r.statuses) > 0 {
+		status := r.statuses[0]
+		r.statuses = r.statuses[1:]
+		if status != http.StatusNoContent {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte("slow down"))
+			return
+		}
+	}
+	body, err := gzip.NewReader(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var request struct {
+		Streams []stream `json:"streams"`
+	}
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.pushes = append(r.pushes, request.Streams)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+const modelUUID = "deadbeef-2f18-4fd2-967d-db9663db7bea"
+
+func (s *ClientSuite) open(c *gc.C, r *receiver, batchSize int) *loki.Client {
+	client, err := loki.OpenForClient(loki.RawConfig{
+		Enabled:  true,
+		Endpoint: r.URL,
+		Headers:  "x-scope-orgid=juju",
+	}, loki.ClientConfig{
+		HTTPClient:    r.Client(),
+		Clock:         testclock.NewDilatedWallClock(time.Millisecond),
+		BatchSize:     batchSize,
+		RetryAttempts: 3,
+		RetryDelay:    time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) record(id int64, tag names.Tag, module string) logfwd.Record {
+	var origin logfwd.Origin
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		origin = logfwd.OriginForUnitAgent(tag, "feebdaed-2f18-4fd2-967d-db9663db7bea", modelUUID, version.MustParse("3.6.0"))
+	case names.MachineTag:
+		origin = logfwd.OriginForMachineAgent(tag, "feebdaed-2f18-4fd2-967d-db9663db7bea", modelUUID, version.MustParse("3.6.0"))
+	}
+	return logfwd.Record{
+		ID:        id,
+		Origin:    origin,
+		Timestamp: time.Date(2025, 6, 1, 12, 0, 0, int(id), time.UTC),
+		Level:     loggo.WARNING,
+		Location: logfwd.SourceLocation{
+			Module:   module,
+			Filename: "uniter.go",
+			Line:     42,
+		},
+		Message: "something happened",
+		Labels:  []string{"cmr", "http"},
+	}
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	r := newReceiver()
+	defer r.Close()
+	client := s.open(c, r, 10)
+
+	err := client.Send([]logfwd.Record{s.record(1, names.NewUnitTag("mysql/0"), "juju.worker.uniter")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(r.pushes, gc.HasLen, 1)
+	c.Check(r.headers[0].Get("X-Scope-OrgID"), gc.Equals, "juju")
+	c.Check(r.pushes[0], jc.DeepEquals, []stream{{
+		Stream: map[string]string{
+			"juju_model":       modelUUID,
+			"juju_application": "mysql",
+			"juju_unit":        "mysql/0",
+			"juju_module":      "juju.worker.uniter",
+		},
+		Values: [][2]string{{
+			"1748779200000000001",
+			`level=WARNING location=uniter.go:42 labels=cmr,http msg="something happened"`,
+		}},
+	}})
+}
+
+func (s *ClientSuite) TestSendGroupsIntoStreams(c *gc.C) {
+	r := newReceiver()
+	defer r.Close()
+	client := s.open(c, r, 10)
+
+	err := client.Send([]logfwd.Record{
+		s.record(3, names.NewUnitTag("mysql/0"), "juju.worker.uniter"),
+		s.record(2, names.NewMachineTag("0"), "juju.worker.uniter"),
+		s.record(1, names.NewUnitTag("mysql/0"), "juju.worker.uniter"),
+		s.record(4, names.NewUnitTag("mysql/0"), "unit.mysql/0.juju-log"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(r.pushes, gc.HasLen, 1)
+	streams := r.pushes[0]
+	c.Assert(streams, gc.HasLen, 3)
+	c.Check(streams[0].Stream["juju_unit"], gc.Equals, "mysql/0")
+	c.Check(streams[0].Values, gc.HasLen, 2)
+	// Entries within a stream are in time order.
+	c.Check(streams[0].Values[0][0], gc.Equals, "1748779200000000001")
+	c.Check(streams[0].Values[1][0], gc.Equals, "1748779200000000003")
+	c.Check(streams[1].Stream, jc.DeepEquals, map[string]string{
+		"juju_model":   modelUUID,
+		"juju_machine": "0",
+		"juju_module":  "juju.worker.uniter",
+	})
+	c.Check(streams[2].Stream["juju_module"], gc.Equals, "unit.mysql/0.juju-log")
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	r := newReceiver()
+	defer r.Close()
+	client := s.open(c, r, 2)
+
+	var records []logfwd.Record
+	for i := int64(1); i <= 5; i++ {
+		records = append(records, s.record(i, names.NewUnitTag("mysql/0"), "juju.worker.uniter"))
+	}
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(r.pushes, gc.HasLen, 3)
+	for i, expected := range []int{2, 2, 1} {
+		c.Check(r.pushes[i][0].Values, gc.HasLen, expected)
+	}
+}
+
+func (s *ClientSuite) TestSendBacksOffWhenRateLimited(c *gc.C) {
+	r := newReceiver(http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusNoContent)
+	defer r.Close()
+	client := s.open(c, r, 10)
+
+	err := client.Send([]logfwd.Record{s.record(1, names.NewUnitTag("mysql/0"), "juju.worker.uniter")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(r.headers, gc.HasLen, 3)
+	c.Check(r.pushes, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestSendRetriesExhausted(c *gc.C) {
+	r := newReceiver(http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests)
+	defer r.Close()
+	client := s.open(c, r, 10)
+
+	err := client.Send([]logfwd.Record{s.record(1, names.NewUnitTag("mysql/0"), "juju.worker.uniter")})
+	c.Assert(err, gc.ErrorMatches, `pushing 1 log records to .*: Loki returned 429 Too Many Requests: slow down`)
+	c.Check(r.headers, gc.HasLen, 3)
+}
+
+func (s *ClientSuite) TestSendNotRetried(c *gc.C) {
+	r := newReceiver(http.StatusBadRequest)
+	defer r.Close()
+	client := s.open(c, r, 10)
+
+	err := client.Send([]logfwd.Record{s.record(1, names.NewUnitTag("mysql/0"), "juju.worker.uniter")})
+	c.Assert(err, gc.ErrorMatches, `pushing 1 log records to .*: Loki returned 400 Bad Request: slow down`)
+	c.Check(r.headers, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := loki.Open(loki.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, `empty Endpoint not valid`)
+}
@@ -0,0 +1,6 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package loki holds the tools needed to perform log forwarding
+// from Juju to a Grafana Loki compatible push API.
+package loki
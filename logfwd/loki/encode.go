@@ -0,0 +1,142 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loki
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// pushRequest is the JSON body of a request to the Loki push API.
+type pushRequest struct {
+	Streams []stream `json:"streams"`
+}
+
+// stream holds the entries of a single set of labels. Each entry is a
+// pair of the timestamp in nanoseconds since the epoch, as a string, and
+// the log line.
+type stream struct {
+	Labels map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// The labels of the streams. The number of distinct values of each label
+// is bounded by the number of models, applications, units, machines and
+// modules, which keeps the number of streams manageable.
+const (
+	labelModel       = "juju_model"
+	labelApplication = "juju_application"
+	labelUnit        = "juju_unit"
+	labelMachine     = "juju_machine"
+	labelModule      = "juju_module"
+)
+
+// streamLabels returns the labels of the stream to which the record
+// belongs.
+func streamLabels(rec logfwd.Record) map[string]string {
+	labels := map[string]string{
+		labelModel: rec.Origin.ModelUUID,
+	}
+	switch rec.Origin.Type {
+	case logfwd.OriginTypeUnit:
+		labels[labelUnit] = rec.Origin.Name
+		if application, err := names.UnitApplication(rec.Origin.Name); err == nil {
+			labels[labelApplication] = application
+		}
+	case logfwd.OriginTypeMachine:
+		labels[labelMachine] = rec.Origin.Name
+	}
+	if rec.Location.Module != "" {
+		labels[labelModule] = rec.Location.Module
+	}
+	return labels
+}
+
+// streamKey returns a string which identifies the set of labels.
+func streamKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var key strings.Builder
+	for _, k := range keys {
+		key.WriteString(k)
+		key.WriteByte('=')
+		key.WriteString(strconv.Quote(labels[k]))
+		key.WriteByte(',')
+	}
+	return key.String()
+}
+
+// newPushRequest groups the records into streams by their labels. The
+// entries of every stream are in time order, as Loki requires.
+func newPushRequest(records []logfwd.Record) pushRequest {
+	var request pushRequest
+	index := make(map[string]int)
+	for _, rec := range records {
+		labels := streamLabels(rec)
+		key := streamKey(labels)
+		i, ok := index[key]
+		if !ok {
+			i = len(request.Streams)
+			index[key] = i
+			request.Streams = append(request.Streams, stream{Labels: labels})
+		}
+		request.Streams[i].Values = append(request.Streams[i].Values, [2]string{
+			strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+			formatLine(rec),
+		})
+	}
+	for _, s := range request.Streams {
+		values := s.Values
+		sort.SliceStable(values, func(i, j int) bool {
+			ti, _ := strconv.ParseInt(values[i][0], 10, 64)
+			tj, _ := strconv.ParseInt(values[j][0], 10, 64)
+			return ti < tj
+		})
+	}
+	return request
+}
+
+// formatLine formats the record as a logfmt line, which Loki can parse
+// with its logfmt parser.
+func formatLine(rec logfwd.Record) string {
+	fields := []string{
+		"level", rec.Level.String(),
+		"location", rec.Location.String(),
+		"labels", strings.Join(rec.Labels, ","),
+		"msg", rec.Message,
+	}
+	var line strings.Builder
+	for i := 0; i < len(fields); i += 2 {
+		key, value := fields[i], fields[i+1]
+		if value == "" && key != "msg" {
+			continue
+		}
+		if line.Len() > 0 {
+			line.WriteByte(' ')
+		}
+		line.WriteString(key)
+		line.WriteByte('=')
+		line.WriteString(logfmtValue(value))
+	}
+	return line.String()
+}
+
+// logfmtValue quotes the value if it is empty or contains spaces, quotes,
+// equals signs or control characters.
+func logfmtValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " =\"\\") || strings.IndexFunc(value, func(r rune) bool {
+		return r < ' ' || r == 0x7f
+	}) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
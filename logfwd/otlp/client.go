@@ -0,0 +1,159 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package otlp
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httppush"
+)
+
+const (
+	// DefaultBatchSize is the maximum number of records sent in a
+	// single export request.
+	DefaultBatchSize = 512
+
+	// DefaultRetryAttempts is the number of times an export request
+	// is attempted before giving up.
+	DefaultRetryAttempts = httppush.DefaultRetryAttempts
+
+	// DefaultRetryDelay is the delay before the first retry of a failed
+	// export request. The delay doubles for every following attempt.
+	DefaultRetryDelay = httppush.DefaultRetryDelay
+)
+
+// HTTPClient exposes the underlying functionality needed by Client.
+type HTTPClient = httppush.HTTPClient
+
+// ClientConfig holds the settings of a Client that aren't part of the
+// model config.
+type ClientConfig struct {
+	// HTTPClient is used to send the export requests.
+	HTTPClient HTTPClient
+
+	// Clock is used to wait between retries.
+	Clock clock.Clock
+
+	// BatchSize is the maximum number of records sent in a single
+	// export request.
+	BatchSize int
+
+	// RetryAttempts is the number of times an export request is
+	// attempted before giving up.
+	RetryAttempts int
+
+	// RetryDelay is the delay before the first retry of a failed
+	// export request.
+	RetryDelay time.Duration
+}
+
+// Validate ensures that the config is valid.
+func (cfg ClientConfig) Validate() error {
+	if cfg.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	return nil
+}
+
+// Client sends log records to an OTLP/HTTP receiver.
+type Client struct {
+	pusher    *httppush.Pusher
+	url       string
+	batchSize int
+}
+
+// Open returns a client that sends log records to the OTLP/HTTP receiver
+// described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	client, err := OpenForClient(cfg, ClientConfig{
+		HTTPClient:    httppush.NewHTTPClient(tlsCfg),
+		Clock:         clock.WallClock,
+		BatchSize:     DefaultBatchSize,
+		RetryAttempts: DefaultRetryAttempts,
+		RetryDelay:    DefaultRetryDelay,
+	})
+	return client, errors.Trace(err)
+}
+
+// OpenForClient returns a client that sends log records to the OTLP/HTTP
+// receiver described by the config, using the given client config.
+func OpenForClient(cfg RawConfig, clientCfg ClientConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := clientCfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	u, err := cfg.url()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	headers, err := cfg.headers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pusher, err := httppush.NewPusher(httppush.PusherConfig{
+		Name:          "OTLP receiver",
+		URL:           u.String(),
+		Headers:       headers,
+		HTTPClient:    clientCfg.HTTPClient,
+		Clock:         clientCfg.Clock,
+		RetryAttempts: clientCfg.RetryAttempts,
+		RetryDelay:    clientCfg.RetryDelay,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		pusher:    pusher,
+		url:       u.String(),
+		batchSize: clientCfg.BatchSize,
+	}, nil
+}
+
+// Close stops any retries in progress.
+func (client *Client) Close() error {
+	return client.pusher.Close()
+}
+
+// Send sends the records to the OTLP/HTTP receiver, in batches of at
+// most the configured batch size. Failed batches are retried with an
+// increasing delay when the receiver reports that it is unavailable.
+func (client *Client) Send(records []logfwd.Record) error {
+	for len(records) > 0 {
+		n := len(records)
+		if n > client.batchSize {
+			n = client.batchSize
+		}
+		if err := client.sendBatch(records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (client *Client) sendBatch(records []logfwd.Record) error {
+	body, err := json.Marshal(newExportLogsRequest(records))
+	if err != nil {
+		return errors.Annotate(err, "encoding log records")
+	}
+	err = client.pusher.Push(httppush.Request{
+		Body:        body,
+		ContentType: "application/json",
+	})
+	return errors.Annotatef(err, "sending %d log records to %s", len(records), client.url)
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package otlp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/otlp"
+)
+
+// receiver is an in-process OTLP/HTTP logs receiver.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []map[string]interface{}
+	headers  []http.Header
+	statuses []int
+}
+
+func newReceiver(statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	return r
+}
+
+func (r *receiver) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if req.URL.Path != "/v1/logs" || req.Header.Get("Content-Type") != "application/json" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.headers = append(r.headers, req.Header)
+	if len(r.statuses) > 0 {
+		status := r.statuses[0]
+		r.statuses = r.statuses[1:]
+		if status != http.StatusOK {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte("try again"))
+			return
+		}
+	}
+	var request map[string]interface{}
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.requests = append(r.requests, request)
+	_, _ = w.Write([]byte("{}"))
+}
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) open(c *gc.C, r *receiver, batchSize int) *otlp.Client {
+	client, err := otlp.OpenForClient(otlp.RawConfig{
+		Enabled:  true,
+		Endpoint: r.URL,
+		Headers:  "authorization=Bearer abc",
+	}, otlp.ClientConfig{
+		HTTPClient:    r.Client(),
+		Clock:         testclock.NewDilatedWallClock(time.Millisecond),
+		BatchSize:     batchSize,
+		RetryAttempts: 3,
+		RetryDelay:    time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) record(id int64, model string) logfwd.Record {
+	origin := logfwd.OriginForMachineAgent(names.NewMachineTag("99"), "feebdaed-2f18-4fd2-967d-db9663db7bea", model, version.MustParse("3.6.0"))
+	return logfwd.Record{
+		ID:        id,
+		Origin:    origin,
+		Timestamp: time.Date(2025, 6, 1, 12, 0, 0, int(id), time.UTC),
+		Level:     loggo.WARNING,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.uniter",
+			Filename: "uniter.go",
+			Line:     42,
+		},
+		Message: "something happened",
+		Labels:  []string{"cmr", "http"},
+	}
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	r := newReceiver()
+	defer r.Close()
+	client := s.open(c, r, 10)
+
+	err := client.Send([]logfwd.Record{s.record(1, "deadbeef-2f18-4fd2-967d-db9663db7bea")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(r.requests, gc.HasLen, 1)
+	c.Check(r.headers[0].Get("Authorization"), gc.Equals, "Bearer abc")
+	data, err := json.Marshal(r.requests[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), jc.JSONEquals, map[string]interface{}{
+		"resourceLogs": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": []interface{}{
+					attribute("service.name", "stringValue", "jujud-machine-agent"),
+					attribute("service.version", "stringValue", "3.6.0"),
+					attribute("host.name", "stringValue", "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea"),
+					attribute("juju.controller.uuid", "stringValue", "feebdaed-2f18-4fd2-967d-db9663db7bea"),
+					attribute("juju.model.uuid", "stringValue", "deadbeef-2f18-4fd2-967d-db9663db7bea"),
+					attribute("juju.origin.type", "stringValue", "machine"),
+					attribute("juju.origin.name", "stringValue", "99"),
+				},
+			},
+			"scopeLogs": []interface{}{map[string]interface{}{
+				"scope": map[string]interface{}{"name": "juju", "version": "3.6.0"},
+				"logRecords": []interface{}{map[string]interface{}{
+					"timeUnixNano":         "1748779200000000001",
+					"observedTimeUnixNano": "1748779200000000001",
+					"severityNumber":       13,
+					"severityText":         "WARNING",
+					"body":                 map[string]interface{}{"stringValue": "something happened"},
+					"attributes": []interface{}{
+						attribute("juju.record.id", "intValue", "1"),
+						attribute("code.namespace", "stringValue", "juju.worker.uniter"),
+						attribute("code.filepath", "stringValue", "uniter.go"),
+						attribute("code.lineno", "intValue", "42"),
+						map[string]interface{}{
+							"key": "juju.labels",
+							"value": map[string]interface{}{"arrayValue": map[string]interface{}{
+								"values": []interface{}{
+									map[string]interface{}{"stringValue": "cmr"},
+									map[string]interface{}{"stringValue": "http"},
+								},
+							}},
+						},
+					},
+				}},
+			}},
+		}},
+	})
+}
+
+func attribute(key, kind, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"key":   key,
+		"value": map[string]interface{}{kind: value},
+	}
+}
+
+func (s *ClientSuite) TestSendGroupsByOrigin(c *gc.C) {
+	r := newReceiver()
+	defer r.Close()
+	client := s.open(c, r, 10)
+
+	err := client.Send([]logfwd.Record{
+		s.record(1, "deadbeef-2f18-4fd2-967d-db9663db7bea"),
+		s.record(2, "d00dd00d-2f18-4fd2-967d-db9663db7bea"),
+		s.record(3, "deadbeef-2f18-4fd2-967d-db9663db7bea"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(r.requests, gc.HasLen, 1)
+	resourceLogs := r.requests[0]["resourceLogs"].([]interface{})
+	c.Assert(resourceLogs, gc.HasLen, 2)
+	c.Check(countLogRecords(resourceLogs[0]), gc.Equals, 2)
+	c.Check(countLogRecords(resourceLogs[1]), gc.Equals, 1)
+}
+
+func countLogRecords(resourceLogs interface{}) int {
+	scopeLogs := resourceLogs.(map[string]interface{})["scopeLogs"].([]interface{})
+	return len(scopeLogs[0].(map[string]interface{})["logRecords"].([]interface{}))
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	r := newReceiver()
+	defer r.Close()
+	client := s.open(c, r, 2)
+
+	var records []logfwd.Record
+	for i := int64(1); i <= 5; i++ {
+		records = append(records, s.record(i, "deadbeef-2f18-4fd2-967d-db9663db7bea"))
+	}
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(r.requests, gc.HasLen, 3)
+	for i, expected := range []int{2, 2, 1} {
+		resourceLogs := r.requests[i]["resourceLogs"].([]interface{})
+		c.Check(countLogRecords(resourceLogs[0]), gc.Equals, expected)
+	}
+}
+
+func (s *ClientSuite) TestSendRetries(c *gc.C) {
+	r := newReceiver(http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+	defer r.Close()
+	client := s.open(c, r, 10)
+
+	err := client.Send([]logfwd.Record{s.record(1, "deadbeef-2f18-4fd2-967d-db9663db7bea")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(r.headers, gc.HasLen, 3)
+	c.Check(r.requests, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestSendRetriesExhausted(c *gc.C) {
+	r := newReceiver(http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	defer r.Close()
+	client := s.open(c, r, 10)
+
+	err := client.Send([]logfwd.Record{s.record(1, "deadbeef-2f18-4fd2-967d-db9663db7bea")})
+	c.Assert(err, gc.ErrorMatches, `sending 1 log records to .*: OTLP receiver returned 503 Service Unavailable: try again`)
+	c.Check(r.headers, gc.HasLen, 3)
+}
+
+func (s *ClientSuite) TestSendNotRetried(c *gc.C) {
+	r := newReceiver(http.StatusUnauthorized)
+	defer r.Close()
+	client := s.open(c, r, 10)
+
+	err := client.Send([]logfwd.Record{s.record(1, "deadbeef-2f18-4fd2-967d-db9663db7bea")})
+	c.Assert(err, gc.ErrorMatches, `sending 1 log records to .*: OTLP receiver returned 401 Unauthorized: try again`)
+	c.Check(r.headers, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := otlp.Open(otlp.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, `empty Endpoint not valid`)
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package otlp
+
+import (
+	"crypto/tls"
+	"net/url"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/httppush"
+)
+
+// logsPath is the path of the OTLP/HTTP logs endpoint, used when the
+// configured endpoint has no path.
+const logsPath = "/v1/logs"
+
+// RawConfig holds the raw configuration data for a connection to an
+// OTLP/HTTP log forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Endpoint is the URL of the OTLP/HTTP receiver, for example:
+	//
+	//   https://otel-collector:4318
+	//
+	// If the URL has no path then the standard logs path (/v1/logs)
+	// is used.
+	Endpoint string
+
+	// Headers holds extra HTTP headers to send with every request,
+	// such as authentication tokens. The format is a comma separated
+	// list of key=value pairs.
+	Headers string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If it
+	// is empty then the system roots are used.
+	CACert string
+
+	// ClientCert is the TLS certificate (x.509, PEM-encoded) to use
+	// when connecting. It is optional.
+	ClientCert string
+
+	// ClientKey is the TLS private key (x.509, PEM-encoded) to use
+	// when connecting. It is required if ClientCert is set.
+	ClientKey string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.Endpoint == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty Endpoint")
+		}
+	} else if _, err := cfg.url(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if _, err := cfg.headers(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if _, err := cfg.tlsConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+// url returns the URL to which the logs are sent.
+func (cfg RawConfig) url() (*url.URL, error) {
+	u, err := httppush.ParseEndpoint(cfg.Endpoint, logsPath)
+	return u, errors.Trace(err)
+}
+
+// headers parses the extra HTTP headers.
+func (cfg RawConfig) headers() (map[string]string, error) {
+	headers, err := httppush.ParseHeaders(cfg.Headers)
+	return headers, errors.Trace(err)
+}
+
+// tlsConfig returns the TLS config to use when connecting, or nil if
+// the default config should be used.
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	tlsCfg, err := httppush.TLSConfig(cfg.CACert, cfg.ClientCert, cfg.ClientKey)
+	return tlsCfg, errors.Trace(err)
+}
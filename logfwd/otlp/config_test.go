@@ -0,0 +1,108 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package otlp_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/otlp"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := otlp.RawConfig{
+		Enabled:    true,
+		Endpoint:   "https://otel-collector:4318",
+		Headers:    "authorization=Bearer abc, x-scope-orgid=juju",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateWithoutTLS(c *gc.C) {
+	cfg := otlp.RawConfig{
+		Enabled:  true,
+		Endpoint: "http://otel-collector:4318/v1/logs",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg otlp.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingEndpoint(c *gc.C) {
+	cfg := otlp.RawConfig{
+		Enabled: true,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `empty Endpoint not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadEndpoint(c *gc.C) {
+	cfg := otlp.RawConfig{
+		Enabled:  true,
+		Endpoint: "otel-collector:4318",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Endpoint "otel-collector:4318", expected an http or https URL not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadHeaders(c *gc.C) {
+	cfg := otlp.RawConfig{
+		Enabled:  true,
+		Endpoint: "https://otel-collector:4318",
+		Headers:  "authorization",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `header "authorization", expected key=value not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := otlp.RawConfig{
+		Enabled:  true,
+		Endpoint: "https://otel-collector:4318",
+		CACert:   "abc",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: no certificates found`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingClientKey(c *gc.C) {
+	cfg := otlp.RawConfig{
+		Enabled:    true,
+		Endpoint:   "https://otel-collector:4318",
+		ClientCert: coretesting.ServerCert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing client key pair: .*`)
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package otlp
+
+import (
+	"strconv"
+
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// The types below are the subset of the OTLP logs data model that is
+// needed to forward log records, following the JSON encoding of the
+// protobuf messages described at:
+//
+//   https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+//
+// Note that 64 bit integers are encoded as strings.
+
+type exportLogsRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeLogs struct {
+	Scope      scope       `json:"scope"`
+	LogRecords []logRecord `json:"logRecords"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type logRecord struct {
+	TimeUnixNano         string     `json:"timeUnixNano"`
+	ObservedTimeUnixNano string     `json:"observedTimeUnixNano"`
+	SeverityNumber       int        `json:"severityNumber"`
+	SeverityText         string     `json:"severityText"`
+	Body                 anyValue   `json:"body"`
+	Attributes           []keyValue `json:"attributes,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string     `json:"stringValue,omitempty"`
+	IntValue    *string     `json:"intValue,omitempty"`
+	ArrayValue  *arrayValue `json:"arrayValue,omitempty"`
+}
+
+type arrayValue struct {
+	Values []anyValue `json:"values"`
+}
+
+func stringValue(value string) anyValue {
+	return anyValue{StringValue: &value}
+}
+
+func intValue(value int64) anyValue {
+	encoded := strconv.FormatInt(value, 10)
+	return anyValue{IntValue: &encoded}
+}
+
+// The severity numbers of the OTLP logs data model.
+const (
+	severityTrace = 1
+	severityDebug = 5
+	severityInfo  = 9
+	severityWarn  = 13
+	severityError = 17
+	severityFatal = 21
+)
+
+func severityNumber(level loggo.Level) int {
+	switch level {
+	case loggo.TRACE:
+		return severityTrace
+	case loggo.DEBUG:
+		return severityDebug
+	case loggo.INFO:
+		return severityInfo
+	case loggo.WARNING:
+		return severityWarn
+	case loggo.ERROR:
+		return severityError
+	case loggo.CRITICAL:
+		return severityFatal
+	}
+	return 0
+}
+
+// scopeName is the instrumentation scope of the forwarded records.
+const scopeName = "juju"
+
+// newExportLogsRequest converts the records into an export request,
+// with the records of each origin grouped under a single resource.
+func newExportLogsRequest(records []logfwd.Record) exportLogsRequest {
+	var request exportLogsRequest
+	index := make(map[logfwd.Origin]int)
+	for _, rec := range records {
+		i, ok := index[rec.Origin]
+		if !ok {
+			i = len(request.ResourceLogs)
+			index[rec.Origin] = i
+			request.ResourceLogs = append(request.ResourceLogs, resourceLogs{
+				Resource: resourceFromOrigin(rec.Origin),
+				ScopeLogs: []scopeLogs{{
+					Scope: scope{
+						Name:    scopeName,
+						Version: rec.Origin.Software.Version.String(),
+					},
+				}},
+			})
+		}
+		scoped := &request.ResourceLogs[i].ScopeLogs[0]
+		scoped.LogRecords = append(scoped.LogRecords, logRecordFromRecord(rec))
+	}
+	return request
+}
+
+func resourceFromOrigin(origin logfwd.Origin) resource {
+	return resource{
+		Attributes: []keyValue{
+			{Key: "service.name", Value: stringValue(origin.Software.Name)},
+			{Key: "service.version", Value: stringValue(origin.Software.Version.String())},
+			{Key: "host.name", Value: stringValue(origin.Hostname)},
+			{Key: "juju.controller.uuid", Value: stringValue(origin.ControllerUUID)},
+			{Key: "juju.model.uuid", Value: stringValue(origin.ModelUUID)},
+			{Key: "juju.origin.type", Value: stringValue(origin.Type.String())},
+			{Key: "juju.origin.name", Value: stringValue(origin.Name)},
+		},
+	}
+}
+
+func logRecordFromRecord(rec logfwd.Record) logRecord {
+	timestamp := strconv.FormatInt(rec.Timestamp.UnixNano(), 10)
+	attributes := []keyValue{
+		{Key: "juju.record.id", Value: intValue(rec.ID)},
+	}
+	if rec.Location.Module != "" {
+		attributes = append(attributes, keyValue{Key: "code.namespace", Value: stringValue(rec.Location.Module)})
+	}
+	if rec.Location.Filename != "" {
+		attributes = append(attributes, keyValue{Key: "code.filepath", Value: stringValue(rec.Location.Filename)})
+	}
+	if rec.Location.Line > 0 {
+		attributes = append(attributes, keyValue{Key: "code.lineno", Value: intValue(int64(rec.Location.Line))})
+	}
+	if len(rec.Labels) > 0 {
+		labels := make([]anyValue, len(rec.Labels))
+		for i, label := range rec.Labels {
+			labels[i] = stringValue(label)
+		}
+		attributes = append(attributes, keyValue{Key: "juju.labels", Value: anyValue{ArrayValue: &arrayValue{Values: labels}}})
+	}
+	return logRecord{
+		TimeUnixNano:         timestamp,
+		ObservedTimeUnixNano: timestamp,
+		SeverityNumber:       severityNumber(rec.Level),
+		SeverityText:         rec.Level.String(),
+		Body:                 stringValue(rec.Message),
+		Attributes:           attributes,
+	}
+}
@@ -33,6 +33,9 @@ type Record struct {
 
 	// Message is the record's body. It may be empty.
 	Message string
+
+	// Labels are the logging labels of the record. They are optional.
+	Labels []string
 }
 
 // Validate ensures that the record is correct.
@@ -0,0 +1,92 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ssh
+
+import (
+	"bytes"
+	"crypto/rand"
+	"time"
+
+	"github.com/juju/errors"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// DefaultUserCertificateValidity is how long user certificates
+	// issued by the controller remain valid.
+	DefaultUserCertificateValidity = 5 * time.Minute
+
+	// userCertificateClockSkew is how far in the past a user
+	// certificate's validity starts, to allow for clocks that
+	// aren't quite in step.
+	userCertificateClockSkew = time.Minute
+)
+
+// userCertificateExtensions holds the permissions granted to user
+// certificates, matching those OpenSSH grants to plain keys.
+var userCertificateExtensions = map[string]string{
+	"permit-X11-forwarding":   "",
+	"permit-agent-forwarding": "",
+	"permit-port-forwarding":  "",
+	"permit-pty":              "",
+	"permit-user-rc":          "",
+}
+
+// NewUserCertificate returns a user certificate for key, signed by
+// the certificate authority ca. The certificate is only valid for
+// the given principals, from now until validity has elapsed.
+func NewUserCertificate(
+	ca gossh.Signer, key gossh.PublicKey, keyID string, principals []string,
+	validity time.Duration, now time.Time,
+) (*gossh.Certificate, error) {
+	if len(principals) == 0 {
+		return nil, errors.NotValidf("user certificate without principals")
+	}
+	if validity <= 0 {
+		return nil, errors.NotValidf("user certificate validity %v", validity)
+	}
+	cert := &gossh.Certificate{
+		Key:             key,
+		CertType:        gossh.UserCert,
+		KeyId:           keyID,
+		ValidPrincipals: principals,
+		ValidAfter:      uint64(now.Add(-userCertificateClockSkew).Unix()),
+		ValidBefore:     uint64(now.Add(validity).Unix()),
+		Permissions: gossh.Permissions{
+			Extensions: userCertificateExtensions,
+		},
+	}
+	if err := cert.SignCert(rand.Reader, ca); err != nil {
+		return nil, errors.Annotate(err, "signing user certificate")
+	}
+	return cert, nil
+}
+
+// CheckUserCertificate checks that cert is a user certificate signed
+// by the certificate authority caKey, that it's valid at the given
+// time, and that it allows principal.
+func CheckUserCertificate(caKey gossh.PublicKey, cert *gossh.Certificate, principal string, now time.Time) error {
+	if cert.CertType != gossh.UserCert {
+		return errors.NotValidf("certificate type %d", cert.CertType)
+	}
+	checker := gossh.CertChecker{
+		IsUserAuthority: func(auth gossh.PublicKey) bool {
+			return keysEqual(auth, caKey)
+		},
+		Clock: func() time.Time {
+			return now
+		},
+	}
+	if !checker.IsUserAuthority(cert.SignatureKey) {
+		return errors.NotValidf("certificate not signed by the controller")
+	}
+	if err := checker.CheckCert(principal, cert); err != nil {
+		return errors.NewNotValid(err, "user certificate")
+	}
+	return nil
+}
+
+func keysEqual(a, b gossh.PublicKey) bool {
+	return bytes.Equal(a.Marshal(), b.Marshal())
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ssh_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gossh "golang.org/x/crypto/ssh"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/pki/ssh"
+)
+
+type CertificateSuite struct {
+	ca  gossh.Signer
+	key gossh.Signer
+	now time.Time
+}
+
+var _ = gc.Suite(&CertificateSuite{})
+
+func (s *CertificateSuite) SetUpTest(c *gc.C) {
+	s.ca = newSigner(c)
+	s.key = newSigner(c)
+	s.now = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func newSigner(c *gc.C) gossh.Signer {
+	privateKey, err := ssh.ED25519()
+	c.Assert(err, jc.ErrorIsNil)
+	signer, err := gossh.NewSignerFromKey(privateKey)
+	c.Assert(err, jc.ErrorIsNil)
+	return signer
+}
+
+func (s *CertificateSuite) newCertificate(c *gc.C, principals ...string) *gossh.Certificate {
+	cert, err := ssh.NewUserCertificate(
+		s.ca, s.key.PublicKey(), "bob", principals, ssh.DefaultUserCertificateValidity, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+	return cert
+}
+
+func (s *CertificateSuite) TestNewUserCertificate(c *gc.C) {
+	cert := s.newCertificate(c, "0.model.juju.local")
+	c.Check(cert.CertType, gc.Equals, uint32(gossh.UserCert))
+	c.Check(cert.KeyId, gc.Equals, "bob")
+	c.Check(cert.ValidPrincipals, jc.DeepEquals, []string{"0.model.juju.local"})
+	c.Check(cert.Key.Marshal(), jc.DeepEquals, s.key.PublicKey().Marshal())
+	c.Check(cert.SignatureKey.Marshal(), jc.DeepEquals, s.ca.PublicKey().Marshal())
+	c.Check(cert.ValidBefore, gc.Equals, uint64(s.now.Add(ssh.DefaultUserCertificateValidity).Unix()))
+	c.Check(cert.Permissions.Extensions, jc.DeepEquals, map[string]string{
+		"permit-X11-forwarding":   "",
+		"permit-agent-forwarding": "",
+		"permit-port-forwarding":  "",
+		"permit-pty":              "",
+		"permit-user-rc":          "",
+	})
+}
+
+func (s *CertificateSuite) TestNewUserCertificateRequiresPrincipals(c *gc.C) {
+	_, err := ssh.NewUserCertificate(
+		s.ca, s.key.PublicKey(), "bob", nil, ssh.DefaultUserCertificateValidity, s.now)
+	c.Assert(err, gc.ErrorMatches, "user certificate without principals not valid")
+}
+
+func (s *CertificateSuite) TestNewUserCertificateRequiresValidity(c *gc.C) {
+	_, err := ssh.NewUserCertificate(
+		s.ca, s.key.PublicKey(), "bob", []string{"0.model.juju.local"}, 0, s.now)
+	c.Assert(err, gc.ErrorMatches, "user certificate validity 0s not valid")
+}
+
+func (s *CertificateSuite) TestCheckUserCertificate(c *gc.C) {
+	cert := s.newCertificate(c, "0.model.juju.local", "1.model.juju.local")
+	err := ssh.CheckUserCertificate(s.ca.PublicKey(), cert, "1.model.juju.local", s.now.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CertificateSuite) TestCheckUserCertificateWrongPrincipal(c *gc.C) {
+	cert := s.newCertificate(c, "0.model.juju.local")
+	err := ssh.CheckUserCertificate(s.ca.PublicKey(), cert, "2.model.juju.local", s.now)
+	c.Assert(err, gc.ErrorMatches, `user certificate: .*principal "2.model.juju.local" not in the set of valid principals.*`)
+}
+
+func (s *CertificateSuite) TestCheckUserCertificateExpired(c *gc.C) {
+	cert := s.newCertificate(c, "0.model.juju.local")
+	now := s.now.Add(ssh.DefaultUserCertificateValidity + time.Second)
+	err := ssh.CheckUserCertificate(s.ca.PublicKey(), cert, "0.model.juju.local", now)
+	c.Assert(err, gc.ErrorMatches, "user certificate: .*cert has expired.*")
+}
+
+func (s *CertificateSuite) TestCheckUserCertificateWrongAuthority(c *gc.C) {
+	cert := s.newCertificate(c, "0.model.juju.local")
+	err := ssh.CheckUserCertificate(newSigner(c).PublicKey(), cert, "0.model.juju.local", s.now)
+	c.Assert(err, gc.ErrorMatches, "certificate not signed by the controller not valid")
+}
+
+func (s *CertificateSuite) TestCheckUserCertificateHostCertificate(c *gc.C) {
+	cert := s.newCertificate(c, "0.model.juju.local")
+	cert.CertType = gossh.HostCert
+	err := ssh.CheckUserCertificate(s.ca.PublicKey(), cert, "0.model.juju.local", s.now)
+	c.Assert(err, gc.ErrorMatches, "certificate type 2 not valid")
+}
@@ -3,7 +3,10 @@
 
 package apiserver
 
-import "github.com/juju/juju/pubsub/common"
+import (
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/pubsub/common"
+)
 
 // DetailsTopic is the topic name for the published message when the details
 // of the api servers change. This message is normally published by the
@@ -98,3 +101,31 @@ const RestartTopic = "apiserver.restart"
 // Restart message only contains the local-only indicator as the restart
 // is only ever for the same agent.
 type Restart common.LocalOnly
+
+// AuditLogQueryTopic is used by an API server to ask every API server,
+// itself included, to search its audit log.
+// data: `AuditLogQuery`
+const AuditLogQueryTopic = "apiserver.auditlog-query"
+
+// AuditLogResultTopic is used by the API servers to respond to the
+// query topic above.
+// data: `AuditLogResult`
+const AuditLogResultTopic = "apiserver.auditlog-result"
+
+// AuditLogQuery asks for the audit log entries matching the filter.
+type AuditLogQuery struct {
+	Origin    string          `yaml:"origin"`
+	RequestID string          `yaml:"request-id"`
+	Filter    auditlog.Filter `yaml:"filter"`
+}
+
+// AuditLogResult holds the audit log entries of the server identified
+// by Origin, in response to the query with the same RequestID sent by
+// Target.
+type AuditLogResult struct {
+	Origin    string           `yaml:"origin"`
+	Target    string           `yaml:"target"`
+	RequestID string           `yaml:"request-id"`
+	Entries   []auditlog.Entry `yaml:"entries,omitempty"`
+	Error     string           `yaml:"error,omitempty"`
+}
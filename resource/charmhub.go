@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/charmhub/transport"
+	"github.com/juju/juju/controller"
 	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/state"
 )
@@ -34,9 +35,11 @@ func (ch *charmHubOpener) NewClient() (*ResourceRetryClient, error) {
 }
 
 // chClientState represents a state which can provide a model to create a
-// CharmHub client.
+// CharmHub client, and the controller's Charmhub cache.
 type chClientState interface {
 	Model() (*state.Model, error)
+	ControllerConfig() (controller.Config, error)
+	CharmhubCache() *state.CharmhubCache
 }
 
 func newCharmHubClient(st chClientState) (ResourceGetter, error) {
@@ -49,10 +52,20 @@ func newCharmHubClient(st chClientState) (ResourceGetter, error) {
 		return &CharmHubClient{}, errors.Trace(err)
 	}
 
+	controllerCfg, err := st.ControllerConfig()
+	if err != nil {
+		return &CharmHubClient{}, errors.Trace(err)
+	}
+	httpClient := charmhub.DefaultHTTPClient(logger)
+	if mode := controllerCfg.CharmhubCacheMode(); mode != "" {
+		httpClient = charmhub.NewCachingHTTPClient(httpClient, st.CharmhubCache(), charmhub.CacheMode(mode), logger)
+	}
+
 	chURL, _ := modelCfg.CharmHubURL()
 	chClient, err := charmhub.NewClient(charmhub.Config{
-		URL:    chURL,
-		Logger: logger,
+		URL:        chURL,
+		HTTPClient: httpClient,
+		Logger:     logger,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
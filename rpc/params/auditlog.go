@@ -0,0 +1,81 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogQueryArgs holds the args for the AuditLog Query method.
+// Empty values match every entry.
+type AuditLogQueryArgs struct {
+	// Users holds the names of the users whose requests are wanted.
+	Users []string `json:"users,omitempty"`
+
+	// Models holds the names or UUIDs of the models whose requests
+	// are wanted.
+	Models []string `json:"models,omitempty"`
+
+	// ConversationID selects the requests of a single conversation.
+	ConversationID string `json:"conversation-id,omitempty"`
+
+	// Facade and Method select requests by the API method called.
+	Facade string `json:"facade,omitempty"`
+	Method string `json:"method,omitempty"`
+
+	// From and To select requests made at or after From and
+	// before To.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+
+	// Result selects requests by their outcome, either "success" or
+	// "error".
+	Result string `json:"result,omitempty"`
+
+	// Limit is the maximum number of entries to return; the most
+	// recent entries are kept. Zero means the controller's maximum.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry is a request recorded in the audit log of a
+// controller, along with the conversation it was made in.
+type AuditLogEntry struct {
+	ControllerID   string          `json:"controller-id"`
+	ConversationID string          `json:"conversation-id"`
+	ConnectionID   string          `json:"connection-id"`
+	RequestID      uint64          `json:"request-id"`
+	Who            string          `json:"who"`
+	What           string          `json:"what"`
+	ModelName      string          `json:"model-name"`
+	ModelUUID      string          `json:"model-uuid"`
+	When           time.Time       `json:"when"`
+	Facade         string          `json:"facade"`
+	Method         string          `json:"method"`
+	Version        int             `json:"version"`
+	Args           string          `json:"args,omitempty"`
+	Responded      bool            `json:"responded"`
+	Errors         []AuditLogError `json:"errors,omitempty"`
+}
+
+// AuditLogError holds an error returned in response to a request.
+type AuditLogError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// AuditLogControllerError reports a controller whose audit log could
+// not be read.
+type AuditLogControllerError struct {
+	ControllerID string `json:"controller-id"`
+	Error        *Error `json:"error"`
+}
+
+// AuditLogQueryResult holds the result of an AuditLog Query call.
+type AuditLogQueryResult struct {
+	// Entries holds the matching entries of every controller,
+	// oldest first.
+	Entries []AuditLogEntry `json:"entries"`
+
+	// Errors holds the controllers whose audit logs could not be
+	// read.
+	Errors []AuditLogControllerError `json:"errors,omitempty"`
+}
@@ -9,10 +9,18 @@ import (
 	"github.com/juju/version/v2"
 )
 
+// BackupsScheduledNotes are the notes recorded against every backup
+// created on schedule by a controller agent.
+const BackupsScheduledNotes = "scheduled backup"
+
 // BackupsCreateArgs holds the args for the API Create method.
 type BackupsCreateArgs struct {
 	Notes      string `json:"notes"`
 	NoDownload bool   `json:"no-download"`
+
+	// EncryptTo holds public keys to encrypt the archive to, in
+	// addition to any configured on the controller.
+	EncryptTo []string `json:"encrypt-to,omitempty"`
 }
 
 // BackupsDownloadArgs holds the args for the API Download method.
@@ -20,6 +28,32 @@ type BackupsDownloadArgs struct {
 	ID string `json:"id"`
 }
 
+// BackupsRemoveArgs holds the args for the API Remove method.
+type BackupsRemoveArgs struct {
+	IDs []string `json:"ids"`
+}
+
+// BackupsListResult holds the result of the API List method.
+type BackupsListResult struct {
+	List []BackupsArchive `json:"list"`
+}
+
+// BackupsArchive describes a backup archive held on a controller machine.
+type BackupsArchive struct {
+	// ID identifies the archive for download and removal.
+	ID string `json:"id"`
+
+	Size    int64     `json:"size"`
+	Created time.Time `json:"created"`
+
+	// Machine is the controller machine holding the archive.
+	Machine string `json:"machine"`
+
+	// Notes are the notes given when the archive was created, if
+	// they are known.
+	Notes string `json:"notes,omitempty"`
+}
+
 // BackupsMetadataResult holds the metadata for a backup as returned by
 // an API backups method (such as Create).
 type BackupsMetadataResult struct {
@@ -57,4 +91,26 @@ type BackupsMetadataResult struct {
 
 	// HANodes reflects HA configuration: number of controller nodes in HA.
 	HANodes int64 `json:"ha-nodes"`
+
+	// Remote describes where the archive was uploaded, if it was.
+	Remote *BackupsRemoteResult `json:"remote,omitempty"`
+
+	// Encryption describes how the archive was encrypted, if it was.
+	Encryption *BackupsEncryptionResult `json:"encryption,omitempty"`
+}
+
+// BackupsEncryptionResult describes an encrypted backup archive.
+type BackupsEncryptionResult struct {
+	Format     string   `json:"format"`
+	Recipients []string `json:"recipients"`
+}
+
+// BackupsRemoteResult describes a backup archive uploaded to an
+// S3-compatible object store.
+type BackupsRemoteResult struct {
+	Endpoint string    `json:"endpoint"`
+	Bucket   string    `json:"bucket"`
+	Key      string    `json:"key"`
+	ETag     string    `json:"etag"`
+	Uploaded time.Time `json:"uploaded"`
 }
@@ -0,0 +1,47 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// CharmhubCacheEntry describes a Charmhub response, charm archive or
+// resource held in the controller's Charmhub cache.
+type CharmhubCacheEntry struct {
+	Key      string    `json:"key"`
+	Kind     string    `json:"kind"`
+	Name     string    `json:"name"`
+	Revision int       `json:"revision,omitempty"`
+	Size     int64     `json:"size"`
+	SHA256   string    `json:"sha256"`
+	Created  time.Time `json:"created"`
+	LastUsed time.Time `json:"last-used"`
+}
+
+// CharmhubCacheEntries holds entries of the controller's Charmhub cache.
+type CharmhubCacheEntries struct {
+	Entries []CharmhubCacheEntry `json:"entries"`
+}
+
+// CharmhubCachePruneArgs selects the entries to remove from the
+// controller's Charmhub cache. Entries matching all of the given
+// criteria are removed; at least one criterion is required.
+type CharmhubCachePruneArgs struct {
+	// Keys selects entries by key.
+	Keys []string `json:"keys,omitempty"`
+
+	// Kind selects entries holding the given kind of content:
+	// "refresh", "charm", "resource" or "metadata".
+	Kind string `json:"kind,omitempty"`
+
+	// Name selects entries by the name of the charm, resource or
+	// query they hold.
+	Name string `json:"name,omitempty"`
+
+	// UnusedSince selects entries which haven't been used since
+	// the given time.
+	UnusedSince *time.Time `json:"unused-since,omitempty"`
+
+	// All selects every entry.
+	All bool `json:"all,omitempty"`
+}
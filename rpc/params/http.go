@@ -29,6 +29,13 @@ const (
 
 	// ContentTypeXJS is the outdated HTTP content-type value used for javascript.
 	ContentTypeXJS = "application/x-javascript"
+
+	// ContentTypeTar is the HTTP content-type value used for tar archives.
+	ContentTypeTar = "application/x-tar"
+
+	// ContentTypeAsciicast is the HTTP content-type value used for
+	// asciicast terminal session recordings.
+	ContentTypeAsciicast = "application/x-asciicast"
 )
 
 // EncodeChecksum base64 encodes a sha256 checksum according to RFC 4648 and
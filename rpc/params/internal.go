@@ -922,6 +922,7 @@ type SingularClaims struct {
 
 // LogMessage is a structured logging entry.
 type LogMessage struct {
+	ModelUUID string    `json:"model-uuid,omitempty"`
 	Entity    string    `json:"tag"`
 	Timestamp time.Time `json:"ts"`
 	Severity  string    `json:"sev"`
@@ -107,6 +107,8 @@ func (d *Delta) UnmarshalJSON(data []byte) error {
 		d.Entity = new(RelationInfo)
 	case "remoteApplication":
 		d.Entity = new(RemoteApplicationUpdate)
+	case "storageInstance":
+		d.Entity = new(StorageInstanceInfo)
 	case "unit":
 		d.Entity = new(UnitInfo)
 	default:
@@ -319,6 +321,8 @@ type RelationInfo struct {
 	ModelUUID string     `json:"model-uuid"`
 	Key       string     `json:"key"`
 	Id        int        `json:"id"`
+	Life      life.Value `json:"life,omitempty"`
+	Status    StatusInfo `json:"status"`
 	Endpoints []Endpoint `json:"endpoints"`
 }
 
@@ -353,6 +357,30 @@ func (i *RelationInfo) EntityId() EntityId {
 	}
 }
 
+// StorageInstanceInfo holds the information about a storage instance that
+// is tracked by multiwatcherStore.
+type StorageInstanceInfo struct {
+	ModelUUID       string     `json:"model-uuid"`
+	Id              string     `json:"id"`
+	Kind            string     `json:"kind"`
+	Life            life.Value `json:"life"`
+	Owner           string     `json:"owner,omitempty"`
+	StorageName     string     `json:"storage-name"`
+	Pool            string     `json:"pool,omitempty"`
+	Size            uint64     `json:"size,omitempty"`
+	AttachmentCount int        `json:"attachment-count"`
+}
+
+// EntityId returns a unique identifier for a storage instance across
+// models.
+func (i *StorageInstanceInfo) EntityId() EntityId {
+	return EntityId{
+		Kind:      "storageInstance",
+		ModelUUID: i.ModelUUID,
+		Id:        i.Id,
+	}
+}
+
 // AnnotationInfo holds the information about an annotation that is
 // tracked by multiwatcherStore.
 type AnnotationInfo struct {
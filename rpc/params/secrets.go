@@ -260,6 +260,32 @@ type ListSecretResults struct {
 	Results []ListSecretResult `json:"results"`
 }
 
+// CheckSecretContentDriftArgs holds the args for checking
+// secret content drift.
+type CheckSecretContentDriftArgs struct {
+	Filter SecretsFilter `json:"filter"`
+}
+
+// SecretContentDriftResults holds the outcome of checking secret
+// revisions stored in external backends for content drift.
+type SecretContentDriftResults struct {
+	// Checked is the number of revisions checked.
+	Checked int `json:"checked"`
+	// Results holds the revisions which have drifted or
+	// which couldn't be checked.
+	Results []SecretContentDriftResult `json:"results"`
+}
+
+// SecretContentDriftResult holds the outcome of checking
+// the content of a secret revision.
+type SecretContentDriftResult struct {
+	URI         string `json:"uri"`
+	Revision    int    `json:"revision"`
+	BackendName string `json:"backend-name"`
+	Drift       string `json:"drift,omitempty"`
+	Error       *Error `json:"error,omitempty"`
+}
+
 // SecretValueRef holds a reference to a secret
 // value in a secret backend.
 type SecretValueRef struct {
@@ -451,6 +477,44 @@ type RemoveSecretBackendArg struct {
 	Force bool   `json:"force,omitempty"`
 }
 
+// MigrateSecretsArgs holds the args for moving secret content
+// from one backend to another.
+type MigrateSecretsArgs struct {
+	FromBackend string `json:"from-backend"`
+	ToBackend   string `json:"to-backend"`
+	// ModelUUID limits the migration to the secrets of one model.
+	ModelUUID string `json:"model-uuid,omitempty"`
+	// After is the position returned by the previous call, if any.
+	After string `json:"after,omitempty"`
+	// Limit is the maximum number of revisions to migrate.
+	Limit int `json:"limit"`
+}
+
+// MigrateSecretsResult holds the result of migrating secret content.
+type MigrateSecretsResult struct {
+	Results []SecretRevisionMigrationResult `json:"results"`
+	// Remaining is the number of revisions still to be migrated. It is
+	// only counted by the first call, which has no After cursor.
+	Remaining int `json:"remaining"`
+	// Next is the position to continue the migration from, empty once
+	// there are no more revisions to migrate.
+	Next string `json:"next,omitempty"`
+}
+
+// SecretRevisionMigrationResult holds the result of migrating the
+// content of a secret revision.
+type SecretRevisionMigrationResult struct {
+	ModelUUID string `json:"model-uuid"`
+	ModelName string `json:"model-name"`
+	URI       string `json:"uri"`
+	Revision  int    `json:"revision"`
+	// Migrated is true if the content was moved to the new backend;
+	// if there is also an error, the content couldn't be deleted
+	// from the old backend.
+	Migrated bool   `json:"migrated"`
+	Error    *Error `json:"error,omitempty"`
+}
+
 // RotateSecretBackendArgs holds the args for updating rotated secret backend info.
 type RotateSecretBackendArgs struct {
 	BackendIDs []string `json:"backend-ids"`
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/sshpolicy"
 )
 
 // SSHHostKeySet defines SSH host keys for one or more entities
@@ -143,3 +144,140 @@ type ListAuthorizedKeysResult struct {
 	Error          *Error   `json:"error,omitempty"`
 	AuthorizedKeys []string `json:"public-keys,omitempty"`
 }
+
+// SSHSessionRecordingArg describes a recording of an SSH session
+// proxied through the controller. The recording itself, in asciicast
+// v2 format, is the body of the request to the controller's
+// /ssh-session-recordings HTTP endpoint, which is given these fields
+// as query parameters.
+type SSHSessionRecordingArg struct {
+	User           string    `json:"user"`
+	KeyFingerprint string    `json:"key-fingerprint,omitempty"`
+	Target         string    `json:"target"`
+	Started        time.Time `json:"started"`
+	Ended          time.Time `json:"ended"`
+}
+
+// SSHSessionRecordingFilter selects SSH session recordings.
+type SSHSessionRecordingFilter struct {
+	User   string     `json:"user,omitempty"`
+	Target string     `json:"target,omitempty"`
+	Since  *time.Time `json:"since,omitempty"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+// SSHSessionRecording describes a recorded SSH session.
+type SSHSessionRecording struct {
+	ID             string    `json:"id"`
+	User           string    `json:"user"`
+	KeyFingerprint string    `json:"key-fingerprint,omitempty"`
+	Target         string    `json:"target"`
+	Started        time.Time `json:"started"`
+	Ended          time.Time `json:"ended"`
+	Size           int64     `json:"size"`
+}
+
+// SSHSessionRecordingIDArg identifies an SSH session recording.
+type SSHSessionRecordingIDArg struct {
+	ID string `json:"id"`
+}
+
+// SSHSessionRecordingsResult holds SSH session recordings.
+type SSHSessionRecordingsResult struct {
+	Results []SSHSessionRecording `json:"results"`
+}
+
+// SSHSessionRecordingResult holds an SSH session recording
+// and its content.
+type SSHSessionRecordingResult struct {
+	Error     *Error              `json:"error,omitempty"`
+	Metadata  SSHSessionRecording `json:"metadata"`
+	Recording []byte              `json:"recording,omitempty"`
+}
+
+// SSHPolicy holds a policy controlling what may be done through
+// the controller's SSH server in a model.
+type SSHPolicy struct {
+	Name           string   `json:"name"`
+	Users          []string `json:"users"`
+	Applications   []string `json:"applications,omitempty"`
+	Commands       []string `json:"commands,omitempty"`
+	Shell          bool     `json:"shell,omitempty"`
+	SFTP           bool     `json:"sftp,omitempty"`
+	PortForwarding bool     `json:"port-forwarding,omitempty"`
+}
+
+// FromSSHPolicy returns the SSHPolicy representing the policy.
+func FromSSHPolicy(p sshpolicy.Policy) SSHPolicy {
+	return SSHPolicy{
+		Name:           p.Name,
+		Users:          p.Users,
+		Applications:   p.Applications,
+		Commands:       p.Commands,
+		Shell:          p.Shell,
+		SFTP:           p.SFTP,
+		PortForwarding: p.PortForwarding,
+	}
+}
+
+// SSHPolicy returns the policy represented by the SSHPolicy.
+func (p SSHPolicy) SSHPolicy() sshpolicy.Policy {
+	return sshpolicy.Policy{
+		Name:           p.Name,
+		Users:          p.Users,
+		Applications:   p.Applications,
+		Commands:       p.Commands,
+		Shell:          p.Shell,
+		SFTP:           p.SFTP,
+		PortForwarding: p.PortForwarding,
+	}
+}
+
+// SSHPoliciesArg identifies the model whose SSH policies to get.
+type SSHPoliciesArg struct {
+	ModelUUID string `json:"model-uuid"`
+}
+
+// SSHPoliciesResult holds a model's SSH policies.
+type SSHPoliciesResult struct {
+	Error    *Error      `json:"error,omitempty"`
+	Policies []SSHPolicy `json:"policies"`
+}
+
+// SSHPolicyNameArg identifies an SSH policy.
+type SSHPolicyNameArg struct {
+	Name string `json:"name"`
+}
+
+// SSHUserCertificateAuthorityResult holds the public key of the
+// controller's SSH user certificate authority, and the principals a
+// machine accepts certificates for. The public key is empty when
+// machines aren't to trust the certificate authority. CertificatesOnly
+// is set when the keys of the model's users are to be removed from the
+// machine.
+type SSHUserCertificateAuthorityResult struct {
+	Error            *Error   `json:"error,omitempty"`
+	PublicKey        string   `json:"public-key,omitempty"`
+	Principals       []string `json:"principals,omitempty"`
+	CertificatesOnly bool     `json:"certificates-only,omitempty"`
+}
+
+// SSHUserCertificateAuthorityResults holds the results of a bulk
+// SSHUserCertificateAuthority call.
+type SSHUserCertificateAuthorityResults struct {
+	Results []SSHUserCertificateAuthorityResult `json:"results"`
+}
+
+// SSHUserCertificateArg holds a public key to issue an SSH user
+// certificate for, and the machines and units it's to be valid for.
+type SSHUserCertificateArg struct {
+	Targets   []string `json:"targets"`
+	PublicKey string   `json:"public-key"`
+}
+
+// SSHUserCertificateResult holds an SSH user certificate in
+// authorized_keys format.
+type SSHUserCertificateResult struct {
+	Error       *Error `json:"error,omitempty"`
+	Certificate string `json:"certificate,omitempty"`
+}
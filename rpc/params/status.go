@@ -18,6 +18,17 @@ import (
 type StatusParams struct {
 	Patterns       []string `json:"patterns"`
 	IncludeStorage bool     `json:"include-storage,omitempty"`
+
+	// Where holds predicates on the status of units, such as
+	// "workload=blocked", "agent!=idle" or "since<10m". Only units
+	// matching all the predicates, along with their applications and
+	// machines, are returned.
+	Where []string `json:"where,omitempty"`
+
+	// Fields holds the sections of the status to return, such as
+	// "machines", "applications" or "units". All sections are returned
+	// when it is empty.
+	Fields []string `json:"fields,omitempty"`
 }
 
 // FullStatus holds information about the status of a juju model.
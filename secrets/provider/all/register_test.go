@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/secrets/provider"
 	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/secrets/provider/aws"
 	"github.com/juju/juju/secrets/provider/juju"
 	"github.com/juju/juju/secrets/provider/kubernetes"
 	"github.com/juju/juju/secrets/provider/vault"
@@ -23,6 +24,7 @@ var _ = gc.Suite(&allSuite{})
 
 func (s *allSuite) TestInit(c *gc.C) {
 	for _, name := range []string{
+		aws.BackendType,
 		juju.BackendType,
 		kubernetes.BackendType,
 		vault.BackendType,
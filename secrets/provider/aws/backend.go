@@ -0,0 +1,135 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+const (
+	controllerUUIDTag = "juju-controller-uuid"
+	modelUUIDTag      = "juju-model-uuid"
+	secretIDTag       = "juju-secret-id"
+	secretRevisionTag = "juju-secret-revision"
+)
+
+// secretNamePrefix returns the prefix of the names of all secrets
+// stored for the model.
+func secretNamePrefix(modelUUID string) string {
+	return "juju/" + modelUUID + "/"
+}
+
+type awsBackend struct {
+	controllerUUID string
+	modelUUID      string
+	kmsKeyID       string
+	client         *secretsmanager.Client
+}
+
+// GetContent implements SecretsBackend.
+func (k awsBackend) GetContent(ctx context.Context, revisionId string) (_ secrets.SecretValue, err error) {
+	defer func() {
+		err = maybePermissionDenied(err)
+	}()
+
+	out, err := k.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: awssdk.String(revisionId),
+	})
+	if isNotFound(err) {
+		return nil, errors.NotFoundf("secret revision %q", revisionId)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting secret %q", revisionId)
+	}
+	val := make(map[string]string)
+	if err := json.Unmarshal([]byte(awssdk.ToString(out.SecretString)), &val); err != nil {
+		return nil, errors.Annotatef(err, "reading secret %q", revisionId)
+	}
+	return secrets.NewSecretValue(val), nil
+}
+
+// DeleteContent implements SecretsBackend.
+func (k awsBackend) DeleteContent(ctx context.Context, revisionId string) (err error) {
+	defer func() {
+		err = maybePermissionDenied(err)
+	}()
+
+	_, err = k.client.DeleteSecret(ctx, &secretsmanager.DeleteSecretInput{
+		SecretId:                   awssdk.String(revisionId),
+		ForceDeleteWithoutRecovery: awssdk.Bool(true),
+	})
+	if isNotFound(err) {
+		return errors.NotFoundf("secret revision %q", revisionId)
+	}
+	return errors.Trace(err)
+}
+
+// SaveContent implements SecretsBackend.
+func (k awsBackend) SaveContent(ctx context.Context, uri *secrets.URI, revision int, value secrets.SecretValue) (_ string, err error) {
+	defer func() {
+		err = maybePermissionDenied(err)
+	}()
+
+	name := secretNamePrefix(k.modelUUID) + uri.Name(revision)
+	data, err := json.Marshal(value.EncodedValues())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	input := &secretsmanager.CreateSecretInput{
+		Name:         awssdk.String(name),
+		SecretString: awssdk.String(string(data)),
+		Tags: []types.Tag{
+			{Key: awssdk.String(controllerUUIDTag), Value: awssdk.String(k.controllerUUID)},
+			{Key: awssdk.String(modelUUIDTag), Value: awssdk.String(k.modelUUID)},
+			{Key: awssdk.String(secretIDTag), Value: awssdk.String(uri.ID)},
+			{Key: awssdk.String(secretRevisionTag), Value: awssdk.String(strconv.Itoa(revision))},
+		},
+	}
+	if k.kmsKeyID != "" {
+		input.KmsKeyId = awssdk.String(k.kmsKeyID)
+	}
+	_, err = k.client.CreateSecret(ctx, input)
+	if isAlreadyExists(err) {
+		// The content may have been saved by an earlier attempt
+		// which was interrupted, so overwrite it.
+		_, err = k.client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+			SecretId:     awssdk.String(name),
+			SecretString: awssdk.String(string(data)),
+		})
+	}
+	if err != nil {
+		return "", errors.Annotatef(err, "saving secret content for %q", name)
+	}
+	return name, nil
+}
+
+// Ping implements SecretsBackend.
+func (k awsBackend) Ping() error {
+	prefix := "juju/"
+	if k.modelUUID != "" {
+		prefix = secretNamePrefix(k.modelUUID)
+	}
+	_, err := k.client.ListSecrets(context.Background(), &secretsmanager.ListSecretsInput{
+		MaxResults: awssdk.Int32(1),
+		Filters: []types.Filter{{
+			Key:    types.FilterNameStringTypeName,
+			Values: []string{prefix},
+		}},
+	})
+	if err == nil {
+		return nil
+	}
+	if isPermissionDenied(err) {
+		return errors.New("credentials invalid: permission denied")
+	}
+	return errors.Annotate(err, "backend not reachable")
+}
@@ -0,0 +1,201 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package aws
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	coreconfig "github.com/juju/juju/core/config"
+	"github.com/juju/juju/secrets/provider"
+)
+
+const (
+	EndpointKey     = "endpoint"
+	STSEndpointKey  = "sts-endpoint"
+	RegionKey       = "region"
+	AccessKeyKey    = "access-key"
+	SecretKeyKey    = "secret-key"
+	SessionTokenKey = "session-token"
+	RoleARNKey      = "role-arn"
+	ExternalIDKey   = "external-id"
+	KMSKeyIDKey     = "kms-key-id"
+)
+
+var configSchema = environschema.Fields{
+	EndpointKey: {
+		Description: "The Secrets Manager endpoint, when not using the AWS endpoint for the region.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	},
+	STSEndpointKey: {
+		Description: "The STS endpoint used to issue agent credentials, when not using the AWS endpoint for the region.",
+		Type:        environschema.Tstring,
+	},
+	RegionKey: {
+		Description: "The AWS region in which to store secrets.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+		Mandatory:   true,
+	},
+	AccessKeyKey: {
+		Description: "The access key ID of static credentials. If not set, the credentials of the controller machine's instance profile are used, and a role arn is needed.",
+		Type:        environschema.Tstring,
+	},
+	SecretKeyKey: {
+		Description: "The secret access key of static credentials.",
+		Type:        environschema.Tstring,
+		Secret:      true,
+	},
+	SessionTokenKey: {
+		Description: "The session token of temporary credentials.",
+		Type:        environschema.Tstring,
+		Secret:      true,
+	},
+	RoleARNKey: {
+		Description: "The ARN of an IAM role to assume to access secrets. It is needed unless the static credentials are those of an IAM user.",
+		Type:        environschema.Tstring,
+	},
+	ExternalIDKey: {
+		Description: "The external ID to present when assuming the IAM role.",
+		Type:        environschema.Tstring,
+	},
+	KMSKeyIDKey: {
+		Description: "The KMS key used to encrypt secrets, if not the AWS managed key.",
+		Type:        environschema.Tstring,
+	},
+}
+
+var configDefaults = schema.Defaults{}
+
+type backendConfig struct {
+	validAttrs map[string]interface{}
+}
+
+func (c *backendConfig) endpoint() string {
+	v, _ := c.validAttrs[EndpointKey].(string)
+	return v
+}
+
+func (c *backendConfig) stsEndpoint() string {
+	v, _ := c.validAttrs[STSEndpointKey].(string)
+	return v
+}
+
+func (c *backendConfig) region() string {
+	return c.validAttrs[RegionKey].(string)
+}
+
+func (c *backendConfig) accessKey() string {
+	v, _ := c.validAttrs[AccessKeyKey].(string)
+	return v
+}
+
+func (c *backendConfig) secretKey() string {
+	v, _ := c.validAttrs[SecretKeyKey].(string)
+	return v
+}
+
+func (c *backendConfig) sessionToken() string {
+	v, _ := c.validAttrs[SessionTokenKey].(string)
+	return v
+}
+
+func (c *backendConfig) roleARN() string {
+	v, _ := c.validAttrs[RoleARNKey].(string)
+	return v
+}
+
+func (c *backendConfig) externalID() string {
+	v, _ := c.validAttrs[ExternalIDKey].(string)
+	return v
+}
+
+func (c *backendConfig) kmsKeyID() string {
+	v, _ := c.validAttrs[KMSKeyIDKey].(string)
+	return v
+}
+
+// ConfigSchema implements SecretBackendProvider.
+func (p awsProvider) ConfigSchema() environschema.Fields {
+	return configSchema
+}
+
+// ConfigDefaults implements SecretBackendProvider.
+func (p awsProvider) ConfigDefaults() schema.Defaults {
+	return configDefaults
+}
+
+// ValidateConfig implements SecretBackendProvider.
+func (p awsProvider) ValidateConfig(oldCfg, newCfg provider.ConfigAttrs, tokenRotateInterval *time.Duration) error {
+	newValidCfg, err := newConfig(newCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, endpoint := range []string{newValidCfg.endpoint(), newValidCfg.stsEndpoint()} {
+		if endpoint == "" {
+			continue
+		}
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.NotValidf("aws secrets manager endpoint %q", endpoint)
+		}
+	}
+
+	accessKey := newValidCfg.accessKey()
+	secretKey := newValidCfg.secretKey()
+	if accessKey != "" && secretKey == "" {
+		return errors.NotValidf("aws secrets manager config missing secret key")
+	}
+	if accessKey == "" && secretKey != "" {
+		return errors.NotValidf("aws secrets manager config missing access key")
+	}
+	if newValidCfg.sessionToken() != "" && accessKey == "" {
+		return errors.NotValidf("aws secrets manager config with session token but no access key")
+	}
+	if newValidCfg.externalID() != "" && newValidCfg.roleARN() == "" {
+		return errors.NotValidf("aws secrets manager config with external id but no role arn")
+	}
+	// Agent credentials are issued by assuming the role or, without
+	// one, by getting a federation token, which needs the long term
+	// credentials of an IAM user.
+	if newValidCfg.roleARN() == "" && (accessKey == "" || newValidCfg.sessionToken() != "") {
+		return errors.NotValidf("aws secrets manager config without role arn or long term access key")
+	}
+	if roleARN := newValidCfg.roleARN(); roleARN != "" && !arn.IsARN(roleARN) {
+		return errors.NotValidf("aws secrets manager role arn %q", roleARN)
+	}
+
+	if oldCfg == nil {
+		return nil
+	}
+	oldValidCfg, err := newConfig(oldCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for n, field := range configSchema {
+		if !field.Immutable {
+			continue
+		}
+		oldV := oldValidCfg.validAttrs[n]
+		newV := newValidCfg.validAttrs[n]
+		if oldV != newV {
+			return errors.Errorf("cannot change immutable field %q", n)
+		}
+	}
+	return nil
+}
+
+func newConfig(attrs map[string]interface{}) (*backendConfig, error) {
+	cfg, err := coreconfig.NewConfig(attrs, configSchema, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &backendConfig{cfg.Attributes()}, nil
+}
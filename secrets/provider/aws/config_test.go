@@ -0,0 +1,98 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package aws_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/secrets/provider"
+	_ "github.com/juju/juju/secrets/provider/all"
+	jujuaws "github.com/juju/juju/secrets/provider/aws"
+)
+
+type configSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&configSuite{})
+
+func (s *configSuite) TestValidateConfig(c *gc.C) {
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	configValidator, ok := p.(provider.ProviderConfig)
+	c.Assert(ok, jc.IsTrue)
+	for _, t := range []struct {
+		cfg    map[string]interface{}
+		oldCfg map[string]interface{}
+		err    string
+	}{{
+		cfg: map[string]interface{}{},
+		err: "region: expected string, got nothing",
+	}, {
+		cfg:    map[string]interface{}{"region": "us-east-1", "role-arn": "arn:aws:iam::123456789012:role/juju"},
+		oldCfg: map[string]interface{}{"region": "eu-west-1", "role-arn": "arn:aws:iam::123456789012:role/juju"},
+		err:    `cannot change immutable field "region"`,
+	}, {
+		cfg:    map[string]interface{}{"region": "us-east-1", "role-arn": "arn:aws:iam::123456789012:role/juju", "endpoint": "https://new"},
+		oldCfg: map[string]interface{}{"region": "us-east-1", "role-arn": "arn:aws:iam::123456789012:role/juju", "endpoint": "https://old"},
+		err:    `cannot change immutable field "endpoint"`,
+	}, {
+		cfg: map[string]interface{}{"region": "us-east-1", "endpoint": "localhost:8080"},
+		err: `aws secrets manager endpoint "localhost:8080" not valid`,
+	}, {
+		cfg: map[string]interface{}{"region": "us-east-1", "access-key": "aaa"},
+		err: `aws secrets manager config missing secret key not valid`,
+	}, {
+		cfg: map[string]interface{}{"region": "us-east-1", "secret-key": "aaa"},
+		err: `aws secrets manager config missing access key not valid`,
+	}, {
+		cfg: map[string]interface{}{"region": "us-east-1", "session-token": "aaa"},
+		err: `aws secrets manager config with session token but no access key not valid`,
+	}, {
+		cfg: map[string]interface{}{"region": "us-east-1", "external-id": "aaa"},
+		err: `aws secrets manager config with external id but no role arn not valid`,
+	}, {
+		cfg: map[string]interface{}{"region": "us-east-1"},
+		err: `aws secrets manager config without role arn or long term access key not valid`,
+	}, {
+		cfg: map[string]interface{}{"region": "us-east-1", "access-key": "aaa", "secret-key": "bbb", "session-token": "ccc"},
+		err: `aws secrets manager config without role arn or long term access key not valid`,
+	}, {
+		cfg: map[string]interface{}{"region": "us-east-1", "role-arn": "juju-secrets"},
+		err: `aws secrets manager role arn "juju-secrets" not valid`,
+	}} {
+		err = configValidator.ValidateConfig(t.oldCfg, t.cfg, nil)
+		c.Assert(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *configSuite) TestValidateConfigValid(c *gc.C) {
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	configValidator := p.(provider.ProviderConfig)
+	cfg := map[string]interface{}{
+		"region":      "us-east-1",
+		"endpoint":    "http://localhost:4566",
+		"access-key":  "AKIDEXAMPLE",
+		"secret-key":  "secret",
+		"role-arn":    "arn:aws:iam::123456789012:role/juju-secrets",
+		"external-id": "juju",
+	}
+	err = configValidator.ValidateConfig(cfg, cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *configSuite) TestValidateConfigInstanceProfile(c *gc.C) {
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	configValidator := p.(provider.ProviderConfig)
+	cfg := map[string]interface{}{
+		"region":   "us-east-1",
+		"role-arn": "arn:aws:iam::123456789012:role/juju-secrets",
+	}
+	err = configValidator.ValidateConfig(nil, cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,18 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package aws provides the AWS Secrets Manager secrets backend, which
+// also works with other services implementing the Secrets Manager API.
+//
+// Each secret revision is stored as a secret named
+// juju/<model-uuid>/<secret-id>-<revision>, tagged with the controller,
+// model and secret it belongs to. Agents are given temporary STS
+// credentials whose session policy allows them to read the secrets
+// named and tagged for their model, and to update and delete those
+// with the IDs of the secrets they own. Session policies are too small
+// to list the secrets each agent reads, and only list a few dozen
+// owned secrets, so agents owning more can't be given credentials.
+// The credentials in the backend config must themselves be allowed to
+// manage the secrets, and to assume the configured role or, for an IAM
+// user without a role, to get federation tokens.
+package aws
@@ -0,0 +1,156 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package aws
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// maxSessionPolicySize is the largest session policy, in characters,
+// which STS accepts.
+const maxSessionPolicySize = 2048
+
+type policyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []policyStatement `json:"Statement"`
+}
+
+type policyStatement struct {
+	Effect    string                         `json:"Effect"`
+	Action    []string                       `json:"Action"`
+	Resource  []string                       `json:"Resource"`
+	Condition map[string]map[string][]string `json:"Condition,omitempty"`
+}
+
+func allow(actions ...string) policyStatement {
+	return policyStatement{Effect: "Allow", Action: actions, Resource: []string{"*"}}
+}
+
+// on limits the statement to the given resources.
+func (s policyStatement) on(resources ...string) policyStatement {
+	s.Resource = resources
+	return s
+}
+
+func (s policyStatement) when(key string, values ...string) policyStatement {
+	return s.condition("StringEquals", key, values...)
+}
+
+// whenAbsent limits the statement to requests without the key, eg to
+// resources without a given tag.
+func (s policyStatement) whenAbsent(key string) policyStatement {
+	return s.condition("Null", key, "true")
+}
+
+func (s policyStatement) condition(operator, key string, values ...string) policyStatement {
+	if s.Condition == nil {
+		s.Condition = make(map[string]map[string][]string)
+	}
+	if s.Condition[operator] == nil {
+		s.Condition[operator] = make(map[string][]string)
+	}
+	s.Condition[operator][key] = values
+	return s
+}
+
+func resourceTag(key string) string {
+	return "secretsmanager:ResourceTag/" + key
+}
+
+// modelSecretsARN returns the ARN pattern matching all the secrets of
+// the model. Secrets Manager appends a random suffix to the secret
+// name in its ARN.
+func modelSecretsARN(partition, region, modelUUID string) string {
+	return "arn:" + partition + ":secretsmanager:" + region + ":*:secret:" + secretNamePrefix(modelUUID) + "*"
+}
+
+// onKMSKey limits the statement to the KMS key, which may be
+// given as a key ID, key ARN, alias name or alias ARN. Policies can
+// only match aliases with a condition, as KMS authorises requests
+// against the key they refer to.
+func (s policyStatement) onKMSKey(partition, region, kmsKeyID string) policyStatement {
+	alias := ""
+	switch {
+	case strings.HasPrefix(kmsKeyID, "alias/"):
+		alias = kmsKeyID
+	case strings.HasPrefix(kmsKeyID, "arn:"):
+		if _, name, ok := strings.Cut(kmsKeyID, ":alias/"); ok {
+			alias = "alias/" + name
+		} else {
+			return s.on(kmsKeyID)
+		}
+	default:
+		return s.on("arn:" + partition + ":kms:" + region + ":*:key/" + kmsKeyID)
+	}
+	return s.condition("ForAnyValue:StringEquals", "kms:ResourceAliases", alias)
+}
+
+// sessionPolicy returns the policy which limits the temporary
+// credentials issued to an agent to the secrets of its model. Secrets
+// are matched both by their names, which start with the model UUID,
+// and by the model tag they are created with.
+//
+// Agents may only update and delete the secrets they own, which are
+// matched by their secret ID tags. As STS limits session policies to
+// 2048 characters, the policy doesn't name the secrets agents read:
+// they may read any of the model's secrets, but the controller only
+// gives them the revision IDs of the secrets they own or consume. The
+// drain worker may update any of the model's secrets, as it may need
+// to update content which it saved before being restarted.
+//
+// Agents can only tag secrets which aren't tagged yet, ie as they
+// create them, as they could otherwise give a secret of another model
+// the tags of their own.
+func sessionPolicy(partition, region, modelUUID, kmsKeyID string, admin, forDrain bool, owned []string) (string, error) {
+	modelSecrets := modelSecretsARN(partition, region, modelUUID)
+	statements := []policyStatement{allow(
+		"secretsmanager:GetSecretValue", "secretsmanager:DescribeSecret",
+	).on(modelSecrets).when(resourceTag(modelUUIDTag), modelUUID)}
+	if forDrain {
+		statements = append(statements, allow(
+			"secretsmanager:PutSecretValue",
+		).on(modelSecrets).when(resourceTag(modelUUIDTag), modelUUID))
+	}
+	if len(owned) > 0 {
+		// Secret IDs are unique, so the model's secrets with the
+		// ID tags of the owned secrets are those secrets.
+		statements = append(statements, allow(
+			"secretsmanager:PutSecretValue", "secretsmanager:DeleteSecret",
+		).on(modelSecrets).when(resourceTag(secretIDTag), owned...))
+	}
+	if !admin {
+		// Agents can create new secrets in the model. Creating a
+		// secret with tags also needs TagResource, which is only
+		// allowed for the new, untagged, secret.
+		statements = append(statements, allow(
+			"secretsmanager:CreateSecret", "secretsmanager:TagResource",
+		).on(modelSecrets).when("aws:RequestTag/"+modelUUIDTag, modelUUID).
+			whenAbsent(resourceTag(modelUUIDTag)))
+	}
+	if kmsKeyID != "" {
+		// Secrets encrypted with a customer managed key also need
+		// the key, but only for use by Secrets Manager on the
+		// model's secrets. The DNS suffix of the service depends
+		// on the partition.
+		statements = append(statements, allow(
+			"kms:Decrypt", "kms:GenerateDataKey",
+		).onKMSKey(partition, region, kmsKeyID).
+			condition("StringLike", "kms:ViaService", "secretsmanager."+region+".*").
+			condition("StringLike", "kms:EncryptionContext:SecretARN", modelSecrets))
+	}
+	data, err := json.Marshal(policyDocument{
+		Version:   "2012-10-17",
+		Statement: statements,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(data) > maxSessionPolicySize {
+		return "", errors.NotSupportedf("session policy for %d owned secrets", len(owned))
+	}
+	return string(data), nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package aws
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/secrets/provider"
+)
+
+var logger = loggo.GetLogger("juju.secrets.aws")
+
+const (
+	// BackendType is the type of the AWS Secrets Manager secrets backend.
+	BackendType = "aws-secrets-manager"
+
+	// restrictedCredentialsDuration is how long the credentials
+	// issued to agents are valid for. It's the shortest duration
+	// STS allows.
+	restrictedCredentialsDuration = 15 * time.Minute
+)
+
+// NewProvider returns an AWS Secrets Manager secrets provider.
+func NewProvider() provider.SecretBackendProvider {
+	return awsProvider{}
+}
+
+type awsProvider struct {
+}
+
+func (p awsProvider) Type() string {
+	return BackendType
+}
+
+// Initialise is not used.
+func (p awsProvider) Initialise(*provider.ModelBackendConfig) error {
+	return nil
+}
+
+// CleanupModel deletes all secrets associated with the model.
+func (p awsProvider) CleanupModel(cfg *provider.ModelBackendConfig) error {
+	backend, err := p.newBackend(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx := context.Background()
+	paginator := secretsmanager.NewListSecretsPaginator(backend.client, &secretsmanager.ListSecretsInput{
+		Filters: []types.Filter{{
+			Key:    types.FilterNameStringTypeName,
+			Values: []string{secretNamePrefix(cfg.ModelUUID)},
+		}},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return errors.Annotatef(err, "listing secrets for model %q", cfg.ModelUUID)
+		}
+		for _, s := range page.SecretList {
+			name := awssdk.ToString(s.Name)
+			err := backend.DeleteContent(ctx, name)
+			if err != nil && !errors.Is(err, errors.NotFound) {
+				return errors.Annotatef(err, "deleting secret %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+// CleanupSecrets is not used; the access to secrets is granted by
+// the policies of credentials issued in RestrictedConfig, which
+// expire by themselves.
+func (p awsProvider) CleanupSecrets(*provider.ModelBackendConfig, names.Tag, provider.SecretRevisions) error {
+	return nil
+}
+
+// RestrictedConfig returns the config needed to create a
+// secrets backend client restricted to manage the specified
+// owned secrets and read shared secrets for the given entity tag.
+func (p awsProvider) RestrictedConfig(
+	adminCfg *provider.ModelBackendConfig, sameController, forDrain bool, tag names.Tag, owned provider.SecretRevisions, read provider.SecretRevisions,
+) (_ *provider.BackendConfig, err error) {
+	defer func() {
+		err = maybePermissionDenied(err)
+	}()
+
+	validCfg, err := newConfig(adminCfg.Config)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid aws secrets manager config")
+	}
+	logger.Debugf("owned secrets: %#v", owned)
+	logger.Debugf("consumed secrets: %#v", read)
+	if validCfg.roleARN() == "" && (validCfg.accessKey() == "" || validCfg.sessionToken() != "") {
+		// Rejected by ValidateConfig, as temporary credentials
+		// such as those of an instance profile can't get
+		// federation tokens.
+		return nil, errors.NotSupportedf("issuing secret access credentials without a role arn or long term access key")
+	}
+
+	sessionName := "juju-controller"
+	if tag != nil {
+		sessionName = "juju-" + tag.String()
+	}
+
+	awsCfg, err := loadConfig(validCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := newSTSClient(awsCfg, validCfg)
+	ctx := context.Background()
+	partition, err := awsPartition(ctx, client, validCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ownedIDs := make([]string, 0, len(owned))
+	for id := range owned {
+		ownedIDs = append(ownedIDs, id)
+	}
+	sort.Strings(ownedIDs)
+	policy, err := sessionPolicy(
+		partition, validCfg.region(), adminCfg.ModelUUID, validCfg.kmsKeyID(), tag == nil, forDrain, ownedIDs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Tracef("session policy: %s", policy)
+
+	duration := awssdk.Int32(int32(restrictedCredentialsDuration.Seconds()))
+	var creds *ststypes.Credentials
+	if validCfg.roleARN() != "" {
+		input := &sts.AssumeRoleInput{
+			RoleArn:         awssdk.String(validCfg.roleARN()),
+			RoleSessionName: awssdk.String(truncate(sessionName, 64)),
+			Policy:          awssdk.String(policy),
+			DurationSeconds: duration,
+		}
+		if externalID := validCfg.externalID(); externalID != "" {
+			input.ExternalId = awssdk.String(externalID)
+		}
+		out, err := client.AssumeRole(ctx, input)
+		if err != nil {
+			return nil, errors.Annotate(err, "assuming role for secret access")
+		}
+		creds = out.Credentials
+	} else {
+		out, err := client.GetFederationToken(ctx, &sts.GetFederationTokenInput{
+			Name:            awssdk.String(truncate(sessionName, 32)),
+			Policy:          awssdk.String(policy),
+			DurationSeconds: duration,
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "creating secret access credentials")
+		}
+		creds = out.Credentials
+	}
+	if creds == nil {
+		return nil, errors.New("no secret access credentials issued")
+	}
+
+	cfg := adminCfg.BackendConfig
+	cfg.Config = make(provider.ConfigAttrs)
+	for k, v := range adminCfg.Config {
+		cfg.Config[k] = v
+	}
+	delete(cfg.Config, RoleARNKey)
+	delete(cfg.Config, ExternalIDKey)
+	cfg.Config[AccessKeyKey] = awssdk.ToString(creds.AccessKeyId)
+	cfg.Config[SecretKeyKey] = awssdk.ToString(creds.SecretAccessKey)
+	cfg.Config[SessionTokenKey] = awssdk.ToString(creds.SessionToken)
+	return &cfg, nil
+}
+
+// awsPartition returns the partition of the account holding the
+// secrets, eg "aws" or "aws-cn", from the role ARN if there is one or
+// else from the identity of the credentials.
+func awsPartition(ctx context.Context, client *sts.Client, cfg *backendConfig) (string, error) {
+	callerARN := cfg.roleARN()
+	if callerARN == "" {
+		out, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return "", errors.Annotate(err, "getting caller identity")
+		}
+		callerARN = awssdk.ToString(out.Arn)
+	}
+	parsed, err := arn.Parse(callerARN)
+	if err != nil {
+		return "", errors.Annotatef(err, "parsing arn %q", callerARN)
+	}
+	return parsed.Partition, nil
+}
+
+// NewBackend returns an AWS Secrets Manager backed secrets backend client.
+func (p awsProvider) NewBackend(cfg *provider.ModelBackendConfig) (provider.SecretsBackend, error) {
+	return p.newBackend(cfg)
+}
+
+func (p awsProvider) newBackend(cfg *provider.ModelBackendConfig) (*awsBackend, error) {
+	validCfg, err := newConfig(cfg.Config)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid aws secrets manager config")
+	}
+	awsCfg, err := loadConfig(validCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if roleARN := validCfg.roleARN(); roleARN != "" {
+		roleProvider := stscreds.NewAssumeRoleProvider(newSTSClient(awsCfg, validCfg), roleARN,
+			func(o *stscreds.AssumeRoleOptions) {
+				if externalID := validCfg.externalID(); externalID != "" {
+					o.ExternalID = awssdk.String(externalID)
+				}
+			})
+		awsCfg.Credentials = awssdk.NewCredentialsCache(roleProvider)
+	}
+	client := secretsmanager.NewFromConfig(awsCfg, func(o *secretsmanager.Options) {
+		if endpoint := validCfg.endpoint(); endpoint != "" {
+			o.BaseEndpoint = awssdk.String(endpoint)
+		}
+	})
+	return &awsBackend{
+		controllerUUID: cfg.ControllerUUID,
+		modelUUID:      cfg.ModelUUID,
+		kmsKeyID:       validCfg.kmsKeyID(),
+		client:         client,
+	}, nil
+}
+
+// loadConfig returns the AWS config using the static credentials
+// from the backend config, if any, or the default credentials chain,
+// eg the controller machine's instance profile.
+func loadConfig(cfg *backendConfig) (awssdk.Config, error) {
+	opts := []func(*config.LoadOptions) error{
+		config.WithRegion(cfg.region()),
+	}
+	if accessKey := cfg.accessKey(); accessKey != "" {
+		opts = append(opts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(accessKey, cfg.secretKey(), cfg.sessionToken()),
+		))
+	}
+	awsCfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return awssdk.Config{}, errors.Annotate(err, "loading aws config")
+	}
+	return awsCfg, nil
+}
+
+func newSTSClient(awsCfg awssdk.Config, cfg *backendConfig) *sts.Client {
+	return sts.NewFromConfig(awsCfg, func(o *sts.Options) {
+		if endpoint := cfg.stsEndpoint(); endpoint != "" {
+			o.BaseEndpoint = awssdk.String(endpoint)
+		}
+	})
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
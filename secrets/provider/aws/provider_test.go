@@ -0,0 +1,470 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package aws_test
+
+import (
+	"context"
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets"
+	"github.com/juju/juju/secrets/provider"
+	_ "github.com/juju/juju/secrets/provider/all"
+	jujuaws "github.com/juju/juju/secrets/provider/aws"
+	awstesting "github.com/juju/juju/secrets/provider/aws/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const (
+	accessKey = "AKIDEXAMPLE"
+	modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+)
+
+type providerSuite struct {
+	testing.IsolationSuite
+	coretesting.JujuOSEnvSuite
+
+	server *awstesting.Server
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.JujuOSEnvSuite.SetUpTest(c)
+	s.server = awstesting.NewServer(accessKey)
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *providerSuite) TearDownTest(c *gc.C) {
+	s.JujuOSEnvSuite.TearDownTest(c)
+	s.IsolationSuite.TearDownTest(c)
+}
+
+func (s *providerSuite) adminConfig(extra map[string]interface{}) *provider.ModelBackendConfig {
+	cfg := provider.ConfigAttrs{
+		"endpoint":     s.server.URL,
+		"sts-endpoint": s.server.URL,
+		"region":       "us-east-1",
+		"access-key":   accessKey,
+		"secret-key":   "secret",
+	}
+	for k, v := range extra {
+		cfg[k] = v
+	}
+	return &provider.ModelBackendConfig{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		ModelUUID:      modelUUID,
+		ModelName:      "fred",
+		BackendConfig: provider.BackendConfig{
+			BackendType: jujuaws.BackendType,
+			Config:      cfg,
+		},
+	}
+}
+
+func (s *providerSuite) newBackend(c *gc.C, cfg *provider.ModelBackendConfig) provider.SecretsBackend {
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	b, err := p.NewBackend(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return b
+}
+
+func (s *providerSuite) restrictedBackend(c *gc.C, adminCfg *provider.ModelBackendConfig, tag names.Tag, owned, read provider.SecretRevisions) provider.SecretsBackend {
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := p.RestrictedConfig(adminCfg, true, false, tag, owned, read)
+	c.Assert(err, jc.ErrorIsNil)
+	modelCfg := *adminCfg
+	modelCfg.BackendConfig = *cfg
+	return s.newBackend(c, &modelCfg)
+}
+
+func (s *providerSuite) TestSaveGetDeleteContent(c *gc.C) {
+	b := s.newBackend(c, s.adminConfig(nil))
+	ctx := context.Background()
+	uri := coresecrets.NewURI()
+
+	revisionID, err := b.SaveContent(ctx, uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisionID, gc.Equals, fmt.Sprintf("juju/%s/%s-1", modelUUID, uri.ID))
+
+	secret, ok := s.server.Secret(revisionID)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(secret.Value, gc.Equals, `{"foo":"YmFy"}`)
+	c.Check(secret.Tags, jc.DeepEquals, map[string]string{
+		"juju-controller-uuid": coretesting.ControllerTag.Id(),
+		"juju-model-uuid":      modelUUID,
+		"juju-secret-id":       uri.ID,
+		"juju-secret-revision": "1",
+	})
+
+	val, err := b.GetContent(ctx, revisionID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+
+	err = b.DeleteContent(ctx, revisionID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.Names(), gc.HasLen, 0)
+
+	_, err = b.GetContent(ctx, revisionID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = b.DeleteContent(ctx, revisionID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *providerSuite) TestSaveContentOverwritesExisting(c *gc.C) {
+	b := s.newBackend(c, s.adminConfig(nil))
+	ctx := context.Background()
+	uri := coresecrets.NewURI()
+
+	_, err := b.SaveContent(ctx, uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+	revisionID, err := b.SaveContent(ctx, uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmF6"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	val, err := b.GetContent(ctx, revisionID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmF6"})
+}
+
+func (s *providerSuite) TestSaveContentKMSKey(c *gc.C) {
+	b := s.newBackend(c, s.adminConfig(map[string]interface{}{"kms-key-id": "alias/juju"}))
+	revisionID, err := b.SaveContent(context.Background(), coresecrets.NewURI(), 1,
+		coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+	secret, _ := s.server.Secret(revisionID)
+	c.Assert(secret.KMSKeyID, gc.Equals, "alias/juju")
+}
+
+func (s *providerSuite) TestPing(c *gc.C) {
+	b := s.newBackend(c, s.adminConfig(nil))
+	c.Assert(b.Ping(), jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestPingInvalidCredentials(c *gc.C) {
+	b := s.newBackend(c, s.adminConfig(map[string]interface{}{"access-key": "AKIDOTHER"}))
+	err := b.Ping()
+	c.Assert(err, gc.ErrorMatches, "backend not reachable: .*UnrecognizedClientException.*")
+}
+
+func (s *providerSuite) TestCleanupModel(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		s.server.SetSecret(fmt.Sprintf("juju/%s/secret%d-1", modelUUID, i), awstesting.Secret{Value: "{}"})
+	}
+	s.server.SetSecret("juju/other-model/secret0-1", awstesting.Secret{Value: "{}"})
+	s.server.SetSecret("not-juju", awstesting.Secret{Value: "{}"})
+
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.CleanupModel(s.adminConfig(nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.Names(), jc.DeepEquals, []string{"juju/other-model/secret0-1", "not-juju"})
+}
+
+func (s *providerSuite) TestRestrictedConfigFederationToken(c *gc.C) {
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	owned := provider.SecretRevisions{}
+	owned.Add(coresecrets.NewURI(), "rev-1")
+	cfg, err := p.RestrictedConfig(s.adminConfig(nil), true, false, names.NewUnitTag("mysql/0"), owned, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sessions := s.server.Sessions()
+	c.Assert(sessions, gc.HasLen, 1)
+	c.Check(sessions[0].Action, gc.Equals, "GetFederationToken")
+	c.Check(sessions[0].Name, gc.Equals, "juju-unit-mysql-0")
+	c.Check(sessions[0].Policy, gc.Not(gc.Equals), "")
+
+	c.Check(cfg.BackendType, gc.Equals, jujuaws.BackendType)
+	c.Check(cfg.Config["access-key"], gc.Equals, sessions[0].AccessKey)
+	c.Check(cfg.Config["secret-key"], gc.Equals, "secret-"+sessions[0].AccessKey)
+	c.Check(cfg.Config["session-token"], gc.Not(gc.Equals), "")
+	c.Check(cfg.Config["region"], gc.Equals, "us-east-1")
+}
+
+func (s *providerSuite) TestRestrictedConfigAssumeRole(c *gc.C) {
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	adminCfg := s.adminConfig(map[string]interface{}{
+		"role-arn":    "arn:aws:iam::123456789012:role/juju-secrets",
+		"external-id": "juju",
+	})
+	cfg, err := p.RestrictedConfig(adminCfg, true, false, names.NewUnitTag("mysql/0"), nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sessions := s.server.Sessions()
+	c.Assert(sessions, gc.HasLen, 1)
+	c.Check(sessions[0].Action, gc.Equals, "AssumeRole")
+	c.Check(sessions[0].Name, gc.Equals, "juju-unit-mysql-0")
+	c.Check(sessions[0].RoleARN, gc.Equals, "arn:aws:iam::123456789012:role/juju-secrets")
+	c.Check(sessions[0].ExternalID, gc.Equals, "juju")
+
+	c.Check(cfg.Config["access-key"], gc.Equals, sessions[0].AccessKey)
+	_, ok := cfg.Config["role-arn"]
+	c.Check(ok, jc.IsFalse)
+	_, ok = cfg.Config["external-id"]
+	c.Check(ok, jc.IsFalse)
+	// The admin config is not changed.
+	c.Check(adminCfg.Config["access-key"], gc.Equals, accessKey)
+}
+
+func (s *providerSuite) TestRestrictedConfigNotSupported(c *gc.C) {
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	adminCfg := s.adminConfig(map[string]interface{}{"session-token": "token"})
+	_, err = p.RestrictedConfig(adminCfg, true, false, names.NewUnitTag("mysql/0"), nil, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *providerSuite) TestRestrictedConfigAgentAccess(c *gc.C) {
+	admin := s.newBackend(c, s.adminConfig(nil))
+	ctx := context.Background()
+	save := func(b provider.SecretsBackend, uri *coresecrets.URI) (string, error) {
+		return b.SaveContent(ctx, uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	}
+
+	ownedURI := coresecrets.NewURI()
+	readURI := coresecrets.NewURI()
+	ownedID, err := save(admin, ownedURI)
+	c.Assert(err, jc.ErrorIsNil)
+	readID, err := save(admin, readURI)
+	c.Assert(err, jc.ErrorIsNil)
+
+	owned := provider.SecretRevisions{}
+	owned.Add(ownedURI, ownedID)
+	read := provider.SecretRevisions{}
+	read.Add(readURI, readID)
+	agent := s.restrictedBackend(c, s.adminConfig(nil), names.NewUnitTag("mysql/0"), owned, read)
+
+	// Owned secrets can be read, updated and deleted.
+	_, err = agent.GetContent(ctx, ownedID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = save(agent, ownedURI)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Consumed secrets can be read.
+	_, err = agent.GetContent(ctx, readID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// New secrets can be created in the model.
+	newID, err := save(agent, coresecrets.NewURI())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newID, jc.HasPrefix, "juju/"+modelUUID+"/")
+
+	// Consumed secrets can't be updated or deleted.
+	_, err = save(agent, readURI)
+	c.Assert(err, jc.ErrorIs, secrets.PermissionDenied)
+	err = agent.DeleteContent(ctx, readID)
+	c.Assert(err, jc.ErrorIs, secrets.PermissionDenied)
+
+	err = agent.DeleteContent(ctx, ownedID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.server.Secret(ownedID)
+	c.Assert(ok, jc.IsFalse)
+
+	// Agents which don't own secrets can only read them.
+	consumer := s.restrictedBackend(c, s.adminConfig(nil), names.NewUnitTag("wordpress/0"), nil, read)
+	_, err = consumer.GetContent(ctx, readID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = save(consumer, readURI)
+	c.Assert(err, jc.ErrorIs, secrets.PermissionDenied)
+	err = consumer.DeleteContent(ctx, readID)
+	c.Assert(err, jc.ErrorIs, secrets.PermissionDenied)
+}
+
+func (s *providerSuite) TestRestrictedConfigManySecrets(c *gc.C) {
+	owned := provider.SecretRevisions{}
+	read := provider.SecretRevisions{}
+	for i := 0; i < 100; i++ {
+		if i < 10 {
+			owned.Add(coresecrets.NewURI(), fmt.Sprintf("owned-%d", i))
+		}
+		read.Add(coresecrets.NewURI(), fmt.Sprintf("read-%d", i))
+	}
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.RestrictedConfig(s.adminConfig(map[string]interface{}{"kms-key-id": "alias/juju"}),
+		true, false, names.NewUnitTag("mysql/0"), owned, read)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sessions := s.server.Sessions()
+	c.Assert(sessions, gc.HasLen, 1)
+	c.Check(len(sessions[0].Policy) <= 2048, jc.IsTrue)
+
+	// The session policy lists the owned secrets, so only so many
+	// of them fit.
+	for i := 10; i < 100; i++ {
+		owned.Add(coresecrets.NewURI(), fmt.Sprintf("owned-%d", i))
+	}
+	_, err = p.RestrictedConfig(s.adminConfig(map[string]interface{}{"kms-key-id": "alias/juju"}),
+		true, false, names.NewUnitTag("mysql/0"), owned, read)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *providerSuite) TestRestrictedConfigKMSKey(c *gc.C) {
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	for i, keyID := range []string{
+		"1234abcd-12ab-34cd-56ef-1234567890ab",
+		"arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		"alias/juju",
+		"arn:aws:kms:us-east-1:123456789012:alias/juju",
+	} {
+		c.Logf("test %d: %s", i, keyID)
+		_, err = p.RestrictedConfig(s.adminConfig(map[string]interface{}{"kms-key-id": keyID}),
+			true, false, names.NewUnitTag("mysql/0"), nil, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	sessions := s.server.Sessions()
+	c.Assert(sessions, gc.HasLen, 4)
+	for _, session := range sessions {
+		// The key may only be used by Secrets Manager for the
+		// model's secrets.
+		c.Check(session.Policy, jc.Contains,
+			`"kms:EncryptionContext:SecretARN":["arn:aws:secretsmanager:us-east-1:*:secret:juju/`+modelUUID+`/*"]`)
+	}
+	key := "arn:aws:kms:us-east-1:*:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	c.Check(sessions[0].Policy, jc.Contains, `"Resource":["`+key+`"]`)
+	key = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	c.Check(sessions[1].Policy, jc.Contains, `"Resource":["`+key+`"]`)
+	c.Check(sessions[2].Policy, jc.Contains, `"kms:ResourceAliases":["alias/juju"]`)
+	c.Check(sessions[3].Policy, jc.Contains, `"kms:ResourceAliases":["alias/juju"]`)
+}
+
+func (s *providerSuite) TestRestrictedConfigPartition(c *gc.C) {
+	s.server.Partition = "aws-cn"
+	admin := s.newBackend(c, s.adminConfig(nil))
+	ctx := context.Background()
+	revisionID, err := admin.SaveContent(ctx, coresecrets.NewURI(), 1,
+		coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	read := provider.SecretRevisions{}
+	read.Add(coresecrets.NewURI(), revisionID)
+	agent := s.restrictedBackend(c, s.adminConfig(nil), names.NewUnitTag("mysql/0"), nil, read)
+	_, err = agent.GetContent(ctx, revisionID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.server.Sessions()[0].Policy, jc.Contains, `"arn:aws-cn:secretsmanager:us-east-1:*:secret:juju/`)
+}
+
+func (s *providerSuite) TestRestrictedConfigCannotRetagSecrets(c *gc.C) {
+	admin := s.newBackend(c, s.adminConfig(nil))
+	ctx := context.Background()
+	ownedURI := coresecrets.NewURI()
+	otherURI := coresecrets.NewURI()
+	ownedID, err := admin.SaveContent(ctx, ownedURI, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+	otherID, err := admin.SaveContent(ctx, otherURI, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	owned := provider.SecretRevisions{}
+	owned.Add(ownedURI, ownedID)
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := p.RestrictedConfig(s.adminConfig(nil), true, false, names.NewUnitTag("mysql/0"), owned, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The agent tries to give a secret it doesn't own the tags of
+	// one it does, using its credentials directly.
+	client := secretsmanager.New(secretsmanager.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: awssdk.String(s.server.URL),
+		Credentials: credentials.NewStaticCredentialsProvider(
+			cfg.Config[jujuaws.AccessKeyKey].(string),
+			cfg.Config[jujuaws.SecretKeyKey].(string),
+			cfg.Config[jujuaws.SessionTokenKey].(string),
+		),
+	})
+	_, err = client.TagResource(ctx, &secretsmanager.TagResourceInput{
+		SecretId: awssdk.String(otherID),
+		Tags: []types.Tag{
+			{Key: awssdk.String("juju-model-uuid"), Value: awssdk.String(modelUUID)},
+			{Key: awssdk.String("juju-secret-id"), Value: awssdk.String(ownedURI.ID)},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, ".*AccessDeniedException.*")
+	secret, ok := s.server.Secret(otherID)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(secret.Tags["juju-secret-id"], gc.Equals, otherURI.ID)
+}
+
+func (s *providerSuite) TestRestrictedConfigScopedToModel(c *gc.C) {
+	otherModelCfg := s.adminConfig(nil)
+	otherModelCfg.ModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+	uri := coresecrets.NewURI()
+	otherID, err := s.newBackend(c, otherModelCfg).SaveContent(
+		context.Background(), uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The agent owns a secret with the same ID, but in another model.
+	owned := provider.SecretRevisions{}
+	owned.Add(uri, otherID)
+	agent := s.restrictedBackend(c, s.adminConfig(nil), names.NewUnitTag("mysql/0"), owned, nil)
+	_, err = agent.GetContent(context.Background(), otherID)
+	c.Assert(err, jc.ErrorIs, secrets.PermissionDenied)
+}
+
+func (s *providerSuite) TestRestrictedConfigAdmin(c *gc.C) {
+	admin := s.newBackend(c, s.adminConfig(nil))
+	ctx := context.Background()
+	revisionID, err := admin.SaveContent(ctx, coresecrets.NewURI(), 1,
+		coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	user := s.restrictedBackend(c, s.adminConfig(nil), nil, nil, nil)
+	_, err = user.GetContent(ctx, revisionID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = user.SaveContent(ctx, coresecrets.NewURI(), 1,
+		coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIs, secrets.PermissionDenied)
+	c.Check(s.server.Sessions()[0].Name, gc.Equals, "juju-controller")
+}
+
+func (s *providerSuite) TestRestrictedConfigForDrain(c *gc.C) {
+	admin := s.newBackend(c, s.adminConfig(nil))
+	ctx := context.Background()
+	uri := coresecrets.NewURI()
+	_, err := admin.SaveContent(ctx, uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	p, err := provider.Provider(jujuaws.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	adminCfg := s.adminConfig(nil)
+	cfg, err := p.RestrictedConfig(adminCfg, true, true, names.NewUnitTag("mysql/0"), nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	adminCfg.BackendConfig = *cfg
+	drain := s.newBackend(c, adminCfg)
+
+	// Content saved before a restart can be updated.
+	_, err = drain.SaveContent(ctx, uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmF6"}))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestNewBackendAssumesRole(c *gc.C) {
+	b := s.newBackend(c, s.adminConfig(map[string]interface{}{
+		"role-arn":    "arn:aws:iam::123456789012:role/juju-secrets",
+		"external-id": "juju",
+	}))
+	c.Assert(b.Ping(), jc.ErrorIsNil)
+
+	sessions := s.server.Sessions()
+	c.Assert(sessions, gc.HasLen, 1)
+	c.Check(sessions[0].Action, gc.Equals, "AssumeRole")
+	c.Check(sessions[0].RoleARN, gc.Equals, "arn:aws:iam::123456789012:role/juju-secrets")
+	c.Check(sessions[0].ExternalID, gc.Equals, "juju")
+	c.Check(sessions[0].Policy, gc.Equals, "")
+}
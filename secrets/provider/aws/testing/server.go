@@ -0,0 +1,513 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSessionPolicySize is the largest session policy, in characters,
+// accepted by STS.
+const maxSessionPolicySize = 2048
+
+// Secret is a secret held by a Server.
+type Secret struct {
+	Value    string
+	Tags     map[string]string
+	KMSKeyID string
+}
+
+// Session records the temporary credentials issued by a Server.
+type Session struct {
+	// Action is AssumeRole or GetFederationToken.
+	Action string
+
+	// Name is the role session name or federated user name.
+	Name string
+
+	RoleARN    string
+	ExternalID string
+	Policy     string
+	AccessKey  string
+}
+
+// Server is an in-memory stand-in for AWS Secrets Manager and the
+// parts of STS used to issue temporary credentials. Request
+// signatures are not checked, but requests must use the access key of
+// the server's credentials or of the temporary credentials it issued,
+// and the session policies of temporary credentials are enforced for
+// the resource ARNs and StringEquals and Null conditions on tags used
+// by the secrets backend.
+type Server struct {
+	*httptest.Server
+
+	// Partition is the partition of the ARNs of the account and its
+	// secrets, "aws" by default.
+	Partition string
+
+	mu          sync.Mutex
+	secrets     map[string]Secret
+	credentials map[string]*credential
+	sessions    []Session
+	nextID      int
+}
+
+type credential struct {
+	sessionToken string
+	// policy is the session policy of temporary credentials, if
+	// any; other credentials may do anything.
+	policy *policy
+}
+
+// NewServer returns a running Server which accepts the given long
+// term access key.
+func NewServer(accessKey string) *Server {
+	s := &Server{
+		Partition: "aws",
+		secrets:   make(map[string]Secret),
+		credentials: map[string]*credential{
+			accessKey: {},
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *Server) arnPrefix() string {
+	return "arn:" + s.Partition + ":secretsmanager:us-east-1:123456789012:secret:"
+}
+
+// Secret returns the named secret, if it exists.
+func (s *Server) Secret(name string) (Secret, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secret, ok := s.secrets[name]
+	return secret, ok
+}
+
+// SetSecret stores a secret, replacing any with the same name.
+func (s *Server) SetSecret(name string, secret Secret) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secrets[name] = secret
+}
+
+// Names returns the names of the secrets held, sorted.
+func (s *Server) Names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedNames()
+}
+
+// Sessions returns the temporary credentials issued, in order.
+func (s *Server) Sessions() []Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Session(nil), s.sessions...)
+}
+
+func (s *Server) sortedNames() []string {
+	names := make([]string, 0, len(s.secrets))
+	for name := range s.secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target := r.Header.Get("X-Amz-Target")
+	isSecretsManager := strings.HasPrefix(target, "secretsmanager.")
+	cred, ok := s.credentials[accessKey(r)]
+	if !ok || cred.sessionToken != r.Header.Get("X-Amz-Security-Token") {
+		if isSecretsManager {
+			writeJSONError(w, "UnrecognizedClientException", "The security token included in the request is invalid.")
+		} else {
+			writeXMLError(w, http.StatusForbidden, "InvalidClientTokenId", "The security token included in the request is invalid.")
+		}
+		return
+	}
+	if isSecretsManager {
+		s.serveSecretsManager(w, r, cred, strings.TrimPrefix(target, "secretsmanager."))
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeXMLError(w, http.StatusBadRequest, "MalformedInput", err.Error())
+		return
+	}
+	s.serveSTS(w, r, cred)
+}
+
+// accessKey returns the access key from the request's signature.
+func accessKey(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	i := strings.Index(auth, "Credential=")
+	if i < 0 {
+		return ""
+	}
+	key, _, _ := strings.Cut(auth[i+len("Credential="):], "/")
+	return key
+}
+
+type tag struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
+}
+
+type secretsManagerRequest struct {
+	Name                       string `json:"Name"`
+	SecretId                   string `json:"SecretId"`
+	SecretString               string `json:"SecretString"`
+	KmsKeyId                   string `json:"KmsKeyId"`
+	Tags                       []tag  `json:"Tags"`
+	ForceDeleteWithoutRecovery bool   `json:"ForceDeleteWithoutRecovery"`
+	Filters                    []struct {
+		Key    string   `json:"Key"`
+		Values []string `json:"Values"`
+	} `json:"Filters"`
+	MaxResults int    `json:"MaxResults"`
+	NextToken  string `json:"NextToken"`
+}
+
+func (s *Server) serveSecretsManager(w http.ResponseWriter, r *http.Request, cred *credential, op string) {
+	var req secretsManagerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, "InvalidRequestException", err.Error())
+		return
+	}
+	name := strings.TrimPrefix(req.SecretId, s.arnPrefix())
+	secret, exists := s.secrets[name]
+
+	// Check the session policy, if any.
+	resource := "*"
+	context := make(map[string]string)
+	if op == "CreateSecret" || op == "TagResource" {
+		for _, t := range req.Tags {
+			context["aws:RequestTag/"+t.Key] = t.Value
+		}
+	}
+	if op == "CreateSecret" {
+		resource = s.arnPrefix() + req.Name
+	} else if exists {
+		resource = s.arnPrefix() + name
+		for k, v := range secret.Tags {
+			context["secretsmanager:ResourceTag/"+k] = v
+		}
+	}
+	if cred.policy != nil && (exists || op == "CreateSecret" || op == "ListSecrets") {
+		actions := []string{op}
+		if op == "CreateSecret" && len(req.Tags) > 0 {
+			// Tagging a secret as it's created also needs TagResource.
+			actions = append(actions, "TagResource")
+		}
+		for _, action := range actions {
+			if !cred.policy.allows("secretsmanager:"+action, resource, context) {
+				writeJSONError(w, "AccessDeniedException", fmt.Sprintf("not authorized to perform secretsmanager:%s", action))
+				return
+			}
+		}
+	}
+
+	switch op {
+	case "CreateSecret":
+		if _, ok := s.secrets[req.Name]; ok {
+			writeJSONError(w, "ResourceExistsException", fmt.Sprintf("the secret %s already exists", req.Name))
+			return
+		}
+		tags := make(map[string]string)
+		for _, t := range req.Tags {
+			tags[t.Key] = t.Value
+		}
+		s.secrets[req.Name] = Secret{Value: req.SecretString, Tags: tags, KMSKeyID: req.KmsKeyId}
+		s.writeJSON(w, map[string]interface{}{
+			"ARN": s.arnPrefix() + req.Name, "Name": req.Name, "VersionId": s.newID(),
+		})
+		return
+	case "ListSecrets":
+		s.listSecrets(w, req)
+		return
+	case "GetSecretValue", "PutSecretValue", "DeleteSecret", "TagResource":
+	default:
+		writeJSONError(w, "InvalidAction", fmt.Sprintf("operation %s not supported", op))
+		return
+	}
+
+	if !exists {
+		writeJSONError(w, "ResourceNotFoundException", "Secrets Manager can't find the specified secret.")
+		return
+	}
+	switch op {
+	case "GetSecretValue":
+		s.writeJSON(w, map[string]interface{}{
+			"ARN": s.arnPrefix() + name, "Name": name, "SecretString": secret.Value, "VersionId": s.newID(),
+		})
+	case "PutSecretValue":
+		secret.Value = req.SecretString
+		s.secrets[name] = secret
+		s.writeJSON(w, map[string]interface{}{
+			"ARN": s.arnPrefix() + name, "Name": name, "VersionId": s.newID(),
+		})
+	case "TagResource":
+		for _, t := range req.Tags {
+			secret.Tags[t.Key] = t.Value
+		}
+		s.secrets[name] = secret
+		s.writeJSON(w, map[string]interface{}{})
+	case "DeleteSecret":
+		if !req.ForceDeleteWithoutRecovery {
+			writeJSONError(w, "InvalidRequestException", "only forced deletion is supported")
+			return
+		}
+		delete(s.secrets, name)
+		s.writeJSON(w, map[string]interface{}{
+			"ARN": s.arnPrefix() + name, "Name": name, "DeletionDate": time.Now().Unix(),
+		})
+	}
+}
+
+func (s *Server) listSecrets(w http.ResponseWriter, req secretsManagerRequest) {
+	var matches []string
+	for _, name := range s.sortedNames() {
+		if matchesFilters(name, req) {
+			matches = append(matches, name)
+		}
+	}
+	start, _ := strconv.Atoi(req.NextToken)
+	if start > len(matches) {
+		start = len(matches)
+	}
+	maxResults := req.MaxResults
+	if maxResults <= 0 {
+		maxResults = 100
+	}
+	end := start + maxResults
+	if end > len(matches) {
+		end = len(matches)
+	}
+	entries := []map[string]interface{}{}
+	for _, name := range matches[start:end] {
+		var tags []tag
+		for k, v := range s.secrets[name].Tags {
+			tags = append(tags, tag{Key: k, Value: v})
+		}
+		sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+		entries = append(entries, map[string]interface{}{
+			"ARN": s.arnPrefix() + name, "Name": name, "Tags": tags,
+		})
+	}
+	resp := map[string]interface{}{"SecretList": entries}
+	if end < len(matches) {
+		resp["NextToken"] = strconv.Itoa(end)
+	}
+	s.writeJSON(w, resp)
+}
+
+func matchesFilters(name string, req secretsManagerRequest) bool {
+	for _, filter := range req.Filters {
+		if filter.Key != "name" {
+			continue
+		}
+		matched := false
+		for _, prefix := range filter.Values {
+			if strings.HasPrefix(name, prefix) {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+type stsCredentials struct {
+	AccessKeyId     string `xml:"AccessKeyId"`
+	SecretAccessKey string `xml:"SecretAccessKey"`
+	SessionToken    string `xml:"SessionToken"`
+	Expiration      string `xml:"Expiration"`
+}
+
+type stsResult struct {
+	XMLName     xml.Name
+	Credentials *stsCredentials `xml:"Credentials,omitempty"`
+	Arn         string          `xml:"Arn,omitempty"`
+}
+
+type stsResponse struct {
+	XMLName xml.Name
+	Xmlns   string `xml:"xmlns,attr"`
+	Result  stsResult
+}
+
+func (s *Server) serveSTS(w http.ResponseWriter, r *http.Request, cred *credential) {
+	action := r.Form.Get("Action")
+	if action == "GetCallerIdentity" {
+		// Any credentials may get their own identity.
+		s.writeSTS(w, action, stsResult{Arn: "arn:" + s.Partition + ":iam::123456789012:user/juju"})
+		return
+	}
+	if action != "AssumeRole" && action != "GetFederationToken" {
+		writeXMLError(w, http.StatusBadRequest, "InvalidAction", fmt.Sprintf("action %s not supported", action))
+		return
+	}
+	if cred.policy != nil {
+		writeXMLError(w, http.StatusForbidden, "AccessDenied", fmt.Sprintf("not authorized to perform sts:%s", action))
+		return
+	}
+	session := Session{
+		Action:     action,
+		RoleARN:    r.Form.Get("RoleArn"),
+		ExternalID: r.Form.Get("ExternalId"),
+		Policy:     r.Form.Get("Policy"),
+	}
+	if action == "AssumeRole" {
+		session.Name = r.Form.Get("RoleSessionName")
+	} else {
+		session.Name = r.Form.Get("Name")
+	}
+	newCred := &credential{sessionToken: "token-" + s.newID()}
+	if len(session.Policy) > maxSessionPolicySize {
+		writeXMLError(w, http.StatusBadRequest, "PackedPolicyTooLarge", "session policy is too large")
+		return
+	}
+	if session.Policy != "" {
+		var p policy
+		if err := json.Unmarshal([]byte(session.Policy), &p); err != nil {
+			writeXMLError(w, http.StatusBadRequest, "MalformedPolicyDocument", err.Error())
+			return
+		}
+		newCred.policy = &p
+	}
+	session.AccessKey = "ASIA" + s.newID()
+	s.credentials[session.AccessKey] = newCred
+	s.sessions = append(s.sessions, session)
+
+	duration, _ := strconv.Atoi(r.Form.Get("DurationSeconds"))
+	creds := &stsCredentials{
+		AccessKeyId:     session.AccessKey,
+		SecretAccessKey: "secret-" + session.AccessKey,
+		SessionToken:    newCred.sessionToken,
+		Expiration:      time.Now().Add(time.Duration(duration) * time.Second).UTC().Format(time.RFC3339),
+	}
+	s.writeSTS(w, action, stsResult{Credentials: creds})
+}
+
+func (s *Server) writeSTS(w http.ResponseWriter, action string, result stsResult) {
+	result.XMLName = xml.Name{Local: action + "Result"}
+	resp := stsResponse{
+		XMLName: xml.Name{Local: action + "Response"},
+		Xmlns:   "https://sts.amazonaws.com/doc/2011-06-15/",
+		Result:  result,
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	_ = xml.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%08d", s.nextID)
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, code, message string) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"__type": code, "message": message})
+}
+
+func writeXMLError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, `<ErrorResponse><Error><Type>Sender</Type><Code>%s</Code><Message>%s</Message></Error></ErrorResponse>`,
+		code, message)
+}
+
+// policy is an IAM policy document, supporting only Allow statements
+// with StringEquals and Null conditions.
+type policy struct {
+	Statement []struct {
+		Effect    string                         `json:"Effect"`
+		Action    []string                       `json:"Action"`
+		Resource  stringOrList                   `json:"Resource"`
+		Condition map[string]map[string][]string `json:"Condition"`
+	} `json:"Statement"`
+}
+
+// stringOrList is a policy element which may be given as a single
+// string or a list of them.
+type stringOrList []string
+
+func (l *stringOrList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = []string{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+func (p *policy) allows(action, resource string, context map[string]string) bool {
+	for _, statement := range p.Statement {
+		if statement.Effect != "Allow" || !contains(statement.Action, action) ||
+			!matchesAnyResource(statement.Resource, resource) {
+			continue
+		}
+		matched := true
+		for operator, conditions := range statement.Condition {
+			for key, values := range conditions {
+				value, ok := context[key]
+				switch operator {
+				case "StringEquals":
+					matched = matched && ok && contains(values, value)
+				case "Null":
+					matched = matched && contains(values, strconv.FormatBool(!ok))
+				default:
+					matched = false
+				}
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyResource reports whether the resource ARN matches any of
+// the patterns, in which * matches any sequence of characters.
+func matchesAnyResource(patterns []string, resource string) bool {
+	for _, pattern := range patterns {
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+		if regexp.MustCompile(expr).MatchString(resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"sort"
+
 	"github.com/juju/mgo/v3"
 
 	"github.com/juju/juju/state/bakerystorage"
@@ -197,6 +199,14 @@ func allCollections() CollectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection indexes the controller's cache of Charmhub
+		// responses, charm archives and resources, which are held in
+		// the controller model's blob storage.
+		charmhubCacheC: {
+			global:    true,
+			rawAccess: true,
+		},
+
 		// This collection holds the last time the model user connected
 		// to the model.
 		modelUserLastConnectionC: {
@@ -665,6 +675,23 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		// sshSessionRecordingsC indexes the recordings of SSH sessions
+		// proxied through the controller, which are held in the
+		// model's blob storage.
+		sshSessionRecordingsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "user", "started"},
+			}, {
+				Key: []string{"model-uuid", "target", "started"},
+			}, {
+				Key: []string{"model-uuid", "started"},
+			}},
+		},
+
+		// sshPoliciesC holds the policies controlling what may be done
+		// through the controller's SSH server in a model.
+		sshPoliciesC: {},
+
 		// sshConnRequestsC holds the ssh connection requests.
 		// The documents are added/removed by the controller, and units are watching
 		// the collection to start a ssh connection to controllers.
@@ -685,6 +712,19 @@ func allCollections() CollectionSchema {
 	return result
 }
 
+// AllCollectionNames returns the names of the collections juju uses,
+// sorted. A backup of a controller running this version of juju is not
+// expected to hold any collection not named here.
+func AllCollectionNames() []string {
+	schema := allCollections()
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // These constants are used to avoid sprinkling the package with any more
 // magic strings. If a collection deserves documentation, please document
 // it in allCollections, above; and please keep this list sorted for easy
@@ -699,6 +739,7 @@ const (
 	bakeryStorageItemsC        = "bakeryStorageItems"
 	blockDevicesC              = "blockdevices"
 	blocksC                    = "blocks"
+	charmhubCacheC             = "charmhubCache"
 	charmsC                    = "charms"
 	cleanupsC                  = "cleanups"
 	cloudimagemetadataC        = "cloudimagemetadata"
@@ -752,6 +793,8 @@ const (
 	resourcesC                 = "resources"
 	sshHostKeysC               = "sshhostkeys"
 	sshConnRequestsC           = "sshrequests"
+	sshSessionRecordingsC      = "sshsessionrecordings"
+	sshPoliciesC               = "sshpolicies"
 	spacesC                    = "spaces"
 	statusesC                  = "statuses"
 	statusesHistoryC           = "statuseshistory"
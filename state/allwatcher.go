@@ -5,6 +5,7 @@ package state
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/juju/charm/v12"
@@ -99,6 +100,8 @@ func makeAllWatcherCollectionInfo(collNames []string) map[string]allWatcherState
 			collection.docType = reflect.TypeOf(backingRemoteApplication{})
 		case applicationOffersC:
 			collection.docType = reflect.TypeOf(backingApplicationOffer{})
+		case storageInstancesC:
+			collection.docType = reflect.TypeOf(backingStorageInstance{})
 		case generationsC:
 			collection.docType = reflect.TypeOf(backingGeneration{})
 		case permissionsC:
@@ -1036,8 +1039,20 @@ func (r *backingRelation) updated(ctx *allWatcherContext) error {
 		ModelUUID: r.ModelUUID,
 		Key:       r.Key,
 		ID:        r.Id,
+		Life:      life.Value(r.Life.String()),
 		Endpoints: eps,
 	}
+	oldInfo := ctx.store.Get(info.EntityID())
+	if oldInfo == nil {
+		key := relationGlobalScope(r.Id)
+		relationStatus, err := ctx.getStatus(key, "relation")
+		if err != nil && !errors.Is(err, errors.NotFound) {
+			return errors.Annotatef(err, "reading relation status for key %s", key)
+		}
+		info.Status = relationStatus
+	} else if oldRelation, ok := oldInfo.(*multiwatcher.RelationInfo); ok {
+		info.Status = oldRelation.Status
+	}
 	ctx.store.Update(info)
 	return nil
 }
@@ -1067,6 +1082,35 @@ func (r *backingRelation) mongoID() string {
 	return r.Key
 }
 
+type backingStorageInstance storageInstanceDoc
+
+func (si *backingStorageInstance) updated(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`storage instance "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	info := &multiwatcher.StorageInstanceInfo{
+		ModelUUID:       si.ModelUUID,
+		ID:              si.Id,
+		Kind:            si.Kind.String(),
+		Life:            life.Value(si.Life.String()),
+		Owner:           si.Owner,
+		StorageName:     si.StorageName,
+		Pool:            si.Constraints.Pool,
+		Size:            si.Constraints.Size,
+		AttachmentCount: si.AttachmentCount,
+	}
+	ctx.store.Update(info)
+	return nil
+}
+
+func (si *backingStorageInstance) removed(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`storage instance "%s:%s" removed`, ctx.modelUUID, ctx.id)
+	ctx.removeFromStore(multiwatcher.StorageInstanceKind)
+	return nil
+}
+
+func (si *backingStorageInstance) mongoID() string {
+	return si.Id
+}
+
 type backingAnnotation annotatorDoc
 
 func (a *backingAnnotation) updated(ctx *allWatcherContext) error {
@@ -1176,6 +1220,9 @@ func (s *backingStatus) toStatusInfo() multiwatcher.StatusInfo {
 
 func (s *backingStatus) updated(ctx *allWatcherContext) error {
 	allWatcherLogger.Tracef(`status "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	if strings.HasPrefix(ctx.id, "r#") {
+		return s.updatedRelationStatus(ctx)
+	}
 	parentID, suffix, ok := ctx.entityIDForGlobalKey(ctx.id)
 	if !ok {
 		return nil
@@ -1255,6 +1302,44 @@ func (s *backingStatus) updated(ctx *allWatcherContext) error {
 	return nil
 }
 
+// updatedRelationStatus updates the status of the relation with the
+// id found in the status global key. Relation entities are keyed on the
+// relation key, not the id, so the key is read from the relation doc.
+func (s *backingStatus) updatedRelationStatus(ctx *allWatcherContext) error {
+	id, err := strconv.Atoi(strings.TrimPrefix(ctx.id, "r#"))
+	if err != nil {
+		allWatcherLogger.Tracef("relation status key %q unhandled", ctx.id)
+		return nil
+	}
+	relations, closer := ctx.state.db().GetCollection(relationsC)
+	defer closer()
+
+	var doc struct {
+		Key string `bson:"key"`
+	}
+	err = relations.Find(bson.D{{"id", id}}).Select(bson.D{{"key", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		// The relation doesn't exist yet. Ignore the status until it does.
+		return nil
+	}
+	if err != nil {
+		return errors.Annotatef(err, "reading relation %d", id)
+	}
+	relationInfo := &multiwatcher.RelationInfo{
+		ModelUUID: ctx.modelUUID,
+		Key:       doc.Key,
+	}
+	info, ok := ctx.store.Get(relationInfo.EntityID()).(*multiwatcher.RelationInfo)
+	if !ok {
+		// The relation isn't in the store yet. Ignore the status until it is.
+		return nil
+	}
+	newInfo := *info
+	newInfo.Status = s.toStatusInfo()
+	ctx.store.Update(&newInfo)
+	return nil
+}
+
 func (s *backingStatus) updateApplicationWorkload(ctx *allWatcherContext, unit *multiwatcher.UnitInfo) {
 	// If the workload version is blank, do nothing.
 	if s.StatusInfo == "" {
@@ -1654,6 +1739,7 @@ func NewAllWatcherBacking(pool *StatePool) (AllWatcherBacking, error) {
 		remoteApplicationsC,
 		statusesC,
 		settingsC,
+		storageInstancesC,
 		// And for CAAS we need to watch these...
 		podSpecsC,
 	}
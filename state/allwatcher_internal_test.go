@@ -254,6 +254,12 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		ModelUUID: modelUUID,
 		Key:       "logging:logging-directory wordpress:logging-dir",
 		ID:        rel.Id(),
+		Life:      life.Alive,
+		Status: multiwatcher.StatusInfo{
+			Current: "joining",
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "logging", Relation: multiwatcher.CharmRelation{Name: "logging-directory", Role: "requirer", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}},
 			{ApplicationName: "wordpress", Relation: multiwatcher.CharmRelation{Name: "logging-dir", Role: "provider", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}}},
@@ -429,6 +435,12 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		ModelUUID: modelUUID,
 		Key:       rel.Tag().Id(),
 		ID:        rel.Id(),
+		Life:      life.Alive,
+		Status: multiwatcher.StatusInfo{
+			Current: "joining",
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "mysql", Relation: multiwatcher.CharmRelation{Name: "server", Role: "provider", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}},
 			{ApplicationName: "remote-wordpress2", Relation: multiwatcher.CharmRelation{Name: "db", Role: "requirer", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}}},
@@ -441,6 +453,12 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		ModelUUID: modelUUID,
 		Key:       rel2.Tag().Id(),
 		ID:        rel2.Id(),
+		Life:      life.Alive,
+		Status: multiwatcher.StatusInfo{
+			Current: "joining",
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "mysql", Relation: multiwatcher.CharmRelation{Name: "server", Role: "provider", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}},
 			{ApplicationName: "remote-wordpress", Relation: multiwatcher.CharmRelation{Name: "db", Role: "requirer", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}}},
@@ -2066,6 +2084,7 @@ func testChangeRelations(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C
 			c.Assert(err, jc.ErrorIsNil)
 			_, err = st.AddRelation(eps...)
 			c.Assert(err, jc.ErrorIsNil)
+			now := st.clock().Now()
 
 			return changeTestCase{
 				about: "relation is added if it's in backing but not in Store",
@@ -2077,11 +2096,66 @@ func testChangeRelations(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C
 					&multiwatcher.RelationInfo{
 						ModelUUID: st.ModelUUID(),
 						Key:       "logging:logging-directory wordpress:logging-dir",
+						Life:      life.Alive,
+						Status: multiwatcher.StatusInfo{
+							Current: "joining",
+							Data:    map[string]interface{}{},
+							Since:   &now,
+						},
 						Endpoints: []multiwatcher.Endpoint{
 							{ApplicationName: "logging", Relation: multiwatcher.CharmRelation{Name: "logging-directory", Role: "requirer", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}},
 							{ApplicationName: "wordpress", Relation: multiwatcher.CharmRelation{Name: "logging-dir", Role: "provider", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}}},
 					}}}
 		},
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "no relation in state -> relation status is ignored",
+				change: watcher.Change{
+					C:  "statuses",
+					Id: st.docID(relationGlobalScope(42)),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			AddTestingApplication(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"))
+			AddTestingApplication(c, st, "logging", AddTestingCharm(c, st, "logging"))
+			eps, err := st.InferEndpoints("logging", "wordpress")
+			c.Assert(err, jc.ErrorIsNil)
+			rel, err := st.AddRelation(eps...)
+			c.Assert(err, jc.ErrorIsNil)
+			err = rel.SetStatus(status.StatusInfo{Status: status.Joined})
+			c.Assert(err, jc.ErrorIsNil)
+			now := st.clock().Now()
+
+			return changeTestCase{
+				about: "relation status is updated in the store",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.RelationInfo{
+						ModelUUID: st.ModelUUID(),
+						Key:       "logging:logging-directory wordpress:logging-dir",
+						ID:        rel.Id(),
+						Life:      life.Alive,
+						Status: multiwatcher.StatusInfo{
+							Current: "joining",
+						},
+					},
+				},
+				change: watcher.Change{
+					C:  "statuses",
+					Id: st.docID(relationGlobalScope(rel.Id())),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.RelationInfo{
+						ModelUUID: st.ModelUUID(),
+						Key:       "logging:logging-directory wordpress:logging-dir",
+						ID:        rel.Id(),
+						Life:      life.Alive,
+						Status: multiwatcher.StatusInfo{
+							Current: "joined",
+							Data:    map[string]interface{}{},
+							Since:   &now,
+						},
+					}}}
+		},
 	}
 	runChangeTests(c, changeTestFuncs)
 }
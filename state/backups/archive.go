@@ -99,8 +99,13 @@ func newArchiveWorkspace() (*ArchiveWorkspace, error) {
 // workspace dir populated from the archive. Note that this involves
 // unpacking the entire archive into a directory under the host's
 // "temporary" directory. For relatively large archives this could have
-// adverse effects on hosts with little disk space.
-func NewArchiveWorkspaceReader(archive io.Reader) (*ArchiveWorkspace, error) {
+// adverse effects on hosts with little disk space. Encrypted archives
+// are decrypted with the given identities.
+func NewArchiveWorkspaceReader(archive io.Reader, identities ...Identity) (*ArchiveWorkspace, error) {
+	archive, err := NewDecryptingReader(archive, identities...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	ws, err := newArchiveWorkspace()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -9,6 +9,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -24,6 +25,12 @@ const (
 
 	// FilenameTemplate is used with time.Time.Format to generate a filename.
 	FilenameTemplate = FilenamePrefix + "20060102-150405.tar.gz"
+
+	// metadataFileSuffix is added to the name of an archive file to
+	// name the file holding its metadata. The metadata is kept beside
+	// the archive so it can be read without reading the archive, which
+	// may be encrypted.
+	metadataFileSuffix = ".metadata.json"
 )
 
 var logger = loggo.GetLogger("juju.state.backups")
@@ -46,12 +53,39 @@ var (
 
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
-	// Create creates a new juju backup archive. It updates
-	// the provided metadata.
-	Create(meta *Metadata, dbInfo *DBInfo) (string, error)
+	// Create creates a new juju backup archive, encrypted to the
+	// recipients if any are given. It updates the provided metadata.
+	Create(meta *Metadata, dbInfo *DBInfo, recipients []Recipient) (string, error)
 
 	// Get returns the metadata and specified archive file.
 	Get(fileName string) (*Metadata, io.ReadCloser, error)
+
+	// List returns the backup archives held in the backup
+	// directory, oldest first.
+	List() ([]ArchiveInfo, error)
+
+	// Remove deletes the specified archive file.
+	Remove(fileName string) error
+
+	// Upload sends the specified archive file to an object store,
+	// recording the upload in the metadata.
+	Upload(meta *Metadata, fileName string, target UploadTarget) error
+}
+
+// ArchiveInfo describes a backup archive held on the machine.
+type ArchiveInfo struct {
+	// Filename is the absolute path to the archive file.
+	Filename string
+
+	// Size is the size of the archive file in bytes.
+	Size int64
+
+	// Created is when the archive file was written.
+	Created time.Time
+
+	// Notes are the notes recorded in the metadata of the archive, if
+	// the metadata is available.
+	Notes string
 }
 
 type backups struct {
@@ -81,9 +115,17 @@ func totalDirSize(path string) (int64, error) {
 
 // Create creates and stores a new juju backup archive (based on arguments)
 // and updates the provided metadata.  A filename to download the backup is provided.
-func (b *backups) Create(meta *Metadata, dbInfo *DBInfo) (string, error) {
+// If any recipients are given, the archive is encrypted to them.
+func (b *backups) Create(meta *Metadata, dbInfo *DBInfo, recipients []Recipient) (string, error) {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
+	meta.Encryption = nil
+	if len(recipients) > 0 {
+		meta.Encryption = &EncryptionMetadata{
+			Format:     EncryptionFormat,
+			Recipients: recipientFingerprints(recipients),
+		}
+	}
 
 	// The metadata file will not contain the ID or the "finished" data.
 	// However, that information is not as critical. The alternatives
@@ -157,6 +199,7 @@ func (b *backups) Create(meta *Metadata, dbInfo *DBInfo) (string, error) {
 		filesToBackUp:  filesToBackUp,
 		db:             dumper,
 		metadataReader: metadataFile,
+		recipients:     recipients,
 	}
 	result, err := runCreate(&args)
 	if err != nil {
@@ -169,10 +212,52 @@ func (b *backups) Create(meta *Metadata, dbInfo *DBInfo) (string, error) {
 	if err != nil {
 		return "", errors.Annotate(err, "while updating metadata")
 	}
+	if err := writeMetadataFile(result.filename, meta); err != nil {
+		return "", errors.Annotate(err, "while writing metadata file")
+	}
 
 	return result.filename, nil
 }
 
+// writeMetadataFile writes the metadata of the archive to the file
+// beside it.
+func writeMetadataFile(archiveFilename string, meta *Metadata) error {
+	r, err := meta.AsJSONBuffer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.WriteFile(archiveFilename+metadataFileSuffix, data, 0600))
+}
+
+// readMetadataFile reads the metadata of the archive from the file
+// beside it. Archives created before metadata files were written have
+// none, and errors.NotFound is returned.
+func readMetadataFile(archiveFilename string) (*Metadata, error) {
+	f, err := os.Open(archiveFilename + metadataFileSuffix)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("metadata for backup %q", archiveFilename)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	meta, err := NewMetadataJSONReader(f)
+	return meta, errors.Trace(err)
+}
+
+// removeMetadataFile removes the metadata file of the archive, if
+// there is one.
+func removeMetadataFile(archiveFilename string) error {
+	err := os.Remove(archiveFilename + metadataFileSuffix)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func isValidFilepath(root string, filePath string) (bool, error) {
 	if !filepath.IsAbs(filePath) {
 		return false, nil
@@ -211,6 +296,9 @@ func (b *backups) Get(fileName string) (_ *Metadata, _ io.ReadCloser, err error)
 		if err2 := os.Remove(fileName); err2 != nil && !os.IsNotExist(err2) {
 			logger.Errorf("error removing backup archive: %v", err2.Error())
 		}
+		if err2 := removeMetadataFile(fileName); err2 != nil {
+			logger.Errorf("error removing backup metadata: %v", err2.Error())
+		}
 	}()
 
 	readCloser, err := os.Open(fileName)
@@ -231,3 +319,62 @@ func (b *backups) Get(fileName string) (_ *Metadata, _ io.ReadCloser, err error)
 
 	return meta, readCloser, nil
 }
+
+// List returns the backup archives held in the backup directory,
+// oldest first. Only the top level of the directory is considered,
+// since that is where Create writes new archives. The notes of each
+// archive are read from its metadata file, if it has one.
+func (b *backups) List() ([]ArchiveInfo, error) {
+	entries, err := os.ReadDir(b.paths.BackupDir)
+	if err != nil {
+		return nil, errors.Annotate(err, "while listing backup directory")
+	}
+	var result []ArchiveInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), FilenamePrefix) ||
+			strings.HasSuffix(entry.Name(), metadataFileSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if os.IsNotExist(err) {
+			// The archive was removed while we were looking at it.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		archive := ArchiveInfo{
+			Filename: filepath.Join(b.paths.BackupDir, entry.Name()),
+			Size:     info.Size(),
+			Created:  info.ModTime().UTC(),
+		}
+		meta, err := readMetadataFile(archive.Filename)
+		if err == nil {
+			archive.Notes = meta.Notes
+		} else if !errors.Is(err, errors.NotFound) {
+			logger.Warningf("cannot read metadata of backup archive %q: %v", archive.Filename, err)
+		}
+		result = append(result, archive)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Created.Equal(result[j].Created) {
+			return result[i].Filename < result[j].Filename
+		}
+		return result[i].Created.Before(result[j].Created)
+	})
+	return result, nil
+}
+
+// Remove deletes the specified archive file from the backup directory.
+func (b *backups) Remove(fileName string) error {
+	valid, err := isValidFilepath(b.paths.BackupDir, fileName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !valid {
+		return errors.NotFoundf("backup file %q", fileName)
+	}
+	if err := os.Remove(fileName); err != nil {
+		return errors.Annotate(err, "while removing backup archive")
+	}
+	return errors.Annotate(removeMetadataFile(fileName), "while removing backup metadata")
+}
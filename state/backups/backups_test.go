@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/juju/collections/set"
@@ -77,7 +78,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
 
-	_, err := s.api.Create(meta, &dbInfo)
+	_, err := s.api.Create(meta, &dbInfo, nil)
 	c.Check(err, gc.ErrorMatches, expected)
 }
 
@@ -113,7 +114,7 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
-	resultFilename, err := s.api.Create(meta, &dbInfo)
+	resultFilename, err := s.api.Create(meta, &dbInfo, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(resultFilename, gc.Equals, path.Join(s.paths.BackupDir, "test-backup.tar.gz"))
 
@@ -136,6 +137,16 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	c.Check(meta.Origin.Machine, gc.Equals, "<machine ID>")
 	c.Check(meta.Origin.Hostname, gc.Equals, "<hostname>")
 	c.Check(meta.Notes, gc.Equals, "some notes")
+	c.Check(meta.Encryption, gc.IsNil)
+
+	// The metadata is written beside the archive.
+	f, err := os.Open(resultFilename + ".metadata.json")
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	written, err := backups.NewMetadataJSONReader(f)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(written.Notes, gc.Equals, "some notes")
+	c.Check(written.Checksum(), gc.Equals, "<checksum>")
 }
 
 func (s *backupsSuite) TestCreateFailToListFiles(c *gc.C) {
@@ -230,3 +241,72 @@ func (s *backupsSuite) TestGetFileName(c *gc.C) {
 	_, err = os.Stat(backupFilename)
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("stat %s: no such file or directory", backupFilename))
 }
+
+func (s *backupsSuite) writeArchive(c *gc.C, name string, modTime time.Time) string {
+	fileName := filepath.Join(s.paths.BackupDir, name)
+	err := os.WriteFile(fileName, []byte("archive "+name), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Chtimes(fileName, modTime, modTime)
+	c.Assert(err, jc.ErrorIsNil)
+	return fileName
+}
+
+func (s *backupsSuite) writeMetadata(c *gc.C, archiveFilename, notes string) {
+	meta := backupstesting.NewMetadataStarted()
+	meta.Notes = notes
+	r, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.WriteFile(archiveFilename+".metadata.json", data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *backupsSuite) TestList(c *gc.C) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	newer := s.writeArchive(c, "juju-backup-20250301-120000.tar.gz", now)
+	older := s.writeArchive(c, "juju-backup-20250228-120000.tar.gz", now.Add(-24*time.Hour))
+	s.writeMetadata(c, newer, "scheduled backup")
+	s.writeArchive(c, "not-a-backup.tar.gz", now)
+	err := os.MkdirAll(filepath.Join(s.paths.BackupDir, "juju-backup-dir"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archives, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archives, jc.DeepEquals, []backups.ArchiveInfo{{
+		Filename: older,
+		Size:     42,
+		Created:  now.Add(-24 * time.Hour),
+	}, {
+		Filename: newer,
+		Size:     42,
+		Created:  now,
+		Notes:    "scheduled backup",
+	}})
+}
+
+func (s *backupsSuite) TestListEmpty(c *gc.C) {
+	archives, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archives, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestRemove(c *gc.C) {
+	fileName := s.writeArchive(c, "juju-backup-20250301-120000.tar.gz", time.Now())
+	s.writeMetadata(c, fileName, "some notes")
+
+	err := s.api.Remove(fileName)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(fileName)
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+	_, err = os.Stat(fileName + ".metadata.json")
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+
+	err = s.api.Remove(fileName)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *backupsSuite) TestRemoveOutsideBackupDir(c *gc.C) {
+	err := s.api.Remove("/etc/hostname")
+	c.Assert(err, gc.ErrorMatches, `backup file "/etc/hostname" not found`)
+}
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"filippo.io/age"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/v3/hash"
@@ -31,6 +32,7 @@ type createArgs struct {
 	filesToBackUp  []string
 	db             DBDumper
 	metadataReader io.Reader
+	recipients     []Recipient
 }
 
 type createResult struct {
@@ -48,6 +50,7 @@ func create(args *createArgs) (_ *createResult, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	builder.recipients = args.recipients
 	defer func() {
 		if cerr := builder.cleanUp(err != nil); cerr != nil {
 			cerr.Log(logger)
@@ -99,6 +102,8 @@ type builder struct {
 	filesToBackUp []string
 	// db is the wrapper around the DB dump command and args.
 	db DBDumper
+	// recipients are the public keys the archive is encrypted to, if any.
+	recipients []Recipient
 	// checksum is the checksum of the archive file.
 	checksum string
 	// archiveFile is the backup archive file.
@@ -304,9 +309,32 @@ func (b *builder) buildDBDump() error {
 	return nil
 }
 
-func (b *builder) buildArchive(outFile io.Writer) error {
+func (b *builder) buildArchive(outFile io.Writer) (err error) {
+	if len(b.recipients) > 0 {
+		ageRecipients := make([]age.Recipient, len(b.recipients))
+		for i, r := range b.recipients {
+			ageRecipients[i] = r.Recipient
+		}
+		encrypted, err := age.Encrypt(outFile, ageRecipients...)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting final archive")
+		}
+		// The encrypted stream must be closed after the gzip writer,
+		// so that the last of the compressed data is encrypted.
+		defer func() {
+			if cerr := encrypted.Close(); cerr != nil && err == nil {
+				err = errors.Annotate(cerr, "while encrypting final archive")
+			}
+		}()
+		outFile = encrypted
+	}
+
 	tarball := gzip.NewWriter(outFile)
-	defer tarball.Close()
+	defer func() {
+		if cerr := tarball.Close(); cerr != nil && err == nil {
+			err = errors.Annotate(cerr, "while compressing final archive")
+		}
+	}()
 
 	// We add a trailing slash (or whatever) to root so that everything
 	// in the path up to and including that slash is stripped off when
@@ -331,6 +359,8 @@ func (b *builder) buildArchiveAndChecksum() error {
 	// than to the uncompressed contents of the tarball.  This is so
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
+	// Likewise, the checksum of an encrypted archive is that of the
+	// encrypted file.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
 	if err := b.buildArchive(hasher); err != nil {
 		return errors.Trace(err)
@@ -0,0 +1,153 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/agessh"
+	"github.com/juju/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+// EncryptionFormat identifies the format of encrypted backup archives.
+// Archives are encrypted with age (https://age-encryption.org), so
+// they can also be decrypted with the age command line tool.
+const EncryptionFormat = "age-encryption.org/v1"
+
+// encryptedHeader is the start of every encrypted backup archive.
+var encryptedHeader = []byte("age-encryption.org/")
+
+// Recipient is a public key to which backup archives are encrypted.
+type Recipient struct {
+	age.Recipient
+
+	// Fingerprint identifies the public key, and is recorded in the
+	// metadata of the archives encrypted to it.
+	Fingerprint string
+}
+
+// ParseRecipient parses a public key to encrypt backup archives to.
+// Both age X25519 public keys ("age1...") and SSH public keys in
+// authorized_keys format (ssh-ed25519 and ssh-rsa) are accepted.
+func ParseRecipient(s string) (Recipient, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "age1") {
+		r, err := age.ParseX25519Recipient(s)
+		if err != nil {
+			return Recipient{}, errors.NewNotValid(err, "invalid backup recipient")
+		}
+		sum := sha256.Sum256([]byte(r.String()))
+		return Recipient{
+			Recipient:   r,
+			Fingerprint: "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]),
+		}, nil
+	}
+
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(s))
+	if err != nil {
+		return Recipient{}, errors.NewNotValid(err, "invalid backup recipient")
+	}
+	r, err := agessh.ParseRecipient(string(ssh.MarshalAuthorizedKey(pub)))
+	if err != nil {
+		return Recipient{}, errors.NewNotValid(err, "invalid backup recipient")
+	}
+	return Recipient{
+		Recipient:   r,
+		Fingerprint: ssh.FingerprintSHA256(pub),
+	}, nil
+}
+
+// ParseRecipients parses each of the public keys, ignoring duplicates.
+func ParseRecipients(keys []string) ([]Recipient, error) {
+	var (
+		result []Recipient
+		seen   = make(map[string]bool)
+	)
+	for _, key := range keys {
+		r, err := ParseRecipient(key)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if seen[r.Fingerprint] {
+			continue
+		}
+		seen[r.Fingerprint] = true
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// EncryptionMetadata records how a backup archive was encrypted.
+type EncryptionMetadata struct {
+	// Format identifies the encryption format.
+	Format string
+
+	// Recipients holds the fingerprints of the public keys the
+	// archive was encrypted to.
+	Recipients []string
+}
+
+// Identity is a private key with which backup archives are decrypted.
+type Identity = age.Identity
+
+// ParseIdentities parses the private keys in an identity file, which
+// may either be an age identity file or a single unencrypted SSH
+// private key in PEM format.
+func ParseIdentities(r io.Reader) ([]Identity, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if bytes.Contains(data, []byte("PRIVATE KEY-----")) {
+		identity, err := agessh.ParseIdentity(data)
+		if err != nil {
+			return nil, errors.NewNotValid(err, "invalid backup identity")
+		}
+		return []Identity{identity}, nil
+	}
+	identities, err := age.ParseIdentities(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.NewNotValid(err, "invalid backup identity")
+	}
+	return identities, nil
+}
+
+// NewDecryptingReader returns a reader of the compressed archive
+// held in r. If the archive is encrypted it is decrypted with the
+// first of the identities able to do so, otherwise it is returned
+// unchanged.
+func NewDecryptingReader(r io.Reader, identities ...Identity) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(len(encryptedHeader))
+	if err != nil && err != io.EOF {
+		return nil, errors.Trace(err)
+	}
+	if !bytes.Equal(header, encryptedHeader) {
+		return br, nil
+	}
+	if len(identities) == 0 {
+		return nil, errors.New("backup archive is encrypted; an identity is required to decrypt it")
+	}
+	decrypted, err := age.Decrypt(br, identities...)
+	if err != nil {
+		return nil, errors.Annotate(err, "while decrypting archive")
+	}
+	return decrypted, nil
+}
+
+// recipientFingerprints returns the fingerprints of the recipients.
+func recipientFingerprints(recipients []Recipient) []string {
+	fingerprints := make([]string, len(recipients))
+	for i, r := range recipients {
+		fingerprints[i] = r.Fingerprint
+	}
+	return fingerprints
+}
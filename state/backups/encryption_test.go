@@ -0,0 +1,178 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/ssh"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type encryptionSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&encryptionSuite{})
+
+func (s *encryptionSuite) TestParseRecipientAge(c *gc.C) {
+	identity, err := age.GenerateX25519Identity()
+	c.Assert(err, jc.ErrorIsNil)
+
+	r, err := backups.ParseRecipient(identity.Recipient().String() + "\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(r.Fingerprint, gc.Matches, `SHA256:[A-Za-z0-9+/]{43}`)
+}
+
+func (s *encryptionSuite) TestParseRecipientSSH(c *gc.C) {
+	pub, _ := newSSHKey(c)
+
+	r, err := backups.ParseRecipient(string(ssh.MarshalAuthorizedKey(pub)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(r.Fingerprint, gc.Equals, ssh.FingerprintSHA256(pub))
+}
+
+func (s *encryptionSuite) TestParseRecipientInvalid(c *gc.C) {
+	_, err := backups.ParseRecipient("age1notakey")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	_, err = backups.ParseRecipient("ssh-dss AAAA")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *encryptionSuite) TestParseRecipientsDeduplicates(c *gc.C) {
+	identity, err := age.GenerateX25519Identity()
+	c.Assert(err, jc.ErrorIsNil)
+	key := identity.Recipient().String()
+
+	recipients, err := backups.ParseRecipients([]string{key, " " + key})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(recipients, gc.HasLen, 1)
+}
+
+func (s *encryptionSuite) TestParseIdentities(c *gc.C) {
+	identity, err := age.GenerateX25519Identity()
+	c.Assert(err, jc.ErrorIsNil)
+	identities, err := backups.ParseIdentities(strings.NewReader("# key\n" + identity.String() + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(identities, gc.HasLen, 1)
+
+	_, private := newSSHKey(c)
+	identities, err = backups.ParseIdentities(bytes.NewReader(private))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(identities, gc.HasLen, 1)
+
+	_, err = backups.ParseIdentities(strings.NewReader("nonsense"))
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *encryptionSuite) TestNewDecryptingReaderUnencrypted(c *gc.C) {
+	r, err := backups.NewDecryptingReader(strings.NewReader("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+}
+
+func (s *encryptionSuite) TestNewDecryptingReader(c *gc.C) {
+	identity, err := age.GenerateX25519Identity()
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, identity.Recipient())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = io.WriteString(w, "<archive>")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	_, err = backups.NewDecryptingReader(bytes.NewReader(buf.Bytes()))
+	c.Check(err, gc.ErrorMatches, "backup archive is encrypted; an identity is required to decrypt it")
+
+	other, err := age.GenerateX25519Identity()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = backups.NewDecryptingReader(bytes.NewReader(buf.Bytes()), other)
+	c.Check(err, gc.ErrorMatches, "while decrypting archive: no identity matched any of the recipients")
+
+	r, err := backups.NewDecryptingReader(bytes.NewReader(buf.Bytes()), other, identity)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+}
+
+func (s *encryptionSuite) TestMetadataRoundTrip(c *gc.C) {
+	meta := backupstesting.NewMetadata()
+	meta.Encryption = &backups.EncryptionMetadata{
+		Format:     backups.EncryptionFormat,
+		Recipients: []string{"SHA256:one", "SHA256:two"},
+	}
+	buf, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := backups.NewMetadataJSONReader(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got.Encryption, jc.DeepEquals, meta.Encryption)
+}
+
+func (s *createSuite) TestCreateEncrypted(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	backupDir := c.MkDir()
+	_, testFiles, expected := s.createTestFiles(c)
+
+	identity, err := age.GenerateX25519Identity()
+	c.Assert(err, jc.ErrorIsNil)
+	recipients, err := backups.ParseRecipients([]string{identity.Recipient().String()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	dumper := &TestDBDumper{}
+	args := backups.NewTestEncryptedCreateArgs(backupDir, testFiles, dumper, metadataFile, recipients)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archiveFile, size, checksum, _ := backups.ExposeCreateResult(result)
+	file, ok := archiveFile.(*os.File)
+	c.Assert(ok, jc.IsTrue)
+
+	// The size and checksum are those of the encrypted file.
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+
+	_, err = backups.NewDecryptingReader(file)
+	c.Check(err, gc.ErrorMatches, "backup archive is encrypted; an identity is required to decrypt it")
+	resetFile(c, file)
+
+	r, err := backups.NewDecryptingReader(file, identity)
+	c.Assert(err, jc.ErrorIsNil)
+	ad, err := backups.NewArchiveDataReader(r)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkTarContents(c, ad.NewBuffer(), []tarContent{
+		{"juju-backup", "", nil},
+		{"juju-backup/dump", "", nil},
+		{"juju-backup/root.tar", "", expected},
+		{"juju-backup/metadata.json", "", nil},
+	})
+}
+
+func newSSHKey(c *gc.C) (ssh.PublicKey, []byte) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, jc.ErrorIsNil)
+	pub, err := ssh.NewPublicKey(public)
+	c.Assert(err, jc.ErrorIsNil)
+	block, err := ssh.MarshalPrivateKey(private, "")
+	c.Assert(err, jc.ErrorIsNil)
+	return pub, pem.EncodeToMemory(block)
+}
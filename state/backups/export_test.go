@@ -41,6 +41,14 @@ func NewTestCreateArgs(backupDir string, filesToBackUp []string, db DBDumper, me
 	return &args
 }
 
+// NewTestEncryptedCreateArgs builds a new args value for create() calls
+// which encrypt the archive to the given recipients.
+func NewTestEncryptedCreateArgs(backupDir string, filesToBackUp []string, db DBDumper, metar io.Reader, recipients []Recipient) *createArgs {
+	args := NewTestCreateArgs(backupDir, filesToBackUp, db, metar)
+	args.recipients = recipients
+	return args
+}
+
 // ExposeCreateResult extracts the values in a create() args value.
 func ExposeCreateArgs(args *createArgs) (string, []string, DBDumper) {
 	return args.destinationDir, args.filesToBackUp, args.db
@@ -89,6 +89,13 @@ type Metadata struct {
 
 	// Controller contains metadata about the controller where the backup was taken.
 	Controller ControllerMetadata
+
+	// Encryption records how the archive was encrypted, if it was.
+	Encryption *EncryptionMetadata
+
+	// Remote records where the archive was uploaded, if it was.
+	// It is not written to the metadata file in the archive.
+	Remote *RemoteMetadata
 }
 
 // ControllerMetadata contains controller specific metadata.
@@ -106,6 +113,24 @@ type ControllerMetadata struct {
 	HANodes int64
 }
 
+// RemoteMetadata records the upload of a backup archive to an
+// S3-compatible object store.
+type RemoteMetadata struct {
+	// Endpoint is the URL of the object store.
+	Endpoint string
+
+	// Bucket and Key identify the uploaded object.
+	Bucket string
+	Key    string
+
+	// ETag is the entity tag of the uploaded object, verified
+	// against the archive content.
+	ETag string
+
+	// Uploaded records when the upload completed.
+	Uploaded time.Time
+}
+
 // All un-versioned metadata is considered to be version 0,
 // so the versions start with 1.
 const currentFormatVersion = 1
@@ -257,6 +282,11 @@ type flatMetadata struct {
 	HANodes                     int64
 	ControllerMachineID         string
 	ControllerMachineInstanceID string
+
+	// encryption, omitted for unencrypted archives
+
+	EncryptionFormat string   `json:",omitempty"`
+	EncryptedTo      []string `json:",omitempty"`
 }
 
 func (m *Metadata) flat() flatMetadata {
@@ -286,6 +316,11 @@ func (m *Metadata) flat() flatMetadata {
 	if m.Finished != nil {
 		flat.Finished = *m.Finished
 	}
+
+	if m.Encryption != nil {
+		flat.EncryptionFormat = m.Encryption.Format
+		flat.EncryptedTo = m.Encryption.Recipients
+	}
 	return flat
 }
 
@@ -322,6 +357,13 @@ func (flat *flatMetadata) inflate() (*Metadata, error) {
 		MachineInstanceID: flat.ControllerMachineInstanceID,
 		HANodes:           flat.HANodes,
 	}
+
+	if flat.EncryptionFormat != "" {
+		meta.Encryption = &EncryptionMetadata{
+			Format:     flat.EncryptionFormat,
+			Recipients: flat.EncryptedTo,
+		}
+	}
 	return meta, nil
 }
 
@@ -28,9 +28,17 @@ type FakeBackups struct {
 	Error error
 	// Filename holds the name of the file to return.
 	Filename string
+	// Archives holds the archive list to return.
+	Archives []backups.ArchiveInfo
+	// Remote holds the upload record to set on uploaded metadata.
+	Remote *backups.RemoteMetadata
 
 	// IDArg holds the ID that was passed in.
 	IDArg string
+	// TargetArg holds the upload target that was passed in.
+	TargetArg backups.UploadTarget
+	// RecipientsArg holds the encryption recipients that were passed in.
+	RecipientsArg []backups.Recipient
 	// DBInfoArg holds the ConnInfo that was passed in.
 	DBInfoArg *backups.DBInfo
 	// MetaArg holds the backup metadata that was passed in.
@@ -50,10 +58,12 @@ var _ backups.Backups = (*FakeBackups)(nil)
 func (b *FakeBackups) Create(
 	meta *backups.Metadata,
 	dbInfo *backups.DBInfo,
+	recipients []backups.Recipient,
 ) (string, error) {
 	b.Calls = append(b.Calls, "Create")
 
 	b.DBInfoArg = dbInfo
+	b.RecipientsArg = recipients
 	b.MetaArg = meta
 
 	if b.Meta != nil {
@@ -69,3 +79,29 @@ func (b *FakeBackups) Get(id string) (*backups.Metadata, io.ReadCloser, error) {
 	b.IDArg = id
 	return b.Meta, b.Archive, b.Error
 }
+
+// List returns the archives held by the fake.
+func (b *FakeBackups) List() ([]backups.ArchiveInfo, error) {
+	b.Calls = append(b.Calls, "List")
+	return b.Archives, b.Error
+}
+
+// Remove records the removal of the specified archive.
+func (b *FakeBackups) Remove(id string) error {
+	b.Calls = append(b.Calls, "Remove")
+	b.IDArg = id
+	return b.Error
+}
+
+// Upload records the upload of the specified archive, and sets the
+// metadata's upload record to Remote.
+func (b *FakeBackups) Upload(meta *backups.Metadata, fileName string, target backups.UploadTarget) error {
+	b.Calls = append(b.Calls, "Upload")
+	b.IDArg = fileName
+	b.TargetArg = target
+	if b.Error != nil {
+		return b.Error
+	}
+	meta.Remote = b.Remote
+	return nil
+}
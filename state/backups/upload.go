@@ -0,0 +1,106 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// ObjectStore is an S3-compatible object store to which backup
+// archives can be uploaded.
+type ObjectStore interface {
+	// PutObject uploads the content of body to the named object,
+	// verifying the upload before returning its result.
+	PutObject(ctx context.Context, bucketName, objectName string, body io.Reader, metadata map[string]string) (UploadResult, error)
+}
+
+// UploadResult describes an archive written to an object store.
+type UploadResult struct {
+	// Size is the number of bytes written.
+	Size int64
+
+	// ETag is the entity tag of the uploaded object.
+	ETag string
+}
+
+// UploadTarget identifies where backup archives are uploaded.
+type UploadTarget struct {
+	// Store is the object store to upload to.
+	Store ObjectStore
+
+	// Endpoint is the URL of the object store, recorded in the
+	// metadata of uploaded archives.
+	Endpoint string
+
+	// Bucket is the bucket to upload to.
+	Bucket string
+
+	// Prefix is prepended to the name of each uploaded archive.
+	Prefix string
+}
+
+// Validate returns an error if the target cannot be uploaded to.
+func (t UploadTarget) Validate() error {
+	if t.Store == nil {
+		return errors.NotValidf("nil Store")
+	}
+	if t.Bucket == "" {
+		return errors.NotValidf("empty Bucket")
+	}
+	return nil
+}
+
+// Upload sends the specified archive file to the target object store
+// and records the upload in the metadata. The size of the uploaded
+// object is checked against the size recorded in the metadata, and
+// the archive checksum is stored alongside the object so it can be
+// checked again once downloaded.
+func (b *backups) Upload(meta *Metadata, fileName string, target UploadTarget) error {
+	if err := target.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	valid, err := isValidFilepath(b.paths.BackupDir, fileName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !valid {
+		return errors.NotValidf("backup file %q", fileName)
+	}
+
+	archive, err := os.Open(fileName)
+	if err != nil {
+		return errors.Annotate(err, "while opening archive file for upload")
+	}
+	defer func() { _ = archive.Close() }()
+
+	key := path.Join(target.Prefix, filepath.Base(fileName))
+	logger.Infof("uploading backup archive %q to %s/%s", fileName, target.Bucket, key)
+	result, err := target.Store.PutObject(context.Background(), target.Bucket, key, archive, map[string]string{
+		"checksum":        meta.Checksum(),
+		"checksum-format": meta.ChecksumFormat(),
+		"controller-uuid": meta.Controller.UUID,
+	})
+	if err != nil {
+		return errors.Annotate(err, "while uploading backup archive")
+	}
+	if size := meta.Size(); size != 0 && result.Size != size {
+		return errors.Errorf("uploaded %d bytes of backup archive, expected %d", result.Size, size)
+	}
+
+	meta.Remote = &RemoteMetadata{
+		Endpoint: target.Endpoint,
+		Bucket:   target.Bucket,
+		Key:      key,
+		ETag:     result.ETag,
+		Uploaded: time.Now().UTC(),
+	}
+	return nil
+}
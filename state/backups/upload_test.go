@@ -0,0 +1,108 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type fakeObjectStore struct {
+	bucket, key string
+	data        []byte
+	metadata    map[string]string
+	err         error
+}
+
+func (s *fakeObjectStore) PutObject(
+	_ context.Context, bucketName, objectName string, body io.Reader, metadata map[string]string,
+) (backups.UploadResult, error) {
+	if s.err != nil {
+		return backups.UploadResult{}, s.err
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return backups.UploadResult{}, err
+	}
+	s.bucket, s.key, s.data, s.metadata = bucketName, objectName, data, metadata
+	return backups.UploadResult{Size: int64(len(data)), ETag: "etag-1"}, nil
+}
+
+func (s *backupsSuite) TestUpload(c *gc.C) {
+	fileName := s.writeArchive(c, "juju-backup-20250301-120000.tar.gz", time.Now())
+	meta := backupstesting.NewMetadataStarted()
+	meta.Controller.UUID = "controller-uuid"
+	store := &fakeObjectStore{}
+
+	err := s.api.Upload(meta, fileName, backups.UploadTarget{
+		Store:    store,
+		Endpoint: "https://minio.example.com",
+		Bucket:   "juju-backups",
+		Prefix:   "prod/",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(store.bucket, gc.Equals, "juju-backups")
+	c.Check(store.key, gc.Equals, "prod/juju-backup-20250301-120000.tar.gz")
+	c.Check(string(store.data), gc.Equals, "archive juju-backup-20250301-120000.tar.gz")
+	c.Check(store.metadata, jc.DeepEquals, map[string]string{
+		"checksum":        meta.Checksum(),
+		"checksum-format": meta.ChecksumFormat(),
+		"controller-uuid": "controller-uuid",
+	})
+
+	c.Assert(meta.Remote, gc.NotNil)
+	c.Check(meta.Remote.Endpoint, gc.Equals, "https://minio.example.com")
+	c.Check(meta.Remote.Bucket, gc.Equals, "juju-backups")
+	c.Check(meta.Remote.Key, gc.Equals, "prod/juju-backup-20250301-120000.tar.gz")
+	c.Check(meta.Remote.ETag, gc.Equals, "etag-1")
+	c.Check(meta.Remote.Uploaded.IsZero(), jc.IsFalse)
+}
+
+func (s *backupsSuite) TestUploadSizeMismatch(c *gc.C) {
+	fileName := s.writeArchive(c, "juju-backup-20250301-120000.tar.gz", time.Now())
+	meta := backupstesting.NewMetadata()
+
+	err := s.api.Upload(meta, fileName, backups.UploadTarget{
+		Store:  &fakeObjectStore{},
+		Bucket: "juju-backups",
+	})
+	c.Assert(err, gc.ErrorMatches, `uploaded 42 bytes of backup archive, expected 10`)
+	c.Check(meta.Remote, gc.IsNil)
+}
+
+func (s *backupsSuite) TestUploadError(c *gc.C) {
+	fileName := s.writeArchive(c, "juju-backup-20250301-120000.tar.gz", time.Now())
+	meta := backupstesting.NewMetadata()
+
+	err := s.api.Upload(meta, fileName, backups.UploadTarget{
+		Store:  &fakeObjectStore{err: errors.New("boom")},
+		Bucket: "juju-backups",
+	})
+	c.Assert(err, gc.ErrorMatches, `while uploading backup archive: boom`)
+	c.Check(meta.Remote, gc.IsNil)
+}
+
+func (s *backupsSuite) TestUploadOutsideBackupDir(c *gc.C) {
+	err := s.api.Upload(backupstesting.NewMetadata(), "/etc/hostname", backups.UploadTarget{
+		Store:  &fakeObjectStore{},
+		Bucket: "juju-backups",
+	})
+	c.Assert(err, gc.ErrorMatches, `backup file "/etc/hostname" not valid`)
+}
+
+func (s *backupsSuite) TestUploadInvalidTarget(c *gc.C) {
+	err := s.api.Upload(backupstesting.NewMetadata(), "/etc/hostname", backups.UploadTarget{
+		Store: &fakeObjectStore{},
+	})
+	c.Assert(err, gc.ErrorMatches, `empty Bucket not valid`)
+}
@@ -0,0 +1,319 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3/bson"
+)
+
+const (
+	// jujuDatabase is the name of the juju database in the dump.
+	jujuDatabase = "juju"
+
+	// oplogFile holds the oplog entries written during the dump,
+	// which are replayed on restore.
+	oplogFile = "oplog.bson"
+
+	// modelsCollection holds the models of the controller.
+	modelsCollection = "models"
+
+	// maxDocumentSize is the largest BSON document that mongo stores,
+	// which bounds the size read for each document in the dump.
+	maxDocumentSize = 16 * 1024 * 1024
+)
+
+// requiredCollections are the collections without which a backup
+// cannot be restored.
+var requiredCollections = []string{"controllers", modelsCollection}
+
+// VerifyArgs holds the arguments to Verify.
+type VerifyArgs struct {
+	// Checksum is the expected checksum of the archive, as reported
+	// when it was created. If empty, the archive is checked against
+	// the checksum recorded in its metadata, if any.
+	Checksum string
+
+	// Identities are used to decrypt encrypted archives.
+	Identities []Identity
+
+	// Collections holds the names of the collections juju uses. Any
+	// other collection found in the database dump is reported.
+	Collections []string
+}
+
+// ModelSummary describes a model held in a backup archive.
+type ModelSummary struct {
+	UUID  string
+	Name  string
+	Owner string
+	Type  string
+}
+
+// VerifyResult reports the content of a verified backup archive.
+type VerifyResult struct {
+	// Metadata is the metadata stored in the archive, if any.
+	Metadata *Metadata
+
+	// Checksum is the checksum of the archive as read.
+	Checksum string
+
+	// ChecksumVerified is true if the checksum matched the
+	// expected one.
+	ChecksumVerified bool
+
+	// Encrypted is true if the archive was encrypted.
+	Encrypted bool
+
+	// Collections holds the collections in the juju database dump.
+	Collections []string
+
+	// UnknownCollections holds the collections in the juju database
+	// dump which are not used by this version of juju.
+	UnknownCollections []string
+
+	// AgentConfigs holds the names of the agents whose configuration
+	// is in the archive.
+	AgentConfigs []string
+
+	// Tools holds the versions of the agent binaries in the archive.
+	Tools []string
+
+	// Models holds the models in the database dump.
+	Models []ModelSummary
+
+	// Warnings holds issues which need not prevent a restore.
+	Warnings []string
+
+	// Problems holds issues which will prevent a restore.
+	Problems []string
+}
+
+// OK returns true if no problems were found.
+func (r *VerifyResult) OK() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *VerifyResult) problemf(format string, args ...interface{}) {
+	r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+}
+
+func (r *VerifyResult) warningf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Verify unpacks the backup archive and checks that it holds what a
+// restore needs: the metadata, a dump of the juju database including
+// the oplog and the required collections, and the agent configuration
+// and binaries of the controller machine. An error is returned only if
+// the archive cannot be read at all; anything wrong with its content
+// is reported in the result.
+func Verify(archive io.Reader, args VerifyArgs) (*VerifyResult, error) {
+	hasher := sha1.New()
+	source := io.TeeReader(archive, hasher)
+	br := bufio.NewReader(source)
+	header, _ := br.Peek(len(encryptedHeader))
+	encrypted := bytes.Equal(header, encryptedHeader)
+	ws, err := NewArchiveWorkspaceReader(br, args.Identities...)
+	if ws != nil {
+		defer func() { _ = ws.Close() }()
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "while unpacking archive")
+	}
+	// Any trailing data is still part of the archive checksum.
+	if _, err := io.Copy(io.Discard, source); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := &VerifyResult{
+		Checksum:  base64.StdEncoding.EncodeToString(hasher.Sum(nil)),
+		Encrypted: encrypted,
+	}
+
+	meta, err := ws.Metadata()
+	if err != nil {
+		result.problemf("cannot read metadata: %v", err)
+	} else {
+		result.Metadata = meta
+	}
+
+	expected := args.Checksum
+	if expected == "" && meta != nil {
+		expected = meta.Checksum()
+	}
+	switch {
+	case expected == "":
+		result.warningf("no checksum to verify the archive against")
+	case expected != result.Checksum:
+		result.problemf("checksum mismatch: archive has %q, expected %q", result.Checksum, expected)
+	default:
+		result.ChecksumVerified = true
+	}
+
+	verifyDump(ws, args.Collections, result)
+	verifyFilesBundle(ws, result)
+	return result, nil
+}
+
+// verifyDump checks the database dump and reads the models from it.
+func verifyDump(ws *ArchiveWorkspace, known []string, result *VerifyResult) {
+	if _, err := os.Stat(filepath.Join(ws.DBDumpDir, oplogFile)); err != nil {
+		result.problemf("no oplog in database dump")
+	}
+
+	dbDir := filepath.Join(ws.DBDumpDir, jujuDatabase)
+	entries, err := os.ReadDir(dbDir)
+	if err != nil {
+		result.problemf("no %s database in database dump", jujuDatabase)
+		return
+	}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".bson"); ok && !entry.IsDir() {
+			result.Collections = append(result.Collections, name)
+		}
+	}
+	sort.Strings(result.Collections)
+
+	found := set.NewStrings(result.Collections...)
+	for _, name := range requiredCollections {
+		if !found.Contains(name) {
+			result.problemf("no %s collection in database dump", name)
+		}
+	}
+
+	if len(known) > 0 {
+		knownSet := set.NewStrings(known...)
+		for _, name := range result.Collections {
+			// Collections such as txns.log and txns.stash belong to
+			// the collection they are named after.
+			base, _, _ := strings.Cut(name, ".")
+			if !knownSet.Contains(name) && !knownSet.Contains(base) {
+				result.UnknownCollections = append(result.UnknownCollections, name)
+			}
+		}
+		if n := len(result.UnknownCollections); n > 0 {
+			result.warningf("%d collection(s) not used by this version of juju: %s",
+				n, strings.Join(result.UnknownCollections, ", "))
+		}
+	}
+
+	if !found.Contains(modelsCollection) {
+		return
+	}
+	models, err := readModels(filepath.Join(dbDir, modelsCollection+".bson"))
+	if err != nil {
+		result.problemf("cannot read models from database dump: %v", err)
+		return
+	}
+	if len(models) == 0 {
+		result.problemf("no models in database dump")
+	}
+	result.Models = models
+}
+
+// readModels reads the models from a dump of the models collection,
+// which holds a sequence of BSON documents.
+func readModels(fileName string) ([]ModelSummary, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var models []ModelSummary
+	for {
+		var size int32
+		if err := binary.Read(f, binary.LittleEndian, &size); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size < 5 || size > maxDocumentSize {
+			return nil, errors.Errorf("invalid document size %d", size)
+		}
+		doc := make([]byte, size)
+		binary.LittleEndian.PutUint32(doc, uint32(size))
+		if _, err := io.ReadFull(f, doc[4:]); err != nil {
+			return nil, errors.Trace(err)
+		}
+		var model struct {
+			UUID  string `bson:"_id"`
+			Name  string `bson:"name"`
+			Owner string `bson:"owner"`
+			Type  string `bson:"type"`
+		}
+		if err := bson.Unmarshal(doc, &model); err != nil {
+			return nil, errors.Trace(err)
+		}
+		models = append(models, ModelSummary{
+			UUID:  model.UUID,
+			Name:  model.Name,
+			Owner: model.Owner,
+			Type:  model.Type,
+		})
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].Name < models[j].Name
+	})
+	return models, nil
+}
+
+// verifyFilesBundle checks that the files bundle holds the agent
+// configuration and binaries.
+func verifyFilesBundle(ws *ArchiveWorkspace, result *VerifyResult) {
+	f, err := os.Open(ws.FilesBundle)
+	if err != nil {
+		result.problemf("no files bundle in archive")
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	tools := set.NewStrings()
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			result.problemf("cannot read files bundle: %v", err)
+			return
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		dir, base := path.Split(hdr.Name)
+		dir = path.Clean(dir)
+		switch {
+		case base == "agent.conf" && path.Base(path.Dir(dir)) == agentsDir:
+			result.AgentConfigs = append(result.AgentConfigs, path.Base(dir))
+		case base == "jujud" && path.Base(path.Dir(dir)) == toolsDir:
+			tools.Add(path.Base(dir))
+		}
+	}
+	sort.Strings(result.AgentConfigs)
+	result.Tools = tools.SortedValues()
+
+	if len(result.AgentConfigs) == 0 {
+		result.problemf("no agent configuration in files bundle")
+	}
+	if len(result.Tools) == 0 {
+		result.problemf("no agent binaries in files bundle")
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+
+	"filippo.io/age"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	bt "github.com/juju/juju/state/backups/testing"
+)
+
+type verifySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+var verifyFiles = []bt.File{{
+	Name:    "var/lib/juju/agents/machine-0/agent.conf",
+	Content: "<agent config>",
+}, {
+	Name:    "var/lib/juju/tools/3.6.0-ubuntu-amd64/jujud",
+	Content: "<jujud>",
+}, {
+	Name:    "var/lib/juju/system-identity",
+	Content: "<an ssh key goes here>",
+}}
+
+func modelsDump(c *gc.C, names ...string) string {
+	var buf bytes.Buffer
+	for i, name := range names {
+		data, err := bson.Marshal(bson.M{
+			"_id":   []string{"uuid-a", "uuid-b", "uuid-c"}[i],
+			"name":  name,
+			"owner": "admin",
+			"type":  "iaas",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		buf.Write(data)
+	}
+	return buf.String()
+}
+
+func (s *verifySuite) newArchive(c *gc.C, files, dump []bt.File) []byte {
+	meta := bt.NewMetadataStarted()
+	archive, err := bt.NewArchive(meta, files, dump)
+	c.Assert(err, jc.ErrorIsNil)
+	return archive.Bytes()
+}
+
+func (s *verifySuite) validDump(c *gc.C) []bt.File {
+	return []bt.File{
+		{Name: "oplog.bson"},
+		{Name: "juju", IsDir: true},
+		{Name: "juju/controllers.bson", Content: "<BSON data goes here>"},
+		{Name: "juju/controllers.metadata.json", Content: "{}"},
+		{Name: "juju/models.bson", Content: modelsDump(c, "default", "controller")},
+		{Name: "juju/txns.log.bson"},
+		{Name: "juju/widgets.bson"},
+	}
+}
+
+func checksum(data []byte) string {
+	sum := sha1.Sum(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	data := s.newArchive(c, verifyFiles, s.validDump(c))
+
+	result, err := backups.Verify(bytes.NewReader(data), backups.VerifyArgs{
+		Checksum:    checksum(data),
+		Collections: []string{"controllers", "models", "txns"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Problems, gc.HasLen, 0)
+	c.Check(result.OK(), jc.IsTrue)
+	c.Check(result.Checksum, gc.Equals, checksum(data))
+	c.Check(result.ChecksumVerified, jc.IsTrue)
+	c.Check(result.Encrypted, jc.IsFalse)
+	c.Check(result.Metadata, gc.NotNil)
+	c.Check(result.Collections, jc.DeepEquals, []string{"controllers", "models", "txns.log", "widgets"})
+	c.Check(result.UnknownCollections, jc.DeepEquals, []string{"widgets"})
+	c.Check(result.Warnings, jc.DeepEquals, []string{
+		"1 collection(s) not used by this version of juju: widgets",
+	})
+	c.Check(result.AgentConfigs, jc.DeepEquals, []string{"machine-0"})
+	c.Check(result.Tools, jc.DeepEquals, []string{"3.6.0-ubuntu-amd64"})
+	c.Check(result.Models, jc.DeepEquals, []backups.ModelSummary{
+		{UUID: "uuid-b", Name: "controller", Owner: "admin", Type: "iaas"},
+		{UUID: "uuid-a", Name: "default", Owner: "admin", Type: "iaas"},
+	})
+}
+
+func (s *verifySuite) TestVerifyNoChecksum(c *gc.C) {
+	data := s.newArchive(c, verifyFiles, s.validDump(c))
+
+	result, err := backups.Verify(bytes.NewReader(data), backups.VerifyArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.OK(), jc.IsTrue)
+	c.Check(result.ChecksumVerified, jc.IsFalse)
+	c.Check(result.UnknownCollections, gc.HasLen, 0)
+	c.Check(result.Warnings, jc.DeepEquals, []string{"no checksum to verify the archive against"})
+}
+
+func (s *verifySuite) TestVerifyChecksumMismatch(c *gc.C) {
+	data := s.newArchive(c, verifyFiles, s.validDump(c))
+
+	result, err := backups.Verify(bytes.NewReader(data), backups.VerifyArgs{Checksum: "bogus"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.OK(), jc.IsFalse)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		`checksum mismatch: archive has "` + checksum(data) + `", expected "bogus"`,
+	})
+}
+
+func (s *verifySuite) TestVerifyIncomplete(c *gc.C) {
+	data := s.newArchive(c, verifyFiles[2:], []bt.File{
+		{Name: "juju", IsDir: true},
+		{Name: "juju/models.bson"},
+	})
+
+	result, err := backups.Verify(bytes.NewReader(data), backups.VerifyArgs{Checksum: checksum(data)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.OK(), jc.IsFalse)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"no oplog in database dump",
+		"no controllers collection in database dump",
+		"no models in database dump",
+		"no agent configuration in files bundle",
+		"no agent binaries in files bundle",
+	})
+}
+
+func (s *verifySuite) TestVerifyInvalidModelsDump(c *gc.C) {
+	dump := s.validDump(c)
+	// A document which claims to be larger than mongo allows.
+	dump[4].Content = "\xff\xff\xff\x7f"
+	data := s.newArchive(c, verifyFiles, dump)
+
+	result, err := backups.Verify(bytes.NewReader(data), backups.VerifyArgs{Checksum: checksum(data)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"cannot read models from database dump: invalid document size 2147483647",
+	})
+}
+
+func (s *verifySuite) TestVerifyNoDatabase(c *gc.C) {
+	data := s.newArchive(c, verifyFiles, []bt.File{{Name: "oplog.bson"}})
+
+	result, err := backups.Verify(bytes.NewReader(data), backups.VerifyArgs{Checksum: checksum(data)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Problems, jc.DeepEquals, []string{"no juju database in database dump"})
+}
+
+func (s *verifySuite) TestVerifyNotAnArchive(c *gc.C) {
+	_, err := backups.Verify(bytes.NewReader([]byte("not an archive")), backups.VerifyArgs{})
+	c.Check(err, gc.ErrorMatches, "while unpacking archive: while uncompressing archive file: .*")
+}
+
+func (s *verifySuite) TestVerifyEncrypted(c *gc.C) {
+	plain := s.newArchive(c, verifyFiles, s.validDump(c))
+	identity, err := age.GenerateX25519Identity()
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, identity.Recipient())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write(plain)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	data := buf.Bytes()
+
+	_, err = backups.Verify(bytes.NewReader(data), backups.VerifyArgs{})
+	c.Check(err, gc.ErrorMatches, "while unpacking archive: backup archive is encrypted; an identity is required to decrypt it")
+
+	result, err := backups.Verify(bytes.NewReader(data), backups.VerifyArgs{
+		Checksum:   checksum(data),
+		Identities: []backups.Identity{identity},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.OK(), jc.IsTrue)
+	c.Check(result.ChecksumVerified, jc.IsTrue)
+	c.Check(result.Encrypted, jc.IsTrue)
+	c.Check(result.Models, gc.HasLen, 2)
+}
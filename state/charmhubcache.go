@@ -0,0 +1,197 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/storage"
+)
+
+// CharmhubCache returns the controller's cache of Charmhub responses,
+// charm archives and resources, which implements charmhub.Cache. The
+// cache is shared by all models, and its content is held in the
+// controller model's blob storage.
+func (st *State) CharmhubCache() *CharmhubCache {
+	return &CharmhubCache{st: st}
+}
+
+// CharmhubCache is a charmhub.Cache backed by the state.
+type CharmhubCache struct {
+	st *State
+}
+
+var _ charmhub.Cache = (*CharmhubCache)(nil)
+
+type charmhubCacheDoc struct {
+	Key         string    `bson:"_id"`
+	Kind        string    `bson:"kind"`
+	Name        string    `bson:"name"`
+	Revision    int       `bson:"revision,omitempty"`
+	ContentType string    `bson:"content-type,omitempty"`
+	Size        int64     `bson:"size"`
+	SHA256      string    `bson:"sha256"`
+	Created     time.Time `bson:"created"`
+	LastUsed    time.Time `bson:"last-used"`
+	StoragePath string    `bson:"storage-path"`
+}
+
+func (doc charmhubCacheDoc) entry() charmhub.CacheEntry {
+	return charmhub.CacheEntry{
+		Key:         doc.Key,
+		Kind:        charmhub.CacheEntryKind(doc.Kind),
+		Name:        doc.Name,
+		Revision:    doc.Revision,
+		ContentType: doc.ContentType,
+		Size:        doc.Size,
+		SHA256:      doc.SHA256,
+		Created:     doc.Created.UTC(),
+		LastUsed:    doc.LastUsed.UTC(),
+	}
+}
+
+// charmhubCacheStoragePath returns where the content of the entry with
+// the given key is stored. Keys are hashed as they're derived from
+// URLs and may be long.
+func charmhubCacheStoragePath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "charmhubcache/" + hex.EncodeToString(sum[:])
+}
+
+// Get implements charmhub.Cache.Get, and records that the entry
+// has been used.
+func (cache *CharmhubCache) Get(ctx context.Context, key string) (charmhub.CacheEntry, io.ReadCloser, error) {
+	entry, r, err := cache.Open(key)
+	if err != nil {
+		return charmhub.CacheEntry{}, nil, errors.Trace(err)
+	}
+
+	coll, closeColl := cache.coll()
+	defer closeColl()
+	entry.LastUsed = cache.st.clock().Now().UTC()
+	err = coll.UpdateId(key, bson.D{{"$set", bson.D{{"last-used", entry.LastUsed}}}})
+	if err != nil && errors.Cause(err) != mgo.ErrNotFound {
+		logger.Warningf("cannot record use of charmhub cache entry %q: %v", key, err)
+	}
+	return entry, r, nil
+}
+
+// Open returns the entry with the given key along with a reader for
+// its content, without recording that the entry has been used. The
+// caller must close the reader.
+func (cache *CharmhubCache) Open(key string) (charmhub.CacheEntry, io.ReadCloser, error) {
+	coll, closeColl := cache.coll()
+	defer closeColl()
+
+	var doc charmhubCacheDoc
+	err := coll.FindId(key).One(&doc)
+	if errors.Cause(err) == mgo.ErrNotFound {
+		return charmhub.CacheEntry{}, nil, errors.NotFoundf("charmhub cache entry %q", key)
+	}
+	if err != nil {
+		return charmhub.CacheEntry{}, nil, errors.Annotatef(err, "getting charmhub cache entry %q", key)
+	}
+	r, _, err := cache.storage().Get(doc.StoragePath)
+	if err != nil {
+		return charmhub.CacheEntry{}, nil, errors.Annotatef(err, "reading charmhub cache entry %q", key)
+	}
+	return doc.entry(), r, nil
+}
+
+// Put implements charmhub.Cache.Put. If the entry has a SHA256 hash,
+// the content is checked against it.
+func (cache *CharmhubCache) Put(ctx context.Context, entry charmhub.CacheEntry, r io.Reader) error {
+	if entry.Key == "" {
+		return errors.NotValidf("empty charmhub cache key")
+	}
+	storagePath := charmhubCacheStoragePath(entry.Key)
+	stor := cache.storage()
+
+	hash := sha256.New()
+	if err := stor.Put(storagePath, io.TeeReader(r, hash), entry.Size); err != nil {
+		return errors.Annotatef(err, "storing charmhub cache entry %q", entry.Key)
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if entry.SHA256 != "" && entry.SHA256 != sum {
+		if err := stor.Remove(storagePath); err != nil {
+			logger.Warningf("cannot remove charmhub cache entry %q: %v", entry.Key, err)
+		}
+		return errors.NotValidf("charmhub cache entry %q with SHA256 %s, expected %s", entry.Key, sum, entry.SHA256)
+	}
+
+	now := cache.st.clock().Now().UTC()
+	coll, closeColl := cache.coll()
+	defer closeColl()
+	_, err := coll.UpsertId(entry.Key, charmhubCacheDoc{
+		Key:         entry.Key,
+		Kind:        string(entry.Kind),
+		Name:        entry.Name,
+		Revision:    entry.Revision,
+		ContentType: entry.ContentType,
+		Size:        entry.Size,
+		SHA256:      sum,
+		Created:     now,
+		LastUsed:    now,
+		StoragePath: storagePath,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "indexing charmhub cache entry %q", entry.Key)
+	}
+	return nil
+}
+
+// Entries returns all the entries in the cache, ordered by key.
+func (cache *CharmhubCache) Entries() ([]charmhub.CacheEntry, error) {
+	coll, closeColl := cache.coll()
+	defer closeColl()
+
+	var docs []charmhubCacheDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading charmhub cache entries")
+	}
+	entries := make([]charmhub.CacheEntry, len(docs))
+	for i, doc := range docs {
+		entries[i] = doc.entry()
+	}
+	return entries, nil
+}
+
+// Remove removes the entry with the given key from the cache. Removing
+// an entry that doesn't exist is not an error.
+func (cache *CharmhubCache) Remove(key string) error {
+	coll, closeColl := cache.coll()
+	defer closeColl()
+
+	err := coll.RemoveId(key)
+	if errors.Cause(err) == mgo.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return errors.Annotatef(err, "removing charmhub cache entry %q", key)
+	}
+	err = cache.storage().Remove(charmhubCacheStoragePath(key))
+	if err != nil && !errors.Is(err, errors.NotFound) {
+		return errors.Annotatef(err, "removing content of charmhub cache entry %q", key)
+	}
+	return nil
+}
+
+func (cache *CharmhubCache) storage() storage.Storage {
+	return storage.NewStorage(cache.st.ControllerModelUUID(), cache.st.MongoSession())
+}
+
+func (cache *CharmhubCache) coll() (mongo.WriteCollection, func()) {
+	coll, closer := cache.st.db().GetCollection(charmhubCacheC)
+	return coll.Writeable(), closer
+}
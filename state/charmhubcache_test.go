@@ -0,0 +1,114 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type charmhubCacheSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&charmhubCacheSuite{})
+
+func (s *charmhubCacheSuite) put(c *gc.C, key, content string) {
+	err := s.State.CharmhubCache().Put(context.Background(), charmhub.CacheEntry{
+		Key:  key,
+		Kind: charmhub.CacheEntryCharm,
+		Name: "mysql-id_42.charm",
+		Size: int64(len(content)),
+	}, strings.NewReader(content))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmhubCacheSuite) TestPutGet(c *gc.C) {
+	s.put(c, "get:/api/v1/charms/download/mysql-id_42.charm", "archive")
+
+	entry, r, err := s.State.CharmhubCache().Get(context.Background(), "get:/api/v1/charms/download/mysql-id_42.charm")
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "archive")
+
+	sum := sha256.Sum256([]byte("archive"))
+	c.Check(entry.Kind, gc.Equals, charmhub.CacheEntryCharm)
+	c.Check(entry.Name, gc.Equals, "mysql-id_42.charm")
+	c.Check(entry.Size, gc.Equals, int64(7))
+	c.Check(entry.SHA256, gc.Equals, hex.EncodeToString(sum[:]))
+	c.Check(entry.Created.IsZero(), jc.IsFalse)
+}
+
+func (s *charmhubCacheSuite) TestPutReplaces(c *gc.C) {
+	s.put(c, "refresh:abc", "old")
+	s.put(c, "refresh:abc", "new")
+
+	entries, err := s.State.CharmhubCache().Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+
+	_, r, err := s.State.CharmhubCache().Get(context.Background(), "refresh:abc")
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "new")
+}
+
+func (s *charmhubCacheSuite) TestPutChecksHash(c *gc.C) {
+	err := s.State.CharmhubCache().Put(context.Background(), charmhub.CacheEntry{
+		Key:    "refresh:abc",
+		Size:   3,
+		SHA256: "0000",
+	}, strings.NewReader("abc"))
+	c.Assert(err, gc.ErrorMatches, `charmhub cache entry "refresh:abc" with SHA256 [0-9a-f]+, expected 0000 not valid`)
+
+	_, _, err = s.State.CharmhubCache().Get(context.Background(), "refresh:abc")
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *charmhubCacheSuite) TestEntriesAndRemove(c *gc.C) {
+	s.put(c, "refresh:b", "b")
+	s.put(c, "refresh:a", "a")
+
+	cache := s.State.CharmhubCache()
+	entries, err := cache.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+	c.Check(entries[0].Key, gc.Equals, "refresh:a")
+	c.Check(entries[1].Key, gc.Equals, "refresh:b")
+
+	err = cache.Remove("refresh:a")
+	c.Assert(err, jc.ErrorIsNil)
+	err = cache.Remove("refresh:missing")
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err = cache.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	_, _, err = cache.Get(context.Background(), "refresh:a")
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *charmhubCacheSuite) TestSharedByModels(c *gc.C) {
+	s.put(c, "refresh:abc", "abc")
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, r, err := st.CharmhubCache().Get(context.Background(), "refresh:abc")
+	c.Assert(err, jc.ErrorIsNil)
+	r.Close()
+}
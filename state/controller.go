@@ -14,6 +14,7 @@ import (
 	"github.com/juju/names/v5"
 
 	jujucontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/pki/ssh"
 )
 
 const (
@@ -359,3 +360,51 @@ func (st *State) SSHServerHostKey() (string, error) {
 	}
 	return keyDoc.Key, nil
 }
+
+// sshUserCAKeyDocId holds the document ID to retrieve the SSH user
+// certificate authority key within the controller collection.
+const sshUserCAKeyDocId = "sshUserCAKey"
+
+// SSHUserCAKey returns the private key of the certificate authority
+// used to sign SSH user certificates. The key is generated the first
+// time it's asked for.
+func (st *State) SSHUserCAKey() (string, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var keyDoc sshServerHostKeyDoc
+	err := controllers.Find(bson.D{{"_id", sshUserCAKeyDocId}}).One(&keyDoc)
+	if err == nil {
+		return keyDoc.Key, nil
+	}
+	if err != mgo.ErrNotFound {
+		return "", errors.Trace(err)
+	}
+
+	privateKey, err := ssh.ED25519()
+	if err != nil {
+		return "", errors.Annotate(err, "generating ssh user CA key")
+	}
+	key, err := ssh.MarshalPrivateKey(privateKey)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      controllersC,
+		Id:     sshUserCAKeyDocId,
+		Assert: txn.DocMissing,
+		Insert: &sshServerHostKeyDoc{Key: string(key)},
+	}}
+	err = st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		// Another controller created the key first, use that one.
+		if err := controllers.Find(bson.D{{"_id", sshUserCAKeyDocId}}).One(&keyDoc); err != nil {
+			return "", errors.Trace(err)
+		}
+		return keyDoc.Key, nil
+	}
+	if err != nil {
+		return "", errors.Annotate(err, "saving ssh user CA key")
+	}
+	return string(key), nil
+}
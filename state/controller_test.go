@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	mgotesting "github.com/juju/mgo/v3/testing"
 	jc "github.com/juju/testing/checkers"
+	gossh "golang.org/x/crypto/ssh"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/controller"
@@ -371,3 +372,15 @@ func (s *ControllerSuite) TestSSHServerHostKey(c *gc.C) {
 
 	c.Assert(key, gc.Equals, testing.SSHServerHostKey)
 }
+
+func (s *ControllerSuite) TestSSHUserCAKey(c *gc.C) {
+	key, err := s.State.SSHUserCAKey()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = gossh.ParsePrivateKey([]byte(key))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The key is only generated once.
+	again, err := s.State.SSHUserCAKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again, gc.Equals, key)
+}
@@ -0,0 +1,14 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"github.com/juju/errors"
+)
+
+const (
+	// ErrSecretRevisionChanged indicates that the content of a secret
+	// revision was moved by someone else while it was being changed.
+	ErrSecretRevisionChanged = errors.ConstError("secret revision content changed")
+)
@@ -345,6 +345,39 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestTimeRangeFiltering(c *gc.C) {
+	startT := coretesting.NonZeroTime()
+	endT := startT.Add(10 * time.Second)
+	s.writeLogsT(c,
+		s.otherUUID,
+		startT.Add(-5*time.Second), startT.Add(-time.Millisecond), 5,
+		logTemplate{Message: "too early"},
+	)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, startT, endT.Add(-time.Second), 5, want)
+	s.writeLogsT(c, s.otherUUID, endT, endT.Add(5*time.Second), 5, logTemplate{Message: "too late"})
+
+	tailer, err := state.NewLogTailer(s.otherState, corelogger.LogTailerParams{
+		StartTime:    startT,
+		EndTime:      endT,
+		FromTheStart: true,
+	}, s.oplogColl)
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// The tailer stops once it has read the logs in the range, as no
+	// new logs can be in it.
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
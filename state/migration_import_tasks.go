@@ -712,6 +712,11 @@ func (ImportSecrets) Execute(src SecretsInput, runner TransactionRunner, knownSe
 				}
 				seenBackendIds.Add(valueRef.BackendID)
 			}
+			// Only the latest revision's checksum is exported.
+			var checksum string
+			if rev.Number() == secret.LatestRevision() {
+				checksum = secret.LatestRevisionChecksum()
+			}
 			ops = append(ops, txn.Op{
 				C:      secretRevisionsC,
 				Id:     key,
@@ -726,6 +731,7 @@ func (ImportSecrets) Execute(src SecretsInput, runner TransactionRunner, knownSe
 					PendingDelete: rev.PendingDelete(),
 					Data:          dataCopy,
 					ValueRef:      valueRef,
+					Checksum:      checksum,
 					OwnerTag:      owner.String(),
 				},
 			})
@@ -3323,6 +3323,7 @@ func (s *MigrationImportSuite) TestSecrets(c *gc.C) {
 			RevisionID: "rev-id",
 		},
 		BackendName: ptr("myvault"),
+		Checksum:    "deadbeef",
 		CreateTime:  now,
 		UpdateTime:  now,
 	}})
@@ -107,6 +107,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// The autocert cache is non-critical. After migration
 		// you'll just need to acquire new certificates.
 		autocertCacheC,
+		// The charmhub cache is shared by the controller's models,
+		// and is repopulated as charms are used.
+		charmhubCacheC,
 		// We don't export the controller model at this stage.
 		controllersC,
 		controllerNodesC,
@@ -220,6 +223,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// sshConnRequestsC is a new collection and doesn't need to be
 		// migrated.
 		sshConnRequestsC,
+
+		// SSH session recordings are an audit record of sessions
+		// proxied through the source controller.
+		sshSessionRecordingsC,
+
+		// SSH policies control access through the source controller's
+		// SSH server and are re-created on the target controller.
+		sshPoliciesC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/core/secrets"
 	corewatcher "github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/mongo/utils"
+	stateerrors "github.com/juju/juju/state/errors"
 	"github.com/juju/juju/state/watcher"
 )
 
@@ -71,6 +72,12 @@ type ChangeSecretBackendParams struct {
 	Revision int
 	ValueRef *secrets.ValueRef
 	Data     secrets.SecretData
+
+	// CheckValueRef makes the change conditional on the revision still
+	// referring to OldValueRef, which is nil for content stored in the
+	// controller. Otherwise the change fails with ErrSecretRevisionChanged.
+	CheckValueRef bool
+	OldValueRef   *secrets.ValueRef
 }
 
 // SecretsFilter holds attributes to match when listing secrets.
@@ -148,6 +155,10 @@ type secretRevisionDoc struct {
 	Data       secretsDataMap `bson:"data"`
 	ValueRef   *valueRefDoc   `bson:"value-reference,omitempty"`
 
+	// Checksum is the checksum of the revision content, used to
+	// detect changes made to content stored in an external backend.
+	Checksum string `bson:"checksum,omitempty"`
+
 	// PendingDelete is true if the revision is to be deleted.
 	// It will not be drained to a new active backend.
 	PendingDelete bool `bson:"pending-delete"`
@@ -248,7 +259,7 @@ func splitSecretRevision(c string) (string, int) {
 	return parts[0], rev
 }
 
-func (s *secretsStore) secretRevisionDoc(uri *secrets.URI, owner string, revision int, expireTime *time.Time, data secrets.SecretData, valueRef *secrets.ValueRef) *secretRevisionDoc {
+func (s *secretsStore) secretRevisionDoc(uri *secrets.URI, owner string, revision int, expireTime *time.Time, data secrets.SecretData, valueRef *secrets.ValueRef, checksum string) *secretRevisionDoc {
 	dataCopy := make(secretsDataMap)
 	for k, v := range data {
 		dataCopy[k] = v
@@ -269,6 +280,7 @@ func (s *secretsStore) secretRevisionDoc(uri *secrets.URI, owner string, revisio
 		UpdateTime: now,
 		Data:       dataCopy,
 		ValueRef:   valRefDoc,
+		Checksum:   checksum,
 	}
 	if expireTime != nil {
 		expire := expireTime.Round(time.Second).UTC()
@@ -287,7 +299,7 @@ func (s *secretsStore) CreateSecret(uri *secrets.URI, p CreateSecretParams) (*se
 		return nil, errors.Trace(err)
 	}
 	revision := 1
-	valueDoc := s.secretRevisionDoc(uri, p.Owner.String(), revision, p.ExpireTime, p.Data, p.ValueRef)
+	valueDoc := s.secretRevisionDoc(uri, p.Owner.String(), revision, p.ExpireTime, p.Data, p.ValueRef, p.Checksum)
 	// OwnerTag has already been validated.
 	owner, _ := names.ParseTag(metadataDoc.OwnerTag)
 	entity, scopeCollName, scopeDocID, err := s.st.findSecretEntity(owner)
@@ -439,7 +451,7 @@ func (s *secretsStore) UpdateSecret(uri *secrets.URI, p UpdateSecretParams) (*se
 			if revisionExists {
 				return nil, errors.AlreadyExistsf("secret value with revision %d for %q", metadataDoc.LatestRevision, uri.String())
 			}
-			revisionDoc := s.secretRevisionDoc(uri, metadataDoc.OwnerTag, metadataDoc.LatestRevision, newExpireTime, p.Data, p.ValueRef)
+			revisionDoc := s.secretRevisionDoc(uri, metadataDoc.OwnerTag, metadataDoc.LatestRevision, newExpireTime, p.Data, p.ValueRef, p.Checksum)
 			ops = append(ops, txn.Op{
 				C:      secretRevisionsC,
 				Id:     revisionDoc.DocID,
@@ -849,6 +861,18 @@ func (s *secretsStore) ChangeSecretBackend(arg ChangeSecretBackendParams) error
 	}
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			err := secretRevisionsCollection.FindId(key).One(&doc)
+			if err == mgo.ErrNotFound {
+				return nil, errors.NotFoundf("secret revision %q", key)
+			}
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if arg.CheckValueRef && !sameValueRef(doc.ValueRef, arg.OldValueRef) {
+			return nil, stateerrors.ErrSecretRevisionChanged
+		}
 		var ops []txn.Op
 		if doc.ValueRef != nil {
 			refOps, err := s.st.decSecretBackendRefCountOp(doc.ValueRef.BackendID)
@@ -864,10 +888,19 @@ func (s *secretsStore) ChangeSecretBackend(arg ChangeSecretBackendParams) error
 			}
 			ops = append(ops, refOps...)
 		}
+		// The reference counts of the backends depend on where the
+		// content is, so make sure it hasn't moved.
+		assert := bson.D{{"value-reference", nil}}
+		if doc.ValueRef != nil {
+			assert = bson.D{
+				{"value-reference.backend-id", doc.ValueRef.BackendID},
+				{"value-reference.revision-id", doc.ValueRef.RevisionID},
+			}
+		}
 		return append(ops, txn.Op{
 			C:      secretRevisionsC,
 			Id:     doc.DocID,
-			Assert: txn.DocExists,
+			Assert: assert,
 			Update: bson.M{"$set": bson.M{"value-reference": valRefDoc, "data": dataCopy}},
 		}), nil
 	}
@@ -875,6 +908,13 @@ func (s *secretsStore) ChangeSecretBackend(arg ChangeSecretBackendParams) error
 	return errors.Trace(err)
 }
 
+func sameValueRef(doc *valueRefDoc, ref *secrets.ValueRef) bool {
+	if doc == nil || ref == nil {
+		return doc == nil && ref == nil
+	}
+	return doc.BackendID == ref.BackendID && doc.RevisionID == ref.RevisionID
+}
+
 // SecretGrants returns the list of access information of the secret for the specified role.
 func (s *secretsStore) SecretGrants(uri *secrets.URI, role secrets.SecretRole) ([]secrets.AccessInfo, error) {
 	secretPermissionsCollection, closer := s.st.db().GetCollection(secretPermissionsC)
@@ -1199,6 +1239,7 @@ func (s *secretsStore) listSecretRevisions(uri *secrets.URI, revision *int) ([]*
 			Revision:    doc.Revision,
 			ValueRef:    valueRef,
 			BackendName: backendName,
+			Checksum:    doc.Checksum,
 			CreateTime:  doc.CreateTime,
 			UpdateTime:  doc.UpdateTime,
 			ExpireTime:  doc.ExpireTime,
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
+	stateerrors "github.com/juju/juju/state/errors"
 	"github.com/juju/juju/state/testing"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/provider"
@@ -1243,6 +1244,63 @@ func (s *SecretsSuite) TestChangeSecretBackendExternalToExternal(c *gc.C) {
 	})
 }
 
+func (s *SecretsSuite) TestChangeSecretBackendCheckValueRef(c *gc.C) {
+	backendStore := state.NewSecretBackends(s.State)
+	_, err := backendStore.CreateSecretBackend(state.CreateSecretBackendParams{
+		ID:          "new-backend-id",
+		Name:        "bar",
+		BackendType: "vault",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	uri := secrets.NewURI()
+	_, err = s.store.CreateSecret(uri, state.CreateSecretParams{
+		Version: 1,
+		Owner:   s.owner.Tag(),
+		UpdateSecretParams: state.UpdateSecretParams{
+			LeaderToken: &fakeToken{},
+			Data:        map[string]string{"foo": "bar"},
+			Checksum:    "7a38bf81f383f69433ad6e900d35b3e2385593f76a7b7ab5d4355b8ba41ee24b",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The content is still in the controller, not in another backend.
+	newRef := &secrets.ValueRef{BackendID: "new-backend-id", RevisionID: "rev-id"}
+	err = s.store.ChangeSecretBackend(state.ChangeSecretBackendParams{
+		URI:           uri,
+		Token:         &fakeToken{},
+		Revision:      1,
+		ValueRef:      newRef,
+		CheckValueRef: true,
+		OldValueRef:   &secrets.ValueRef{BackendID: "other-backend-id", RevisionID: "rev-id"},
+	})
+	c.Assert(err, jc.ErrorIs, stateerrors.ErrSecretRevisionChanged)
+
+	err = s.store.ChangeSecretBackend(state.ChangeSecretBackendParams{
+		URI:           uri,
+		Token:         &fakeToken{},
+		Revision:      1,
+		ValueRef:      newRef,
+		CheckValueRef: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, valRef, err := s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(valRef, jc.DeepEquals, newRef)
+
+	// The content has moved, so it can't be moved from the controller again.
+	err = s.store.ChangeSecretBackend(state.ChangeSecretBackendParams{
+		URI:           uri,
+		Token:         &fakeToken{},
+		Revision:      1,
+		Data:          map[string]string{"foo": "bar"},
+		CheckValueRef: true,
+	})
+	c.Assert(err, jc.ErrorIs, stateerrors.ErrSecretRevisionChanged)
+}
+
 func (s *SecretsSuite) TestChangeSecretBackendInternalToExternal(c *gc.C) {
 	backendStore := state.NewSecretBackends(s.State)
 
@@ -1492,10 +1550,12 @@ func (s *SecretsSuite) TestListSecretRevisions(c *gc.C) {
 	mc.AddExpr(`_.UpdateTime`, jc.Almost, jc.ExpectedValue)
 	c.Assert(r, mc, []*secrets.SecretRevisionMetadata{{
 		Revision:   1,
+		Checksum:   "7a38bf81f383f69433ad6e900d35b3e2385593f76a7b7ab5d4355b8ba41ee24b",
 		CreateTime: now,
 		UpdateTime: now,
 	}, {
 		Revision:   2,
+		Checksum:   "7a38bf81f383f69433ad6e900d35b3e2385593f76a7b7ab5d4355b8ba41ee24b",
 		CreateTime: updateTime,
 		UpdateTime: updateTime,
 	}, {
@@ -1505,6 +1565,7 @@ func (s *SecretsSuite) TestListSecretRevisions(c *gc.C) {
 			RevisionID: "rev-id",
 		},
 		BackendName: ptr("myvault"),
+		Checksum:    "deadbeef",
 		CreateTime:  updateTime2,
 		UpdateTime:  updateTime2,
 	}})
@@ -1587,6 +1648,7 @@ func (s *SecretsSuite) TestGetSecretRevision(c *gc.C) {
 	mc.AddExpr(`_.UpdateTime`, jc.Almost, jc.ExpectedValue)
 	c.Assert(r, mc, &secrets.SecretRevisionMetadata{
 		Revision:   2,
+		Checksum:   "deadbeef",
 		CreateTime: updateTime,
 		UpdateTime: updateTime,
 	})
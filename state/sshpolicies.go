@@ -0,0 +1,115 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+
+	"github.com/juju/juju/core/sshpolicy"
+)
+
+type sshPolicyDoc struct {
+	DocID          string   `bson:"_id"`
+	Name           string   `bson:"name"`
+	Users          []string `bson:"users"`
+	Applications   []string `bson:"applications,omitempty"`
+	Commands       []string `bson:"commands,omitempty"`
+	Shell          bool     `bson:"shell"`
+	SFTP           bool     `bson:"sftp"`
+	PortForwarding bool     `bson:"port-forwarding"`
+}
+
+func (doc sshPolicyDoc) policy() sshpolicy.Policy {
+	return sshpolicy.Policy{
+		Name:           doc.Name,
+		Users:          doc.Users,
+		Applications:   doc.Applications,
+		Commands:       doc.Commands,
+		Shell:          doc.Shell,
+		SFTP:           doc.SFTP,
+		PortForwarding: doc.PortForwarding,
+	}
+}
+
+// SetSSHPolicy adds the SSH access policy to the model,
+// replacing any existing policy with the same name.
+func (st *State) SetSSHPolicy(p sshpolicy.Policy) error {
+	if err := p.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	doc := sshPolicyDoc{
+		DocID:          st.docID(p.Name),
+		Name:           p.Name,
+		Users:          p.Users,
+		Applications:   p.Applications,
+		Commands:       p.Commands,
+		Shell:          p.Shell,
+		SFTP:           p.SFTP,
+		PortForwarding: p.PortForwarding,
+	}
+	policies, closer := st.db().GetCollection(sshPoliciesC)
+	defer closer()
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		n, err := policies.FindId(doc.DocID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return []txn.Op{{
+				C:      sshPoliciesC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      sshPoliciesC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"users", doc.Users},
+				{"applications", doc.Applications},
+				{"commands", doc.Commands},
+				{"shell", doc.Shell},
+				{"sftp", doc.SFTP},
+				{"port-forwarding", doc.PortForwarding},
+			}}},
+		}}, nil
+	}
+	return errors.Annotatef(st.db().Run(buildTxn), "setting ssh policy %q", p.Name)
+}
+
+// RemoveSSHPolicy removes the named SSH access policy from the model.
+func (st *State) RemoveSSHPolicy(name string) error {
+	ops := []txn.Op{{
+		C:      sshPoliciesC,
+		Id:     st.docID(name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("ssh policy %q", name)
+	}
+	return errors.Annotatef(err, "removing ssh policy %q", name)
+}
+
+// SSHPolicies returns the model's SSH access policies, ordered by name.
+func (st *State) SSHPolicies() ([]sshpolicy.Policy, error) {
+	policies, closer := st.db().GetCollection(sshPoliciesC)
+	defer closer()
+
+	var docs []sshPolicyDoc
+	if err := policies.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading ssh policies")
+	}
+	result := make([]sshpolicy.Policy, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.policy()
+	}
+	return result, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/sshpolicy"
+)
+
+type SSHPoliciesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&SSHPoliciesSuite{})
+
+func (s *SSHPoliciesSuite) TestSSHPoliciesEmpty(c *gc.C) {
+	policies, err := s.State.SSHPolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, gc.HasLen, 0)
+}
+
+func (s *SSHPoliciesSuite) TestSetSSHPolicy(c *gc.C) {
+	dbas := sshpolicy.Policy{
+		Name:         "dbas",
+		Users:        []string{"alice"},
+		Applications: []string{"postgresql"},
+		Commands:     []string{"psql*"},
+	}
+	operators := sshpolicy.Policy{
+		Name:  "operators",
+		Users: []string{sshpolicy.Everyone},
+		Shell: true,
+	}
+	err := s.State.SetSSHPolicy(operators)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetSSHPolicy(dbas)
+	c.Assert(err, jc.ErrorIsNil)
+
+	policies, err := s.State.SSHPolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, jc.DeepEquals, []sshpolicy.Policy{dbas, operators})
+}
+
+func (s *SSHPoliciesSuite) TestSetSSHPolicyReplaces(c *gc.C) {
+	p := sshpolicy.Policy{
+		Name:  "operators",
+		Users: []string{"alice"},
+		Shell: true,
+	}
+	err := s.State.SetSSHPolicy(p)
+	c.Assert(err, jc.ErrorIsNil)
+
+	p.Users = []string{"bob"}
+	p.Shell = false
+	p.SFTP = true
+	err = s.State.SetSSHPolicy(p)
+	c.Assert(err, jc.ErrorIsNil)
+
+	policies, err := s.State.SSHPolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, jc.DeepEquals, []sshpolicy.Policy{p})
+}
+
+func (s *SSHPoliciesSuite) TestSetSSHPolicyInvalid(c *gc.C) {
+	err := s.State.SetSSHPolicy(sshpolicy.Policy{Name: "nothing", Users: []string{"alice"}})
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+}
+
+func (s *SSHPoliciesSuite) TestRemoveSSHPolicy(c *gc.C) {
+	err := s.State.SetSSHPolicy(sshpolicy.Policy{
+		Name:  "operators",
+		Users: []string{"alice"},
+		Shell: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveSSHPolicy("operators")
+	c.Assert(err, jc.ErrorIsNil)
+	policies, err := s.State.SSHPolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, gc.HasLen, 0)
+
+	err = s.State.RemoveSSHPolicy("operators")
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+	"github.com/juju/utils/v3"
+
+	"github.com/juju/juju/state/storage"
+)
+
+// SSHSessionRecording describes a recording of an SSH session
+// proxied through the controller's SSH server.
+type SSHSessionRecording struct {
+	// ID uniquely identifies the recording.
+	ID string
+	// User is the user name the session was opened with.
+	User string
+	// KeyFingerprint is the fingerprint of the public key
+	// used to authenticate the session, if any.
+	KeyFingerprint string
+	// Target is the virtual hostname of the session's target.
+	Target string
+	// Started and Ended hold when the session started and ended.
+	Started time.Time
+	Ended   time.Time
+	// Size is the size of the recording in bytes.
+	Size int64
+}
+
+type sshSessionRecordingDoc struct {
+	DocID          string    `bson:"_id"`
+	ID             string    `bson:"id"`
+	User           string    `bson:"user"`
+	KeyFingerprint string    `bson:"key-fingerprint,omitempty"`
+	Target         string    `bson:"target"`
+	Started        time.Time `bson:"started"`
+	Ended          time.Time `bson:"ended"`
+	Size           int64     `bson:"size"`
+	StoragePath    string    `bson:"storage-path"`
+}
+
+func (doc sshSessionRecordingDoc) recording() SSHSessionRecording {
+	return SSHSessionRecording{
+		ID:             doc.ID,
+		User:           doc.User,
+		KeyFingerprint: doc.KeyFingerprint,
+		Target:         doc.Target,
+		Started:        doc.Started.UTC(),
+		Ended:          doc.Ended.UTC(),
+		Size:           doc.Size,
+	}
+}
+
+// AddSSHSessionRecordingArgs holds the details of an
+// SSH session recording to add.
+type AddSSHSessionRecordingArgs struct {
+	User           string
+	KeyFingerprint string
+	Target         string
+	Started        time.Time
+	Ended          time.Time
+
+	// Data holds the recording itself, of length Size.
+	Data io.Reader
+	Size int64
+}
+
+// SSHSessionRecordingFilter is used to select SSH session recordings.
+// Empty fields match all recordings.
+type SSHSessionRecordingFilter struct {
+	User   string
+	Target string
+	// Since and Until select recordings of sessions
+	// which started in the given time range.
+	Since *time.Time
+	Until *time.Time
+}
+
+func sshSessionRecordingPath(id string) string {
+	return "sshsessionrecordings/" + id
+}
+
+// AddSSHSessionRecording stores an SSH session recording in the
+// model's blob storage and indexes it by user, target and time.
+func (st *State) AddSSHSessionRecording(args AddSSHSessionRecordingArgs) (SSHSessionRecording, error) {
+	if args.Target == "" {
+		return SSHSessionRecording{}, errors.NotValidf("empty target")
+	}
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return SSHSessionRecording{}, errors.Trace(err)
+	}
+	id := uuid.String()
+	doc := sshSessionRecordingDoc{
+		DocID:          st.docID(id),
+		ID:             id,
+		User:           args.User,
+		KeyFingerprint: args.KeyFingerprint,
+		Target:         args.Target,
+		Started:        args.Started.UTC(),
+		Ended:          args.Ended.UTC(),
+		Size:           args.Size,
+		StoragePath:    sshSessionRecordingPath(id),
+	}
+
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	if err := stor.Put(doc.StoragePath, args.Data, args.Size); err != nil {
+		return SSHSessionRecording{}, errors.Annotate(err, "storing ssh session recording")
+	}
+	ops := []txn.Op{{
+		C:      sshSessionRecordingsC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if removeErr := stor.Remove(doc.StoragePath); removeErr != nil {
+			logger.Warningf("cannot remove unindexed ssh session recording %q: %v", doc.StoragePath, removeErr)
+		}
+		return SSHSessionRecording{}, errors.Annotate(err, "adding ssh session recording")
+	}
+	return doc.recording(), nil
+}
+
+// SSHSessionRecordings returns the SSH session recordings
+// matching the filter, ordered by when they started.
+func (st *State) SSHSessionRecordings(filter SSHSessionRecordingFilter) ([]SSHSessionRecording, error) {
+	recordings, closer := st.db().GetCollection(sshSessionRecordingsC)
+	defer closer()
+
+	query := bson.D{}
+	if filter.User != "" {
+		query = append(query, bson.DocElem{Name: "user", Value: filter.User})
+	}
+	if filter.Target != "" {
+		query = append(query, bson.DocElem{Name: "target", Value: filter.Target})
+	}
+	started := bson.D{}
+	if filter.Since != nil {
+		started = append(started, bson.DocElem{Name: "$gte", Value: filter.Since.UTC()})
+	}
+	if filter.Until != nil {
+		started = append(started, bson.DocElem{Name: "$lte", Value: filter.Until.UTC()})
+	}
+	if len(started) > 0 {
+		query = append(query, bson.DocElem{Name: "started", Value: started})
+	}
+
+	var docs []sshSessionRecordingDoc
+	if err := recordings.Find(query).Sort("started").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading ssh session recordings")
+	}
+	result := make([]SSHSessionRecording, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.recording()
+	}
+	return result, nil
+}
+
+// SSHSessionRecording returns the SSH session recording with the
+// given ID, along with a reader for its content. The caller must
+// close the reader.
+func (st *State) SSHSessionRecording(id string) (SSHSessionRecording, io.ReadCloser, error) {
+	recordings, closer := st.db().GetCollection(sshSessionRecordingsC)
+	defer closer()
+
+	var doc sshSessionRecordingDoc
+	err := recordings.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return SSHSessionRecording{}, nil, errors.NotFoundf("ssh session recording %q", id)
+	}
+	if err != nil {
+		return SSHSessionRecording{}, nil, errors.Annotatef(err, "getting ssh session recording %q", id)
+	}
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	r, _, err := stor.Get(doc.StoragePath)
+	if err != nil {
+		return SSHSessionRecording{}, nil, errors.Annotatef(err, "reading ssh session recording %q", id)
+	}
+	return doc.recording(), r, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type SSHSessionRecordingsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&SSHSessionRecordingsSuite{})
+
+func (s *SSHSessionRecordingsSuite) addRecording(c *gc.C, user, target string, started time.Time, data string) state.SSHSessionRecording {
+	rec, err := s.State.AddSSHSessionRecording(state.AddSSHSessionRecordingArgs{
+		User:    user,
+		Target:  target,
+		Started: started,
+		Ended:   started.Add(time.Minute),
+		Data:    strings.NewReader(data),
+		Size:    int64(len(data)),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return rec
+}
+
+func (s *SSHSessionRecordingsSuite) TestAddSSHSessionRecording(c *gc.C) {
+	started := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	rec := s.addRecording(c, "ubuntu", "0."+s.Model.UUID()+".juju.local", started, "some data")
+	c.Assert(rec.ID, gc.Not(gc.Equals), "")
+	c.Assert(rec, jc.DeepEquals, state.SSHSessionRecording{
+		ID:      rec.ID,
+		User:    "ubuntu",
+		Target:  "0." + s.Model.UUID() + ".juju.local",
+		Started: started,
+		Ended:   started.Add(time.Minute),
+		Size:    9,
+	})
+
+	got, r, err := s.State.SSHSessionRecording(rec.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	c.Assert(got, jc.DeepEquals, rec)
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "some data")
+}
+
+func (s *SSHSessionRecordingsSuite) TestAddSSHSessionRecordingNoTarget(c *gc.C) {
+	_, err := s.State.AddSSHSessionRecording(state.AddSSHSessionRecordingArgs{
+		Data: strings.NewReader(""),
+	})
+	c.Assert(err, gc.ErrorMatches, "empty target not valid")
+}
+
+func (s *SSHSessionRecordingsSuite) TestSSHSessionRecordingNotFound(c *gc.C) {
+	_, _, err := s.State.SSHSessionRecording("missing")
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *SSHSessionRecordingsSuite) TestSSHSessionRecordings(c *gc.C) {
+	machine := "0." + s.Model.UUID() + ".juju.local"
+	unit := "0.mysql." + s.Model.UUID() + ".juju.local"
+	t0 := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	rec1 := s.addRecording(c, "ubuntu", machine, t0.Add(2*time.Hour), "1")
+	rec2 := s.addRecording(c, "ubuntu", unit, t0, "2")
+	rec3 := s.addRecording(c, "root", machine, t0.Add(time.Hour), "3")
+
+	for i, t := range []struct {
+		filter   state.SSHSessionRecordingFilter
+		expected []state.SSHSessionRecording
+	}{{
+		expected: []state.SSHSessionRecording{rec2, rec3, rec1},
+	}, {
+		filter:   state.SSHSessionRecordingFilter{User: "ubuntu"},
+		expected: []state.SSHSessionRecording{rec2, rec1},
+	}, {
+		filter:   state.SSHSessionRecordingFilter{Target: machine},
+		expected: []state.SSHSessionRecording{rec3, rec1},
+	}, {
+		filter:   state.SSHSessionRecordingFilter{Since: ptr(t0.Add(time.Hour))},
+		expected: []state.SSHSessionRecording{rec3, rec1},
+	}, {
+		filter:   state.SSHSessionRecordingFilter{Until: ptr(t0.Add(time.Hour))},
+		expected: []state.SSHSessionRecording{rec2, rec3},
+	}, {
+		filter:   state.SSHSessionRecordingFilter{User: "root", Target: unit},
+		expected: []state.SSHSessionRecording{},
+	}} {
+		c.Logf("test %d", i)
+		recs, err := s.State.SSHSessionRecordings(t.filter)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(recs, jc.DeepEquals, t.expected)
+	}
+}